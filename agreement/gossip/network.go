@@ -180,7 +180,7 @@ func (i *networkImpl) Disconnect(h agreement.MessageHandle) {
 		return
 	}
 
-	i.net.Disconnect(metadata.raw.Sender)
+	i.net.ReportPeer(metadata.raw.Sender, network.OffenseInvalidAgreementMessage)
 }
 
 // broadcastTimeout is currently only used by test code.
@@ -140,6 +140,15 @@ func (w *whiteholeNetwork) Disconnect(badnode network.Peer) {
 func (w *whiteholeNetwork) DisconnectPeers() {
 	return
 }
+func (w *whiteholeNetwork) ReportPeer(peer network.Peer, offense network.PeerOffense) {
+	return
+}
+func (w *whiteholeNetwork) BanPeer(peer network.Peer, duration time.Duration) error {
+	return nil
+}
+func (w *whiteholeNetwork) AddPhonebookEntry(address string, role network.PhoneBookEntryRoles) {
+	return
+}
 func (w *whiteholeNetwork) Ready() chan struct{} {
 	return make(chan struct{})
 }
@@ -33,6 +33,10 @@ import (
 	"github.com/algorand/go-algorand/network"
 )
 
+// maxCatchpointDeltaChainLength is the maximal number of catchpoint files, including the complete catchpoint file and the catchpoint
+// delta files applied on top of it, which would be downloaded to catchup to a single catchpoint.
+const maxCatchpointDeltaChainLength = 128
+
 // CatchpointCatchupNodeServices defines the extenal node support needed
 // for the catchpoint service to switch the node between "regular" operational mode and catchup mode.
 type CatchpointCatchupNodeServices interface {
@@ -274,26 +278,31 @@ func (cs *CatchpointCatchupService) processStageLedgerDownload() (err error) {
 	for {
 		attemptsCount++
 
-		err = cs.ledgerAccessor.ResetStagingBalances(cs.ctx, true)
-		if err != nil {
-			if cs.ctx.Err() != nil {
-				return cs.stopOrAbort()
-			}
-			return cs.abort(fmt.Errorf("processStageLedgerDownload failed to reset staging balances : %v", err))
-		}
 		psp, err := peerSelector.getNextPeer()
 		if err != nil {
 			err = fmt.Errorf("processStageLedgerDownload: catchpoint catchup was unable to obtain a list of peers to retrieve the catchpoint file from")
 			return cs.abort(err)
 		}
 		peer := psp.Peer
-		err = ledgerFetcher.downloadLedger(cs.ctx, peer, round)
+		var chain []basics.Round
+		chain, err = cs.fetchCatchpointChain(ledgerFetcher, peer, round, label)
+		if err == nil {
+			// the download of the complete catchpoint file resumes from where a previous attempt stopped, if it was interrupted.
+			err = ledgerFetcher.resumeLedger(cs.ctx, peer, chain[0])
+		}
 		if err == nil {
 			err = cs.ledgerAccessor.BuildMerkleTrie(cs.ctx, cs.updateVerifiedAccounts)
+			// apply the catchpoint deltas, if any, on top of the complete catchpoint.
+			for _, deltaRound := range chain[1:] {
+				if err != nil {
+					break
+				}
+				err = ledgerFetcher.downloadLedger(cs.ctx, peer, deltaRound)
+			}
 			if err == nil {
 				break
 			}
-			// failed to build the merkle trie for the above catchpoint file.
+			// failed to build the merkle trie for the above catchpoint file, or to apply the catchpoint deltas on top of it.
 			peerSelector.rankPeer(psp, peerRankInvalidDownload)
 		} else {
 			peerSelector.rankPeer(psp, peerRankDownloadFailed)
@@ -320,6 +329,32 @@ func (cs *CatchpointCatchupService) processStageLedgerDownload() (err error) {
 	return nil
 }
 
+// fetchCatchpointChain returns the rounds of the catchpoint files which need to be downloaded from the given peer in order to
+// catchup to the given catchpoint : a complete catchpoint file, followed by the catchpoint delta files to be applied on top of it,
+// ordered from the oldest to the newest. The chain is discovered by following the base catchpoint of each of the catchpoint
+// files headers.
+func (cs *CatchpointCatchupService) fetchCatchpointChain(lf *ledgerFetcher, peer network.Peer, round basics.Round, label string) (chain []basics.Round, err error) {
+	for len(chain) < maxCatchpointDeltaChainLength {
+		var header ledger.CatchpointFileHeader
+		header, err = lf.downloadLedgerHeader(cs.ctx, peer, round)
+		if err != nil {
+			return nil, err
+		}
+		if header.Catchpoint != label {
+			return nil, fmt.Errorf("fetchCatchpointChain: catchpoint file of round %d was generated for catchpoint '%s' rather than '%s'", round, header.Catchpoint, label)
+		}
+		chain = append([]basics.Round{round}, chain...)
+		if header.BaseRound == 0 {
+			return chain, nil
+		}
+		if header.BaseRound >= round {
+			return nil, fmt.Errorf("fetchCatchpointChain: catchpoint delta file of round %d has an invalid base round %d", round, header.BaseRound)
+		}
+		round, label = header.BaseRound, header.BaseCatchpoint
+	}
+	return nil, fmt.Errorf("fetchCatchpointChain: catchpoint chain exceeds the maximal length of %d catchpoint files", maxCatchpointDeltaChainLength)
+}
+
 // updateVerifiedAccounts update the user's statistics for the given verified accounts
 func (cs *CatchpointCatchupService) updateVerifiedAccounts(verifiedAccounts uint64) {
 	cs.statsMu.Lock()
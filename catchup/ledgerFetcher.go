@@ -25,13 +25,16 @@ import (
 	"net/http"
 	"path"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/crypto"
 	"github.com/algorand/go-algorand/data/basics"
 	"github.com/algorand/go-algorand/ledger"
 	"github.com/algorand/go-algorand/logging"
 	"github.com/algorand/go-algorand/network"
+	"github.com/algorand/go-algorand/protocol"
 	"github.com/algorand/go-algorand/rpcs"
 	"github.com/algorand/go-algorand/util"
 )
@@ -45,10 +48,14 @@ const (
 	defaultMinCatchpointFileDownloadBytesPerSecond = 20 * 1024
 	// catchpointFileStreamReadSize defines the number of bytes we would attempt to read at each itration from the incoming http data stream
 	catchpointFileStreamReadSize = 4096
+	// tarBlockSize is the block size of the tar format; each of the catchpoint file sections starts at a block boundary.
+	tarBlockSize = 512
 )
 
 var errNonHTTPPeer = fmt.Errorf("downloadLedger : non-HTTPPeer encountered")
 
+var errCatchpointResumeMismatch = errors.New("getPeerLedger : the catchpoint file served by the peer doesn't match the partially downloaded one")
+
 type ledgerFetcherReporter interface {
 	updateLedgerFetcherProgress(*ledger.CatchpointCatchupAccessorProgress)
 }
@@ -80,52 +87,183 @@ func (lf *ledgerFetcher) downloadLedger(ctx context.Context, peer network.Peer,
 	return lf.getPeerLedger(ctx, httpPeer, round)
 }
 
-func (lf *ledgerFetcher) getPeerLedger(ctx context.Context, peer network.HTTPPeer, round basics.Round) error {
-	parsedURL, err := network.ParseHostOrURL(peer.GetAddress())
+// resumeLedger downloads the catchpoint file of the given round from the peer, persisting the download progress as it goes. If
+// the download of the same catchpoint file was previously interrupted, it is resumed from where it stopped rather than started over.
+// Once the catchpoint file was completely processed, the persisted progress is cleared.
+func (lf *ledgerFetcher) resumeLedger(ctx context.Context, peer network.Peer, round basics.Round) error {
+	httpPeer, ok := peer.(network.HTTPPeer)
+	if !ok {
+		return errNonHTTPPeer
+	}
+	progress, err := lf.accessor.GetDownloadProgress(ctx)
 	if err != nil {
 		return err
 	}
+	if progress.Round != round || progress.Sections == 0 {
+		// there is nothing to resume; start a new download.
+		err = lf.accessor.ResetStagingBalances(ctx, true)
+		if err != nil {
+			return err
+		}
+		progress = ledger.CatchpointDownloadProgress{Round: round}
+	} else {
+		lf.log.Infof("resuming the download of catchpoint file %d from peer %s after %d bytes", round, httpPeer.GetAddress(), progress.ReceivedBytes)
+	}
+	err = lf.fetchPeerLedger(ctx, httpPeer, round, &progress)
+	if err == errCatchpointResumeMismatch {
+		// the partially downloaded catchpoint file can't be completed; drop it altogether.
+		if resetErr := lf.accessor.ResetStagingBalances(ctx, true); resetErr != nil {
+			return resetErr
+		}
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	return lf.accessor.ResetDownloadProgress(ctx)
+}
+
+// downloadLedgerHeader retrieves only the header of the catchpoint file of the given round from the peer.
+func (lf *ledgerFetcher) downloadLedgerHeader(ctx context.Context, peer network.Peer, round basics.Round) (header ledger.CatchpointFileHeader, err error) {
+	httpPeer, ok := peer.(network.HTTPPeer)
+	if !ok {
+		return header, errNonHTTPPeer
+	}
+	return lf.getPeerLedgerHeader(ctx, httpPeer, round)
+}
+
+// requestPeerLedger sends the catchpoint file request for the given round to the peer, and verifies the response headers.
+// On success, the caller is responsible for closing the response body.
+// A non-zero offset requests the uncompressed catchpoint file stream starting at the given offset; the peer may either serve the
+// requested range, or ignore it and serve the complete file.
+func (lf *ledgerFetcher) requestPeerLedger(ctx context.Context, peer network.HTTPPeer, round basics.Round, offset uint64) (*http.Response, error) {
+	parsedURL, err := network.ParseHostOrURL(peer.GetAddress())
+	if err != nil {
+		return nil, err
+	}
 
 	parsedURL.Path = lf.net.SubstituteGenesisID(path.Join(parsedURL.Path, "/v1/{genesisID}/ledger/"+strconv.FormatUint(uint64(round), 36)))
 	ledgerURL := parsedURL.String()
 	lf.log.Debugf("ledger GET %#v peer %#v %T", ledgerURL, peer, peer)
 	request, err := http.NewRequest(http.MethodGet, ledgerURL, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	timeoutContext, timeoutContextCancel := context.WithTimeout(ctx, lf.config.MaxCatchpointDownloadDuration)
-	defer timeoutContextCancel()
-	request = request.WithContext(timeoutContext)
+	request = request.WithContext(ctx)
 	network.SetUserAgentHeader(request.Header)
+	if offset > 0 {
+		request.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
 	response, err := peer.GetHTTPClient().Do(request)
 	if err != nil {
 		lf.log.Debugf("getPeerLedger GET %v : %s", ledgerURL, err)
-		return err
+		return nil, err
 	}
-	defer response.Body.Close()
 
 	// check to see that we had no errors.
 	switch response.StatusCode {
 	case http.StatusOK:
+	case http.StatusPartialContent:
+		contentRange := response.Header.Get("Content-Range")
+		if offset == 0 || !strings.HasPrefix(contentRange, fmt.Sprintf("bytes %d-", offset)) {
+			response.Body.Close()
+			return nil, fmt.Errorf("getPeerLedger received an unexpected content range '%s' when requesting offset %d", contentRange, offset)
+		}
 	case http.StatusNotFound: // server could not find a block with that round numbers.
-		return errNoLedgerForRound
+		response.Body.Close()
+		return nil, errNoLedgerForRound
 	default:
-		return fmt.Errorf("getPeerLedger error response status code %d", response.StatusCode)
+		response.Body.Close()
+		return nil, fmt.Errorf("getPeerLedger error response status code %d", response.StatusCode)
 	}
 
 	// at this point, we've already receieved the response headers. ensure that the
 	// response content type is what we'd like it to be.
 	contentTypes := response.Header["Content-Type"]
 	if len(contentTypes) != 1 {
-		err = fmt.Errorf("getPeerLedger : http ledger fetcher invalid content type count %d", len(contentTypes))
-		return err
+		response.Body.Close()
+		return nil, fmt.Errorf("getPeerLedger : http ledger fetcher invalid content type count %d", len(contentTypes))
 	}
 
 	if contentTypes[0] != rpcs.LedgerResponseContentType {
-		err = fmt.Errorf("getPeerLedger : http ledger fetcher response has an invalid content type : %s", contentTypes[0])
+		response.Body.Close()
+		return nil, fmt.Errorf("getPeerLedger : http ledger fetcher response has an invalid content type : %s", contentTypes[0])
+	}
+	return response, nil
+}
+
+// getPeerLedgerHeader reads the content header of the catchpoint file of the given round, and drops the
+// connection without downloading the account balances.
+func (lf *ledgerFetcher) getPeerLedgerHeader(ctx context.Context, peer network.HTTPPeer, round basics.Round) (fileHeader ledger.CatchpointFileHeader, err error) {
+	timeoutContext, timeoutContextCancel := context.WithTimeout(ctx, lf.config.MaxCatchpointDownloadDuration)
+	defer timeoutContextCancel()
+	response, err := lf.requestPeerLedger(timeoutContext, peer, round, 0)
+	if err != nil {
+		return
+	}
+	defer response.Body.Close()
+
+	tarReader := tar.NewReader(response.Body)
+	header, err := tarReader.Next()
+	if err != nil {
+		return
+	}
+	if header.Name != "content.msgpack" {
+		err = fmt.Errorf("getPeerLedgerHeader received an unexpected first section '%s'", header.Name)
+		return
+	}
+	if header.Size > maxCatchpointFileChunkSize || header.Size < 1 {
+		err = fmt.Errorf("getPeerLedgerHeader received a tar header with data size of %d", header.Size)
+		return
+	}
+	headerBytes := make([]byte, header.Size)
+	_, err = io.ReadFull(tarReader, headerBytes)
+	if err != nil {
+		return
+	}
+	err = protocol.Decode(headerBytes, &fileHeader)
+	return
+}
+
+func (lf *ledgerFetcher) getPeerLedger(ctx context.Context, peer network.HTTPPeer, round basics.Round) error {
+	return lf.fetchPeerLedger(ctx, peer, round, nil)
+}
+
+// fetchPeerLedger downloads and processes the catchpoint file of the given round. When a download progress is provided, the
+// progress is persisted after each processed section, and the download is resumed from the last section the progress
+// records, if any.
+func (lf *ledgerFetcher) fetchPeerLedger(ctx context.Context, peer network.HTTPPeer, round basics.Round, progress *ledger.CatchpointDownloadProgress) error {
+	var downloadProgress ledger.CatchpointCatchupAccessorProgress
+	offset := uint64(0)
+	verifySection := false
+	if progress != nil && progress.Sections > 0 {
+		// the last processed section is downloaded once more, so that we could verify that the peer serves the very same catchpoint file.
+		offset = progress.SectionOffset
+		verifySection = true
+		downloadProgress = progress.StagingProgress()
+	}
+
+	timeoutContext, timeoutContextCancel := context.WithTimeout(ctx, lf.config.MaxCatchpointDownloadDuration)
+	defer timeoutContextCancel()
+	response, err := lf.requestPeerLedger(timeoutContext, peer, round, offset)
+	if err != nil {
 		return err
 	}
+	defer response.Body.Close()
+
+	if offset > 0 && response.StatusCode == http.StatusOK {
+		// the peer doesn't support range requests, and sent the entire catchpoint file. start over.
+		lf.log.Infof("getPeerLedger: peer %s doesn't support resuming the download of catchpoint file %d; restarting the download", peer.GetAddress(), round)
+		err = lf.accessor.ResetStagingBalances(ctx, true)
+		if err != nil {
+			return err
+		}
+		*progress = ledger.CatchpointDownloadProgress{Round: round}
+		offset = 0
+		verifySection = false
+		downloadProgress = ledger.CatchpointCatchupAccessorProgress{}
+	}
 
 	// maxCatchpointFileChunkDownloadDuration is the maximum amount of time we would wait to download a single chunk off a catchpoint file
 	maxCatchpointFileChunkDownloadDuration := 2 * time.Minute
@@ -137,9 +275,11 @@ func (lf *ledgerFetcher) getPeerLedger(ctx context.Context, peer network.HTTPPee
 
 	watchdogReader := util.MakeWatchdogStreamReader(response.Body, catchpointFileStreamReadSize, 2*maxCatchpointFileChunkSize, maxCatchpointFileChunkDownloadDuration)
 	defer watchdogReader.Close()
-	tarReader := tar.NewReader(watchdogReader)
-	var downloadProgress ledger.CatchpointCatchupAccessorProgress
+	streamReader := &countingReader{reader: watchdogReader}
+	tarReader := tar.NewReader(streamReader)
 	for {
+		// tar sections are aligned to the tar block size; the next section starts at the next block boundary.
+		sectionOffset := offset + (streamReader.count+tarBlockSize-1)/tarBlockSize*tarBlockSize
 		header, err := tarReader.Next()
 		if err != nil {
 			if err == io.EOF {
@@ -166,9 +306,30 @@ func (lf *ledgerFetcher) getPeerLedger(ctx context.Context, peer network.HTTPPee
 				return err
 			}
 		}
-		err = lf.processBalancesBlock(ctx, header.Name, balancesBlockBytes, &downloadProgress)
-		if err != nil {
-			return err
+		if verifySection {
+			// this section was already processed; make sure it's identical to the one we've processed.
+			verifySection = false
+			if crypto.Hash(balancesBlockBytes) != progress.SectionHash {
+				return errCatchpointResumeMismatch
+			}
+		} else {
+			downloadProgress.DownloadProgress = nil
+			if progress != nil {
+				// the accessor persists the download progress along with the staged section.
+				sectionProgress := *progress
+				sectionProgress.ReceivedBytes = offset + streamReader.count
+				sectionProgress.SectionOffset = sectionOffset
+				sectionProgress.Sections++
+				sectionProgress.SectionHash = crypto.Hash(balancesBlockBytes)
+				downloadProgress.DownloadProgress = &sectionProgress
+			}
+			err = lf.processBalancesBlock(ctx, header.Name, balancesBlockBytes, &downloadProgress)
+			if err != nil {
+				return err
+			}
+			if progress != nil {
+				*progress = *downloadProgress.DownloadProgress
+			}
 		}
 		if lf.reporter != nil {
 			lf.reporter.updateLedgerFetcherProgress(&downloadProgress)
@@ -183,6 +344,18 @@ func (lf *ledgerFetcher) getPeerLedger(ctx context.Context, peer network.HTTPPee
 	}
 }
 
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	reader io.Reader
+	count  uint64
+}
+
+func (cr *countingReader) Read(p []byte) (n int, err error) {
+	n, err = cr.reader.Read(p)
+	cr.count += uint64(n)
+	return
+}
+
 func (lf *ledgerFetcher) processBalancesBlock(ctx context.Context, sectionName string, bytes []byte, downloadProgress *ledger.CatchpointCatchupAccessorProgress) error {
 	return lf.accessor.ProgressStagingBalances(ctx, sectionName, bytes, downloadProgress)
 }
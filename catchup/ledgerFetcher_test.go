@@ -17,20 +17,25 @@
 package catchup
 
 import (
+	"archive/tar"
+	"bytes"
 	"context"
 	"fmt"
 	"net"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/algorand/go-algorand/components/mocks"
 	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/crypto"
 	"github.com/algorand/go-algorand/data/basics"
 	"github.com/algorand/go-algorand/ledger"
 	"github.com/algorand/go-algorand/logging"
 	"github.com/algorand/go-algorand/network"
+	"github.com/algorand/go-algorand/rpcs"
 )
 
 type dummyLedgerFetcherReporter struct {
@@ -97,3 +102,146 @@ func TestLedgerFetcherErrorResponseHandling(t *testing.T) {
 	err = lf.getPeerLedger(context.Background(), &peer, basics.Round(0))
 	require.Equal(t, fmt.Errorf("getPeerLedger : http ledger fetcher response has an invalid content type : %s", contentTypes[0]), err)
 }
+
+// resumeTestAccessor is a catchpoint catchup accessor which keeps the download progress in memory, and records the processed sections.
+type resumeTestAccessor struct {
+	mocks.MockCatchpointCatchupAccessor
+	progress  ledger.CatchpointDownloadProgress
+	processed []string
+	resets    int
+	failAt    string
+}
+
+func (a *resumeTestAccessor) ResetStagingBalances(ctx context.Context, newCatchup bool) (err error) {
+	a.resets++
+	a.processed = nil
+	a.progress = ledger.CatchpointDownloadProgress{}
+	return nil
+}
+
+func (a *resumeTestAccessor) ProgressStagingBalances(ctx context.Context, sectionName string, bytes []byte, progress *ledger.CatchpointCatchupAccessorProgress) (err error) {
+	if sectionName == a.failAt {
+		return fmt.Errorf("failed processing %s", sectionName)
+	}
+	a.processed = append(a.processed, sectionName)
+	progress.ProcessedBytes += uint64(len(bytes))
+	if progress.DownloadProgress != nil {
+		progress.DownloadProgress.ProcessedBytes = progress.ProcessedBytes
+		a.progress = *progress.DownloadProgress
+	}
+	return nil
+}
+
+func (a *resumeTestAccessor) GetDownloadProgress(ctx context.Context) (progress ledger.CatchpointDownloadProgress, err error) {
+	return a.progress, nil
+}
+
+func (a *resumeTestAccessor) SaveDownloadProgress(ctx context.Context, progress ledger.CatchpointDownloadProgress) (err error) {
+	a.progress = progress
+	return nil
+}
+
+func (a *resumeTestAccessor) ResetDownloadProgress(ctx context.Context) (err error) {
+	a.progress = ledger.CatchpointDownloadProgress{}
+	return nil
+}
+
+func makeTestCatchpointTar(t *testing.T, sections []string, sectionSize int) []byte {
+	var buf bytes.Buffer
+	tarWriter := tar.NewWriter(&buf)
+	for i, section := range sections {
+		content := make([]byte, sectionSize+i)
+		crypto.RandBytes(content)
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: section, Mode: 0600, Size: int64(len(content))}))
+		_, err := tarWriter.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tarWriter.Close())
+	return buf.Bytes()
+}
+
+func TestLedgerFetcherResumeDownload(t *testing.T) {
+	mux := http.NewServeMux()
+	s := &http.Server{
+		Handler: mux,
+	}
+	listener, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err)
+	go s.Serve(listener)
+	defer s.Close()
+	defer listener.Close()
+
+	sections := []string{"content.msgpack", "balances.1.4.msgpack", "balances.2.4.msgpack", "balances.3.4.msgpack", "balances.4.4.msgpack"}
+	catchpointFile := makeTestCatchpointTar(t, sections, 1000)
+	supportRanges := true
+	var requestedRanges []string
+	mux.HandleFunc("/", func(w http.ResponseWriter, req *http.Request) {
+		requestedRanges = append(requestedRanges, req.Header.Get("Range"))
+		w.Header().Set("Content-Type", rpcs.LedgerResponseContentType)
+		if !supportRanges {
+			req.Header.Del("Range")
+		}
+		http.ServeContent(w, req, "", time.Time{}, bytes.NewReader(catchpointFile))
+	})
+
+	accessor := &resumeTestAccessor{failAt: sections[3]}
+	lf := makeLedgerFetcher(&mocks.MockNetwork{}, accessor, logging.TestingLog(t), &dummyLedgerFetcherReporter{}, config.GetDefaultLocal())
+	peer := testHTTPPeer(listener.Addr().String())
+
+	// the download fails while processing the fourth section; the progress of the first three is persisted.
+	err = lf.resumeLedger(context.Background(), &peer, basics.Round(100))
+	require.Error(t, err)
+	require.Equal(t, sections[:3], accessor.processed)
+	require.Equal(t, basics.Round(100), accessor.progress.Round)
+	require.Equal(t, uint64(3), accessor.progress.Sections)
+	require.Equal(t, []string{""}, requestedRanges)
+	require.Equal(t, 1, accessor.resets)
+
+	// resuming the download requests the last processed section onward, and processes only the remaining sections.
+	accessor.failAt = ""
+	requestedRanges = nil
+	err = lf.resumeLedger(context.Background(), &peer, basics.Round(100))
+	require.NoError(t, err)
+	require.Equal(t, sections, accessor.processed)
+	require.Len(t, requestedRanges, 1)
+	require.NotEmpty(t, requestedRanges[0])
+	require.Equal(t, 1, accessor.resets)
+	// the progress is cleared once the catchpoint file was completely processed.
+	require.Equal(t, ledger.CatchpointDownloadProgress{}, accessor.progress)
+
+	// a peer serving a different catchpoint file can't be resumed from.
+	accessor.failAt = sections[3]
+	err = lf.resumeLedger(context.Background(), &peer, basics.Round(100))
+	require.Error(t, err)
+	require.Equal(t, 2, accessor.resets)
+	accessor.failAt = ""
+	catchpointFile = makeTestCatchpointTar(t, sections, 1000)
+	err = lf.resumeLedger(context.Background(), &peer, basics.Round(100))
+	require.Equal(t, errCatchpointResumeMismatch, err)
+	require.Equal(t, 3, accessor.resets)
+	require.Equal(t, ledger.CatchpointDownloadProgress{}, accessor.progress)
+
+	// a peer which doesn't support range requests has the download start over.
+	accessor.failAt = sections[3]
+	err = lf.resumeLedger(context.Background(), &peer, basics.Round(100))
+	require.Error(t, err)
+	require.Equal(t, 4, accessor.resets)
+	accessor.failAt = ""
+	supportRanges = false
+	err = lf.resumeLedger(context.Background(), &peer, basics.Round(100))
+	require.NoError(t, err)
+	require.Equal(t, sections, accessor.processed)
+	require.Equal(t, 5, accessor.resets)
+
+	// a different catchpoint round isn't resumed either.
+	supportRanges = true
+	accessor.failAt = sections[3]
+	err = lf.resumeLedger(context.Background(), &peer, basics.Round(100))
+	require.Error(t, err)
+	accessor.failAt = ""
+	requestedRanges = nil
+	err = lf.resumeLedger(context.Background(), &peer, basics.Round(200))
+	require.NoError(t, err)
+	require.Equal(t, sections, accessor.processed)
+	require.Equal(t, []string{""}, requestedRanges)
+}
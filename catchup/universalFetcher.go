@@ -161,7 +161,11 @@ func (w *wsFetcherClient) requestBlock(ctx context.Context, round basics.Round)
 	}
 	resp, err := w.target.Request(ctx, protocol.UniEnsBlockReqTag, topics)
 	if err != nil {
-		return nil, makeErrWsFetcherRequestFailed(round, w.target.GetAddress(), err.Error())
+		requestErr := makeErrWsFetcherRequestFailed(round, w.target.GetAddress(), err.Error())
+		// the request context is only canceled when the block is no longer needed; a deadline means that the peer
+		// didn't answer in time.
+		requestErr.unanswered = err == context.DeadlineExceeded
+		return nil, requestErr
 	}
 
 	if errMsg, found := resp.Topics.GetValue(network.ErrorKey); found {
@@ -333,6 +337,8 @@ type errWsFetcherRequestFailed struct {
 	round basics.Round
 	peer  string
 	cause string
+	// unanswered is set when the peer didn't answer the request before it timed out.
+	unanswered bool
 }
 
 func makeErrWsFetcherRequestFailed(round basics.Round, peer, cause string) errWsFetcherRequestFailed {
@@ -199,6 +199,9 @@ func printAccountsDatabase(databaseName string, fileHeader ledger.CatchpointFile
 			fileHeader.Catchpoint,
 			fileHeader.TotalAccounts,
 			fileHeader.TotalChunks)
+		if fileHeader.BaseRound != 0 {
+			fmt.Fprintf(fileWriter, "Base Round: %d\nBase Catchpoint: %s\n", fileHeader.BaseRound, fileHeader.BaseCatchpoint)
+		}
 
 		totals := fileHeader.Totals
 		fmt.Fprintf(fileWriter, "AccountTotals - Online Money: %d\nAccountTotals - Online RewardUnits : %d\nAccountTotals - Offline Money: %d\nAccountTotals - Offline RewardUnits : %d\nAccountTotals - Not Participating Money: %d\nAccountTotals - Not Participating Money RewardUnits: %d\nAccountTotals - Rewards Level: %d\n",
@@ -0,0 +1,141 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+// ledgeraudit replays the blocks of the ledger of an algod data directory, from the genesis or from a catchpoint file,
+// and compares the resulting accounts, account totals and accounts merkle trie root against the stored accounts database.
+// The node must not be running while its ledger is being audited.
+package main
+
+import (
+	"compress/gzip"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/data"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/ledger"
+	"github.com/algorand/go-algorand/logging"
+)
+
+var dataDirectory = flag.String("d", "", "Algod data directory")
+var catchpointFile = flag.String("catchpoint", "", "Catchpoint file to start the replay from, instead of the genesis")
+var backend = flag.String("backend", "", "Storage backend the ledger is stored by; defaults to the LedgerStorageBackend of the node config.json")
+
+func main() {
+	flag.Parse()
+
+	dataDir := *dataDirectory
+	if dataDir == "" {
+		dataDir = os.Getenv("ALGORAND_DATA")
+	}
+	if dataDir == "" {
+		fmt.Fprintln(os.Stderr, "Data directory not specified. Please use -d or set $ALGORAND_DATA in your environment.")
+		os.Exit(1)
+	}
+	absolutePath, err := filepath.Abs(dataDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Can't convert data directory's path to absolute, %v\n", dataDir)
+		os.Exit(1)
+	}
+
+	genesis, err := bookkeeping.LoadGenesisFromFile(filepath.Join(absolutePath, config.GenesisJSONFile))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Cannot load genesis file: %v\n", err)
+		os.Exit(1)
+	}
+	err = config.LoadConfigurableConsensusProtocols(absolutePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to load optional consensus protocols file: %v\n", err)
+		os.Exit(1)
+	}
+	if _, ok := config.Consensus[genesis.Proto]; !ok {
+		fmt.Fprintf(os.Stderr, "Genesis protocol '%s' is not supported\n", genesis.Proto)
+		os.Exit(1)
+	}
+	storageBackend := *backend
+	if storageBackend == "" {
+		cfg, err := config.LoadConfigFromDisk(absolutePath)
+		if err != nil && !os.IsNotExist(err) {
+			fmt.Fprintf(os.Stderr, "Cannot load config: %v\n", err)
+			os.Exit(1)
+		}
+		storageBackend = cfg.LedgerStorageBackend
+	}
+
+	genesisBalances, err := data.MakeGenesisBalancesFromGenesis(genesis)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Cannot load genesis allocation: %v\n", err)
+		os.Exit(1)
+	}
+	genesisInitState, err := data.MakeGenesisInitState(genesis.Proto, genesisBalances, genesis.ID(), crypto.HashObj(genesis))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Cannot create genesis state: %v\n", err)
+		os.Exit(1)
+	}
+
+	var catchpoint io.Reader
+	if *catchpointFile != "" {
+		f, err := os.Open(*catchpointFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to open catchpoint file: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		catchpoint = f
+		// catchpoint files are served and stored gzip compressed.
+		if strings.HasSuffix(*catchpointFile, ".gz") {
+			gzipReader, err := gzip.NewReader(f)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Unable to decompress catchpoint file: %v\n", err)
+				os.Exit(1)
+			}
+			defer gzipReader.Close()
+			catchpoint = gzipReader
+		}
+	}
+
+	log := logging.Base()
+	log.SetLevel(logging.Info)
+	log.SetOutput(os.Stdout)
+
+	ledgerPathnamePrefix := filepath.Join(absolutePath, genesis.ID(), config.LedgerFilenamePrefix)
+	report, err := ledger.AuditLedger(log, ledgerPathnamePrefix, storageBackend, genesisInitState, catchpoint)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to audit the ledger: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Replayed rounds: %d-%d\n", report.StartRound+1, report.ReplayedRound)
+	fmt.Printf("Accounts round: %d\n", report.AccountsRound)
+	fmt.Printf("Compared accounts: %d\n", report.ComparedAccounts)
+	fmt.Printf("Merkle trie root compared: %v\n", report.TrieRootCompared)
+	if report.Divergent {
+		fmt.Printf("Divergent round: %d\n", report.DivergentRound)
+		if report.DivergentAccounts > 0 {
+			fmt.Printf("Divergent accounts: %d\n", report.DivergentAccounts)
+			fmt.Printf("First divergent account: %v\n", report.DivergentAccount)
+		}
+		fmt.Printf("Reason: %s\n", report.Reason)
+		os.Exit(2)
+	}
+	fmt.Println("The stored ledger matches the replayed one.")
+}
@@ -0,0 +1,82 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+// ledgermigrate copies the ledger of an algod data directory from one storage backend to another.
+// The node must not be running while its ledger is being migrated. Once the migration completes,
+// set the LedgerStorageBackend of the node config.json to the destination backend.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/ledger"
+	"github.com/algorand/go-algorand/logging"
+)
+
+var dataDirectory = flag.String("d", "", "Algod data directory")
+var fromBackend = flag.String("from", ledger.StorageBackendSQLite, "Storage backend the ledger is currently stored by")
+var toBackend = flag.String("to", ledger.StorageBackendLevelDB, "Storage backend to migrate the ledger into")
+
+func main() {
+	flag.Parse()
+
+	dataDir := *dataDirectory
+	if dataDir == "" {
+		dataDir = os.Getenv("ALGORAND_DATA")
+	}
+	if dataDir == "" {
+		fmt.Fprintln(os.Stderr, "Data directory not specified. Please use -d or set $ALGORAND_DATA in your environment.")
+		os.Exit(1)
+	}
+	absolutePath, err := filepath.Abs(dataDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Can't convert data directory's path to absolute, %v\n", dataDir)
+		os.Exit(1)
+	}
+
+	genesis, err := bookkeeping.LoadGenesisFromFile(filepath.Join(absolutePath, config.GenesisJSONFile))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Cannot load genesis file: %v\n", err)
+		os.Exit(1)
+	}
+	err = config.LoadConfigurableConsensusProtocols(absolutePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to load optional consensus protocols file: %v\n", err)
+		os.Exit(1)
+	}
+	genesisProto, ok := config.Consensus[genesis.Proto]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Genesis protocol '%s' is not supported\n", genesis.Proto)
+		os.Exit(1)
+	}
+
+	log := logging.Base()
+	log.SetLevel(logging.Info)
+	log.SetOutput(os.Stdout)
+
+	ledgerPathnamePrefix := filepath.Join(absolutePath, genesis.ID(), config.LedgerFilenamePrefix)
+	err = ledger.MigrateLedgerStorage(log, ledgerPathnamePrefix, genesisProto, *fromBackend, *toBackend)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to migrate the ledger: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("The ledger was migrated into the '%s' storage backend. Set \"LedgerStorageBackend\": \"%s\" in config.json to use it.\n", *toBackend, *toBackend)
+}
@@ -0,0 +1,164 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+// msgreplay starts a node on an algod data directory, isolated from the network, and feeds it the network messages
+// recorded by a node with EnableMessageRecording set, at their original or at an accelerated pace. Replaying a
+// recording into a copy of the data directory of the recording node, as it was when the recording started, puts the
+// agreement and the transaction handling of the node through the same messages. The timers of the node run as they
+// please though, and the recorder drops messages when it falls behind, so the replay may still diverge from the
+// recorded run.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/network/messagerecorder"
+	"github.com/algorand/go-algorand/node"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+var dataDirectory = flag.String("d", "", "Algod data directory of the node the messages are replayed into")
+var recording = flag.String("r", "", "Message recording file, or directory of message recording files, to replay")
+var speed = flag.Float64("speed", 1, "Replay speed relative to the recorded timing; 0 replays the messages as fast as possible")
+var settle = flag.Duration("settle", 5*time.Second, "Duration the node keeps running once all the messages were replayed")
+
+func main() {
+	flag.Parse()
+
+	dataDir := *dataDirectory
+	if dataDir == "" {
+		dataDir = os.Getenv("ALGORAND_DATA")
+	}
+	if dataDir == "" {
+		fmt.Fprintln(os.Stderr, "Data directory not specified. Please use -d or set $ALGORAND_DATA in your environment.")
+		os.Exit(1)
+	}
+	if *recording == "" {
+		fmt.Fprintln(os.Stderr, "Recording not specified. Please use -r.")
+		os.Exit(1)
+	}
+	if *speed < 0 {
+		fmt.Fprintln(os.Stderr, "The replay speed can't be negative.")
+		os.Exit(1)
+	}
+	absolutePath, err := filepath.Abs(dataDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Can't convert data directory's path to absolute, %v\n", dataDir)
+		os.Exit(1)
+	}
+
+	genesis, err := bookkeeping.LoadGenesisFromFile(filepath.Join(absolutePath, config.GenesisJSONFile))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Cannot load genesis file: %v\n", err)
+		os.Exit(1)
+	}
+	err = config.LoadConfigurableConsensusProtocols(absolutePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to load optional consensus protocols file: %v\n", err)
+		os.Exit(1)
+	}
+	cfg, err := config.LoadConfigFromDisk(absolutePath)
+	if err != nil && !os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Cannot load config: %v\n", err)
+		os.Exit(1)
+	}
+	// isolate the node from the network, so that it only handles the replayed messages.
+	cfg.NetAddress = ""
+	cfg.DNSBootstrapID = ""
+	cfg.EnableMessageRecording = false
+
+	reader, err := messagerecorder.OpenRecording(*recording)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Cannot open recording: %v\n", err)
+		os.Exit(1)
+	}
+	defer reader.Close()
+
+	log := logging.Base()
+	log.SetLevel(logging.Level(cfg.BaseLoggerDebugLevel))
+	logFile, err := os.OpenFile(filepath.Join(absolutePath, "msgreplay.log"), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Cannot open log file: %v\n", err)
+		os.Exit(1)
+	}
+	defer logFile.Close()
+	log.SetOutput(logFile)
+
+	fullNode, err := node.MakeFull(log, absolutePath, cfg, nil, genesis)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Cannot create node: %v\n", err)
+		os.Exit(1)
+	}
+	fullNode.Start()
+	defer fullNode.Stop()
+	startRound := fullNode.Ledger().Latest()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	interrupted := make(chan os.Signal, 1)
+	signal.Notify(interrupted, os.Interrupt)
+	go func() {
+		<-interrupted
+		cancel()
+	}()
+
+	tagCounts := make(map[protocol.Tag]int)
+	var replayErr error
+	count, dropped, err := messagerecorder.Replay(ctx, reader, *speed, func(msg messagerecorder.Message) {
+		if replayErr != nil {
+			return
+		}
+		replayErr = fullNode.ReplayMessage(msg)
+		tagCounts[msg.Tag]++
+	})
+	if err == nil {
+		err = replayErr
+	}
+	if err != nil && err != context.Canceled {
+		fmt.Fprintf(os.Stderr, "Unable to replay the recording: %v\n", err)
+		os.Exit(1)
+	}
+	if err == nil {
+		select {
+		case <-time.After(*settle):
+		case <-ctx.Done():
+		}
+	}
+
+	fmt.Printf("Replayed messages: %d\n", count)
+	if dropped > 0 {
+		fmt.Printf("Messages missing from the recording: %d\n", dropped)
+	}
+	tags := make([]string, 0, len(tagCounts))
+	for tag := range tagCounts {
+		tags = append(tags, string(tag))
+	}
+	sort.Strings(tags)
+	for _, tag := range tags {
+		fmt.Printf("  %s: %d\n", tag, tagCounts[protocol.Tag(tag)])
+	}
+	fmt.Printf("Rounds: %d-%d\n", startRound, fullNode.Ledger().Latest())
+}
@@ -57,6 +57,21 @@ func (m *MockCatchpointCatchupAccessor) ProgressStagingBalances(ctx context.Cont
 	return nil
 }
 
+// GetDownloadProgress returns the persisted progress of the catchpoint file download
+func (m *MockCatchpointCatchupAccessor) GetDownloadProgress(ctx context.Context) (progress ledger.CatchpointDownloadProgress, err error) {
+	return ledger.CatchpointDownloadProgress{}, nil
+}
+
+// SaveDownloadProgress persists the progress of the catchpoint file download, along with the hash of the last processed section
+func (m *MockCatchpointCatchupAccessor) SaveDownloadProgress(ctx context.Context, progress ledger.CatchpointDownloadProgress) (err error) {
+	return nil
+}
+
+// ResetDownloadProgress clears the persisted progress of the catchpoint file download
+func (m *MockCatchpointCatchupAccessor) ResetDownloadProgress(ctx context.Context) (err error) {
+	return nil
+}
+
 // BuildMerkleTrie inserts the account hashes into the merkle trie
 func (m *MockCatchpointCatchupAccessor) BuildMerkleTrie(ctx context.Context, progressUpdates func(uint64)) (err error) {
 	return nil
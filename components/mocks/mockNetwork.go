@@ -20,6 +20,7 @@ import (
 	"context"
 	"net"
 	"net/http"
+	"time"
 
 	"github.com/algorand/go-algorand/network"
 	"github.com/algorand/go-algorand/protocol"
@@ -65,6 +66,19 @@ func (network *MockNetwork) Disconnect(badpeer network.Peer) {
 func (network *MockNetwork) DisconnectPeers() {
 }
 
+// ReportPeer - unused function
+func (network *MockNetwork) ReportPeer(peer network.Peer, offense network.PeerOffense) {
+}
+
+// BanPeer - unused function
+func (network *MockNetwork) BanPeer(peer network.Peer, duration time.Duration) error {
+	return nil
+}
+
+// AddPhonebookEntry - unused function
+func (network *MockNetwork) AddPhonebookEntry(address string, role network.PhoneBookEntryRoles) {
+}
+
 // RegisterRPCName - unused function
 func (network *MockNetwork) RegisterRPCName(name string, rcvr interface{}) {
 }
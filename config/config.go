@@ -63,7 +63,7 @@ type Local struct {
 	// Version tracks the current version of the defaults so we can migrate old -> new
 	// This is specifically important whenever we decide to change the default value
 	// for an existing parameter. This field tag must be updated any time we add a new version.
-	Version uint32 `version[0]:"0" version[1]:"1" version[2]:"2" version[3]:"3" version[4]:"4" version[5]:"5" version[6]:"6" version[7]:"7" version[8]:"8" version[9]:"9" version[10]:"10" version[11]:"11" version[12]:"12" version[13]:"13" version[14]:"14" version[15]:"15" version[16]:"16"`
+	Version uint32 `version[0]:"0" version[1]:"1" version[2]:"2" version[3]:"3" version[4]:"4" version[5]:"5" version[6]:"6" version[7]:"7" version[8]:"8" version[9]:"9" version[10]:"10" version[11]:"11" version[12]:"12" version[13]:"13" version[14]:"14" version[15]:"15" version[16]:"16" version[17]:"17"`
 
 	// environmental (may be overridden)
 	// When enabled, stores blocks indefinitally, otherwise, only the most recents blocks
@@ -116,7 +116,8 @@ type Local struct {
 	AnnounceParticipationKey bool `version[4]:"true"`
 
 	// PriorityPeers specifies peer IP addresses that should always get
-	// outgoing broadcast messages from this node.
+	// outgoing broadcast messages from this node. Peers connected over an
+	// encrypted connection may also be listed by their authenticated identity.
 	PriorityPeers map[string]bool `version[4]:""`
 
 	// To make sure the algod process does not run out of FDs, algod ensures
@@ -418,6 +419,122 @@ type Local struct {
 	// features like catchpoint catchup would be rendered completly non-operational, and many of the node inner
 	// working would be completly dis-functional.
 	DisableNetworking bool `version[16]:"false"`
+
+	// ParallelBlockEvaluationWorkers controls the number of go-routines the ledger would use when evaluating the transaction
+	// groups of a block. When set to a value greater than one, transaction groups that don't access any common account, asset
+	// or application are evaluated concurrently and merged back in their original order. A value of zero or one retains the
+	// sequential evaluation.
+	ParallelBlockEvaluationWorkers int `version[17]:"0"`
+
+	// LedgerStorageBackend defines the storage engine used for the ledger blocks and tracker databases. The supported options are:
+	// sqlite - the ledger is stored in the ledger.block.sqlite and ledger.tracker.sqlite SQLite database files.
+	// leveldb - the ledger is stored in the ledger.block.leveldb and ledger.tracker.leveldb directories, using an embedded LSM key-value store.
+	// Switching between the backends requires migrating the existing ledger using the ledgermigrate utility; otherwise, the node
+	// would start over from the genesis block.
+	LedgerStorageBackend string `version[17]:"sqlite"`
+
+	// CatchpointFullFileInterval controls the generation of incremental catchpoint files. When set to zero, every catchpoint file
+	// contains the complete account balances. When set to N > 0, only every Nth catchpoint file contains the complete account balances,
+	// while the catchpoint files in between contain just the accounts modified since the preceding catchpoint, and are applied on top of
+	// it during catchpoint catchup. CatchpointFileHistoryLength should be larger than N, so that the complete catchpoint file the
+	// incremental files are based on would still be available.
+	CatchpointFullFileInterval uint64 `version[17]:"0"`
+
+	// EnableTxPoolJournal enables recording the transactions pending in the transaction pool in a journal file within the
+	// genesis directory, so that they are restored into the transaction pool once the node restarts. Journaled transactions
+	// which expired or were committed while the node was down are dropped.
+	EnableTxPoolJournal bool `version[17]:"false"`
+
+	// EnableWebhooks enables the webhook notifications, where the transactions of every committed block which involve a
+	// watched address, asset or application are POSTed to the callback URL registered along with the watcher.
+	EnableWebhooks bool `version[17]:"false"`
+
+	// WebhookMaxDeliveryAttempts is the number of times a webhook notification is attempted before it is dropped.
+	WebhookMaxDeliveryAttempts uint64 `version[17]:"20"`
+
+	// GRPCEndpointAddress configures the address the node listens to for gRPC connections, which serve the operations
+	// of the V2 REST API authenticated by the same API tokens. The gRPC server is disabled when empty.
+	GRPCEndpointAddress string `version[17]:""`
+
+	// MaxBatchRequestItems is the maximal number of lookups a single /v2/batch request may hold. Setting it to zero
+	// disables the batch requests.
+	MaxBatchRequestItems uint64 `version[17]:"1000"`
+
+	// MaxBatchRequestBytes is the maximal size of the body of a /v2/batch request.
+	MaxBatchRequestBytes uint64 `version[17]:"262144"`
+
+	// EnableMessageCompression enables the negotiation of a compression codec with the peers during the connection
+	// handshake. Once a codec was negotiated, the proposals, vote bundles, transactions and catchup responses exchanged
+	// with the peer are compressed. Peers which don't support compression keep exchanging uncompressed messages.
+	EnableMessageCompression bool `version[17]:"false"`
+
+	// PeerBanPenaltyThreshold is the accumulated misbehavior penalty at which a peer gets banned. The penalties decay
+	// over time, so that only repeated misbehavior leads to a ban. The relays the node connects to, as well as the
+	// hosts of the relays of its phonebook, are never banned. Peers aren't banned for misbehaving when it's 0, the default;
+	// the offenses are still counted by the algod_network_peer_offenses_total metric, which tells how a threshold
+	// would affect the peers before enabling the bans. The peers banned through the REST API are banned regardless.
+	PeerBanPenaltyThreshold uint64 `version[17]:"0"`
+
+	// PeerBanDurationSeconds is the duration of the first ban of a peer. The duration doubles on every subsequent
+	// ban of the same peer, until the peer behaves for a day.
+	PeerBanDurationSeconds uint64 `version[17]:"3600"`
+
+	// EnableTransactionAnnouncements makes the node announce the digests of the transaction groups it relays to the
+	// peers supporting the announcements, which then request the groups they haven't seen yet, instead of sending them
+	// the groups in full. The announcements of the peers are handled regardless of this setting.
+	EnableTransactionAnnouncements bool `version[17]:"false"`
+
+	// EnablePeerEncryption enables the encrypted handshake of the gossip connections with the peers supporting it. The
+	// handshake authenticates the long-term identity key of each peer, kept in the data directory, and encrypts the
+	// messages of the connection. Peers which don't support it keep exchanging plain messages.
+	EnablePeerEncryption bool `version[17]:"false"`
+
+	// PeerIdentityAllowlist lists the identities of the peers the node accepts connections with. When it isn't empty,
+	// only the peers which authenticated with one of the listed identities over the encrypted handshake are connected,
+	// which allows running a private mesh of relays. It only applies when EnablePeerEncryption is set.
+	PeerIdentityAllowlist map[string]bool `version[17]:""`
+
+	// EnableMessageRecording records every message received from the peers, along with its tag, peer and time of
+	// reception, to rotating files of the data directory. The recordings can be replayed into another node with
+	// msgreplay, to investigate the behavior of the node.
+	EnableMessageRecording bool `version[17]:"false"`
+
+	// MessageRecordingFileSize is the size, in bytes, at which a message recording file is closed and a new file
+	// started.
+	MessageRecordingFileSize uint64 `version[17]:"104857600"`
+
+	// MessageRecordingFileCount is the number of message recording files kept; the oldest files are removed first.
+	MessageRecordingFileCount uint64 `version[17]:"10"`
+
+	// EnableInboundPriorityQueues queues the messages received from the peers by priority, so that the agreement votes
+	// are handled before the proposals, which are handled before the compact certificate signatures, the other
+	// messages and, last, the transactions. Every peer has up to 10 messages of each class queued at a time, and the
+	// transactions are dropped rather than delaying the other messages of their peer once their queue is full. When it
+	// isn't set, the messages are handled in the order of their arrival.
+	EnableInboundPriorityQueues bool `version[17]:"true"`
+
+	// InboundMessageRateLimits maps message tags to the number of messages per second each peer may send with that
+	// tag, e.g. {"TX": 1000}. The messages over the limit are dropped. The tags which aren't listed aren't limited.
+	InboundMessageRateLimits map[string]uint64 `version[17]:""`
+
+	// InboundMessageBurstSeconds is for how many seconds a peer may accumulate its InboundMessageRateLimits allowance
+	// while it's idle, and then send it in a burst.
+	InboundMessageBurstSeconds uint64 `version[17]:"5"`
+
+	// AdditionalNetAddresses lists the addresses a relay listens on in addition to NetAddress, e.g. the addresses of
+	// its private interfaces, or an IPv6 address. Note that listening on a wildcard such as ":4160" already covers
+	// both IPv4 and IPv6 on most systems, so the additional addresses should be specific ones.
+	AdditionalNetAddresses map[string]bool `version[17]:""`
+
+	// AdditionalPublicAddresses lists the addresses, such as IPv6 ones, the relay advertises to its peers in addition
+	// to PublicAddress. When PublicAddress isn't set, the relay advertises all the addresses it listens on instead.
+	AdditionalPublicAddresses map[string]bool `version[17]:""`
+
+	// ConnectionsIPv6PrefixLength is the length of the IPv6 prefixes whose addresses are counted as a single host by
+	// the MaxConnectionsPerIP and the ConnectionsRateLimitingCount limits, e.g. 64 to count a /64, which is usually
+	// assigned to a single site, as one host. The IPv6 addresses are counted one by one when it's 128, the default, or
+	// out of the 1 to 128 range.
+	ConnectionsIPv6PrefixLength uint64 `version[17]:"128"`
 }
 
 // Filenames of config files within the configdir (e.g. ~/.algorand)
@@ -439,6 +556,30 @@ const CrashFilename = "crash.sqlite"
 // It is used to track in-progress compact certificates.
 const CompactCertFilename = "compactcert.sqlite"
 
+// TxPoolJournalFilename is the name of the transaction pool journal file.
+// It is used to restore the pending transactions once the node restarts.
+const TxPoolJournalFilename = "txpool.journal"
+
+// WebhooksFilename is the name of the webhooks database file.
+// It holds the registered watchers and the queue of notifications yet to be delivered.
+const WebhooksFilename = "webhooks.sqlite"
+
+// PeerBansFilename is the name of the peer bans file.
+// It is used to keep the misbehaving peers banned across restarts.
+const PeerBansFilename = "peerbans.json"
+
+// AddressBookFilename is the name of the address book file.
+// It holds the connection quality history of the relays, used to prefer the relays which performed well.
+const AddressBookFilename = "addressbook.json"
+
+// PeerIdentityFilename is the name of the peer identity key file.
+// It holds the private key the node authenticates with during the encrypted handshake of its gossip connections.
+const PeerIdentityFilename = "peer_identity.key"
+
+// MessageRecordingsDirname is the name of the directory of the message recording files.
+// It holds the messages received from the peers, when the message recording is enabled.
+const MessageRecordingsDirname = "recordings"
+
 // ConfigurableConsensusProtocolsFilename defines a set of consensus prototocols that
 // are to be loaded from the data directory ( if present ), to override the
 // built-in supported consensus protocols.
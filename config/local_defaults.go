@@ -20,9 +20,11 @@
 package config
 
 var defaultLocal = Local{
-	Version:                                 16,
+	Version:                                 17,
 	AccountUpdatesStatsInterval:             5000000000,
 	AccountsRebuildSynchronousMode:          1,
+	AdditionalNetAddresses:                  map[string]bool{},
+	AdditionalPublicAddresses:               map[string]bool{},
 	AnnounceParticipationKey:                true,
 	Archival:                                false,
 	BaseLoggerDebugLevel:                    4,
@@ -30,6 +32,7 @@ var defaultLocal = Local{
 	BroadcastConnectionsLimit:               -1,
 	CadaverSizeTarget:                       1073741824,
 	CatchpointFileHistoryLength:             365,
+	CatchpointFullFileInterval:              0,
 	CatchpointInterval:                      10000,
 	CatchpointTracking:                      0,
 	CatchupBlockDownloadRetryAttempts:       1000,
@@ -39,6 +42,7 @@ var defaultLocal = Local{
 	CatchupHTTPBlockFetchTimeoutSec:         4,
 	CatchupLedgerDownloadRetryAttempts:      50,
 	CatchupParallelBlocks:                   16,
+	ConnectionsIPv6PrefixLength:             128,
 	ConnectionsRateLimitingCount:            60,
 	ConnectionsRateLimitingWindowSeconds:    1,
 	DNSBootstrapID:                          "<network>.algorand.network",
@@ -56,29 +60,44 @@ var defaultLocal = Local{
 	EnableCatchupFromArchiveServers:         false,
 	EnableDeveloperAPI:                      false,
 	EnableGossipBlockService:                true,
+	EnableInboundPriorityQueues:             true,
 	EnableIncomingMessageFilter:             false,
 	EnableLedgerService:                     false,
+	EnableMessageCompression:                false,
+	EnableMessageRecording:                  false,
 	EnableMetricReporting:                   false,
 	EnableOutgoingNetworkMessageFiltering:   true,
+	EnablePeerEncryption:                    false,
 	EnablePingHandler:                       true,
 	EnableProcessBlockStats:                 false,
 	EnableProfiler:                          false,
 	EnableRequestLogger:                     false,
 	EnableTopAccountsReporting:              false,
+	EnableTransactionAnnouncements:          false,
+	EnableTxPoolJournal:                     false,
+	EnableWebhooks:                          false,
 	EndpointAddress:                         "127.0.0.1:0",
 	FallbackDNSResolverAddress:              "",
 	ForceRelayMessages:                      false,
+	GRPCEndpointAddress:                     "",
 	GossipFanout:                            4,
+	InboundMessageBurstSeconds:              5,
+	InboundMessageRateLimits:                map[string]uint64{},
 	IncomingConnectionsLimit:                10000,
 	IncomingMessageFilterBucketCount:        5,
 	IncomingMessageFilterBucketSize:         512,
 	IsIndexerActive:                         false,
+	LedgerStorageBackend:                    "sqlite",
 	LedgerSynchronousMode:                   2,
 	LogArchiveMaxAge:                        "",
 	LogArchiveName:                          "node.archive.log",
 	LogSizeLimit:                            1073741824,
+	MaxBatchRequestBytes:                    262144,
+	MaxBatchRequestItems:                    1000,
 	MaxCatchpointDownloadDuration:           7200000000000,
 	MaxConnectionsPerIP:                     30,
+	MessageRecordingFileCount:               10,
+	MessageRecordingFileSize:                104857600,
 	MinCatchpointFileDownloadBytesPerSecond: 20480,
 	NetAddress:                              "",
 	NetworkMessageTraceServer:               "",
@@ -88,8 +107,12 @@ var defaultLocal = Local{
 	OptimizeAccountsDatabaseOnStartup:       false,
 	OutgoingMessageFilterBucketCount:        3,
 	OutgoingMessageFilterBucketSize:         128,
+	ParallelBlockEvaluationWorkers:          0,
 	ParticipationKeysRefreshInterval:        60000000000,
+	PeerBanDurationSeconds:                  3600,
+	PeerBanPenaltyThreshold:                 0,
 	PeerConnectionsUpdateInterval:           3600,
+	PeerIdentityAllowlist:                   map[string]bool{},
 	PeerPingPeriodSeconds:                   0,
 	PriorityPeers:                           map[string]bool{},
 	PublicAddress:                           "",
@@ -110,4 +133,5 @@ var defaultLocal = Local{
 	TxSyncTimeoutSeconds:                    30,
 	UseXForwardedForAddressField:            "",
 	VerifiedTranscationsCacheSize:           30000,
+	WebhookMaxDeliveryAttempts:              20,
 }
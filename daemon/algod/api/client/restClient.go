@@ -344,6 +344,10 @@ type appsParams struct {
 	Max    uint64 `url:"max"`
 }
 
+type feeEstimateParams struct {
+	TargetRounds uint64 `url:"target-rounds"`
+}
+
 type rawblockParams struct {
 	Raw uint64 `url:"raw"`
 }
@@ -446,6 +450,12 @@ func (client RestClient) SuggestedParams() (response v1.TransactionParams, err e
 	return
 }
 
+// TransactionFeeEstimate gets the fee per byte suggested for a new transaction to be committed within targetRounds rounds
+func (client RestClient) TransactionFeeEstimate(targetRounds uint64) (response generatedV2.TransactionFeeEstimateResponse, err error) {
+	err = client.get(&response, "/v2/transactions/fee-estimate", feeEstimateParams{TargetRounds: targetRounds})
+	return
+}
+
 // SendRawTransaction gets a SignedTxn and broadcasts it to the network
 func (client RestClient) SendRawTransaction(txn transactions.SignedTxn) (response v1.TransactionID, err error) {
 	err = client.post(&response, "/v1/transactions", protocol.Encode(&txn))
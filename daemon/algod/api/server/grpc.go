@@ -0,0 +1,147 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/algorand/go-algorand/daemon/algod/api/server/lib/middlewares"
+	"github.com/algorand/go-algorand/daemon/algod/api/server/v2"
+	"github.com/algorand/go-algorand/daemon/algod/api/server/v2/generated/rpc"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/util/tokens"
+)
+
+// grpcTokenMetadata is the metadata key holding the API token of gRPC requests. gRPC metadata keys are lower case.
+var grpcTokenMetadata = strings.ToLower(TokenHeader)
+
+// grpcRoutes maps the gRPC methods to the REST API routes serving the same operations, so that the scoped tokens are
+// permitted to call them with the same scopes.
+var grpcRoutes = map[string]struct {
+	method string
+	path   string
+}{
+	"/algod.Algod/GetStatus":                     {http.MethodGet, "/v2/status"},
+	"/algod.Algod/WaitForBlock":                  {http.MethodGet, "/v2/status/wait-for-block-after/:round"},
+	"/algod.Algod/AccountInformation":            {http.MethodGet, "/v2/accounts/:address"},
+	"/algod.Algod/GetBlock":                      {http.MethodGet, "/v2/blocks/:round"},
+	"/algod.Algod/SubmitTransactions":            {http.MethodPost, "/v2/transactions"},
+	"/algod.Algod/PendingTransactionInformation": {http.MethodGet, "/v2/transactions/pending/:txid"},
+	"/algod.Algod/GetPendingTransactions":        {http.MethodGet, "/v2/transactions/pending"},
+	"/algod.Algod/TealDryrun":                    {http.MethodPost, "/v2/teal/dryrun"},
+	"/algod.Algod/TealCompile":                   {http.MethodPost, "/v2/teal/compile"},
+}
+
+// grpcAuth checks that the gRPC requests carry one of the API tokens, or a scoped token permitted to access the REST
+// API route serving the same operation.
+type grpcAuth struct {
+	tokens       [][]byte
+	scopedTokens *middlewares.ScopedTokens
+}
+
+// authorize authorizes a call of the given gRPC method. On success, the returned release function needs to be called
+// once the call is served.
+func (auth *grpcAuth) authorize(ctx context.Context, fullMethod string) (release func(), err error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var providedToken []byte
+	if values := md.Get(grpcTokenMetadata); len(values) > 0 {
+		providedToken = []byte(values[0])
+	} else if values := md.Get("authorization"); len(values) > 0 {
+		// Accept tokens provided in a bearer token format.
+		authentication := strings.SplitN(values[0], " ", 2)
+		if len(authentication) == 2 && strings.EqualFold("Bearer", authentication[0]) {
+			providedToken = []byte(authentication[1])
+		}
+	}
+
+	// Check the tokens in constant time
+	for _, tokenBytes := range auth.tokens {
+		if subtle.ConstantTimeCompare(providedToken, tokenBytes) == 1 {
+			return func() {}, nil
+		}
+	}
+
+	if auth.scopedTokens == nil {
+		return nil, status.Error(codes.Unauthenticated, middlewares.InvalidTokenMessage)
+	}
+	route, ok := grpcRoutes[fullMethod]
+	if !ok {
+		// the scoped tokens are only permitted to call the methods known to serve a REST API route.
+		return nil, status.Error(codes.PermissionDenied, middlewares.ForbiddenScopeMessage)
+	}
+	release, err = auth.scopedTokens.Authorize(providedToken, routeScope(route.method, route.path))
+	switch err {
+	case nil:
+		return release, nil
+	case middlewares.ErrForbiddenScope:
+		return nil, status.Error(codes.PermissionDenied, middlewares.ForbiddenScopeMessage)
+	case middlewares.ErrTooManyRequests:
+		return nil, status.Error(codes.ResourceExhausted, middlewares.TooManyRequestsMessage)
+	default:
+		return nil, status.Error(codes.Unauthenticated, middlewares.InvalidTokenMessage)
+	}
+}
+
+func (auth *grpcAuth) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	release, err := auth.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return handler(ctx, req)
+}
+
+func (auth *grpcAuth) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	release, err := auth.authorize(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return handler(srv, ss)
+}
+
+// NewGRPCServer builds and returns a new gRPC server serving the V2 API operations, authenticated by the API tokens. The
+// scoped tokens, if not nil, are accepted as well, for the operations of the REST API endpoints they are permitted to
+// access.
+func NewGRPCServer(logger logging.Logger, node v2.NodeInterface, shutdown <-chan struct{}, apiToken string, adminAPIToken string, scopedTokens *middlewares.ScopedTokens) *grpc.Server {
+	if err := tokens.ValidateAPIToken(apiToken); err != nil {
+		logger.Errorf("Invalid apiToken was passed to NewGRPCServer ('%s'): %v", apiToken, err)
+	}
+	if err := tokens.ValidateAPIToken(adminAPIToken); err != nil {
+		logger.Errorf("Invalid adminAPIToken was passed to NewGRPCServer ('%s'): %v", adminAPIToken, err)
+	}
+	auth := &grpcAuth{
+		tokens:       [][]byte{[]byte(adminAPIToken), []byte(apiToken)},
+		scopedTokens: scopedTokens,
+	}
+
+	s := grpc.NewServer(grpc.UnaryInterceptor(auth.unary), grpc.StreamInterceptor(auth.stream))
+	rpc.RegisterAlgodServer(s, &v2.RPCHandlers{
+		Node:     node,
+		Log:      logger,
+		Shutdown: shutdown,
+	})
+	return s
+}
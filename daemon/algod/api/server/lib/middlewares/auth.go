@@ -35,6 +35,12 @@ const urlAuthFormatter = "/urlAuth/%s"
 // InvalidTokenMessage is the message set when an invalid / missing token is found.
 const InvalidTokenMessage = "Invalid API Token"
 
+// ForbiddenScopeMessage is the message set when a scoped token isn't permitted to access the requested endpoint.
+const ForbiddenScopeMessage = "API Token is not permitted to access this endpoint"
+
+// TooManyRequestsMessage is the message set when a scoped token exceeds its request rate or concurrency limit.
+const TooManyRequestsMessage = "API Token exceeded its request limit"
+
 // AuthMiddleware provides some data to the handler.
 type AuthMiddleware struct {
 	// Header is the token header which needs to be provided. For example 'X-Algod-API-Token'.
@@ -42,18 +48,32 @@ type AuthMiddleware struct {
 
 	// Tokens is the set of tokens which can be set to allow access.
 	tokens [][]byte
+
+	// scopedTokens, if set, are additional tokens which allow access to the endpoints of the scopes they are permitted to.
+	scopedTokens *ScopedTokens
+
+	// scopeOf returns the scope of the requested endpoint.
+	scopeOf func(ctx echo.Context) TokenScope
 }
 
 // MakeAuth constructs the auth middleware function
 func MakeAuth(header string, tokens []string) echo.MiddlewareFunc {
+	return MakeScopedAuth(header, tokens, nil, nil)
+}
+
+// MakeScopedAuth constructs the auth middleware function, which accepts the scoped tokens as well, as long as they are
+// permitted to access the scope of the requested endpoint, as returned by scopeOf.
+func MakeScopedAuth(header string, tokens []string, scopedTokens *ScopedTokens, scopeOf func(ctx echo.Context) TokenScope) echo.MiddlewareFunc {
 	apiTokenBytes := make([][]byte, 0)
 	for _, token := range tokens {
 		apiTokenBytes = append(apiTokenBytes, []byte(token))
 	}
 
 	auth := AuthMiddleware{
-		header: header,
-		tokens: apiTokenBytes,
+		header:       header,
+		tokens:       apiTokenBytes,
+		scopedTokens: scopedTokens,
+		scopeOf:      scopeOf,
 	}
 
 	return auth.handler
@@ -101,6 +121,19 @@ func (auth *AuthMiddleware) handler(next echo.HandlerFunc) echo.HandlerFunc {
 			}
 		}
 
+		if auth.scopedTokens != nil {
+			release, err := auth.scopedTokens.Authorize(providedToken, auth.scopeOf(ctx))
+			switch err {
+			case nil:
+				defer release()
+				return next(ctx)
+			case ErrForbiddenScope:
+				return echo.NewHTTPError(http.StatusForbidden, ForbiddenScopeMessage)
+			case ErrTooManyRequests:
+				return echo.NewHTTPError(http.StatusTooManyRequests, TooManyRequestsMessage)
+			}
+		}
+
 		return echo.NewHTTPError(http.StatusUnauthorized, InvalidTokenMessage)
 	}
 }
@@ -0,0 +1,347 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package middlewares
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/algorand/go-deadlock"
+
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/util/metrics"
+	"github.com/algorand/go-algorand/util/tokens"
+)
+
+// TokenScope is a group of REST API endpoints a scoped API token could be permitted to access.
+type TokenScope string
+
+const (
+	// ScopeRead permits the endpoints reading the node and ledger state.
+	ScopeRead TokenScope = "read"
+	// ScopeSubmit permits the endpoints submitting transactions to the network.
+	ScopeSubmit TokenScope = "submit"
+	// ScopeDryrun permits the endpoints compiling and dry-running TEAL programs.
+	ScopeDryrun TokenScope = "dryrun"
+	// ScopeAdmin permits the endpoints otherwise requiring the admin API token.
+	ScopeAdmin TokenScope = "admin"
+)
+
+var knownScopes = map[TokenScope]bool{
+	ScopeRead:   true,
+	ScopeSubmit: true,
+	ScopeDryrun: true,
+	ScopeAdmin:  true,
+}
+
+// ErrUnknownToken is returned by ScopedTokens.Authorize when the provided token isn't one of the scoped tokens.
+var ErrUnknownToken = errors.New(InvalidTokenMessage)
+
+// ErrForbiddenScope is returned by ScopedTokens.Authorize when the token isn't permitted to access the requested scope.
+var ErrForbiddenScope = errors.New(ForbiddenScopeMessage)
+
+// ErrTooManyRequests is returned by ScopedTokens.Authorize when the token exceeded its request rate or concurrency limit.
+var ErrTooManyRequests = errors.New(TooManyRequestsMessage)
+
+var restAPITokenRequests = metrics.MakeCounter(metrics.RestAPITokenRequests)
+var restAPITokenRejected = metrics.MakeCounter(metrics.RestAPITokenRejected)
+
+// ScopedTokenConfig is the definition of a single scoped API token, as stored in the scoped tokens file.
+type ScopedTokenConfig struct {
+	// Name identifies the token in logs and metrics.
+	Name string `json:"name"`
+	// Token is the API token itself.
+	Token string `json:"token"`
+	// Scopes are the endpoint groups the token is permitted to access.
+	Scopes []TokenScope `json:"scopes"`
+	// RequestsPerSecond is the sustained rate of requests the token is allowed to make. Zero means no limit.
+	RequestsPerSecond float64 `json:"requests-per-second,omitempty"`
+	// Burst is the number of requests the token is allowed to make at once, beyond the sustained rate.
+	// It defaults to the requests per second, rounded up.
+	Burst int `json:"burst,omitempty"`
+	// MaxConcurrent is the number of requests the token is allowed to have in flight. Zero means no limit.
+	MaxConcurrent int `json:"max-concurrent,omitempty"`
+}
+
+// ScopedTokensFile is the content of the scoped tokens file.
+type ScopedTokensFile struct {
+	Tokens []ScopedTokenConfig `json:"tokens"`
+}
+
+// tokenLimiter enforces the request rate and concurrency limits of a single scoped API token. It's carried over across
+// reloads of the scoped tokens file, as long as the token keeps its name.
+type tokenLimiter struct {
+	mu deadlock.Mutex
+	// available is the number of requests which could currently be made, up to the burst.
+	available  float64
+	lastRefill time.Time
+	rate       float64
+	burst      float64
+
+	// inFlight is the number of requests currently being served; accessed atomically.
+	inFlight      int32
+	maxConcurrent int32
+}
+
+// setLimits updates the limits enforced by the limiter.
+func (l *tokenLimiter) setLimits(cfg ScopedTokenConfig) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rate = cfg.RequestsPerSecond
+	l.burst = float64(cfg.Burst)
+	if l.burst <= 0 {
+		l.burst = math.Max(1, math.Ceil(cfg.RequestsPerSecond))
+	}
+	if l.lastRefill.IsZero() || l.available > l.burst {
+		l.available = l.burst
+	}
+	atomic.StoreInt32(&l.maxConcurrent, int32(cfg.MaxConcurrent))
+}
+
+// allow tests whether the request rate allows another request to be made at the given time, and accounts for it if it does.
+func (l *tokenLimiter) allow(now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.rate <= 0 {
+		return true
+	}
+	if !l.lastRefill.IsZero() {
+		l.available = math.Min(l.burst, l.available+now.Sub(l.lastRefill).Seconds()*l.rate)
+	}
+	l.lastRefill = now
+	if l.available < 1 {
+		return false
+	}
+	l.available--
+	return true
+}
+
+// acquire reserves one of the concurrent requests the token is allowed to have in flight. Every successful acquire needs
+// to be followed by a release once the request is served.
+func (l *tokenLimiter) acquire() bool {
+	inFlight := atomic.AddInt32(&l.inFlight, 1)
+	maxConcurrent := atomic.LoadInt32(&l.maxConcurrent)
+	if maxConcurrent > 0 && inFlight > maxConcurrent {
+		atomic.AddInt32(&l.inFlight, -1)
+		return false
+	}
+	return true
+}
+
+func (l *tokenLimiter) release() {
+	atomic.AddInt32(&l.inFlight, -1)
+}
+
+// scopedToken is a loaded scoped API token.
+type scopedToken struct {
+	name    string
+	token   []byte
+	scopes  map[TokenScope]bool
+	limiter *tokenLimiter
+}
+
+// ScopedTokens is the set of scoped API tokens defined by the scoped tokens file. The set is reloaded whenever the
+// file changes, without interrupting the requests being served.
+type ScopedTokens struct {
+	path string
+	log  logging.Logger
+
+	mu      deadlock.RWMutex
+	tokens  []*scopedToken
+	modTime time.Time
+	size    int64
+}
+
+// LoadScopedTokens loads the scoped API tokens defined by the file at the given path. A missing file defines no tokens.
+func LoadScopedTokens(path string, log logging.Logger) (*ScopedTokens, error) {
+	st := &ScopedTokens{
+		path: path,
+		log:  log,
+	}
+	err := st.Reload()
+	if err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+// Reload reads the scoped tokens file again. If the file is invalid, the previously loaded tokens remain in effect.
+func (st *ScopedTokens) Reload() error {
+	var file ScopedTokensFile
+	var modTime time.Time
+	var size int64
+	info, err := os.Stat(st.path)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return err
+	default:
+		modTime, size = info.ModTime(), info.Size()
+		data, err := ioutil.ReadFile(st.path)
+		if err != nil {
+			return err
+		}
+		err = json.Unmarshal(data, &file)
+		if err != nil {
+			return fmt.Errorf("unable to parse %s: %v", st.path, err)
+		}
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	limiters := make(map[string]*tokenLimiter, len(st.tokens))
+	for _, token := range st.tokens {
+		limiters[token.name] = token.limiter
+	}
+	loaded, err := makeScopedTokens(file, limiters)
+	if err != nil {
+		return fmt.Errorf("invalid scoped tokens file %s: %v", st.path, err)
+	}
+	st.tokens = loaded
+	st.modTime, st.size = modTime, size
+	return nil
+}
+
+// makeScopedTokens validates the scoped tokens definitions, and loads them, reusing the limiters of the existing tokens.
+func makeScopedTokens(file ScopedTokensFile, limiters map[string]*tokenLimiter) ([]*scopedToken, error) {
+	names := make(map[string]bool, len(file.Tokens))
+	values := make(map[string]bool, len(file.Tokens))
+	loaded := make([]*scopedToken, 0, len(file.Tokens))
+	for _, cfg := range file.Tokens {
+		if cfg.Name == "" {
+			return nil, fmt.Errorf("token has no name")
+		}
+		if names[cfg.Name] {
+			return nil, fmt.Errorf("token name %s is used more than once", cfg.Name)
+		}
+		names[cfg.Name] = true
+		err := tokens.ValidateAPIToken(cfg.Token)
+		if err != nil {
+			return nil, fmt.Errorf("token %s: %v", cfg.Name, err)
+		}
+		if values[cfg.Token] {
+			return nil, fmt.Errorf("token %s: the same token is defined more than once", cfg.Name)
+		}
+		values[cfg.Token] = true
+		if cfg.RequestsPerSecond < 0 || cfg.Burst < 0 || cfg.MaxConcurrent < 0 {
+			return nil, fmt.Errorf("token %s: limits must not be negative", cfg.Name)
+		}
+
+		token := &scopedToken{
+			name:   cfg.Name,
+			token:  []byte(cfg.Token),
+			scopes: make(map[TokenScope]bool, len(cfg.Scopes)),
+		}
+		for _, scope := range cfg.Scopes {
+			if !knownScopes[scope] {
+				return nil, fmt.Errorf("token %s: unknown scope '%s'", cfg.Name, scope)
+			}
+			token.scopes[scope] = true
+		}
+		loaded = append(loaded, token)
+	}
+
+	// the limits are only updated once the whole file is known to be valid.
+	for i, token := range loaded {
+		token.limiter = limiters[token.name]
+		if token.limiter == nil {
+			token.limiter = &tokenLimiter{}
+		}
+		token.limiter.setLimits(file.Tokens[i])
+	}
+	return loaded, nil
+}
+
+// changed tests whether the scoped tokens file was modified since it was last loaded.
+func (st *ScopedTokens) changed() bool {
+	var modTime time.Time
+	var size int64
+	info, err := os.Stat(st.path)
+	if err == nil {
+		modTime, size = info.ModTime(), info.Size()
+	}
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return !modTime.Equal(st.modTime) || size != st.size
+}
+
+// Watch reloads the scoped tokens file whenever it changes, checking it at the given interval, until stop is closed.
+func (st *ScopedTokens) Watch(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		if !st.changed() {
+			continue
+		}
+		err := st.Reload()
+		if err != nil {
+			st.log.Warnf("ScopedTokens.Watch: keeping the previous scoped API tokens: %v", err)
+			continue
+		}
+		st.log.Infof("ScopedTokens.Watch: reloaded the scoped API tokens from %s", st.path)
+	}
+}
+
+// lookup returns the scoped token matching the provided token, or nil if there is none.
+func (st *ScopedTokens) lookup(providedToken []byte) *scopedToken {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	var found *scopedToken
+	// compare against every token, so that the time taken doesn't reveal which of them matched.
+	for _, token := range st.tokens {
+		if subtle.ConstantTimeCompare(providedToken, token.token) == 1 {
+			found = token
+		}
+	}
+	return found
+}
+
+// Authorize checks that the provided token is one of the scoped tokens, that it is permitted to access the requested
+// scope and that it is within its limits. On success, the returned release function needs to be called once the request
+// is served.
+func (st *ScopedTokens) Authorize(providedToken []byte, scope TokenScope) (release func(), err error) {
+	token := st.lookup(providedToken)
+	if token == nil {
+		return nil, ErrUnknownToken
+	}
+	if !token.scopes[scope] {
+		restAPITokenRejected.Inc(map[string]string{"token": token.name, "reason": "scope"})
+		return nil, ErrForbiddenScope
+	}
+	if !token.limiter.allow(time.Now()) {
+		restAPITokenRejected.Inc(map[string]string{"token": token.name, "reason": "rate"})
+		return nil, ErrTooManyRequests
+	}
+	if !token.limiter.acquire() {
+		restAPITokenRejected.Inc(map[string]string{"token": token.name, "reason": "concurrency"})
+		return nil, ErrTooManyRequests
+	}
+	restAPITokenRequests.Inc(map[string]string{"token": token.name})
+	return token.limiter.release, nil
+}
@@ -0,0 +1,156 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package middlewares
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/logging"
+)
+
+var forbiddenScopeError = echo.NewHTTPError(http.StatusForbidden, ForbiddenScopeMessage)
+var tooManyRequestsError = echo.NewHTTPError(http.StatusTooManyRequests, TooManyRequestsMessage)
+
+func writeScopedTokensFile(t *testing.T, path string, file ScopedTokensFile) {
+	data, err := json.Marshal(file)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path, data, 0600))
+}
+
+func scopedTestRequest(handler echo.HandlerFunc, token string, path string) error {
+	req, _ := http.NewRequest("GET", "N/A", nil)
+	req.Header.Set(testAPIHeader, token)
+	ctx := e.NewContext(req, nil)
+	ctx.SetPath(path)
+	return handler(ctx)
+}
+
+func testScope(ctx echo.Context) TokenScope {
+	return TokenScope(strings.TrimPrefix(ctx.Path(), "/"))
+}
+
+func TestScopedAuth(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scopedtokens")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "algod.tokens.json")
+
+	readToken := strings.Repeat("r", 64)
+	submitToken := strings.Repeat("s", 64)
+	writeScopedTokensFile(t, path, ScopedTokensFile{Tokens: []ScopedTokenConfig{
+		{Name: "reader", Token: readToken, Scopes: []TokenScope{ScopeRead, ScopeDryrun}},
+		{Name: "submitter", Token: submitToken, Scopes: []TokenScope{ScopeSubmit}, RequestsPerSecond: 0.001, Burst: 2},
+	}})
+	scopedTokens, err := LoadScopedTokens(path, logging.TestingLog(t))
+	require.NoError(t, err)
+
+	handler := MakeScopedAuth(testAPIHeader, []string{"token1"}, scopedTokens, testScope)(success)
+
+	// the regular tokens are permitted to access every endpoint.
+	require.Equal(t, errSuccess, scopedTestRequest(handler, "token1", "/admin"))
+
+	require.Equal(t, errSuccess, scopedTestRequest(handler, readToken, "/read"))
+	require.Equal(t, errSuccess, scopedTestRequest(handler, readToken, "/dryrun"))
+	require.Equal(t, forbiddenScopeError, scopedTestRequest(handler, readToken, "/submit"))
+	require.Equal(t, forbiddenScopeError, scopedTestRequest(handler, readToken, "/admin"))
+	require.Equal(t, invalidTokenError, scopedTestRequest(handler, strings.Repeat("x", 64), "/read"))
+
+	// the submitter is allowed a burst of two requests.
+	require.Equal(t, errSuccess, scopedTestRequest(handler, submitToken, "/submit"))
+	require.Equal(t, errSuccess, scopedTestRequest(handler, submitToken, "/submit"))
+	require.Equal(t, tooManyRequestsError, scopedTestRequest(handler, submitToken, "/submit"))
+
+	// reloading the file replaces the tokens, and keeps the limiter state of the tokens which remain.
+	writeScopedTokensFile(t, path, ScopedTokensFile{Tokens: []ScopedTokenConfig{
+		{Name: "submitter", Token: submitToken, Scopes: []TokenScope{ScopeSubmit, ScopeRead}, RequestsPerSecond: 0.001, Burst: 2},
+	}})
+	require.NoError(t, scopedTokens.Reload())
+	require.Equal(t, invalidTokenError, scopedTestRequest(handler, readToken, "/read"))
+	require.Equal(t, tooManyRequestsError, scopedTestRequest(handler, submitToken, "/read"))
+
+	// an invalid file leaves the previous tokens in effect.
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"tokens": [{"name": "short", "token": "abc", "scopes": ["read"]}]}`), 0600))
+	require.Error(t, scopedTokens.Reload())
+	require.NotNil(t, scopedTokens.lookup([]byte(submitToken)))
+}
+
+func TestScopedAuthConcurrency(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scopedtokens")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "algod.tokens.json")
+
+	token := strings.Repeat("c", 64)
+	writeScopedTokensFile(t, path, ScopedTokensFile{Tokens: []ScopedTokenConfig{
+		{Name: "concurrent", Token: token, Scopes: []TokenScope{ScopeRead}, MaxConcurrent: 1},
+	}})
+	scopedTokens, err := LoadScopedTokens(path, logging.TestingLog(t))
+	require.NoError(t, err)
+
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	blocking := MakeScopedAuth(testAPIHeader, nil, scopedTokens, testScope)(func(ctx echo.Context) error {
+		close(started)
+		<-unblock
+		return errSuccess
+	})
+	handler := MakeScopedAuth(testAPIHeader, nil, scopedTokens, testScope)(success)
+
+	done := make(chan error)
+	go func() {
+		done <- scopedTestRequest(blocking, token, "/read")
+	}()
+	<-started
+	require.Equal(t, tooManyRequestsError, scopedTestRequest(handler, token, "/read"))
+	close(unblock)
+	require.Equal(t, errSuccess, <-done)
+	require.Equal(t, errSuccess, scopedTestRequest(handler, token, "/read"))
+}
+
+func TestScopedTokensWatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scopedtokens")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "algod.tokens.json")
+
+	// a missing file defines no tokens.
+	scopedTokens, err := LoadScopedTokens(path, logging.TestingLog(t))
+	require.NoError(t, err)
+	token := strings.Repeat("w", 64)
+	require.Nil(t, scopedTokens.lookup([]byte(token)))
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go scopedTokens.Watch(10*time.Millisecond, stop)
+
+	writeScopedTokensFile(t, path, ScopedTokensFile{Tokens: []ScopedTokenConfig{
+		{Name: "watched", Token: token, Scopes: []TokenScope{ScopeRead}},
+	}})
+	require.Eventually(t, func() bool {
+		return scopedTokens.lookup([]byte(token)) != nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -63,6 +63,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -102,16 +103,39 @@ func registerHandlers(router *echo.Echo, prefix string, routes lib.Routes, ctx l
 // TokenHeader is the header where we put the token.
 const TokenHeader = "X-Algo-API-Token"
 
-// NewRouter builds and returns a new router with our REST handlers registered.
-func NewRouter(logger logging.Logger, node *node.AlgorandFullNode, shutdown <-chan struct{}, apiToken string, adminAPIToken string, listener net.Listener) *echo.Echo {
+// apiScope returns the scope of the requested non-admin endpoint, which the scoped tokens need to be permitted to access.
+func apiScope(ctx echo.Context) middlewares.TokenScope {
+	return routeScope(ctx.Request().Method, ctx.Path())
+}
+
+// routeScope returns the scope of the non-admin endpoint registered for the given method and route path.
+func routeScope(method string, path string) middlewares.TokenScope {
+	switch {
+	case method == http.MethodPost && (path == "/v1/transactions" || path == "/v2/transactions"):
+		return middlewares.ScopeSubmit
+	case strings.HasPrefix(path, "/v2/teal/"):
+		return middlewares.ScopeDryrun
+	default:
+		return middlewares.ScopeRead
+	}
+}
+
+// adminScope returns the scope of the requested admin endpoint.
+func adminScope(ctx echo.Context) middlewares.TokenScope {
+	return middlewares.ScopeAdmin
+}
+
+// NewRouter builds and returns a new router with our REST handlers registered. The scoped tokens, if not nil, are accepted
+// in addition to the API tokens, for the endpoints they are permitted to access.
+func NewRouter(logger logging.Logger, node *node.AlgorandFullNode, shutdown <-chan struct{}, apiToken string, adminAPIToken string, scopedTokens *middlewares.ScopedTokens, listener net.Listener) *echo.Echo {
 	if err := tokens.ValidateAPIToken(apiToken); err != nil {
 		logger.Errorf("Invalid apiToken was passed to NewRouter ('%s'): %v", apiToken, err)
 	}
 	if err := tokens.ValidateAPIToken(adminAPIToken); err != nil {
 		logger.Errorf("Invalid adminAPIToken was passed to NewRouter ('%s'): %v", adminAPIToken, err)
 	}
-	adminAuthenticator := middlewares.MakeAuth(TokenHeader, []string{adminAPIToken})
-	apiAuthenticator := middlewares.MakeAuth(TokenHeader, []string{adminAPIToken, apiToken})
+	adminAuthenticator := middlewares.MakeScopedAuth(TokenHeader, []string{adminAPIToken}, scopedTokens, adminScope)
+	apiAuthenticator := middlewares.MakeScopedAuth(TokenHeader, []string{adminAPIToken, apiToken}, scopedTokens, apiScope)
 
 	e := echo.New()
 
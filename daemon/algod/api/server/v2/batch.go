@@ -0,0 +1,200 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package v2
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/algorand/go-algorand/daemon/algod/api/server/v2/generated"
+	"github.com/algorand/go-algorand/data"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/transactions"
+)
+
+// The types of the lookups of a batch request.
+const (
+	batchAccount            = "account"
+	batchAsset              = "asset"
+	batchApplication        = "application"
+	batchPendingTransaction = "pending-transaction"
+)
+
+// batchResult is the encoded form of the BatchResult, embedding the pending transaction response.
+type batchResult struct {
+	Account            *generated.Account          `codec:"account,omitempty"`
+	Application        *generated.Application      `codec:"application,omitempty"`
+	Asset              *generated.Asset            `codec:"asset,omitempty"`
+	Error              *string                     `codec:"error,omitempty"`
+	PendingTransaction *pendingTransactionResponse `codec:"pending-transaction,omitempty"`
+}
+
+// batchResponse is the encoded form of the BatchResponse.
+type batchResponse struct {
+	Results []batchResult `codec:"results"`
+	Round   basics.Round  `codec:"round"`
+}
+
+// validateBatchRequestItem checks that the field identifying the looked up object is set.
+func validateBatchRequestItem(item generated.BatchRequestItem) error {
+	var field string
+	switch item.Type {
+	case batchAccount:
+		if item.Address != nil {
+			return nil
+		}
+		field = "address"
+	case batchAsset:
+		if item.AssetId != nil {
+			return nil
+		}
+		field = "asset-id"
+	case batchApplication:
+		if item.ApplicationId != nil {
+			return nil
+		}
+		field = "application-id"
+	case batchPendingTransaction:
+		if item.Txid != nil {
+			return nil
+		}
+		field = "txid"
+	default:
+		return fmt.Errorf(errInvalidBatchRequestType, item.Type)
+	}
+	return fmt.Errorf(errMissingBatchRequestField, field, item.Type)
+}
+
+// lookupBatchItem executes a lookup of a batch request. The account, asset and application lookups are made against
+// the round rnd.
+func (v2 *Handlers) lookupBatchItem(l *data.Ledger, rnd basics.Round, item generated.BatchRequestItem) (result batchResult) {
+	var err error
+	switch item.Type {
+	case batchAccount:
+		result.Account, err = v2.lookupBatchAccount(l, rnd, *item.Address)
+	case batchAsset:
+		result.Asset, err = v2.lookupBatchAsset(l, rnd, basics.AssetIndex(*item.AssetId))
+	case batchApplication:
+		result.Application, err = v2.lookupBatchApplication(l, rnd, basics.AppIndex(*item.ApplicationId))
+	case batchPendingTransaction:
+		result.PendingTransaction, err = v2.lookupBatchPendingTransaction(l, *item.Txid)
+	}
+	if err != nil {
+		msg := err.Error()
+		result.Error = &msg
+	}
+	return
+}
+
+// batchLookupError logs the internal error of a lookup, returning the error reported to the client.
+func (v2 *Handlers) batchLookupError(internal error, external string) error {
+	v2.Log.Info(internal)
+	return errors.New(external)
+}
+
+func (v2 *Handlers) lookupBatchAccount(l *data.Ledger, rnd basics.Round, address string) (*generated.Account, error) {
+	addr, err := basics.UnmarshalChecksumAddress(address)
+	if err != nil {
+		return nil, v2.batchLookupError(err, errFailedToParseAddress)
+	}
+
+	record, err := l.Lookup(rnd, addr)
+	if err != nil {
+		return nil, v2.batchLookupError(err, errFailedLookingUpLedger)
+	}
+	recordWithoutPendingRewards, _, err := l.LookupWithoutRewards(rnd, addr)
+	if err != nil {
+		return nil, v2.batchLookupError(err, errFailedLookingUpLedger)
+	}
+
+	assetsCreators := make(map[basics.AssetIndex]string, len(record.Assets))
+	for curid := range record.Assets {
+		// Asset may have been deleted, so we can no longer fetch the creator
+		var creator string
+		creatorAddr, ok, err := l.GetCreatorForRound(rnd, basics.CreatableIndex(curid), basics.AssetCreatable)
+		if err == nil && ok {
+			creator = creatorAddr.String()
+		}
+		assetsCreators[curid] = creator
+	}
+
+	account, err := AccountDataToAccount(address, &record, assetsCreators, rnd, recordWithoutPendingRewards.MicroAlgos)
+	if err != nil {
+		return nil, v2.batchLookupError(err, errInternalFailure)
+	}
+	return &account, nil
+}
+
+func (v2 *Handlers) lookupBatchAsset(l *data.Ledger, rnd basics.Round, assetIdx basics.AssetIndex) (*generated.Asset, error) {
+	creator, ok, err := l.GetCreatorForRound(rnd, basics.CreatableIndex(assetIdx), basics.AssetCreatable)
+	if err != nil {
+		return nil, v2.batchLookupError(err, errFailedLookingUpLedger)
+	}
+	if !ok {
+		return nil, errors.New(errAssetDoesNotExist)
+	}
+
+	record, _, err := l.LookupWithoutRewards(rnd, creator)
+	if err != nil {
+		return nil, v2.batchLookupError(err, errFailedLookingUpLedger)
+	}
+	assetParams, ok := record.AssetParams[assetIdx]
+	if !ok {
+		return nil, errors.New(errAssetDoesNotExist)
+	}
+
+	asset := AssetParamsToAsset(creator.String(), assetIdx, &assetParams)
+	return &asset, nil
+}
+
+func (v2 *Handlers) lookupBatchApplication(l *data.Ledger, rnd basics.Round, appIdx basics.AppIndex) (*generated.Application, error) {
+	creator, ok, err := l.GetCreatorForRound(rnd, basics.CreatableIndex(appIdx), basics.AppCreatable)
+	if err != nil {
+		return nil, v2.batchLookupError(err, errFailedLookingUpLedger)
+	}
+	if !ok {
+		return nil, errors.New(errAppDoesNotExist)
+	}
+
+	record, _, err := l.LookupWithoutRewards(rnd, creator)
+	if err != nil {
+		return nil, v2.batchLookupError(err, errFailedLookingUpLedger)
+	}
+	appParams, ok := record.AppParams[appIdx]
+	if !ok {
+		return nil, errors.New(errAppDoesNotExist)
+	}
+
+	app := AppParamsToApplication(creator.String(), appIdx, &appParams)
+	return &app, nil
+}
+
+// lookupBatchPendingTransaction looks up a transaction of the transaction pool, or of the recently confirmed rounds.
+// Unlike the other lookups, it isn't bound to the round of the batch.
+func (v2 *Handlers) lookupBatchPendingTransaction(l *data.Ledger, txid string) (*pendingTransactionResponse, error) {
+	txID := transactions.Txid{}
+	if err := txID.UnmarshalText([]byte(txid)); err != nil {
+		return nil, v2.batchLookupError(err, errNoTxnSpecified)
+	}
+
+	txn, ok := v2.Node.GetPendingTransaction(txID)
+	if !ok {
+		return nil, errors.New(errTransactionNotFound)
+	}
+	response := makePendingTransactionResponse(txn, l)
+	return &response, nil
+}
@@ -18,11 +18,13 @@ package v2
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/algorand/go-algorand/config"
 	"github.com/algorand/go-algorand/daemon/algod/api/server/v2/generated"
+	"github.com/algorand/go-algorand/data"
 	"github.com/algorand/go-algorand/data/basics"
 	"github.com/algorand/go-algorand/data/bookkeeping"
 	"github.com/algorand/go-algorand/data/transactions"
@@ -58,6 +60,40 @@ type DryrunRequest struct {
 	Sources []generated.DryrunSource `codec:"sources"`
 }
 
+// errUnsupportedDryrunProtocol is returned when a dry-run request asks for an unknown protocol version.
+var errUnsupportedDryrunProtocol = errors.New("unsupported protocol version")
+
+// setDryrunDefaults defaults the protocol version, round and timestamp the dry-run request leaves unset to the ones
+// following the latest block.
+func setDryrunDefaults(dr *DryrunRequest, l *data.Ledger) error {
+	// fetch previous block header just once to prevent racing with network
+	var hdr bookkeeping.BlockHeader
+	if dr.ProtocolVersion == "" || dr.Round == 0 || dr.LatestTimestamp == 0 {
+		var err error
+		hdr, err = l.BlockHdr(l.Latest())
+		if err != nil {
+			return err
+		}
+	}
+
+	if dr.ProtocolVersion != "" {
+		if _, ok := config.Consensus[protocol.ConsensusVersion(dr.ProtocolVersion)]; !ok {
+			return errUnsupportedDryrunProtocol
+		}
+	} else {
+		dr.ProtocolVersion = string(hdr.CurrentProtocol)
+	}
+
+	if dr.Round == 0 {
+		dr.Round = uint64(hdr.Round + 1)
+	}
+
+	if dr.LatestTimestamp == 0 {
+		dr.LatestTimestamp = hdr.TimeStamp
+	}
+	return nil
+}
+
 // DryrunRequestFromGenerated converts generated.DryrunRequest to DryrunRequest field by fields
 // and re-types Txns []transactions.SignedTxn
 func DryrunRequestFromGenerated(gdr *generated.DryrunRequest) (dr DryrunRequest, err error) {
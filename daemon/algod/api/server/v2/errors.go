@@ -38,4 +38,18 @@ var (
 	errFailedToAbortCatchup                    = "failed to abort catchup : %v"
 	errFailedToStartCatchup                    = "failed to start catchup : %v"
 	errOperationNotAvailableDuringCatchup      = "operation not available during catchup"
+	errInvalidTargetRounds                     = "target-rounds must be between 1 and %d"
+	errFailedToParseParticipationID            = "failed to parse the participation key id"
+	errInvalidValidityRange                    = "last-valid must not precede first-valid, nor exceed it by more than %d rounds"
+	errIndexerNotActive                        = "indexer isn't running, set IsIndexerActive on an archival node to enable this call"
+	errFailedGettingInformationFromIndexer     = "failed retrieving information from the indexer"
+	errFailedToParseNextToken                  = "failed to parse the next token"
+	errInvalidLimit                            = "limit must not exceed %d"
+	errInvalidTxType                           = "invalid tx-type: %s"
+	errInvalidNotePrefix                       = "note-prefix must be base64 encoded, and no longer than %d bytes"
+	errWebhooksNotActive                       = "webhooks aren't enabled, set EnableWebhooks to enable this call"
+	errBatchRequestsNotActive                  = "batch requests aren't enabled, set MaxBatchRequestItems to enable this call"
+	errTooManyBatchRequestItems                = "a batch must not hold more than %d requests"
+	errInvalidBatchRequestType                 = "invalid request type: %s"
+	errMissingBatchRequestField                = "%s must be set for %s requests"
 )
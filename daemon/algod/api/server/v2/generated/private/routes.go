@@ -23,12 +23,45 @@ type ServerInterface interface {
 	// Starts a catchpoint catchup.
 	// (POST /v2/catchup/{catchpoint})
 	StartCatchup(ctx echo.Context, catchpoint string) error
+	// Return a list of participation keys
+	// (GET /v2/participation)
+	GetParticipationKeys(ctx echo.Context) error
+	// Add a participation key to the node
+	// (POST /v2/participation)
+	AddParticipationKey(ctx echo.Context) error
+	// Delete a participation key
+	// (DELETE /v2/participation/{participation-id})
+	DeleteParticipationKeyByID(ctx echo.Context, participationId string) error
+	// Get the registration transaction of a participation key
+	// (GET /v2/participation/{participation-id}/registration)
+	GetParticipationKeyRegistration(ctx echo.Context, participationId string, params GetParticipationKeyRegistrationParams) error
+	// Return a list of the connected peers
+	// (GET /v2/peers)
+	GetPeers(ctx echo.Context) error
+	// Disconnect a peer
+	// (DELETE /v2/peers/{peer-address})
+	DisconnectPeer(ctx echo.Context, peerAddress string) error
+	// Ban a peer
+	// (POST /v2/peers/{peer-address}/ban)
+	BanPeer(ctx echo.Context, peerAddress string, params BanPeerParams) error
+	// Add a phonebook entry
+	// (POST /v2/phonebook)
+	AddPhonebookEntry(ctx echo.Context) error
 
 	// (POST /v2/register-participation-keys/{address})
 	RegisterParticipationKeys(ctx echo.Context, address string, params RegisterParticipationKeysParams) error
 
 	// (POST /v2/shutdown)
 	ShutdownNode(ctx echo.Context, params ShutdownNodeParams) error
+	// Return a list of webhook watchers
+	// (GET /v2/webhooks)
+	GetWebhooks(ctx echo.Context) error
+	// Register a webhook watcher
+	// (POST /v2/webhooks)
+	AddWebhook(ctx echo.Context) error
+	// Unregister a webhook watcher
+	// (DELETE /v2/webhooks/{webhook-id})
+	DeleteWebhook(ctx echo.Context, webhookId uint64) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -96,6 +129,266 @@ func (w *ServerInterfaceWrapper) StartCatchup(ctx echo.Context) error {
 	return err
 }
 
+// GetParticipationKeys converts echo context to params.
+func (w *ServerInterfaceWrapper) GetParticipationKeys(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetParticipationKeys(ctx)
+	return err
+}
+
+// AddParticipationKey converts echo context to params.
+func (w *ServerInterfaceWrapper) AddParticipationKey(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.AddParticipationKey(ctx)
+	return err
+}
+
+// DeleteParticipationKeyByID converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteParticipationKeyByID(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "participation-id" -------------
+	var participationId string
+
+	err = runtime.BindStyledParameter("simple", false, "participation-id", ctx.Param("participation-id"), &participationId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter participation-id: %s", err))
+	}
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.DeleteParticipationKeyByID(ctx, participationId)
+	return err
+}
+
+// GetParticipationKeyRegistration converts echo context to params.
+func (w *ServerInterfaceWrapper) GetParticipationKeyRegistration(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":      true,
+		"fee":         true,
+		"first-valid": true,
+		"last-valid":  true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "participation-id" -------------
+	var participationId string
+
+	err = runtime.BindStyledParameter("simple", false, "participation-id", ctx.Param("participation-id"), &participationId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter participation-id: %s", err))
+	}
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetParticipationKeyRegistrationParams
+	// ------------- Optional query parameter "fee" -------------
+	if paramValue := ctx.QueryParam("fee"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "fee", ctx.QueryParams(), &params.Fee)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter fee: %s", err))
+	}
+
+	// ------------- Optional query parameter "first-valid" -------------
+	if paramValue := ctx.QueryParam("first-valid"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "first-valid", ctx.QueryParams(), &params.FirstValid)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter first-valid: %s", err))
+	}
+
+	// ------------- Optional query parameter "last-valid" -------------
+	if paramValue := ctx.QueryParam("last-valid"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "last-valid", ctx.QueryParams(), &params.LastValid)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter last-valid: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetParticipationKeyRegistration(ctx, participationId, params)
+	return err
+}
+
+// GetPeers converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPeers(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetPeers(ctx)
+	return err
+}
+
+// DisconnectPeer converts echo context to params.
+func (w *ServerInterfaceWrapper) DisconnectPeer(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "peer-address" -------------
+	var peerAddress string
+
+	err = runtime.BindStyledParameter("simple", false, "peer-address", ctx.Param("peer-address"), &peerAddress)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter peer-address: %s", err))
+	}
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.DisconnectPeer(ctx, peerAddress)
+	return err
+}
+
+// BanPeer converts echo context to params.
+func (w *ServerInterfaceWrapper) BanPeer(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":   true,
+		"duration": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "peer-address" -------------
+	var peerAddress string
+
+	err = runtime.BindStyledParameter("simple", false, "peer-address", ctx.Param("peer-address"), &peerAddress)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter peer-address: %s", err))
+	}
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params BanPeerParams
+	// ------------- Optional query parameter "duration" -------------
+	if paramValue := ctx.QueryParam("duration"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "duration", ctx.QueryParams(), &params.Duration)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter duration: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.BanPeer(ctx, peerAddress, params)
+	return err
+}
+
+// AddPhonebookEntry converts echo context to params.
+func (w *ServerInterfaceWrapper) AddPhonebookEntry(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.AddPhonebookEntry(ctx)
+	return err
+}
+
 // RegisterParticipationKeys converts echo context to params.
 func (w *ServerInterfaceWrapper) RegisterParticipationKeys(ctx echo.Context) error {
 
@@ -208,6 +501,82 @@ func (w *ServerInterfaceWrapper) ShutdownNode(ctx echo.Context) error {
 	return err
 }
 
+// GetWebhooks converts echo context to params.
+func (w *ServerInterfaceWrapper) GetWebhooks(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetWebhooks(ctx)
+	return err
+}
+
+// AddWebhook converts echo context to params.
+func (w *ServerInterfaceWrapper) AddWebhook(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.AddWebhook(ctx)
+	return err
+}
+
+// DeleteWebhook converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteWebhook(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "webhook-id" -------------
+	var webhookId uint64
+
+	err = runtime.BindStyledParameter("simple", false, "webhook-id", ctx.Param("webhook-id"), &webhookId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter webhook-id: %s", err))
+	}
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.DeleteWebhook(ctx, webhookId)
+	return err
+}
+
 // RegisterHandlers adds each server route to the EchoRouter.
 func RegisterHandlers(router interface {
 	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
@@ -227,143 +596,217 @@ func RegisterHandlers(router interface {
 
 	router.DELETE("/v2/catchup/:catchpoint", wrapper.AbortCatchup, m...)
 	router.POST("/v2/catchup/:catchpoint", wrapper.StartCatchup, m...)
+	router.GET("/v2/participation", wrapper.GetParticipationKeys, m...)
+	router.POST("/v2/participation", wrapper.AddParticipationKey, m...)
+	router.DELETE("/v2/participation/:participation-id", wrapper.DeleteParticipationKeyByID, m...)
+	router.GET("/v2/participation/:participation-id/registration", wrapper.GetParticipationKeyRegistration, m...)
+	router.GET("/v2/peers", wrapper.GetPeers, m...)
+	router.DELETE("/v2/peers/:peer-address", wrapper.DisconnectPeer, m...)
+	router.POST("/v2/peers/:peer-address/ban", wrapper.BanPeer, m...)
+	router.POST("/v2/phonebook", wrapper.AddPhonebookEntry, m...)
 	router.POST("/v2/register-participation-keys/:address", wrapper.RegisterParticipationKeys, m...)
 	router.POST("/v2/shutdown", wrapper.ShutdownNode, m...)
+	router.GET("/v2/webhooks", wrapper.GetWebhooks, m...)
+	router.POST("/v2/webhooks", wrapper.AddWebhook, m...)
+	router.DELETE("/v2/webhooks/:webhook-id", wrapper.DeleteWebhook, m...)
 
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+x9/XfbtrLgv4LVe+fkY0XJ+eq98Tk9b32TtNfbNM2Jfbu7L862EDmSUJMAS4C21Kz/",
-	"9z0zAEiQBCXZ8ctuz7s/JRZBYDDfmBkMP09SVZRKgjR6cvx5UvKKF2Cgor94mqpamkRk+FcGOq1EaYSS",
-	"k2P/jGlTCbmaTCcCfy25WU+mE8kLaMfg+9NJBb/XooJscmyqGqYTna6h4Dix2ZY4uplpk6xU4qY4sVOc",
-	"vp7c7HjAs6wCrYdQ/iTzLRMyzesMmKm41DzFR5pdC7NmZi00cy8zIZmSwNSSmXVnMFsKyDM985v8vYZq",
-	"G+zSLT6+pZsWxKRSOQzhfKWKhZDgoYIGqIYgzCiWwZIGrblhuALC6gcaxTTwKl2zpar2gGqBCOEFWReT",
-	"448TDTKDiqiVgrii/y4rgD8gMbxagZl8msY2tzRQJUYUka2dOuxXoOvcaEZjaY8rcQWS4Vsz9mOtDVsA",
-	"45J9+O4Ve/bs2UvcSMGNgcwx2eiu2tXDPdnXJ8eTjBvwj4e8xvOVqrjMkmb8h+9e0fpnboOHjuJaQ1xY",
-	"TvAJO309tgH/YoSFhDSwIjp0uB/fiAhF+/MClqqCA2liB98rUcL1/59SJeUmXZdKSBOhC6OnzD6O6rDg",
-	"9V06rAGgM75ETFU46cej5OWnz0+mT45u/uXjSfLv7s8Xz24O3P6rZt49GIgOTOuqApluk1UFnKRlzeUQ",
-	"Hx8cP+i1qvOMrfkVEZ8XpOrduwzftarziuc18olIK3WSr5Rm3LFRBkte54b5hVktc1RTOJvjdiY0Kyt1",
-	"JTLIpqh9r9ciXbOUazsFjWPXIs+RB2sN2RivxXe3Q5huQpQgXHfCB23o/19ktPvagwnYkDZI0lxpSIza",
-	"Y568xeEyY6FBaW2Vvp2xYudrYLQ4PrDGlnAnkafzfMsM0TVjXDPOvGmaMrFkW1WzayJOLi7pfbcbxFrB",
-	"EGlEnI4dReEdQ98AGRHkLZTKgUtCnpe7IcrkUqzqCjS7XoNZO5tXgS6V1MDU4jdIDZL9v5/99I6piv0I",
-	"WvMVvOfpJQOZqmycxm7RmAX/TSskeKFXJU8v4+Y6F4WIgPwj34iiLpisiwVUSC9vH4xiFZi6kmMA2Rn3",
-	"8FnBN8NFz6tapkTcdtmOo4asJHSZ8+2MnS5ZwTffHk0dOJrxPGclyEzIFTMbOeqk4dr7wUsqVcvsAB/G",
-	"IMECq6lLSMVSQMaaWXZA4pbZB4+Qt4On9awCcPwko+A0q+wBR8ImwjMouviElXwFAcvM2D+c5qKnRl2C",
-	"bBQcW2zpUVnBlVC1bl4agZGW3u1eS2UgKStYigiPnTl0oPawY5x6LZyDkyppuJCQoeYloJUBq4lGYQoW",
-	"3H2YGZroBdfwzfMxA94+PZD6S9Wn+k6KH0RtGpRYkYzYRXzqBDbuNnXeP+DwF66txSqxPw8IKVbnaEqW",
-	"Iicz8xvSz6Oh1qQEOojwhkeLleSmruD4Qj7Gv1jCzgyXGa8y/KWwP/1Y50aciRX+lNuf3qqVSM/EagSZ",
-	"DazR0xS9Vth/cL64Ojab6KHhrVKXdRluKO2cShdbdvp6jMh2ztsy5klzlA1PFecbf9K47Rtm0xByBMhR",
-	"3JUcB17CtgKElqdL+mezJH7iy+oP/Kcs8xhOkYGdoaWggAsWfHC/4U8o8mDPBDiLSDkidU7m8/hzANC/",
-	"VrCcHE/+Zd5GSub2qZ67eXHFm+nkpJ3n/ldq37T76x1k2sdMSEsdGjq1Z8L7hwdnjUJCjmoPhr/lKr28",
-	"EwxlpUqojLB0XOA8Q0mh6dkaeAYVy7jhs/ZQZf2sEX6nF/9O79EpCaqIifuJ/sNzho9RCrnx7hu6rkKj",
-	"E6eCQFOGHp+1I3YlHECeqGKFdfIYOme3gvJVu7hV0I1G/ejQ8qk/W4Q6b6xfyegNvwncentqPFmo6m78",
-	"0mMEydqzMOM4a+P94s67lKWhdZk4/ET8aTugN1Ebfhyq1RBD/eljuOpg4czw/wAsaJz1PrDQnei+saCK",
-	"UuRwD/K65no93AQ6OM+esrO/n7x48vSXpy++QQtdVmpV8YIttgY0e+jsCtNmm8Oj4c5Iwde5ic/+zXN/",
-	"gurOuxdDBHAz9yESdQ6oGSzGmI0XIHSvq21Vy3tAIVSVqiI+L7GOUanKkyuotFCR8MV7N4K5EaiHrN/d",
-	"+91Cy665Zrg2HcdqmUE1i2Eez1lk0g0Uep+hsFOfb2SLGzchryq+HVDA7jeyO7fuITTpIt9795qVUCVm",
-	"I1kGi3oV2ii2rFTBOMvoRVKI71QGZ4abWt+DFmgna4FBQoQg8IWqDeNMqgwFGgfH9cNILJOCKBT7MaHK",
-	"MWtrfxaA3nHK69XaMHQrVYy07YsJTy1RErIVeuTo15zZ7Si7nI2T5RXwbMsWAJKphTtfuZMfbZJTWMb4",
-	"jIvTTi1YzZmgA1dZqRS0hixx6aW9oPlxlspmB54IcAK4WYVpxZa8uiOwRhme7wGUxsTAbdwJdygdQn3Y",
-	"8rsI2F88JCOv8IxpuQB9F5TuHAyMofBAnFxBRYez/1D6+UXuSr66HEmdOAt8LgoUXya5VBpSJTMdnSzn",
-	"2iT7xBYHddwE3EEgKTFJpYlHAgRvuTb2iC5kRi6jVTe0Dr1DS4wDPGpRcOafvTEZzp2inpS61o1l0XVZ",
-	"qspAFtuDhM2Otd7BpllLLYO5G/NlFKs17Jt5DEvB/A5ZdicWQdy4GFETwxpujsLxaAe2UVR2gGgRsQuQ",
-	"Mz8qwG4YPh4BBM8XzZvEOEL3OKeJWU8n2qiyRPkzSS2b98bQdGZHn5h/tGOHzMVNq9czBbi68TA5yK8t",
-	"Zm3iYM3Rt6OZWcEv0TaRp2ZjCUOYURgTLWQKyS7OR7E8w1GhCOwR0hEn2aUmg9V6wtHj3yjTjTLBHiqM",
-	"bXjEY39vI+DnbXToHpyW12C4yHXjmDRh9nYVisj3qyXQi6wgBWnyLfLqUlSFTWqROdP+N+v2ZG4Vm75p",
-	"xU9mrIJrXmV+xPC0FGwmETKDTVy78k5sJIMNE3Ggl83KwrDUp5xkOMEsKug2iZfmSgu5Smx2cJ9Ra5J6",
-	"DzSrpXAG7BoqB9cSKmd2jc+OJUb5DNouOHahwgVn7oIEfDW+rAXOUkvHkqj0AAWxEGmluM2NIlJ7G2QV",
-	"FByhoyydM/vja+5C9iv73KdqfYg85N34vJ5fRzVMw6LXayIWqto+EkOux6MtaBjbyCpXC54n6PBDkkFu",
-	"9obe8CABr2kk2muVDl/vgnxx8THPLi4+sbc4ls4WwC5hO6eMNUvXXK6gTSOE8mJPDbCBtA5NSw+NBx0E",
-	"Xay0C333KDidlErlSXPk7ac9Buamj/dLkV5CxlBfkYg5K/igSyFchD1EFtdNYuh6vfUuZFmChOzRjLET",
-	"yaAozdbFV3oeT29x+cDsWn9Dq2Y15ai5ZLTJ2YWMhzZshvsLZcpPs1uSbMnXFy5lJ9m9kNnIEXHi15Sg",
-	"wemi8rkzOnpGbwamb2DRA6ayUBwSQ/ie6qB4h8oio+NIa910vSgEFUMFw6aoOX1+enjCF2bG2DnpDjxg",
-	"abiCiudU6aF94FhoVgg8qOs6TQGy4wuZdCBJVeEWftj+16qli/ro6Bmwo0f9d7RBd9WdJa0M9N/9lh1N",
-	"7SNCF/uWXUwuJoOZKijUFWT2PBbytX1r77T/pZn3Qv40UMys4Ft7kvOyyHS9XIpUWKTnCvX6SvW8Tqno",
-	"CVQIHqCZ1UyYKZkywih565YurQBOot7TfcR8IrOin46mFLWdz0p2eUcz2PAUd8lJyWytR9Dw2dAJMqpM",
-	"wgmiIegdK7okgO7o8TvK3VCf2wDEbvjOeyGIDjoCdp3t990HyIhCcIj4n7BSIdWFqz/yRSq50GYApAtH",
-	"UAaoYciI0Zmx/6VqlnKS37I20JztVEUHJjpI4wpkY/2azlNrMQQ5FGAjRPTk8eP+xh8/djQXmi3h2hft",
-	"4cA+Oh4/tkKgtPliCeix5uY04kBRYB6taaTQes31erY3SE/zHhSbD6Y+fe0XJGHSmkwMbrxSankPuxXZ",
-	"JuqzwCa2U0c5Crc90Kzk21H3ukQAI9VaUF3mFMtXyx5HMqf/1qLEKdvKkq2BTlXq/374b8cfT5J/58kf",
-	"R8nL/zr/9Pn5zaPHgx+f3nz77f/p/vTs5ttH//avMedFG7GI533+zvUaIXWaYyNPpc3coudJAbutiwOo",
-	"5deGu8diSEyP+WBLhzDd+xhBBLoSRGziubO6LPPtPRgZOxGrwJ0xdCc8qu1TtQyLUh3n6a02UAwzDPbV",
-	"X0ZOPx98dGLApUrmQkJSKAnb6D0MIeFHehj1DUktjbxMBmLs3X70pgN/D6zuOocQ80vxS9QO1ND7pkT2",
-	"Hojfn7eXXArLcelkA3nJOEtzQaFzJbWp6tRcSE7BuZ7r3WMLH3IcD9e+8kPi8eFI+NZNdSG5Rhw2Ibto",
-	"0nEJkWD8dwA+aqvr1Qp0zxVnS4AL6UYJSYEWWotOMoklWAkVZYdndiR6n0ueU3T5D6gUW9Sma+6patB6",
-	"0zbThcswtbyQ3LAcuDbsRyHPNzSdP1V7npFgrlV12WBhJCoAErTQSVyRfm+fkj5121873UpXOOxjr2++",
-	"tgHwsMdq2hzkp6+dK3z6mvydNsc1gP2rJT4KIZMok+ERtRCSSqN7vMUeotfmGehRmy1zVL+QZiORka54",
-	"LjJu7sYOfRU3kEUrHT2u6RCiF8f2e/0UO2KvVFLy9JLqTyYrYdb1YpaqYu6PAPOVao4D84xDoSQ9y+a8",
-	"FHNdQjq/erLHHfsCfcUi6upmOnFaR997pZubOLah/ppNBsn/bRR78P2bczZ3lNIPbIGrnTqoTIyc2tz9",
-	"yk4AATdvL2jZCl88QL+GpZACnx9fyIwbPl9wLVI9rzVUf+M5lynMVoodMzfla244xZ160fSxO5QUE3TQ",
-	"lPUiFym7DE1xK5pjwdiLi4/IIBcXnwb55qHhdEvFA9y0QHItzFrVJnEZifHYVRvfo5ltLHjXqlPm5rYc",
-	"6TIebv6RoHtZ6iSIwsa3X5Y5bj9gQ83oJapXZNqoyitB1Iwujob0fadcxr3i1/7WSK1Bs18LXn4U0nxi",
-	"iYv5nJQlhXgpxvqr0zXIk9sSDo/TtiC2k8XO9rRx61DBxlQ8KfkKdHT7BnhJ1CdDXVAULc8ZvdaJN/tq",
-	"LZqq3cDOuGIAx61raWlzZ/Ytn0CJb4EeEQlpDGqnNh5+V3rhVH9XOTLZnckVzBGlUm3WCcp2dFcaWdxT",
-	"prnYtUKd7PPfWqwkCoG7A7cAlq4hvYSMkn8UH592XvclFs7CedUhtL22Zktm6W4FhUIWwOoy484H4HLb",
-	"L3LXYIyv7P8Al7A9V+3VjNtUtd9MJy6hlSDPjAkqcWpgjJBZQ7H1SbEe8V1+k5JOZclsXsdWI3u2OG74",
-	"wr8zLsjWQt6DEMeYokHDDn4veRVBhGX+ERTcYaM43xexfjSLxCsjUlHa/R+Wl3rfeQcn2WdcouZELftW",
-	"Y6DUo0rMDk4WXMcNCOATpAfKUL+aya9ko4o2Uc2o9YFj3EUOQUZVO8nmFTldftv2LvcYaHEugUq2Vt2D",
-	"0cVI6D6sXWmAuGoLAijkc4ih3ZuQRS7yNTuim3oRuG4OV3w0CzZ65+g0KMQJrrI2N4q8YusLw7S5XWa7",
-	"SvibR/66kb9jNJne6r7QdOJqQ2PkUJK8jAxyWHGX9KGqU19wYEF7oAMCIRw/LZe5kMCSWE0P11qlwtYB",
-	"tLrcrQHohD5mzAZ42MEzxNg4AJui5TQxe6dC2ZSr2wApQVB4nfu5Kc4e/A37o81tew/n3u51Q4e6oxWi",
-	"aXv9zpJxGIWaTqIqaeyE0BnF7JAFDI5UMRZF1TSMywyjPxpyIHOcdDRrchmL1qFXAcSGZ/614NjAHool",
-	"GvlHQdKkgpXQBtpzM0qrDwR93djFlTKQLEWlTUJH9uj2cNB3mpzB73BoXP10UMVsfwCRxbUPLXsJ2yQT",
-	"eR2ntlv3h9e47Lvm/KTrxSVsycgAT9dsQf0s0Ap1lscxO5a2dW07N/zWbvgtv7f9HsZLOBQXrpQyvTX+",
-	"JFzV0ye7hCnCgDHmGFJtFKU71EtQiTPULcGZzNYLUW3RbFfUYCBMt65mGtW8dqboXgJHd+cubNGbrWsL",
-	"2kEM71iMyAAvS5Ftemd4O+tI2o4c+Fs46tbjj6SiJs1kezAQnNdjZbwV+JiDJWlgM21jj0Gp437M9Ass",
-	"A4UQLiW0b0s1RBSyNlWi7cPVOfD8B9j+jGNpO5Ob6eTLjvwxXLsZ9+D6fUPeKJ4plm2PgJ0I3i1Rzsuy",
-	"Ulc8T1xgZIw1K3XlWJOG+zjKV1Z18eP3+ZuTt+8d+FS5CbxyBYu7dkXjyj/NrvBEHKtaPA8iI+St+rOz",
-	"dcQC4jd3icNgii8y7fhyqMUcc1nxagNlgSi64MoynlLbGypxMT27xR2xPSib0F57IraRvW40j19xkfuj",
-	"qId2f1HsnbRCp6r2S6OCYYntvaqbgXTHpaPlrj06KVxrRxOUwvb50UzJfmERupB0wiVWLfgWOcgGp4fK",
-	"SdZFguKX6Fyk8bCFXGhkDmljvjiY0eARZxRnrMVICkHWIpgLh+kDsmU9IIM1osikkNIO3C2Ua9BYS/F7",
-	"DUxkIA0+qlyhYUdQUS597fzQnMbr9N3ErlS/mf5LfAycasy7ICB2OxhhhDlyS8QfOP1Gm9A4/hAEBm+R",
-	"qApXHJjEHUkmxx+Om222f92NFIf9FIf6DxnD9t7Z38zRhy3WFtCRNaLNGUetxcm4paD7F4fbiNYkELih",
-	"MbA1sTzXKjJNLa+5tL3W8D2LQ/e2BhszwLeuVUWXFjVEs/RCJ8tK/QHxk+wSCRWpfXSoJHeR3p5FLoP1",
-	"lWgTlWm7aHr8hnCMsvaYJxc8ZN1E4oiEE5cHoXMq5vYBLi4tW9u+cJ30dVw4wpKTuZ2/FQ4H86BMJ+fX",
-	"Cx5rkYIOFcJ00iZpOqE4o5h/2VNBN3cYHO8F+Z5mrLA3/Uqo2gLl4a3yOzpHfy6WzyAVBc/jXlJG2O9e",
-	"AcvEStjmerWGoHubm8h2JbVc5Drg2TRYi5rTJTuaBv0hHTUycSW0WORAI57YEQuuwd40C2+fucIoA9Ks",
-	"NQ1/esDwdS2zCjKz1haxWrHGgbWXinzsewHmGkCyIxr35CV7SFF/La7gEWLR+SKT4ycvqSzF/nEUM3au",
-	"i+YuvZKRYvkfTrHE+ZjSHnYONFJu1ln01qltfTyuwnZIk331EFmikU7r7Zelgku+gng2t9gDk32XqElB",
-	"wx5eZGb7dmpTqS0TJr4+GI76aaQ0DdWfBcPdUSlQgIxiWhXIT21rNruon842AXXtkjxc/iGlWEp/16h3",
-	"YP66AWJry2O7pkTYO15AF61Txu3lbLou5S71O4U4G+lrA9VVfJFqhMDebrp32UOpZFKg7GSP2qLHgP+i",
-	"bV2U4Xm8fMPrrn71zu6pD3W1cJZkFLF1B7E80El3RnFdxffJa1zqHx/eOsNQqCrWo6XVhs5IVGAqAVdR",
-	"ie0X7zWeSWMuPOZjDsrfapFnP7clt712aBWX6Toae13gi7+0/RsbtFusR6++rrmUkEens7L8i5f5iFb6",
-	"TR26TiHkgWP7bc7sdnubawHvgumB8gsieoXJcYEQq90axKZoJV+pjNE6bZOFlhGGdxODlk+/16BN7B4l",
-	"PbD1XnTGRn/FdhxiIDOy9jNm7x0iLJ2bY2RlRVHn9hYSZCuoXPCnLnPFsynDec7fnLxldlXt7tDTfTfq",
-	"eLSyd1g7u+idrYKOLLe51DtWHnb4PLvrVXDX2lBLBm14UcYqf3HEuR9A5cVhvInMT4idGXttLb/2dsUu",
-	"0t7dZs1yTtcQT+B/jOHpmkxqxwCNs/zhrbo8V+qgZW3T/bNpqmKvIxvlu3XZZl1TptDvuRbatt2GK+gW",
-	"GzeV986l88XH3e1VtZSWU+L2acfNkLug3QNnk4o+JBWFrIf4W5oZreoqhdt2Ljujt6J3G/tt0Aa9au0t",
-	"p6ZXpP+cQsqlkiKlm4VBo+8GZNfC+5B47QGXMPvHZS/iTkIjwhVtvtaULTgsjrZj84rQIW4YMAqeIlEt",
-	"d9g/DfWKxoPgCox2mg2yqW+w585xQmpwTXKom3ugJ/E43s9dRtMqbZuMW7IRlR6OuCvf4TNyVYQrF7oU",
-	"ki6NO7S5yiR70qIOwwaPd8KwlQLt9tO9Kqg/4jszui6XwebTzHckpjlsCBm3bfMlw6lOfPbEZStw7Csc",
-	"yyhc3P7cKXO0i56UpVs0etOvoXCsReAogiNR8MSHIQPkNvOHs+1gt51pT7KnyGhwRUkTKMkODxhjpPXE",
-	"GzzUWo6yN9htuUH0eoqQETDeCgltv+yIgUijJoEIQ/I68p5OK26sC3iQTjsHnlOmJKbQtHGhoy+dqkdg",
-	"Qgnt0a8xTsa2UeSI4mgGtI4bl9umTTdyd+BMvKLvAzhEDts+klflnKiMCsp6jSBjigMVt2+h2jUAQzEY",
-	"+kT2dVNxKzm3sURjhfiZ0HgcKRZ5pITmdfMwaIZKtXqLLf0bu/g/vgOXWLtzoxp68db+5e6mMTnSPtFi",
-	"dUeqtO/fI1l6MhDSKMb9b1CthHeXBj0crOJprhZR+YDyranpUNEUxXd5lhRd9NDWdhnefWgd7xc8JdU4",
-	"UkT0ob01y632tbHBsVKidLTyjRtX1mo429W9yTb5jc1g85C2ubD9UE80MDCWe7SpR3w8ePswv2HghdHc",
-	"OxHqk9pDgH7wFTOs5MIFvlsRGWLW1dYNqx0PqbppCdzfhKtYo0liO7ljgdlBsjfEUkSww9KAPex52UGp",
-	"vYnS8yRVBfeM2sCE3hK1w6KHQ7dH+yCOqTUM93kwATq4HcH9IYhv9cIQuePibBaHiHO8oB9fJ31iEeKv",
-	"nAy1yVfTBp3e5G7dGNV/Hose2BPySKCqh9Na5Nk+4nbCju2Vbgqs/bL45nknevc1L5X/YhPyQ3Fz92tv",
-	"Y/j7RCDERPbaWTxYKggoHhBLdK9FIofUgy2tK2G2VLvjPU3xS7Qm+nuQrkO7++BFkwF1CTj7rSUXml41",
-	"o9vP43yvbMv6At1fcgUNNSd6s+FFmYOTi28fLP4Cz/76PDt69uQvi78evThK4fmLl0dH/OVz/uTlsyfw",
-	"9K8vnh/Bk+U3LxdPs6fPny6eP33+zYuX6bPnTxbPv3n5lwf+2zQW0Pa7L/+TOi8kJ+9Pk3MEtsUJL8UP",
-	"sLV3rZGN/S1unpIkQsFFPjn2P/03L2GzVBXB5zTdrxMX6Z+sjSn18Xx+fX09C1+Zr6hdZmJUna7nfp1h",
-	"L6j3p02A1ib8iaI29oasQER1rHBCzz68OTtnJ+9PZy3DTI4nR7Oj2RNqllKC5KWYHE+e0U8kPWui+9wx",
-	"2+T48810Ml8Dz83a/VGAqUTqH+lrvlpBNXPX2fGnq6dzH9+Zf3ZJ7ptdz7pVBu7aSvBCcO9x/rnTbzUL",
-	"56VbgfPPvgIjeGT7ic8/U/ho9PcuGJ/NRmQ3c9/3yL3h+vLOP7eNsm+sdOQQO/n7Bn7tcGrMR98P0fZX",
-	"FAifVxS621e9oe5phlTFt141TcPDzyR//E/6UdFPvW8sPT06+k/2tZjnt9zxTn+2c/6L9Jr4G8+Yzy3R",
-	"2k++3tqnkm6ZoEJjVmHfTCcvvubuTyWyPM8ZjQyqQYak/4e8lOpa+pFoXeui4NXWi7HuKAX/KQDS4Xyl",
-	"qWFoJa7wUP+JOtLGknkjyoU+y3Nr5ULfGvqncvlayuXP8RGmp7cU8D//jv+pTv9s6vTMqrvD1alz5Wz5",
-	"wty2z2s9PH9jc3iNsevNjulkd9RhDylOKuH6kSuBsNNGrsQ26WaV2ZiIb6/kC6uCdvtdnf3BTdq5ff0D",
-	"bPU+BX6+Bvarmz4R2a9U/knJhylTFfuV53nwG7XJ8W77LK7v22uSe7+y2gpoDKwlgC9GpaJT13UYDdkl",
-	"+Au1FgedBOUwp98241vC6Je2bc+yUIM5FnxydHQUKwbqw+ziNxZiKv69VkkOV5APST0GRO9e7a7v0o5+",
-	"uWd4HTo8d0e4zn/GvbkhPfqZ3u4d39tA91rJB4Zdc+E+fhD01LGfciqE8V+wtkVCroCwsRHxrx4nOOXu",
-	"j6J/qfH+83URvtmh7PS6Npm6luOKi24X8dyV51LBbBNuMIr5CRpNNWP+k6T51n9Tm3EqV1K16X7q3rfK",
-	"6DVLb5o5rYSkBUjKaRVbh86DKk/36ZyhEjxzkL2zXxrq6b3oF38tjHG5jwn9l/LS0NHYSSvfWqXz9xxZ",
-	"Ht1V+yW1hDA0DGkY4PncFar0frXp5ODHbkP0yK/z5mpX9GE/UBN76uIoflAbIQ0jjkSpJtb48RMinGqI",
-	"HRHbANrxfE4p3LXSZj5BhdMNroUPPzU4/uwp73F98+nm/wYAAP//UWHRAXWKAAA=",
+	"H4sIAAAAAAAC/+y9a3cbN9Ig/Ffwcp5zkvglJfmSzFh7cp5VfMlox0l8LGVmd2NvAnYXSYyaQA+AFsV4",
+	"9d/3VAHoRnejyaaseJMdf7LFxqVQqCoU6ob3k0ytSyVBWjM5fT8pueZrsKDpL55lqpJ2JnL8KweTaVFa",
+	"oeTkNHxjxmohl5PpROCvJberyXQi+Romp3H/6UTDvyqhIZ+cWl3BdGKyFaw5Dmy3JbauR7qZLdXMD3Hm",
+	"hjh/Prnd8YHnuQZj+lD+IIstEzIrqhyY1VwanuEnwzbCrphdCcN8ZyYkUxKYWjC7ajVmCwFFbo7CIv9V",
+	"gd5Gq/STDy/ptgFxplUBfTifqfVcSAhQQQ1UvSHMKpbDghqtuGU4A8IaGlrFDHCdrdhC6T2gOiBieEFW",
+	"68npTxMDMgdNu5WBuKb/LjTArzCzXC/BTt5NU4tbWNAzK9aJpZ177GswVWENo7a0xqW4Bsmw1xH7rjKW",
+	"zYFxyd68fMYeP378FBey5tZC7olscFXN7PGaXPfJ6STnFsLnPq3xYqk0l/msbv/m5TOa/8IvcGwrXpaF",
+	"yDiuO8kyZ813dv58aDHtQRJEJaSFJegeQzQdPVMYA2nePcMvO0AIHXdM3pkbeyR4tPl5DgulYSSJuMb3",
+	"SiPx/P9XiSTjNluVSkib2BdGX5n7nBSpUfddIrUGoNW+RExpHPSnk9nTd+8fTh+e3P7pp7PZ//R/fvn4",
+	"duTyn9Xj7sFAsmFWaQ0y286WGjgx74rLPj7eeHowK1UVOVvxa9p8vqaTx/dl2NdJ8mteVEgnItPqrFgq",
+	"w7gnoxwWvCosCxOzShZgDI3mqZ0Jw0qtrkUO+ZQJyTYrka1Yxo0bgtqxjSgKpMHKQD5Ea+nV7eLkGCUI",
+	"153wQQv6/SKjWdceTMANSYNZVigDM6v2nJbhAOQyZ/H51hyd5rCzk12ugNHk+MGd/YQ7iTRdFFtmaV9z",
+	"xg3jLJyUUyYWbKsqtqHNKcQV9ferQaytGSKNNqd1rCPzDqGvh4wE8uZKFcAlIS/wXR9lciGWlQbDNiuw",
+	"K38EazClkgaYmv8TMovb/t8ufvieKc2+A2P4El7z7IqBzFQ+vMd+0pRC8U+jcMPXZlny7CqtPRRiLRIg",
+	"f8dvxLpaM1mt56Bxv8L5YBXTYCsthwByI+6hszW/6U96qSuZ0eY207b0RiQlYcqCb4/Y+YKt+c3XJ1MP",
+	"jmG8KFgJMhdyyeyNHNQZce794M20qmQ+QqWyuGHRqWlKyMRCQM7qUXZA4qfZB4+Qh8HTKHoROELuAUfI",
+	"ceBIuEnQDLIufmElX0JEMkfsRy+56KtVVyBrAcfmW/pUargWqjJ1pwEYaerd2r5UFmalhoVI0NiFRwdK",
+	"D9fGi9e1V3AyJS0XEnImpANaWXCSaBCmaMLdd6v+ET3nBr56Mrnd97Xk2opMlMMaLqJf5OEK1WrPrmB7",
+	"lNZpeuOOvywirksAPRu8AV6SlFsjAn2bGjxAoc0NWyljT0ul7RB88QSHwTaSYRaqyyg7mWQUg1CjmZNi",
+	"CVUCv3oZl151q/+IVcdzG7GcuZ97tC+Wl3j6LkRBJ/M/keQDGipDcrOFiHBWG7GU3FYaTt/KB/gXm7EL",
+	"y2XOdY6/rN1P31WFFRdiiT8V7qdXaimyC7EcQGYNa/I+TN3W7h8cL32CubuxE1wJGvy+OcGoRc/AIOhc",
+	"Ic5frwVdZ1C3EfKIPXfKGjV4OEQQ7fn3bI69SfLuK6WuqjLGftaCcb5l588HAbgZuC/uFDxnNU/Ft8bL",
+	"m3CTPLSHvampbgDIwY0uOTa8gq0GhJZnC/rnZkHEzxf614m74KcJYAPzlVJXI4Sib8k2eC8CPSByovEO",
+	"Yj3ke6/SER16K9kb/xv+hIcLuNtnZGs4JkXt9H00+H9oWExOJ386bkyEx+6rOfbj4oy30zDLZaQn3WnG",
+	"UqsStBUOdndzsENKB6K14MY6nmJC5nCDfLMCGVRb4psNaOKrsrJOg+2bUfBAn5FO0J/mRwM5yeeSL4Uk",
+	"yKdukjW/QnHFpSJ1GncJjA1ahdPzadDGqudVE6/7H7Fz+5lhKnC8h1yDv22wtdIQKyMduptOYs00YU6Q",
+	"jGvNt0h6Ger/eo0CtenjtX4aW1hYm/4mCGk1d1swU4uFgQGly31LGU+dIGMC7TqFyq7SW7Bjk+lTf1hu",
+	"okUJuWPYAasTjo1fjOXrMkBOIO6djNQGA5lCYb7Qas2gVNkqDYO9kenJ8Uhrb8iU8ULJZUMwObe8Jl5H",
+	"IchCWyQ8YaP53E4OyNkLmihiT6cg1GLlp1qjiPA1Te29W8277rS39Q9EcL3h26zcodv+aNi9axkLCj1i",
+	"JUnMpNdxybzTYcqM5doiomiDLIkLixyqJBwhyJHF1Euw+xBbfv4BVdSrkVBroaE5U6Ul2rLK/d5M2WLP",
+	"vgxoIX46Rmw2HJUUksGD8P+MoNxDjfWOHU6Jqd3jsr15bUq7/6O46ZmEt/nMhHQigZpOnXn+G15wmcF9",
+	"UP7cD5UmupUqctAN3ePkXXknNDUTctk+k3at/zshBa3kr67nJ564D56o9/Jwnog2OliUj2pyu3/yx1GT",
+	"QOGHLsl/g1r3PZC6xyv+dxSRhnmrwqbocxRd1gcbodTZuyPmLuj+5sl2zXNgfMmFNDZFtkPHv1/WmE2/",
+	"jFgFt5rNcY34Xw/JNFivlM69IXUF4SNRxDeobN2H4MFx+uij4dkKOE6PmtRYfYk6/pX6kfQAndB6f6D/",
+	"8ILhZ7wtcxvM6OhCEIYJw1QUfxDdTdxM2AB30iq2dsZ2hkbyg6B81kze21WHljF7+cLZ97326xeBS2+8",
+	"d2dzpe/Gvb1LSeOTZBxHrb0QuPLOHRCbVuXM4yfh13ANOgM1USl7pF5n+BSuWli4sPw3wAJpqveBhfZA",
+	"940FtS5FAffArytuVv1FoKH58SN28dezLx8++vnRl1+hyCi1Wmq+ZvOtBcM+9/YfZuy2gC9SV2InktKj",
+	"f/UkeLLa4+7FEAFcjz1KOgJKBocx1gj+53qrK3kPKAStlU7eB0qtrMpUMbsGbYRKaCWvfQvmW5D5kfwf",
+	"nd8dtHT7xbnJLVbJHHTaGHEjxx+IbujLGzl0KHZ2wK03sTo/75g9aSM/eFkMK9FFfyNZDvNqGWsM7ubI",
+	"WU4dSSB+r3K4sNxW5h6kQDNYAwxuRAwCn6vKMs6kypGhsXFaPgzElJAz2yl3scixK3f+zAHV0IxXy5Vl",
+	"aP5Vqa1tOs545jZlRmfFgK7f+E5dKzedi1coNPB8y+YAaH/yfi7vgaNFcnKP17YkL52SuncEV6lVBsZA",
+	"Ptt9AW9AC+0a+8AQnghwAriehRnFFlzfEVirLC/2AEptUuDW6oSQA1CPm37XBnYnj7eR073CUQGziqRc",
+	"ARaGUDgSJ9egyePzm+5fmOSu21eVA8ZEfwJfijWyL5NcKm8fTA6GhuvZPrbFRvFaDK4g4pQUp9LAA9eJ",
+	"V9zYN8FYTiqjYbZtRccphgEePFFw5L+Hw6Q/dqakAWkqU58spipLpS3kqTXQjXpwru/hpp5LLaKx6+PL",
+	"KlYZ2DfyEJai8T2y3Eocgrj1vvr6Rt5fHIVFkZl22GAQgGgQsQuQi9Aqwm4cxjMAiDANoh3hCNOhnDp2",
+	"aDoxVpUl8p+dVbLuN4SmC9f6zP7YtO0TF7eNXM8V4Ow2wOQh3wSnKF5oV9wwD0cwkZCm5nx+fZiRGWdG",
+	"yAxmuygf2fICW8UssIdJB5RkbxuPZuswR4d+k0Q3SAR7dmFowQMa++s4sOJvsL2bzjJKn+tOlVDokhaE",
+	"XoyIYUIay4sCcqZkTT2kfLUmeQNLYay+u1G1rUNFnoS0NPYhbPUNopLef3MFW6YjYNoOnY3mRM1CMp7w",
+	"+DjznlRNfAP5ljZaWOuk/S9LxYtw2LFsxeUSlCyEBKcNspn6BfXxJmBna2HvjSZe7lhrz66F7oj5wY0D",
+	"0L8l8QFop0ePJjugsNAgmgQp4BIySyqNh5nC+CKv2b3bLvtTpOD9lkLgeQvbLp7ABZ9KW2yZqebeEtyi",
+	"PmHr0MT+pULYI8Yua5uxgWvQeGvlxh0vPpB4LfBuYKosA8hP38pZC5ImYuXz5r/urHxbnZw8BnbyRbeP",
+	"sXhCevW1VKro9/2anUzdJ7r5sa/Z28nbSW8kDWt1DblTAal5XrmwW+q1d9j/rx73rfxB9/y+a751yuMK",
+	"mRhZ11SLhciEQzr5LvhSdQ46qegLmfXXgOqqYQLttmTIFcYpCG5fGJcOlAGau49rZmJUpHgehQf0BZNh",
+	"cMMzXCUnRtk6w3JNZ30rlVXlbHxAQn/GZDTCHd3bfRO7u/Pshu+yc+tpoSMi1xEW9R4ykhCMdK4o3HXh",
+	"Q89DfHIhjO0B6W9AxTaAiwT5mWmhmVbA/oeqWMZlcHDV6qTSpKNhX5pBmGhO4YR8gyEoYA3uUkpfHjzo",
+	"LvzBA7/nwrAFbEK+xoMHfXQ8eOCYQBnbOek/+HQ/NHS10UGSQay7z9beZGMP2N5cZHqrYamxc8+BVvbm",
+	"PIEQ0nNQAU/E9KA9dD8eaNxRa4+GPn8eJiRRY0zwoePC/+HC4u5hzeMD9pzGAxr9N03M3h72j4Yfu/md",
+	"wEDa+mZuhwKt1OIeFi/ym1RMcg43qc32rE0moM8MK/mWPLqpq22JACYyOUBfFWRfVouOyGL+gFyJMqXE",
+	"Nhlr/+vz/zzFTDU++/Vk9vT/P373/sntFw96Pz66/frr/93+6fHt11/853+kzAHGinnaF/FXbsiV6Y+W",
+	"G3kunTcRnflkRNr6u6lafGy4O6SGmxkwHy1pDNm9Tm0IXVR8pN7tdIKmh2J7D1qIG4hpKDUYOjNik51x",
+	"X9UiTljzlGe2xsK6b/V2XX8euPO/CTfmHpW629NsrSRskynjQsJ39DHV251bA51Jgxjqmw65+Dlc7Vtg",
+	"tecZs5kfil/a7UgSvwR4YaxYc3sfLr8FwAz9LMQf6WjIarkEQ6E2ABQ7UEnhYgvWCPLMwVyCJqfd0VvZ",
+	"Umgpd8ZdLJyfAYfBztyyArix7DuB/qaXAHW6RUCOBLtR+qq2IB4Nm0DHBSPj4OCRxyy/AuNDw9wtPj0+",
+	"pj4tYAA7ayEpGa5VlgCAfS6VrXHyRWOX90t8K9GxZRVmg4ocobnb2n1O20inz6LCvFBqyjZK21VPT91w",
+	"QaGZngJ76ukAEDoDaUUxgKPme3N24w+EqFwYq8W8ojlsl97IFcYN2Zz2TT6w/NYEfv4FQEOxrOSi9nR1",
+	"A1cjaB3iRse/vQR43SAmcfMxHP0zYx12LSDCItqLc9HQReHMJXj1TqNsT1ZMZ95DM2NG3ME6WTEtm22H",
+	"ontoapHbtC2/Gl5t08WBum4kYQM1Nhhv23skQE6YKDld7VxIVRozDoe09h5+u0L+dZ0jfQ8nfHfcjlc7",
+	"zscmrxwUJeMsKwT57JQ0VleZfSs5eQWi5SciYoKvY9hP9Cw0STumEn4jP9RbyYkla19BMtohKalfOknS",
+	"Fi8dkf1W+lYjTjhsiTaoBeYVW8V+Ba3YvLLMjjn63sp7O/uWIMEIM0try9+6r6Q0++WvvAKN//edm/SP",
+	"j6vlB9hFPgj5+XNvEDt/TlaPxrneg/2jeVx/v+pAV4/t8aLjjg7VtDaiI4zDWt+lIh+XaoaOFwp8myyF",
+	"XVXzo0ytj4Mh8HipaqPgcc5hrSR9y495KY5NCdnx9cM9ovgD5BVLiKvb6cRLHXPvTgM/cGpB3Tlr13X4",
+	"2yr22bcvLtmx3ynzGYLqrSq/oYPGzzDWO9MxhcR2kI5P8DZkQ8bZjgnDs/vQ9oHgzrnyQi7ZGn0Az2Eh",
+	"pMDvp29lzi0/nnMjMnNcGdA+VeJoqdgp80M+55a/lb3zaTAdPQrkZmU1L0SGdr6UXHFVXfojvH37E1L3",
+	"27fvelE6/audnyopYNwEM1QaVGVnQSHSsOE6pa+ZumwBjUy9d846ZX5s+tGPz/z46XOGl6WZFSrjxcxY",
+	"biG9/LIscPkRYRpGnVy+nLFKBwkuTICG9vd75eOUNN+EmieVAcN+WfPyJyHtOzbzbquzsnyFY6JjEX7x",
+	"ghIZalvCaOU8yr5pBksp6bRwd+WHG0y7w4wKk1y+BV7S7pOWsaY7blEw6hbjpI5xpaGaBey8hEZwHJx+",
+	"RIu7cL1C/bH0EugTbSG1QdHaBKjcdb+iHKA7b9eePCJe2RVVgkiuyiCJh52pyxK5JAwfNYReL2QCX8EJ",
+	"tfcVZFeQUzEZWJd2O211V4vW8RxEhzCu6JJLNKDKIOTNwWJMZc69AsPltpvCb8DaUGThDVzB9lI1hUUO",
+	"ydnHiDhXBmmGNDPEqESp0UmKxBqzrR+ju/k+yBEh5WXJloWae+6uyeK0povQZ5iR3fF+D0ycTC4LaNhB",
+	"7yXXCURQhyEU3GGhON4HkX5qeS2n1Mi6AC33XWw/GjxckscJxv+3T42eUE9nfVPjGRp0ktsB+AX3A3mo",
+	"GwMaZnKOUZfDyaiOqCfceUGKVB1+6jib65b/Ti53gZamEtCyOdUDGG2MxOrDiptQfCyfRgwz6qDdm3GG",
+	"VBQiHUU7ekTgvAVc8yH8D5d/OY/CF6NCbHXwUxBsXWaY1rWRXInWUAQmVH4J5V4m04NKt0wnPqI+tR1K",
+	"kpaRQwFL7uNWsHEn9/czE20QwvHDYlEICWyWioTkxqhMEL9HstzPAaiEPmDMuSDY6BFSZByBTRZVGph9",
+	"r2LelMtDgJQgKEKAh7EpVCD6G/a7hJsqSl693auG9mVHw0TTphKS28ZUYYKkSBq6IbRaMddkDr37YIpE",
+	"mZAJo1LfdGUADadCyVk7TuAKtmmtAogML0K36NrAPheYY7v9Ior7iK5K9UFQF5P6uIaXa2VhthAag2PR",
+	"3pBcHjZ6aUgZfIlN0+KnhSrmqluKgWouNO0VbGe5KKr0bvt5//Ycp22qM5lq7iNQGfBs1WS09qNUd0zt",
+	"ooF3LviVW/Arfm/rHUdL2BQn1krZzhx/EKrqyJNdzJQgwBRx9HdtEKU7xAvdfZ5DYfnuus90q0WBaXlf",
+	"NERWgx4z5WHsXepXBMWw5HUjJdfSALp7FVRsieIZhY2KmfYz0wZ4gJelyG86d3g36oD3Dac4RFF3Gn8i",
+	"WGJSD7YHA9F9PZX8oCHYHNyWRmemK0sruyVc9mPmsl33JRYI8VTCDFejQNKmyr/7cIUJqn+D7d+xLS1n",
+	"cjudfNiVP4VrP+IeXL+utzeJZzLEuytgy4J3IMp5ieU1eDHzhpEh0tTq2pMmNQ92lI8s6tLX78sXZ69e",
+	"e/Dx7lkA185UtnNV1K78w6xKA7dK76yg5LTVcHd2ili0+XUFhtiYsqFY944uh1LME5djr8ZQ1owXjCuL",
+	"tD9wr6nE2/TcEnfY9qCsTXvNjZg6d6x5/JqLIlxFA7QDvjtaXGNPPVgqxAN8sFUwMu7O7lXc9Lg7zR0N",
+	"de2RSfFcO0r4rl2ValM7JprYaFQhcQZHqujHnYM3TveFk6zWFFwwM4XI0mYLOTdIHNLZfLExo8YDyiiO",
+	"WIkBF4KsRDQWNjMjXH0dIKM5ksg0yXKBDe7mypc2qqT4VwVM5CAtftI+V6LFqMiXoaBQ/zhF3aE/lx+Y",
+	"+kTDf4iOgUMNaRcExG4FI7Yw98B9Xl84w0Jr0ziXLcPgAY6qeMbekbjDyeTpw1OzC1VYtS3F8WsgffmH",
+	"hOEqR+9/iiQuHpU2ng0+LTJ4WpwNnxTY+4AzojkSCNz4MHBpPbwwKjFMJTdcupcCsJ/Doe9twNkMsNdG",
+	"aUr1NpAMMRBmttDqV0jfZBe4UYn0DY9KUhep91EihbYrRGurTPMGTMBvDMcgaQ9pctFH1nYkDnA4UXlk",
+	"Oqd8tGDg4tKRtXvVoOV7TzNH1MIcu/Eb5vAw92KMCr6Z8+wqrVAhTGeNk6ZlirOKhc5hF7zVsKG9yN9T",
+	"txUuP7oE3eRY9YjhrsrRH4vkc8jEmhdpLSkn7LfjB3OxFK5KdmUgenvAD+Te1HFU5N9vcG6wBjXnC0wO",
+	"bF438buRi2thxLwAavHQtUAHAq2tVc7QR3VZkHZlqPmjEc1Xlcw15HZlHGKNYrUCS1e52vY9B7sBkOyE",
+	"2j18yj4nq78R1/AFYtHrIpPTh08ppsb9cZI67PwbMLvkSk6C5R9esKTpmNwebgw8pPyoR8lcffeO2LAI",
+	"28FNrusYXqKWXurt56U1l3wJaW/ueg9Mri/tJhkNO3iR1CgHY7Xatmv1RvOD5SifBuLqUPw5MHyQ5xoZ",
+	"yCpm1BrpqamS7yYNw7knbNw5XMMVPpKLpWTpMrMf10DszvLUqskR9j1fQxutru4ypjKIpmiQF4hHA9XA",
+	"QF+nJ9EDGxzOTd8XY+rkbI28k3/RRGxG9JeamJx4yWltkF3d6J3dQ49VtXCU2SBiqxZieSST7oziSqfX",
+	"ySuc6sc3r/zBQPVI+0nojTT0h4QGqwVcJzm2G3lYayb1cREwn1JQfBFOqrSaenytW8Cyf0nzZVoPrvtJ",
+	"vc4trPfWOatn2LcAGi2xCAd7VJHTCYPgaW1KcDJnOw/+a//NlaP1j17VFyZT94MchUeoEDk67O2yH9JS",
+	"e3ZJ63N/xJg/0MKy7/HFdlJn1NoD0K+kOhAgNXiV6czgmInGxv/uHtXe7B+xVdgBx60fmmo+DCOwJrzU",
+	"JH3qOIo86U0QYbh7R9iKHLTpmiIDvEtfdxB5uopjU3TWUXiC2l84LzVorfSU+fBmImi2xhYh+inBEa4e",
+	"a4Ksm7jSUY9itBF0WHQRD0aTUcE6dRnIPprok2dufNkKpLsLxDvcI5HUVt6liElqXytR5H9vsjU6JXw1",
+	"l9kq6fmaY8efm4eMau53Z16KnbIVlxKK5HBOk/o5aFwJnfCfauw8ayFHtu2W5nXL7SyuAbwNZgAqTIhs",
+	"I2yBE8RYbYev1yGDGArPaJ6mMFhzDPerDUdlSgeOSv/BRdtaes5JaV8lk4HM6a51xFzhGoSllalJdxyx",
+	"rgpXxgLyJWhveq/KQvF8ynAc9AkwN6vr4wumUJXOpUsebK1i+BmGcQFwDfemgnPHj7M7WtC9QTGrHx5J",
+	"JY1gi8vQgImOtZ+U/xg77Ueioocuoncy6tGcpkc0gf+xlmcrX/RoOobkx5eXDVRpoufu/P+zmhId3yHc",
+	"vsKsKzA7dYrIRhj3ZCeWJmpRdQAjSPCQt9Jenq6kdJSSvh3syBy/C9oDcDRu7RBIQtZB/IFKvlGVzmA8",
+	"TTp+vqBeKaLsle7tPdrmqiDU9c3DU8wZl0qKjErTRI+E1iD75z/H6HIjqvh0jZWBxT2HJpgrWTC4Dhrz",
+	"WBwsITydtBDXN9dHX3FTHXW4Py29M4lmuCVY4yUb5NNQFNpb0YQ0oJuHX1pZwW2tlCRk0qk9q50fB5IR",
+	"aUUDl8WX+I0uisIHa14JSbqoR5sjaOHsXPTUnl2BZMKypQr57t1M55+wzxGV08jh5t1ReJqPxnAOPFy2",
+	"81b3hzoLvmvvK8a2z7AtI2dd83MryNxNelaWftKUJDD1DqfKWg8iOOGDnAUnUITcevx4tB3ktjPohM5T",
+	"JDS4Jpc1lHQO9whjQDV8gSZFR1HUgrlgr2Rmo5AJMF4JCc3DkYkDIkseCbQxxK8D/UymUT0fLdPQVU1+",
+	"6mTOu/WG+w8dqrPBhBJaY5hjeBub4uYDgqNu0ChumLERmAKpO1ImntHbwh6R/VLlpFV5JSqncN5O8fKU",
+	"4EDBHcr+tw+Avc9M1d2t5hm0+o44iYbSoHJhuDGwnheJAMbn9ceogD/uCJqp8N/DHsryYQ0HB9aFGAbq",
+	"eLB+2R6ppx3i3s8wfv5uu9L0v8dt6fBAvEcp6n+htdJxLmmvCKATPHVWKgVvqfCcCl0q6pSkNs3it/Sl",
+	"rXkZY7fZYfiNiymJxoEQzjdNVR3upK/zzAwFcmaDccfc+qQCy1lT3aAvDt3DFKkRXBQIffeP/CfNskOR",
+	"Hy7wAz/3eo/TG3pa2EAR2QihIaSoD9DfQrwiK7nwbseGRfqY9ZHN/VjzMTGPzQZ3F+HjhWmQ1EraRVaS",
+	"9pYFwIhSL1HW8ehyNX0s7K6m9DKCZEQhpRG1dvaoPcOVUlK47D3YlrBiG7EuC+faum5qvce92AcZn4OP",
+	"p59Gtj8Bu+13jrw20AnZiazcaSTviOv4R1Td/U6BHP1Em93RG70C3old6denHKjVfQ9+geBYjSaFbtzz",
+	"WN/AzhwU4t0o8yTMG5IuhmOtd5jsvedkG4zN6bqhoXL8MGAFvwNcB2UY4Sw+9+Pvb17SDLue799TZXxU",
+	"9k2bgQaTbhCGa0VZansybkYu02W9uLU2A3/wctFePpRGeNmsoZ08iBPz+q9mmymxEQ+La2CzFj+41+SE",
+	"hPAipDA4CraXys6iDJ1eP6nsYF+4KUXTyRNYU8E6ZMQ0viAEbTKdtOfEFm6g/Z4fGq+RT+38nH4aTipZ",
+	"J5XsszcVKNqnpABsisinRB+ArgVcv178QQJvqYwRZVfu4QynrYAqYanUWi64F7ChWI+q7FIhTXkghJKm",
+	"dnV5Ao8G4fk1QmaaOkBMyEytcQQlIe0srNfnXpsYfj67/SRKAxHBDsbyeSHMyqUo129m05jUYcfD2bnQ",
+	"MKBIx8cj7UhYPG5TNGkHptirGVAwmU4CPpMZwk6U2+2QrHdfm9mxRgT+ltWJKdNQNztGTnjVlfoIw0Bm",
+	"elsOBSrROSszGDDWXYaS1YhVWYfNuNEHIo/c9XIWMsj3ienQoU45b55dwkmmpPDw5eh6A5d8+WzI31ID",
+	"Z2C/AlYDZnxw1G8CUkkZyO51dC3KHU/K+9Nai9KxRyziS/fmr9mQnJ5vI1jb79EMFQ9Yo/TbKVxWyrjo",
+	"s/qJnYjqRIsjPXE65u3EegwSjqWS71ZvZ8tqSGup27Bvf2xCuweHHPQkXaYeJZSwVDbK+toxdC+2poW+",
+	"WMD05V2KRbqUmT5Geu7wRJi/5aIw9dt+iUAOsoh3i2FufMUHSsmtnXuh9gOY8FvIXnezFOIK4hesyJWK",
+	"CfShRdI22ATTpDMrurmK1IyJNNCLembRhCX30/WGom2yQhnkv3E3svoi9plx8W3khdmA9nAtQPuX64g7",
+	"CmVgZlU4MHfBsQsV/rXpuyDBDNZTd8AN1gx50xRFoZs9p4s997F78QKZhjUX0hcN3Xkp3YfsZ+57yE8L",
+	"GkWnXGdi3ECv+19hDwHpwvSQGFP9gnmj3P68t7tbeTvde+a0gio+vYrSc69ge+xMWu6xJlPjKOYX9/Am",
+	"3EBWxcV/Omi8P8tyqVQxG/AJnfdLgHTxfiWwShVqnnW46MDTIuxzckXUTv/NahteYXRv6HxxxNiZdAHb",
+	"wf/fLo/ZmVx+ZnfNfxO/+1M/p/NWpuOBSZTrD+SpMMxuTjIg8w+eyg2yeyJ7M3B4YtGo/kM7Yx8YT3jk",
+	"u4a/hqgcFMkTcaUkzJW6eiGtTtuVQgsG2GQaP7GoclcC15/Ph9+2UCE6JWWoc+HSUPAtU82ZobMVbmra",
+	"GEgfebH7KkLg128rueGY5v47/uhmPcCAl8LoHYtNjBImfZt9QpjUmnJiMxOaOYVnek28W8e5Gq/hDxA/",
+	"X6YH8N3CxHvCUflyMvXgpFAeJ0bvcQ9dtVwarg5fJ5JDabhn10bkwj7QtdFP+R67PFoHHW6Vgf46x9+7",
+	"YtymqK1e21i/XB+5w+40Ox/jTkvHTmN38uc5hISCe30i/WjeuNZ79n7e1K7/fejO5SLUBgJFOzjFmNK9",
+	"GRBx2G9TjZsCW3+ef/WkFT37MeuB/+xur0O30YMc791NIMQk1tqaPJoqCugdEcvruyUjd0Ol5YRgDg9L",
+	"9dMJDBPyWhXXzjoRzslpk4SudIe3p0wq6/xzdqVVtVwxzjAchPJYf3zz6sBD2sGWJy5h95n/Uc8y7tq5",
+	"d5jhi9v+bA6/G1McxWloSmbQevErKUh0kR55ZW2J+4T/GtwBrz7hLoViq1wDe/3DxWU37HTgTMS5Ek9a",
+	"TCcGskoLu6XqGMFiIH5OOl6wwr6mq+4KeA66yTH2Ka5WXUFdX2VZt65M8J19q3hB+Y94ryAnhqUXDF/c",
+	"0IsdXvZ+/dn8z/D4L0/yk8cP/zz/y8mXJxk8+fLpyQl/+oQ/fPr4ITz6y5dPTuDh4qun80f5oyeP5k8e",
+	"Pfnqy6fZ4ycP50++evrnzybTiUCQHaCTkJ84+e/kMZ+dvT6fXSKwDeJ4KeitY0QKispQOZ1nJO3xzl1M",
+	"TsNP/zUQMJavb4YPv0787k5oC0+PjzebzVHc5XhJNoiZVVW2Og7z9B+MfH1eB+G7/COSGi6+GungaNKI",
+	"mzP69ubFxSU7e31+NImscZOTo5Ojhzi+KkHyUkxOJ4/pJ5LQK9r3Yy/QJqfvb6eT4xXwwq78H2uwWmTh",
+	"k9nw5RL0kS8hjz9dPzoOMbzH7z3r3+76dtx5v3Ncw2NvX4s6NAIAO7VEx+3IZsdRhkFobwwQFF583O74",
+	"dDx3RTqi7nMf/xj+pIdojt+TTeR26Pf2St9jItntcXiHz/fwb5cfv6f/EGHcOk4tIBVAEl4cbprTS8J8",
+	"rjQl8/ssqpBFLEzUcjKd1JSGz0pOzrDXMwdBqI3iisWd/tQ/pGggFkYidkRaa7ilNVMjrayuIK5fVh8R",
+	"rfaNYvHTyezpu/cPpw9Pbv+EioP/88vHtyOPnmf1uOyi1gpGNnw3nYTgN2KiRycnH/DszpmM0O82qY6t",
+	"S9y4/Dv2UcRcx77nGnQGYjUy9qTkdoYfeJn+yYEr3nljbcUbJp6U+IbnLOQy0dwPP97c59IFa6BQdofH",
+	"7XTy5cdc/blEkucFo5ZR7Yf+1v8or6TayNAST/pqveZ6G9jYtIQC85tN5wlfGrIIaXHNLUzekcnR2NHC",
+	"xVh+B+Fygb0+CZePJVxok+5DuLQHumfh8uhABv/jr/iTOP2jidMLJ+7Gi1Ovyrl02WP3nGuj4fUeaVhC",
+	"Mm+XMmh583Z8Ku7NpKM6p4xjTFjtdBc+OEzYLdPOz4UXtFa021FPYH8Lthtp6iq6f4DEGmVk7M469i2q",
+	"seg5+sQLd+WFHln2cX6QhnHu9ofx/kBsIQqgEjeNpWG+Zb8s0cYQIiZ5nmPHK9j+Mg15Vdp6i4SwfaI+",
+	"y/MeeU1DKZdvVL7dsSU3s7mQ3PnFEhqG/9g/J8YRK62XWRVo9qin19x+IPO1j8N26OV+K1jDSsnw292H",
+	"Y2+ysU/t99GELu4aFs/Kn47UP9gNJc+TLG9VLaRTYgQV60zlsAQ58yw7m6t8GyqNXsF2QS8rp47a4/dd",
+	"ItxpWLmwqowsmwk6DBFtLkVI5FNGxlbA/HrNDTBhDf6kwVfFcFPRzwhmXzg9pwZd+fTN9vz5vgtTm1WT",
+	"DJq4Q/W4ctdNqsvgA5eXTjZxD20OCfknvn1y8uTjQdDeh7/BFp8XYi8puusPKkMcs6TEyC6lfJ9IOHZe",
+	"HT1KO3f1sL1PCIk77twOjaIoGffZvVI7SqQwJTEyTcgpmyPbOa2mecfIZYr4snGpp9g9bO0MN6coVXP/",
+	"FLlVcfGTUfeANzGSfn+iaTqU9Nn3p/YrwzSvgS8Aauj+VYHeNuC5R5N7EERZl2Ny1zpb1uSKJaoEhY6D",
+	"ELVycw6FrOB3BIzq2PKi1aMQC6B4+hJHte2FD8Ff8HHgv7tXJdjuiv2myCSzxBetQ8Wccew+ZRvNy9K/",
+	"7JkI63OcLlXzmh5ddDYaOVL2rzkuOtVxu09Om6lfRmS9dUOnkmX/dmvgO+XaMFd/Ot8/ne/hfP/y5PHH",
+	"m/4C9LXIgF3CulSaa1Fs2Y+yrg92Z33jW7A+FnWYFw5VRoCOzP1KBjUczGnsmvuaaE64cZLD599Q0q5j",
+	"2XX6nAf3MthHsPE1KZxjrXs7cfA7sendj1EtSgWD3C18Lxkdv8d/ZlFoxuDV9rlWZTLJkbvsLHYZkNyO",
+	"5o4SJ5dcSCpdqJmS/4XNeUOn2NAXMaVkgsQ1Vxg/KFLBGP3RpYOl0nDp3KzjxoeUyQgz93/HDcTpM4BN",
+	"vXNH/44HESKidfbc7XJXo9ET5aH0fzzntKy02fkbT69IOF5uI9wUgYV3NTQ851qVLgm0KDrcYhy7COtY",
+	"hfrOuTTM0BkhDNtw9wb1loErrEpxl7XwyhVQqgzyjZNrOPVamDms+LV/VKfNNN9w+XvnluTNIq/8UenB",
+	"mHMZp5f3bxS4ym+4fO77Xbh2oTuhjzLLlkM3iTDjne4RO1h7zqX8xNR/XIPRN5TUs1eYhASnYemB1mvb",
+	"robjk4WmPkcpyima1gdnGDgm5ZA/hQct1eSMTtsgXkBaTSV58xzySLxoQBGioSx41th4lISaWZ5/f8Hm",
+	"SlljNS+dfyxX2KkEbYSxjGdaGcM0uJCetLusnRM21ll2GA10JhlSAdvJZ8ylAo92lO0fcMM9mj/dXz/I",
+	"sdNG611cOeDpwDFlMJz268W0o5GH4ti8A5l9rjTTIGHzhc+td8OmIgeieAGvFzijbOR5DukPbZZ54wdN",
+	"xU7sPbp/8cPPRP4LPZBFBUJJrPzCiyL6jdQS39oMnOT3doij7dQ/10XPcnnjcSjLNHQlNvdkXa1J8eHJ",
+	"ycl0hEnT53g5iHH37EbNCriGYocBugNEp5jRB1hU4wfj47yJBNVtRFGg3b5+Qz4FGY06G2sw7UH3nI6A",
+	"DRe+IkCzX4gx95gBm8NCafCF/P0TS3VcXQooqWY4ZAqWJm/2no23N+eJqAWy1CLEfWM/w+yuEQmmOO4o",
+	"82g0dFM8hZjDuLSv29sdqoZZVTZXmx33FHp/lRf+ATN6UqxOF7GKhQGayCb2g6/NWmwZFuAWOTBOxW1U",
+	"ZZt8HmZVncffFBLAEZhZqQprIsJSSJqAuJxmcS/18bggXFMCpxPs6yH73nnyO3IvRT8exjTfp5j+Q2mp",
+	"H5y5c69CubHW38dI8hjiO6NMjxlhqJ8GYoEXx76YfOdXV/I5+jGZNRP/eoxVO8FYsXalx1NN6vdxkx+7",
+	"KTaprz49pWm0cdmKo8yVvm1InDNRqlwrDo8UHhEqpYcZvOsSJJpr86Sd8h8BmI9hqvSTjbVTjl38v+E9",
+	"Mmwb3SVfuP29P6tpF/EHBSIGrY3x0P9ekm97rnm6m+ETKVt/0lJYIwqPUNyLZgBm+6m202iWZop2qij1",
+	"i7N7p0yD1VTdlIxW+LtaYDFcK4p2a8dKuQnG4Ec3N4PBwWd5Hvjit7kR1lw3wGV+k6yq+YvSTDOst6Wk",
+	"ezOxhbaZcBWd2rmB9YOtPj/4t4y49AQ6ItYyEhl+oSNefI+GH+vb7bCMr1rWpDV/cuL+HsTjHzNQvJGn",
+	"bSK7izVgUwuDtjJy/L4h+p0urx+lHgQoMvULazp5+E4oo4jdgvWaSQ6FuHYMko7gbCTjAZFRHagGrvTN",
+	"esfc6u9g7k6JhEr2hMK/I2P+8U3gu9hg2CjeVFmJK0oQQde1JH56hyRGrzB7Wm8KJJweH1OBvpUy9nhy",
+	"O42/mc7HdzUU72t/lIfm9t3t/xkAQOU6iwTrAAA=",
 }
 
 // GetSwagger returns the Swagger specification corresponding to the generated code
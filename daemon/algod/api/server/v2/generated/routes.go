@@ -20,15 +20,27 @@ type ServerInterface interface {
 	// Get account information.
 	// (GET /v2/accounts/{address})
 	AccountInformation(ctx echo.Context, address string, params AccountInformationParams) error
+	// Get the confirmed transactions of an account.
+	// (GET /v2/accounts/{address}/transactions)
+	AccountTransactions(ctx echo.Context, address string, params AccountTransactionsParams) error
 	// Get a list of unconfirmed transactions currently in the transaction pool by address.
 	// (GET /v2/accounts/{address}/transactions/pending)
 	GetPendingTransactionsByAddress(ctx echo.Context, address string, params GetPendingTransactionsByAddressParams) error
 	// Get application information.
 	// (GET /v2/applications/{application-id})
 	GetApplicationByID(ctx echo.Context, applicationId uint64) error
+	// Get the accounts opted into an application.
+	// (GET /v2/applications/{application-id}/accounts)
+	GetApplicationAccounts(ctx echo.Context, applicationId uint64, params GetApplicationAccountsParams) error
 	// Get asset information.
 	// (GET /v2/assets/{asset-id})
 	GetAssetByID(ctx echo.Context, assetId uint64) error
+	// Get the holders of an asset.
+	// (GET /v2/assets/{asset-id}/balances)
+	GetAssetBalances(ctx echo.Context, assetId uint64, params GetAssetBalancesParams) error
+	// Execute a batch of lookups against a single ledger round.
+	// (POST /v2/batch)
+	Batch(ctx echo.Context) error
 	// Get the block for the given round.
 	// (GET /v2/blocks/{round})
 	GetBlock(ctx echo.Context, round uint64, params GetBlockParams) error
@@ -53,6 +65,9 @@ type ServerInterface interface {
 	// Broadcasts a raw transaction to the network.
 	// (POST /v2/transactions)
 	RawTransaction(ctx echo.Context) error
+	// Estimate the fee needed for a new transaction to be committed in time
+	// (GET /v2/transactions/fee-estimate)
+	TransactionFeeEstimate(ctx echo.Context, params TransactionFeeEstimateParams) error
 	// Get parameters for constructing a new transaction
 	// (GET /v2/transactions/params)
 	TransactionParams(ctx echo.Context) error
@@ -112,6 +127,159 @@ func (w *ServerInterfaceWrapper) AccountInformation(ctx echo.Context) error {
 	return err
 }
 
+// AccountTransactions converts echo context to params.
+func (w *ServerInterfaceWrapper) AccountTransactions(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":         true,
+		"limit":          true,
+		"next":           true,
+		"min-round":      true,
+		"max-round":      true,
+		"tx-type":        true,
+		"asset-id":       true,
+		"application-id": true,
+		"note-prefix":    true,
+		"before-time":    true,
+		"after-time":     true,
+		"format":         true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "address" -------------
+	var address string
+
+	err = runtime.BindStyledParameter("simple", false, "address", ctx.Param("address"), &address)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter address: %s", err))
+	}
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AccountTransactionsParams
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := ctx.QueryParam("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "next" -------------
+	if paramValue := ctx.QueryParam("next"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "next", ctx.QueryParams(), &params.Next)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter next: %s", err))
+	}
+
+	// ------------- Optional query parameter "min-round" -------------
+	if paramValue := ctx.QueryParam("min-round"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "min-round", ctx.QueryParams(), &params.MinRound)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter min-round: %s", err))
+	}
+
+	// ------------- Optional query parameter "max-round" -------------
+	if paramValue := ctx.QueryParam("max-round"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "max-round", ctx.QueryParams(), &params.MaxRound)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter max-round: %s", err))
+	}
+
+	// ------------- Optional query parameter "tx-type" -------------
+	if paramValue := ctx.QueryParam("tx-type"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "tx-type", ctx.QueryParams(), &params.TxType)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tx-type: %s", err))
+	}
+
+	// ------------- Optional query parameter "asset-id" -------------
+	if paramValue := ctx.QueryParam("asset-id"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "asset-id", ctx.QueryParams(), &params.AssetId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter asset-id: %s", err))
+	}
+
+	// ------------- Optional query parameter "application-id" -------------
+	if paramValue := ctx.QueryParam("application-id"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "application-id", ctx.QueryParams(), &params.ApplicationId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter application-id: %s", err))
+	}
+
+	// ------------- Optional query parameter "note-prefix" -------------
+	if paramValue := ctx.QueryParam("note-prefix"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "note-prefix", ctx.QueryParams(), &params.NotePrefix)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter note-prefix: %s", err))
+	}
+
+	// ------------- Optional query parameter "before-time" -------------
+	if paramValue := ctx.QueryParam("before-time"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "before-time", ctx.QueryParams(), &params.BeforeTime)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter before-time: %s", err))
+	}
+
+	// ------------- Optional query parameter "after-time" -------------
+	if paramValue := ctx.QueryParam("after-time"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "after-time", ctx.QueryParams(), &params.AfterTime)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter after-time: %s", err))
+	}
+
+	// ------------- Optional query parameter "format" -------------
+	if paramValue := ctx.QueryParam("format"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "format", ctx.QueryParams(), &params.Format)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter format: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.AccountTransactions(ctx, address, params)
+	return err
+}
+
 // GetPendingTransactionsByAddress converts echo context to params.
 func (w *ServerInterfaceWrapper) GetPendingTransactionsByAddress(ctx echo.Context) error {
 
@@ -196,6 +364,71 @@ func (w *ServerInterfaceWrapper) GetApplicationByID(ctx echo.Context) error {
 	return err
 }
 
+// GetApplicationAccounts converts echo context to params.
+func (w *ServerInterfaceWrapper) GetApplicationAccounts(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty": true,
+		"limit":  true,
+		"next":   true,
+		"format": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "application-id" -------------
+	var applicationId uint64
+
+	err = runtime.BindStyledParameter("simple", false, "application-id", ctx.Param("application-id"), &applicationId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter application-id: %s", err))
+	}
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApplicationAccountsParams
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := ctx.QueryParam("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "next" -------------
+	if paramValue := ctx.QueryParam("next"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "next", ctx.QueryParams(), &params.Next)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter next: %s", err))
+	}
+
+	// ------------- Optional query parameter "format" -------------
+	if paramValue := ctx.QueryParam("format"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "format", ctx.QueryParams(), &params.Format)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter format: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetApplicationAccounts(ctx, applicationId, params)
+	return err
+}
+
 // GetAssetByID converts echo context to params.
 func (w *ServerInterfaceWrapper) GetAssetByID(ctx echo.Context) error {
 
@@ -226,6 +459,116 @@ func (w *ServerInterfaceWrapper) GetAssetByID(ctx echo.Context) error {
 	return err
 }
 
+// GetAssetBalances converts echo context to params.
+func (w *ServerInterfaceWrapper) GetAssetBalances(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":                true,
+		"limit":                 true,
+		"next":                  true,
+		"currency-greater-than": true,
+		"currency-less-than":    true,
+		"format":                true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "asset-id" -------------
+	var assetId uint64
+
+	err = runtime.BindStyledParameter("simple", false, "asset-id", ctx.Param("asset-id"), &assetId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter asset-id: %s", err))
+	}
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAssetBalancesParams
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := ctx.QueryParam("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "next" -------------
+	if paramValue := ctx.QueryParam("next"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "next", ctx.QueryParams(), &params.Next)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter next: %s", err))
+	}
+
+	// ------------- Optional query parameter "currency-greater-than" -------------
+	if paramValue := ctx.QueryParam("currency-greater-than"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "currency-greater-than", ctx.QueryParams(), &params.CurrencyGreaterThan)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter currency-greater-than: %s", err))
+	}
+
+	// ------------- Optional query parameter "currency-less-than" -------------
+	if paramValue := ctx.QueryParam("currency-less-than"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "currency-less-than", ctx.QueryParams(), &params.CurrencyLessThan)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter currency-less-than: %s", err))
+	}
+
+	// ------------- Optional query parameter "format" -------------
+	if paramValue := ctx.QueryParam("format"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "format", ctx.QueryParams(), &params.Format)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter format: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetAssetBalances(ctx, assetId, params)
+	return err
+}
+
+// Batch converts echo context to params.
+func (w *ServerInterfaceWrapper) Batch(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.Batch(ctx)
+	return err
+}
+
 // GetBlock converts echo context to params.
 func (w *ServerInterfaceWrapper) GetBlock(ctx echo.Context) error {
 
@@ -465,6 +808,42 @@ func (w *ServerInterfaceWrapper) RawTransaction(ctx echo.Context) error {
 	return err
 }
 
+// TransactionFeeEstimate converts echo context to params.
+func (w *ServerInterfaceWrapper) TransactionFeeEstimate(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":        true,
+		"target-rounds": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params TransactionFeeEstimateParams
+	// ------------- Optional query parameter "target-rounds" -------------
+	if paramValue := ctx.QueryParam("target-rounds"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "target-rounds", ctx.QueryParams(), &params.TargetRounds)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter target-rounds: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.TransactionFeeEstimate(ctx, params)
+	return err
+}
+
 // TransactionParams converts echo context to params.
 func (w *ServerInterfaceWrapper) TransactionParams(ctx echo.Context) error {
 
@@ -596,9 +975,13 @@ func RegisterHandlers(router interface {
 	}
 
 	router.GET("/v2/accounts/:address", wrapper.AccountInformation, m...)
+	router.GET("/v2/accounts/:address/transactions", wrapper.AccountTransactions, m...)
 	router.GET("/v2/accounts/:address/transactions/pending", wrapper.GetPendingTransactionsByAddress, m...)
 	router.GET("/v2/applications/:application-id", wrapper.GetApplicationByID, m...)
+	router.GET("/v2/applications/:application-id/accounts", wrapper.GetApplicationAccounts, m...)
 	router.GET("/v2/assets/:asset-id", wrapper.GetAssetByID, m...)
+	router.GET("/v2/assets/:asset-id/balances", wrapper.GetAssetBalances, m...)
+	router.POST("/v2/batch", wrapper.Batch, m...)
 	router.GET("/v2/blocks/:round", wrapper.GetBlock, m...)
 	router.GET("/v2/blocks/:round/transactions/:txid/proof", wrapper.GetProof, m...)
 	router.GET("/v2/ledger/supply", wrapper.GetSupply, m...)
@@ -607,6 +990,7 @@ func RegisterHandlers(router interface {
 	router.POST("/v2/teal/compile", wrapper.TealCompile, m...)
 	router.POST("/v2/teal/dryrun", wrapper.TealDryrun, m...)
 	router.POST("/v2/transactions", wrapper.RawTransaction, m...)
+	router.GET("/v2/transactions/fee-estimate", wrapper.TransactionFeeEstimate, m...)
 	router.GET("/v2/transactions/params", wrapper.TransactionParams, m...)
 	router.GET("/v2/transactions/pending", wrapper.GetPendingTransactions, m...)
 	router.GET("/v2/transactions/pending/:txid", wrapper.PendingTransactionInformation, m...)
@@ -616,179 +1000,256 @@ func RegisterHandlers(router interface {
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+x9a3PbuJLoX8FqtyrJrGg5z3Piqqm9njgz43uSTCr2nD1349xZiGxJOCYBDgDa0uT6",
-	"v99CAyBBEpTkR16z+pRYxKPR6Be6G42Po1QUpeDAtRodfByVVNICNEj8i6apqLhOWGb+ykClkpWaCT46",
-	"8N+I0pLx+Wg8YubXkurFaDzitICmjek/Hkn4vWISstGBlhWMRypdQEHNwHpVmtb1SMtkLhI3xKEd4vho",
-	"dLXmA80yCUr1ofyF5yvCeJpXGRAtKVc0NZ8UuWR6QfSCKeI6E8aJ4EDEjOhFqzGZMcgztecX+XsFchWs",
-	"0k0+vKSrBsREihz6cL4QxZRx8FBBDVS9IUQLksEMGy2oJmYGA6tvqAVRQGW6IDMhN4BqgQjhBV4Vo4P3",
-	"IwU8A4m7lQK7wP/OJMAfkGgq56BHH8axxc00yESzIrK0Y4d9CarKtSLYFtc4ZxfAiem1R15XSpMpEMrJ",
-	"ux9fkMePHz83Cymo1pA5IhtcVTN7uCbbfXQwyqgG/7lPazSfC0l5ltTt3/34Auc/cQvcthVVCuLMcmi+",
-	"kOOjoQX4jhESYlzDHPehRf2mR4Qpmp+nMBMSttwT2/hONyWc/4vuSkp1uigF4zqyLwS/Evs5KsOC7utk",
-	"WA1Aq31pMCXNoO/3k+cfPj4cP9y/+tf3h8l/uT+fPr7acvkv6nE3YCDaMK2kBJ6ukrkEityyoLyPj3eO",
-	"HtRCVHlGFvQCN58WKOpdX2L6WtF5QfPK0AlLpTjM50IR6sgogxmtck38xKTiuRFTZjRH7YQpUkpxwTLI",
-	"xkb6Xi5YuiApVXYIbEcuWZ4bGqwUZEO0Fl/dGma6ClFi4LoRPnBBXy8ymnVtwAQsURokaS4UJFpsUE9e",
-	"41CekVChNLpKXU9ZkdMFEJzcfLDKFnHHDU3n+Ypo3NeMUEUo8appTNiMrERFLnFzcnaO/d1qDNYKYpCG",
-	"m9PSo4Z5h9DXQ0YEeVMhcqAckef5ro8yPmPzSoIilwvQC6fzJKhScAVETP8JqTbb/r9PfnlDhCSvQSk6",
-	"h7c0PSfAU5EN77GbNKbB/6mE2fBCzUuansfVdc4KFgH5NV2yoioIr4opSLNfXj9oQSToSvIhgOyIG+is",
-	"oMv+pKey4ilubjNty1AzpMRUmdPVHjmekYIuv98fO3AUoXlOSuAZ43Oil3zQSDNzbwYvkaLi2RY2jDYb",
-	"FmhNVULKZgwyUo+yBhI3zSZ4GL8ePI1lFYDjBxkEp55lAzgclhGaMaxrvpCSziEgmT3yq5Nc+FWLc+C1",
-	"gCPTFX4qJVwwUam60wCMOPV685oLDUkpYcYiNHbi0GGkh23jxGvhDJxUcE0Zh8xIXgRaaLCSaBCmYML1",
-	"h5m+ip5SBc+eDCnw5uuWuz8T3V1fu+Nb7TY2SixLRvSi+eoYNm42tfpvcfgL51ZsntifexvJ5qdGlcxY",
-	"jmrmn2b/PBoqhUKghQiveBSbc6orCQdn/DvzF0nIiaY8ozIzvxT2p9dVrtkJm5ufcvvTKzFn6QmbDyCz",
-	"hjV6msJuhf3HjBcXx3oZPTS8EuK8KsMFpa1T6XRFjo+GNtmOeV3CPKyPsuGp4nTpTxrX7aGX9UYOADmI",
-	"u5KahuewkmCgpekM/1nOkJ7oTP5h/inLPIZTQ8BO0aJTwDkL3rnfzE+G5cGeCcwoLKUGqRNUnwcfA4D+",
-	"TcJsdDD610njKZnYr2rixjUzXo1Hh804dz9T09Our3OQaT4Txu3uYNOxPRPePTxm1CgkaKh2YPghF+n5",
-	"jWAopShBamb3cWrG6XMKDk8WQDOQJKOa7jWHKmtnDdA7dvwZ++EpCWRExf2C/6E5MZ8NF1LtzTdjujJl",
-	"jDgROJoyY/FZPWJnMg3QEhWksEYeMcbZtaB80UxuBXQtUd87tHzojhbZnZfWriTYwy/CLL05NR5OhbwZ",
-	"vXQIgZPmLEyoGbW2fs3K2zuLTasycfiJ2NO2QWegxv3YF6shhrrDx3DVwsKJpp8AC8qMehdYaA9011gQ",
-	"RclyuAN+XVC16C/CGDiPH5GTnw+fPnz026Onz4yGLqWYS1qQ6UqDIvedXiFKr3J40F8ZCvgq1/HRnz3x",
-	"J6j2uBsxhADXY2/DUadgJIPFGLH+AgPdkVzJit8BCkFKISM2L5KOFqnIkwuQiomI++Kta0FcCyOHrN3d",
-	"+d1CSy6pImZuPI5VPAO5F8O8OWehStdQqE2Kwg59uuQNbtyAVEq66u2AXW9kdW7ebfakjXxv3StSgkz0",
-	"kpMMptU81FFkJkVBKMmwIwrENyKDE011pe5ACjSDNcCYjQhBoFNRaUIJF5lhaNM4Lh8GfJnoREHfjw5F",
-	"jl5Y/TMFYx2ntJovNDFmpYhtbdMxoandlAR1hRo4+tVndtvKTmf9ZLkEmq3IFIATMXXnK3fyw0VSdMto",
-	"H3Fx0qkBqz4TtOAqpUhBKcgSF17aCJpvZ3dZr8ETAo4A17MQJciMyhsCq4Wm+QZAsU0M3NqccIfSPtTb",
-	"Tb9uA7uTh9tIpTljWiowtovh7hw0DKFwS5xcgMTD2SfdPz/JTbevKgdCJ04Dn7LCsC/hlAsFqeCZig6W",
-	"U6WTTWxrGrXMBLOCgFNinIoDDzgIXlGl7RGd8QxNRitucB7sg1MMAzyoUczIf/fKpD92auQkV5WqNYuq",
-	"ylJIDVlsDRyWa+Z6A8t6LjELxq7VlxakUrBp5CEsBeM7ZNmVWARR7XxEtQ+rvzh0xxs9sIqisgVEg4h1",
-	"gJz4VgF2Q/fxACDmfFH3RMJhqkM5tc96PFJalKXhP51UvO43hKYT2/pQ/9q07RMX1Y1czwSY2bWHyUF+",
-	"aTFrAwcLamw7HJkU9NzoJrTUrC+hD7NhxkQxnkKyjvINW56YViELbGDSASPZhSaD2TrM0aHfKNENEsGG",
-	"XRha8IDF/tZ6wE8b79AdGC1HoCnLVW2Y1G72Zhb0yHezJYwVKSEFrvOVodUZk4UNaqE6U/43a/ZkbhYb",
-	"vmnYj2dEwiWVmW/RPy0Fi0kYz2AZl6605RvJYElYHOhZPTPTJPUhJx4OsBdldBvES3OhGJ8nNjq4SanV",
-	"Qb17ilScOQV2CdLBNQPp1K720bFECx9BWwfHOlQ458xNkGC6xqe1wNndUrEgKn4wjFiwVApqY6MGqZ0F",
-	"EgkFNdBhlM6p/eE51yH7hf3uQ7XeRR7SbnxcT6+DEqYm0csFbpYRtV0khlRvjragYGgh81xMaZ4Ygx+S",
-	"DHK90fVmDhJwhC2NvhZpv3sb5LOz93l2dvaBvDJt8WwB5BxWE4xYk3RB+RyaMELIL/bUAEtIq1C1dNC4",
-	"1UHQ+Urb0LePguNRKUSe1Efebtijp266eD9n6TlkxMgrZDGnBe+1d8hMQu4bEld1YOhysfImZFkCh+zB",
-	"HiGHnEBR6pXzr3Qsns7k/J5eN/8SZ80qjFFTTnCRe2c87tqwEe5b8pQfZj0n2ZSvW05lB1k/kV7yAXai",
-	"lxigMcNF+XOtd/QEewaqr6fRA6KyUGzjQ/gJ86Boa5dZhseRRrupalowTIYKmo2N5PTx6f4Jn+k9Qk5R",
-	"dpgDloILkDTHTA/lHcdMkYKZg7qq0hQgOzjjSQuSVBRu4vvNf61YOqv29x8D2X/Q7aO0MVfdWdLyQLfv",
-	"92R/bD8husj35Gx0NuqNJKEQF5DZ81hI17bXxmH/pR73jP/SE8ykoCt7kvO8SFQ1m7GUWaTnwsj1uehY",
-	"nVzgF5AGPDBqVhGmx6jKEKNordt9aRhwFLWe7sLnExnV2OlGlRpp56OSbdpRBJY0NaukKGRW1iKo6axv",
-	"BGlRJuEAURf0mhldEEC15PgN+a4vz60DYj18px0XRAsdAbnubbbde8iIQrAN+x+SUphdZy7/yCep5Ezp",
-	"HpDOHYERoJogI0pnj/wfUZGUIv+WlYb6bCckHpjwIG1mQB3r53SWWoMhyKEA6yHCL9991134d9+5PWeK",
-	"zODSJ+2Zhl10fPedZQKh9K05oEOay+OIAYWOeaNNI4nWC6oWexud9DjuVr75YOjjIz8hMpNSqGLMwqUQ",
-	"sztYLcuWUZsFlrGVup1Dd9s9RUq6GjSvSwNgJFsL5HmOvnwx61AkcfJvwUozZJNZstLQykr9v/f/4+D9",
-	"YfJfNPljP3n+75MPH59cPfiu9+Ojq++//3/tnx5fff/gP/4tZrwozabxuM/PVC0MpE5yLPkxt5FbY3mi",
-	"w27l/ABi9rnh7pCY2UyP+WBJ2xDd29iGMGNK4GYjzZ1UZZmv7kDJ2IGIBHfGUC33qLJfxSxMSnWUp1ZK",
-	"Q9GPMNiuvw2cft5570SPSgXPGYekEBxW0XsYjMNr/Bi1DVEsDXRGBTHUt+u9acHfAas9zzabeVv84m4H",
-	"YuhtnSJ7B5vfHbcTXArTcfFkA3lJKElzhq5zwZWWVarPOEXnXMf07pCFdzkOu2tf+CZx/3DEfeuGOuNU",
-	"GRzWLrto0HEGEWf8jwDea6uq+RxUxxQnM4Az7loxjo4WnAtPMondsBIkRof3bEtjfc5ojt7lP0AKMq10",
-	"W91j1qC1pm2ky0xDxOyMU01yoEqT14yfLnE4f6r2NMNBXwp5XmNhwCsAHBRTSVyQ/mS/ojx1y1842YpX",
-	"OOxnL28+twLwsMdy2hzkx0fOFD4+QnuniXH1YP9sgY+C8SRKZOaIWjCOqdEd2iL3jdXmCehBEy1zu37G",
-	"9ZIbQrqgOcuovhk5dEVcjxctd3SoprURHT+2X+uH2BF7LpKSpueYfzKaM72opnupKCb+CDCZi/o4MMko",
-	"FILjt2xCSzZRJaSTi4cbzLFbyCsSEVdX45GTOurOM93cwLEFdeesI0j+by3IvZ9enpKJ2yl1zya42qGD",
-	"zMTIqc3dr2w5EMzi7QUtm+FrDtBHMGOcme8HZzyjmk6mVLFUTSoF8geaU57C3lyQA+KGPKKaot+p400f",
-	"ukOJPkEHTVlNc5aS81AVN6w55Iw9O3tvCOTs7EMv3txXnG6quIMbJ0gumV6ISicuIjHsu2r8eziy9QWv",
-	"m3VM3NiWIl3Ew40/4HQvS5UEXtj48ssyN8sPyFAR7IT5ikRpIb0QNJLR+dHM/r4RLuIu6aW/NVIpUOS/",
-	"C1q+Z1x/IInz+RyWJbp40cf6307WGJpclbC9n7YBsRksdrbHhVuDCpZa0qSkc1DR5WugJe4+KuoCvWh5",
-	"TrBby9/ss7VwqGYBa/2KARzXzqXFxZ3YXj6AEl8CfsItxDZGOjX+8JvulxnqZ5EbIrvxdgVjRHep0ovE",
-	"8HZ0VcqQuN+Z+mLX3MhkH/9WbM4NE7g7cFMg6QLSc8gw+If+8XGru0+xcBrOiw6m7LU1mzKLdyvQFTIF",
-	"UpUZdTYA5atukrsCrX1m/zs4h9WpaK5mXCer/Wo8cgGtxNDMEKMipQbKyBBryLY+KNbZfBffxKBTWRIb",
-	"17HZyJ4sDmq68H2GGdlqyDtg4hhR1GhYQ+8llRFEWOIfQMENFmrGuxXpR6NIVGqWstKuf7u41NtWHzPI",
-	"JuUSVSdi1tUaPaEeFWK2cTKlKq5AwHwx+2F4qJvN5GeyXkUbqCZY+sAR7jSHIKKqHGdTiUaXX7a9yz0E",
-	"WpxKQPJGq3sw2hgJzYeFSw1gF01CALp8tlG0GwOyhop8zg5rh16YmTeHCzoYBRu8c3QcJOIEV1nrG0Ve",
-	"sHWZYVzfLrNVJfzNI3/dyN8xGo2vdV9oPHK5obHtEBytjAxymFMX9MGsU59wYEG7p4INMnD8MpvljANJ",
-	"Yjk9VCmRMpsH0MhyNwcYI/Q7QqyDh2w9QoyMA7DRW44Dkzci5E0+vw6QHBi616kfG/3swd+w2dvclPdw",
-	"5u1GM7QvOxomGjfX7+w29r1Q41FUJA2dEFqtiG0yhd6RKkaiRjT1/TJ974+CHFAdJy3JmpzHvHXGqgAk",
-	"wxPfLTg2kPtsZpT8gyBoImHOlIbm3Gy41TuCPq/v4kJoSGZMKp3gkT26PNPoR4XG4I+maVz8tFBFbH0A",
-	"lsWlD057DqskY3kV320379+OzLRv6vOTqqbnsEIlAzRdkCnWszBaqDW9abNmapvXtnbBr+yCX9E7W+92",
-	"tGSamomlELozxzdCVR15so6ZIgQYI47+rg2idI14CTJx+rIlOJPZfCHMLdpb5zXoMdO1s5kGJa8dKbqW",
-	"wNBduwqb9Gbz2oJyEP07FgM8QMuSZcvOGd6OOhC2QwP+Goa6tfgjoahRPdgGDATn9VgarwTvc7BbGuhM",
-	"W9ijl+q4GTPdBMtAIIRTMeXLUvURZUgbM9E24eoUaP43WP3dtMXljK7Go9sd+WO4diNuwPXbenujeEZf",
-	"tj0Ctjx410Q5LUspLmieOMfIEGlKceFIE5t7P8pnFnXx4/fpy8NXbx34mLkJVLqExXWrwnblN7MqcyKO",
-	"ZS2eBp4RtFb92dkaYsHm13eJQ2eKTzJt2XJGijnisuzVOMoCVnTOlVk8pLbRVeJ8enaJa3x7UNauveZE",
-	"bD17bW8evaAs90dRD+3mpNgbSYVWVu1tvYJhiu2dipsed8e5o6GuDTIpnGtNEZTC1vlRRPBuYpExIfGE",
-	"i6Ra0JWhIOuc7gsnXhWJYb9E5SyNuy34VBni4NbnaxoTbDxgjJoRKzYQQuAVC8YyzdQW0bIOkMEcUWSi",
-	"S2kN7qbCFWisOPu9AsIy4Np8ki7RsMWohi997nxfncbz9N3ALlW/Hv42NoYZasi6QCDWGxihhzlyS8Qf",
-	"OP1Ca9e4+SFwDF4jUBXO2FOJa4JMjj4cNdto/6LtKQ7rKfblnyEMW3tnczFH77ZYWEAH5ogWZxzUFofD",
-	"mgLvX2yvIxqVgOCGysDmxNJcicgwFb+k3NZaM/0sDl1vBdZnYHpdComXFhVEo/RMJTMp/oD4SXZmNiqS",
-	"++hQieYi9t6LXAbrCtHaK9NU0fT4DeEYJO0hSy74SNqBxAEORyoPXOeYzO0dXJRbsrZ14Vrh6zhzhCkn",
-	"Ezt+wxwO5l6aTk4vpzRWIsUYVAamwyZI03LFaUF8Z78Lqr7D4GgviPfUbZm96VeCbBKU+7fKb2gcfVsk",
-	"n0HKCprHraQMsd++ApaxObPF9SoFQfU2N5CtSmqpyFXAs2GwBjXHM7I/DupDut3I2AVTbJoDtnhoW0yp",
-	"AnvTLLx95hKjNHC9UNj80RbNFxXPJGR6oSxilSC1AWsvFXnf9xT0JQAn+9ju4XNyH73+il3AA4NFZ4uM",
-	"Dh4+x7QU+8d+TNm5Kprr5EqGguU/nWCJ0zGGPewYRkm5Ufeit05t6eNhEbaGm2zXbXgJWzqpt5mXCsrp",
-	"HOLR3GIDTLYv7iY6DTt44Zmt26m0FCvCdHx+0NTIp4HUNCP+LBjujkphGEgLokRh6KkpzWYn9cPZIqCu",
-	"XJKHy3/EEEvp7xp1Dsyf10FsdXls1RgIe0MLaKN1TKi9nI3XpdylficQ9wbq2oC8iE8iBzbY603Xl9zn",
-	"gieF4Z3sQZP0GNBftKyL0DSPp2942dXN3lk/9LamlhklGURs1UIsDWTSjVFcyfg6aWWm+vXdK6cYCiFj",
-	"NVoaaeiUhAQtGVxEObabvFdbJrW68JiPGSg/VCzP/t6k3HbKoUnK00XU9zo1HX9r6jfWaLdYj159XVDO",
-	"IY8OZ3n5N8/zEan0T7HtPAXjW7btljmzy+0srgG8DaYHyk9o0Mt0biYIsdrOQayTVvK5yAjO0xRZaAih",
-	"fzcxKPn0ewVKx+5R4geb74VnbGOv2IpDBHiG2n6P2HuHBpbWzTHUsqyocnsLCbI5SOf8qcpc0GxMzDin",
-	"Lw9fETurcnfo8b4bVjya2zusrVV0zlZBRZbrXOodSg/bfpz1+Spm1UpjSQalaVHGMn9Ni1PfANOLQ38T",
-	"qp8QO3vkyGp+5fWKnaS5u03q6ZysQZow/9GapgtUqS0FNEzy25fq8lSpgpK1dfXPuqiKvY6sha/WZYt1",
-	"jYkwds8lU7bsNlxAO9m4zrx3Jp1PPm4vT1acW0qJ66c1N0NugnYPnA0qepdUFLIO4q+pZpSoZArXrVx2",
-	"gr2idxu7ZdB6tWrtLae6VqR/TiGlXHCW4s3CoNB3DbIr4b2Nv3aLS5jd47JnccehEeaKFl+r0xYcFgfL",
-	"sXlB6BDXdxgFX82mWuqwf2qsFW0OgnPQykk2yMa+wJ47xzGuwBXJwWrugZw0x/Fu7DIaVmnKZFyTjDD1",
-	"cMBc+dF8Q1OFuXShc8bx0rhDm8tMsictrDCszfGOaTIXoNx62lcF1XvTZw+vy2Ww/LDnKxLjGNaFbJZt",
-	"4yX9oQ599MRFK0zbF6YtQXdx83MrzdFOeliWbtLoTb96h2MlAgcRHPGCJ94NGSC3Hj8cbQ25rQ17oj41",
-	"hAYXGDSBEvVwjzAGSk+8NIdaS1H2BrtNN4heT2E8AsYrxqGplx1REGlUJeDGIL8O9FOppNqagFvJtFOg",
-	"OUZKYgJNaec6uu1QnQ1GlOAa/RzD29gUihwQHHWDxnCjfFWX6TbUHRgTL/B9AIfIftlHtKqcEZVhQlmn",
-	"EGRMcBjB7UuothVAnw36NpHtriW1nHMdTTSUiJ8xZY4jxTSPpNAc1R+DYqiYqzdd4b+xi//DK3CBtRsX",
-	"qsGO17Yv1xeNyc3eJ4rNb7grTf873JYOD4R7FKP+l0ashHeXejUcrOCprxZh+oDwpanxUFEnxbdpFgVd",
-	"9NDWVBlef2gdrhc8RtE4kET0rrk1S630tb7BoVSidDDzjWqX1qopWVe9yRb5jY1g45C2uLB9qCfqGBiK",
-	"PdrQo/nc672d3dCzwnDstQj1Qe0+QH/zGTOkpMw5vhsW6WPW5db1sx23ybppNri7CJexhoPEVnLDBLOt",
-	"eK+PpQhjh6kBG8jzvIVSexOlY0kKCXeM2kCFXhO1/aSHbZeH60CKqRT017n1BrRwO4D7bRDfyIU+cofZ",
-	"WU+3Yed4Qr/pjvLEIsRfOelLk88mDVq1yd28sV3/+5D3wJ6QBxxVHZxWLM82bW7L7dhc6UbH2m/TZ09a",
-	"3rvPean8NxuQ77Obu197HcXf3QRETGStrcmDqQKH4ha+RNct4jnEGmxpJZleYe6OtzTZb9Gc6J+Auwrt",
-	"7sGLOgLqAnD2rSXnmp7XrZvncX4StmR9YcxfNAU1Fid6uaRFmYPji+/vTf8Cj//6JNt//PAv07/uP91P",
-	"4cnT5/v79PkT+vD544fw6K9Pn+zDw9mz59NH2aMnj6ZPHj159vR5+vjJw+mTZ8//cs+/TWMBbd59+QdW",
-	"XkgO3x4npwbYBie0ZH+Dlb1rbcjY3+KmKXIiFJTlowP/0//yHLaXiiJ4TtP9OnKe/tFC61IdTCaXl5d7",
-	"YZfJHMtlJlpU6WLi5+nXgnp7XDtobcAfd9T63gwp4KY6UjjEb+9enpySw7fHew3BjA5G+3v7ew+xWEoJ",
-	"nJZsdDB6jD8h9yxw3yeO2EYHH6/Go8kCaK4X7o8CtGSp/6Qu6XwOcs9dZzc/XTyaeP/O5KMLcl+ZUeex",
-	"rCZf4q72L/ZveY+tw8KcWeqSdsFFIuXuF43J1ObvEFdVkWfoAbS5GUa01cg6zoLHe4NXYsatt4fff0PP",
-	"6cXqrcWuy8ceSK4z3IcfyAreEPXvhj7961Uk0PSh8+jRo/39T/DQ0bg1isfLDV9MenKHILZPULcGtDtc",
-	"Tyq8prmhG6gfwRzhgh5+sws65niXxIgtYsXy1Xj09BveoWNuGIfmBFsGKSR9UfgrP+fikvuWRiVXRUHl",
-	"ChVucIk9NK2uBkVuO3nL3QYclsMQVP4LLhC3HNvTlaezMVF1ofdSMmEMB3wyNoNUAkU1LyTGg5oagu6a",
-	"JNjK9q8P/4He49eH/7DFOaPPaQbT20K1bSH+E+hIjcsfVs2TcGsl+pcSk+Ov9gXSb0fn3VbV7CqlfrOV",
-	"UrcQ2rvd3dXB/Wbr4H7bJumyTrylhAuecCyocAEkcGvtbNSv2kZ9uv/4m13NCcgLlgI5haIUkkqWr8iv",
-	"vM4Iup0JXsucigc5WmvlT+/Vh8aKDsz3oLjT5GPrUZlss/OkdRs8a9X+p/FHeYO6Ny4bdNxccaU8s5kc",
-	"Plarxv6qJ3rr7J1qux/j3kXQvZiRHoRaflgdH21jl7fWFNxAi9nmLXxd76nvT+qxuPGDyZ9SA/Tg+IFm",
-	"xKeMfmLZvJ0wfbL/5PNBEO7CG6HJj5hk9olF+if1E8TJKhA2WEBt8tFfVttCwLiLoG3R0n1lOyZUDIeO",
-	"Xc6+K1VdP3pj5IkVhPYubl9qmBm2lRf9u6oxSdHcz/taZMS1HjHfyYWdXLixXOgSVCMR7Iurk4+YYBuK",
-	"gx5L4mMJf6JASVC5T4rCl44RZAY6Xbgn6jux7IhY8YnJwzJl3bXCW8uXTnQdt6hHHvaZCxevxetu13n6",
-	"/2cbPr0aj1KQEeL7xWeBmc9shqXx6qRzf3tWcDSL3YvC7p2p+sYdU8QQqBbE5XoRs4vXgvJFM3k/to5o",
-	"uZk3aYfg2yC4J9ReunfxLXu5RXzrjo9AW5KEvEFzCBnc51z/Gd0en1Ijf+oFvREcCCyZwoqelhZ34cba",
-	"XKifhqrfiwir/g+YDu2g40e9ZNnVpH48asioeOveOFprVDSamvHgWezQvULLEqhUN1bSm8Nhp50Zj4/C",
-	"EpSiTnUitHlCKgKKwcs1I4n/vk0Y8c8brdu9c7Z75+xm75x91iNzk5BjRZWPE8mO1Pii52n9Rc7TbwRP",
-	"UNsC197ya6Hly52t8VpLqxa8v6TMhX1hTUg0EkI5oPa2Uq8wGEpoCRVM6RwmY6dsU6rTRVVOPuJ/MBn0",
-	"qkm7tDfyJ9bNtk7f2hflRneaQLF7BfAbeAXwy7vwbmWOdlYroayT0DBaj/TfcIuvvt0vSd3OTHbN1aLS",
-	"mbgM8pibVw4GOcm2uFNOeiMysOO2c/n7RWCofXVaeSA6DFTLiHjRM4/Npp299s6Ue3I/pdV8oW3Vo2hJ",
-	"tbpjQlNL+Ik9DsQnbJImbCv/2P0FEJpLoNmKTAE4EVOz6GZfcZGddxqcJIwXkWngKqVIQSnIkrCsyDrQ",
-	"6qxy9AfqNXhCwBHgehaiBJlReUNgrUhYD2i3+lENbu31cVzfh3q76ddtYHfycBuphObpQS0wqyYH9wxV",
-	"BIVb4gRNVfaJ989PctPtq0qsXBF5j9R+PWUFXnPjlAsFqeCZig6GxfQ3sS2+IxmsRYEtIuc55XO+V2mr",
-	"/w/dCDMjxx9itWuoX/2oa8pYSwuyaNUzWK6Z6w0s67nELPbSqy1xuGnkISwF49dVZnTtkaA68EiY4SKL",
-	"w6f/qTO8ImWHQyAaRKwD5MS3CrAbHvsHAGGqQXT9GkubcoLyg0qLsjT8p5OK1/2G0HRiWx/qX5u2feJy",
-	"ieAo1zMBKjSzHeSXFrO2gNSCKuLgIAU9dxb63OVj92E2zJgoxlP3PsXQq02sgBPTKmSBDUzaNfJC9u88",
-	"cNpijg79RolukAg27MLQgmNm5VdhBF73lNf1H3xCt2fbrA7Mq8astH9PLinTyUxIqzETLJ0aiaC2Z/9P",
-	"yrQr2OvOwFo4t6UrvmoFihsnKJ+mwmRW96KW4yOz+/38CTPVj0JuFbBtfKtaELMwUnHN/HU7fHnR25hf",
-	"X/RzZz3vrOed9byznnfW88563lnPO+v5U1vPXyYDkySJl9P+ek3scg0ZfZMW/jd0f+VzXjhpjP7a5MdD",
-	"gjHRDR+vzczQQPOJK1qKIXShBlO8wwKoqZmOcVLmFF9lWGp/0RgfZHj2xCcK1KX8bA0kI2tMg8ePyMnP",
-	"h08fPvrt0dNnRvrYt0Bbbe/7AvJKr3J44DLY6gInPpUNOMWagZjJRv3pJ/VZDtaan7EcCD4J/hKbH8EF",
-	"5MaUt7FOYg4j/ePRKdD8hUOOlUqg9A8iW3UIx6x/gqhok0wTMGecykgZzj6h9JCsBZbidXVleyeoqzvN",
-	"mYjnCfQ3bNNeDbwXECXvdfSyMS/AVVB3Y28TIzN76tFJXAnPLyqyCULkyKwRT19NJn33GS3HONjWWBWO",
-	"/77VrHeP+CjjIduODU1mVQr4IqujuGViGs2BJ04sJFORrfwTWq4icEvK2lKtw0L25RLSyvASQuLY4L56",
-	"4B6/xpLToasnWio/ePkBcLzmwcbPLTht1dG1cvPm1NF+w+DWOZPd4fpSI0i6uC8kmUtRlQ/sY018hUfi",
-	"oqR85d1gxlbERxDwrXPM875bSV0XgO7J2e1r+IfnFby03/3dooVcUuUL+Ge2gn+8imG3zvxmjDdVlDdV",
-	"vbPrjVZ8H6jv3t9Ev8su0bF2/ZUgE73kkbrLnSrLu8tV/yNUwlspLpg5OEclbD8LqxEIexs1gwxEFqqG",
-	"TqkNrxva8vQdvQwLd2wrU5eJMzxvbZUuwD6K6q20SF0Soy+loFlKFd4fcU9jfGKLVS+PI34HBBPrS/Uz",
-	"fY0C3/z+EY67lT3ZzvR2E2IBGGULaX5Z67LJNj1013Va2Ni5Av4sroAfPPMpQomkl13mDJ6r2UJM0Uu9",
-	"5FEpNWke841mvAUMUb/+eYexu97w7RBe8MymDUFAXhJK0pxhgEJwpWWV6jNO0QUaPm/aD+95x+6wKfXC",
-	"N4l74SNOcjfUGaf4IF3tGI2aVDOIPd4C4C02Vc3noHRHEs8AzrhrxXjz+F3BUikSm/dp1LWR6Hu2ZUFX",
-	"ZEZz9OH/AVKQqTlFhDVL0KGoNMtzF0800xAxO+NUkxyM0H/NjEFnhvM+pzpG7p5J8lgYeE3dVpQdeLHx",
-	"J/sVLy245Xu/Ebq37GefDT3+MnWfow8xO8iPj1w9seMjLBHTRBJ7sH+28FLBeBIlMqPxXUS+S1vkvnv9",
-	"EwnoQROTdLt+xo0xrQVBQd88+389cuiGAXq8aLmjQzWtjehEC/xaP8Tuss5FYo6M+MzEaM70oppi5WV/",
-	"x3UyF/V910lGoRAcv2UTWrKJKiGdXDzcYB/cQl6RiLjaae4/jxO/+zx0vfHGiO3t/YBevoPyrV93zdaN",
-	"KUq7Cqm7Cqm7Gpq7Cqm73d1VSN3VD93VD/2fWj90b62F6GpubKzo17ppnNlX7SWkduZagIfNWrX/+mFJ",
-	"pvcIOcUn66nRAXABkuYkpcoaRtxmyhVsvtBEVWkKkB2c8aQFiX073Ux8v/mvPeaeVfv7j4HsP+j2sX6L",
-	"QPL2+6Kpip/sA4bfk7PR2ag3koRCXICrBIbNswpjxbbXxmH/pR73F9nbuoKurHNlQcsSjFpT1WzGUmZR",
-	"ngtzGJiLTn4fF/gFpAHOFpogTNuiq4hPzIt02TnU3TaPGd19/X6Nh28OO+SyK2ryKQzsI9CU5aq+nRA5",
-	"T+HJpktZl1Q1rFtLFV/OAJT/zQWs3Sw5O4cwBxezDy6pzHyL6MOzTZld/7By37XUrj+awdKbBF2gZ/XM",
-	"TNuKoebA2XsKsO/ZslU801yYM2tiH3jalNmOL0aZfvcUek0to6G9inDNQLrce/Rm5UJBokVTqXkYjnWo",
-	"cCUXb4IENVikxgJnd0vFnjbED0YkoleYolMYkdpZoBEq1EAn8RqSzf0fnnMdsl/Y7+61rdor2PHBR8b1",
-	"9DqYZlyT6CUqF5R6XSSGVD8jrkLCgCPaPh5sEzlu/IRwp3vvdcY8Ozv7QF7ZStn4tOg5rCb2Ubt0Qfkc",
-	"VI2jkF/s1SGb3hPkl3fQeHfPFhvtlQw8OH7czznv4v2cpeeQESOvkMVcKnzkMEHu12V/Zwwl+crfI7Hq",
-	"8MEeIYecQFHqFbEStuPz7kzO7+l18y9DBd7WjJH0xRTYBchb8pQfZj0nKTAMd8up7CDrJ9JLPsBO9DJy",
-	"tN62DmTkJN051wZEZaG4CwfFTjvutONOO+6040477rTjn1479pxSO7fN53DbfHHHzZ+oBvau3PVXtqAw",
-	"mbX1nsUtvNn1q90xa9z5qZtX8cNX5tHLWL8v//7D1QfzTV54B2TzaPrBZIJWxUIoPRldjT92HlQPPxpR",
-	"Sud2BOfgKyW7wGr1H67+fwAAAP//rYSwcWn0AAA=",
+	"H4sIAAAAAAAC/+y9e3cbN5I4+lXw4+45ib2k5FeyE5+Ts1fxI6Od2PGxlNnZG/lmwO4iiVET6AHQEhlf",
+	"f/ffqQLQje5Gky1ZfmX0l2U2HoVCoVCo59tJptalkiCtmTx+Oym55muwoOl/PMtUJe1M5Pi/HEymRWmF",
+	"kpPH4RszVgu5nEwnAn8tuV1NphPJ1zB5HPefTjT8sxIa8sljqyuYTky2gjXHge22xNb1SJvZUs38EEdu",
+	"iOOnk3c7PvA812BMH8qfZbFlQmZFlQOzmkvDM/xk2KWwK2ZXwjDfmQnJlASmFsyuWo3ZQkCRm4OwyH9W",
+	"oLfRKv3kw0t614A406qAPpxP1HouJASooAaq3hBmFcthQY1W3DKcAWENDa1iBrjOVmyh9B5QHRAxvCCr",
+	"9eTxrxMDMgdNu5WBuKA/Fxrgd5hZrpdgJ2+mqcUtLOiZFevE0o499jWYqrCGUVta41JcgGTY64C9qIxl",
+	"c2BcstfPn7CHDx9+hwtZc2sh90Q2uKpm9nhNrvvk8STnFsLnPq3xYqk0l/msbv/6+ROa/8QvcGwrXpaF",
+	"yDiuO3lkjprv7Pjp0GLagySISkgLS9C9A9F09IfCGEif3SP8sgOE0HHH5J25sUfijDY/z2GhNIwkEdf4",
+	"Rmkknv+TEknGbbYqlZA2sS+MvjL3OclSo+67WGoNQKt9iZjSOOiv92bfvXl7f3r/3rt/+/Vo9v/6/37z",
+	"8N3I5T+px92DgWTDrNIaZLadLTVwOrwrLvv4eO3pwaxUVeRsxS9o8/mabh7fl2Ffx8kveFEhnYhMq6Ni",
+	"qQzjnoxyWPCqsCxMzCpZgDE0mqd2JgwrtboQOeRTJiS7XIlsxTJu3BDUjl2KokAarAzkQ7SWXt2ukxyj",
+	"BOG6Fj5oQZ8vMpp17cEEbIgbzLJCGZhZtee2DBcglzmL77fm6jRXuzvZ6QoYTY4f3N1PuJNI00WxZZb2",
+	"NWfcMM7CTTllYsG2qmKXtDmFOKf+fjWItTVDpNHmtK51PLxD6OshI4G8uVIFcEnIC+eujzK5EMtKg2GX",
+	"K7ArfwVrMKWSBpia/wMyi9v+3yc/v2RKsxdgDF/CK56dM5CZyof32E+aEij+YRRu+NosS56dp6WHQqxF",
+	"AuQXfCPW1ZrJaj0HjfsV7germAZbaTkEkBtxD52t+aY/6amuZEab20zbkhuRlIQpC749YMcLtuab7+9N",
+	"PTiG8aJgJchcyCWzGzkoM+Lc+8GbaVXJfIRIZXHDolvTlJCJhYCc1aPsgMRPsw8eIa8GTyPoReAIuQcc",
+	"IceBI2GToBk8uviFlXwJEckcsF8856KvVp2DrBkcm2/pU6nhQqjK1J0GYKSpd0v7UlmYlRoWIkFjJx4d",
+	"yD1cG89e117AyZS0XEjImZAOaGXBcaJBmKIJd7+t+lf0nBv49tHk3b6vJddWZKIclnAR/SIPT6hWe3YO",
+	"24O0TNMbd/xjEXFdAujZ4AvwlLjcGhHo29TgATJtbthKGfu4VNoOwRdPcDXYRh6YheoelJ2HZNQBoUYz",
+	"x8USogR+9TwuvepW/xGrjuc2YjlzP/doXyxP8fZdiIJu5n8gyQc0VIb4ZgsR4a42Yim5rTQ8PpN38X9s",
+	"xk4slznXOf6ydj+9qAorTsQSfyrcTz+ppchOxHIAmTWsyfcwdVu7f3C89A3m3saOcSVo8GVzg1GLnoJB",
+	"0L1CJ3+9FvScQdlGyAP21Alr1OD+EEG059+zOXaTPLs/KXVelTH2sxaM8y07fjoIwGbgvbiT8RzVZyp+",
+	"NZ5uwkvyqj3spqa6ASAHN7rk2PActhoQWp4t6J/NgoifL/TvE/fATxPAJcxXSp2PYIq+JbvEdxHoAZYT",
+	"jXelo4fn3ot0RIdeS/ba/4Y/4eUC7vUZ6RoOSVB7/DYa/N81LCaPJ/922KgID91Xc+jHxRnfTcMsp5Gc",
+	"dK0ZS61K0FY42N3LwQ4JHYjWghvrzhQTMocNnpsVyCDa0rm5BE3nqqysk2D7ahS80GckE/Sn+cVATvy5",
+	"5EshCfKpm2TNz5FdcalInMZdAmODVOHkfBq00ep50cTL/gfs2H5lmAon3kOuwb822FppiIWRDt1NJ7Fk",
+	"mlAnSMa15lskvQzlf71Ghtr08VI/jS0srE1/E4S0mrstmKnFwsCA0OW+pZSnjpExgXqdQmXn6S3Yscn0",
+	"qT8sN9GihNwx7IDWCcfGL8bydRkgJxD3TkZig4FMITNfaLVmUKpslYbBbmR6crzS2hsyZbxQctkQTM4t",
+	"r4nXUQgeoS0SnrDRfG4nB/jsCU0UHU8nINRs5ddaoojwNU3tvVvNm+607+ofiOB6w7ePcodu+6Nh965m",
+	"LAj0iJUkMZNcxyXzRocpM5Zri4iiDbLELiyeUCXhAEGONKaeg90E2/LzD4iiXoyEWgoNzZkqLdGWVe73",
+	"ZsrW8ezzgBbip2PYZnOikkwyWBD+MIxyDzXWO3Z1SkztHpftzWtT2s1fxU3PJLzNZyakYwnUdOrU8z/w",
+	"gssMboLy536oNNGtVJGDbugeJ+/yO6GpmZDL9p20a/0vhBS0kj+7nrdn4ibORL2XVz8T0UYHjfJBTW43",
+	"T/44ahIo/NAl+R9Q6r4BUvd4xT9HEWmYtypsij5H0WV9sRFKnb47OtwFvd882a55DowvuZDGpsh26Pr3",
+	"yxqz6afRUcGtZnNcI/7pIZkG7ZXSuVekriB8JIr4AYWtm2A8OE4ffTQ8WwHH6VGSGisvUcc/Uz/iHqAT",
+	"Uu/P9AcvGH7G1zK3QY2OJgRhmDBMRf4H0dvEzYQNcCetYmunbGeoJL8SlE+ayXu76tAyZi+fOf2+l379",
+	"InDpjfXuaK709U5v71HS2CQZx1FrKwSuvPMGxKZVOfP4Sdg1XIPOQI1Xyh6u1xk+hasWFk4s/wBYIEn1",
+	"JrDQHuimsaDWpSjgBs7riptVfxGoaH74gJ38+eib+w9+e/DNt8gySq2Wmq/ZfGvBsK+9/ocZuy3gTupJ",
+	"7FhSevRvHwVLVnvcvRgigOuxR3FHQM7gMMYaxv9Ub3UlbwCFoLXSyfdAqZVVmSpmF6CNUAmp5JVvwXwL",
+	"Uj+S/aPzu4OWXr84N5nFKpmDTisjNnL8heiGPt3IoUuxswNuvYnV+XnH7Ekb+cHKYliJJvqNZDnMq2Us",
+	"MbiXI2c5dSSG+FLlcGK5rcwNcIFmsAYY3IgYBD5XlWWcSZXjgcbGaf4w4FNCxmwn3MUsx67c/TMHFEMz",
+	"Xi1XlqH6V6W2tuk445nblBndFQOyfmM7da3cdM5fodDA8y2bA6D+ydu5vAWOFsnJPF7rkjx3SsreEVyl",
+	"VhkYA/ls9wO8AS20a/QDQ3giwAngehZmFFtwfU1grbK82AMotUmBW4sTQg5APW76XRvYnTzeRk7vCkcF",
+	"zCricgVYGELhSJxcgCaLzwfdvzDJdbevKgeUif4GPhVrPL5Mcqm8fjA5GCquZ/uOLTaK12JwBdFJSZ1U",
+	"GnjgOfETN/Z1UJaTyGiYbWvRcYphgAdvFBz5r+Ey6Y+dKWlAmsrUN4upylJpC3lqDfSiHpzrJWzqudQi",
+	"Gru+vqxilYF9Iw9hKRrfI8utxCGIW2+rr1/k/cWRWxSpaYcVBgGIBhG7ADkJrSLsxm48A4AI0yDaEY4w",
+	"HcqpfYemE2NVWeL5s7NK1v2G0HTiWh/ZX5q2feLituHruQKc3QaYPOSXwSiKD9oVN8zDEVQkJKk5m18f",
+	"ZjyMMyNkBrNdlI/H8gRbxUdgzyEdEJK9bjyarXM4OvSbJLpBItizC0MLHpDYX8WOFX+B7fVkllHyXHeq",
+	"hECX1CD0fEQME9JYXhSQMyVr6iHhqzXJa1gKY/X1laptGSqyJKS5sXdhq18QlfT2m3PYMh0B0zboXGpO",
+	"1Cwk4wmLj1PvSdX4N5Bt6VILax23//tS8SJcdixbcbkEJQshwUmDbKb+jvJ447CztbD3RRMvd6y2Z9dC",
+	"d/j84MYB6A9JfADaydGjyQ7ILTSwJkECuITMkkjjYSY3vshqduO6y/4UKXh/JBd43sK28ydwzqfSFltm",
+	"qrnXBLeoT9jaNbH/qBD2gLHTWmds4AI0vlq5cdeLdyReC3wbmCrLAPLHZ3LWgqTxWPm6+dPdlWfVvXsP",
+	"gd270+1jLN6QXnwtlSr6fb9n96buE7382PfsbHI26Y2kYa0uIHciIDXPK+d2S732Dvt/6nHP5M+6Z/dd",
+	"860THld4iPHommqxEJlwSCfbBV+qzkUnFX0htf4aUFw1TKDelhS5wjgBwe0L49KBMkBzN/HMTIyKFM8j",
+	"94A+YzIMNjzDVXI6KFunWK7prK+lsqqcjXdI6M+Y9Ea4pnm7r2J3b57d8J12Xj0tdETkOkKj3kNGEoKR",
+	"xhWFuy6863nwTy6EsT0g/Quo2AZwkSC/Mi000wrY/6qKZVwGA1ctTipNMhr2pRmEieYUjsk3GIIC1uAe",
+	"pfTl7t3uwu/e9XsuDFvAZYjXuHu3j467d90hUMZ2bvr3vt2v6rrayCBJJ9bdd2tvsrEXbG8uUr3VsNTY",
+	"uWFHK7s5TiCE5BwUwBM+PagP3Y8HGnfU2qOhj5+GCYnVGBNs6Ljw/3FucTew5vEOe07iAY32m8Znb8/x",
+	"j4Yfu/kdx0Da+mZuhwKt1OIGFi/yTconOYdNarP90SYV0FeGlXxLFt3U07ZEABORHKDPC9Ivq0WHZTF/",
+	"Qa5EmRJim4i1/+/r/3qMkWp89vu92Xf/cfjm7aN3d+72fnzw7vvv///2Tw/ffX/nv/49pQ4wVszTtog/",
+	"c0OmTH+1bOSxdNZENOaTEmnr36Zq8bHh7pAabmbAfLSkMWT3KrUh9FDxnnrvphNUPRTbG5BC3EBMQ6nB",
+	"0J0Rq+yM+6oWccCapzyzNRbWfa236/rbwJv/dXgx96jUvZ5mayVhmwwZFxJe0MdUb3dvDXQmCWKob9rl",
+	"4rfwtG+B1Z5nzGa+L35ptyNO/BzgmbFize1NmPwWADO0s9D5SHtDVsslGHK1ASDfgUoK51uwRpBnDuYS",
+	"NBntDs5kS6Cl2Bn3sHB2BhwGO3PLCuDGshcC7U3PAepwi4AcCfZS6fNag3gwrAId54yMg4NHHrP8HIx3",
+	"DXOv+PT4GPq0gAHsrIWkYLhWWgIA9rVUtsbJnUYv75d4JtGwZRVGg4ocobne2n1M20ijz6LCuFBqyi6V",
+	"tquenHrJBblmegrsiacDQOgMpBXFAI6a783djT8QonJhrBbziuawXXojUxg3pHPaN/nA8lsT+PkXAA3F",
+	"spKL2tLVdVyNoHWIG+3/9hzgVYOYxMvHcLTPjDXYtYAIi2gvznlDF4VTl+DTO42yPVExnXmvGhkz4g3W",
+	"iYpp6Ww7FN1DU4vcpm3+1ZzVNl1cUdaNOGygxgbjbX2PBMgJEyWnp51zqUpjxuGQ1t7Db5fJv6pjpG/g",
+	"hu+O27Fqx/HYZJWDomScZYUgm52Sxuoqs2eSk1UgWn7CIybYOobtRE9Ck7RhKmE38kOdSU5HsrYVJL0d",
+	"kpz6ueMkbfbSYdln0rcaccNhS9RBLTCu2Cr2O2jF5pVldszVdyZv7O5bggQjzCwtLf/ovpLQ7Je/8gI0",
+	"/u07N+EfH1fKD7CLfBDy46deIXb8lLQejXG9B/tHs7h+vuJAV47tnUV3OjpU09qIDjMOa32T8nxcqhka",
+	"XsjxbbIUdlXNDzK1PgyKwMOlqpWChzmHtZL0LT/kpTg0JWSHF/f3sOL34Fcswa7eTSee65gbNxr4gVML",
+	"6s5Zm67D/61iX/347JQd+p0yXyGoXqvyAQ00foax1pmOKiTWg3Rsgu9CNGQc7ZhQPLsPbRsI7pxLL+SC",
+	"rdEG8BQWQgr8/vhM5tzywzk3IjOHlQHtQyUOloo9Zn7Ip9zyM9m7nwbD0SNHblZW80JkqOdL8RWX1aU/",
+	"wtnZr0jdZ2dvel46/aednyrJYNwEMxQaVGVnQSDScMl1Sl4zddoCGpl675x1yvzY9KMfn/nx0/cML0sz",
+	"K1TGi5mx3EJ6+WVZ4PIjwjSMOrl4OWOVDhxcmAAN7e9L5f2UNL8MOU8qA4b9fc3LX4W0b9jMm62OyvIn",
+	"HBMNi/B3zyjxQG1LGC2cR9E3zWApIZ0W7p78sMGwO4yoMMnlW+Al7T5JGWt64xYFo24xTmofVxqqWcDO",
+	"R2gEx5XDj2hxJ65XyD+WXgJ9oi2kNshaGweV6+5XFAN07e3aE0fEK7uiTBDJVRkk8bAzdVoiF4ThvYbQ",
+	"6oWHwGdwQul9Bdk55JRMBtal3U5b3dWidT0H1iGMS7rkAg0oMwhZczAZU5lzL8Bwue2G8BuwNiRZeA3n",
+	"sD1VTWKRq8Tso0ecS4M0Q5oZOqhEqdFNisQaH1s/RnfzvZMjQsrLki0LNfenuyaLxzVdhD7DB9ld7zdw",
+	"iJPBZQENO+i95DqBCOowhIJrLBTHey/STy2vZZQamRegZb6L9UeDl0vyOkH///at0WPq6ahvajxDhU5y",
+	"OwC/4H7gGer6gIaZnGHUxXAyyiPqCXdekCBVu5+6k811y34nl7tAS1MJaNnc6gGMNkZi8WHFTUg+lk+j",
+	"AzPqot0bcYZUFDwdRdt7ROC8BVzwIfwPp385jtwXo0RstfNTYGzdwzCtcyO5FK0hCUzI/BLSvUymV0rd",
+	"Mp14j/rUdihJUkYOBSy591vBxp3Y369MtEEIx8+LRSEksFnKE5IbozJB5z3i5X4OQCH0LmPOBMFGj5Ai",
+	"4whs0qjSwOylis+mXF4FSAmCPAR4GJtcBaL/w36TcJNFyYu3e8XQPu9oDtG0yYTktjGVmCDJkoZeCK1W",
+	"zDWZQ+89mCJRJmRCqdRXXRlAxalQctb2EziHbVqqACLDk9AtejawrwXG2G7vRH4f0VOpvgjqZFIfV/Fy",
+	"oSzMFkKjcyzqG5LLw0bPDQmDz7Fpmv20UMVcdksxkM2Fpj2H7SwXRZXebT/vX57itE12JlPNvQcqA56t",
+	"mojWvpfqjqmdN/DOBf/kFvwTv7H1jqMlbIoTa6VsZ44vhKo6/GTXYUoQYIo4+rs2iNId7IXePk+hsHx3",
+	"3md61SLDtLzPGiKtQe8w5WHsXeJXBMUw53UjJdfSALp7FZRsifwZhY2SmfYj0wbOAC9LkW86b3g36oD1",
+	"Dae4iqDuJP6Es8SkHmwPBqL3eir4QUPQObgtje5Ml5ZWdlO47MfMaTvvS8wQ4qmEGc5GgaRNmX/34QoD",
+	"VP8C279iW1rO5N108n5P/hSu/Yh7cP2q3t4knkkR756ALQ3eFVHOS0yvwYuZV4wMkaZWF540qXnQo3xk",
+	"Vpd+fp8+O/rplQcf354FcO1UZTtXRe3KL2ZVGrhVemcGJSethrezE8Siza8zMMTKlEvyde/IcsjFPHG5",
+	"49UoyprxgnJlkbYH7lWVeJ2eW+IO3R6UtWqveRFT5442j19wUYSnaIB2wHZHi2v0qVfmCvEA760VjJS7",
+	"sxtlN73TnT4dDXXt4UnxXDtS+K5dlmpTGyYa32gUIXEGR6pox52DV073mZOs1uRcMDOFyNJqCzk3SBzS",
+	"6XyxMaPGA8IojliJAROCrEQ0FjYzI0x9HSCjOZLINMl0gQ3u5sqnNqqk+GcFTOQgLX7SPlaidVDxXIaE",
+	"Qv3rFGWH/lx+YOoTDf8+MgYONSRdEBC7BYxYw9wD92n94AwLrVXjXLYUg1cwVMUz9q7EHUYmTx+emp2r",
+	"wqqtKY6rgfT5HxKGyxy9vxRJnDwqrTwbLC0yeFscDd8U2PsKd0RzJRC48WXgwnp4YVRimEpecukqBWA/",
+	"h0Pf24DTGWCvS6Up1NtA0sVAmNlCq98h/ZJd4EYlwjc8KklcpN4HiRDaLhOttTJNDZiA3xiOQdIekuSi",
+	"j6xtSBw44UTlkeqc4tGCgotLR9auqkHL9p4+HFELc+jGbw6Hh7nnY1TwyznPztMCFcJ01BhpWqo4q1jo",
+	"HHbBaw0b2ovsPXVb4eKjS9BNjFWPGK4rHH1ZJJ9DJta8SEtJOWG/7T+Yi6VwWbIrA1HtAT+Qq6njqMjX",
+	"b3BmsAY1xwsMDmyqm/jdyMWFMGJeALW471qgAYHW1kpn6L26LEi7MtT8wYjmq0rmGnK7Mg6xRrFagKWn",
+	"XK37noO9BJDsHrW7/x37mrT+RlzAHcSil0Umj+9/Rz417j/3UpedrwGzi6/kxFj+xzOWNB2T2cONgZeU",
+	"H/UgGavv6ogNs7Adp8l1HXOWqKXnevvP0ppLvoS0NXe9BybXl3aTlIYdvEhqlIOxWm3buXqj+cFy5E8D",
+	"fnXI/hwY3slzjQfIKmbUGumpyZLvJg3DuRI27h6u4QofycRSsnSa2Y+rIHZ3eWrVZAh7ydfQRqvLu4yh",
+	"DKJJGuQZ4sFANjDQF+lJ9MAGh3vT90WfOjlb49nJ7zQemxH9pSYmI15yWht4V9d7Z/fQY0UtHGU2iNiq",
+	"hVge8aRro7jS6XXyCqf65fVP/mKgfKT9IPSGG/pLQoPVAi6SJ7breVhLJvV1ETCfElB8Ek7KtJoqvtZN",
+	"YNl/pPk0rVfO+0m9ji2s9+Y5q2fYtwAaLbEIB3uUkdMxg2BpbVJwMqc7D/Zr/82lo/VFr+oHk6n7QY7M",
+	"I2SIHO32dtp3aaktuyT1uf/EmL+ihmVf8cV2UGfU2gPQz6Q64CA1+JTpzOAOE42Nf+4e1W72j9hK7IDj",
+	"1oWmmg/DCKwJLzVJnzoOIkt640QY3t4RtiIDbTqnyMDZpa87iDydxbFJOusoPEHtz5yVGrRWesq8ezMR",
+	"NFtji+D9lDgRLh9rgqwbv9JRRTHaCLqadxEPSpNRzjp1Gsg+muiTP9xY2QqkewvEO9wjkdRWXieJSWpf",
+	"K1Hkf22iNTopfDWX2Spp+Zpjx9+aQkb16Xd3Xuo4ZSsuJRTJ4Zwk9VuQuBIy4T/U2HnWQo5s203N65bb",
+	"WVwDeBvMAFSYEI+NsAVOEGO17b5euwyiKzyjeZrEYM013M82HKUpHbgq/QfnbWupnJPSPksmA5nTW+uA",
+	"ucQ1CEsrUpPeOGJdFS6NBeRL0F71XpWF4vmU4ThoE2BuVtfHJ0yhLJ1LFzzYWsVwGYZxDnDN6U05544f",
+	"Z7e3oKtBMasLj6SCRrDFaWjAREfbT8J/jJ12kaio0EVUJ6MezUl6RBP4h7U8W/mkR9MxJD8+vWygShOV",
+	"u/N/ZzUlunOHcPsMsy7B7NQJIpfCuJKdmJqoRdUBjMDBQ9xKe3m6ktJRSvp1sCNy/DpoD8DRuLVBIAlZ",
+	"B/FXFPKNqnQG42nSnecT6pUiyl7q3l7RNpcFoc5vHkoxZ1wqKTJKTRMVCa1B9uU/x8hyI7L4dJWV4Yj7",
+	"E5o4XMmEwbXTmMfiYArh6aSFuL66PvqKm+qow/3XUp1JVMMtwRrP2SCfhqTQXosmpAHdFH5pRQW3pVLi",
+	"kEmj9qw2flyRjEgqGngsPsdv9FAU3lnzXEiSRT3aHEELp+eiUnt2BZIJy5YqxLt3I51/xT4HlE4jh82b",
+	"g1Caj8ZwBjxctrNW94c6CrZrbyvGtk+wLSNjXfNzy8ncTXpUln7SFCcw9Q6n0loPIjhhg5wFI1CE3Hr8",
+	"eLQd5LbT6YTuUyQ0uCCTNZR0D/cIY0A0fIYqRUdR1II5Z69kZKOQCTB+EhKawpGJCyJLXgm0MXReB/qZ",
+	"TKN4Ppqnoama7NTJmHfrFffvO1RngwkltMYwx/A2NsnNBxhH3aAR3DBiIxwKpO5ImHhCtYU9Ivupykmq",
+	"8kJUTu68neTlKcaBjDuk/W9fAHvLTNXdreYZtPqOuImGwqByYbgxsJ4XCQfGp/XHKIE/7giqqfDfqxXK",
+	"8m4NV3asCz4M1PHK8mV7pJ50iHs/Q//56+1K0/8Gt6VzBuI9SlH/M62VjmNJe0kAHeOpo1LJeUuFcir0",
+	"qKhDkto0i9/Sj7amMsZutcNwjYspscYBF87XTVYd7rivs8wMOXJmg37H3PqgAstZk92gzw5dYYrUCM4L",
+	"hL77Iv9JteyQ54dz/MDPvd7j5IaeFDaQRDZCaHAp6gP0l+CvyEouvNmxOSJ9zHrP5r6v+Rifx2aDu4vw",
+	"/sI0SGol7SQrSX3LAmBEqpco6nh0upo+FnZnU3oeQTIikdKIXDt7xJ7hTCkpXPYKtiW02Easy8KZti6a",
+	"XO9xL/Zeyudg4+mHke0PwG7bnSOrDXRcdiItdxrJO/w6/ifK7n4tR45+oM1u741eAu/ErvTzUw7k6r4B",
+	"u0AwrEaTQtfveaxtYGcMCp3dKPIkzBuCLoZ9rXeo7L3lZBuUzem8oSFz/DBgBb8GXFeKMMJZfOzHX18/",
+	"pxl2le/fk2V8VPRN+wANBt0gDBeKotT2RNyMXKaLenFrbQZ+7+WivnwojPC0WUM7eBAn5vX/mm2mwEa8",
+	"LC6AzVrnwVWTExJCRUhhcBRsL5WdRRE6vX5S2cG+sClF08kTWJPBOkTENLYgBG0ynbTnxBZuoP2WHxqv",
+	"4U/t+Jx+GE4qWCcV7LM3FCjapyQDbJLIp1gfgK4ZXD9f/JUY3lIZI8ou38MZHrccqoSlVGu54J7BhmQ9",
+	"qrJLhTTlgRBKmtrU5Qk8GoTnFwiZafIAMSEztcYRlIS0sbBen6s2MVw+u10SpYGIYAdj+bwQZuVClOua",
+	"2TQmddhRODsXGgYE6fh6pB0Ji8dtiibtwBRbNQMKJtNJwGcyQtixcrsd4vXuazM75ojA37I6MGUa8mbH",
+	"yAlVXamPMAxkprflkKMS3bMygwFl3WlIWY1YlbXbjBt9wPPIPS9nIYJ8H5sOHeqQ86bsEk4yJYGHL0fn",
+	"GzjlyydD9pYaOAP7BbAaMOOdoz4ISCVFILvq6FqUO0rK+9tai9Idj5jFl67mr7kkPj3fRrC269EMJQ9Y",
+	"I/fbyVxWyjjvs7rETkR1onUiPXG6w9vx9RgkHEsp363ezpbVkNRSt2E//tK4dg8OOWhJOk0VJZSwVDaK",
+	"+toxdM+3poW+mMH0+V3qiHQpM32N9MzhCTd/y0Vh6tp+CUcO0oh3k2Fe+owPFJJbG/dC7gcw4bcQve5m",
+	"KcQ5xBWsyJSKAfShRVI32DjTpCMrurGK1IyJNNCLembRuCX3w/WGvG2yQhk8f+NeZPVD7Cvj/NvICnMJ",
+	"2sO1AO0r19HpKJSBmVXhwtwFxy5U+GrT10GCGcyn7oAbzBnyukmKQi97Tg977n334gUyDWsupE8auvNR",
+	"ug/ZT9z3EJ8WJIpOus7EuIFe91dhDw7pwvSQGFP9gnml3P64t+treTvde+q0gjI+/RSF557D9tCptFyx",
+	"JlPjKD4vrvAmbCCr4uQ/HTTenGa5VKqYDdiEjvspQLp4PxeYpQolz9pddKC0CPuaTBG10f9ytQ1VGF0N",
+	"nTsHjB1J57Ad7P/t9JidyeVXdtf8m7juT11O50ym/YGJlev3PFNhmN0nyYDM33sqN8juiexm4PLEpFH9",
+	"QjtjC4wnLPJdxV9DVA6K5I24UhLmSp0/k1an9UqhBQNsMo1LLKrcpcD19/PVX1soED0mYajz4NJQ8C1T",
+	"zZ2hsxVualoZSB95sfspQuDXtZXccExz/x1/dLNeQYGXwug1k02MYiZ9nX2CmdSScmIzE5I5uWd6Sbyb",
+	"x7kaL+EPED9fpgfw3cLEe9xR+XIy9eCkUB4HRu8xD523TBouD1/Hk0NpuGHTRmTCvqJpox/yPXZ5tA66",
+	"3CoD/XWOf3fFuE1RW722sXa5PnKHzWl2Psaclvadxu5kz3MICQn3+kT60axxrXr2ft7Urv916M3lPNQG",
+	"HEU7OEWf0r0RELHbb5ONmxxbf5t/+6jlPfsx84H/5l6vQ6/RKxneu5tAiEmstTV5NFXk0DvCl9d3S3ru",
+	"hkzLCcYcCkv1wwkME/JCFRdOOxHuyWkThK5052xPmVTW2efsSqtquWKcoTsIxbH+8vqnK17SDrY88Qi7",
+	"yfiPepZxz869www/3PZHc/jdmOIoTkJTMoNWxa8kI9FFeuSVtSXuE/5rcAe8+IS7FJKtcg3s1c8np123",
+	"04E7EedKlLSYTgxklRZ2S9kxgsZA/JY0vGCGfU1P3RXwHHQTY+xDXK06hzq/yrJuXZlgO/tR8YLiH/Fd",
+	"QUYMSxUMn22oYofnvd9/Nf9PePinR/m9h/f/c/6ne9/cy+DRN9/du8e/e8Tvf/fwPjz40zeP7sH9xbff",
+	"zR/kDx49mD968Ojbb77LHj66P3/07Xf/+dVkOhEIsgN0EuITJ38ji/ns6NXx7BSBbRDHS0G1jhEpyCpD",
+	"5nSeEbfHN3cxeRx++n8CAWP6+mb48OvE7+6EtvDx4eHl5eVB3OVwSTqImVVVtjoM8/QLRr46rp3wXfwR",
+	"cQ3nX410cDBp2M0RfXv97OSUHb06PphE2rjJvYN7B/dxfFWC5KWYPJ48pJ+IQ69o3w89Q5s8fvtuOjlc",
+	"AS/syv9nDVaLLHwyl3y5BH3gU8jjTxcPDoMP7+Fbf/Tf4ajLVN6QUAe39iHv51GfupchMqK67m1kCzPe",
+	"CDdlc5chg/mnqszJy9upNMxkOqmRhZURQ4634+YyDEk+XNazx78mio8sxLLSnTrttcuTO0xMGPbfJz+/",
+	"xHP7wkmrr6IS0weBIP9Zgd42BOOgmMTpuoJRw/tb+1rVSVtcqihrKiE9zYz7HFFqrTlt2ITVFcSQNHc3",
+	"3sf3Zt+9efvNn94leM2b6SSggyjpwb17N1YjoY7laF8MhwEv1xgIh3p0gyC2veTeG9DucD2u8IIXSDfN",
+	"HTuhBd3/Yhd0LJ0bBLI7x5bfTSfffME7dCzx4PCCUcsoSUOfFf4iz6W6lKElXsnVes1RuzL5EaI08bH4",
+	"/m6Q5R52yyKn+S/U9qQQVRR1o0/OsY03ngc+YcsUGa8mN4jaauhjlJSEA9aEdRqfSHsZAiRC4hFR2GA0",
+	"c0pijbrVaQwDvb+mkcjakVeBlRoWwiWuJNscjeHlEH9pEF8zce1klObx3nXafdBBPUVGWz73tvmgIKJe",
+	"Jk61f2yOXccj8p04GLpeTtvlonfeL5+Kafdujxcu5UirgJwJMVHuCh66xgqxFu1brO9mmFQMYWQNUkg0",
+	"2wH7xTgjF311MiUGi4g8MrRquBCqMnWnAcBwiBRcw1g4dga4hoYd/dVJaJpEHVjpqE6cm5q8bnBFzKRB",
+	"mMNCaejCwDd7YOCbkTCketvNzGsf+vJJybHhOWw14JuOZwv6Z7MgeZsv9O8+unyc6OKThw0sI8phNbyK",
+	"obRiu/SqwzO2H6FXmLfpmJz9JIqh9EzMMaGQGsirC5rCZcTvajf6JJmTJxUNtoPa0w/vOTfgYsh2ft1H",
+	"pBF5uqsDuXITXcgle/38CXv48OF3PpuNhdybjIZW5YZ0/hnxqmrgcm7rz2OW+vr5EwLgpNb9jGq193zW",
+	"zOGmVk4jfn4L/3yfQu/7AkmWox5ZIdjJErlLSGYj8Yf8FFztkSEdEN5PM7riEpJh8PIr+VJIL/vQJGt+",
+	"7vR7LsWLTzQTLklvfcZBG7caf9PW+ppjdKlQodioh1wDiWxSuQw/0d3aV5p2xMy+TIPqVLzWkzKmJ5V2",
+	"adxuGlCruffTUotFMv8o7oT7lqp272uoCtuv+XiV8ikpG3qzKCF3DLvDt6wJ4/eQE4h7J3M5nbzrJQng",
+	"O7wuB43JfUPylHF0E24IhmK3AvE6CsEjRG7wrRRo72l3DsJJhK9pau+HjdI79ffto9yh26RGdO/L8JZX",
+	"3PKKW15xyyv6ap+j+i27Q7nict7WKdN2alMmX7im8gees5B66I+oo3x079EXuyAXaEDarBBY5HVgfzzt",
+	"6zf3Hn6xqzkBfSEyYKewLpXmWhRb9ous8xm9l3J5LJsarXE+9P7vezXPhTA2LgrZmhtz5AfHBaO0d6ot",
+	"tVBoqqYAixwyDZwMy0pTlimrK+kCdbzfK0j688XR38gD/8XR39j3mBY6WBMpCUdieudv2tbr/gi2Hw1g",
+	"ftge1RrZL0PHe1ojKXKyj1FvVcipTUhb8833QyjbSLND63hFnecfV7XQSZ/Tp6LaydQLwX2ByzDY8Awj",
+	"RTiZM7buuWCqeZMQuy0QW1XOxovd/RmTMvc1hbhEjjIqSr0bvtNOSuWOj5OzAihXlXFPToMeMpIQXO8x",
+	"eLu7X+zupsR3hWdaUA685j4Jd1ULyKagopCxibEbQ3HA/ldVZPr0D6RUfQ+aQZhoTh941GCI4vCkrbFz",
+	"92534Xfv+j0Xhi3gMvip373bR8fdu1/80+IF39QWE86kkjNJRXIvgEWv2luviFu5/AuUy3nNcyo5IJ/3",
+	"+E8veKuRoiPxvUEJivAtG+d+d72oPRP5lAnbSIbxp7jAeF3L3Gf4nzZlC8knhHI++gxwZhrK9+EnXyfT",
+	"7ce0V9zvICWkR8bXH7bHT8fI5a01RVXFUrJ51yY8LKL3Lq0P6iPX9Ezea+m9+dA3wKfUBn169c3OXXip",
+	"LHtOWsYPzNI/qGdamqxGMpvDOKv3TlWBZ2LQTStlmCqbdLuNy1rsKhbn5SGtqnZVr8gFrK1BGHBd28dm",
+	"jprMxZ8Lq7n17Fr+a6kYhtPlD6Zo23+ahsL/9iYuHWERbYxlSfvncD60L9QGusfQVO/YTRikb7f/j7T9",
+	"e2yMqd3rhu7e2hBvX/S3L/rPwNI26rAG2dkYICubd3we8Tj3hbHbz3L34+4HOYowU1/DkMoFblmd94UX",
+	"zZWRFoVxhrFv7X7t7pTo2/h6fy7va1ejK8GMu+i9fVPfvqnf603dJagdHOHQR76OeEEHxhPST0evZV8o",
+	"tOXQJZoawcNPaHo01+82oSM+ccUgsFYW73XIjTTAacKaPy23uX1iJ5/Yr/2azUpVRc5W/ILiH9ymsiVe",
+	"OMEsZeua2Afshci0cqnsuY8h8SfIq7mzLatkAcbUeSxmgmpvhaWRRseF0WTcY4DasUtRFEhwlRl+vIdJ",
+	"Zh7CGUJ4xR3ZvfTCV3L/TNeNU1xn0f8qKpWY06bSkhU5aNMpFzvIUkcnU+pVV7h9bd/Aa7vey5tQttwS",
+	"xr8GYexRw0Qb3WSBvdW73OpdbvUun4HeJX06/bNqHioVlipVH/mZy+obapLTGCF0I/DzBjkuW/uOWu70",
+	"MmoPQH0SNeuJqaHD7ZrnwPiSC2ncegxfQyi27B9jdRoL99SAvBuiU78PnvFs5adgK24o4gkR6BpM64cY",
+	"iFqcw9aQs6oM8pzPgZwuSy4SrzcqBO9fWGDsDyrf3hi1+iLznlGl4xwCSq0KaZrdTjSvtdBCGEbvsea1",
+	"9IJv4imO8YJ2TvPLStf2kfbb8d2NSp9+70ZXAIzL7ickg1ESwUgaJYEhJtARDpt1FJdf1pjrNlYoqEV8",
+	"Gj0k0+AGRWqJWmPhj92tWu6jquWIAMP6DWnmnn2c0KJPfFN+tKstcS3V14a/KTgzQi6L9k1Bx5MvUWM2",
+	"cZEoePw2M3yIL0HOPIOezVW+DQlNdM1b/Y2Jwafm8C0NGNslehq7H7DlHyi/XsMiKVjT3XxWsQXgHhBe",
+	"uml2ExrHhv8NqRvXQqIycfL43vTmDR2dByxtUY/2aOdCms+cu6TjYyIEqOOfqR+9QkEnKPvnUCAWP7uk",
+	"plDXoz91tciZksXWW6vigHk3EzYwYBH5ITc47uKVoHzSTN5P+1uoFk28h4bgFsFXQnCPhT5zJ9wfL7+I",
+	"P9Djms3YS7LL0gH3IulttPRntqCXSgKDjTAUq+9o8TZLZeuZ7ZASCuQ486IXOtKiQzty+K3doFGz1Eot",
+	"dgkVr6jBHqGiualr+0g3RoKXJXBtrn1JjzPSxTMePyXs1GJDyJBNieCUWgyAgni5Yjjwf4yJBf5XMd6I",
+	"fJOskwSbVCIY/4wkSv3KsJJvBzPD16TaMQWDPi/AbWknbJCtAbm7WYkyVZL2wxZLMFbMsXxBH+I/c0NP",
+	"CB/euZHH8of6MF+A9tWPayL9mHD3Ks5uJgHz0ZLGCBKvUhsiJL6hXMafj60kaPI4O1YVtFG6wzU+qQbB",
+	"fhINwkslZ3TbgrRB8muh5dMpEkjrOY386OqyllJZ8p9TLqFPzAfMwajrFQbjAePB6FjyYTL2l22GmoGq",
+	"PHxLf1ANgXdNtn6nFTh0/n677tsT1+JGsyC4MZluSv7EpVEcTHhSIwcJz5fN1lhY94tPua6/Deg2X/vb",
+	"vM/DXS3t2VrJVMWLn+nrC/qY6u0iqwc6U4z7UN+0LfK3IHa0wGrPM4bVvS9+Dz4PpeX75bVpr1ZDWWeS",
+	"wc+O/pvT0qo03hyT1s+Hb1v/9W65I1seunowujsBkATb+u/hW/xnFpXR2PH1cM7j4UL9veYnNy/ofjH1",
+	"dqkO39ysKpury2jMpiL+II9wLW6UR7xUObhx2wWUYmfmUHWXSguaAESHNdTcL23zCHTStHM1LoXxhT0z",
+	"Xi1XFs1fViXLq9cdZzxzR3rmHjr7quG5VqGkJjqMFRp4vmVzAMnU3KeB9hRLi+R1In76zfP4dJXWBq5S",
+	"qwyMgXy2OzqoAS20a9LSDeGJACeA61mYUWzB9TWBdcxuN6C2kz+kBrfWZwk5APW46XdtYHfyeBs5eaA4",
+	"KmBWkTNNARaGUDgSJySEiw+8f2GS625fNVRL/Yn7eirW0CmOnhys4MbO9h1bbBSvxeAKopOSOqk08ICI",
+	"8BM39nXI0RoVsI2St+IUwwAPlj7Hkf9aF+HrjZ0paUCaytSV+rwMCXlqDeR7NTjXS9jUc6lFNHYtpFrF",
+	"KgP7Rh7CUjS+R5aJ65HbuNwr+m71F0d+qdyLlAOuZQGIBhG7ADkJrSLsxgqNAUCEaRBd12xuU05d0HU6",
+	"MVaVJZ4/O6tk3W8ITSeu9ZH9pWnbJy6fpw7nZLkCEz8gPOSXDrOGDPArbpiHIzjTUeIMly6uDzMeRlcC",
+	"f7aL8vFYnmCr+AjsOaRd8TU+/q1z1jkcHfpNEt0gEezZhaEFpwTmz0K8ver7tasZ+YAK3faDIRKvGoHZ",
+	"/f/wkguLdh93Y86ockHCNtwptMyFNf4ZS/2o3jcpZH01BRqA+XGI+uMicD7XlgMh5Huksgs91yOc6rnS",
+	"o0zRkQuMYrgwVkkrQv05PG+1jPn52XVvpedb6flWer6Vnm+l51vp+VZ6vpWeP7T0/Gm8adlsFvh0yP6Z",
+	"yv3JJl+khH/r6rrjNRKJqf6RgCI6lc7Y5XNigRe0IFHAcJyHy6Jx+uzoJ2ZUpTNgGU4nJCsLLiSzsLEh",
+	"DzpzRfGCC0TIXsnQGu54DTZ4+ICd/Pnom/sPfnvwzbds5U3s7bZf+/zxzNhtAXe8b15d8Ts46YXapK6y",
+	"Snj9hBgEcNL8QhTADCLL+Vo/hQsoVAnaWXEZPkb6z6NT4MUTj5xd8Rm4/kNCRZtkGlcAIbneJuz3fV/+",
+	"LpKtcmUPCYr8AwdTpD0g+hu2b69SMoCLUEiPPkQvez0eCOB67FHBEsCLgE7WxIF8OpbNCCJPZg17+myi",
+	"Itot64NDbT9iXZQPpc8JiE8ePDq2U6TJvMqAwsE8xe2OCnDjtLlsrre6knuD6YyDxB+Dr80dJiRpKVDU",
+	"jFU9Ocyr5RI5fF9tQQFoNB562n8axvnUrffDxLW5wV9HwRfv5Q3aHa7PNSJ3kq+VZkutqvIO7QeXW3oS",
+	"r0sut0ENhrLiuiocDp0H+81yaucMk0o5GJ5jwy+5V75F/F5xqU86vzu0UOk0t7+Qs0piOtgUg8eaJ6PD",
+	"8NzQpxs5FIrXYfRuvYnV+XnHsP6wy24TGtVfCXpmN9KdqNZpIg0HZ+7o3gbK/YtcCa980oU0h+37lzUM",
+	"4WBy1XixbiWQcDe0+elrfhlxoNE8dTPzgud7S6Xo9Lu1UEtpibIpeF9qxfOMG4qMkWAvlT7/wBKr3Rwn",
+	"9A4EJm5cwocZL/D9+S9o3FHyZNuH3U9I9WmM+RgpRvdIl40f7ZEPRGph41YV8EdRBfwQDp9hnGl+2T2c",
+	"TutHZ3IEm+KXdiOTXOpwATADY8WaWxg0Yp5UyyXFN+O0CwC8ZB0LafNOCZAbBK/kVKzDRajXj96QzcYV",
+	"oKWjzPUSbJxKjrSYU1Ir5Ey5VrnAcz0n+TecyQWAYSUXtdWrW9LPUoBHVntVO3WFHzFTEhcUjdcrZtQX",
+	"g5sWzwGeBZztMbS+7CytN5kwAwg6YE8d5VCD+0PhJw6FToVpJh80cWibWSPloLCFdDBQT9eRDeS4W5S2",
+	"oJLCpTVYi0yrmfPHDcR0cCZb5cRI62ks2gGc0ZNID21WlhWAF9MLgULnc4BaL1bb8d3ZqKXgg2F7zLhy",
+	"1jh4OCfM8nMwPqttKBuZGn8t5GwBA9jxJvIWMeACv5bK1ji50xgJ/RLPJEq3VjHivAjN9dbuc7eMtEAv",
+	"KsyxR03ZpdJ21asSFjSUAzV6hoDQeEK9vrIPQPO9c6QX0GELtktvZJf3XGTf5APLH+Y7gf1diQGNTnr2",
+	"HOBVg5hEXhPD0Vg81nugzQUDC28tztXTLgrICHtardMoazObffNeleGNqIDXYXYtA1KHontoapHbtM2/",
+	"mrPaposrCo3RxRCoscH4h7krbx+ytwLnTaRZqe82T61In/7W4WRg7j4PYzJFEhVrSIuYJB8NB1VEx+eV",
+	"a3mj7mG94dteYo3w5r1coCgZZ1khyAdGSWN1ldkz2UNCv1hp7TswrK17EpqkHT0Sfhh+qDPpslPXtvek",
+	"1i4pbDx3l2H7huxIHWfStxohpGHLNd+yBaZss4r9DlqxeWWZHSO9nckbE9+WIMEIM0sbun50Xyni1y8/",
+	"mCbxb985hBJ+7BDlALvIByE/fuor6h4/pSKJjbNaD/aP5sH0+Uq0XU+T3ll0p6NDNa2N6MgTYa1vUolg",
+	"lmqGVgm+xN+Xwq6q+UGm1ochQczhUtXJYg5zDmsl6Vt+yEtxaErIDi/u75Em3oNfsQS7ur2r/zh+IjEd",
+	"4GmpN57yDnf3fuBedvLy3poKoUBrItunmTJTO8aVWigt7JZe+jm0aw5Oo4rP3p8NnCfgi6O/UQH+F0d/",
+	"Y9+zoRL80ZwHZzJVNCFRkXyvF3wN0kB9bqtYLkxZ8C2BuOab74cA3MjB6gJrvrnNNn9bRf5LryI/wix/",
+	"u7tf7O6m8r4rPNOCF8U24t7hOthdo9s7oPTUgOx/VUWVcXzu/Zq/KU3W5vrCESaaUziZvcEQFLAGF8dB",
+	"X+7e7S787l2/58KwBVyGYix37/bRcffuwW3e99u87//CFfQPdkqIPmHd3rp88agid9mrnfK52DYMPG7W",
+	"quDX93wT9oBh9nJf98LABWh0+OTGCUa+xtBaYNydqbIMIH98JmctSBot1dfNn+6Ze1bdu/cQ2L073T5O",
+	"bxFx3n5fElXpk0sO/z07m5xNeiNpWKsLr1N3zfOK3BFdr73D/p963J91b+tQC0PKlRUvS8BrzVSLhciE",
+	"QznlxedL1QkhkYq+gEbgXJY2JqyrWUb4pNAbtyt4WRMgKaG7f78fN1u4t2xZh1xuMwJ+CAH7KVguClMH",
+	"wCbeU/Sy6VIWGoTqo1tzlZALDEz4zftE+lkKcQ5xmBc5uF5ynYcWfeGtVf0dMxUOFHNuVeDHhIYiDfSi",
+	"nllYV/cTH5yyW+S5r9lyNceyQuGbdeaKme2zcNWljr4ypDV1B43kVYJrAdqHd2JLHBtmVoXygbvg2IUK",
+	"XzjxOkgwgxkeHXButxIS6mv3gQnptMLclXJDpHYWiEyFC+mNWTvN4/uQ/cR9D5Xlglawo4NPjBvodX9J",
+	"qEu6XIjrdZEYU/2C+fRiA4roQs15MXO+wjkUdq/EgAHr8JRaorZWZf3ubZDPzn4t8rOzN+wnbOvcktk5",
+	"bA+pwB7LVlwuwdQ4is+Li0731UCaEMYOGkdZpo/cdrah77548Paa1S7NvWSk3bDGLt7PRYY1WJBfqUUT",
+	"bZl4TLCv6+K9C0GcfBtCld11eOeAsSPJYF3aLXMctqPz7kwuv7K75t/EF3j7ZkxEyGQgLkC/55kKw+w+",
+	"SQZk/t5TuUF2T4R+ZOnjxC8TT+uxSdQTL+nOuzYiKgfFTSgobm/H29vx9na8vR1vb8fb2/EPfzu+m96q",
+	"bT6B2uaTK25uq7Pe1or5UAuK46Ww9t1zkijeT5vtb6wsKY3XeupLmK+UOo/ySYdfDt/6v+Kk1c4HiFrj",
+	"lJBVWtgtqSV5KX47B/z7DSrfDOiLoLGsdDF5PFlZWz4+PCQxZKWMPZy8m8bfTOfjm7rw3NugESy1uKDa",
+	"UG/e/d8BAIJKynS9aAEA",
 }
 
 // GetSwagger returns the Swagger specification corresponding to the generated code
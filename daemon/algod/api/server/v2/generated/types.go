@@ -233,6 +233,52 @@ type AssetParams struct {
 	Url *string `json:"url,omitempty"`
 }
 
+// BatchRequest defines model for BatchRequest.
+type BatchRequest struct {
+	Requests []BatchRequestItem `json:"requests"`
+}
+
+// BatchRequestItem defines model for BatchRequestItem.
+type BatchRequestItem struct {
+
+	// The address of the account, for account lookups.
+	Address *string `json:"address,omitempty"`
+
+	// The id of the application, for application lookups.
+	ApplicationId *uint64 `json:"application-id,omitempty"`
+
+	// The id of the asset, for asset lookups.
+	AssetId *uint64 `json:"asset-id,omitempty"`
+
+	// The id of the transaction, for pending transaction lookups.
+	Txid *string `json:"txid,omitempty"`
+
+	// The type of the lookup.
+	Type string `json:"type"`
+}
+
+// BatchResult defines model for BatchResult.
+type BatchResult struct {
+
+	// Account information at a given round.
+	//
+	// Definition:
+	// data/basics/userBalance.go : AccountData
+	Account *Account `json:"account,omitempty"`
+
+	// Application index and its parameters
+	Application *Application `json:"application,omitempty"`
+
+	// Specifies both the unique identifier and the parameters for an asset
+	Asset *Asset `json:"asset,omitempty"`
+
+	// The error which prevented the lookup.
+	Error *string `json:"error,omitempty"`
+
+	// Details about a pending transaction. If the transaction was recently confirmed, includes confirmation details like the round and reward details.
+	PendingTransaction *PendingTransaction `json:"pending-transaction,omitempty"`
+}
+
 // BuildVersion defines model for BuildVersion.
 type BuildVersion struct {
 	Branch      string `json:"branch"`
@@ -327,9 +373,158 @@ type EvalDeltaKeyValue struct {
 	Value EvalDelta `json:"value"`
 }
 
+// FeePercentile defines model for FeePercentile.
+type FeePercentile struct {
+
+	// Fee per byte, in units of micro-Algos per byte.
+	FeePerByte uint64 `json:"fee-per-byte"`
+	Percentile uint64 `json:"percentile"`
+}
+
+// MiniAssetHolding defines model for MiniAssetHolding.
+type MiniAssetHolding struct {
+
+	// The address of the holding account.
+	Address string `json:"address"`
+
+	// The number of units of the asset held by the account.
+	Amount uint64 `json:"amount"`
+
+	// Whether the holding is frozen.
+	IsFrozen bool `json:"is-frozen"`
+}
+
+// ParticipationKey defines model for ParticipationKey.
+type ParticipationKey struct {
+
+	// The address of the account the key participates for.
+	Address string `json:"address"`
+
+	// The first round the key is valid for.
+	FirstValid uint64 `json:"first-valid"`
+
+	// The id identifying the key.
+	Id string `json:"id"`
+
+	// The last round the key is valid for.
+	LastValid uint64 `json:"last-valid"`
+
+	// The public VRF key of the participation key.
+	SelectionParticipationKey []byte `json:"selection-participation-key"`
+
+	// The number of subkeys in each batch of the voting keys.
+	VoteKeyDilution uint64 `json:"vote-key-dilution"`
+
+	// The root public voting key of the participation key.
+	VoteParticipationKey []byte `json:"vote-participation-key"`
+
+	// The voting status of the key as of the last round:
+	// * active - the account is online with this key
+	// * not-registered - the account is not online with this key
+	// * expired - the key is no longer valid
+	VotingStatus string `json:"voting-status"`
+}
+
+// PeerStatus defines model for PeerStatus.
+type PeerStatus struct {
+
+	// The gossip address of the peer: the address it was dialed on for the outgoing connections, or the public address it advertised for the incoming ones.
+	Address *string `json:"address,omitempty"`
+
+	// The time at which the connection was established, in seconds since the epoch.
+	ConnectedSince uint64 `json:"connected-since"`
+
+	// Whether the node or the peer established the connection.
+	Direction string `json:"direction"`
+
+	// The identity the peer authenticated with, if the connection with the peer is encrypted.
+	Identity *string `json:"identity,omitempty"`
+
+	// The instance name of the peer.
+	InstanceName *string `json:"instance-name,omitempty"`
+
+	// The number of messages received from the peer, by tag.
+	MessagesReceived []TagCount `json:"messages-received"`
+
+	// The number of messages sent to the peer, by tag.
+	MessagesSent []TagCount `json:"messages-sent"`
+
+	// The round trip time of the last ping answered by the peer, in nanoseconds.
+	PingRoundTripTime *uint64 `json:"ping-round-trip-time,omitempty"`
+
+	// The host and port the connection is established with, which identifies the peer.
+	RemoteAddress string `json:"remote-address"`
+
+	// The telemetry GUID of the peer.
+	TelemetryGuid *string `json:"telemetry-guid,omitempty"`
+
+	// The protocol version negotiated with the peer.
+	Version *string `json:"version,omitempty"`
+}
+
+// PendingTransaction defines model for PendingTransaction.
+type PendingTransaction struct {
+
+	// The application index if the transaction was found and it created an application.
+	ApplicationIndex *uint64 `json:"application-index,omitempty"`
+
+	// The number of the asset's unit that were transferred to the close-to address.
+	AssetClosingAmount *uint64 `json:"asset-closing-amount,omitempty"`
+
+	// The asset index if the transaction was found and it created an asset.
+	AssetIndex *uint64 `json:"asset-index,omitempty"`
+
+	// Rewards in microalgos applied to the close remainder to account.
+	CloseRewards *uint64 `json:"close-rewards,omitempty"`
+
+	// Closing amount for the transaction.
+	ClosingAmount *uint64 `json:"closing-amount,omitempty"`
+
+	// The round where this transaction was confirmed, if present.
+	ConfirmedRound *uint64 `json:"confirmed-round,omitempty"`
+
+	// Application state delta.
+	GlobalStateDelta *StateDelta `json:"global-state-delta,omitempty"`
+
+	// \[ld\] Local state key/value changes for the application being executed by this transaction.
+	LocalStateDelta *[]AccountStateDelta `json:"local-state-delta,omitempty"`
+
+	// Indicates that the transaction was kicked out of this node's transaction pool (and specifies why that happened).  An empty string indicates the transaction wasn't kicked out of this node's txpool due to an error.
+	PoolError string `json:"pool-error"`
+
+	// Rewards in microalgos applied to the receiver account.
+	ReceiverRewards *uint64 `json:"receiver-rewards,omitempty"`
+
+	// Rewards in microalgos applied to the sender account.
+	SenderRewards *uint64 `json:"sender-rewards,omitempty"`
+
+	// The raw signed transaction.
+	Txn map[string]interface{} `json:"txn"`
+}
+
+// PhonebookEntry defines model for PhonebookEntry.
+type PhonebookEntry struct {
+
+	// The host:port address of the relay or of the archiver.
+	Address string `json:"address"`
+
+	// Whether the entry is an archiver rather than a relay.
+	Archival *bool `json:"archival,omitempty"`
+}
+
 // StateDelta defines model for StateDelta.
 type StateDelta []EvalDeltaKeyValue
 
+// TagCount defines model for TagCount.
+type TagCount struct {
+
+	// The number of messages.
+	Count uint64 `json:"count"`
+
+	// The message tag.
+	Tag string `json:"tag"`
+}
+
 // TealKeyValue defines model for TealKeyValue.
 type TealKeyValue struct {
 	Key string `json:"key"`
@@ -362,6 +557,25 @@ type Version struct {
 	Versions       []string     `json:"versions"`
 }
 
+// Webhook defines model for Webhook.
+type Webhook struct {
+
+	// The watched address.
+	Address *string `json:"address,omitempty"`
+
+	// The watched application.
+	ApplicationId *uint64 `json:"application-id,omitempty"`
+
+	// The watched asset.
+	AssetId *uint64 `json:"asset-id,omitempty"`
+
+	// The id of the watcher, assigned once registered.
+	Id *uint64 `json:"id,omitempty"`
+
+	// The http or https URL the notifications are POSTed to.
+	Url string `json:"url"`
+}
+
 // AccountId defines model for account-id.
 type AccountId string
 
@@ -374,6 +588,9 @@ type AddressRole string
 // AfterTime defines model for after-time.
 type AfterTime time.Time
 
+// ApplicationId defines model for application-id.
+type ApplicationId uint64
+
 // AssetId defines model for asset-id.
 type AssetId uint64
 
@@ -413,6 +630,12 @@ type Next string
 // NotePrefix defines model for note-prefix.
 type NotePrefix string
 
+// ParticipationId defines model for participation-id.
+type ParticipationId string
+
+// PeerAddress defines model for peer-address.
+type PeerAddress string
+
 // Round defines model for round.
 type Round uint64
 
@@ -422,21 +645,87 @@ type RoundNumber uint64
 // SigType defines model for sig-type.
 type SigType string
 
+// TargetRounds defines model for target-rounds.
+type TargetRounds uint64
+
 // TxId defines model for tx-id.
 type TxId string
 
 // TxType defines model for tx-type.
 type TxType string
 
+// WebhookId defines model for webhook-id.
+type WebhookId uint64
+
 // AccountResponse defines model for AccountResponse.
 type AccountResponse Account
 
+// AccountTransactionsResponse defines model for AccountTransactionsResponse.
+type AccountTransactionsResponse struct {
+
+	// The last round indexed when the results were computed.
+	CurrentRound uint64 `json:"current-round"`
+
+	// Used for pagination, when making another request provide this token with the next parameter. It's omitted when there are no more results.
+	NextToken *string `json:"next-token,omitempty"`
+
+	// An array of confirmed transaction objects.
+	Transactions []struct {
+
+		// The offset of the transaction within its block.
+		IntraRoundOffset uint64 `json:"intra-round-offset"`
+
+		// The round the transaction was confirmed in.
+		Round uint64 `json:"round"`
+
+		// The timestamp of the block the transaction was confirmed in, as seconds from epoch.
+		RoundTime uint64 `json:"round-time"`
+
+		// The signed transaction, along with the data computed when applying it.
+		Txn map[string]interface{} `json:"txn"`
+	} `json:"transactions"`
+}
+
+// ApplicationAccountsResponse defines model for ApplicationAccountsResponse.
+type ApplicationAccountsResponse struct {
+
+	// The addresses of the accounts opted into the application.
+	Accounts []string `json:"accounts"`
+
+	// The round the results were computed for.
+	CurrentRound uint64 `json:"current-round"`
+
+	// Used for pagination, when making another request provide this token with the next parameter. It's omitted when there are no more results.
+	NextToken *string `json:"next-token,omitempty"`
+}
+
 // ApplicationResponse defines model for ApplicationResponse.
 type ApplicationResponse Application
 
+// AssetBalancesResponse defines model for AssetBalancesResponse.
+type AssetBalancesResponse struct {
+
+	// The holders of the asset, along with their holdings.
+	Balances []MiniAssetHolding `json:"balances"`
+
+	// The round the results were computed for.
+	CurrentRound uint64 `json:"current-round"`
+
+	// Used for pagination, when making another request provide this token with the next parameter. It's omitted when there are no more results.
+	NextToken *string `json:"next-token,omitempty"`
+}
+
 // AssetResponse defines model for AssetResponse.
 type AssetResponse Asset
 
+// BatchResponse defines model for BatchResponse.
+type BatchResponse struct {
+	Results []BatchResult `json:"results"`
+
+	// The round the account, asset and application lookups were made against.
+	Round uint64 `json:"round"`
+}
+
 // BlockResponse defines model for BlockResponse.
 type BlockResponse struct {
 
@@ -529,45 +818,21 @@ type NodeStatusResponse struct {
 	TimeSinceLastRound uint64 `json:"time-since-last-round"`
 }
 
-// PendingTransactionResponse defines model for PendingTransactionResponse.
-type PendingTransactionResponse struct {
-
-	// The application index if the transaction was found and it created an application.
-	ApplicationIndex *uint64 `json:"application-index,omitempty"`
-
-	// The number of the asset's unit that were transferred to the close-to address.
-	AssetClosingAmount *uint64 `json:"asset-closing-amount,omitempty"`
-
-	// The asset index if the transaction was found and it created an asset.
-	AssetIndex *uint64 `json:"asset-index,omitempty"`
-
-	// Rewards in microalgos applied to the close remainder to account.
-	CloseRewards *uint64 `json:"close-rewards,omitempty"`
+// ParticipationKeysResponse defines model for ParticipationKeysResponse.
+type ParticipationKeysResponse []ParticipationKey
 
-	// Closing amount for the transaction.
-	ClosingAmount *uint64 `json:"closing-amount,omitempty"`
-
-	// The round where this transaction was confirmed, if present.
-	ConfirmedRound *uint64 `json:"confirmed-round,omitempty"`
-
-	// Application state delta.
-	GlobalStateDelta *StateDelta `json:"global-state-delta,omitempty"`
+// ParticipationRegistrationResponse defines model for ParticipationRegistrationResponse.
+type ParticipationRegistrationResponse struct {
 
-	// \[ld\] Local state key/value changes for the application being executed by this transaction.
-	LocalStateDelta *[]AccountStateDelta `json:"local-state-delta,omitempty"`
-
-	// Indicates that the transaction was kicked out of this node's transaction pool (and specifies why that happened).  An empty string indicates the transaction wasn't kicked out of this node's txpool due to an error.
-	PoolError string `json:"pool-error"`
-
-	// Rewards in microalgos applied to the receiver account.
-	ReceiverRewards *uint64 `json:"receiver-rewards,omitempty"`
+	// The msgpack encoded unsigned key registration transaction, wrapped in a signed transaction with no signature, as written by `goal account changeonlinestatus -o`.
+	Transaction []byte `json:"transaction"`
+}
 
-	// Rewards in microalgos applied to the sender account.
-	SenderRewards *uint64 `json:"sender-rewards,omitempty"`
+// PeersResponse defines model for PeersResponse.
+type PeersResponse []PeerStatus
 
-	// The raw signed transaction.
-	Txn map[string]interface{} `json:"txn"`
-}
+// PendingTransactionResponse defines model for PendingTransactionResponse.
+type PendingTransactionResponse PendingTransaction
 
 // PendingTransactionsResponse defines model for PendingTransactionsResponse.
 type PendingTransactionsResponse struct {
@@ -579,6 +844,13 @@ type PendingTransactionsResponse struct {
 	TotalTransactions uint64 `json:"total-transactions"`
 }
 
+// PostParticipationResponse defines model for PostParticipationResponse.
+type PostParticipationResponse struct {
+
+	// The id of the installed participation key.
+	ParticipationId string `json:"participation-id"`
+}
+
 // PostTransactionsResponse defines model for PostTransactionsResponse.
 type PostTransactionsResponse struct {
 
@@ -586,6 +858,13 @@ type PostTransactionsResponse struct {
 	TxId string `json:"txId"`
 }
 
+// PostWebhookResponse defines model for PostWebhookResponse.
+type PostWebhookResponse struct {
+
+	// The id of the registered watcher.
+	WebhookId uint64 `json:"webhook-id"`
+}
+
 // ProofResponse defines model for ProofResponse.
 type ProofResponse struct {
 
@@ -612,6 +891,36 @@ type SupplyResponse struct {
 	TotalMoney uint64 `json:"total-money"`
 }
 
+// TransactionFeeEstimateResponse defines model for TransactionFeeEstimateResponse.
+type TransactionFeeEstimateResponse struct {
+
+	// The suggested fee, in units of micro-Algos per byte.
+	// Transactions must still have a fee of at least MinTxnFee for the current network protocol.
+	FeePerByte uint64 `json:"fee-per-byte"`
+
+	// The last round the estimate takes into account.
+	LastRound uint64 `json:"last-round"`
+
+	// The minimum transaction fee (not per byte) required for the
+	// txn to validate for the current network protocol.
+	MinFee uint64 `json:"min-fee"`
+
+	// The number of full blocks worth of transactions waiting in the transaction pool.
+	PendingBlocks uint64 `json:"pending-blocks"`
+
+	// The percentile of the recent fee distribution the suggested fee is based on.
+	Percentile uint64 `json:"percentile"`
+
+	// The distribution of the fees per byte paid by the transactions of the recent blocks.
+	Percentiles []FeePercentile `json:"percentiles"`
+
+	// The number of recent blocks the fee distribution was collected from.
+	SampledBlocks uint64 `json:"sampled-blocks"`
+
+	// The number of rounds the transaction is to be committed within.
+	TargetRounds uint64 `json:"target-rounds"`
+}
+
 // TransactionParametersResponse defines model for TransactionParametersResponse.
 type TransactionParametersResponse struct {
 
@@ -642,6 +951,9 @@ type TransactionParametersResponse struct {
 // VersionsResponse defines model for VersionsResponse.
 type VersionsResponse Version
 
+// WebhooksResponse defines model for WebhooksResponse.
+type WebhooksResponse []Webhook
+
 // AccountInformationParams defines parameters for AccountInformation.
 type AccountInformationParams struct {
 
@@ -649,6 +961,41 @@ type AccountInformationParams struct {
 	Format *string `json:"format,omitempty"`
 }
 
+// AccountTransactionsParams defines parameters for AccountTransactions.
+type AccountTransactionsParams struct {
+
+	// Maximum number of results to return.
+	Limit *uint64 `json:"limit,omitempty"`
+
+	// The next page of results. Use the next token provided by the previous results.
+	Next *string `json:"next,omitempty"`
+
+	// Include results at or after the specified min-round.
+	MinRound *uint64 `json:"min-round,omitempty"`
+
+	// Include results at or before the specified max-round.
+	MaxRound *uint64 `json:"max-round,omitempty"`
+	TxType   *string `json:"tx-type,omitempty"`
+
+	// Asset ID
+	AssetId *uint64 `json:"asset-id,omitempty"`
+
+	// Application ID
+	ApplicationId *uint64 `json:"application-id,omitempty"`
+
+	// Specifies a prefix which must be contained in the note field.
+	NotePrefix *string `json:"note-prefix,omitempty"`
+
+	// Include results before the given time. Must be an RFC 3339 formatted string.
+	BeforeTime *time.Time `json:"before-time,omitempty"`
+
+	// Include results after the given time. Must be an RFC 3339 formatted string.
+	AfterTime *time.Time `json:"after-time,omitempty"`
+
+	// Configures whether the response object is JSON or MessagePack encoded.
+	Format *string `json:"format,omitempty"`
+}
+
 // GetPendingTransactionsByAddressParams defines parameters for GetPendingTransactionsByAddress.
 type GetPendingTransactionsByAddressParams struct {
 
@@ -659,6 +1006,41 @@ type GetPendingTransactionsByAddressParams struct {
 	Format *string `json:"format,omitempty"`
 }
 
+// GetApplicationAccountsParams defines parameters for GetApplicationAccounts.
+type GetApplicationAccountsParams struct {
+
+	// Maximum number of results to return.
+	Limit *uint64 `json:"limit,omitempty"`
+
+	// The next page of results. Use the next token provided by the previous results.
+	Next *string `json:"next,omitempty"`
+
+	// Configures whether the response object is JSON or MessagePack encoded.
+	Format *string `json:"format,omitempty"`
+}
+
+// GetAssetBalancesParams defines parameters for GetAssetBalances.
+type GetAssetBalancesParams struct {
+
+	// Maximum number of results to return.
+	Limit *uint64 `json:"limit,omitempty"`
+
+	// The next page of results. Use the next token provided by the previous results.
+	Next *string `json:"next,omitempty"`
+
+	// Results should have an amount greater than this value. MicroAlgos are the default currency unless an asset-id is provided, in which case the asset will be used.
+	CurrencyGreaterThan *uint64 `json:"currency-greater-than,omitempty"`
+
+	// Results should have an amount less than this value. MicroAlgos are the default currency unless an asset-id is provided, in which case the asset will be used.
+	CurrencyLessThan *uint64 `json:"currency-less-than,omitempty"`
+
+	// Configures whether the response object is JSON or MessagePack encoded.
+	Format *string `json:"format,omitempty"`
+}
+
+// BatchJSONBody defines parameters for Batch.
+type BatchJSONBody BatchRequest
+
 // GetBlockParams defines parameters for GetBlock.
 type GetBlockParams struct {
 
@@ -676,6 +1058,13 @@ type GetProofParams struct {
 // TealDryrunJSONBody defines parameters for TealDryrun.
 type TealDryrunJSONBody DryrunRequest
 
+// TransactionFeeEstimateParams defines parameters for TransactionFeeEstimate.
+type TransactionFeeEstimateParams struct {
+
+	// Number of rounds the transaction is to be committed within. Defaults to 1.
+	TargetRounds *uint64 `json:"target-rounds,omitempty"`
+}
+
 // GetPendingTransactionsParams defines parameters for GetPendingTransactions.
 type GetPendingTransactionsParams struct {
 
@@ -693,5 +1082,8 @@ type PendingTransactionInformationParams struct {
 	Format *string `json:"format,omitempty"`
 }
 
+// BatchRequestBody defines body for Batch for application/json ContentType.
+type BatchJSONRequestBody BatchJSONBody
+
 // TealDryrunRequestBody defines body for TealDryrun for application/json ContentType.
 type TealDryrunJSONRequestBody TealDryrunJSONBody
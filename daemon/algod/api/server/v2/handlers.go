@@ -24,6 +24,7 @@ import (
 	"io"
 	"math"
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/labstack/echo/v4"
@@ -35,17 +36,31 @@ import (
 	"github.com/algorand/go-algorand/data"
 	"github.com/algorand/go-algorand/data/basics"
 	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/data/pools"
 	"github.com/algorand/go-algorand/data/transactions"
 	"github.com/algorand/go-algorand/data/transactions/logic"
+	"github.com/algorand/go-algorand/ledger"
 	"github.com/algorand/go-algorand/ledger/ledgercore"
 	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/network"
 	"github.com/algorand/go-algorand/node"
+	"github.com/algorand/go-algorand/node/indexer"
+	"github.com/algorand/go-algorand/node/webhooks"
 	"github.com/algorand/go-algorand/protocol"
 	"github.com/algorand/go-algorand/rpcs"
 )
 
 const maxTealSourceBytes = 1e5
 const maxTealDryrunBytes = 1e5
+const maxParticipationKeyFileBytes = 64 * 1024 * 1024
+const defaultAccountTransactionsLimit = 100
+const maxAccountTransactionsLimit = 1000
+const defaultHoldersLimit = 100
+const maxHoldersLimit = 1000
+const maxWebhookBytes = 1e4
+
+// maxPhonebookEntryBytes is the maximal size of a phonebook entry posted to /v2/phonebook.
+const maxPhonebookEntryBytes = 1e3
 
 // Handlers is an implementation to the V2 route handler interface defined by the generated code.
 type Handlers struct {
@@ -64,6 +79,19 @@ type NodeInterface interface {
 	GetPendingTransaction(txID transactions.Txid) (res node.TxnWithStatus, found bool)
 	GetPendingTxnsFromPool() ([]transactions.SignedTxn, error)
 	SuggestedFee() basics.MicroAlgos
+	FeeEstimate(targetRounds uint64) pools.FeeEstimate
+	ListParticipationKeys() ([]node.ParticipationKeyInfo, error)
+	InstallParticipationKey(partKeyBinary []byte) (crypto.Digest, error)
+	ParticipationKeyRegistration(id crypto.Digest, fee basics.MicroAlgos, firstValid, lastValid basics.Round) (transactions.Transaction, error)
+	RemoveParticipationKey(id crypto.Digest) error
+	ListAccountTransactions(addr basics.Address, filter indexer.TransactionFilter) ([]node.IndexedTransaction, basics.Round, error)
+	ListWebhooks() ([]webhooks.Watcher, error)
+	AddWebhook(w webhooks.Watcher) (webhooks.Watcher, error)
+	RemoveWebhook(id uint64) error
+	ListPeers() []network.PeerInfo
+	DisconnectPeer(address string) error
+	BanPeer(address string, duration time.Duration) error
+	AddPhonebookEntry(address string, archival bool) error
 	StartCatchup(catchpoint string) error
 	AbortCatchup(catchpoint string) error
 	Config() config.Local
@@ -83,6 +111,298 @@ func (v2 *Handlers) ShutdownNode(ctx echo.Context, params private.ShutdownNodePa
 	return ctx.String(http.StatusNotImplemented, "Endpoint not implemented.")
 }
 
+// GetParticipationKeys returns the participation keys installed on the node.
+// (GET /v2/participation)
+func (v2 *Handlers) GetParticipationKeys(ctx echo.Context) error {
+	infos, err := v2.Node.ListParticipationKeys()
+	if err != nil {
+		return internalError(ctx, err, errFailedLookingUpLedger, v2.Log)
+	}
+
+	response := make(private.ParticipationKeysResponse, 0, len(infos))
+	for _, info := range infos {
+		response = append(response, private.ParticipationKey{
+			Id:                        info.ID.String(),
+			Address:                   info.Address.String(),
+			FirstValid:                uint64(info.FirstValid),
+			LastValid:                 uint64(info.LastValid),
+			VoteKeyDilution:           info.KeyDilution,
+			VoteParticipationKey:      info.VoteID[:],
+			SelectionParticipationKey: info.SelectionID[:],
+			VotingStatus:              info.VotingStatus,
+		})
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// AddParticipationKey installs a participation key file on the node.
+// (POST /v2/participation)
+func (v2 *Handlers) AddParticipationKey(ctx echo.Context) error {
+	buf := new(bytes.Buffer)
+	ctx.Request().Body = http.MaxBytesReader(nil, ctx.Request().Body, maxParticipationKeyFileBytes)
+	_, err := buf.ReadFrom(ctx.Request().Body)
+	if err != nil {
+		return badRequest(ctx, err, err.Error(), v2.Log)
+	}
+
+	id, err := v2.Node.InstallParticipationKey(buf.Bytes())
+	if err != nil {
+		if errors.Is(err, node.ErrInvalidParticipationKey) || err == node.ErrParticipationKeyExists {
+			return badRequest(ctx, err, err.Error(), v2.Log)
+		}
+		return internalError(ctx, err, err.Error(), v2.Log)
+	}
+	return ctx.JSON(http.StatusOK, private.PostParticipationResponse{ParticipationId: id.String()})
+}
+
+// DeleteParticipationKeyByID removes a participation key from the node.
+// (DELETE /v2/participation/{participation-id})
+func (v2 *Handlers) DeleteParticipationKeyByID(ctx echo.Context, participationID string) error {
+	id, err := crypto.DigestFromString(participationID)
+	if err != nil {
+		return badRequest(ctx, err, errFailedToParseParticipationID, v2.Log)
+	}
+	err = v2.Node.RemoveParticipationKey(id)
+	if err != nil {
+		if err == node.ErrParticipationKeyNotFound {
+			return notFound(ctx, err, err.Error(), v2.Log)
+		}
+		return internalError(ctx, err, err.Error(), v2.Log)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+// GetParticipationKeyRegistration returns the unsigned key registration transaction of a participation key.
+// (GET /v2/participation/{participation-id}/registration)
+func (v2 *Handlers) GetParticipationKeyRegistration(ctx echo.Context, participationID string, params private.GetParticipationKeyRegistrationParams) error {
+	id, err := crypto.DigestFromString(participationID)
+	if err != nil {
+		return badRequest(ctx, err, errFailedToParseParticipationID, v2.Log)
+	}
+	stat, err := v2.Node.Status()
+	if err != nil {
+		return internalError(ctx, err, errFailedRetrievingNodeStatus, v2.Log)
+	}
+	if stat.Catchpoint != "" {
+		// node is currently catching up to the requested catchpoint.
+		return serviceUnavailable(ctx, fmt.Errorf("GetParticipationKeyRegistration failed as the node was catchpoint catchuping"), errOperationNotAvailableDuringCatchup, v2.Log)
+	}
+	proto := config.Consensus[stat.LastVersion]
+
+	firstValid := stat.LastRound
+	if params.FirstValid != nil {
+		firstValid = basics.Round(*params.FirstValid)
+	}
+	lastValid := firstValid + basics.Round(proto.MaxTxnLife)
+	if params.LastValid != nil {
+		lastValid = basics.Round(*params.LastValid)
+	}
+	if lastValid < firstValid || lastValid-firstValid > basics.Round(proto.MaxTxnLife) {
+		err = fmt.Errorf(errInvalidValidityRange, proto.MaxTxnLife)
+		return badRequest(ctx, err, err.Error(), v2.Log)
+	}
+	var fee basics.MicroAlgos
+	if params.Fee != nil {
+		fee.Raw = *params.Fee
+	}
+
+	tx, err := v2.Node.ParticipationKeyRegistration(id, fee, firstValid, lastValid)
+	if err != nil {
+		if err == node.ErrParticipationKeyNotFound {
+			return notFound(ctx, err, err.Error(), v2.Log)
+		}
+		return internalError(ctx, err, errFailedLookingUpLedger, v2.Log)
+	}
+	if params.Fee == nil {
+		// default to the suggested fee, which depends on the size of the transaction once signed.
+		tx.Fee = basics.MulAIntSaturate(v2.Node.SuggestedFee(), tx.EstimateEncodedSize())
+		if tx.Fee.Raw < proto.MinTxnFee {
+			tx.Fee.Raw = proto.MinTxnFee
+		}
+	}
+
+	stxn := transactions.SignedTxn{Txn: tx}
+	return ctx.JSON(http.StatusOK, private.ParticipationRegistrationResponse{Transaction: protocol.Encode(&stxn)})
+}
+
+// GetWebhooks returns the webhook watchers registered on the node.
+// (GET /v2/webhooks)
+func (v2 *Handlers) GetWebhooks(ctx echo.Context) error {
+	watchers, err := v2.Node.ListWebhooks()
+	if err != nil {
+		if err == node.ErrWebhooksNotActive {
+			return notFound(ctx, err, errWebhooksNotActive, v2.Log)
+		}
+		return internalError(ctx, err, err.Error(), v2.Log)
+	}
+
+	response := make(private.WebhooksResponse, 0, len(watchers))
+	for _, w := range watchers {
+		id := w.ID
+		webhook := private.Webhook{Id: &id, Url: w.URL}
+		if !w.Address.IsZero() {
+			addr := w.Address.String()
+			webhook.Address = &addr
+		}
+		if w.AssetID != 0 {
+			assetID := uint64(w.AssetID)
+			webhook.AssetId = &assetID
+		}
+		if w.AppID != 0 {
+			appID := uint64(w.AppID)
+			webhook.ApplicationId = &appID
+		}
+		response = append(response, webhook)
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// AddWebhook registers a webhook watcher.
+// (POST /v2/webhooks)
+func (v2 *Handlers) AddWebhook(ctx echo.Context) error {
+	req := ctx.Request()
+	buf := new(bytes.Buffer)
+	req.Body = http.MaxBytesReader(nil, req.Body, maxWebhookBytes)
+	_, err := buf.ReadFrom(req.Body)
+	if err != nil {
+		return badRequest(ctx, err, err.Error(), v2.Log)
+	}
+
+	var webhook private.Webhook
+	err = decode(protocol.JSONHandle, buf.Bytes(), &webhook)
+	if err != nil {
+		return badRequest(ctx, err, err.Error(), v2.Log)
+	}
+	w := webhooks.Watcher{URL: webhook.Url}
+	if webhook.Address != nil {
+		w.Address, err = basics.UnmarshalChecksumAddress(*webhook.Address)
+		if err != nil {
+			return badRequest(ctx, err, errFailedToParseAddress, v2.Log)
+		}
+	}
+	if webhook.AssetId != nil {
+		w.AssetID = basics.AssetIndex(*webhook.AssetId)
+	}
+	if webhook.ApplicationId != nil {
+		w.AppID = basics.AppIndex(*webhook.ApplicationId)
+	}
+
+	w, err = v2.Node.AddWebhook(w)
+	if err != nil {
+		if err == node.ErrWebhooksNotActive {
+			return notFound(ctx, err, errWebhooksNotActive, v2.Log)
+		}
+		if errors.Is(err, webhooks.ErrInvalidWatcher) {
+			return badRequest(ctx, err, err.Error(), v2.Log)
+		}
+		return internalError(ctx, err, err.Error(), v2.Log)
+	}
+	return ctx.JSON(http.StatusOK, private.PostWebhookResponse{WebhookId: w.ID})
+}
+
+// DeleteWebhook unregisters a webhook watcher.
+// (DELETE /v2/webhooks/{webhook-id})
+func (v2 *Handlers) DeleteWebhook(ctx echo.Context, webhookID uint64) error {
+	err := v2.Node.RemoveWebhook(webhookID)
+	if err != nil {
+		if err == node.ErrWebhooksNotActive {
+			return notFound(ctx, err, errWebhooksNotActive, v2.Log)
+		}
+		if err == webhooks.ErrWatcherNotFound {
+			return notFound(ctx, err, err.Error(), v2.Log)
+		}
+		return internalError(ctx, err, err.Error(), v2.Log)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+// GetPeers returns the peers the node is connected to.
+// (GET /v2/peers)
+func (v2 *Handlers) GetPeers(ctx echo.Context) error {
+	infos := v2.Node.ListPeers()
+	response := make(private.PeersResponse, 0, len(infos))
+	for _, info := range infos {
+		peer := private.PeerStatus{
+			RemoteAddress:     info.RemoteAddress,
+			Direction:         "incoming",
+			ConnectedSince:    uint64(info.ConnectedSince.Unix()),
+			MessagesReceived:  tagCounts(info.MessagesReceived),
+			MessagesSent:      tagCounts(info.MessagesSent),
+			Address:           strOrNil(info.Address),
+			Version:           strOrNil(info.Version),
+			TelemetryGuid:     strOrNil(info.TelemetryGUID),
+			InstanceName:      strOrNil(info.InstanceName),
+			Identity:          strOrNil(info.Identity),
+			PingRoundTripTime: numOrNil(uint64(info.PingRoundTripTime)),
+		}
+		if info.Outgoing {
+			peer.Direction = "outgoing"
+		}
+		response = append(response, peer)
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// tagCounts returns the message counters of a peer, sorted by tag.
+func tagCounts(counts map[protocol.Tag]uint64) []private.TagCount {
+	result := make([]private.TagCount, 0, len(counts))
+	for tag, count := range counts {
+		result = append(result, private.TagCount{Tag: string(tag), Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Tag < result[j].Tag })
+	return result
+}
+
+// DisconnectPeer drops the connection with a peer.
+// (DELETE /v2/peers/{peer-address})
+func (v2 *Handlers) DisconnectPeer(ctx echo.Context, peerAddress string) error {
+	err := v2.Node.DisconnectPeer(peerAddress)
+	if err != nil {
+		return notFound(ctx, err, err.Error(), v2.Log)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+// BanPeer bans a peer for a while.
+// (POST /v2/peers/{peer-address}/ban)
+func (v2 *Handlers) BanPeer(ctx echo.Context, peerAddress string, params private.BanPeerParams) error {
+	duration := time.Duration(v2.Node.Config().PeerBanDurationSeconds) * time.Second
+	if params.Duration != nil {
+		duration = time.Duration(*params.Duration) * time.Second
+	}
+	err := v2.Node.BanPeer(peerAddress, duration)
+	if err != nil {
+		if err == node.ErrPeerNotFound {
+			return notFound(ctx, err, err.Error(), v2.Log)
+		}
+		return internalError(ctx, err, err.Error(), v2.Log)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+// AddPhonebookEntry adds an entry to the phonebook of the node.
+// (POST /v2/phonebook)
+func (v2 *Handlers) AddPhonebookEntry(ctx echo.Context) error {
+	req := ctx.Request()
+	buf := new(bytes.Buffer)
+	req.Body = http.MaxBytesReader(nil, req.Body, maxPhonebookEntryBytes)
+	_, err := buf.ReadFrom(req.Body)
+	if err != nil {
+		return badRequest(ctx, err, err.Error(), v2.Log)
+	}
+
+	var entry private.PhonebookEntry
+	err = decode(protocol.JSONHandle, buf.Bytes(), &entry)
+	if err != nil {
+		return badRequest(ctx, err, err.Error(), v2.Log)
+	}
+	err = v2.Node.AddPhonebookEntry(entry.Address, entry.Archival != nil && *entry.Archival)
+	if err != nil {
+		return badRequest(ctx, err, err.Error(), v2.Log)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
 // AccountInformation gets account information for a given account.
 // (GET /v2/accounts/{address})
 func (v2 *Handlers) AccountInformation(ctx echo.Context, address string, params generated.AccountInformationParams) error {
@@ -143,6 +463,189 @@ func (v2 *Handlers) AccountInformation(ctx echo.Context, address string, params
 	return ctx.JSON(http.StatusOK, response)
 }
 
+// AccountTransactions gets the confirmed transactions of the given account, using the indexer.
+// (GET /v2/accounts/{address}/transactions)
+func (v2 *Handlers) AccountTransactions(ctx echo.Context, address string, params generated.AccountTransactionsParams) error {
+	stat, err := v2.Node.Status()
+	if err != nil {
+		return internalError(ctx, err, errFailedRetrievingNodeStatus, v2.Log)
+	}
+	if stat.Catchpoint != "" {
+		// node is currently catching up to the requested catchpoint.
+		return serviceUnavailable(ctx, fmt.Errorf("AccountTransactions failed as the node was catchpoint catchuping"), errOperationNotAvailableDuringCatchup, v2.Log)
+	}
+
+	addr, err := basics.UnmarshalChecksumAddress(address)
+	if err != nil {
+		return badRequest(ctx, err, errFailedToParseAddress, v2.Log)
+	}
+
+	handle, contentType, err := getCodecHandle(params.Format)
+	if err != nil {
+		return badRequest(ctx, err, errFailedParsingFormatOption, v2.Log)
+	}
+
+	filter, err := makeAccountTransactionsFilter(params)
+	if err != nil {
+		return badRequest(ctx, err, err.Error(), v2.Log)
+	}
+
+	txns, indexedRound, err := v2.Node.ListAccountTransactions(addr, filter)
+	if err == node.ErrIndexerNotActive {
+		return notFound(ctx, err, errIndexerNotActive, v2.Log)
+	}
+	if err != nil {
+		return internalError(ctx, err, errFailedGettingInformationFromIndexer, v2.Log)
+	}
+
+	type indexedTransaction struct {
+		Round            basics.Round                 `codec:"round"`
+		RoundTime        int64                        `codec:"round-time"`
+		IntraRoundOffset uint64                       `codec:"intra-round-offset"`
+		Txn              transactions.SignedTxnWithAD `codec:"txn"`
+	}
+
+	// Encoding wasn't working well without embedding "real" objects.
+	response := struct {
+		CurrentRound basics.Round         `codec:"current-round"`
+		NextToken    *string              `codec:"next-token,omitempty"`
+		Transactions []indexedTransaction `codec:"transactions"`
+	}{
+		CurrentRound: indexedRound,
+		Transactions: make([]indexedTransaction, 0, len(txns)),
+	}
+	for _, txn := range txns {
+		response.Transactions = append(response.Transactions, indexedTransaction{
+			Round:            txn.Round,
+			RoundTime:        txn.RoundTime,
+			IntraRoundOffset: txn.Intra,
+			Txn:              txn.Txn,
+		})
+	}
+	if len(txns) > 0 && uint64(len(txns)) == filter.Limit {
+		last := txns[len(txns)-1]
+		next := fmt.Sprintf("%d:%d", last.Round, last.Intra)
+		response.NextToken = &next
+	}
+
+	data, err := encode(handle, response)
+	if err != nil {
+		return internalError(ctx, err, errFailedToEncodeResponse, v2.Log)
+	}
+
+	return ctx.Blob(http.StatusOK, contentType, data)
+}
+
+// makeAccountTransactionsFilter converts the parameters of the account transactions endpoint into an indexer filter.
+func makeAccountTransactionsFilter(params generated.AccountTransactionsParams) (filter indexer.TransactionFilter, err error) {
+	filter.Limit = defaultAccountTransactionsLimit
+	if params.Limit != nil && *params.Limit != 0 {
+		if *params.Limit > maxAccountTransactionsLimit {
+			return filter, fmt.Errorf(errInvalidLimit, maxAccountTransactionsLimit)
+		}
+		filter.Limit = *params.Limit
+	}
+	if params.Next != nil {
+		var next indexer.TransactionLocation
+		_, err = fmt.Sscanf(*params.Next, "%d:%d", &next.Round, &next.Intra)
+		if err != nil || fmt.Sprintf("%d:%d", next.Round, next.Intra) != *params.Next {
+			return filter, errors.New(errFailedToParseNextToken)
+		}
+		filter.Next = &next
+	}
+	if params.MinRound != nil {
+		filter.MinRound = basics.Round(*params.MinRound)
+	}
+	if params.MaxRound != nil {
+		filter.MaxRound = basics.Round(*params.MaxRound)
+	}
+	if params.TxType != nil {
+		switch txType := protocol.TxType(*params.TxType); txType {
+		case protocol.PaymentTx, protocol.KeyRegistrationTx, protocol.AssetConfigTx, protocol.AssetTransferTx, protocol.AssetFreezeTx, protocol.ApplicationCallTx:
+			filter.Type = txType
+		default:
+			return filter, fmt.Errorf(errInvalidTxType, *params.TxType)
+		}
+	}
+	if params.AssetId != nil {
+		filter.AssetID = basics.AssetIndex(*params.AssetId)
+	}
+	if params.ApplicationId != nil {
+		filter.AppID = basics.AppIndex(*params.ApplicationId)
+	}
+	if params.NotePrefix != nil {
+		filter.NotePrefix, err = base64.StdEncoding.DecodeString(*params.NotePrefix)
+		if err != nil || len(filter.NotePrefix) > indexer.MaxNotePrefixLength {
+			return filter, fmt.Errorf(errInvalidNotePrefix, indexer.MaxNotePrefixLength)
+		}
+	}
+	if params.AfterTime != nil {
+		filter.AfterTime = params.AfterTime.Unix()
+	}
+	if params.BeforeTime != nil {
+		filter.BeforeTime = params.BeforeTime.Unix()
+	}
+	return filter, nil
+}
+
+// Batch executes a batch of account, asset, application and pending transaction lookups.
+// (POST /v2/batch)
+func (v2 *Handlers) Batch(ctx echo.Context) error {
+	cfg := v2.Node.Config()
+	if cfg.MaxBatchRequestItems == 0 {
+		err := errors.New(errBatchRequestsNotActive)
+		return notFound(ctx, err, err.Error(), v2.Log)
+	}
+
+	stat, err := v2.Node.Status()
+	if err != nil {
+		return internalError(ctx, err, errFailedRetrievingNodeStatus, v2.Log)
+	}
+	if stat.Catchpoint != "" {
+		// node is currently catching up to the requested catchpoint.
+		return serviceUnavailable(ctx, fmt.Errorf("Batch failed as the node was catchpoint catchuping"), errOperationNotAvailableDuringCatchup, v2.Log)
+	}
+
+	req := ctx.Request()
+	buf := new(bytes.Buffer)
+	req.Body = http.MaxBytesReader(nil, req.Body, int64(cfg.MaxBatchRequestBytes))
+	_, err = buf.ReadFrom(req.Body)
+	if err != nil {
+		return badRequest(ctx, err, err.Error(), v2.Log)
+	}
+
+	var batch generated.BatchRequest
+	err = decode(protocol.JSONHandle, buf.Bytes(), &batch)
+	if err != nil {
+		return badRequest(ctx, err, err.Error(), v2.Log)
+	}
+	if uint64(len(batch.Requests)) > cfg.MaxBatchRequestItems {
+		err = fmt.Errorf(errTooManyBatchRequestItems, cfg.MaxBatchRequestItems)
+		return badRequest(ctx, err, err.Error(), v2.Log)
+	}
+	for i, item := range batch.Requests {
+		if err := validateBatchRequestItem(item); err != nil {
+			return badRequest(ctx, err, fmt.Sprintf("request %d: %v", i, err), v2.Log)
+		}
+	}
+
+	// all the lookups are made against the same round, whichever rounds get committed meanwhile.
+	myLedger := v2.Node.Ledger()
+	response := batchResponse{
+		Round:   myLedger.Latest(),
+		Results: make([]batchResult, len(batch.Requests)),
+	}
+	for i, item := range batch.Requests {
+		response.Results[i] = v2.lookupBatchItem(myLedger, response.Round, item)
+	}
+
+	data, err := encode(protocol.JSONHandle, response)
+	if err != nil {
+		return internalError(ctx, err, errFailedToEncodeResponse, v2.Log)
+	}
+	return ctx.Blob(http.StatusOK, "application/json", data)
+}
+
 // GetBlock gets the block for the given round.
 // (GET /v2/blocks/{round})
 func (v2 *Handlers) GetBlock(ctx echo.Context, round uint64, params generated.GetBlockParams) error {
@@ -406,41 +909,17 @@ func (v2 *Handlers) TealDryrun(ctx echo.Context) error {
 		}
 	}
 
-	// fetch previous block header just once to prevent racing with network
-	var hdr bookkeeping.BlockHeader
-	if dr.ProtocolVersion == "" || dr.Round == 0 || dr.LatestTimestamp == 0 {
-		actualLedger := v2.Node.Ledger()
-		hdr, err = actualLedger.BlockHdr(actualLedger.Latest())
-		if err != nil {
-			return internalError(ctx, err, "current block error", v2.Log)
+	err = setDryrunDefaults(&dr, v2.Node.Ledger())
+	if err != nil {
+		if err == errUnsupportedDryrunProtocol {
+			return badRequest(ctx, nil, err.Error(), v2.Log)
 		}
+		return internalError(ctx, err, "current block error", v2.Log)
 	}
 
 	var response generated.DryrunResponse
-
-	var protocolVersion protocol.ConsensusVersion
-	if dr.ProtocolVersion != "" {
-		var ok bool
-		_, ok = config.Consensus[protocol.ConsensusVersion(dr.ProtocolVersion)]
-		if !ok {
-			return badRequest(ctx, nil, "unsupported protocol version", v2.Log)
-		}
-		protocolVersion = protocol.ConsensusVersion(dr.ProtocolVersion)
-	} else {
-		protocolVersion = hdr.CurrentProtocol
-	}
-	dr.ProtocolVersion = string(protocolVersion)
-
-	if dr.Round == 0 {
-		dr.Round = uint64(hdr.Round + 1)
-	}
-
-	if dr.LatestTimestamp == 0 {
-		dr.LatestTimestamp = hdr.TimeStamp
-	}
-
 	doDryrunRequest(&dr, &response)
-	response.ProtocolVersion = string(protocolVersion)
+	response.ProtocolVersion = dr.ProtocolVersion
 	return ctx.JSON(http.StatusOK, response)
 }
 
@@ -471,6 +950,91 @@ func (v2 *Handlers) TransactionParams(ctx echo.Context) error {
 	return ctx.JSON(http.StatusOK, response)
 }
 
+// TransactionFeeEstimate suggests the fee per byte a new transaction needs to pay in order to be committed
+// within the target number of rounds.
+// (GET /v2/transactions/fee-estimate)
+func (v2 *Handlers) TransactionFeeEstimate(ctx echo.Context, params generated.TransactionFeeEstimateParams) error {
+	stat, err := v2.Node.Status()
+	if err != nil {
+		return internalError(ctx, err, errFailedRetrievingNodeStatus, v2.Log)
+	}
+	if stat.Catchpoint != "" {
+		// node is currently catching up to the requested catchpoint.
+		return serviceUnavailable(ctx, fmt.Errorf("TransactionFeeEstimate failed as the node was catchpoint catchuping"), errOperationNotAvailableDuringCatchup, v2.Log)
+	}
+
+	proto := config.Consensus[stat.LastVersion]
+	targetRounds := uint64(1)
+	if params.TargetRounds != nil {
+		targetRounds = *params.TargetRounds
+	}
+	// a transaction can't be committed later than its maximal lifetime.
+	if targetRounds == 0 || targetRounds > proto.MaxTxnLife {
+		err = fmt.Errorf(errInvalidTargetRounds, proto.MaxTxnLife)
+		return badRequest(ctx, err, err.Error(), v2.Log)
+	}
+
+	estimate := v2.Node.FeeEstimate(targetRounds)
+	response := generated.TransactionFeeEstimateResponse{
+		TargetRounds:  estimate.TargetRounds,
+		LastRound:     uint64(stat.LastRound),
+		PendingBlocks: estimate.PendingBlocks,
+		SampledBlocks: estimate.SampledBlocks,
+		Percentile:    estimate.Percentile,
+		FeePerByte:    estimate.FeePerByte,
+		MinFee:        proto.MinTxnFee,
+		Percentiles:   make([]generated.FeePercentile, 0, len(estimate.Percentiles)),
+	}
+	for _, percentile := range estimate.Percentiles {
+		response.Percentiles = append(response.Percentiles, generated.FeePercentile{
+			Percentile: percentile.Percentile,
+			FeePerByte: percentile.FeePerByte,
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// pendingTransactionResponse is the encoded form of the PendingTransactionResponse.
+// Encoding wasn't working well without embedding "real" objects.
+type pendingTransactionResponse struct {
+	AssetIndex         *uint64                        `codec:"asset-index,omitempty"`
+	AssetClosingAmount *uint64                        `codec:"asset-closing-amount,omitempty"`
+	ApplicationIndex   *uint64                        `codec:"application-index,omitempty"`
+	CloseRewards       *uint64                        `codec:"close-rewards,omitempty"`
+	ClosingAmount      *uint64                        `codec:"closing-amount,omitempty"`
+	ConfirmedRound     *uint64                        `codec:"confirmed-round,omitempty"`
+	GlobalStateDelta   *generated.StateDelta          `codec:"global-state-delta,omitempty"`
+	LocalStateDelta    *[]generated.AccountStateDelta `codec:"local-state-delta,omitempty"`
+	PoolError          string                         `codec:"pool-error"`
+	ReceiverRewards    *uint64                        `codec:"receiver-rewards,omitempty"`
+	SenderRewards      *uint64                        `codec:"sender-rewards,omitempty"`
+	Txn                transactions.SignedTxn         `codec:"txn"`
+}
+
+func makePendingTransactionResponse(txn node.TxnWithStatus, l *data.Ledger) pendingTransactionResponse {
+	response := pendingTransactionResponse{
+		Txn: txn.Txn,
+	}
+
+	if txn.ConfirmedRound != 0 {
+		r := uint64(txn.ConfirmedRound)
+		response.ConfirmedRound = &r
+
+		response.ClosingAmount = &txn.ApplyData.ClosingAmount.Raw
+		response.AssetClosingAmount = &txn.ApplyData.AssetClosingAmount
+		response.SenderRewards = &txn.ApplyData.SenderRewards.Raw
+		response.ReceiverRewards = &txn.ApplyData.ReceiverRewards.Raw
+		response.CloseRewards = &txn.ApplyData.CloseRewards.Raw
+
+		response.AssetIndex = computeAssetIndexFromTxn(txn, l)
+		response.ApplicationIndex = computeAppIndexFromTxn(txn, l)
+
+		response.LocalStateDelta, response.GlobalStateDelta = convertToDeltas(txn)
+	}
+	return response
+}
+
 // PendingTransactionInformation returns a transaction with the specified txID
 // from the transaction pool. If not found looks for the transaction in the
 // last proto.MaxTxnLife rounds
@@ -499,44 +1063,12 @@ func (v2 *Handlers) PendingTransactionInformation(ctx echo.Context, txid string,
 		return notFound(ctx, err, err.Error(), v2.Log)
 	}
 
-	// Encoding wasn't working well without embedding "real" objects.
-	response := struct {
-		AssetIndex         *uint64                        `codec:"asset-index,omitempty"`
-		AssetClosingAmount *uint64                        `codec:"asset-closing-amount,omitempty"`
-		ApplicationIndex   *uint64                        `codec:"application-index,omitempty"`
-		CloseRewards       *uint64                        `codec:"close-rewards,omitempty"`
-		ClosingAmount      *uint64                        `codec:"closing-amount,omitempty"`
-		ConfirmedRound     *uint64                        `codec:"confirmed-round,omitempty"`
-		GlobalStateDelta   *generated.StateDelta          `codec:"global-state-delta,omitempty"`
-		LocalStateDelta    *[]generated.AccountStateDelta `codec:"local-state-delta,omitempty"`
-		PoolError          string                         `codec:"pool-error"`
-		ReceiverRewards    *uint64                        `codec:"receiver-rewards,omitempty"`
-		SenderRewards      *uint64                        `codec:"sender-rewards,omitempty"`
-		Txn                transactions.SignedTxn         `codec:"txn"`
-	}{
-		Txn: txn.Txn,
-	}
-
 	handle, contentType, err := getCodecHandle(params.Format)
 	if err != nil {
 		return badRequest(ctx, err, errFailedParsingFormatOption, v2.Log)
 	}
 
-	if txn.ConfirmedRound != 0 {
-		r := uint64(txn.ConfirmedRound)
-		response.ConfirmedRound = &r
-
-		response.ClosingAmount = &txn.ApplyData.ClosingAmount.Raw
-		response.AssetClosingAmount = &txn.ApplyData.AssetClosingAmount
-		response.SenderRewards = &txn.ApplyData.SenderRewards.Raw
-		response.ReceiverRewards = &txn.ApplyData.ReceiverRewards.Raw
-		response.CloseRewards = &txn.ApplyData.CloseRewards.Raw
-
-		response.AssetIndex = computeAssetIndexFromTxn(txn, v2.Node.Ledger())
-		response.ApplicationIndex = computeAppIndexFromTxn(txn, v2.Node.Ledger())
-
-		response.LocalStateDelta, response.GlobalStateDelta = convertToDeltas(txn)
-	}
+	response := makePendingTransactionResponse(txn, v2.Node.Ledger())
 
 	data, err := encode(handle, response)
 	if err != nil {
@@ -728,6 +1260,142 @@ func (v2 *Handlers) GetAssetByID(ctx echo.Context, assetID uint64) error {
 	return ctx.JSON(http.StatusOK, response)
 }
 
+// GetAssetBalances returns the holders of the given asset, along with their holdings.
+// (GET /v2/assets/{asset-id}/balances)
+func (v2 *Handlers) GetAssetBalances(ctx echo.Context, assetID uint64, params generated.GetAssetBalancesParams) error {
+	stat, err := v2.Node.Status()
+	if err != nil {
+		return internalError(ctx, err, errFailedRetrievingNodeStatus, v2.Log)
+	}
+	if stat.Catchpoint != "" {
+		// node is currently catching up to the requested catchpoint.
+		return serviceUnavailable(ctx, fmt.Errorf("GetAssetBalances failed as the node was catchpoint catchuping"), errOperationNotAvailableDuringCatchup, v2.Log)
+	}
+
+	handle, contentType, err := getCodecHandle(params.Format)
+	if err != nil {
+		return badRequest(ctx, err, errFailedParsingFormatOption, v2.Log)
+	}
+
+	maxResults, after, err := parseHoldersPaging(params.Limit, params.Next)
+	if err != nil {
+		return badRequest(ctx, err, err.Error(), v2.Log)
+	}
+
+	// the currency filters are exclusive.
+	minBalance, maxBalance := uint64(0), uint64(math.MaxUint64)
+	if params.CurrencyGreaterThan != nil {
+		if *params.CurrencyGreaterThan == math.MaxUint64 {
+			maxResults = 0
+		}
+		minBalance = *params.CurrencyGreaterThan + 1
+	}
+	if params.CurrencyLessThan != nil {
+		if *params.CurrencyLessThan == 0 {
+			maxResults = 0
+		}
+		maxBalance = *params.CurrencyLessThan - 1
+	}
+
+	myLedger := v2.Node.Ledger()
+	var holders []ledger.AssetHolder
+	rnd := myLedger.Latest()
+	if maxResults > 0 {
+		holders, rnd, err = myLedger.ListAssetHolders(basics.AssetIndex(assetID), after, minBalance, maxBalance, maxResults)
+		if err != nil {
+			return internalError(ctx, err, errFailedLookingUpLedger, v2.Log)
+		}
+	}
+
+	response := generated.AssetBalancesResponse{
+		CurrentRound: uint64(rnd),
+		Balances:     make([]generated.MiniAssetHolding, 0, len(holders)),
+	}
+	for _, holder := range holders {
+		response.Balances = append(response.Balances, generated.MiniAssetHolding{
+			Address:  holder.Address.String(),
+			Amount:   holder.Holding.Amount,
+			IsFrozen: holder.Holding.Frozen,
+		})
+	}
+	if len(holders) > 0 && uint64(len(holders)) == maxResults {
+		next := holders[len(holders)-1].Address.String()
+		response.NextToken = &next
+	}
+
+	data, err := encode(handle, response)
+	if err != nil {
+		return internalError(ctx, err, errFailedToEncodeResponse, v2.Log)
+	}
+	return ctx.Blob(http.StatusOK, contentType, data)
+}
+
+// GetApplicationAccounts returns the addresses of the accounts opted into the given application.
+// (GET /v2/applications/{application-id}/accounts)
+func (v2 *Handlers) GetApplicationAccounts(ctx echo.Context, applicationID uint64, params generated.GetApplicationAccountsParams) error {
+	stat, err := v2.Node.Status()
+	if err != nil {
+		return internalError(ctx, err, errFailedRetrievingNodeStatus, v2.Log)
+	}
+	if stat.Catchpoint != "" {
+		// node is currently catching up to the requested catchpoint.
+		return serviceUnavailable(ctx, fmt.Errorf("GetApplicationAccounts failed as the node was catchpoint catchuping"), errOperationNotAvailableDuringCatchup, v2.Log)
+	}
+
+	handle, contentType, err := getCodecHandle(params.Format)
+	if err != nil {
+		return badRequest(ctx, err, errFailedParsingFormatOption, v2.Log)
+	}
+
+	maxResults, after, err := parseHoldersPaging(params.Limit, params.Next)
+	if err != nil {
+		return badRequest(ctx, err, err.Error(), v2.Log)
+	}
+
+	addrs, rnd, err := v2.Node.Ledger().ListApplicationAccounts(basics.AppIndex(applicationID), after, maxResults)
+	if err != nil {
+		return internalError(ctx, err, errFailedLookingUpLedger, v2.Log)
+	}
+
+	response := generated.ApplicationAccountsResponse{
+		CurrentRound: uint64(rnd),
+		Accounts:     make([]string, 0, len(addrs)),
+	}
+	for _, addr := range addrs {
+		response.Accounts = append(response.Accounts, addr.String())
+	}
+	if len(addrs) > 0 && uint64(len(addrs)) == maxResults {
+		next := addrs[len(addrs)-1].String()
+		response.NextToken = &next
+	}
+
+	data, err := encode(handle, response)
+	if err != nil {
+		return internalError(ctx, err, errFailedToEncodeResponse, v2.Log)
+	}
+	return ctx.Blob(http.StatusOK, contentType, data)
+}
+
+// parseHoldersPaging converts the paging parameters of the asset balances and application accounts endpoints. The
+// next token of these is the address of the last returned account.
+func parseHoldersPaging(limit *uint64, next *string) (maxResults uint64, after *basics.Address, err error) {
+	maxResults = defaultHoldersLimit
+	if limit != nil && *limit != 0 {
+		if *limit > maxHoldersLimit {
+			return 0, nil, fmt.Errorf(errInvalidLimit, maxHoldersLimit)
+		}
+		maxResults = *limit
+	}
+	if next != nil {
+		addr, err := basics.UnmarshalChecksumAddress(*next)
+		if err != nil {
+			return 0, nil, errors.New(errFailedToParseNextToken)
+		}
+		after = &addr
+	}
+	return maxResults, after, nil
+}
+
 // GetPendingTransactionsByAddress takes an Algorand address and returns its associated list of unconfirmed transactions currently in the transaction pool.
 // (GET /v2/accounts/{address}/transactions/pending)
 func (v2 *Handlers) GetPendingTransactionsByAddress(ctx echo.Context, addr string, params generated.GetPendingTransactionsByAddressParams) error {
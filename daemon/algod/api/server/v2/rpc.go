@@ -0,0 +1,352 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/daemon/algod/api/server/v2/generated"
+	"github.com/algorand/go-algorand/daemon/algod/api/server/v2/generated/rpc"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/data/transactions/logic"
+	"github.com/algorand/go-algorand/logging"
+)
+
+// RPCHandlers implements the gRPC interface defined by the generated code, serving the same operations as Handlers.
+type RPCHandlers struct {
+	Node     NodeInterface
+	Log      logging.Logger
+	Shutdown <-chan struct{}
+}
+
+// rpcError logs an internal message while returning an error with the given code.
+func rpcError(code codes.Code, internal error, external string, logger logging.Logger) error {
+	logger.Info(internal)
+	return status.Error(code, external)
+}
+
+// GetStatus returns the current node status.
+func (h *RPCHandlers) GetStatus(ctx context.Context, req *rpc.StatusRequest) (*rpc.NodeStatus, error) {
+	stat, err := h.Node.Status()
+	if err != nil {
+		return nil, rpcError(codes.Internal, err, errFailedRetrievingNodeStatus, h.Log)
+	}
+	return &rpc.NodeStatus{
+		LastRound:                 uint64(stat.LastRound),
+		LastVersion:               string(stat.LastVersion),
+		NextVersion:               string(stat.NextVersion),
+		NextVersionRound:          uint64(stat.NextVersionRound),
+		NextVersionSupported:      stat.NextVersionSupported,
+		TimeSinceLastRound:        uint64(stat.TimeSinceLastRound().Nanoseconds()),
+		CatchupTime:               uint64(stat.CatchupTime.Nanoseconds()),
+		StoppedAtUnsupportedRound: stat.StoppedAtUnsupportedRound,
+		LastCatchpoint:            stat.LastCatchpoint,
+		Catchpoint:                stat.Catchpoint,
+	}, nil
+}
+
+// WaitForBlock streams the node status every time a round following the requested one is reached.
+func (h *RPCHandlers) WaitForBlock(req *rpc.WaitForBlockRequest, stream rpc.Algod_WaitForBlockServer) error {
+	ledger := h.Node.Ledger()
+	round := basics.Round(req.Round)
+	for {
+		stat, err := h.Node.Status()
+		if err != nil {
+			return rpcError(codes.Internal, err, errFailedRetrievingNodeStatus, h.Log)
+		}
+		if stat.StoppedAtUnsupportedRound {
+			return rpcError(codes.FailedPrecondition, errors.New(errRequestedRoundInUnsupportedRound), errRequestedRoundInUnsupportedRound, h.Log)
+		}
+		if stat.Catchpoint != "" {
+			// node is currently catching up to the requested catchpoint.
+			return rpcError(codes.Unavailable, fmt.Errorf("WaitForBlock failed as the node was catchpoint catchuping"), errOperationNotAvailableDuringCatchup, h.Log)
+		}
+
+		latestBlkHdr, err := ledger.BlockHdr(ledger.Latest())
+		if err != nil {
+			return rpcError(codes.Internal, err, errFailedRetrievingLatestBlockHeaderStatus, h.Log)
+		}
+		if latestBlkHdr.NextProtocol != "" {
+			if _, nextProtocolSupported := config.Consensus[latestBlkHdr.NextProtocol]; !nextProtocolSupported {
+				// see if the desired protocol switch is expect to happen before or after the above point.
+				if latestBlkHdr.NextProtocolSwitchOn <= round+1 {
+					// we would never reach to this round, since this round would happen after the (unsupported) protocol upgrade.
+					return rpcError(codes.FailedPrecondition, errors.New(errRequestedRoundInUnsupportedRound), errRequestedRoundInUnsupportedRound, h.Log)
+				}
+			}
+		}
+
+		select {
+		case <-h.Shutdown:
+			return rpcError(codes.Unavailable, errors.New(errServiceShuttingDown), errServiceShuttingDown, h.Log)
+		case <-stream.Context().Done():
+			return nil
+		case <-ledger.Wait(round + 1):
+		}
+
+		response, err := h.GetStatus(stream.Context(), &rpc.StatusRequest{})
+		if err != nil {
+			return err
+		}
+		err = stream.Send(response)
+		if err != nil {
+			return err
+		}
+		round = basics.Round(response.LastRound)
+	}
+}
+
+// AccountInformation returns the state of an account as of the latest round.
+func (h *RPCHandlers) AccountInformation(ctx context.Context, req *rpc.AccountInformationRequest) (*rpc.Account, error) {
+	addr, err := basics.UnmarshalChecksumAddress(req.Address)
+	if err != nil {
+		return nil, rpcError(codes.InvalidArgument, err, errFailedToParseAddress, h.Log)
+	}
+
+	ledger := h.Node.Ledger()
+	lastRound := ledger.Latest()
+	record, err := ledger.Lookup(lastRound, addr)
+	if err != nil {
+		return nil, rpcError(codes.Internal, err, errFailedLookingUpLedger, h.Log)
+	}
+	recordWithoutPendingRewards, _, err := ledger.LookupWithoutRewards(lastRound, addr)
+	if err != nil {
+		return nil, rpcError(codes.Internal, err, errFailedLookingUpLedger, h.Log)
+	}
+
+	assetsCreators := make(map[basics.AssetIndex]string, len(record.Assets))
+	for idx := range record.Assets {
+		creator, ok, err := ledger.GetCreator(basics.CreatableIndex(idx), basics.AssetCreatable)
+		if err != nil {
+			return nil, rpcError(codes.Internal, err, errFailedLookingUpLedger, h.Log)
+		}
+		if ok {
+			assetsCreators[idx] = creator.String()
+		}
+	}
+
+	account, err := rpcAccount(req.Address, record, assetsCreators, lastRound, recordWithoutPendingRewards.MicroAlgos)
+	if err != nil {
+		return nil, rpcError(codes.Internal, err, errInternalFailure, h.Log)
+	}
+	return account, nil
+}
+
+// GetBlock returns the block of a round.
+func (h *RPCHandlers) GetBlock(ctx context.Context, req *rpc.BlockRequest) (*rpc.Block, error) {
+	ledger := h.Node.Ledger()
+	block, err := ledger.Block(basics.Round(req.Round))
+	if err != nil {
+		return nil, rpcError(codes.NotFound, err, errFailedLookingUpLedger, h.Log)
+	}
+	payset, err := block.DecodePaysetFlat()
+	if err != nil {
+		return nil, rpcError(codes.Internal, err, errFailedToParseBlock, h.Log)
+	}
+	return rpcBlock(block, payset), nil
+}
+
+// SubmitTransactions broadcasts a group of signed transactions to the network.
+func (h *RPCHandlers) SubmitTransactions(ctx context.Context, req *rpc.SubmitTransactionsRequest) (*rpc.SubmitTransactionsResponse, error) {
+	stat, err := h.Node.Status()
+	if err != nil {
+		return nil, rpcError(codes.Internal, err, errFailedRetrievingNodeStatus, h.Log)
+	}
+	if stat.Catchpoint != "" {
+		// node is currently catching up to the requested catchpoint.
+		return nil, rpcError(codes.Unavailable, fmt.Errorf("SubmitTransactions failed as the node was catchpoint catchuping"), errOperationNotAvailableDuringCatchup, h.Log)
+	}
+	proto := config.Consensus[stat.LastVersion]
+
+	if len(req.SignedTransactions) == 0 {
+		err := errors.New("empty txgroup")
+		return nil, rpcError(codes.InvalidArgument, err, err.Error(), h.Log)
+	}
+	if len(req.SignedTransactions) > proto.MaxTxGroupSize {
+		err := fmt.Errorf("max group size is %d", proto.MaxTxGroupSize)
+		return nil, rpcError(codes.InvalidArgument, err, err.Error(), h.Log)
+	}
+	txgroup := make([]transactions.SignedTxn, len(req.SignedTransactions))
+	for i, stxn := range req.SignedTransactions {
+		txgroup[i], err = signedTransactionFromRPC(stxn)
+		if err != nil {
+			return nil, rpcError(codes.InvalidArgument, err, errFailedToParseTransaction, h.Log)
+		}
+	}
+
+	err = h.Node.BroadcastSignedTxGroup(txgroup)
+	if err != nil {
+		return nil, rpcError(codes.InvalidArgument, err, err.Error(), h.Log)
+	}
+	return &rpc.SubmitTransactionsResponse{Txid: txgroup[0].ID().String()}, nil
+}
+
+// PendingTransactionInformation returns a transaction from the transaction pool, or from the recently confirmed rounds.
+func (h *RPCHandlers) PendingTransactionInformation(ctx context.Context, req *rpc.PendingTransactionRequest) (*rpc.PendingTransaction, error) {
+	stat, err := h.Node.Status()
+	if err != nil {
+		return nil, rpcError(codes.Internal, err, errFailedRetrievingNodeStatus, h.Log)
+	}
+	if stat.Catchpoint != "" {
+		// node is currently catching up to the requested catchpoint.
+		return nil, rpcError(codes.Unavailable, fmt.Errorf("PendingTransactionInformation failed as the node was catchpoint catchuping"), errOperationNotAvailableDuringCatchup, h.Log)
+	}
+
+	var txID transactions.Txid
+	if err := txID.UnmarshalText([]byte(req.Txid)); err != nil {
+		return nil, rpcError(codes.InvalidArgument, err, errNoTxnSpecified, h.Log)
+	}
+
+	txn, ok := h.Node.GetPendingTransaction(txID)
+	if !ok {
+		err := errors.New(errTransactionNotFound)
+		return nil, rpcError(codes.NotFound, err, err.Error(), h.Log)
+	}
+
+	response := &rpc.PendingTransaction{
+		Txn:       rpcSignedTransaction(&txn.Txn, nil),
+		PoolError: txn.PoolError,
+	}
+	if txn.ConfirmedRound != 0 {
+		response.ConfirmedRound = uint64(txn.ConfirmedRound)
+		response.ClosingAmount = txn.ApplyData.ClosingAmount.Raw
+		response.AssetClosingAmount = txn.ApplyData.AssetClosingAmount
+		response.SenderRewards = txn.ApplyData.SenderRewards.Raw
+		response.ReceiverRewards = txn.ApplyData.ReceiverRewards.Raw
+		response.CloseRewards = txn.ApplyData.CloseRewards.Raw
+		response.GlobalDelta = rpcStateDelta(txn.ApplyData.EvalDelta.GlobalDelta)
+		response.LocalDeltas = rpcLocalDeltas(txn.ApplyData.EvalDelta.LocalDeltas)
+		if aidx := computeAssetIndexFromTxn(txn, h.Node.Ledger()); aidx != nil {
+			response.AssetIndex = *aidx
+		}
+		if appIdx := computeAppIndexFromTxn(txn, h.Node.Ledger()); appIdx != nil {
+			response.ApplicationIndex = *appIdx
+		}
+	}
+	return response, nil
+}
+
+// GetPendingTransactions returns the transactions of the transaction pool.
+func (h *RPCHandlers) GetPendingTransactions(ctx context.Context, req *rpc.PendingTransactionsRequest) (*rpc.PendingTransactions, error) {
+	stat, err := h.Node.Status()
+	if err != nil {
+		return nil, rpcError(codes.Internal, err, errFailedRetrievingNodeStatus, h.Log)
+	}
+	if stat.Catchpoint != "" {
+		// node is currently catching up to the requested catchpoint.
+		return nil, rpcError(codes.Unavailable, fmt.Errorf("GetPendingTransactions failed as the node was catchpoint catchuping"), errOperationNotAvailableDuringCatchup, h.Log)
+	}
+
+	var addrPtr *basics.Address
+	if req.Address != "" {
+		addr, err := basics.UnmarshalChecksumAddress(req.Address)
+		if err != nil {
+			return nil, rpcError(codes.InvalidArgument, err, errFailedToParseAddress, h.Log)
+		}
+		addrPtr = &addr
+	}
+
+	txnPool, err := h.Node.GetPendingTxnsFromPool()
+	if err != nil {
+		return nil, rpcError(codes.Internal, err, errFailedLookingUpTransactionPool, h.Log)
+	}
+
+	// MatchAddress uses this to check FeeSink, we don't care about that here.
+	spec := transactions.SpecialAddresses{}
+	response := &rpc.PendingTransactions{TotalTransactions: uint64(len(txnPool))}
+	for i := range txnPool {
+		if req.Max != 0 && uint64(len(response.TopTransactions)) >= req.Max {
+			break
+		}
+		if addrPtr != nil && !txnPool[i].Txn.MatchAddress(*addrPtr, spec) {
+			continue
+		}
+		response.TopTransactions = append(response.TopTransactions, rpcSignedTransaction(&txnPool[i], nil))
+	}
+	return response, nil
+}
+
+// TealDryrun executes TEAL programs in a sandbox.
+func (h *RPCHandlers) TealDryrun(ctx context.Context, req *rpc.DryrunRequest) (*rpc.DryrunResponse, error) {
+	if !h.Node.Config().EnableDeveloperAPI {
+		err := errors.New("TealDryrun was not enabled in the configuration file by setting the EnableDeveloperAPI to true")
+		return nil, rpcError(codes.Unimplemented, err, err.Error(), h.Log)
+	}
+	if proto.Size(req) > maxTealDryrunBytes {
+		err := fmt.Errorf("dry-run request exceeds %d bytes", int(maxTealDryrunBytes))
+		return nil, rpcError(codes.InvalidArgument, err, err.Error(), h.Log)
+	}
+
+	dr, err := dryrunRequestFromRPC(req)
+	if err != nil {
+		return nil, rpcError(codes.InvalidArgument, err, err.Error(), h.Log)
+	}
+	err = setDryrunDefaults(&dr, h.Node.Ledger())
+	if err != nil {
+		if err == errUnsupportedDryrunProtocol {
+			return nil, rpcError(codes.InvalidArgument, err, err.Error(), h.Log)
+		}
+		return nil, rpcError(codes.Internal, err, "current block error", h.Log)
+	}
+
+	var result generated.DryrunResponse
+	doDryrunRequest(&dr, &result)
+
+	response := &rpc.DryrunResponse{
+		Error:           result.Error,
+		ProtocolVersion: dr.ProtocolVersion,
+		Txns:            make([]*rpc.DryrunTxnResult, 0, len(result.Txns)),
+	}
+	for i := range result.Txns {
+		txn, err := rpcDryrunTxnResult(&result.Txns[i])
+		if err != nil {
+			return nil, rpcError(codes.Internal, err, errInternalFailure, h.Log)
+		}
+		response.Txns = append(response.Txns, txn)
+	}
+	return response, nil
+}
+
+// TealCompile compiles TEAL source code to its binary form.
+func (h *RPCHandlers) TealCompile(ctx context.Context, req *rpc.CompileRequest) (*rpc.CompileResponse, error) {
+	if !h.Node.Config().EnableDeveloperAPI {
+		err := errors.New("TealCompile was not enabled in the configuration file by setting the EnableDeveloperAPI to true")
+		return nil, rpcError(codes.Unimplemented, err, err.Error(), h.Log)
+	}
+	if len(req.Source) > maxTealSourceBytes {
+		err := fmt.Errorf("source exceeds %d bytes", int(maxTealSourceBytes))
+		return nil, rpcError(codes.InvalidArgument, err, err.Error(), h.Log)
+	}
+	ops, err := logic.AssembleString(req.Source)
+	if err != nil {
+		return nil, rpcError(codes.InvalidArgument, err, err.Error(), h.Log)
+	}
+	pd := logic.HashProgram(ops.Program)
+	addr := basics.Address(pd)
+	return &rpc.CompileResponse{
+		Hash:   addr.String(),
+		Result: ops.Program,
+	}, nil
+}
@@ -0,0 +1,964 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package v2
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/crypto/compactcert"
+	"github.com/algorand/go-algorand/daemon/algod/api/server/v2/generated"
+	"github.com/algorand/go-algorand/daemon/algod/api/server/v2/generated/rpc"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// This file converts between the messages of the gRPC interface and the ledger types. The conversions of the signed
+// transactions are lossless, so that the transactions submitted over gRPC keep their ids and signatures.
+
+// rpcAddress returns the checksummed form of the address, or an empty string for the zero address.
+func rpcAddress(addr basics.Address) string {
+	if addr.IsZero() {
+		return ""
+	}
+	return addr.String()
+}
+
+// addressFromRPC parses the checksummed form of an address, an empty string being the zero address.
+func addressFromRPC(addr string) (basics.Address, error) {
+	if addr == "" {
+		return basics.Address{}, nil
+	}
+	return basics.UnmarshalChecksumAddress(addr)
+}
+
+// rpcFixedBytes returns the bytes of a fixed size field, or nil if they are all zero.
+func rpcFixedBytes(b []byte) []byte {
+	for _, c := range b {
+		if c != 0 {
+			return append([]byte(nil), b...)
+		}
+	}
+	return nil
+}
+
+// fixedBytesFromRPC copies the bytes of a fixed size field, which are either missing or of the size of the field.
+func fixedBytesFromRPC(dst []byte, src []byte, field string) error {
+	if len(src) != 0 && len(src) != len(dst) {
+		return fmt.Errorf("%s is %d bytes long instead of %d", field, len(src), len(dst))
+	}
+	copy(dst, src)
+	return nil
+}
+
+func rpcStateSchema(schema basics.StateSchema) *rpc.StateSchema {
+	return &rpc.StateSchema{
+		NumUint:      schema.NumUint,
+		NumByteSlice: schema.NumByteSlice,
+	}
+}
+
+func stateSchemaFromRPC(schema *rpc.StateSchema) basics.StateSchema {
+	return basics.StateSchema{
+		NumUint:      schema.GetNumUint(),
+		NumByteSlice: schema.GetNumByteSlice(),
+	}
+}
+
+func rpcTealKeyValue(tkv basics.TealKeyValue) []*rpc.TealKeyValue {
+	if len(tkv) == 0 {
+		return nil
+	}
+	converted := make([]*rpc.TealKeyValue, 0, len(tkv))
+	for k, v := range tkv {
+		converted = append(converted, &rpc.TealKeyValue{
+			Key: []byte(k),
+			Value: &rpc.TealValue{
+				Type:  uint64(v.Type),
+				Bytes: []byte(v.Bytes),
+				Uint:  v.Uint,
+			},
+		})
+	}
+	sort.Slice(converted, func(i, j int) bool {
+		return string(converted[i].Key) < string(converted[j].Key)
+	})
+	return converted
+}
+
+func tealKeyValueFromRPC(kvs []*rpc.TealKeyValue) basics.TealKeyValue {
+	if len(kvs) == 0 {
+		return nil
+	}
+	tkv := make(basics.TealKeyValue, len(kvs))
+	for _, kv := range kvs {
+		tkv[string(kv.Key)] = basics.TealValue{
+			Type:  basics.TealType(kv.Value.GetType()),
+			Bytes: string(kv.Value.GetBytes()),
+			Uint:  kv.Value.GetUint(),
+		}
+	}
+	return tkv
+}
+
+func rpcAssetParams(creator string, params basics.AssetParams) *rpc.AssetParams {
+	return &rpc.AssetParams{
+		Creator:       creator,
+		Total:         params.Total,
+		Decimals:      params.Decimals,
+		DefaultFrozen: params.DefaultFrozen,
+		UnitName:      params.UnitName,
+		Name:          params.AssetName,
+		Url:           params.URL,
+		MetadataHash:  rpcFixedBytes(params.MetadataHash[:]),
+		Manager:       rpcAddress(params.Manager),
+		Reserve:       rpcAddress(params.Reserve),
+		Freeze:        rpcAddress(params.Freeze),
+		Clawback:      rpcAddress(params.Clawback),
+	}
+}
+
+func assetParamsFromRPC(params *rpc.AssetParams) (out basics.AssetParams, err error) {
+	if params == nil {
+		return
+	}
+	out = basics.AssetParams{
+		Total:         params.Total,
+		Decimals:      params.Decimals,
+		DefaultFrozen: params.DefaultFrozen,
+		UnitName:      params.UnitName,
+		AssetName:     params.Name,
+		URL:           params.Url,
+	}
+	if err = fixedBytesFromRPC(out.MetadataHash[:], params.MetadataHash, "metadata_hash"); err != nil {
+		return
+	}
+	if out.Manager, err = addressFromRPC(params.Manager); err != nil {
+		return
+	}
+	if out.Reserve, err = addressFromRPC(params.Reserve); err != nil {
+		return
+	}
+	if out.Freeze, err = addressFromRPC(params.Freeze); err != nil {
+		return
+	}
+	out.Clawback, err = addressFromRPC(params.Clawback)
+	return
+}
+
+func rpcApplication(creator string, appIdx basics.AppIndex, params basics.AppParams) *rpc.Application {
+	return &rpc.Application{
+		Id: uint64(appIdx),
+		Params: &rpc.ApplicationParams{
+			Creator:           creator,
+			ApprovalProgram:   params.ApprovalProgram,
+			ClearStateProgram: params.ClearStateProgram,
+			ExtraProgramPages: params.ExtraProgramPages,
+			LocalStateSchema:  rpcStateSchema(params.LocalStateSchema),
+			GlobalStateSchema: rpcStateSchema(params.GlobalStateSchema),
+			GlobalState:       rpcTealKeyValue(params.GlobalState),
+		},
+	}
+}
+
+func appParamsFromRPC(params *rpc.ApplicationParams) basics.AppParams {
+	return basics.AppParams{
+		ApprovalProgram:   params.GetApprovalProgram(),
+		ClearStateProgram: params.GetClearStateProgram(),
+		GlobalState:       tealKeyValueFromRPC(params.GetGlobalState()),
+		StateSchemas: basics.StateSchemas{
+			LocalStateSchema:  stateSchemaFromRPC(params.GetLocalStateSchema()),
+			GlobalStateSchema: stateSchemaFromRPC(params.GetGlobalStateSchema()),
+		},
+		ExtraProgramPages: params.GetExtraProgramPages(),
+	}
+}
+
+// rpcAccount converts an account record to its gRPC message, mirroring AccountDataToAccount.
+func rpcAccount(address string, record basics.AccountData, assetsCreators map[basics.AssetIndex]string, lastRound basics.Round, amountWithoutPendingRewards basics.MicroAlgos) (*rpc.Account, error) {
+	pendingRewards, overflowed := basics.OSubA(record.MicroAlgos, amountWithoutPendingRewards)
+	if overflowed {
+		return nil, fmt.Errorf("overflow on pending reward calcuation")
+	}
+
+	account := &rpc.Account{
+		Address:                     address,
+		Round:                       uint64(lastRound),
+		Amount:                      record.MicroAlgos.Raw,
+		AmountWithoutPendingRewards: amountWithoutPendingRewards.Raw,
+		PendingRewards:              pendingRewards.Raw,
+		Rewards:                     record.RewardedMicroAlgos.Raw,
+		RewardBase:                  record.RewardsBase,
+		Status:                      record.Status.String(),
+		AppsTotalSchema:             rpcStateSchema(record.TotalAppSchema),
+		AppsTotalExtraPages:         uint64(record.TotalExtraAppPages),
+		AuthAddr:                    rpcAddress(record.AuthAddr),
+	}
+	if record.VoteID != (crypto.OneTimeSignatureVerifier{}) {
+		account.Participation = &rpc.AccountParticipation{
+			VoteParticipationKey:      append([]byte(nil), record.VoteID[:]...),
+			SelectionParticipationKey: append([]byte(nil), record.SelectionID[:]...),
+			VoteFirstValid:            uint64(record.VoteFirstValid),
+			VoteLastValid:             uint64(record.VoteLastValid),
+			VoteKeyDilution:           record.VoteKeyDilution,
+		}
+	}
+
+	for idx, holding := range record.Assets {
+		account.Assets = append(account.Assets, &rpc.AssetHolding{
+			AssetId:  uint64(idx),
+			Amount:   holding.Amount,
+			IsFrozen: holding.Frozen,
+			Creator:  assetsCreators[idx],
+		})
+	}
+	sort.Slice(account.Assets, func(i, j int) bool {
+		return account.Assets[i].AssetId < account.Assets[j].AssetId
+	})
+
+	for idx, params := range record.AssetParams {
+		account.CreatedAssets = append(account.CreatedAssets, &rpc.Asset{
+			Index:  uint64(idx),
+			Params: rpcAssetParams(address, params),
+		})
+	}
+	sort.Slice(account.CreatedAssets, func(i, j int) bool {
+		return account.CreatedAssets[i].Index < account.CreatedAssets[j].Index
+	})
+
+	for appIdx, params := range record.AppParams {
+		account.CreatedApps = append(account.CreatedApps, rpcApplication(address, appIdx, params))
+	}
+	sort.Slice(account.CreatedApps, func(i, j int) bool {
+		return account.CreatedApps[i].Id < account.CreatedApps[j].Id
+	})
+
+	for appIdx, state := range record.AppLocalStates {
+		account.AppsLocalState = append(account.AppsLocalState, &rpc.ApplicationLocalState{
+			Id:       uint64(appIdx),
+			Schema:   rpcStateSchema(state.Schema),
+			KeyValue: rpcTealKeyValue(state.KeyValue),
+		})
+	}
+	sort.Slice(account.AppsLocalState, func(i, j int) bool {
+		return account.AppsLocalState[i].Id < account.AppsLocalState[j].Id
+	})
+	return account, nil
+}
+
+// accountFromRPC converts the gRPC message of an account to its REST API model, as used by the dry-run requests.
+func accountFromRPC(account *rpc.Account) (generated.Account, error) {
+	addr, err := basics.UnmarshalChecksumAddress(account.Address)
+	if err != nil {
+		return generated.Account{}, err
+	}
+	status, err := basics.UnmarshalStatus(account.Status)
+	if err != nil {
+		return generated.Account{}, err
+	}
+	record := basics.AccountData{
+		Status:             status,
+		MicroAlgos:         basics.MicroAlgos{Raw: account.Amount},
+		RewardsBase:        account.RewardBase,
+		RewardedMicroAlgos: basics.MicroAlgos{Raw: account.Rewards},
+		TotalAppSchema:     stateSchemaFromRPC(account.AppsTotalSchema),
+		TotalExtraAppPages: uint32(account.AppsTotalExtraPages),
+	}
+	if record.AuthAddr, err = addressFromRPC(account.AuthAddr); err != nil {
+		return generated.Account{}, err
+	}
+	if part := account.Participation; part != nil {
+		if err = fixedBytesFromRPC(record.VoteID[:], part.VoteParticipationKey, "vote_participation_key"); err != nil {
+			return generated.Account{}, err
+		}
+		if err = fixedBytesFromRPC(record.SelectionID[:], part.SelectionParticipationKey, "selection_participation_key"); err != nil {
+			return generated.Account{}, err
+		}
+		record.VoteFirstValid = basics.Round(part.VoteFirstValid)
+		record.VoteLastValid = basics.Round(part.VoteLastValid)
+		record.VoteKeyDilution = part.VoteKeyDilution
+	}
+
+	assetsCreators := make(map[basics.AssetIndex]string, len(account.Assets))
+	if len(account.Assets) > 0 {
+		record.Assets = make(map[basics.AssetIndex]basics.AssetHolding, len(account.Assets))
+	}
+	for _, holding := range account.Assets {
+		record.Assets[basics.AssetIndex(holding.AssetId)] = basics.AssetHolding{Amount: holding.Amount, Frozen: holding.IsFrozen}
+		assetsCreators[basics.AssetIndex(holding.AssetId)] = holding.Creator
+	}
+	if len(account.CreatedAssets) > 0 {
+		record.AssetParams = make(map[basics.AssetIndex]basics.AssetParams, len(account.CreatedAssets))
+	}
+	for _, asset := range account.CreatedAssets {
+		record.AssetParams[basics.AssetIndex(asset.Index)], err = assetParamsFromRPC(asset.Params)
+		if err != nil {
+			return generated.Account{}, err
+		}
+	}
+	if len(account.CreatedApps) > 0 {
+		record.AppParams = make(map[basics.AppIndex]basics.AppParams, len(account.CreatedApps))
+	}
+	for _, app := range account.CreatedApps {
+		record.AppParams[basics.AppIndex(app.Id)] = appParamsFromRPC(app.Params)
+	}
+	if len(account.AppsLocalState) > 0 {
+		record.AppLocalStates = make(map[basics.AppIndex]basics.AppLocalState, len(account.AppsLocalState))
+	}
+	for _, state := range account.AppsLocalState {
+		record.AppLocalStates[basics.AppIndex(state.Id)] = basics.AppLocalState{
+			Schema:   stateSchemaFromRPC(state.Schema),
+			KeyValue: tealKeyValueFromRPC(state.KeyValue),
+		}
+	}
+
+	withoutPendingRewards, overflowed := basics.OSubA(record.MicroAlgos, basics.MicroAlgos{Raw: account.PendingRewards})
+	if overflowed {
+		return generated.Account{}, fmt.Errorf("pending rewards of %s exceed its amount", account.Address)
+	}
+	return AccountDataToAccount(addr.String(), &record, assetsCreators, basics.Round(account.Round), withoutPendingRewards)
+}
+
+// applicationFromRPC converts the gRPC message of an application to its REST API model, as used by the dry-run
+// requests.
+func applicationFromRPC(app *rpc.Application) (generated.Application, error) {
+	if _, err := addressFromRPC(app.Params.GetCreator()); err != nil {
+		return generated.Application{}, err
+	}
+	params := appParamsFromRPC(app.Params)
+	return AppParamsToApplication(app.Params.GetCreator(), basics.AppIndex(app.Id), &params), nil
+}
+
+func rpcStateDelta(sd basics.StateDelta) []*rpc.EvalDeltaKeyValue {
+	if len(sd) == 0 {
+		return nil
+	}
+	converted := make([]*rpc.EvalDeltaKeyValue, 0, len(sd))
+	for k, v := range sd {
+		converted = append(converted, &rpc.EvalDeltaKeyValue{
+			Key:    []byte(k),
+			Action: uint64(v.Action),
+			Bytes:  []byte(v.Bytes),
+			Uint:   v.Uint,
+		})
+	}
+	sort.Slice(converted, func(i, j int) bool {
+		return string(converted[i].Key) < string(converted[j].Key)
+	})
+	return converted
+}
+
+func rpcLocalDeltas(deltas map[uint64]basics.StateDelta) []*rpc.AccountStateDelta {
+	if len(deltas) == 0 {
+		return nil
+	}
+	converted := make([]*rpc.AccountStateDelta, 0, len(deltas))
+	for idx, sd := range deltas {
+		converted = append(converted, &rpc.AccountStateDelta{
+			Index: idx,
+			Delta: rpcStateDelta(sd),
+		})
+	}
+	sort.Slice(converted, func(i, j int) bool {
+		return converted[i].Index < converted[j].Index
+	})
+	return converted
+}
+
+func rpcApplyData(ad transactions.ApplyData) *rpc.ApplyData {
+	return &rpc.ApplyData{
+		ClosingAmount:      ad.ClosingAmount.Raw,
+		AssetClosingAmount: ad.AssetClosingAmount,
+		SenderRewards:      ad.SenderRewards.Raw,
+		ReceiverRewards:    ad.ReceiverRewards.Raw,
+		CloseRewards:       ad.CloseRewards.Raw,
+		GlobalDelta:        rpcStateDelta(ad.EvalDelta.GlobalDelta),
+		LocalDeltas:        rpcLocalDeltas(ad.EvalDelta.LocalDeltas),
+	}
+}
+
+func rpcMultisigSig(msig crypto.MultisigSig) *rpc.MultisigSig {
+	if msig.MsgIsZero() {
+		return nil
+	}
+	converted := &rpc.MultisigSig{
+		Version:   uint32(msig.Version),
+		Threshold: uint32(msig.Threshold),
+	}
+	for _, subsig := range msig.Subsigs {
+		converted.Subsigs = append(converted.Subsigs, &rpc.MultisigSubsig{
+			Key: append([]byte(nil), subsig.Key[:]...),
+			Sig: rpcFixedBytes(subsig.Sig[:]),
+		})
+	}
+	return converted
+}
+
+func multisigSigFromRPC(msig *rpc.MultisigSig) (out crypto.MultisigSig, err error) {
+	if msig == nil {
+		return
+	}
+	if msig.Version > 255 || msig.Threshold > 255 {
+		return out, fmt.Errorf("multisig version %d or threshold %d is out of range", msig.Version, msig.Threshold)
+	}
+	out.Version = uint8(msig.Version)
+	out.Threshold = uint8(msig.Threshold)
+	if len(msig.Subsigs) > 0 {
+		out.Subsigs = make([]crypto.MultisigSubsig, len(msig.Subsigs))
+	}
+	for i, subsig := range msig.Subsigs {
+		if err = fixedBytesFromRPC(out.Subsigs[i].Key[:], subsig.Key, "subsig key"); err != nil {
+			return
+		}
+		if err = fixedBytesFromRPC(out.Subsigs[i].Sig[:], subsig.Sig, "subsig sig"); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func rpcOneTimeSignature(sig crypto.OneTimeSignature) *rpc.OneTimeSignature {
+	return &rpc.OneTimeSignature{
+		Sig:      rpcFixedBytes(sig.Sig[:]),
+		Pk:       rpcFixedBytes(sig.PK[:]),
+		PkSigOld: rpcFixedBytes(sig.PKSigOld[:]),
+		Pk2:      rpcFixedBytes(sig.PK2[:]),
+		Pk1Sig:   rpcFixedBytes(sig.PK1Sig[:]),
+		Pk2Sig:   rpcFixedBytes(sig.PK2Sig[:]),
+	}
+}
+
+func oneTimeSignatureFromRPC(sig *rpc.OneTimeSignature) (out crypto.OneTimeSignature, err error) {
+	if err = fixedBytesFromRPC(out.Sig[:], sig.GetSig(), "sig"); err != nil {
+		return
+	}
+	if err = fixedBytesFromRPC(out.PK[:], sig.GetPk(), "pk"); err != nil {
+		return
+	}
+	if err = fixedBytesFromRPC(out.PKSigOld[:], sig.GetPkSigOld(), "pk_sig_old"); err != nil {
+		return
+	}
+	if err = fixedBytesFromRPC(out.PK2[:], sig.GetPk2(), "pk2"); err != nil {
+		return
+	}
+	if err = fixedBytesFromRPC(out.PK1Sig[:], sig.GetPk1Sig(), "pk1_sig"); err != nil {
+		return
+	}
+	err = fixedBytesFromRPC(out.PK2Sig[:], sig.GetPk2Sig(), "pk2_sig")
+	return
+}
+
+func rpcDigests(digests []crypto.Digest) [][]byte {
+	converted := make([][]byte, 0, len(digests))
+	for _, d := range digests {
+		converted = append(converted, append([]byte(nil), d[:]...))
+	}
+	return converted
+}
+
+func digestsFromRPC(digests [][]byte, field string) ([]crypto.Digest, error) {
+	if len(digests) == 0 {
+		return nil, nil
+	}
+	converted := make([]crypto.Digest, len(digests))
+	for i, d := range digests {
+		if err := fixedBytesFromRPC(converted[i][:], d, field); err != nil {
+			return nil, err
+		}
+	}
+	return converted, nil
+}
+
+func rpcCompactCert(cert compactcert.Cert) *rpc.CompactCert {
+	converted := &rpc.CompactCert{
+		SigCommit:    rpcFixedBytes(cert.SigCommit[:]),
+		SignedWeight: cert.SignedWeight,
+		SigProofs:    rpcDigests(cert.SigProofs),
+		PartProofs:   rpcDigests(cert.PartProofs),
+	}
+	if len(cert.Reveals) > 0 {
+		converted.Reveals = make(map[uint64]*rpc.CompactCertReveal, len(cert.Reveals))
+	}
+	for pos, r := range cert.Reveals {
+		converted.Reveals[pos] = &rpc.CompactCertReveal{
+			Sig:                    rpcOneTimeSignature(r.SigSlot.Sig.OneTimeSignature),
+			SigWeight:              r.SigSlot.L,
+			ParticipantPk:          rpcFixedBytes(r.Part.PK[:]),
+			ParticipantWeight:      r.Part.Weight,
+			ParticipantKeyDilution: r.Part.KeyDilution,
+		}
+	}
+	return converted
+}
+
+func compactCertFromRPC(cert *rpc.CompactCert) (out compactcert.Cert, err error) {
+	if cert == nil {
+		return
+	}
+	if err = fixedBytesFromRPC(out.SigCommit[:], cert.SigCommit, "sig_commit"); err != nil {
+		return
+	}
+	out.SignedWeight = cert.SignedWeight
+	if out.SigProofs, err = digestsFromRPC(cert.SigProofs, "sig_proofs"); err != nil {
+		return
+	}
+	if out.PartProofs, err = digestsFromRPC(cert.PartProofs, "part_proofs"); err != nil {
+		return
+	}
+	if len(cert.Reveals) > 0 {
+		out.Reveals = make(map[uint64]compactcert.Reveal, len(cert.Reveals))
+	}
+	for pos, r := range cert.Reveals {
+		var reveal compactcert.Reveal
+		if reveal.SigSlot.Sig.OneTimeSignature, err = oneTimeSignatureFromRPC(r.Sig); err != nil {
+			return
+		}
+		reveal.SigSlot.L = r.SigWeight
+		if err = fixedBytesFromRPC(reveal.Part.PK[:], r.ParticipantPk, "participant_pk"); err != nil {
+			return
+		}
+		reveal.Part.Weight = r.ParticipantWeight
+		reveal.Part.KeyDilution = r.ParticipantKeyDilution
+		out.Reveals[pos] = reveal
+	}
+	return
+}
+
+// rpcTransaction converts a transaction to its gRPC message. The fields of each transaction type are only set when
+// any of them is.
+func rpcTransaction(txn *transactions.Transaction) *rpc.Transaction {
+	converted := &rpc.Transaction{
+		Type:        string(txn.Type),
+		Sender:      rpcAddress(txn.Sender),
+		Fee:         txn.Fee.Raw,
+		FirstValid:  uint64(txn.FirstValid),
+		LastValid:   uint64(txn.LastValid),
+		Note:        txn.Note,
+		GenesisId:   txn.GenesisID,
+		GenesisHash: rpcFixedBytes(txn.GenesisHash[:]),
+		Group:       rpcFixedBytes(txn.Group[:]),
+		Lease:       rpcFixedBytes(txn.Lease[:]),
+		RekeyTo:     rpcAddress(txn.RekeyTo),
+	}
+	if !txn.KeyregTxnFields.MsgIsZero() {
+		converted.Keyreg = &rpc.KeyregFields{
+			VotePk:           rpcFixedBytes(txn.VotePK[:]),
+			SelectionPk:      rpcFixedBytes(txn.SelectionPK[:]),
+			VoteFirst:        uint64(txn.VoteFirst),
+			VoteLast:         uint64(txn.VoteLast),
+			VoteKeyDilution:  txn.VoteKeyDilution,
+			Nonparticipation: txn.Nonparticipation,
+		}
+	}
+	if !txn.PaymentTxnFields.MsgIsZero() {
+		converted.Payment = &rpc.PaymentFields{
+			Receiver:         rpcAddress(txn.Receiver),
+			Amount:           txn.Amount.Raw,
+			CloseRemainderTo: rpcAddress(txn.CloseRemainderTo),
+		}
+	}
+	if !txn.AssetConfigTxnFields.MsgIsZero() {
+		converted.AssetConfig = &rpc.AssetConfigFields{
+			ConfigAsset: uint64(txn.ConfigAsset),
+			Params:      rpcAssetParams("", txn.AssetParams),
+		}
+	}
+	if !txn.AssetTransferTxnFields.MsgIsZero() {
+		converted.AssetTransfer = &rpc.AssetTransferFields{
+			XferAsset:     uint64(txn.XferAsset),
+			AssetAmount:   txn.AssetAmount,
+			AssetSender:   rpcAddress(txn.AssetSender),
+			AssetReceiver: rpcAddress(txn.AssetReceiver),
+			AssetCloseTo:  rpcAddress(txn.AssetCloseTo),
+		}
+	}
+	if !txn.AssetFreezeTxnFields.MsgIsZero() {
+		converted.AssetFreeze = &rpc.AssetFreezeFields{
+			FreezeAccount: rpcAddress(txn.FreezeAccount),
+			FreezeAsset:   uint64(txn.FreezeAsset),
+			AssetFrozen:   txn.AssetFrozen,
+		}
+	}
+	if !txn.ApplicationCallTxnFields.MsgIsZero() {
+		call := &rpc.ApplicationCallFields{
+			ApplicationId:     uint64(txn.ApplicationID),
+			OnCompletion:      rpc.OnCompletion(txn.OnCompletion),
+			ApplicationArgs:   txn.ApplicationArgs,
+			LocalStateSchema:  rpcStateSchema(txn.LocalStateSchema),
+			GlobalStateSchema: rpcStateSchema(txn.GlobalStateSchema),
+			ApprovalProgram:   txn.ApprovalProgram,
+			ClearStateProgram: txn.ClearStateProgram,
+			ExtraProgramPages: txn.ExtraProgramPages,
+		}
+		for _, addr := range txn.Accounts {
+			call.Accounts = append(call.Accounts, rpcAddress(addr))
+		}
+		for _, appIdx := range txn.ForeignApps {
+			call.ForeignApps = append(call.ForeignApps, uint64(appIdx))
+		}
+		for _, assetIdx := range txn.ForeignAssets {
+			call.ForeignAssets = append(call.ForeignAssets, uint64(assetIdx))
+		}
+		converted.ApplicationCall = call
+	}
+	if !txn.CompactCertTxnFields.MsgIsZero() {
+		converted.CompactCert = &rpc.CompactCertFields{
+			CertRound: uint64(txn.CertRound),
+			CertType:  uint64(txn.CertType),
+			Cert:      rpcCompactCert(txn.Cert),
+		}
+	}
+	return converted
+}
+
+// transactionFromRPC converts the gRPC message of a transaction to the transaction.
+func transactionFromRPC(txn *rpc.Transaction) (out transactions.Transaction, err error) {
+	if txn == nil {
+		return out, fmt.Errorf("transaction is missing")
+	}
+	out.Type = protocol.TxType(txn.Type)
+	if out.Sender, err = addressFromRPC(txn.Sender); err != nil {
+		return
+	}
+	out.Fee = basics.MicroAlgos{Raw: txn.Fee}
+	out.FirstValid = basics.Round(txn.FirstValid)
+	out.LastValid = basics.Round(txn.LastValid)
+	out.Note = txn.Note
+	out.GenesisID = txn.GenesisId
+	if err = fixedBytesFromRPC(out.GenesisHash[:], txn.GenesisHash, "genesis_hash"); err != nil {
+		return
+	}
+	if err = fixedBytesFromRPC(out.Group[:], txn.Group, "group"); err != nil {
+		return
+	}
+	if err = fixedBytesFromRPC(out.Lease[:], txn.Lease, "lease"); err != nil {
+		return
+	}
+	if out.RekeyTo, err = addressFromRPC(txn.RekeyTo); err != nil {
+		return
+	}
+
+	if keyreg := txn.Keyreg; keyreg != nil {
+		if err = fixedBytesFromRPC(out.VotePK[:], keyreg.VotePk, "vote_pk"); err != nil {
+			return
+		}
+		if err = fixedBytesFromRPC(out.SelectionPK[:], keyreg.SelectionPk, "selection_pk"); err != nil {
+			return
+		}
+		out.VoteFirst = basics.Round(keyreg.VoteFirst)
+		out.VoteLast = basics.Round(keyreg.VoteLast)
+		out.VoteKeyDilution = keyreg.VoteKeyDilution
+		out.Nonparticipation = keyreg.Nonparticipation
+	}
+	if payment := txn.Payment; payment != nil {
+		if out.Receiver, err = addressFromRPC(payment.Receiver); err != nil {
+			return
+		}
+		out.Amount = basics.MicroAlgos{Raw: payment.Amount}
+		if out.CloseRemainderTo, err = addressFromRPC(payment.CloseRemainderTo); err != nil {
+			return
+		}
+	}
+	if config := txn.AssetConfig; config != nil {
+		out.ConfigAsset = basics.AssetIndex(config.ConfigAsset)
+		if out.AssetParams, err = assetParamsFromRPC(config.Params); err != nil {
+			return
+		}
+	}
+	if xfer := txn.AssetTransfer; xfer != nil {
+		out.XferAsset = basics.AssetIndex(xfer.XferAsset)
+		out.AssetAmount = xfer.AssetAmount
+		if out.AssetSender, err = addressFromRPC(xfer.AssetSender); err != nil {
+			return
+		}
+		if out.AssetReceiver, err = addressFromRPC(xfer.AssetReceiver); err != nil {
+			return
+		}
+		if out.AssetCloseTo, err = addressFromRPC(xfer.AssetCloseTo); err != nil {
+			return
+		}
+	}
+	if freeze := txn.AssetFreeze; freeze != nil {
+		if out.FreezeAccount, err = addressFromRPC(freeze.FreezeAccount); err != nil {
+			return
+		}
+		out.FreezeAsset = basics.AssetIndex(freeze.FreezeAsset)
+		out.AssetFrozen = freeze.AssetFrozen
+	}
+	if call := txn.ApplicationCall; call != nil {
+		out.ApplicationID = basics.AppIndex(call.ApplicationId)
+		out.OnCompletion = transactions.OnCompletion(call.OnCompletion)
+		out.ApplicationArgs = call.ApplicationArgs
+		for _, account := range call.Accounts {
+			addr, err := addressFromRPC(account)
+			if err != nil {
+				return out, err
+			}
+			out.Accounts = append(out.Accounts, addr)
+		}
+		for _, appIdx := range call.ForeignApps {
+			out.ForeignApps = append(out.ForeignApps, basics.AppIndex(appIdx))
+		}
+		for _, assetIdx := range call.ForeignAssets {
+			out.ForeignAssets = append(out.ForeignAssets, basics.AssetIndex(assetIdx))
+		}
+		out.LocalStateSchema = stateSchemaFromRPC(call.LocalStateSchema)
+		out.GlobalStateSchema = stateSchemaFromRPC(call.GlobalStateSchema)
+		out.ApprovalProgram = call.ApprovalProgram
+		out.ClearStateProgram = call.ClearStateProgram
+		out.ExtraProgramPages = call.ExtraProgramPages
+	}
+	if cert := txn.CompactCert; cert != nil {
+		out.CertRound = basics.Round(cert.CertRound)
+		out.CertType = protocol.CompactCertType(cert.CertType)
+		if out.Cert, err = compactCertFromRPC(cert.Cert); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// rpcSignedTransaction converts a signed transaction to its gRPC message. The apply data, if not nil, is included as
+// well.
+func rpcSignedTransaction(stxn *transactions.SignedTxn, ad *transactions.ApplyData) *rpc.SignedTransaction {
+	converted := &rpc.SignedTransaction{
+		Txid:     stxn.ID().String(),
+		Txn:      rpcTransaction(&stxn.Txn),
+		Sig:      rpcFixedBytes(stxn.Sig[:]),
+		Msig:     rpcMultisigSig(stxn.Msig),
+		AuthAddr: rpcAddress(stxn.AuthAddr),
+	}
+	if !stxn.Lsig.MsgIsZero() {
+		converted.Lsig = &rpc.LogicSig{
+			Logic: stxn.Lsig.Logic,
+			Sig:   rpcFixedBytes(stxn.Lsig.Sig[:]),
+			Msig:  rpcMultisigSig(stxn.Lsig.Msig),
+			Args:  stxn.Lsig.Args,
+		}
+	}
+	if ad != nil {
+		converted.ApplyData = rpcApplyData(*ad)
+	}
+	return converted
+}
+
+// signedTransactionFromRPC converts the gRPC message of a signed transaction to the signed transaction. Its txid and
+// apply data are ignored.
+func signedTransactionFromRPC(stxn *rpc.SignedTransaction) (out transactions.SignedTxn, err error) {
+	if out.Txn, err = transactionFromRPC(stxn.Txn); err != nil {
+		return
+	}
+	if err = fixedBytesFromRPC(out.Sig[:], stxn.Sig, "sig"); err != nil {
+		return
+	}
+	if out.Msig, err = multisigSigFromRPC(stxn.Msig); err != nil {
+		return
+	}
+	if lsig := stxn.Lsig; lsig != nil {
+		out.Lsig.Logic = lsig.Logic
+		if err = fixedBytesFromRPC(out.Lsig.Sig[:], lsig.Sig, "lsig sig"); err != nil {
+			return
+		}
+		if out.Lsig.Msig, err = multisigSigFromRPC(lsig.Msig); err != nil {
+			return
+		}
+		out.Lsig.Args = lsig.Args
+	}
+	out.AuthAddr, err = addressFromRPC(stxn.AuthAddr)
+	return
+}
+
+// rpcBlock converts a block to its gRPC message, including the apply data of its transactions.
+func rpcBlock(block bookkeeping.Block, payset []transactions.SignedTxnWithAD) *rpc.Block {
+	converted := &rpc.Block{
+		Round:                     uint64(block.Round()),
+		PreviousBlockHash:         rpcFixedBytes(block.Branch[:]),
+		Seed:                      rpcFixedBytes(block.BlockHeader.Seed[:]),
+		TxnRoot:                   rpcFixedBytes(block.TxnRoot[:]),
+		Timestamp:                 block.TimeStamp,
+		GenesisId:                 block.GenesisID(),
+		GenesisHash:               rpcFixedBytes(block.BlockHeader.GenesisHash[:]),
+		FeeSink:                   rpcAddress(block.FeeSink),
+		RewardsPool:               rpcAddress(block.RewardsPool),
+		RewardsLevel:              block.RewardsLevel,
+		RewardsRate:               block.RewardsRate,
+		RewardsResidue:            block.RewardsResidue,
+		RewardsRecalculationRound: uint64(block.RewardsRecalculationRound),
+		CurrentProtocol:           string(block.CurrentProtocol),
+		NextProtocol:              string(block.NextProtocol),
+		NextProtocolApprovals:     block.NextProtocolApprovals,
+		NextProtocolVoteBefore:    uint64(block.NextProtocolVoteBefore),
+		NextProtocolSwitchOn:      uint64(block.NextProtocolSwitchOn),
+		UpgradePropose:            string(block.UpgradePropose),
+		UpgradeDelay:              uint64(block.UpgradeDelay),
+		UpgradeApprove:            block.UpgradeApprove,
+		TxnCounter:                block.TxnCounter,
+		Transactions:              make([]*rpc.SignedTransaction, 0, len(payset)),
+	}
+	for certType, state := range block.CompactCert {
+		converted.CompactCerts = append(converted.CompactCerts, &rpc.CompactCertState{
+			CertType:    uint64(certType),
+			Voters:      rpcFixedBytes(state.CompactCertVoters[:]),
+			VotersTotal: state.CompactCertVotersTotal.Raw,
+			NextRound:   uint64(state.CompactCertNextRound),
+		})
+	}
+	sort.Slice(converted.CompactCerts, func(i, j int) bool {
+		return converted.CompactCerts[i].CertType < converted.CompactCerts[j].CertType
+	})
+	for i := range payset {
+		converted.Transactions = append(converted.Transactions, rpcSignedTransaction(&payset[i].SignedTxn, &payset[i].ApplyData))
+	}
+	return converted
+}
+
+// rpcGeneratedTealValues converts the TEAL values of the REST API, holding base64 encoded byte slices.
+func rpcGeneratedTealValues(values []generated.TealValue) ([]*rpc.TealValue, error) {
+	converted := make([]*rpc.TealValue, 0, len(values))
+	for _, v := range values {
+		b, err := base64.StdEncoding.DecodeString(v.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		converted = append(converted, &rpc.TealValue{Type: v.Type, Bytes: b, Uint: v.Uint})
+	}
+	return converted, nil
+}
+
+func rpcDryrunTrace(trace *[]generated.DryrunState) ([]*rpc.DryrunState, error) {
+	if trace == nil {
+		return nil, nil
+	}
+	converted := make([]*rpc.DryrunState, 0, len(*trace))
+	for _, state := range *trace {
+		step := &rpc.DryrunState{Line: state.Line, Pc: state.Pc}
+		var err error
+		if step.Stack, err = rpcGeneratedTealValues(state.Stack); err != nil {
+			return nil, err
+		}
+		if state.Scratch != nil {
+			if step.Scratch, err = rpcGeneratedTealValues(*state.Scratch); err != nil {
+				return nil, err
+			}
+		}
+		if state.Error != nil {
+			step.Error = *state.Error
+		}
+		converted = append(converted, step)
+	}
+	return converted, nil
+}
+
+// rpcGeneratedStateDelta converts the state delta of the REST API, holding base64 encoded keys and byte slices.
+func rpcGeneratedStateDelta(sd *generated.StateDelta) ([]*rpc.EvalDeltaKeyValue, error) {
+	if sd == nil {
+		return nil, nil
+	}
+	converted := make([]*rpc.EvalDeltaKeyValue, 0, len(*sd))
+	for _, kv := range *sd {
+		key, err := base64.StdEncoding.DecodeString(kv.Key)
+		if err != nil {
+			return nil, err
+		}
+		delta := &rpc.EvalDeltaKeyValue{Key: key, Action: kv.Value.Action}
+		if kv.Value.Bytes != nil {
+			if delta.Bytes, err = base64.StdEncoding.DecodeString(*kv.Value.Bytes); err != nil {
+				return nil, err
+			}
+		}
+		if kv.Value.Uint != nil {
+			delta.Uint = *kv.Value.Uint
+		}
+		converted = append(converted, delta)
+	}
+	sort.Slice(converted, func(i, j int) bool {
+		return string(converted[i].Key) < string(converted[j].Key)
+	})
+	return converted, nil
+}
+
+// rpcDryrunTxnResult converts the dry-run result of a transaction to its gRPC message.
+func rpcDryrunTxnResult(result *generated.DryrunTxnResult) (converted *rpc.DryrunTxnResult, err error) {
+	converted = &rpc.DryrunTxnResult{Disassembly: result.Disassembly}
+	if result.LogicSigMessages != nil {
+		converted.LogicSigMessages = *result.LogicSigMessages
+	}
+	if result.AppCallMessages != nil {
+		converted.AppCallMessages = *result.AppCallMessages
+	}
+	if converted.LogicSigTrace, err = rpcDryrunTrace(result.LogicSigTrace); err != nil {
+		return nil, err
+	}
+	if converted.AppCallTrace, err = rpcDryrunTrace(result.AppCallTrace); err != nil {
+		return nil, err
+	}
+	if converted.GlobalDelta, err = rpcGeneratedStateDelta(result.GlobalDelta); err != nil {
+		return nil, err
+	}
+	if result.LocalDeltas != nil {
+		for i := range *result.LocalDeltas {
+			local := &(*result.LocalDeltas)[i]
+			delta := &rpc.AccountStateDelta{Address: local.Address}
+			if delta.Delta, err = rpcGeneratedStateDelta(&local.Delta); err != nil {
+				return nil, err
+			}
+			converted.LocalDeltas = append(converted.LocalDeltas, delta)
+		}
+	}
+	return converted, nil
+}
+
+// dryrunRequestFromRPC converts the gRPC message of a dry-run request to the request.
+func dryrunRequestFromRPC(req *rpc.DryrunRequest) (dr DryrunRequest, err error) {
+	dr.ProtocolVersion = req.ProtocolVersion
+	dr.Round = req.Round
+	dr.LatestTimestamp = req.LatestTimestamp
+	for i, stxn := range req.Txns {
+		txn, err := signedTransactionFromRPC(stxn)
+		if err != nil {
+			return dr, fmt.Errorf("txns[%d]: %v", i, err)
+		}
+		dr.Txns = append(dr.Txns, txn)
+	}
+	for i, account := range req.Accounts {
+		converted, err := accountFromRPC(account)
+		if err != nil {
+			return dr, fmt.Errorf("accounts[%d]: %v", i, err)
+		}
+		dr.Accounts = append(dr.Accounts, converted)
+	}
+	for i, app := range req.Apps {
+		converted, err := applicationFromRPC(app)
+		if err != nil {
+			return dr, fmt.Errorf("apps[%d]: %v", i, err)
+		}
+		dr.Apps = append(dr.Apps, converted)
+	}
+	for _, source := range req.Sources {
+		dr.Sources = append(dr.Sources, generated.DryrunSource{
+			FieldName: source.FieldName,
+			Source:    source.Source,
+			TxnIndex:  source.TxnIndex,
+			AppIndex:  source.AppIndex,
+		})
+	}
+	return dr, nil
+}
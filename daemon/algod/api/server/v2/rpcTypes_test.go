@@ -0,0 +1,205 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package v2
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/crypto/compactcert"
+	"github.com/algorand/go-algorand/daemon/algod/api/server/v2/generated/rpc"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// requireRPCRoundTrip checks that the signed transaction is unchanged by its conversion to a gRPC message, once the
+// message goes over the wire and back.
+func requireRPCRoundTrip(t *testing.T, stxn transactions.SignedTxn) {
+	converted := rpcSignedTransaction(&stxn, nil)
+	require.Equal(t, stxn.ID().String(), converted.Txid)
+
+	wire, err := proto.Marshal(converted)
+	require.NoError(t, err)
+	var received rpc.SignedTransaction
+	require.NoError(t, proto.Unmarshal(wire, &received))
+
+	back, err := signedTransactionFromRPC(&received)
+	require.NoError(t, err)
+	require.Equal(t, protocol.Encode(&stxn), protocol.Encode(&back))
+	require.Equal(t, stxn.ID(), back.ID())
+}
+
+func TestRPCSignedTransactionRoundTrip(t *testing.T) {
+	var sender, receiver, other basics.Address
+	crypto.RandBytes(sender[:])
+	crypto.RandBytes(receiver[:])
+	crypto.RandBytes(other[:])
+	secrets := crypto.GenerateSignatureSecrets(crypto.Seed{1})
+
+	header := transactions.Header{
+		Sender:      sender,
+		Fee:         basics.MicroAlgos{Raw: 1000},
+		FirstValid:  1,
+		LastValid:   1000,
+		Note:        []byte("note"),
+		GenesisID:   "test",
+		GenesisHash: crypto.Hash([]byte("genesis")),
+		Lease:       [32]byte{1, 2, 3},
+	}
+
+	pay := transactions.Transaction{Type: protocol.PaymentTx, Header: header}
+	pay.Receiver = receiver
+	pay.Amount = basics.MicroAlgos{Raw: 5}
+	pay.CloseRemainderTo = other
+	pay.RekeyTo = other
+	requireRPCRoundTrip(t, pay.Sign(secrets))
+
+	keyreg := transactions.Transaction{Type: protocol.KeyRegistrationTx, Header: header}
+	crypto.RandBytes(keyreg.VotePK[:])
+	crypto.RandBytes(keyreg.SelectionPK[:])
+	keyreg.VoteFirst, keyreg.VoteLast, keyreg.VoteKeyDilution = 1, 100, 10
+	requireRPCRoundTrip(t, keyreg.Sign(secrets))
+
+	acfg := transactions.Transaction{Type: protocol.AssetConfigTx, Header: header}
+	acfg.AssetParams = basics.AssetParams{
+		Total:         100,
+		Decimals:      2,
+		DefaultFrozen: true,
+		UnitName:      "unit",
+		AssetName:     "asset",
+		URL:           "https://example.com",
+		MetadataHash:  [32]byte{4},
+		Manager:       sender,
+		Clawback:      other,
+	}
+	requireRPCRoundTrip(t, transactions.SignedTxn{Txn: acfg, AuthAddr: other})
+
+	axfer := transactions.Transaction{Type: protocol.AssetTransferTx, Header: header}
+	axfer.XferAsset, axfer.AssetAmount, axfer.AssetReceiver, axfer.AssetCloseTo = 7, 3, receiver, other
+	afrz := transactions.Transaction{Type: protocol.AssetFreezeTx, Header: header}
+	afrz.FreezeAccount, afrz.FreezeAsset, afrz.AssetFrozen = receiver, 7, true
+	var group transactions.TxGroup
+	group.TxGroupHashes = []crypto.Digest{crypto.HashObj(axfer), crypto.HashObj(afrz)}
+	axfer.Group = crypto.HashObj(group)
+	afrz.Group = crypto.HashObj(group)
+	requireRPCRoundTrip(t, axfer.Sign(secrets))
+	requireRPCRoundTrip(t, afrz.Sign(secrets))
+
+	appl := transactions.Transaction{Type: protocol.ApplicationCallTx, Header: header}
+	appl.ApplicationID = 10
+	appl.OnCompletion = transactions.UpdateApplicationOC
+	appl.ApplicationArgs = [][]byte{[]byte("arg"), {}, {0}}
+	appl.Accounts = []basics.Address{receiver, other}
+	appl.ForeignApps = []basics.AppIndex{11, 12}
+	appl.ForeignAssets = []basics.AssetIndex{13}
+	appl.LocalStateSchema = basics.StateSchema{NumUint: 1}
+	appl.GlobalStateSchema = basics.StateSchema{NumByteSlice: 2}
+	appl.ApprovalProgram = []byte{2, 0x20, 1, 1, 0x22}
+	appl.ClearStateProgram = []byte{2, 0x20, 1, 1, 0x22}
+	appl.ExtraProgramPages = 1
+	msigKeys := []crypto.PublicKey{secrets.SignatureVerifier, crypto.GenerateSignatureSecrets(crypto.Seed{2}).SignatureVerifier}
+	lsig := transactions.LogicSig{
+		Logic: []byte{1, 0x20, 1, 1, 0x22},
+		Msig: crypto.MultisigSig{
+			Version:   1,
+			Threshold: 1,
+			Subsigs:   []crypto.MultisigSubsig{{Key: msigKeys[0], Sig: secrets.SignBytes([]byte("msg"))}, {Key: msigKeys[1]}},
+		},
+		Args: [][]byte{[]byte("lsig arg")},
+	}
+	requireRPCRoundTrip(t, transactions.SignedTxn{Txn: appl, Lsig: lsig})
+
+	cert := transactions.Transaction{Type: protocol.CompactCertTx, Header: transactions.Header{Sender: transactions.CompactCertSender, FirstValid: 1, LastValid: 10}}
+	cert.CertRound = 256
+	cert.Cert.SignedWeight = 1000
+	cert.Cert.SigCommit = crypto.Hash([]byte("commit"))
+	cert.Cert.SigProofs = []crypto.Digest{crypto.Hash([]byte("sig proof"))}
+	cert.Cert.PartProofs = []crypto.Digest{crypto.Hash([]byte("part proof"))}
+	var reveal compactcert.Reveal
+	reveal.SigSlot.L = 10
+	crypto.RandBytes(reveal.SigSlot.Sig.Sig[:])
+	crypto.RandBytes(reveal.SigSlot.Sig.PK2[:])
+	crypto.RandBytes(reveal.Part.PK[:])
+	reveal.Part.Weight = 100
+	reveal.Part.KeyDilution = 10000
+	cert.Cert.Reveals = map[uint64]compactcert.Reveal{3: reveal}
+	requireRPCRoundTrip(t, transactions.SignedTxn{Txn: cert})
+}
+
+func TestRPCSignedTransactionInvalid(t *testing.T) {
+	_, err := signedTransactionFromRPC(&rpc.SignedTransaction{})
+	require.Error(t, err)
+	_, err = signedTransactionFromRPC(&rpc.SignedTransaction{Txn: &rpc.Transaction{Sender: "bad address"}})
+	require.Error(t, err)
+	_, err = signedTransactionFromRPC(&rpc.SignedTransaction{Txn: &rpc.Transaction{}, Sig: []byte{1, 2, 3}})
+	require.Error(t, err)
+}
+
+func TestRPCAccountRoundTrip(t *testing.T) {
+	var addr, creator basics.Address
+	crypto.RandBytes(addr[:])
+	crypto.RandBytes(creator[:])
+	record := basics.AccountData{
+		Status:             basics.Online,
+		MicroAlgos:         basics.MicroAlgos{Raw: 1000},
+		RewardsBase:        5,
+		RewardedMicroAlgos: basics.MicroAlgos{Raw: 20},
+		VoteFirstValid:     1,
+		VoteLastValid:      100,
+		VoteKeyDilution:    10,
+		AssetParams:        map[basics.AssetIndex]basics.AssetParams{1: {Total: 10, UnitName: "unit", Manager: addr}},
+		Assets:             map[basics.AssetIndex]basics.AssetHolding{1: {Amount: 10}, 2: {Amount: 3, Frozen: true}},
+		AppLocalStates: map[basics.AppIndex]basics.AppLocalState{
+			3: {Schema: basics.StateSchema{NumUint: 1}, KeyValue: basics.TealKeyValue{"k": {Type: basics.TealUintType, Uint: 2}}},
+		},
+		AppParams: map[basics.AppIndex]basics.AppParams{
+			4: {
+				ApprovalProgram: []byte{1},
+				GlobalState:     basics.TealKeyValue{"g": {Type: basics.TealBytesType, Bytes: "value"}},
+				StateSchemas:    basics.StateSchemas{GlobalStateSchema: basics.StateSchema{NumByteSlice: 1}},
+			},
+		},
+		TotalAppSchema: basics.StateSchema{NumUint: 1, NumByteSlice: 1},
+		AuthAddr:       creator,
+	}
+	crypto.RandBytes(record.VoteID[:])
+	crypto.RandBytes(record.SelectionID[:])
+	creators := map[basics.AssetIndex]string{1: addr.String(), 2: creator.String()}
+	withoutRewards := basics.MicroAlgos{Raw: 900}
+
+	converted, err := rpcAccount(addr.String(), record, creators, 7, withoutRewards)
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), converted.PendingRewards)
+	require.Len(t, converted.Assets, 2)
+	require.Equal(t, creator.String(), converted.Assets[1].Creator)
+	require.Equal(t, []byte("value"), converted.CreatedApps[0].Params.GlobalState[0].Value.Bytes)
+
+	// the dry-run requests take the accounts in the REST API model.
+	expected, err := AccountDataToAccount(addr.String(), &record, creators, 7, withoutRewards)
+	require.NoError(t, err)
+	account, err := accountFromRPC(converted)
+	require.NoError(t, err)
+	require.Equal(t, expected, account)
+
+	converted.PendingRewards = converted.Amount + 1
+	_, err = accountFromRPC(converted)
+	require.Error(t, err)
+}
@@ -18,18 +18,22 @@ package test
 
 import (
 	"bytes"
+	"encoding/base64"
 	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/require"
 
+	"github.com/algorand/go-algorand/crypto"
 	v2 "github.com/algorand/go-algorand/daemon/algod/api/server/v2"
 	"github.com/algorand/go-algorand/daemon/algod/api/server/v2/generated"
 	generatedV2 "github.com/algorand/go-algorand/daemon/algod/api/server/v2/generated"
+	"github.com/algorand/go-algorand/daemon/algod/api/server/v2/generated/private"
 	"github.com/algorand/go-algorand/data/account"
 	"github.com/algorand/go-algorand/data/basics"
 	"github.com/algorand/go-algorand/data/transactions"
@@ -167,6 +171,37 @@ func TestGetTransactionParams(t *testing.T) {
 	require.Equal(t, 200, rec.Code)
 }
 
+func transactionFeeEstimateTest(t *testing.T, targetRounds *uint64, expectedCode int) {
+	handler, c, rec, _, _, releasefunc := setupTestForMethodGet(t)
+	defer releasefunc()
+	params := generatedV2.TransactionFeeEstimateParams{TargetRounds: targetRounds}
+	err := handler.TransactionFeeEstimate(c, params)
+	require.NoError(t, err)
+	require.Equal(t, expectedCode, rec.Code)
+	if expectedCode == 200 {
+		var response generatedV2.TransactionFeeEstimateResponse
+		require.NoError(t, protocol.DecodeJSON(rec.Body.Bytes(), &response))
+		expectedTargetRounds := uint64(1)
+		if targetRounds != nil {
+			expectedTargetRounds = *targetRounds
+		}
+		require.Equal(t, expectedTargetRounds, response.TargetRounds)
+		require.Equal(t, uint64(5), response.FeePerByte)
+		require.Equal(t, uint64(75), response.Percentile)
+		require.Len(t, response.Percentiles, 2)
+	}
+}
+
+func TestTransactionFeeEstimate(t *testing.T) {
+	t.Parallel()
+
+	zero, ten, tooMany := uint64(0), uint64(10), uint64(1<<20)
+	transactionFeeEstimateTest(t, nil, 200)
+	transactionFeeEstimateTest(t, &ten, 200)
+	transactionFeeEstimateTest(t, &zero, 400)
+	transactionFeeEstimateTest(t, &tooMany, 400)
+}
+
 func pendingTransactionInformationTest(t *testing.T, txidToUse int, format string, expectedCode int) {
 	handler, c, rec, _, stxns, releasefunc := setupTestForMethodGet(t)
 	defer releasefunc()
@@ -516,3 +551,430 @@ func TestTealDryrun(t *testing.T) {
 	tealDryrunTest(t, &gdr, "msgp", 200, "REJECT", true)
 	tealDryrunTest(t, &gdr, "json", 404, "", false)
 }
+
+func TestGetParticipationKeys(t *testing.T) {
+	t.Parallel()
+
+	handler, c, rec, _, _, releasefunc := setupTestForMethodGet(t)
+	defer releasefunc()
+	err := handler.GetParticipationKeys(c)
+	require.NoError(t, err)
+	require.Equal(t, 200, rec.Code)
+	var response private.ParticipationKeysResponse
+	require.NoError(t, protocol.DecodeJSON(rec.Body.Bytes(), &response))
+	require.Len(t, response, 1)
+	require.Equal(t, participationKeyIDGolden.String(), response[0].Id)
+	require.Equal(t, node.ParticipationKeyNotRegistered, response[0].VotingStatus)
+}
+
+func addParticipationKeyTest(t *testing.T, keyFile []byte, expectedCode int) {
+	handler, _, _, _, _, releasefunc := setupTestForMethodGet(t)
+	defer releasefunc()
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(keyFile))
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	err := handler.AddParticipationKey(c)
+	require.NoError(t, err)
+	require.Equal(t, expectedCode, rec.Code)
+}
+
+func TestAddParticipationKey(t *testing.T) {
+	t.Parallel()
+
+	addParticipationKeyTest(t, []byte("key file"), 200)
+	addParticipationKeyTest(t, nil, 400)
+}
+
+func deleteParticipationKeyTest(t *testing.T, id string, expectedCode int) {
+	handler, c, rec, _, _, releasefunc := setupTestForMethodGet(t)
+	defer releasefunc()
+	err := handler.DeleteParticipationKeyByID(c, id)
+	require.NoError(t, err)
+	require.Equal(t, expectedCode, rec.Code)
+}
+
+func TestDeleteParticipationKey(t *testing.T) {
+	t.Parallel()
+
+	deleteParticipationKeyTest(t, participationKeyIDGolden.String(), 200)
+	deleteParticipationKeyTest(t, crypto.Hash([]byte("unknown")).String(), 404)
+	deleteParticipationKeyTest(t, "bad id", 400)
+}
+
+func participationKeyRegistrationTest(t *testing.T, id string, params private.GetParticipationKeyRegistrationParams, expectedCode int) transactions.SignedTxn {
+	handler, c, rec, _, _, releasefunc := setupTestForMethodGet(t)
+	defer releasefunc()
+	err := handler.GetParticipationKeyRegistration(c, id, params)
+	require.NoError(t, err)
+	require.Equal(t, expectedCode, rec.Code)
+	var stxn transactions.SignedTxn
+	if expectedCode == 200 {
+		var response private.ParticipationRegistrationResponse
+		require.NoError(t, protocol.DecodeJSON(rec.Body.Bytes(), &response))
+		require.NoError(t, protocol.Decode(response.Transaction, &stxn))
+	}
+	return stxn
+}
+
+func TestGetParticipationKeyRegistration(t *testing.T) {
+	t.Parallel()
+
+	id := participationKeyIDGolden.String()
+	stxn := participationKeyRegistrationTest(t, id, private.GetParticipationKeyRegistrationParams{}, 200)
+	require.Equal(t, protocol.KeyRegistrationTx, stxn.Txn.Type)
+	require.Equal(t, cannedStatusReportGolden.LastRound, stxn.Txn.FirstValid)
+	require.NotZero(t, stxn.Txn.Fee.Raw)
+
+	fee, first, last := uint64(2000), uint64(10), uint64(20)
+	stxn = participationKeyRegistrationTest(t, id, private.GetParticipationKeyRegistrationParams{Fee: &fee, FirstValid: &first, LastValid: &last}, 200)
+	require.Equal(t, fee, stxn.Txn.Fee.Raw)
+	require.Equal(t, basics.Round(first), stxn.Txn.FirstValid)
+	require.Equal(t, basics.Round(last), stxn.Txn.LastValid)
+
+	participationKeyRegistrationTest(t, id, private.GetParticipationKeyRegistrationParams{FirstValid: &last, LastValid: &first}, 400)
+	participationKeyRegistrationTest(t, crypto.Hash([]byte("unknown")).String(), private.GetParticipationKeyRegistrationParams{}, 404)
+	participationKeyRegistrationTest(t, "bad id", private.GetParticipationKeyRegistrationParams{}, 400)
+}
+
+type accountTransactionsResponse struct {
+	CurrentRound uint64  `codec:"current-round"`
+	NextToken    *string `codec:"next-token"`
+	Transactions []struct {
+		Round            uint64                       `codec:"round"`
+		RoundTime        int64                        `codec:"round-time"`
+		IntraRoundOffset uint64                       `codec:"intra-round-offset"`
+		Txn              transactions.SignedTxnWithAD `codec:"txn"`
+	} `codec:"transactions"`
+}
+
+func accountTransactionsTest(t *testing.T, indexerActive bool, address string, params generatedV2.AccountTransactionsParams, expectedCode int) (response accountTransactionsResponse) {
+	handler, c, rec, _, _, releasefunc := setupTestForMethodGet(t)
+	defer releasefunc()
+	mock := handler.Node.(mockNode)
+	mock.config.IsIndexerActive = indexerActive
+	handler.Node = mock
+	err := handler.AccountTransactions(c, address, params)
+	require.NoError(t, err)
+	require.Equal(t, expectedCode, rec.Code)
+	if expectedCode == 200 {
+		require.NoError(t, protocol.DecodeJSON(rec.Body.Bytes(), &response))
+	}
+	return
+}
+
+func TestAccountTransactions(t *testing.T) {
+	t.Parallel()
+
+	var addr basics.Address
+	crypto.RandBytes(addr[:])
+	address := addr.String()
+
+	response := accountTransactionsTest(t, true, address, generatedV2.AccountTransactionsParams{}, 200)
+	require.Equal(t, uint64(accountTransactionsRoundsGolden), response.CurrentRound)
+	require.Nil(t, response.NextToken)
+	require.Len(t, response.Transactions, int(accountTransactionsRoundsGolden))
+	require.Equal(t, addr, response.Transactions[0].Txn.Txn.Sender)
+
+	// page through the transactions, one at a time.
+	limit := uint64(1)
+	params := generatedV2.AccountTransactionsParams{Limit: &limit}
+	var rounds []uint64
+	for {
+		response = accountTransactionsTest(t, true, address, params, 200)
+		for _, txn := range response.Transactions {
+			rounds = append(rounds, txn.Round)
+		}
+		if response.NextToken == nil {
+			break
+		}
+		params.Next = response.NextToken
+	}
+	require.Equal(t, []uint64{3, 2, 1}, rounds)
+
+	minRound := uint64(2)
+	response = accountTransactionsTest(t, true, address, generatedV2.AccountTransactionsParams{MinRound: &minRound}, 200)
+	require.Len(t, response.Transactions, 2)
+
+	badNext := "next"
+	accountTransactionsTest(t, true, address, generatedV2.AccountTransactionsParams{Next: &badNext}, 400)
+	badType := "payment"
+	accountTransactionsTest(t, true, address, generatedV2.AccountTransactionsParams{TxType: &badType}, 400)
+	longNotePrefix := base64.StdEncoding.EncodeToString(make([]byte, 33))
+	accountTransactionsTest(t, true, address, generatedV2.AccountTransactionsParams{NotePrefix: &longNotePrefix}, 400)
+	badLimit := uint64(1001)
+	accountTransactionsTest(t, true, address, generatedV2.AccountTransactionsParams{Limit: &badLimit}, 400)
+	accountTransactionsTest(t, true, "bad address", generatedV2.AccountTransactionsParams{}, 400)
+	accountTransactionsTest(t, false, address, generatedV2.AccountTransactionsParams{}, 404)
+}
+
+func TestGetAssetBalances(t *testing.T) {
+	t.Parallel()
+
+	assetBalancesTest := func(params generatedV2.GetAssetBalancesParams, expectedCode int) (response generatedV2.AssetBalancesResponse) {
+		handler, c, rec, _, _, releasefunc := setupTestForMethodGet(t)
+		defer releasefunc()
+		err := handler.GetAssetBalances(c, 1, params)
+		require.NoError(t, err)
+		require.Equal(t, expectedCode, rec.Code)
+		if expectedCode == 200 {
+			require.NoError(t, protocol.DecodeJSON(rec.Body.Bytes(), &response))
+		}
+		return
+	}
+
+	// the testing ledger has no assets.
+	response := assetBalancesTest(generatedV2.GetAssetBalancesParams{}, 200)
+	require.Empty(t, response.Balances)
+	require.Nil(t, response.NextToken)
+
+	lessThan := uint64(0)
+	response = assetBalancesTest(generatedV2.GetAssetBalancesParams{CurrencyLessThan: &lessThan}, 200)
+	require.Empty(t, response.Balances)
+
+	var addr basics.Address
+	crypto.RandBytes(addr[:])
+	next := addr.String()
+	assetBalancesTest(generatedV2.GetAssetBalancesParams{Next: &next}, 200)
+
+	badNext := "next"
+	assetBalancesTest(generatedV2.GetAssetBalancesParams{Next: &badNext}, 400)
+	badLimit := uint64(1001)
+	assetBalancesTest(generatedV2.GetAssetBalancesParams{Limit: &badLimit}, 400)
+	badFormat := "xml"
+	assetBalancesTest(generatedV2.GetAssetBalancesParams{Format: &badFormat}, 400)
+}
+
+func TestGetApplicationAccounts(t *testing.T) {
+	t.Parallel()
+
+	applicationAccountsTest := func(params generatedV2.GetApplicationAccountsParams, expectedCode int) (response generatedV2.ApplicationAccountsResponse) {
+		handler, c, rec, _, _, releasefunc := setupTestForMethodGet(t)
+		defer releasefunc()
+		err := handler.GetApplicationAccounts(c, 1, params)
+		require.NoError(t, err)
+		require.Equal(t, expectedCode, rec.Code)
+		if expectedCode == 200 {
+			require.NoError(t, protocol.DecodeJSON(rec.Body.Bytes(), &response))
+		}
+		return
+	}
+
+	// the testing ledger has no applications.
+	response := applicationAccountsTest(generatedV2.GetApplicationAccountsParams{}, 200)
+	require.Empty(t, response.Accounts)
+	require.Nil(t, response.NextToken)
+
+	badNext := "next"
+	applicationAccountsTest(generatedV2.GetApplicationAccountsParams{Next: &badNext}, 400)
+	badLimit := uint64(1001)
+	applicationAccountsTest(generatedV2.GetApplicationAccountsParams{Limit: &badLimit}, 400)
+}
+
+func webhooksTestHandler(t *testing.T, enabled bool) (v2.Handlers, func()) {
+	handler, _, _, _, _, releasefunc := setupTestForMethodGet(t)
+	mock := handler.Node.(mockNode)
+	mock.config.EnableWebhooks = enabled
+	handler.Node = mock
+	return handler, releasefunc
+}
+
+func getWebhooksTest(t *testing.T, enabled bool, expectedCode int) (response private.WebhooksResponse) {
+	handler, releasefunc := webhooksTestHandler(t, enabled)
+	defer releasefunc()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	err := handler.GetWebhooks(echo.New().NewContext(req, rec))
+	require.NoError(t, err)
+	require.Equal(t, expectedCode, rec.Code)
+	if expectedCode == 200 {
+		require.NoError(t, protocol.DecodeJSON(rec.Body.Bytes(), &response))
+	}
+	return
+}
+
+func TestGetWebhooks(t *testing.T) {
+	t.Parallel()
+
+	response := getWebhooksTest(t, true, 200)
+	require.Len(t, response, 1)
+	require.Equal(t, webhookGolden.ID, *response[0].Id)
+	require.Equal(t, uint64(webhookGolden.AssetID), *response[0].AssetId)
+	require.Equal(t, webhookGolden.URL, response[0].Url)
+	require.Nil(t, response[0].Address)
+
+	getWebhooksTest(t, false, 404)
+}
+
+func addWebhookTest(t *testing.T, enabled bool, body string, expectedCode int) {
+	handler, releasefunc := webhooksTestHandler(t, enabled)
+	defer releasefunc()
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(body)))
+	rec := httptest.NewRecorder()
+	err := handler.AddWebhook(echo.New().NewContext(req, rec))
+	require.NoError(t, err)
+	require.Equal(t, expectedCode, rec.Code)
+	if expectedCode == 200 {
+		var response private.PostWebhookResponse
+		require.NoError(t, protocol.DecodeJSON(rec.Body.Bytes(), &response))
+		require.Equal(t, webhookGolden.ID+1, response.WebhookId)
+	}
+}
+
+func TestAddWebhook(t *testing.T) {
+	t.Parallel()
+
+	addWebhookTest(t, true, `{"url": "http://localhost/hook", "address": "`+poolAddr.String()+`"}`, 200)
+	addWebhookTest(t, true, `{"url": "http://localhost/hook", "application-id": 5}`, 200)
+	addWebhookTest(t, true, `{"url": "http://localhost/hook", "address": "bad address"}`, 400)
+	addWebhookTest(t, true, `{"asset-id": 5}`, 400)
+	addWebhookTest(t, true, `not json`, 400)
+	addWebhookTest(t, false, `{"url": "http://localhost/hook", "asset-id": 5}`, 404)
+}
+
+func deleteWebhookTest(t *testing.T, enabled bool, id uint64, expectedCode int) {
+	handler, releasefunc := webhooksTestHandler(t, enabled)
+	defer releasefunc()
+	req := httptest.NewRequest(http.MethodDelete, "/", nil)
+	rec := httptest.NewRecorder()
+	err := handler.DeleteWebhook(echo.New().NewContext(req, rec), id)
+	require.NoError(t, err)
+	require.Equal(t, expectedCode, rec.Code)
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	t.Parallel()
+
+	deleteWebhookTest(t, true, webhookGolden.ID, 200)
+	deleteWebhookTest(t, true, webhookGolden.ID+1, 404)
+	deleteWebhookTest(t, false, webhookGolden.ID, 404)
+}
+
+func TestGetPeers(t *testing.T) {
+	t.Parallel()
+
+	handler, _, _, _, _, releasefunc := setupTestForMethodGet(t)
+	defer releasefunc()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	err := handler.GetPeers(echo.New().NewContext(req, rec))
+	require.NoError(t, err)
+	require.Equal(t, 200, rec.Code)
+	var response private.PeersResponse
+	require.NoError(t, protocol.DecodeJSON(rec.Body.Bytes(), &response))
+	require.Len(t, response, 1)
+	require.Equal(t, "outgoing", response[0].Direction)
+	require.Equal(t, peerGolden.RemoteAddress, response[0].RemoteAddress)
+	require.Equal(t, peerGolden.Address, *response[0].Address)
+	require.Equal(t, peerGolden.TelemetryGUID, *response[0].TelemetryGuid)
+	require.Nil(t, response[0].Identity)
+	require.Equal(t, uint64(1000), response[0].ConnectedSince)
+	require.Equal(t, uint64(time.Millisecond), *response[0].PingRoundTripTime)
+	require.Equal(t, []private.TagCount{{Tag: string(protocol.AgreementVoteTag), Count: 5}, {Tag: string(protocol.TxnTag), Count: 3}}, response[0].MessagesReceived)
+	require.Equal(t, []private.TagCount{{Tag: string(protocol.TxnTag), Count: 1}}, response[0].MessagesSent)
+}
+
+func peerTestHandler(t *testing.T) (v2.Handlers, func()) {
+	handler, _, _, _, _, releasefunc := setupTestForMethodGet(t)
+	return handler, releasefunc
+}
+
+func TestDisconnectPeer(t *testing.T) {
+	t.Parallel()
+
+	handler, releasefunc := peerTestHandler(t)
+	defer releasefunc()
+	for address, expectedCode := range map[string]int{peerGolden.Address: 200, peerGolden.RemoteAddress: 200, "127.0.0.1:1": 404} {
+		req := httptest.NewRequest(http.MethodDelete, "/", nil)
+		rec := httptest.NewRecorder()
+		err := handler.DisconnectPeer(echo.New().NewContext(req, rec), address)
+		require.NoError(t, err)
+		require.Equal(t, expectedCode, rec.Code)
+	}
+}
+
+func banPeerTest(t *testing.T, address string, expectedCode int) {
+	handler, releasefunc := peerTestHandler(t)
+	defer releasefunc()
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	rec := httptest.NewRecorder()
+	duration := uint64(60)
+	err := handler.BanPeer(echo.New().NewContext(req, rec), address, private.BanPeerParams{Duration: &duration})
+	require.NoError(t, err)
+	require.Equal(t, expectedCode, rec.Code)
+}
+
+func TestBanPeer(t *testing.T) {
+	t.Parallel()
+
+	banPeerTest(t, peerGolden.RemoteAddress, 200)
+	banPeerTest(t, peerGolden.Address, 200)
+	banPeerTest(t, "127.0.0.1:1", 404)
+}
+
+func addPhonebookEntryTest(t *testing.T, body string, expectedCode int) {
+	handler, releasefunc := peerTestHandler(t)
+	defer releasefunc()
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(body)))
+	rec := httptest.NewRecorder()
+	err := handler.AddPhonebookEntry(echo.New().NewContext(req, rec))
+	require.NoError(t, err)
+	require.Equal(t, expectedCode, rec.Code)
+}
+
+func TestAddPhonebookEntry(t *testing.T) {
+	t.Parallel()
+
+	addPhonebookEntryTest(t, `{"address": "relay.algorand.network:4160"}`, 200)
+	addPhonebookEntryTest(t, `{"address": "relay.algorand.network:4160", "archival": true}`, 200)
+	addPhonebookEntryTest(t, `{"archival": true}`, 400)
+	addPhonebookEntryTest(t, `not json`, 400)
+}
+
+func batchTest(t *testing.T, maxItems uint64, body string, expectedCode int) (response generated.BatchResponse) {
+	handler, _, _, _, _, releasefunc := setupTestForMethodGet(t)
+	defer releasefunc()
+	mock := handler.Node.(mockNode)
+	mock.config.MaxBatchRequestItems = maxItems
+	handler.Node = mock
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(body)))
+	rec := httptest.NewRecorder()
+	err := handler.Batch(echo.New().NewContext(req, rec))
+	require.NoError(t, err)
+	require.Equal(t, expectedCode, rec.Code)
+	if expectedCode == 200 {
+		require.NoError(t, protocol.DecodeJSON(rec.Body.Bytes(), &response))
+	}
+	return
+}
+
+func TestBatch(t *testing.T) {
+	t.Parallel()
+
+	txid := transactions.Txid{1}
+	response := batchTest(t, 10, `{"requests": [
+		{"type": "account", "address": "`+poolAddr.String()+`"},
+		{"type": "account", "address": "bad address"},
+		{"type": "asset", "asset-id": 1},
+		{"type": "application", "application-id": 1},
+		{"type": "pending-transaction", "txid": "`+txid.String()+`"}
+	]}`, 200)
+	require.Equal(t, uint64(0), response.Round)
+	require.Len(t, response.Results, 5)
+
+	require.Nil(t, response.Results[0].Error)
+	require.Equal(t, poolAddrResponseGolden.Amount, response.Results[0].Account.Amount)
+	require.Equal(t, poolAddrResponseGolden.Round, response.Results[0].Account.Round)
+	require.Equal(t, "failed to parse the address", *response.Results[1].Error)
+	require.Nil(t, response.Results[1].Account)
+	require.Equal(t, "asset does not exist", *response.Results[2].Error)
+	require.Equal(t, "application does not exist", *response.Results[3].Error)
+	require.Nil(t, response.Results[4].Error)
+	require.NotNil(t, response.Results[4].PendingTransaction)
+	require.Nil(t, response.Results[4].PendingTransaction.ConfirmedRound)
+
+	batchTest(t, 10, `{"requests": [{"type": "account"}]}`, 400)
+	batchTest(t, 10, `{"requests": [{"type": "block", "round": 1}]}`, 400)
+	batchTest(t, 1, `{"requests": [{"type": "asset", "asset-id": 1}, {"type": "asset", "asset-id": 2}]}`, 400)
+	batchTest(t, 10, `not json`, 400)
+	batchTest(t, 0, `{"requests": [{"type": "asset", "asset-id": 1}]}`, 404)
+}
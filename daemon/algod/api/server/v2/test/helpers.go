@@ -31,11 +31,14 @@ import (
 	"github.com/algorand/go-algorand/data/account"
 	"github.com/algorand/go-algorand/data/basics"
 	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/data/pools"
 	"github.com/algorand/go-algorand/data/transactions"
 	"github.com/algorand/go-algorand/ledger/ledgercore"
 	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/network"
 	"github.com/algorand/go-algorand/node"
 	"github.com/algorand/go-algorand/node/indexer"
+	"github.com/algorand/go-algorand/node/webhooks"
 	"github.com/algorand/go-algorand/protocol"
 	"github.com/algorand/go-algorand/util/db"
 )
@@ -128,6 +131,159 @@ func (m mockNode) SuggestedFee() basics.MicroAlgos {
 	return basics.MicroAlgos{Raw: 1}
 }
 
+func (m mockNode) FeeEstimate(targetRounds uint64) pools.FeeEstimate {
+	return pools.FeeEstimate{
+		TargetRounds:  targetRounds,
+		LastRound:     cannedStatusReportGolden.LastRound,
+		PendingBlocks: 3,
+		SampledBlocks: 10,
+		Percentile:    75,
+		FeePerByte:    5,
+		Percentiles: []pools.FeePercentile{
+			{Percentile: 50, FeePerByte: 2},
+			{Percentile: 90, FeePerByte: 8},
+		},
+	}
+}
+
+var accountTransactionsRoundsGolden = basics.Round(3)
+
+var participationKeyIDGolden = crypto.Hash([]byte("participation key"))
+
+func (m mockNode) ListParticipationKeys() ([]node.ParticipationKeyInfo, error) {
+	return []node.ParticipationKeyInfo{{
+		ID:           participationKeyIDGolden,
+		FirstValid:   1,
+		LastValid:    1000,
+		KeyDilution:  10,
+		VotingStatus: node.ParticipationKeyNotRegistered,
+	}}, m.err
+}
+
+func (m mockNode) InstallParticipationKey(partKeyBinary []byte) (crypto.Digest, error) {
+	if len(partKeyBinary) == 0 {
+		return crypto.Digest{}, fmt.Errorf("%w: empty file", node.ErrInvalidParticipationKey)
+	}
+	return participationKeyIDGolden, m.err
+}
+
+func (m mockNode) ParticipationKeyRegistration(id crypto.Digest, fee basics.MicroAlgos, firstValid, lastValid basics.Round) (transactions.Transaction, error) {
+	if id != participationKeyIDGolden {
+		return transactions.Transaction{}, node.ErrParticipationKeyNotFound
+	}
+	return transactions.Transaction{
+		Type: protocol.KeyRegistrationTx,
+		Header: transactions.Header{
+			Fee:        fee,
+			FirstValid: firstValid,
+			LastValid:  lastValid,
+		},
+		KeyregTxnFields: transactions.KeyregTxnFields{
+			VoteFirst:       1,
+			VoteLast:        1000,
+			VoteKeyDilution: 10,
+		},
+	}, m.err
+}
+
+func (m mockNode) RemoveParticipationKey(id crypto.Digest) error {
+	if id != participationKeyIDGolden {
+		return node.ErrParticipationKeyNotFound
+	}
+	return m.err
+}
+
+// ListAccountTransactions returns a payment from the account in each of the rounds from 1 to
+// accountTransactionsRoundsGolden, in the indexer's order.
+func (m mockNode) ListAccountTransactions(addr basics.Address, filter indexer.TransactionFilter) ([]node.IndexedTransaction, basics.Round, error) {
+	if !m.config.IsIndexerActive {
+		return nil, 0, node.ErrIndexerNotActive
+	}
+	var txns []node.IndexedTransaction
+	for rnd := accountTransactionsRoundsGolden; rnd > 0 && uint64(len(txns)) < filter.Limit; rnd-- {
+		if filter.Next != nil && rnd >= filter.Next.Round {
+			continue
+		}
+		if rnd < filter.MinRound || (filter.MaxRound != 0 && rnd > filter.MaxRound) {
+			continue
+		}
+		var txn node.IndexedTransaction
+		txn.Round = rnd
+		txn.Txn.Txn.Type = protocol.PaymentTx
+		txn.Txn.Txn.Sender = addr
+		txn.Txn.Txn.FirstValid = rnd
+		txns = append(txns, txn)
+	}
+	return txns, accountTransactionsRoundsGolden, m.err
+}
+
+var webhookGolden = webhooks.Watcher{ID: 1, AssetID: 10, URL: "http://localhost/hook"}
+
+func (m mockNode) ListWebhooks() ([]webhooks.Watcher, error) {
+	if !m.config.EnableWebhooks {
+		return nil, node.ErrWebhooksNotActive
+	}
+	return []webhooks.Watcher{webhookGolden}, m.err
+}
+
+func (m mockNode) AddWebhook(w webhooks.Watcher) (webhooks.Watcher, error) {
+	if !m.config.EnableWebhooks {
+		return webhooks.Watcher{}, node.ErrWebhooksNotActive
+	}
+	if w.URL == "" {
+		return webhooks.Watcher{}, fmt.Errorf("%w: no callback", webhooks.ErrInvalidWatcher)
+	}
+	w.ID = webhookGolden.ID + 1
+	return w, m.err
+}
+
+func (m mockNode) RemoveWebhook(id uint64) error {
+	if !m.config.EnableWebhooks {
+		return node.ErrWebhooksNotActive
+	}
+	if id != webhookGolden.ID {
+		return webhooks.ErrWatcherNotFound
+	}
+	return m.err
+}
+
+var peerGolden = network.PeerInfo{
+	Address:           "relay.algorand.network:4160",
+	RemoteAddress:     "127.0.0.1:4160",
+	Outgoing:          true,
+	Version:           "2.1",
+	TelemetryGUID:     "guid",
+	ConnectedSince:    time.Unix(1000, 0),
+	PingRoundTripTime: time.Millisecond,
+	MessagesReceived:  map[protocol.Tag]uint64{protocol.TxnTag: 3, protocol.AgreementVoteTag: 5},
+	MessagesSent:      map[protocol.Tag]uint64{protocol.TxnTag: 1},
+}
+
+func (m mockNode) ListPeers() []network.PeerInfo {
+	return []network.PeerInfo{peerGolden}
+}
+
+func (m mockNode) DisconnectPeer(address string) error {
+	if address != peerGolden.Address && address != peerGolden.RemoteAddress {
+		return node.ErrPeerNotFound
+	}
+	return m.err
+}
+
+func (m mockNode) BanPeer(address string, duration time.Duration) error {
+	if address != peerGolden.Address && address != peerGolden.RemoteAddress {
+		return node.ErrPeerNotFound
+	}
+	return m.err
+}
+
+func (m mockNode) AddPhonebookEntry(address string, archival bool) error {
+	if address == "" {
+		return node.ErrInvalidPeerAddress
+	}
+	return m.err
+}
+
 // unused by handlers:
 func (m mockNode) Config() config.Local {
 	return m.config
@@ -0,0 +1,329 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	apiServer "github.com/algorand/go-algorand/daemon/algod/api/server"
+	"github.com/algorand/go-algorand/daemon/algod/api/server/lib/middlewares"
+	"github.com/algorand/go-algorand/daemon/algod/api/server/v2"
+	"github.com/algorand/go-algorand/daemon/algod/api/server/v2/generated/rpc"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+func setupTestForRPC(t *testing.T, enableDeveloperAPI bool) (v2.RPCHandlers, []transactions.SignedTxn, func()) {
+	mockLedger, _, _, stxns, releasefunc := testingenv(t, 1, 1, true)
+	mockNode := makeMockNode(mockLedger, t.Name(), nil)
+	mockNode.config.EnableDeveloperAPI = enableDeveloperAPI
+	handler := v2.RPCHandlers{
+		Node:     mockNode,
+		Log:      logging.Base(),
+		Shutdown: make(chan struct{}),
+	}
+	return handler, stxns, releasefunc
+}
+
+func requireRPCCode(t *testing.T, code codes.Code, err error) {
+	require.Error(t, err)
+	require.Equal(t, code, status.Code(err), "%v", err)
+}
+
+// rpcPayment converts a signed payment to its gRPC message.
+func rpcPayment(stxn transactions.SignedTxn) *rpc.SignedTransaction {
+	return &rpc.SignedTransaction{
+		Txn: &rpc.Transaction{
+			Type:        string(stxn.Txn.Type),
+			Sender:      stxn.Txn.Sender.String(),
+			Fee:         stxn.Txn.Fee.Raw,
+			FirstValid:  uint64(stxn.Txn.FirstValid),
+			LastValid:   uint64(stxn.Txn.LastValid),
+			Note:        stxn.Txn.Note,
+			GenesisHash: stxn.Txn.GenesisHash[:],
+			Payment: &rpc.PaymentFields{
+				Receiver: stxn.Txn.Receiver.String(),
+				Amount:   stxn.Txn.Amount.Raw,
+			},
+		},
+		Sig: stxn.Sig[:],
+	}
+}
+
+func TestRPCGetStatus(t *testing.T) {
+	t.Parallel()
+
+	handler, _, releasefunc := setupTestForRPC(t, false)
+	defer releasefunc()
+	response, err := handler.GetStatus(context.Background(), &rpc.StatusRequest{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(cannedStatusReportGolden.LastRound), response.LastRound)
+	require.Equal(t, string(cannedStatusReportGolden.LastVersion), response.LastVersion)
+	require.Equal(t, cannedStatusReportGolden.StoppedAtUnsupportedRound, response.StoppedAtUnsupportedRound)
+}
+
+func TestRPCAccountInformation(t *testing.T) {
+	t.Parallel()
+
+	handler, _, releasefunc := setupTestForRPC(t, false)
+	defer releasefunc()
+	response, err := handler.AccountInformation(context.Background(), &rpc.AccountInformationRequest{Address: poolAddr.String()})
+	require.NoError(t, err)
+	require.Equal(t, poolAddrResponseGolden.Amount, response.Amount)
+	require.Equal(t, poolAddrResponseGolden.AmountWithoutPendingRewards, response.AmountWithoutPendingRewards)
+	require.Equal(t, poolAddrResponseGolden.Status, response.Status)
+	require.Equal(t, poolAddrResponseGolden.Address, response.Address)
+	require.Equal(t, *poolAddrResponseGolden.RewardBase, response.RewardBase)
+	require.Empty(t, response.Assets)
+
+	_, err = handler.AccountInformation(context.Background(), &rpc.AccountInformationRequest{Address: "bad address"})
+	requireRPCCode(t, codes.InvalidArgument, err)
+}
+
+func TestRPCGetBlock(t *testing.T) {
+	t.Parallel()
+
+	handler, _, releasefunc := setupTestForRPC(t, false)
+	defer releasefunc()
+	response, err := handler.GetBlock(context.Background(), &rpc.BlockRequest{Round: 0})
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), response.Round)
+	require.NotEmpty(t, response.GenesisId)
+
+	block, err := handler.Node.Ledger().Block(0)
+	require.NoError(t, err)
+	genesisHash := block.GenesisHash()
+	require.Equal(t, genesisHash[:], response.GenesisHash)
+	require.Equal(t, string(block.CurrentProtocol), response.CurrentProtocol)
+	require.Equal(t, block.RewardsPool.String(), response.RewardsPool)
+	require.Equal(t, block.RewardsRate, response.RewardsRate)
+	require.Len(t, response.Transactions, len(block.Payset))
+
+	_, err = handler.GetBlock(context.Background(), &rpc.BlockRequest{Round: 1})
+	requireRPCCode(t, codes.NotFound, err)
+}
+
+func TestRPCSubmitTransactions(t *testing.T) {
+	t.Parallel()
+
+	handler, stxns, releasefunc := setupTestForRPC(t, false)
+	defer releasefunc()
+	response, err := handler.SubmitTransactions(context.Background(), &rpc.SubmitTransactionsRequest{SignedTransactions: []*rpc.SignedTransaction{rpcPayment(stxns[0])}})
+	require.NoError(t, err)
+	require.Equal(t, stxns[0].ID().String(), response.Txid)
+
+	_, err = handler.SubmitTransactions(context.Background(), &rpc.SubmitTransactionsRequest{})
+	requireRPCCode(t, codes.InvalidArgument, err)
+	badTxn := &rpc.SignedTransaction{Txn: &rpc.Transaction{Type: string(protocol.PaymentTx), Sender: "bad address"}}
+	_, err = handler.SubmitTransactions(context.Background(), &rpc.SubmitTransactionsRequest{SignedTransactions: []*rpc.SignedTransaction{badTxn}})
+	requireRPCCode(t, codes.InvalidArgument, err)
+}
+
+func TestRPCPendingTransactions(t *testing.T) {
+	t.Parallel()
+
+	handler, stxns, releasefunc := setupTestForRPC(t, false)
+	defer releasefunc()
+	pending, err := handler.PendingTransactionInformation(context.Background(), &rpc.PendingTransactionRequest{Txid: stxns[0].ID().String()})
+	require.NoError(t, err)
+	require.Zero(t, pending.ConfirmedRound)
+	_, err = handler.PendingTransactionInformation(context.Background(), &rpc.PendingTransactionRequest{Txid: "bad txid"})
+	requireRPCCode(t, codes.InvalidArgument, err)
+
+	response, err := handler.GetPendingTransactions(context.Background(), &rpc.PendingTransactionsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(len(txnPoolGolden)), response.TotalTransactions)
+	require.Len(t, response.TopTransactions, len(txnPoolGolden))
+
+	response, err = handler.GetPendingTransactions(context.Background(), &rpc.PendingTransactionsRequest{Max: 1})
+	require.NoError(t, err)
+	require.Len(t, response.TopTransactions, 1)
+	top := response.TopTransactions[0]
+	require.Equal(t, txnPoolGolden[0].ID().String(), top.Txid)
+	require.Equal(t, string(txnPoolGolden[0].Txn.Type), top.Txn.Type)
+}
+
+func TestRPCTealCompile(t *testing.T) {
+	t.Parallel()
+
+	handler, _, releasefunc := setupTestForRPC(t, true)
+	defer releasefunc()
+	response, err := handler.TealCompile(context.Background(), &rpc.CompileRequest{Source: "int 1"})
+	require.NoError(t, err)
+	require.NotEmpty(t, response.Result)
+	_, err = basics.UnmarshalChecksumAddress(response.Hash)
+	require.NoError(t, err)
+
+	_, err = handler.TealCompile(context.Background(), &rpc.CompileRequest{Source: "bad program"})
+	requireRPCCode(t, codes.InvalidArgument, err)
+}
+
+func TestRPCDeveloperAPIDisabled(t *testing.T) {
+	t.Parallel()
+
+	handler, _, releasefunc := setupTestForRPC(t, false)
+	defer releasefunc()
+	_, err := handler.TealCompile(context.Background(), &rpc.CompileRequest{Source: "int 1"})
+	requireRPCCode(t, codes.Unimplemented, err)
+	_, err = handler.TealDryrun(context.Background(), &rpc.DryrunRequest{})
+	requireRPCCode(t, codes.Unimplemented, err)
+}
+
+func TestRPCTealDryrun(t *testing.T) {
+	t.Parallel()
+
+	handler, _, releasefunc := setupTestForRPC(t, true)
+	defer releasefunc()
+	dr := &rpc.DryrunRequest{
+		Txns:            []*rpc.SignedTransaction{{Txn: &rpc.Transaction{Type: string(protocol.PaymentTx)}}},
+		ProtocolVersion: string(protocol.ConsensusCurrentVersion),
+	}
+	response, err := handler.TealDryrun(context.Background(), dr)
+	require.NoError(t, err)
+	require.Equal(t, string(protocol.ConsensusCurrentVersion), response.ProtocolVersion)
+	require.Len(t, response.Txns, 1)
+
+	// the application calls are executed against the provided applications, reporting their traces and deltas.
+	sender := poolAddr.String()
+	app := &rpc.Application{
+		Id: 1,
+		Params: &rpc.ApplicationParams{
+			Creator:           sender,
+			GlobalStateSchema: &rpc.StateSchema{NumUint: 1},
+		},
+	}
+	call := &rpc.SignedTransaction{Txn: &rpc.Transaction{
+		Type:            string(protocol.ApplicationCallTx),
+		Sender:          sender,
+		ApplicationCall: &rpc.ApplicationCallFields{ApplicationId: 1},
+	}}
+	dr = &rpc.DryrunRequest{
+		Txns:            []*rpc.SignedTransaction{call},
+		Apps:            []*rpc.Application{app},
+		ProtocolVersion: string(protocol.ConsensusCurrentVersion),
+		Sources:         []*rpc.DryrunSource{{FieldName: "approv", Source: "#pragma version 2\nbyte \"key\"\nint 5\napp_global_put\nint 1", AppIndex: 1}},
+	}
+	response, err = handler.TealDryrun(context.Background(), dr)
+	require.NoError(t, err)
+	require.Empty(t, response.Error)
+	require.Len(t, response.Txns, 1)
+	require.Equal(t, []string{"ApprovalProgram", "PASS"}, response.Txns[0].AppCallMessages)
+	require.NotEmpty(t, response.Txns[0].AppCallTrace)
+	require.Len(t, response.Txns[0].GlobalDelta, 1)
+	require.Equal(t, []byte("key"), response.Txns[0].GlobalDelta[0].Key)
+	require.Equal(t, uint64(5), response.Txns[0].GlobalDelta[0].Uint)
+
+	dr.ProtocolVersion = "unknown"
+	_, err = handler.TealDryrun(context.Background(), dr)
+	requireRPCCode(t, codes.InvalidArgument, err)
+}
+
+func TestRPCServer(t *testing.T) {
+	t.Parallel()
+
+	mockLedger, _, _, _, releasefunc := testingenv(t, 1, 1, true)
+	defer releasefunc()
+	apiToken, adminToken := strings.Repeat("a", 64), strings.Repeat("b", 64)
+	readToken, dryrunToken := strings.Repeat("r", 64), strings.Repeat("d", 64)
+	dir, err := ioutil.TempDir("", "scopedtokens")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "algod.tokens.json")
+	data, err := json.Marshal(middlewares.ScopedTokensFile{Tokens: []middlewares.ScopedTokenConfig{
+		{Name: "reader", Token: readToken, Scopes: []middlewares.TokenScope{middlewares.ScopeRead}, RequestsPerSecond: 0.001, Burst: 3},
+		{Name: "dryrunner", Token: dryrunToken, Scopes: []middlewares.TokenScope{middlewares.ScopeDryrun}},
+	}})
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path, data, 0600))
+	scopedTokens, err := middlewares.LoadScopedTokens(path, logging.TestingLog(t))
+	require.NoError(t, err)
+
+	server := apiServer.NewGRPCServer(logging.Base(), makeMockNode(mockLedger, t.Name(), nil), make(chan struct{}), apiToken, adminToken, scopedTokens)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	client := rpc.NewAlgodClient(conn)
+
+	_, err = client.GetStatus(context.Background(), &rpc.StatusRequest{})
+	requireRPCCode(t, codes.Unauthenticated, err)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-algo-api-token", "wrong token")
+	_, err = client.GetStatus(ctx, &rpc.StatusRequest{})
+	requireRPCCode(t, codes.Unauthenticated, err)
+
+	for _, ctx := range []context.Context{
+		metadata.AppendToOutgoingContext(context.Background(), "x-algo-api-token", apiToken),
+		metadata.AppendToOutgoingContext(context.Background(), "x-algo-api-token", adminToken),
+		metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+apiToken),
+	} {
+		response, err := client.GetStatus(ctx, &rpc.StatusRequest{})
+		require.NoError(t, err)
+		require.Equal(t, uint64(cannedStatusReportGolden.LastRound), response.LastRound)
+	}
+
+	// the mock node stopped at an unsupported round, which is reported through the stream.
+	ctx = metadata.AppendToOutgoingContext(context.Background(), "x-algo-api-token", apiToken)
+	stream, err := client.WaitForBlock(ctx, &rpc.WaitForBlockRequest{Round: 1})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	requireRPCCode(t, codes.FailedPrecondition, err)
+
+	stream, err = client.WaitForBlock(context.Background(), &rpc.WaitForBlockRequest{Round: 1})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	requireRPCCode(t, codes.Unauthenticated, err)
+
+	// the scoped tokens are permitted to call the methods serving the REST API routes of their scopes, within their
+	// limits.
+	dryrunCtx := metadata.AppendToOutgoingContext(context.Background(), "x-algo-api-token", dryrunToken)
+	_, err = client.GetStatus(dryrunCtx, &rpc.StatusRequest{})
+	requireRPCCode(t, codes.PermissionDenied, err)
+	_, err = client.TealCompile(dryrunCtx, &rpc.CompileRequest{Source: "int 1"})
+	// the developer API is disabled, but the call went through the authentication.
+	requireRPCCode(t, codes.Unimplemented, err)
+
+	readCtx := metadata.AppendToOutgoingContext(context.Background(), "x-algo-api-token", readToken)
+	_, err = client.TealCompile(readCtx, &rpc.CompileRequest{Source: "int 1"})
+	requireRPCCode(t, codes.PermissionDenied, err)
+	_, err = client.SubmitTransactions(readCtx, &rpc.SubmitTransactionsRequest{})
+	requireRPCCode(t, codes.PermissionDenied, err)
+	for i := 0; i < 3; i++ {
+		_, err = client.GetStatus(readCtx, &rpc.StatusRequest{})
+		require.NoError(t, err)
+	}
+	_, err = client.GetStatus(readCtx, &rpc.StatusRequest{})
+	requireRPCCode(t, codes.ResourceExhausted, err)
+}
@@ -31,10 +31,12 @@ import (
 	"time"
 
 	"github.com/algorand/go-deadlock"
+	"google.golang.org/grpc"
 
 	"github.com/algorand/go-algorand/config"
 	apiServer "github.com/algorand/go-algorand/daemon/algod/api/server"
 	"github.com/algorand/go-algorand/daemon/algod/api/server/lib"
+	"github.com/algorand/go-algorand/daemon/algod/api/server/lib/middlewares"
 	"github.com/algorand/go-algorand/data/bookkeeping"
 	"github.com/algorand/go-algorand/logging"
 	"github.com/algorand/go-algorand/logging/telemetryspec"
@@ -45,6 +47,9 @@ import (
 
 var server http.Server
 
+// scopedTokensReloadInterval is how often the scoped API tokens file is checked for changes.
+const scopedTokensReloadInterval = 5 * time.Second
+
 // Server represents an instance of the REST API HTTP server
 type Server struct {
 	RootPath             string
@@ -57,6 +62,7 @@ type Server struct {
 	metricCollector      *metrics.MetricService
 	metricServiceStarted bool
 	stopping             chan struct{}
+	grpcServer           *grpc.Server
 }
 
 // Initialize creates a Node instance with applicable network services
@@ -181,7 +187,14 @@ func (s *Server) Start() {
 		os.Exit(1)
 	}
 
+	scopedTokens, err := middlewares.LoadScopedTokens(filepath.Join(s.RootPath, tokens.AlgodScopedTokensFilename), s.log)
+	if err != nil {
+		fmt.Printf("Scoped API tokens error: %v\n", err)
+		os.Exit(1)
+	}
+
 	s.stopping = make(chan struct{})
+	go scopedTokens.Watch(scopedTokensReloadInterval, s.stopping)
 
 	addr := cfg.EndpointAddress
 	if addr == "" {
@@ -204,7 +217,7 @@ func (s *Server) Start() {
 
 	tcpListener := listener.(*net.TCPListener)
 
-	e := apiServer.NewRouter(s.log, s.node, s.stopping, apiToken, adminAPIToken, tcpListener)
+	e := apiServer.NewRouter(s.log, s.node, s.stopping, apiToken, adminAPIToken, scopedTokens, tcpListener)
 
 	// Set up files for our PID and our listening address
 	// before beginning to listen to prevent 'goal node start'
@@ -226,6 +239,22 @@ func (s *Server) Start() {
 		errChan <- err
 	}()
 
+	if cfg.GRPCEndpointAddress != "" {
+		grpcListener, err := makeListener(cfg.GRPCEndpointAddress)
+		if err != nil {
+			fmt.Printf("Could not start the gRPC server: %v\n", err)
+			os.Exit(1)
+		}
+		s.grpcServer = apiServer.NewGRPCServer(s.log, s.node, s.stopping, apiToken, adminAPIToken, scopedTokens)
+		go func() {
+			err := s.grpcServer.Serve(grpcListener)
+			if err != nil {
+				s.log.Warnf("gRPC server exited: %v", err)
+			}
+		}()
+		fmt.Printf("Node accepting gRPC requests on %v\n", grpcListener.Addr().String())
+	}
+
 	// Handle signals cleanly
 	c := make(chan os.Signal)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
@@ -262,6 +291,9 @@ func (s *Server) Stop() {
 	if err != nil {
 		s.log.Error(err)
 	}
+	if s.grpcServer != nil {
+		s.grpcServer.GracefulStop()
+	}
 
 	if s.metricServiceStarted {
 		if err := s.metricCollector.Shutdown(); err != nil {
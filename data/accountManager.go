@@ -17,6 +17,7 @@
 package data
 
 import (
+	"encoding/binary"
 	"fmt"
 
 	"github.com/algorand/go-deadlock"
@@ -43,6 +44,31 @@ type ParticipationKeyIdentity struct {
 	SelectionID crypto.VrfPubkey
 }
 
+// MakeParticipationKeyIdentity returns the identity of the given participation key.
+func MakeParticipationKeyIdentity(part account.Participation) ParticipationKeyIdentity {
+	first, last := part.ValidInterval()
+	return ParticipationKeyIdentity{
+		Address:     part.Address(),
+		FirstValid:  first,
+		LastValid:   last,
+		VoteID:      part.Voting.OneTimeSignatureVerifier,
+		SelectionID: part.VRF.PK,
+	}
+}
+
+// ID returns a digest uniquely identifying the participation key.
+func (id ParticipationKeyIdentity) ID() crypto.Digest {
+	buf := make([]byte, 0, len(id.Address)+16+len(id.VoteID)+len(id.SelectionID))
+	buf = append(buf, id.Address[:]...)
+	var rounds [16]byte
+	binary.BigEndian.PutUint64(rounds[:8], uint64(id.FirstValid))
+	binary.BigEndian.PutUint64(rounds[8:], uint64(id.LastValid))
+	buf = append(buf, rounds[:]...)
+	buf = append(buf, id.VoteID[:]...)
+	buf = append(buf, id.SelectionID[:]...)
+	return crypto.Hash(buf)
+}
+
 // AccountManager loads and manages accounts for the node
 type AccountManager struct {
 	mu deadlock.Mutex
@@ -103,13 +129,7 @@ func (manager *AccountManager) AddParticipation(participation account.PersistedP
 	address := participation.Address()
 
 	first, last := participation.ValidInterval()
-	partkeyID := ParticipationKeyIdentity{
-		Address:     address,
-		FirstValid:  first,
-		LastValid:   last,
-		VoteID:      participation.Voting.OneTimeSignatureVerifier,
-		SelectionID: participation.VRF.PK,
-	}
+	partkeyID := MakeParticipationKeyIdentity(participation.Participation)
 
 	// Check if we already have participation keys for this address in this interval
 	_, alreadyPresent := manager.partKeys[partkeyID]
@@ -138,6 +158,30 @@ func (manager *AccountManager) AddParticipation(participation account.PersistedP
 	return true
 }
 
+// Participations returns all the managed participation keys, regardless of the rounds they are valid for.
+func (manager *AccountManager) Participations() (out []account.Participation) {
+	manager.mu.Lock()
+	defer manager.mu.Unlock()
+
+	for _, part := range manager.partKeys {
+		out = append(out, part.Participation)
+	}
+	return out
+}
+
+// RemoveParticipation stops managing the participation key of the given identity, and returns it so that the caller
+// could close it. The return value indicates if such a key was managed.
+func (manager *AccountManager) RemoveParticipation(id ParticipationKeyIdentity) (account.PersistedParticipation, bool) {
+	manager.mu.Lock()
+	defer manager.mu.Unlock()
+
+	part, ok := manager.partKeys[id]
+	if ok {
+		delete(manager.partKeys, id)
+	}
+	return part, ok
+}
+
 // DeleteOldKeys deletes all accounts' ephemeral keys strictly older than the
 // next round needed for each account.
 func (manager *AccountManager) DeleteOldKeys(latestHdr bookkeeping.BlockHeader, ccSigs map[basics.Address]basics.Round, agreementProto config.ConsensusParams) {
@@ -124,19 +124,14 @@ func makeGenesisBlock(proto protocol.ConsensusVersion, genesisBal GenesisBalance
 	return blk, nil
 }
 
-// LoadLedger creates a Ledger object to represent the ledger with the
-// specified database file prefix, initializing it if necessary.
-func LoadLedger(
-	log logging.Logger, dbFilenamePrefix string, memory bool,
-	genesisProto protocol.ConsensusVersion, genesisBal GenesisBalances, genesisID string, genesisHash crypto.Digest,
-	blockListeners []ledger.BlockListener, cfg config.Local,
-) (*Ledger, error) {
+// MakeGenesisInitState returns the initial ledger state described by the given genesis parameters and balances
+func MakeGenesisInitState(genesisProto protocol.ConsensusVersion, genesisBal GenesisBalances, genesisID string, genesisHash crypto.Digest) (ledger.InitState, error) {
 	if genesisBal.balances == nil {
 		genesisBal.balances = make(map[basics.Address]basics.AccountData)
 	}
 	genBlock, err := makeGenesisBlock(genesisProto, genesisBal, genesisID, genesisHash)
 	if err != nil {
-		return nil, err
+		return ledger.InitState{}, err
 	}
 
 	params := config.Consensus[genesisProto]
@@ -147,13 +142,27 @@ func LoadLedger(
 		genesisBal.balances[sinkAddr] = sinkData
 	}
 
-	l := &Ledger{
-		log: log,
-	}
-	genesisInitState := ledger.InitState{
+	return ledger.InitState{
 		Block:       genBlock,
 		Accounts:    genesisBal.balances,
 		GenesisHash: genesisHash,
+	}, nil
+}
+
+// LoadLedger creates a Ledger object to represent the ledger with the
+// specified database file prefix, initializing it if necessary.
+func LoadLedger(
+	log logging.Logger, dbFilenamePrefix string, memory bool,
+	genesisProto protocol.ConsensusVersion, genesisBal GenesisBalances, genesisID string, genesisHash crypto.Digest,
+	blockListeners []ledger.BlockListener, cfg config.Local,
+) (*Ledger, error) {
+	genesisInitState, err := MakeGenesisInitState(genesisProto, genesisBal, genesisID, genesisHash)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &Ledger{
+		log: log,
 	}
 	l.log.Debugf("Initializing Ledger(%s)", dbFilenamePrefix)
 
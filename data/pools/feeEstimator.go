@@ -0,0 +1,239 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package pools
+
+import (
+	"math"
+	"sort"
+
+	"github.com/algorand/go-deadlock"
+
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/data/transactions"
+)
+
+const (
+	// feeEstimatorHistory is the number of recent blocks the fee estimator keeps the fee distribution of.
+	feeEstimatorHistory = 64
+
+	// feeEstimatorQuantiles is the number of quantiles the fee distribution of every block is summarized by.
+	feeEstimatorQuantiles = 100
+)
+
+// FeeEstimatePercentiles are the percentiles of the recent fee distribution reported along with every fee estimate.
+var FeeEstimatePercentiles = []uint64{10, 25, 50, 75, 90, 99}
+
+// FeePercentile is the fee per byte paid by the transaction groups at a given percentile of the recent fee distribution.
+type FeePercentile struct {
+	Percentile uint64
+	// FeePerByte is in units of micro-Algos per byte.
+	FeePerByte uint64
+}
+
+// FeeEstimate is a suggestion for the fee a transaction needs to pay in order to be committed within a given number of rounds.
+type FeeEstimate struct {
+	// TargetRounds is the number of rounds the transaction is expected to be committed within.
+	TargetRounds uint64
+	// LastRound is the last round the fee estimator has seen.
+	LastRound basics.Round
+	// PendingBlocks is the number of full blocks worth of transactions waiting in the transaction pool.
+	PendingBlocks uint64
+	// SampledBlocks is the number of recent blocks the fee distribution was collected from.
+	SampledBlocks uint64
+	// Percentile is the percentile of the recent fee distribution the suggestion is based on.
+	Percentile uint64
+	// FeePerByte is the suggested fee, in units of micro-Algos per byte. Transactions must still pay at least the
+	// minimal transaction fee of the current network protocol.
+	FeePerByte uint64
+	// Percentiles is the recent fee distribution.
+	Percentiles []FeePercentile
+}
+
+// blockFees summarizes the fees paid by the transaction groups of a single block.
+type blockFees struct {
+	round basics.Round
+	// count is the number of transaction groups in the block.
+	count int
+	// quantiles holds the fee per byte, as computed by txGroupFeePerByte, paid at each of the feeEstimatorQuantiles+1
+	// quantiles of the transaction groups of the block, lowest first. It is empty if the block has no transactions.
+	quantiles []uint64
+	// pendingBlocks is the number of full blocks worth of transactions which were pending in the pool once the block was committed.
+	pendingBlocks uint64
+}
+
+// feeEstimator records the distribution of the fees paid by the transactions of the recent blocks, along with the
+// pressure on the transaction pool, and estimates the fee a new transaction needs to pay to be committed in time.
+type feeEstimator struct {
+	mu deadlock.RWMutex
+	// blocks is a circular buffer of the most recent blocks.
+	blocks []blockFees
+	next   int
+}
+
+func makeFeeEstimator() *feeEstimator {
+	return &feeEstimator{
+		blocks: make([]blockFees, 0, feeEstimatorHistory),
+	}
+}
+
+// recordBlock summarizes the fees paid by the transaction groups of the given block.
+func (fe *feeEstimator) recordBlock(block bookkeeping.Block, pendingBlocks uint64) {
+	fees := blockFees{
+		round:         block.Round(),
+		pendingBlocks: pendingBlocks,
+	}
+
+	var feesPerByte []uint64
+	payset := block.Payset
+	for start := 0; start < len(payset); {
+		end := start + 1
+		if !payset[start].Txn.Group.IsZero() {
+			for end < len(payset) && payset[end].Txn.Group == payset[start].Txn.Group {
+				end++
+			}
+		}
+		txgroup := make([]transactions.SignedTxn, 0, end-start)
+		for _, txib := range payset[start:end] {
+			txgroup = append(txgroup, txib.SignedTxn)
+		}
+		start = end
+		if isFreeCompactCertGroup(txgroup) {
+			continue
+		}
+		feesPerByte = append(feesPerByte, txGroupFeePerByte(txgroup))
+	}
+
+	if len(feesPerByte) > 0 {
+		sort.Slice(feesPerByte, func(i, j int) bool { return feesPerByte[i] < feesPerByte[j] })
+		fees.count = len(feesPerByte)
+		fees.quantiles = make([]uint64, feeEstimatorQuantiles+1)
+		for q := range fees.quantiles {
+			fees.quantiles[q] = feesPerByte[q*(len(feesPerByte)-1)/feeEstimatorQuantiles]
+		}
+	}
+
+	fe.mu.Lock()
+	defer fe.mu.Unlock()
+	if len(fe.blocks) < feeEstimatorHistory {
+		fe.blocks = append(fe.blocks, fees)
+		return
+	}
+	fe.blocks[fe.next] = fees
+	fe.next = (fe.next + 1) % feeEstimatorHistory
+}
+
+// estimate suggests the fee a transaction needs to pay in order to be committed within targetRounds rounds, given that
+// there are currently pendingBlocks full blocks worth of transactions waiting in the pool, and that the pool would not
+// accept transactions paying less than minFeePerByte.
+//
+// As the pool orders the transaction groups by the fee they are paying, a transaction needs to outbid the part of the
+// pending transactions which would not fit within the target rounds. The suggested fee is therefore the percentile of
+// the recent fee distribution matching that part.
+func (fe *feeEstimator) estimate(targetRounds uint64, pendingBlocks uint64, minFeePerByte uint64) FeeEstimate {
+	if targetRounds == 0 {
+		targetRounds = 1
+	}
+
+	fe.mu.RLock()
+	defer fe.mu.RUnlock()
+
+	result := FeeEstimate{
+		TargetRounds:  targetRounds,
+		PendingBlocks: pendingBlocks,
+	}
+
+	// the pressure on the pool is the greater of the current one and the average over the target rounds,
+	// so that the estimate would not drop right after a block drains the pool.
+	var recentPending, recentBlocks uint64
+	for i := 0; i < len(fe.blocks) && uint64(i) < targetRounds; i++ {
+		block := fe.blocks[(fe.next+len(fe.blocks)-1-i)%len(fe.blocks)]
+		if i == 0 {
+			result.LastRound = block.round
+		}
+		recentPending += block.pendingBlocks
+		recentBlocks++
+	}
+	pressure := pendingBlocks
+	if recentBlocks > 0 && recentPending/recentBlocks > pressure {
+		pressure = recentPending / recentBlocks
+	}
+	if pressure >= targetRounds {
+		result.Percentile = 100 - 100*targetRounds/(pressure+1)
+	}
+
+	var samples []feeSample
+	for _, block := range fe.blocks {
+		if block.count == 0 {
+			continue
+		}
+		result.SampledBlocks++
+		weight := float64(block.count) / float64(len(block.quantiles))
+		for _, feePerByte := range block.quantiles {
+			samples = append(samples, feeSample{feePerByte: feePerByte, weight: weight})
+		}
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i].feePerByte < samples[j].feePerByte })
+
+	result.FeePerByte = feePerByteAtPercentile(samples, result.Percentile)
+	if result.Percentile == 0 {
+		// the pool isn't congested; the transactions which are paying the least are committed in time.
+		result.FeePerByte = 0
+	}
+	if result.FeePerByte < minFeePerByte {
+		result.FeePerByte = minFeePerByte
+	}
+	for _, percentile := range FeeEstimatePercentiles {
+		result.Percentiles = append(result.Percentiles, FeePercentile{
+			Percentile: percentile,
+			FeePerByte: feePerByteAtPercentile(samples, percentile),
+		})
+	}
+	return result
+}
+
+// feeSample is a fee per byte, as computed by txGroupFeePerByte, along with the number of transaction groups it stands for.
+type feeSample struct {
+	feePerByte uint64
+	weight     float64
+}
+
+// feePerByteAtPercentile returns the fee per byte, in units of micro-Algos per byte, at the given percentile of the
+// sorted samples, rounding up.
+func feePerByteAtPercentile(samples []feeSample, percentile uint64) uint64 {
+	if len(samples) == 0 {
+		return 0
+	}
+	var total float64
+	for _, sample := range samples {
+		total += sample.weight
+	}
+	target := total * float64(percentile) / 100
+	var cumulative float64
+	feePerByte := samples[len(samples)-1].feePerByte
+	for _, sample := range samples {
+		cumulative += sample.weight
+		if cumulative >= target {
+			feePerByte = sample.feePerByte
+			break
+		}
+	}
+	if feePerByte == math.MaxUint64 {
+		return feePerByte / feePerByteScale
+	}
+	return (feePerByte + feePerByteScale - 1) / feePerByteScale
+}
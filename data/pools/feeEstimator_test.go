@@ -0,0 +1,102 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package pools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// makeFeeEstimatorTestBlock makes a block holding a payment transaction for every one of the given fees.
+func makeFeeEstimatorTestBlock(rnd basics.Round, fees []uint64) bookkeeping.Block {
+	var block bookkeeping.Block
+	block.BlockHeader.Round = rnd
+	sender := basics.Address(keypair().SignatureVerifier)
+	for i, fee := range fees {
+		var txib transactions.SignedTxnInBlock
+		txib.Txn = transactions.Transaction{
+			Type: protocol.PaymentTx,
+			Header: transactions.Header{
+				Sender:     sender,
+				Fee:        basics.MicroAlgos{Raw: fee},
+				FirstValid: rnd,
+				LastValid:  rnd + 10,
+				Note:       []byte{byte(i)},
+			},
+		}
+		block.Payset = append(block.Payset, txib)
+	}
+	return block
+}
+
+func TestFeeEstimator(t *testing.T) {
+	fe := makeFeeEstimator()
+
+	// without any history, the estimate is the pool minimum.
+	estimate := fe.estimate(1, 0, 3)
+	require.Equal(t, uint64(3), estimate.FeePerByte)
+	require.Zero(t, estimate.SampledBlocks)
+
+	var fees []uint64
+	for i := uint64(1); i <= 100; i++ {
+		fees = append(fees, i*1000)
+	}
+	for rnd := basics.Round(1); rnd <= feeEstimatorHistory+10; rnd++ {
+		fe.recordBlock(makeFeeEstimatorTestBlock(rnd, fees), 0)
+	}
+	// empty blocks don't contribute to the fee distribution.
+	fe.recordBlock(makeFeeEstimatorTestBlock(feeEstimatorHistory+11, nil), 0)
+
+	// the pool isn't congested; the minimal fee is suggested.
+	estimate = fe.estimate(1, 0, 1)
+	require.Equal(t, basics.Round(feeEstimatorHistory+11), estimate.LastRound)
+	require.Equal(t, uint64(feeEstimatorHistory-1), estimate.SampledBlocks)
+	require.Zero(t, estimate.Percentile)
+	require.Equal(t, uint64(1), estimate.FeePerByte)
+	require.Len(t, estimate.Percentiles, len(FeeEstimatePercentiles))
+	for i := 1; i < len(estimate.Percentiles); i++ {
+		require.True(t, estimate.Percentiles[i].FeePerByte >= estimate.Percentiles[i-1].FeePerByte)
+	}
+
+	// with three full blocks pending, a transaction which is to be committed in the next round needs to outbid
+	// three quarters of the pending transactions.
+	congested := fe.estimate(1, 3, 1)
+	require.Equal(t, uint64(75), congested.Percentile)
+	require.True(t, congested.FeePerByte > 1)
+	median := congested.Percentiles[2]
+	require.Equal(t, uint64(50), median.Percentile)
+	require.True(t, congested.FeePerByte > median.FeePerByte)
+
+	// allowing more rounds lowers the suggested fee.
+	relaxed := fe.estimate(2, 3, 1)
+	require.Equal(t, uint64(50), relaxed.Percentile)
+	require.True(t, relaxed.FeePerByte < congested.FeePerByte)
+	require.Zero(t, fe.estimate(4, 3, 1).Percentile)
+
+	// the recent pool pressure is taken into account even once the pool is drained.
+	for rnd := basics.Round(feeEstimatorHistory + 12); rnd <= feeEstimatorHistory+13; rnd++ {
+		fe.recordBlock(makeFeeEstimatorTestBlock(rnd, fees), 3)
+	}
+	require.Equal(t, uint64(75), fe.estimate(1, 0, 1).Percentile)
+	require.Equal(t, uint64(50), fe.estimate(2, 0, 1).Percentile)
+}
@@ -0,0 +1,253 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package pools
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/util/metrics"
+)
+
+// feePerByteScale is the factor the fee per byte of a transaction group is multiplied by, so that groups paying a fraction
+// of a microalgo per byte could still be told apart.
+const feePerByteScale = 1000
+
+// replaceByFeeMinBumpPercent is the minimal fee increase, in percent of the fee of the pending transaction, a transaction
+// sharing its lease has to pay in order to replace it. The increase is also at least the minimal transaction fee, so that
+// a peer can't have the pool replace transactions over and over for a negligible cost.
+const replaceByFeeMinBumpPercent = 10
+
+var transactionPoolEvicted = metrics.MakeCounter(metrics.TransactionPoolEvicted)
+var transactionPoolReplaced = metrics.MakeCounter(metrics.TransactionPoolReplaced)
+
+// errPoolCapacity is returned when the transaction pool is full, and there are not enough lower-paying transactions in it
+// which could be evicted to make room for a new transaction group.
+var errPoolCapacity = fmt.Errorf("TransactionPool.checkPendingQueueSize: transaction pool have reached capacity")
+
+// isFreeCompactCertGroup tests whether the transaction group is the compact cert transaction which, if issued from the
+// special compact-cert-sender address in a singleton group, pays no fee.
+func isFreeCompactCertGroup(txgroup []transactions.SignedTxn) bool {
+	if len(txgroup) != 1 {
+		return false
+	}
+	t := txgroup[0].Txn
+	return t.Type == protocol.CompactCertTx && t.Sender == transactions.CompactCertSender && t.Fee.IsZero()
+}
+
+// txGroupFeePerByte returns the fee per byte, multiplied by feePerByteScale, paid by the transaction group as a whole.
+// The free compact cert transaction is given the highest priority, so that it would never be evicted.
+func txGroupFeePerByte(txgroup []transactions.SignedTxn) uint64 {
+	if isFreeCompactCertGroup(txgroup) {
+		return math.MaxUint64
+	}
+	var fee, length uint64
+	for _, t := range txgroup {
+		fee = basics.AddSaturate(fee, t.Txn.Fee.Raw)
+		length += uint64(t.GetEncodedLength())
+	}
+	if length == 0 {
+		return 0
+	}
+	return basics.MulSaturate(fee, feePerByteScale) / length
+}
+
+// replaceByFeeMinFee returns the minimal fee a transaction has to pay in order to replace a pending transaction paying
+// the given fee.
+func replaceByFeeMinFee(pendingFee uint64, minTxnFee uint64) uint64 {
+	bump := basics.MulSaturate(pendingFee, replaceByFeeMinBumpPercent) / 100
+	if bump < minTxnFee {
+		bump = minTxnFee
+	}
+	return basics.AddSaturate(pendingFee, bump)
+}
+
+// replaceableGroups returns the indices of the pending transaction groups holding a lease which one of the transactions
+// in txgroup is requesting as well, while paying at least replaceByFeeMinFee of the fee of the pending transaction holding
+// it. These groups would be replaced by txgroup once it's remembered.
+func (pool *TransactionPool) replaceableGroups(txgroup []transactions.SignedTxn) (replaced []int) {
+	pool.pendingMu.RLock()
+	defer pool.pendingMu.RUnlock()
+
+	var zeroLease [32]byte
+	minTxnFee := uint64(0)
+	for _, t := range txgroup {
+		if t.Txn.Lease == zeroLease {
+			continue
+		}
+		idx, ok := pool.pendingLeases[ledgercore.Txlease{Sender: t.Txn.Sender, Lease: t.Txn.Lease}]
+		if !ok {
+			continue
+		}
+		for _, pending := range pool.pendingTxGroups[idx] {
+			if pending.Txn.Sender != t.Txn.Sender || pending.Txn.Lease != t.Txn.Lease {
+				continue
+			}
+			if minTxnFee == 0 {
+				minTxnFee = pool.minTxnFee()
+			}
+			if t.Txn.Fee.Raw >= replaceByFeeMinFee(pending.Txn.Fee.Raw, minTxnFee) && !containsGroup(replaced, idx) {
+				replaced = append(replaced, idx)
+			}
+			break
+		}
+	}
+	return
+}
+
+// containsGroup tests whether the group index is one of the given group indices.
+func containsGroup(groups []int, idx int) bool {
+	for _, group := range groups {
+		if group == idx {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPendingQueueSize tests to see if we can grow the pending group transaction list by adding txgroup, once the
+// replaced groups are removed. The limits comes from the total number of transactions and not from the total number of
+// transaction groups. If the pool is full, it returns the indices of the pending groups paying the lowest fee per byte
+// which could be evicted to make room for txgroup; only groups paying a lower fee per byte than txgroup are evicted.
+func (pool *TransactionPool) checkPendingQueueSize(txgroup []transactions.SignedTxn, replaced []int) (evicted []int, err error) {
+	pool.pendingMu.RLock()
+	defer pool.pendingMu.RUnlock()
+
+	removed := make(map[int]bool, len(replaced))
+	pendingSize := len(pool.pendingTxids)
+	for _, idx := range replaced {
+		removed[idx] = true
+		pendingSize -= len(pool.pendingTxGroups[idx])
+	}
+	excess := pendingSize + len(txgroup) - pool.txPoolMaxSize
+	if excess <= 0 {
+		return nil, nil
+	}
+
+	feePerByte := txGroupFeePerByte(txgroup)
+	var candidates []int
+	evictable := 0
+	for idx, groupFeePerByte := range pool.pendingFeePerByte {
+		if groupFeePerByte < feePerByte && !removed[idx] {
+			candidates = append(candidates, idx)
+			evictable += len(pool.pendingTxGroups[idx])
+		}
+	}
+	if evictable < excess {
+		return nil, errPoolCapacity
+	}
+
+	// evict the lowest paying groups first; among groups paying the same, the most recent ones are evicted first.
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if pool.pendingFeePerByte[candidates[i]] != pool.pendingFeePerByte[candidates[j]] {
+			return pool.pendingFeePerByte[candidates[i]] < pool.pendingFeePerByte[candidates[j]]
+		}
+		return candidates[i] > candidates[j]
+	})
+	for _, idx := range candidates {
+		if excess <= 0 {
+			break
+		}
+		evicted = append(evicted, idx)
+		excess -= len(pool.pendingTxGroups[idx])
+	}
+	return evicted, nil
+}
+
+// minTxnFee returns the minimal transaction fee of the consensus protocol of the latest block.
+func (pool *TransactionPool) minTxnFee() uint64 {
+	hdr, err := pool.ledger.BlockHdr(pool.ledger.Latest())
+	if err != nil {
+		return 0
+	}
+	return config.Consensus[hdr.CurrentProtocol].MinTxnFee
+}
+
+// removePendingGroups removes the given pending transaction groups from the pool. The caller is assumed to be holding
+// pool.mu. The pending block evaluator isn't recomputed, and so still holds the removed groups until the next block.
+// The removal isn't journaled; the caller journals the returned transaction IDs once the change is committed.
+func (pool *TransactionPool) removePendingGroups(groups []int) (removedTxids []transactions.Txid) {
+	pool.pendingMu.Lock()
+	defer pool.pendingMu.Unlock()
+
+	removed := make(map[int]bool, len(groups))
+	for _, idx := range groups {
+		removed[idx] = true
+	}
+	txGroups := make([][]transactions.SignedTxn, 0, len(pool.pendingTxGroups))
+	feePerByte := make([]uint64, 0, len(pool.pendingTxGroups))
+	for idx, txgroup := range pool.pendingTxGroups {
+		if removed[idx] {
+			for _, t := range txgroup {
+				txid := t.ID()
+				delete(pool.pendingTxids, txid)
+				removedTxids = append(removedTxids, txid)
+			}
+			continue
+		}
+		txGroups = append(txGroups, txgroup)
+		feePerByte = append(feePerByte, pool.pendingFeePerByte[idx])
+	}
+	pool.pendingTxGroups = txGroups
+	pool.pendingFeePerByte = feePerByte
+	pool.pendingLeases = makeLeaseIndex(txGroups)
+	return removedTxids
+}
+
+// makeLeaseIndex maps the leases held by the transactions of the given groups to the index of the group holding them.
+func makeLeaseIndex(txgroups [][]transactions.SignedTxn) map[ledgercore.Txlease]int {
+	leases := make(map[ledgercore.Txlease]int)
+	for idx, txgroup := range txgroups {
+		addGroupLeases(leases, txgroup, idx)
+	}
+	return leases
+}
+
+// addGroupLeases adds the leases held by the transactions of txgroup to the leases index.
+func addGroupLeases(leases map[ledgercore.Txlease]int, txgroup []transactions.SignedTxn, idx int) {
+	var zeroLease [32]byte
+	for _, t := range txgroup {
+		if t.Txn.Lease != zeroLease {
+			leases[ledgercore.Txlease{Sender: t.Txn.Sender, Lease: t.Txn.Lease}] = idx
+		}
+	}
+}
+
+// txGroupsByFee sorts transaction groups by the fee per byte they are paying, highest first.
+type txGroupsByFee struct {
+	txgroups   [][]transactions.SignedTxn
+	feePerByte []uint64
+}
+
+func (s txGroupsByFee) Len() int {
+	return len(s.txgroups)
+}
+
+func (s txGroupsByFee) Less(i, j int) bool {
+	return s.feePerByte[i] > s.feePerByte[j]
+}
+
+func (s txGroupsByFee) Swap(i, j int) {
+	s.txgroups[i], s.txgroups[j] = s.txgroups[j], s.txgroups[i]
+	s.feePerByte[i], s.feePerByte[j] = s.feePerByte[j], s.feePerByte[i]
+}
@@ -0,0 +1,332 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package pools
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/algorand/go-deadlock"
+
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+const (
+	// journalRecordAdd is a journal record holding a transaction group which was added to the pool.
+	journalRecordAdd byte = 1
+	// journalRecordRemove is a journal record holding the ids of transactions which were removed from the pool.
+	journalRecordRemove byte = 2
+
+	// journalRecordHeaderSize is the size of the record type and record length preceding every journal record.
+	journalRecordHeaderSize = 5
+	// journalMaxRecordSize is the maximal size of a journal record; larger records are considered corrupted.
+	journalMaxRecordSize = 16 * 1024 * 1024
+	// journalCompactionThreshold is the minimal number of records the journal needs to have before it is compacted.
+	journalCompactionThreshold = 1024
+)
+
+// txPoolJournal is an append-only log of the transaction groups added to and removed from the transaction pool,
+// allowing the pending transaction groups to be restored once the node restarts. Records are buffered as they are
+// added, and written through to the operating system by a background writer, so that the transaction pool doesn't
+// wait on the disk; they are not synced to the disk. The compactions are carried out by the background writer too.
+type txPoolJournal struct {
+	path string
+	// file is only accessed by the background writer, once it's started.
+	file *os.File
+	// records is the number of records the journal file has, including the buffered ones and the ones superseded by
+	// a pending compaction.
+	records int
+	log     logging.Logger
+
+	// bufferedMu protects buffered, the records which were not written to the file yet, and the pending compaction.
+	bufferedMu deadlock.Mutex
+	buffered   []byte
+	// compaction, when set, is the pending compaction of the journal. The records buffered before it was requested
+	// are in its superseded records, while buffered holds the records following it.
+	compaction *journalCompaction
+	// writeRequests wakes up the background writer once records are buffered.
+	writeRequests chan struct{}
+	closing       chan struct{}
+	writerDone    sync.WaitGroup
+}
+
+// readTxPoolJournal reads the journal at the given path, and returns the transaction groups which were added and not
+// removed since, in the order they were added. A missing journal has no transaction groups, and a truncated or corrupted
+// journal tail, as might be left behind by a crash, is ignored.
+func readTxPoolJournal(path string, log logging.Logger) ([][]transactions.SignedTxn, error) {
+	journalBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var groups [][]transactions.SignedTxn
+	// live maps the id of the first transaction of every journaled group to its index in groups.
+	live := make(map[transactions.Txid]int)
+	for len(journalBytes) > 0 {
+		if len(journalBytes) < journalRecordHeaderSize {
+			log.Warnf("readTxPoolJournal: ignoring truncated record at the end of %s", path)
+			break
+		}
+		recordType := journalBytes[0]
+		recordSize := binary.BigEndian.Uint32(journalBytes[1:journalRecordHeaderSize])
+		if recordSize > journalMaxRecordSize || int(recordSize) > len(journalBytes)-journalRecordHeaderSize {
+			log.Warnf("readTxPoolJournal: ignoring truncated record at the end of %s", path)
+			break
+		}
+		record := journalBytes[journalRecordHeaderSize : journalRecordHeaderSize+recordSize]
+		journalBytes = journalBytes[journalRecordHeaderSize+recordSize:]
+
+		switch recordType {
+		case journalRecordAdd:
+			var txgroup []transactions.SignedTxn
+			dec := protocol.NewDecoderBytes(record)
+			for {
+				var stxn transactions.SignedTxn
+				err = dec.Decode(&stxn)
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					return nil, fmt.Errorf("readTxPoolJournal: unable to decode transaction group: %v", err)
+				}
+				txgroup = append(txgroup, stxn)
+			}
+			if len(txgroup) == 0 {
+				continue
+			}
+			live[txgroup[0].ID()] = len(groups)
+			groups = append(groups, txgroup)
+		case journalRecordRemove:
+			if len(record)%len(transactions.Txid{}) != 0 {
+				return nil, fmt.Errorf("readTxPoolJournal: invalid removal record size %d", len(record))
+			}
+			for len(record) > 0 {
+				var txid transactions.Txid
+				copy(txid[:], record)
+				record = record[len(txid):]
+				if idx, ok := live[txid]; ok {
+					groups[idx] = nil
+					delete(live, txid)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("readTxPoolJournal: unknown record type %d", recordType)
+		}
+	}
+
+	liveGroups := make([][]transactions.SignedTxn, 0, len(live))
+	for _, txgroup := range groups {
+		if txgroup != nil {
+			liveGroups = append(liveGroups, txgroup)
+		}
+	}
+	return liveGroups, nil
+}
+
+// journalCompaction is a compaction of the journal requested from the transaction pool, and carried out by the
+// background writer.
+type journalCompaction struct {
+	// txgroups are the transaction groups the compacted journal starts with.
+	txgroups [][]transactions.SignedTxn
+	// superseded are the records buffered before the compaction was requested, which are written to the current
+	// journal file only if the compaction fails.
+	superseded []byte
+}
+
+// createTxPoolJournal creates a new journal at the given path, replacing the existing one, holding the given transaction groups.
+func createTxPoolJournal(path string, txgroups [][]transactions.SignedTxn, log logging.Logger) (*txPoolJournal, error) {
+	file, err := writeJournalFile(path, txgroups)
+	if err != nil {
+		return nil, err
+	}
+	journal := &txPoolJournal{
+		path:          path,
+		file:          file,
+		records:       len(txgroups),
+		log:           log,
+		writeRequests: make(chan struct{}, 1),
+		closing:       make(chan struct{}),
+	}
+	journal.writerDone.Add(1)
+	go journal.writer()
+	return journal, nil
+}
+
+// writeJournalFile writes a journal file holding the given transaction groups, syncs it, and moves it over the file at
+// the given path. It returns the new file, open for appending records.
+func writeJournalFile(path string, txgroups [][]transactions.SignedTxn) (*os.File, error) {
+	tmpPath := path + ".tmp"
+	file, err := os.OpenFile(tmpPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, err
+	}
+	var records []byte
+	for _, txgroup := range txgroups {
+		records = appendJournalRecord(records, journalRecordAdd, encodeJournalGroup(txgroup))
+	}
+	_, err = file.Write(records)
+	if err == nil {
+		err = file.Sync()
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		return nil, err
+	}
+	return file, nil
+}
+
+func encodeJournalGroup(txgroup []transactions.SignedTxn) []byte {
+	var encoded []byte
+	for i := range txgroup {
+		encoded = append(encoded, protocol.Encode(&txgroup[i])...)
+	}
+	return encoded
+}
+
+func appendJournalRecord(buffer []byte, recordType byte, record []byte) []byte {
+	var header [journalRecordHeaderSize]byte
+	header[0] = recordType
+	binary.BigEndian.PutUint32(header[1:], uint32(len(record)))
+	buffer = append(buffer, header[:]...)
+	return append(buffer, record...)
+}
+
+// writeRecord appends a record to the journal buffer, and wakes up the background writer.
+func (j *txPoolJournal) writeRecord(recordType byte, record []byte) {
+	j.bufferedMu.Lock()
+	j.buffered = appendJournalRecord(j.buffered, recordType, record)
+	j.bufferedMu.Unlock()
+	j.records++
+	j.wakeWriter()
+}
+
+func (j *txPoolJournal) wakeWriter() {
+	select {
+	case j.writeRequests <- struct{}{}:
+	default:
+	}
+}
+
+// writeBuffered carries out the pending compaction, if any, and writes the buffered records through to the journal
+// file. The records buffered while a write is in progress are written together by the next one.
+func (j *txPoolJournal) writeBuffered() error {
+	j.bufferedMu.Lock()
+	compaction := j.compaction
+	j.compaction = nil
+	buffered := j.buffered
+	j.buffered = nil
+	j.bufferedMu.Unlock()
+
+	if compaction != nil {
+		file, err := writeJournalFile(j.path, compaction.txgroups)
+		if err == nil {
+			j.file.Close()
+			j.file = file
+		} else {
+			// the current journal file keeps on going, with the records the compaction would have superseded.
+			j.log.Warnf("txPoolJournal: unable to compact %s: %v", j.path, err)
+			buffered = append(compaction.superseded, buffered...)
+		}
+	}
+	if len(buffered) == 0 {
+		return nil
+	}
+	_, err := j.file.Write(buffered)
+	return err
+}
+
+// writer is the background writer of the journal, which writes the buffered records until the journal is closed.
+func (j *txPoolJournal) writer() {
+	defer j.writerDone.Done()
+	for {
+		select {
+		case <-j.writeRequests:
+			err := j.writeBuffered()
+			if err != nil {
+				j.log.Warnf("txPoolJournal: unable to write to %s: %v", j.path, err)
+			}
+		case <-j.closing:
+			return
+		}
+	}
+}
+
+// add records the addition of transaction groups to the pool.
+func (j *txPoolJournal) add(txgroups [][]transactions.SignedTxn) {
+	for _, txgroup := range txgroups {
+		j.writeRecord(journalRecordAdd, encodeJournalGroup(txgroup))
+	}
+}
+
+// remove records the removal of transactions from the pool.
+func (j *txPoolJournal) remove(txids []transactions.Txid) {
+	if len(txids) == 0 {
+		return
+	}
+	record := make([]byte, 0, len(txids)*len(transactions.Txid{}))
+	for _, txid := range txids {
+		record = append(record, txid[:]...)
+	}
+	j.writeRecord(journalRecordRemove, record)
+}
+
+// needsCompaction tests whether the journal holds significantly more records than the given number of pending transaction groups.
+func (j *txPoolJournal) needsCompaction(pendingGroups int) bool {
+	return j.records > journalCompactionThreshold && j.records > 2*pendingGroups
+}
+
+// compact has the background writer replace the journal file with a new one, starting with just the given
+// transaction groups, followed by the records added from now on. The groups are copied, so that the caller may keep
+// on changing its list; the groups themselves are never modified.
+func (j *txPoolJournal) compact(txgroups [][]transactions.SignedTxn) {
+	snapshot := append([][]transactions.SignedTxn(nil), txgroups...)
+	j.bufferedMu.Lock()
+	superseded := j.buffered
+	if j.compaction != nil {
+		// the pending compaction is superseded as well, along with what it superseded.
+		superseded = append(j.compaction.superseded, superseded...)
+	}
+	j.compaction = &journalCompaction{txgroups: snapshot, superseded: superseded}
+	j.buffered = nil
+	j.bufferedMu.Unlock()
+	j.records = len(snapshot)
+	j.wakeWriter()
+}
+
+// close stops the background writer, and writes the remaining buffered records before closing the journal file.
+func (j *txPoolJournal) close() {
+	close(j.closing)
+	j.writerDone.Wait()
+	err := j.writeBuffered()
+	if err != nil {
+		j.log.Warnf("txPoolJournal: unable to write to %s: %v", j.path, err)
+	}
+	j.file.Close()
+}
@@ -0,0 +1,223 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package pools
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/agreement"
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/ledger"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
+	"github.com/algorand/go-algorand/logging"
+)
+
+func addJournalTestBlock(t *testing.T, l *ledger.Ledger, txns ...transactions.SignedTxn) *ledger.ValidatedBlock {
+	eval := newBlockEvaluator(t, l)
+	for _, stxn := range txns {
+		require.NoError(t, eval.Transaction(stxn, transactions.ApplyData{}))
+	}
+	blk, err := eval.GenerateBlock()
+	require.NoError(t, err)
+	require.NoError(t, l.AddValidatedBlock(*blk, agreement.Certificate{}))
+	return blk
+}
+
+func TestTxPoolJournalRestore(t *testing.T) {
+	secret := keypair()
+	sender := basics.Address(secret.SignatureVerifier)
+	receiver := basics.Address(keypair().SignatureVerifier)
+
+	mockLedger := makeMockLedger(t, initAccFixed([]basics.Address{sender, receiver}, 1<<32))
+	cfg := config.GetDefaultLocal()
+	cfg.TxPoolSize = testPoolSize
+	cfg.EnableProcessBlockStats = false
+
+	journalDir, err := ioutil.TempDir("", "txpooljournal")
+	require.NoError(t, err)
+	defer os.RemoveAll(journalDir)
+	journalPath := filepath.Join(journalDir, config.TxPoolJournalFilename)
+
+	transactionPool := MakeTransactionPool(mockLedger, cfg, logging.Base())
+	require.NoError(t, transactionPool.OpenJournal(journalPath))
+
+	committedTx := makeFeeTestPayment(mockLedger, sender, receiver, proto.MinTxnFee, 0, 0).Sign(secret)
+	committedWhileDownTx := makeFeeTestPayment(mockLedger, sender, receiver, proto.MinTxnFee, 1, 0).Sign(secret)
+	expiringTx := makeFeeTestPayment(mockLedger, sender, receiver, proto.MinTxnFee, 2, 0)
+	expiringTx.LastValid = 2
+	signedExpiringTx := expiringTx.Sign(secret)
+	pendingTx := makeFeeTestPayment(mockLedger, sender, receiver, proto.MinTxnFee, 3, 0).Sign(secret)
+	for _, stxn := range []transactions.SignedTxn{committedTx, committedWhileDownTx, signedExpiringTx, pendingTx} {
+		require.NoError(t, transactionPool.RememberOne(stxn))
+	}
+
+	blk := addJournalTestBlock(t, mockLedger, committedTx)
+	transactionPool.OnNewBlock(blk.Block(), ledgercore.StateDelta{})
+	require.Equal(t, 3, transactionPool.PendingCount())
+	transactionPool.CloseJournal()
+
+	// while the node is down, one of the transactions is committed, and another one expires.
+	addJournalTestBlock(t, mockLedger, committedWhileDownTx)
+
+	journaled, err := readTxPoolJournal(journalPath, logging.Base())
+	require.NoError(t, err)
+	require.Len(t, journaled, 3)
+
+	restartedPool := MakeTransactionPool(mockLedger, cfg, logging.Base())
+	require.NoError(t, restartedPool.OpenJournal(journalPath))
+	defer restartedPool.CloseJournal()
+	pending := restartedPool.PendingTxGroups()
+	require.Len(t, pending, 1)
+	require.Equal(t, pendingTx.ID(), pending[0][0].ID())
+
+	// the journal was compacted to hold just the restored transaction.
+	journaled, err = readTxPoolJournal(journalPath, logging.Base())
+	require.NoError(t, err)
+	require.Len(t, journaled, 1)
+	require.Equal(t, pendingTx.ID(), journaled[0][0].ID())
+}
+
+func TestTxPoolJournalTruncatedTail(t *testing.T) {
+	secret := keypair()
+	sender := basics.Address(secret.SignatureVerifier)
+	receiver := basics.Address(keypair().SignatureVerifier)
+	mockLedger := makeMockLedger(t, initAccFixed([]basics.Address{sender, receiver}, 1<<32))
+
+	journalDir, err := ioutil.TempDir("", "txpooljournal")
+	require.NoError(t, err)
+	defer os.RemoveAll(journalDir)
+	journalPath := filepath.Join(journalDir, config.TxPoolJournalFilename)
+
+	var txgroups [][]transactions.SignedTxn
+	for i := 0; i < 3; i++ {
+		txgroups = append(txgroups, []transactions.SignedTxn{makeFeeTestPayment(mockLedger, sender, receiver, proto.MinTxnFee, i, 0).Sign(secret)})
+	}
+	journal, err := createTxPoolJournal(journalPath, txgroups[:2], logging.Base())
+	require.NoError(t, err)
+	journal.remove([]transactions.Txid{txgroups[0][0].ID()})
+	journal.add(txgroups[2:])
+	journal.close()
+
+	journaled, err := readTxPoolJournal(journalPath, logging.Base())
+	require.NoError(t, err)
+	require.Equal(t, txgroups[1:], journaled)
+
+	// a record which was partially written is ignored.
+	journalBytes, err := ioutil.ReadFile(journalPath)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(journalPath, journalBytes[:len(journalBytes)-10], 0600))
+	journaled, err = readTxPoolJournal(journalPath, logging.Base())
+	require.NoError(t, err)
+	require.Equal(t, txgroups[1:2], journaled)
+
+	// a missing journal holds no transactions.
+	journaled, err = readTxPoolJournal(filepath.Join(journalDir, "missing"), logging.Base())
+	require.NoError(t, err)
+	require.Empty(t, journaled)
+}
+
+func TestTxPoolJournalCompaction(t *testing.T) {
+	secret := keypair()
+	sender := basics.Address(secret.SignatureVerifier)
+	receiver := basics.Address(keypair().SignatureVerifier)
+	mockLedger := makeMockLedger(t, initAccFixed([]basics.Address{sender, receiver}, 1<<32))
+
+	journalDir, err := ioutil.TempDir("", "txpooljournal")
+	require.NoError(t, err)
+	defer os.RemoveAll(journalDir)
+	journalPath := filepath.Join(journalDir, config.TxPoolJournalFilename)
+
+	var txgroups [][]transactions.SignedTxn
+	for i := 0; i < 4; i++ {
+		txgroups = append(txgroups, []transactions.SignedTxn{makeFeeTestPayment(mockLedger, sender, receiver, proto.MinTxnFee, i, 0).Sign(secret)})
+	}
+	journal, err := createTxPoolJournal(journalPath, txgroups[:3], logging.Base())
+	require.NoError(t, err)
+	journal.remove([]transactions.Txid{txgroups[0][0].ID()})
+
+	// the compaction takes a snapshot of the pending groups, so changing them afterwards doesn't affect it.
+	pending := append([][]transactions.SignedTxn(nil), txgroups[1:3]...)
+	journal.compact(pending)
+	require.Equal(t, 2, journal.records)
+	pending[0] = txgroups[0]
+	journal.add(txgroups[3:])
+	journal.close()
+
+	journaled, err := readTxPoolJournal(journalPath, logging.Base())
+	require.NoError(t, err)
+	require.Equal(t, txgroups[1:], journaled)
+
+	// the compacted journal holds no record of the removed group.
+	journalBytes, err := ioutil.ReadFile(journalPath)
+	require.NoError(t, err)
+	var compacted []byte
+	for _, txgroup := range txgroups[1:] {
+		compacted = appendJournalRecord(compacted, journalRecordAdd, encodeJournalGroup(txgroup))
+	}
+	require.Equal(t, compacted, journalBytes)
+}
+
+func TestTxPoolJournalReplaceByFee(t *testing.T) {
+	secret := keypair()
+	sender := basics.Address(secret.SignatureVerifier)
+	receiver := basics.Address(keypair().SignatureVerifier)
+
+	mockLedger := makeMockLedger(t, initAccFixed([]basics.Address{sender, receiver}, 1<<32))
+	cfg := config.GetDefaultLocal()
+	cfg.TxPoolSize = testPoolSize
+	cfg.EnableProcessBlockStats = false
+
+	journalDir, err := ioutil.TempDir("", "txpooljournal")
+	require.NoError(t, err)
+	defer os.RemoveAll(journalDir)
+	journalPath := filepath.Join(journalDir, config.TxPoolJournalFilename)
+
+	transactionPool := MakeTransactionPool(mockLedger, cfg, logging.Base())
+	require.NoError(t, transactionPool.OpenJournal(journalPath))
+
+	leasedTx := makeFeeTestPayment(mockLedger, sender, receiver, proto.MinTxnFee, 0, 1).Sign(secret)
+	require.NoError(t, transactionPool.RememberOne(leasedTx))
+
+	// a replacing transaction which fails verification doesn't remove the pending one from the journal.
+	overspendingTx := makeFeeTestPayment(mockLedger, sender, receiver, proto.MinTxnFee*3, 1, 1)
+	overspendingTx.Amount.Raw = 1 << 33
+	require.Error(t, transactionPool.RememberOne(overspendingTx.Sign(secret)))
+
+	replacingTx := makeFeeTestPayment(mockLedger, sender, receiver, proto.MinTxnFee*3, 2, 1).Sign(secret)
+	require.NoError(t, transactionPool.RememberOne(replacingTx))
+	transactionPool.CloseJournal()
+
+	journaled, err := readTxPoolJournal(journalPath, logging.Base())
+	require.NoError(t, err)
+	require.Equal(t, [][]transactions.SignedTxn{{replacingTx}}, journaled)
+
+	// the removal of the replaced transaction is journaled after the replacing one, so losing
+	// the last record restores both of them rather than neither.
+	journalBytes, err := ioutil.ReadFile(journalPath)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(journalPath, journalBytes[:len(journalBytes)-1], 0600))
+	journaled, err = readTxPoolJournal(journalPath, logging.Base())
+	require.NoError(t, err)
+	require.Equal(t, [][]transactions.SignedTxn{{leasedTx}, {replacingTx}}, journaled)
+}
@@ -18,6 +18,7 @@ package pools
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -28,11 +29,11 @@ import (
 	"github.com/algorand/go-algorand/data/basics"
 	"github.com/algorand/go-algorand/data/bookkeeping"
 	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/data/transactions/verify"
 	"github.com/algorand/go-algorand/ledger"
 	"github.com/algorand/go-algorand/ledger/ledgercore"
 	"github.com/algorand/go-algorand/logging"
 	"github.com/algorand/go-algorand/logging/telemetryspec"
-	"github.com/algorand/go-algorand/protocol"
 	"github.com/algorand/go-algorand/util/condvar"
 )
 
@@ -45,6 +46,12 @@ import (
 // only if its fees are sufficiently high and its state changes are
 // consistent with the prior transactions in the queue.
 //
+// Whenever the queue is recomputed, it is ordered by the fee per byte
+// the transaction groups are paying.  Once the pool is full, the lowest
+// paying groups are evicted to make room for higher paying ones, and a
+// pending transaction could be replaced by a transaction sharing its
+// sender and lease which pays a higher fee.
+//
 // TransactionPool.AssembleBlock constructs a valid block for
 // proposal given a deadline.
 type TransactionPool struct {
@@ -74,18 +81,30 @@ type TransactionPool struct {
 	assemblyRound   basics.Round
 	assemblyResults poolAsmResults
 
-	// pendingMu protects pendingTxGroups and pendingTxids
+	// pendingMu protects pendingTxGroups, pendingTxids, pendingFeePerByte and pendingLeases
 	pendingMu       deadlock.RWMutex
 	pendingTxGroups [][]transactions.SignedTxn
 	pendingTxids    map[transactions.Txid]transactions.SignedTxn
+	// pendingFeePerByte holds the fee per byte of each of the pendingTxGroups, as computed by txGroupFeePerByte.
+	pendingFeePerByte []uint64
+	// pendingLeases maps the leases held by the pending transactions to the index of the pendingTxGroups group holding them.
+	pendingLeases map[ledgercore.Txlease]int
 
 	// Calls to remember() add transactions to rememberedTxGroups and
 	// rememberedTxids.  Calling rememberCommit() adds them to the
 	// pendingTxGroups and pendingTxids.  This allows us to batch the
 	// changes in OnNewBlock() without preventing a concurrent call
 	// to PendingTxGroups() or Verified().
-	rememberedTxGroups [][]transactions.SignedTxn
-	rememberedTxids    map[transactions.Txid]transactions.SignedTxn
+	rememberedTxGroups   [][]transactions.SignedTxn
+	rememberedTxids      map[transactions.Txid]transactions.SignedTxn
+	rememberedFeePerByte []uint64
+
+	// journal, when enabled, records the changes made to the pending transaction groups,
+	// so that they could be restored once the node restarts. It is protected by pool.mu.
+	journal *txPoolJournal
+
+	// feeEstimator records the fees paid by the transactions of the recent blocks, along with the pool pressure.
+	feeEstimator *feeEstimator
 
 	log logging.Logger
 }
@@ -97,6 +116,7 @@ func MakeTransactionPool(ledger *ledger.Ledger, cfg config.Local, log logging.Lo
 	}
 	pool := TransactionPool{
 		pendingTxids:         make(map[transactions.Txid]transactions.SignedTxn),
+		pendingLeases:        make(map[ledgercore.Txlease]int),
 		rememberedTxids:      make(map[transactions.Txid]transactions.SignedTxn),
 		expiredTxCount:       make(map[basics.Round]int),
 		ledger:               ledger,
@@ -105,6 +125,7 @@ func MakeTransactionPool(ledger *ledger.Ledger, cfg config.Local, log logging.Lo
 		logAssembleStats:     cfg.EnableAssembleStats,
 		expFeeFactor:         cfg.TxPoolExponentialIncreaseFactor,
 		txPoolMaxSize:        cfg.TxPoolSize,
+		feeEstimator:         makeFeeEstimator(),
 		log:                  log,
 	}
 	pool.cond.L = &pool.mu
@@ -146,6 +167,12 @@ const (
 	// duration it would take to execute the GenerateBlock() function
 	generateBlockBaseDuration        = 2 * time.Millisecond
 	generateBlockTransactionDuration = 2155 * time.Nanosecond
+
+	// maxDependentGroupRetries is the number of times recomputeBlockEvaluator retries the pending
+	// groups which were rejected, as they might depend on lower paying groups fed after them. It
+	// bounds the length of the chains of dependent groups which are kept in the pool, and so the
+	// time spent retrying the groups which are no longer valid.
+	maxDependentGroupRetries = 4
 )
 
 // ErrStaleBlockAssemblyRequest returned by AssembleBlock when requested block number is older than the current transaction pool round
@@ -154,10 +181,16 @@ var ErrStaleBlockAssemblyRequest = fmt.Errorf("AssembleBlock: requested block as
 
 // Reset resets the content of the transaction pool
 func (pool *TransactionPool) Reset() {
+	if pool.journal != nil {
+		pool.journal.compact(nil)
+	}
 	pool.pendingTxids = make(map[transactions.Txid]transactions.SignedTxn)
 	pool.pendingTxGroups = nil
+	pool.pendingFeePerByte = nil
+	pool.pendingLeases = make(map[ledgercore.Txlease]int)
 	pool.rememberedTxids = make(map[transactions.Txid]transactions.SignedTxn)
 	pool.rememberedTxGroups = nil
+	pool.rememberedFeePerByte = nil
 	pool.expiredTxCount = make(map[basics.Round]int)
 	pool.numPendingWholeBlocks = 0
 	pool.pendingBlockEvaluator = nil
@@ -217,12 +250,26 @@ func (pool *TransactionPool) rememberCommit(flush bool) {
 	pool.pendingMu.Lock()
 	defer pool.pendingMu.Unlock()
 
+	if pool.journal != nil {
+		if flush {
+			pool.journalFlushedChanges()
+		} else {
+			pool.journal.add(pool.rememberedTxGroups)
+		}
+	}
+
 	if flush {
 		pool.pendingTxGroups = pool.rememberedTxGroups
 		pool.pendingTxids = pool.rememberedTxids
+		pool.pendingFeePerByte = pool.rememberedFeePerByte
+		pool.pendingLeases = makeLeaseIndex(pool.pendingTxGroups)
 		pool.ledger.VerifiedTransactionCache().UpdatePinned(pool.pendingTxids)
 	} else {
+		for i, txgroup := range pool.rememberedTxGroups {
+			addGroupLeases(pool.pendingLeases, txgroup, len(pool.pendingTxGroups)+i)
+		}
 		pool.pendingTxGroups = append(pool.pendingTxGroups, pool.rememberedTxGroups...)
+		pool.pendingFeePerByte = append(pool.pendingFeePerByte, pool.rememberedFeePerByte...)
 
 		for txid, txn := range pool.rememberedTxids {
 			pool.pendingTxids[txid] = txn
@@ -231,6 +278,35 @@ func (pool *TransactionPool) rememberCommit(flush bool) {
 
 	pool.rememberedTxGroups = nil
 	pool.rememberedTxids = make(map[transactions.Txid]transactions.SignedTxn)
+	pool.rememberedFeePerByte = nil
+
+	if flush && pool.journal != nil && pool.journal.needsCompaction(len(pool.pendingTxGroups)) {
+		pool.journal.compact(pool.pendingTxGroups)
+	}
+}
+
+// journalFlushedChanges records in the journal the changes that flushing the remembered transaction
+// groups into the pending ones is about to make. The caller is assumed to be holding pool.pendingMu.
+func (pool *TransactionPool) journalFlushedChanges() {
+	var removed []transactions.Txid
+	for txid := range pool.pendingTxids {
+		if _, ok := pool.rememberedTxids[txid]; !ok {
+			removed = append(removed, txid)
+		}
+	}
+	pool.journal.remove(removed)
+
+	// the remembered transactions are typically a subset of the pending ones, unless pending
+	// transaction groups which were removed are being restored.
+	if len(pool.rememberedTxids)+len(removed) > len(pool.pendingTxids) {
+		var added [][]transactions.SignedTxn
+		for _, txgroup := range pool.rememberedTxGroups {
+			if _, ok := pool.pendingTxids[txgroup[0].ID()]; !ok {
+				added = append(added, txgroup)
+			}
+		}
+		pool.journal.add(added)
+	}
 }
 
 // PendingCount returns the number of transactions currently pending in the pool.
@@ -250,24 +326,22 @@ func (pool *TransactionPool) pendingCountNoLock() int {
 	return count
 }
 
-// checkPendingQueueSize tests to see if we can grow the pending group transaction list
-// by adding txCount more transactions. The limits comes from the total number of transactions
-// and not from the total number of transaction groups.
-// As long as we haven't surpassed the size limit, we should be good to go.
-func (pool *TransactionPool) checkPendingQueueSize(txCount int) error {
-	pendingSize := pool.pendingTxIDsCount()
-	if pendingSize+txCount > pool.txPoolMaxSize {
-		return fmt.Errorf("TransactionPool.checkPendingQueueSize: transaction pool have reached capacity")
-	}
-	return nil
-}
-
 // FeePerByte returns the current minimum microalgos per byte a transaction
 // needs to pay in order to get into the pool.
 func (pool *TransactionPool) FeePerByte() uint64 {
 	return atomic.LoadUint64(&pool.feePerByte)
 }
 
+// EstimateFee suggests the fee per byte a transaction needs to pay in order to be committed within
+// the given number of rounds, based on the fees paid by the transactions of the recent blocks and
+// on the number of full blocks worth of transactions waiting in the pool.
+func (pool *TransactionPool) EstimateFee(targetRounds uint64) FeeEstimate {
+	pool.mu.Lock()
+	pendingBlocks := uint64(pool.numPendingWholeBlocks)
+	pool.mu.Unlock()
+	return pool.feeEstimator.estimate(targetRounds, pendingBlocks, pool.FeePerByte())
+}
+
 // computeFeePerByte computes and returns the current minimum microalgos per byte a transaction
 // needs to pay in order to get into the pool. It also updates the atomic counter that holds
 // the current fee per byte
@@ -313,11 +387,8 @@ func (pool *TransactionPool) checkSufficientFee(txgroup []transactions.SignedTxn
 	// Special case: the compact cert transaction, if issued from the
 	// special compact-cert-sender address, in a singleton group, pays
 	// no fee.
-	if len(txgroup) == 1 {
-		t := txgroup[0].Txn
-		if t.Type == protocol.CompactCertTx && t.Sender == transactions.CompactCertSender && t.Fee.IsZero() {
-			return nil
-		}
+	if isFreeCompactCertGroup(txgroup) {
+		return nil
 	}
 
 	// get the current fee per byte
@@ -337,39 +408,48 @@ func (pool *TransactionPool) checkSufficientFee(txgroup []transactions.SignedTxn
 // Test performs basic duplicate detection and well-formedness checks
 // on a transaction group without storing the group.
 func (pool *TransactionPool) Test(txgroup []transactions.SignedTxn) error {
-	if err := pool.checkPendingQueueSize(len(txgroup)); err != nil {
-		return err
-	}
-
 	pool.mu.Lock()
 	defer pool.mu.Unlock()
 
+	replaced := pool.replaceableGroups(txgroup)
+	if _, err := pool.checkPendingQueueSize(txgroup, replaced); err != nil {
+		return err
+	}
+
 	if pool.pendingBlockEvaluator == nil {
 		return fmt.Errorf("Test: pendingBlockEvaluator is nil")
 	}
 
+	if len(replaced) > 0 {
+		// the pending evaluator would reject the group for using the lease of the pending
+		// transactions it's about to replace.
+		return nil
+	}
 	return pool.pendingBlockEvaluator.TestTransactionGroup(txgroup)
 }
 
 type poolIngestParams struct {
 	recomputing bool // if unset, perform fee checks and wait until ledger is caught up
 	stats       *telemetryspec.AssembleBlockMetrics
+	feePerByte  uint64 // the fee per byte of the transaction group, as computed by txGroupFeePerByte
 }
 
 // remember attempts to add a transaction group to the pool.
 func (pool *TransactionPool) remember(txgroup []transactions.SignedTxn) error {
 	params := poolIngestParams{
 		recomputing: false,
+		feePerByte:  txGroupFeePerByte(txgroup),
 	}
 	return pool.ingest(txgroup, params)
 }
 
 // add tries to add the transaction group to the pool, bypassing the fee
 // priority checks.
-func (pool *TransactionPool) add(txgroup []transactions.SignedTxn, stats *telemetryspec.AssembleBlockMetrics) error {
+func (pool *TransactionPool) add(txgroup []transactions.SignedTxn, feePerByte uint64, stats *telemetryspec.AssembleBlockMetrics) error {
 	params := poolIngestParams{
 		recomputing: true,
 		stats:       stats,
+		feePerByte:  feePerByte,
 	}
 	return pool.ingest(txgroup, params)
 }
@@ -409,6 +489,7 @@ func (pool *TransactionPool) ingest(txgroup []transactions.SignedTxn, params poo
 	}
 
 	pool.rememberedTxGroups = append(pool.rememberedTxGroups, txgroup)
+	pool.rememberedFeePerByte = append(pool.rememberedFeePerByte, params.feePerByte)
 	for _, t := range txgroup {
 		pool.rememberedTxids[t.ID()] = t
 	}
@@ -424,14 +505,19 @@ func (pool *TransactionPool) RememberOne(t transactions.SignedTxn) error {
 // Remember stores the provided transaction group.
 // Precondition: Only Remember() properly-signed and well-formed transactions (i.e., ensure t.WellFormed())
 func (pool *TransactionPool) Remember(txgroup []transactions.SignedTxn) error {
-	if err := pool.checkPendingQueueSize(len(txgroup)); err != nil {
-		return err
-	}
-
 	pool.mu.Lock()
 	defer pool.mu.Unlock()
 
-	err := pool.remember(txgroup)
+	replaced := pool.replaceableGroups(txgroup)
+	evicted, err := pool.checkPendingQueueSize(txgroup, replaced)
+	if err != nil {
+		return err
+	}
+	if len(replaced) > 0 || len(evicted) > 0 {
+		return pool.rememberReplacing(txgroup, replaced, evicted)
+	}
+
+	err = pool.remember(txgroup)
 	if err != nil {
 		return fmt.Errorf("TransactionPool.Remember: %v", err)
 	}
@@ -440,6 +526,90 @@ func (pool *TransactionPool) Remember(txgroup []transactions.SignedTxn) error {
 	return nil
 }
 
+// rememberReplacing stores the provided transaction group in place of the replaced and evicted
+// pending transaction groups. The transaction group is verified before any pending group is
+// removed, so that if it cannot be stored, the pending transaction groups are left intact.
+// The pending block evaluator isn't recomputed, as doing so for every incoming group would be
+// costly: it keeps holding the removed groups until the next block, and a replacing group is
+// added to it only then. The caller is assumed to be holding pool.mu.
+func (pool *TransactionPool) rememberReplacing(txgroup []transactions.SignedTxn, replaced []int, evicted []int) error {
+	if len(replaced) == 0 {
+		// the group doesn't conflict with the pending ones, and so is evaluated on top of them.
+		err := pool.remember(txgroup)
+		if err != nil {
+			return fmt.Errorf("TransactionPool.Remember: %v", err)
+		}
+	} else {
+		// the pending evaluator would reject the group for using the leases of the pending
+		// groups it replaces, so it's evaluated on its own.
+		err := pool.checkSufficientFee(txgroup)
+		if err == nil {
+			err = pool.testGroupAlone(txgroup)
+		}
+		if err != nil {
+			return fmt.Errorf("TransactionPool.Remember: %v", err)
+		}
+		pool.rememberedTxGroups = append(pool.rememberedTxGroups, txgroup)
+		pool.rememberedFeePerByte = append(pool.rememberedFeePerByte, txGroupFeePerByte(txgroup))
+		for _, t := range txgroup {
+			pool.rememberedTxids[t.ID()] = t
+		}
+	}
+
+	pool.pendingMu.RLock()
+	prevTxGroups := pool.pendingTxGroups
+	pool.pendingMu.RUnlock()
+	removedTxids := pool.removePendingGroups(append(append([]int{}, replaced...), evicted...))
+	pool.rememberCommit(false)
+	// the removal is journaled after the replacing group, so that a restart in between
+	// restores both rather than neither.
+	if pool.journal != nil {
+		pool.journal.remove(removedTxids)
+	}
+
+	for _, idx := range replaced {
+		for _, tx := range prevTxGroups[idx] {
+			pool.statusCache.put(tx, "transaction was replaced by a transaction sharing its lease and paying a higher fee")
+			transactionPoolReplaced.Inc(nil)
+		}
+	}
+	for _, idx := range evicted {
+		for _, tx := range prevTxGroups[idx] {
+			pool.statusCache.put(tx, "transaction was evicted from the full transaction pool by transactions paying a higher fee")
+			transactionPoolEvicted.Inc(nil)
+		}
+	}
+	return nil
+}
+
+// testGroupAlone evaluates the transaction group on its own, on top of the latest block rather
+// than on top of the pending transaction groups. The caller is assumed to be holding pool.mu.
+func (pool *TransactionPool) testGroupAlone(txgroup []transactions.SignedTxn) error {
+	if pool.pendingBlockEvaluator == nil {
+		return fmt.Errorf("no pending block evaluator")
+	}
+	// the pending evaluator was started for the block following prev, so its protocol is supported.
+	prev, err := pool.ledger.BlockHdr(pool.pendingBlockEvaluator.Round() - 1)
+	if err != nil {
+		return err
+	}
+	next := bookkeeping.MakeBlock(prev)
+	eval, err := pool.ledger.StartEvaluator(next.BlockHeader, len(txgroup))
+	if err != nil {
+		return err
+	}
+	for _, tx := range txgroup {
+		if tx.Txn.LastValid < eval.Round()+pool.numPendingWholeBlocks {
+			return transactions.TxnDeadError{
+				Round:      eval.Round() + pool.numPendingWholeBlocks,
+				FirstValid: tx.Txn.FirstValid,
+				LastValid:  tx.Txn.LastValid,
+			}
+		}
+	}
+	return eval.TransactionGroup(transactions.WrapSignedTxnsWithAD(txgroup))
+}
+
 // Lookup returns the error associated with a transaction that used
 // to be in the pool.  If no status information is available (e.g., because
 // it was too long ago, or the transaction committed successfully), then
@@ -512,6 +682,7 @@ func (pool *TransactionPool) OnNewBlock(block bookkeeping.Block, delta ledgercor
 		// This has the side-effect of discarding transactions that
 		// have been committed (or that are otherwise no longer valid).
 		stats = pool.recomputeBlockEvaluator(committedTxids, knownCommitted)
+		pool.feeEstimator.recordBlock(block, uint64(pool.numPendingWholeBlocks))
 	}
 
 	stats.KnownCommittedCount = knownCommitted
@@ -647,11 +818,16 @@ func (pool *TransactionPool) recomputeBlockEvaluator(committedTxIds map[transact
 		return
 	}
 
-	// Grab the transactions to be played through the new block evaluator
+	// Grab the transactions to be played through the new block evaluator, ordered by the fee per byte
+	// they are paying. The sort is stable, so groups paying the same fee keep their arrival order.
 	pool.pendingMu.RLock()
-	txgroups := pool.pendingTxGroups
+	txgroups := make([][]transactions.SignedTxn, len(pool.pendingTxGroups))
+	copy(txgroups, pool.pendingTxGroups)
+	feePerByte := make([]uint64, len(pool.pendingFeePerByte))
+	copy(feePerByte, pool.pendingFeePerByte)
 	pendingCount := pool.pendingCountNoLock()
 	pool.pendingMu.RUnlock()
+	sort.Stable(txGroupsByFee{txgroups: txgroups, feePerByte: feePerByte})
 
 	pool.assemblyMu.Lock()
 	pool.assemblyResults = poolAsmResults{
@@ -677,8 +853,13 @@ func (pool *TransactionPool) recomputeBlockEvaluator(committedTxIds map[transact
 
 	firstTxnGrpTime := time.Now()
 
-	// Feed the transactions in order
-	for _, txgroup := range txgroups {
+	// Feed the transactions in order. Groups which were rejected for a reason other than being committed,
+	// expired or underpaying are retried once the rest were fed, as they might depend on a lower paying
+	// group which was fed after them. The retries are repeated as long as they add groups, so that chains
+	// of dependent groups are restored, up to maxDependentGroupRetries passes; the groups depending on a
+	// longer chain of lower paying groups are removed.
+	var deferred []int
+	for i, txgroup := range txgroups {
 		if len(txgroup) == 0 {
 			asmStats.InvalidCount++
 			continue
@@ -687,30 +868,35 @@ func (pool *TransactionPool) recomputeBlockEvaluator(committedTxIds map[transact
 			asmStats.EarlyCommittedCount++
 			continue
 		}
-		err := pool.add(txgroup, &asmStats)
+		err := pool.add(txgroup, feePerByte[i], &asmStats)
 		if err != nil {
-			for _, tx := range txgroup {
-				pool.statusCache.put(tx, err.Error())
-			}
-
 			switch err.(type) {
-			case *ledgercore.TransactionInLedgerError:
-				asmStats.CommittedCount++
-				stats.RemovedInvalidCount++
-			case transactions.TxnDeadError:
-				asmStats.InvalidCount++
-				stats.ExpiredCount++
-			case transactions.MinFeeError:
-				asmStats.InvalidCount++
-				stats.RemovedInvalidCount++
-				pool.log.Infof("Cannot re-add pending transaction to pool: %v", err)
+			case *ledgercore.TransactionInLedgerError, transactions.TxnDeadError, transactions.MinFeeError:
 			default:
-				asmStats.InvalidCount++
-				stats.RemovedInvalidCount++
-				pool.log.Warnf("Cannot re-add pending transaction to pool: %v", err)
+				deferred = append(deferred, i)
+				continue
 			}
+			pool.removeRejectedGroup(txgroup, err, &stats, &asmStats)
 		}
 	}
+	for pass := 1; len(deferred) > 0; pass++ {
+		var rejected []int
+		var errs []error
+		for _, i := range deferred {
+			err := pool.add(txgroups[i], feePerByte[i], &asmStats)
+			if err != nil {
+				rejected = append(rejected, i)
+				errs = append(errs, err)
+			}
+		}
+		if len(rejected) == len(deferred) || pass == maxDependentGroupRetries {
+			for j, i := range rejected {
+				pool.removeRejectedGroup(txgroups[i], errs[j], &stats, &asmStats)
+			}
+			break
+		}
+		deferred = rejected
+	}
 
 	pool.assemblyMu.Lock()
 	if !pool.assemblyDeadline.IsZero() {
@@ -741,6 +927,108 @@ func (pool *TransactionPool) recomputeBlockEvaluator(committedTxIds map[transact
 	return
 }
 
+// removeRejectedGroup records the rejection of a pending transaction group by the pending block evaluator,
+// as the group is being removed from the pool.
+func (pool *TransactionPool) removeRejectedGroup(txgroup []transactions.SignedTxn, err error, stats *telemetryspec.ProcessBlockMetrics, asmStats *telemetryspec.AssembleBlockMetrics) {
+	for _, tx := range txgroup {
+		pool.statusCache.put(tx, err.Error())
+	}
+
+	switch err.(type) {
+	case *ledgercore.TransactionInLedgerError:
+		asmStats.CommittedCount++
+		stats.RemovedInvalidCount++
+	case transactions.TxnDeadError:
+		asmStats.InvalidCount++
+		stats.ExpiredCount++
+	case transactions.MinFeeError:
+		asmStats.InvalidCount++
+		stats.RemovedInvalidCount++
+		pool.log.Infof("Cannot re-add pending transaction to pool: %v", err)
+	default:
+		asmStats.InvalidCount++
+		stats.RemovedInvalidCount++
+		pool.log.Warnf("Cannot re-add pending transaction to pool: %v", err)
+	}
+}
+
+// OpenJournal restores the transaction groups recorded in the journal at the given path into the pool,
+// and starts recording the changes made to the pending transaction groups in a new journal there.
+// Journaled transaction groups which have expired, were already committed or are otherwise no longer
+// valid are skipped.
+func (pool *TransactionPool) OpenJournal(path string) error {
+	txgroups, err := readTxPoolJournal(path, pool.log)
+	if err != nil {
+		pool.log.Warnf("TransactionPool.OpenJournal: unable to read %s, its transactions would not be restored: %v", path, err)
+	}
+	if len(txgroups) > 0 {
+		restored := pool.restoreJournaledGroups(txgroups)
+		pool.log.Infof("TransactionPool.OpenJournal: restored %d out of %d journaled transaction groups", restored, len(txgroups))
+	}
+
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	if pool.journal != nil {
+		pool.journal.close()
+		pool.journal = nil
+	}
+	pool.pendingMu.RLock()
+	journal, err := createTxPoolJournal(path, pool.pendingTxGroups, pool.log)
+	pool.pendingMu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("TransactionPool.OpenJournal: unable to create %s: %v", path, err)
+	}
+	pool.journal = journal
+	return nil
+}
+
+// restoreJournaledGroups verifies and remembers the given transaction groups, and returns the number
+// of transaction groups which were remembered.
+func (pool *TransactionPool) restoreJournaledGroups(txgroups [][]transactions.SignedTxn) (restored int) {
+	latest := pool.ledger.Latest()
+	latestHdr, err := pool.ledger.BlockHdr(latest)
+	if err != nil {
+		pool.log.Warnf("TransactionPool.restoreJournaledGroups: cannot get header for %d: %v", latest, err)
+		return 0
+	}
+	for _, txgroup := range txgroups {
+		expired := false
+		for _, tx := range txgroup {
+			if tx.Txn.LastValid <= latest {
+				expired = true
+				break
+			}
+		}
+		if expired {
+			continue
+		}
+		// the journal is read back from the disk, so the transactions are verified before they are remembered.
+		_, err = verify.TxnGroup(txgroup, latestHdr, pool.ledger.VerifiedTransactionCache())
+		if err != nil {
+			pool.log.Infof("TransactionPool.restoreJournaledGroups: journaled transaction group failed verification: %v", err)
+			continue
+		}
+		if err = pool.Test(txgroup); err != nil {
+			continue
+		}
+		if err = pool.Remember(txgroup); err != nil {
+			continue
+		}
+		restored++
+	}
+	return
+}
+
+// CloseJournal stops recording the changes made to the pending transaction groups.
+func (pool *TransactionPool) CloseJournal() {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	if pool.journal != nil {
+		pool.journal.close()
+		pool.journal = nil
+	}
+}
+
 // AssembleBlock assembles a block for a given round, trying not to
 // take longer than deadline to finish.
 func (pool *TransactionPool) AssembleBlock(round basics.Round, deadline time.Time) (assembled *ledger.ValidatedBlock, err error) {
@@ -1240,3 +1240,224 @@ func TestTxPoolSizeLimits(t *testing.T) {
 		}
 	}
 }
+
+func makeFeeTestPayment(ledger *ledger.Ledger, sender, receiver basics.Address, fee uint64, note int, lease byte) transactions.Transaction {
+	tx := transactions.Transaction{
+		Type: protocol.PaymentTx,
+		Header: transactions.Header{
+			Sender:      sender,
+			Fee:         basics.MicroAlgos{Raw: fee},
+			FirstValid:  0,
+			LastValid:   basics.Round(proto.MaxTxnLife),
+			Note:        []byte{byte(note), byte(note >> 8)},
+			GenesisHash: ledger.GenesisHash(),
+		},
+		PaymentTxnFields: transactions.PaymentTxnFields{
+			Receiver: receiver,
+			Amount:   basics.MicroAlgos{Raw: 1},
+		},
+	}
+	tx.Lease[0] = lease
+	return tx
+}
+
+func TestTxPoolEvictsLowestFee(t *testing.T) {
+	secret := keypair()
+	sender := basics.Address(secret.SignatureVerifier)
+	receiver := basics.Address(keypair().SignatureVerifier)
+
+	mockLedger := makeMockLedger(t, initAccFixed([]basics.Address{sender, receiver}, 1<<32))
+	cfg := config.GetDefaultLocal()
+	cfg.TxPoolSize = 10
+	cfg.EnableProcessBlockStats = false
+	transactionPool := MakeTransactionPool(mockLedger, cfg, logging.Base())
+
+	// fill the pool with transactions paying increasing fees.
+	var txids []transactions.Txid
+	for i := 0; i < cfg.TxPoolSize; i++ {
+		signedTx := makeFeeTestPayment(mockLedger, sender, receiver, proto.MinTxnFee+uint64(i)*100, i, 0).Sign(secret)
+		require.NoError(t, transactionPool.RememberOne(signedTx))
+		txids = append(txids, signedTx.ID())
+	}
+
+	// a transaction paying no more than the lowest paying pending transaction is rejected.
+	lowFeeTx := makeFeeTestPayment(mockLedger, sender, receiver, proto.MinTxnFee, 100, 0).Sign(secret)
+	require.Error(t, transactionPool.Test([]transactions.SignedTxn{lowFeeTx}))
+	require.Error(t, transactionPool.RememberOne(lowFeeTx))
+
+	// a higher paying transaction evicts the lowest paying one, without recomputing the pending evaluator.
+	transactionPool.mu.Lock()
+	pendingBlockEvaluator := transactionPool.pendingBlockEvaluator
+	transactionPool.mu.Unlock()
+	highFeeTx := makeFeeTestPayment(mockLedger, sender, receiver, proto.MinTxnFee*10, 101, 0).Sign(secret)
+	require.NoError(t, transactionPool.Test([]transactions.SignedTxn{highFeeTx}))
+	require.NoError(t, transactionPool.RememberOne(highFeeTx))
+	require.Equal(t, cfg.TxPoolSize, transactionPool.PendingCount())
+	transactionPool.mu.Lock()
+	require.True(t, pendingBlockEvaluator == transactionPool.pendingBlockEvaluator)
+	transactionPool.mu.Unlock()
+
+	_, txErr, found := transactionPool.Lookup(txids[0])
+	require.True(t, found)
+	require.Contains(t, txErr, "evicted")
+	for _, txid := range txids[1:] {
+		_, txErr, found := transactionPool.Lookup(txid)
+		require.True(t, found)
+		require.Empty(t, txErr)
+	}
+	_, txErr, found = transactionPool.Lookup(highFeeTx.ID())
+	require.True(t, found)
+	require.Empty(t, txErr)
+
+	// once recomputed, the pending transactions are ordered by their fee.
+	transactionPool.mu.Lock()
+	transactionPool.recomputeBlockEvaluator(make(map[transactions.Txid]basics.Round), 0)
+	transactionPool.mu.Unlock()
+	pending := transactionPool.PendingTxGroups()
+	require.Len(t, pending, cfg.TxPoolSize)
+	require.Equal(t, highFeeTx.ID(), pending[0][0].ID())
+	for i := 1; i < len(pending); i++ {
+		require.True(t, pending[i-1][0].Txn.Fee.Raw >= pending[i][0].Txn.Fee.Raw)
+	}
+}
+
+func TestTxPoolReplaceByFee(t *testing.T) {
+	secret := keypair()
+	sender := basics.Address(secret.SignatureVerifier)
+	receiver := basics.Address(keypair().SignatureVerifier)
+
+	mockLedger := makeMockLedger(t, initAccFixed([]basics.Address{sender, receiver}, 1<<32))
+	cfg := config.GetDefaultLocal()
+	cfg.TxPoolSize = testPoolSize
+	cfg.EnableProcessBlockStats = false
+	transactionPool := MakeTransactionPool(mockLedger, cfg, logging.Base())
+
+	leasedTx := makeFeeTestPayment(mockLedger, sender, receiver, proto.MinTxnFee*2, 0, 1).Sign(secret)
+	require.NoError(t, transactionPool.RememberOne(leasedTx))
+	otherTx := makeFeeTestPayment(mockLedger, sender, receiver, proto.MinTxnFee, 1, 0).Sign(secret)
+	require.NoError(t, transactionPool.RememberOne(otherTx))
+
+	// a transaction sharing the lease which doesn't pay a higher fee is rejected.
+	lowFeeTx := makeFeeTestPayment(mockLedger, sender, receiver, proto.MinTxnFee*2, 2, 1).Sign(secret)
+	require.Error(t, transactionPool.Test([]transactions.SignedTxn{lowFeeTx}))
+	require.Error(t, transactionPool.RememberOne(lowFeeTx))
+
+	// nor is one which pays a higher fee, but by less than the minimal bump.
+	smallBumpTx := makeFeeTestPayment(mockLedger, sender, receiver, proto.MinTxnFee*3-1, 5, 1).Sign(secret)
+	require.Empty(t, transactionPool.replaceableGroups([]transactions.SignedTxn{smallBumpTx}))
+	require.Error(t, transactionPool.RememberOne(smallBumpTx))
+
+	// a replacing transaction which isn't valid on its own leaves the pending one intact.
+	overspendingTx := makeFeeTestPayment(mockLedger, sender, receiver, proto.MinTxnFee*3, 6, 1)
+	overspendingTx.Amount.Raw = 1 << 33
+	require.Error(t, transactionPool.RememberOne(overspendingTx.Sign(secret)))
+	_, txErr, found := transactionPool.Lookup(leasedTx.ID())
+	require.True(t, found)
+	require.Empty(t, txErr)
+
+	// a transaction sharing the lease and paying a higher fee replaces the pending one, without recomputing the pending evaluator.
+	transactionPool.mu.Lock()
+	pendingBlockEvaluator := transactionPool.pendingBlockEvaluator
+	transactionPool.mu.Unlock()
+	replacingTx := makeFeeTestPayment(mockLedger, sender, receiver, proto.MinTxnFee*3, 3, 1).Sign(secret)
+	require.NoError(t, transactionPool.Test([]transactions.SignedTxn{replacingTx}))
+	require.NoError(t, transactionPool.RememberOne(replacingTx))
+	require.Equal(t, 2, transactionPool.PendingCount())
+	transactionPool.mu.Lock()
+	require.True(t, pendingBlockEvaluator == transactionPool.pendingBlockEvaluator)
+	transactionPool.mu.Unlock()
+
+	_, txErr, found = transactionPool.Lookup(leasedTx.ID())
+	require.True(t, found)
+	require.Contains(t, txErr, "replaced")
+	for _, txid := range []transactions.Txid{otherTx.ID(), replacingTx.ID()} {
+		_, txErr, found := transactionPool.Lookup(txid)
+		require.True(t, found)
+		require.Empty(t, txErr)
+	}
+
+	// the replacing transaction is added to the pending evaluator once it's recomputed.
+	transactionPool.mu.Lock()
+	transactionPool.recomputeBlockEvaluator(make(map[transactions.Txid]basics.Round), 0)
+	transactionPool.mu.Unlock()
+	require.Equal(t, 2, transactionPool.PendingCount())
+	_, txErr, found = transactionPool.Lookup(replacingTx.ID())
+	require.True(t, found)
+	require.Empty(t, txErr)
+
+	// a transaction of a different sender using the same lease value doesn't replace anything.
+	otherSecret := keypair()
+	otherSender := basics.Address(otherSecret.SignatureVerifier)
+	require.Empty(t, transactionPool.replaceableGroups([]transactions.SignedTxn{makeFeeTestPayment(mockLedger, otherSender, receiver, proto.MinTxnFee*10, 4, 1).Sign(otherSecret)}))
+}
+
+func TestTxPoolRecomputeRetriesDependentGroups(t *testing.T) {
+	secret := keypair()
+	sender := basics.Address(secret.SignatureVerifier)
+	fundedSecret := keypair()
+	funded := basics.Address(fundedSecret.SignatureVerifier)
+
+	mockLedger := makeMockLedger(t, initAccFixed([]basics.Address{sender}, 1<<32))
+	cfg := config.GetDefaultLocal()
+	cfg.TxPoolSize = testPoolSize
+	cfg.EnableProcessBlockStats = false
+	transactionPool := MakeTransactionPool(mockLedger, cfg, logging.Base())
+
+	// a low paying transaction funds an account, which then issues a high paying transaction.
+	fundTx := makeFeeTestPayment(mockLedger, sender, funded, proto.MinTxnFee, 0, 0)
+	fundTx.Amount.Raw = minBalance * 10
+	signedFundTx := fundTx.Sign(secret)
+	require.NoError(t, transactionPool.RememberOne(signedFundTx))
+	spendTx := makeFeeTestPayment(mockLedger, funded, sender, proto.MinTxnFee*10, 1, 0).Sign(fundedSecret)
+	require.NoError(t, transactionPool.RememberOne(spendTx))
+
+	// ordering by fee feeds the spending transaction first; it's retried once the funding one was fed.
+	transactionPool.mu.Lock()
+	transactionPool.recomputeBlockEvaluator(make(map[transactions.Txid]basics.Round), 0)
+	transactionPool.mu.Unlock()
+	require.Equal(t, 2, transactionPool.PendingCount())
+	_, txErr, found := transactionPool.Lookup(spendTx.ID())
+	require.True(t, found)
+	require.Empty(t, txErr)
+}
+
+func TestTxPoolRecomputeRetriesDependentGroupChains(t *testing.T) {
+	secret := keypair()
+	sender := basics.Address(secret.SignatureVerifier)
+
+	mockLedger := makeMockLedger(t, initAccFixed([]basics.Address{sender}, 1<<32))
+	cfg := config.GetDefaultLocal()
+	cfg.TxPoolSize = testPoolSize
+	cfg.EnableProcessBlockStats = false
+	transactionPool := MakeTransactionPool(mockLedger, cfg, logging.Base())
+
+	// a chain of accounts, each funded by the previous one, and paying a higher fee than it.
+	chainLength := maxDependentGroupRetries + 2
+	secrets := []*crypto.SignatureSecrets{secret}
+	var txids []transactions.Txid
+	for i := 0; i < chainLength; i++ {
+		next := keypair()
+		tx := makeFeeTestPayment(mockLedger, basics.Address(secrets[i].SignatureVerifier), basics.Address(next.SignatureVerifier), proto.MinTxnFee*uint64(i+1), i, 0)
+		tx.Amount.Raw = minBalance * uint64(10*(chainLength-i))
+		signedTx := tx.Sign(secrets[i])
+		require.NoError(t, transactionPool.RememberOne(signedTx))
+		secrets = append(secrets, next)
+		txids = append(txids, signedTx.ID())
+	}
+
+	// ordering by fee feeds the chain in reverse; every retry pass restores one more link of the chain, up to
+	// maxDependentGroupRetries passes, after which the rest of the chain is removed.
+	transactionPool.mu.Lock()
+	transactionPool.recomputeBlockEvaluator(make(map[transactions.Txid]basics.Round), 0)
+	transactionPool.mu.Unlock()
+	require.Equal(t, maxDependentGroupRetries+1, transactionPool.PendingCount())
+	for i, txid := range txids {
+		_, txErr, found := transactionPool.Lookup(txid)
+		require.True(t, found)
+		if i <= maxDependentGroupRetries {
+			require.Empty(t, txErr, i)
+		} else {
+			require.NotEmpty(t, txErr, i)
+		}
+	}
+}
@@ -129,3 +129,32 @@ func WrapSignedTxnsWithAD(txgroup []SignedTxn) []SignedTxnWithAD {
 	}
 	return txgroupad
 }
+
+// PaysetCreatables returns the asset and the application the transaction at the given offset of a block's flat
+// payset refers to, including the ones it creates. txnCounter is the transaction counter of the block: the index of
+// a created asset or application is the value of the counter once the creating transaction is applied.
+func PaysetCreatables(payset []SignedTxnWithAD, txnCounter uint64, intra int) (basics.AssetIndex, basics.AppIndex) {
+	txn := payset[intra].Txn
+	createdIndex := uint64(0)
+	if txnCounter >= uint64(len(payset)) {
+		createdIndex = txnCounter - uint64(len(payset)) + uint64(intra) + 1
+	}
+	switch txn.Type {
+	case protocol.AssetConfigTx:
+		if txn.ConfigAsset == 0 {
+			return basics.AssetIndex(createdIndex), 0
+		}
+		return txn.ConfigAsset, 0
+	case protocol.AssetTransferTx:
+		return txn.XferAsset, 0
+	case protocol.AssetFreezeTx:
+		return txn.FreezeAsset, 0
+	case protocol.ApplicationCallTx:
+		if txn.ApplicationID == 0 {
+			return 0, basics.AppIndex(createdIndex)
+		}
+		return 0, txn.ApplicationID
+	default:
+		return 0, 0
+	}
+}
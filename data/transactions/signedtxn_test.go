@@ -22,6 +22,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/data/basics"
 	"github.com/algorand/go-algorand/protocol"
 )
 
@@ -80,3 +81,25 @@ func TestSignedTxnInBlockHash(t *testing.T) {
 }
 
 //TODO: test multisig
+
+func TestPaysetCreatables(t *testing.T) {
+	payset := WrapSignedTxnsWithAD([]SignedTxn{
+		{Txn: Transaction{Type: protocol.PaymentTx}},
+		{Txn: Transaction{Type: protocol.AssetConfigTx}},
+		{Txn: Transaction{Type: protocol.AssetConfigTx, AssetConfigTxnFields: AssetConfigTxnFields{ConfigAsset: 3}}},
+		{Txn: Transaction{Type: protocol.AssetTransferTx, AssetTransferTxnFields: AssetTransferTxnFields{XferAsset: 4}}},
+		{Txn: Transaction{Type: protocol.AssetFreezeTx, AssetFreezeTxnFields: AssetFreezeTxnFields{FreezeAsset: 5}}},
+		{Txn: Transaction{Type: protocol.ApplicationCallTx}},
+		{Txn: Transaction{Type: protocol.ApplicationCallTx, ApplicationCallTxnFields: ApplicationCallTxnFields{ApplicationID: 6}}},
+	})
+	// the block leaves the transaction counter at 100, the first transaction of the block being the 94th.
+	expected := []struct {
+		asset basics.AssetIndex
+		app   basics.AppIndex
+	}{{0, 0}, {95, 0}, {3, 0}, {4, 0}, {5, 0}, {0, 99}, {0, 6}}
+	for intra, e := range expected {
+		asset, app := PaysetCreatables(payset, 100, intra)
+		require.Equal(t, e.asset, asset, "offset %d", intra)
+		require.Equal(t, e.app, app, "offset %d", intra)
+	}
+}
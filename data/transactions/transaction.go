@@ -568,6 +568,24 @@ func (tx Transaction) RelevantAddrs(spec SpecialAddresses) []basics.Address {
 	return addrs
 }
 
+// InvolvedAddrs returns the addresses the transaction involves: its sender, the accounts it moves algos or assets
+// between, the account it freezes and the accounts an application call references. The same address may be
+// returned more than once.
+func (tx Transaction) InvolvedAddrs() []basics.Address {
+	addrs := make([]basics.Address, 0, 7+len(tx.Accounts))
+	for _, addr := range []basics.Address{tx.Sender, tx.Receiver, tx.CloseRemainderTo, tx.AssetSender, tx.AssetReceiver, tx.AssetCloseTo, tx.FreezeAccount} {
+		if !addr.IsZero() {
+			addrs = append(addrs, addr)
+		}
+	}
+	for _, addr := range tx.Accounts {
+		if !addr.IsZero() {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
 // TxAmount returns the amount paid to the recipient in this payment
 func (tx Transaction) TxAmount() basics.MicroAlgos {
 	switch tx.Type {
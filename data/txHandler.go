@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io"
 	"sync"
+	"time"
 
 	"github.com/algorand/go-algorand/crypto"
 	"github.com/algorand/go-algorand/data/bookkeeping"
@@ -62,6 +63,7 @@ type TxHandler struct {
 	postVerificationQueue chan *txBacklogMsg
 	backlogWg             sync.WaitGroup
 	net                   network.GossipNode
+	txnRequests           *network.TxnRequestTracker
 	ctx                   context.Context
 	ctxCancel             context.CancelFunc
 }
@@ -88,6 +90,7 @@ func MakeTxHandler(txPool *pools.TransactionPool, ledger *Ledger, net network.Go
 		backlogQueue:          make(chan *txBacklogMsg, txBacklogSize),
 		postVerificationQueue: make(chan *txBacklogMsg, txBacklogSize),
 		net:                   net,
+		txnRequests:           network.MakeTxnRequestTracker(),
 	}
 
 	handler.ctx, handler.ctxCancel = context.WithCancel(context.Background())
@@ -98,9 +101,11 @@ func MakeTxHandler(txPool *pools.TransactionPool, ledger *Ledger, net network.Go
 func (handler *TxHandler) Start() {
 	handler.net.RegisterHandlers([]network.TaggedMessageHandler{
 		{Tag: protocol.TxnTag, MessageHandler: network.HandlerFunc(handler.processIncomingTxn)},
+		{Tag: protocol.TxnAnnounceTag, MessageHandler: network.HandlerFunc(handler.processTxnAnnouncement)},
 	})
-	handler.backlogWg.Add(1)
+	handler.backlogWg.Add(2)
 	go handler.backlogWorker()
+	go handler.txnRequestRetrier()
 }
 
 // Stop suspends the processing of incoming messages at the transaction handler
@@ -162,9 +167,9 @@ func (handler *TxHandler) backlogWorker() {
 
 func (handler *TxHandler) postprocessCheckedTxn(wi *txBacklogMsg) {
 	if wi.verificationErr != nil {
-		// disconnect from peer.
+		// penalize and disconnect from peer.
 		logging.Base().Warnf("Received a malformed tx group %v: %v", wi.unverifiedTxGroup, wi.verificationErr)
-		handler.net.Disconnect(wi.rawmsg.Sender)
+		handler.net.ReportPeer(wi.rawmsg.Sender, network.OffenseInvalidTransaction)
 		return
 	}
 
@@ -217,6 +222,9 @@ func (handler *TxHandler) asyncVerifySignature(arg interface{}) interface{} {
 }
 
 func (handler *TxHandler) processIncomingTxn(rawmsg network.IncomingMessage) network.OutgoingMessage {
+	// the group won't be requested when announced by other peers.
+	handler.txnRequests.MarkSeen(network.TxnDigest(rawmsg.Data))
+
 	dec := protocol.NewDecoderBytes(rawmsg.Data)
 	ntx := 0
 	unverifiedTxGroup := make([]transactions.SignedTxn, 1)
@@ -257,6 +265,52 @@ func (handler *TxHandler) processIncomingTxn(rawmsg network.IncomingMessage) net
 	return network.OutgoingMessage{Action: network.Ignore}
 }
 
+// processTxnAnnouncement requests the announced transaction groups which weren't seen yet from the announcing peer.
+// The requested groups are received as regular transaction messages; the ones which aren't are requested again from
+// the other peers which announced them by txnRequestRetrier.
+func (handler *TxHandler) processTxnAnnouncement(rawmsg network.IncomingMessage) network.OutgoingMessage {
+	digests, err := network.DecodeTxnDigests(rawmsg.Data)
+	if err != nil {
+		logging.Base().Warnf("Received a malformed txn announcement: %v", err)
+		return network.OutgoingMessage{Action: network.Disconnect}
+	}
+
+	peer, ok := rawmsg.Sender.(network.UnicastPeer)
+	if !ok {
+		return network.OutgoingMessage{Action: network.Ignore}
+	}
+	missing := handler.txnRequests.Missing(digests, peer, time.Now())
+	if len(missing) == 0 {
+		return network.OutgoingMessage{Action: network.Ignore}
+	}
+	err = peer.Unicast(handler.ctx, network.EncodeTxnDigests(missing), protocol.TxnRequestTag)
+	if err != nil {
+		logging.Base().Debugf("unable to request announced txns: %v", err)
+	}
+	return network.OutgoingMessage{Action: network.Ignore}
+}
+
+// txnRequestRetrier periodically requests the announced transaction groups whose requests went unanswered from the
+// next peers which announced them.
+func (handler *TxHandler) txnRequestRetrier() {
+	defer handler.backlogWg.Done()
+	ticker := time.NewTicker(network.TxnRequestRetryCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			for _, retry := range handler.txnRequests.Retries(now) {
+				err := retry.Peer.Unicast(handler.ctx, network.EncodeTxnDigests(retry.Digests), protocol.TxnRequestTag)
+				if err != nil {
+					logging.Base().Debugf("unable to request announced txns again: %v", err)
+				}
+			}
+		case <-handler.ctx.Done():
+			return
+		}
+	}
+}
+
 // checkAlreadyCommitted test to see if the given transaction ( in the txBacklogMsg ) was already committed, and
 // whether it would qualify as a candidate for the transaction pool.
 //
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
+	"encoding/binary"
 	"fmt"
 	"time"
 
@@ -46,6 +47,10 @@ type accountsDbQueries struct {
 	insertCatchpointStateUint64 *sql.Stmt
 	selectCatchpointStateString *sql.Stmt
 	insertCatchpointStateString *sql.Stmt
+
+	// r is used for querying the asset holders and application opt-ins indexes. These queries aren't prepared, since
+	// the indexes are missing while the database schema is being upgraded.
+	r db.Queryable
 }
 
 var accountsSchema = []string{
@@ -109,12 +114,37 @@ var accountsResetExprs = []string{
 	`DROP TABLE IF EXISTS storedcatchpoints`,
 	`DROP TABLE IF EXISTS catchpointstate`,
 	`DROP TABLE IF EXISTS accounthashes`,
+	`DROP TABLE IF EXISTS catchpointdeltaaccounts`,
+	`DROP TABLE IF EXISTS assetholders`,
+	`DROP TABLE IF EXISTS appoptins`,
+}
+
+// catchpointDeltaAccountsSchema holds the addresses of the accounts modified since the last catchpoint file was
+// written, which are used for writing the catchpoint delta files.
+var catchpointDeltaAccountsSchema = []string{
+	`CREATE TABLE IF NOT EXISTS catchpointdeltaaccounts (
+		address blob primary key)`,
+}
+
+// holdingsIndexSchema holds the asset holders and application opt-ins indexes, which are maintained alongside the
+// accountbase table. The asset amounts are stored as big-endian blobs; see encodeHoldingAmount.
+var holdingsIndexSchema = []string{
+	`CREATE TABLE IF NOT EXISTS assetholders (
+		asset integer,
+		address blob,
+		amount blob,
+		frozen integer,
+		PRIMARY KEY (asset, address))`,
+	`CREATE TABLE IF NOT EXISTS appoptins (
+		app integer,
+		address blob,
+		PRIMARY KEY (app, address))`,
 }
 
 // accountDBVersion is the database version that this binary would know how to support and how to upgrade to.
 // details about the content of each of the versions can be found in the upgrade functions upgradeDatabaseSchemaXXXX
 // and their descriptions.
-var accountDBVersion = int32(5)
+var accountDBVersion = int32(7)
 
 // persistedAccountData is used for representing a single account stored on the disk. In addition to the
 // basics.AccountData, it also stores complete referencing information used to maintain the base accounts
@@ -176,8 +206,41 @@ const (
 	// catchpointStateCatchupBalancesRound is the balance round that is associated with the current running catchpoint catchup. Typically it would be
 	// equal to catchpointStateCatchupBlockRound - 320.
 	catchpointStateCatchupBalancesRound = catchpointState("catchpointCatchupBalancesRound")
+	// catchpointStateDeltaBaseRound is the round of the catchpoint file the modified accounts of the catchpointdeltaaccounts
+	// table are relative to. Zero indicates that the modified accounts aren't being tracked.
+	catchpointStateDeltaBaseRound = catchpointState("catchpointDeltaBaseRound")
+	// catchpointStateCatchupStagedLabel is the label of the catchpoint which is currently held by the catchpoint catchup staging
+	// tables. Catchpoint delta files can be applied only on top of the catchpoint they were generated for.
+	catchpointStateCatchupStagedLabel = catchpointState("catchpointCatchupStagedLabel")
+	// catchpointStateCatchupDownloadRound is the round of the catchpoint file which is currently being downloaded. The download
+	// progress variables below are stored only while the download is in progress, so that an interrupted download could be resumed.
+	catchpointStateCatchupDownloadRound = catchpointState("catchpointCatchupDownloadRound")
+	// catchpointStateCatchupDownloadBytes is the number of bytes of the uncompressed catchpoint file stream that were received and processed.
+	catchpointStateCatchupDownloadBytes = catchpointState("catchpointCatchupDownloadBytes")
+	// catchpointStateCatchupDownloadSectionOffset is the offset of the last processed section within the uncompressed catchpoint file stream.
+	catchpointStateCatchupDownloadSectionOffset = catchpointState("catchpointCatchupDownloadSectionOffset")
+	// catchpointStateCatchupDownloadSections is the number of catchpoint file sections that were processed.
+	catchpointStateCatchupDownloadSections = catchpointState("catchpointCatchupDownloadSections")
+	// catchpointStateCatchupDownloadTotalAccounts is the number of accounts in the catchpoint file being downloaded.
+	catchpointStateCatchupDownloadTotalAccounts = catchpointState("catchpointCatchupDownloadTotalAccounts")
+	// catchpointStateCatchupDownloadTotalChunks is the number of balances chunks in the catchpoint file being downloaded.
+	catchpointStateCatchupDownloadTotalChunks = catchpointState("catchpointCatchupDownloadTotalChunks")
+	// catchpointStateCatchupDownloadProcessedAccounts is the number of accounts that were processed.
+	catchpointStateCatchupDownloadProcessedAccounts = catchpointState("catchpointCatchupDownloadProcessedAccounts")
+	// catchpointStateCatchupDownloadProcessedBytes is the number of balances bytes that were processed.
+	catchpointStateCatchupDownloadProcessedBytes = catchpointState("catchpointCatchupDownloadProcessedBytes")
 )
 
+// catchpointStateCatchpointUncompressedSize is the size of the uncompressed content of the stored catchpoint file of the given round.
+func catchpointStateCatchpointUncompressedSize(round basics.Round) catchpointState {
+	return catchpointState(fmt.Sprintf("catchpointUncompressedSize%d", round))
+}
+
+// catchpointStateCatchupDownloadSectionHash is the hash of the given processed section of the catchpoint file being downloaded.
+func catchpointStateCatchupDownloadSectionHash(section uint64) catchpointState {
+	return catchpointState(fmt.Sprintf("catchpointCatchupDownloadSectionHash%d", section))
+}
+
 // normalizedAccountBalance is a staging area for a catchpoint file account information before it's being added to the catchpoint staging tables.
 type normalizedAccountBalance struct {
 	address            basics.Address
@@ -400,6 +463,101 @@ func writeCatchpointStagingHashes(ctx context.Context, tx *sql.Tx, bals []normal
 	return nil
 }
 
+// deleteCatchpointStagingBalances deletes the provided accounts, along with the creatables they've created and their
+// holdings index entries, from the catchpoint staging tables.
+func deleteCatchpointStagingBalances(ctx context.Context, tx *sql.Tx, bals []normalizedAccountBalance) error {
+	deleteAcctStmt, err := tx.PrepareContext(ctx, "DELETE FROM catchpointbalances WHERE address=?")
+	if err != nil {
+		return err
+	}
+	defer deleteAcctStmt.Close()
+	deleteCreatableStmt, err := tx.PrepareContext(ctx, "DELETE FROM catchpointassetcreators WHERE asset=?")
+	if err != nil {
+		return err
+	}
+	defer deleteCreatableStmt.Close()
+	holdingsWriter, err := makeHoldingsIndexWriter(ctx, tx, "catchpointassetholders", "catchpointappoptins")
+	if err != nil {
+		return err
+	}
+	defer holdingsWriter.close()
+
+	for _, balance := range bals {
+		_, err = deleteAcctStmt.ExecContext(ctx, balance.address[:])
+		if err != nil {
+			return err
+		}
+		err = holdingsWriter.update(ctx, balance.address, makeHoldingsIndexUpdate(balance.accountData, basics.AccountData{}))
+		if err != nil {
+			return err
+		}
+		for aidx := range balance.accountData.AssetParams {
+			_, err = deleteCreatableStmt.ExecContext(ctx, basics.CreatableIndex(aidx))
+			if err != nil {
+				return err
+			}
+		}
+		for aidx := range balance.accountData.AppParams {
+			_, err = deleteCreatableStmt.ExecContext(ctx, basics.CreatableIndex(aidx))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// readCatchpointStagingBalances returns the accounts of the catchpoint balance staging table matching the provided addresses.
+func readCatchpointStagingBalances(ctx context.Context, tx *sql.Tx, addrs []basics.Address) (bals []encodedBalanceRecord, err error) {
+	selectStmt, err := tx.PrepareContext(ctx, "SELECT data FROM catchpointbalances WHERE address=?")
+	if err != nil {
+		return nil, err
+	}
+	defer selectStmt.Close()
+
+	for _, addr := range addrs {
+		var buf []byte
+		err = selectStmt.QueryRowContext(ctx, addr[:]).Scan(&buf)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		bals = append(bals, encodedBalanceRecord{Address: addr, AccountData: buf})
+	}
+	return bals, nil
+}
+
+// catchpointDeltaAddAccounts records the given addresses as modified since the last catchpoint file was written.
+func catchpointDeltaAddAccounts(ctx context.Context, tx *sql.Tx, addrs []basics.Address) error {
+	insertStmt, err := tx.PrepareContext(ctx, "INSERT OR IGNORE INTO catchpointdeltaaccounts(address) VALUES(?)")
+	if err != nil {
+		return err
+	}
+	defer insertStmt.Close()
+
+	for _, addr := range addrs {
+		_, err = insertStmt.ExecContext(ctx, addr[:])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// catchpointDeltaReset clears the set of the accounts modified since the last catchpoint file was written.
+func catchpointDeltaReset(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM catchpointdeltaaccounts")
+	return err
+}
+
+// catchpointDeltaAccountsCount returns the number of accounts modified since the last catchpoint file was written.
+func catchpointDeltaAccountsCount(ctx context.Context, tx *sql.Tx) (count uint64, err error) {
+	err = tx.QueryRowContext(ctx, "SELECT count(1) FROM catchpointdeltaaccounts").Scan(&count)
+	return
+}
+
 // createCatchpointStagingHashesIndex creates an index on catchpointpendinghashes to allow faster scanning according to the hash order
 func createCatchpointStagingHashesIndex(ctx context.Context, tx *sql.Tx) (err error) {
 	_, err = tx.ExecContext(ctx, "CREATE INDEX IF NOT EXISTS catchpointpendinghashesidx ON catchpointpendinghashes(data)")
@@ -409,14 +567,25 @@ func createCatchpointStagingHashesIndex(ctx context.Context, tx *sql.Tx) (err er
 	return
 }
 
-// writeCatchpointStagingCreatable inserts all the creatables in the provided array into the catchpoint asset creator staging table catchpointassetcreators.
+// writeCatchpointStagingCreatable inserts all the creatables in the provided array into the catchpoint asset creator staging table catchpointassetcreators,
+// and the asset holdings and application opt-ins into the catchpoint holdings index staging tables.
 func writeCatchpointStagingCreatable(ctx context.Context, tx *sql.Tx, bals []normalizedAccountBalance) error {
 	insertStmt, err := tx.PrepareContext(ctx, "INSERT INTO catchpointassetcreators(asset, creator, ctype) VALUES(?, ?, ?)")
 	if err != nil {
 		return err
 	}
+	holdingsWriter, err := makeHoldingsIndexWriter(ctx, tx, "catchpointassetholders", "catchpointappoptins")
+	if err != nil {
+		return err
+	}
+	defer holdingsWriter.close()
 
 	for _, balance := range bals {
+		err = holdingsWriter.update(ctx, balance.address, makeHoldingsIndexUpdate(basics.AccountData{}, balance.accountData))
+		if err != nil {
+			return err
+		}
+
 		// if the account has any asset params, it means that it's the creator of an asset.
 		if len(balance.accountData.AssetParams) > 0 {
 			for aidx := range balance.accountData.AssetParams {
@@ -445,6 +614,8 @@ func resetCatchpointStagingBalances(ctx context.Context, tx *sql.Tx, newCatchup
 		"DROP TABLE IF EXISTS catchpointassetcreators",
 		"DROP TABLE IF EXISTS catchpointaccounthashes",
 		"DROP TABLE IF EXISTS catchpointpendinghashes",
+		"DROP TABLE IF EXISTS catchpointassetholders",
+		"DROP TABLE IF EXISTS catchpointappoptins",
 		"DELETE FROM accounttotals where id='catchpointStaging'",
 	}
 
@@ -462,6 +633,8 @@ func resetCatchpointStagingBalances(ctx context.Context, tx *sql.Tx, newCatchup
 			"CREATE TABLE IF NOT EXISTS catchpointbalances (address blob primary key, data blob, normalizedonlinebalance integer)",
 			"CREATE TABLE IF NOT EXISTS catchpointpendinghashes (data blob)",
 			"CREATE TABLE IF NOT EXISTS catchpointaccounthashes (id integer primary key, data blob)",
+			"CREATE TABLE IF NOT EXISTS catchpointassetholders (asset integer, address blob, amount blob, frozen integer, PRIMARY KEY (asset, address))",
+			"CREATE TABLE IF NOT EXISTS catchpointappoptins (app integer, address blob, PRIMARY KEY (app, address))",
 			createNormalizedOnlineBalanceIndex(idxnameBalances, "catchpointbalances"),
 		)
 	}
@@ -483,14 +656,20 @@ func applyCatchpointStagingBalances(ctx context.Context, tx *sql.Tx, balancesRou
 		"ALTER TABLE accountbase RENAME TO accountbase_old",
 		"ALTER TABLE assetcreators RENAME TO assetcreators_old",
 		"ALTER TABLE accounthashes RENAME TO accounthashes_old",
+		"ALTER TABLE assetholders RENAME TO assetholders_old",
+		"ALTER TABLE appoptins RENAME TO appoptins_old",
 
 		"ALTER TABLE catchpointbalances RENAME TO accountbase",
 		"ALTER TABLE catchpointassetcreators RENAME TO assetcreators",
 		"ALTER TABLE catchpointaccounthashes RENAME TO accounthashes",
+		"ALTER TABLE catchpointassetholders RENAME TO assetholders",
+		"ALTER TABLE catchpointappoptins RENAME TO appoptins",
 
 		"DROP TABLE IF EXISTS accountbase_old",
 		"DROP TABLE IF EXISTS assetcreators_old",
 		"DROP TABLE IF EXISTS accounthashes_old",
+		"DROP TABLE IF EXISTS assetholders_old",
+		"DROP TABLE IF EXISTS appoptins_old",
 	}
 
 	for _, stmt := range stmts {
@@ -567,6 +746,11 @@ func accountsInit(tx *sql.Tx, initAccounts map[basics.Address]basics.AccountData
 		if err != nil {
 			return true, err
 		}
+
+		err = accountsCreateHoldingsIndex(context.Background(), tx)
+		if err != nil {
+			return true, err
+		}
 		newDatabase = true
 	} else {
 		serr, ok := err.(sqlite3.Error)
@@ -581,6 +765,132 @@ func accountsInit(tx *sql.Tx, initAccounts map[basics.Address]basics.AccountData
 	return newDatabase, nil
 }
 
+// accountsCreateCatchpointDeltaAccounts creates the catchpointdeltaaccounts table.
+func accountsCreateCatchpointDeltaAccounts(tx *sql.Tx) error {
+	for _, stmt := range catchpointDeltaAccountsSchema {
+		_, err := tx.Exec(stmt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// accountsCreateHoldingsIndex creates the asset holders and application opt-ins tables, and fills them with the
+// holdings of the accounts in the accountbase table.
+func accountsCreateHoldingsIndex(ctx context.Context, tx *sql.Tx) (err error) {
+	for _, stmt := range holdingsIndexSchema {
+		_, err = tx.ExecContext(ctx, stmt)
+		if err != nil {
+			return err
+		}
+	}
+
+	holdingsWriter, err := makeHoldingsIndexWriter(ctx, tx, "assetholders", "appoptins")
+	if err != nil {
+		return err
+	}
+	defer holdingsWriter.close()
+
+	rows, err := tx.QueryContext(ctx, "SELECT address, data FROM accountbase")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var addrbuf, buf []byte
+	for rows.Next() {
+		err = rows.Scan(&addrbuf, &buf)
+		if err != nil {
+			return err
+		}
+		var data basics.AccountData
+		err = protocol.Decode(buf, &data)
+		if err != nil {
+			return err
+		}
+		if len(data.Assets) == 0 && len(data.AppLocalStates) == 0 {
+			continue
+		}
+		var addr basics.Address
+		if len(addrbuf) != len(addr) {
+			return fmt.Errorf("account DB address length mismatch: %d != %d", len(addrbuf), len(addr))
+		}
+		copy(addr[:], addrbuf)
+		err = holdingsWriter.update(ctx, addr, makeHoldingsIndexUpdate(basics.AccountData{}, data))
+		if err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// holdingsIndexWriter writes the asset holders and application opt-ins index entries of accounts, into either the
+// actual index tables or their catchpoint staging counterparts.
+type holdingsIndexWriter struct {
+	putAssetStmt    *sql.Stmt
+	deleteAssetStmt *sql.Stmt
+	putAppStmt      *sql.Stmt
+	deleteAppStmt   *sql.Stmt
+}
+
+func makeHoldingsIndexWriter(ctx context.Context, tx *sql.Tx, assetHoldersTable string, appOptInsTable string) (*holdingsIndexWriter, error) {
+	w := &holdingsIndexWriter{}
+	var err error
+	w.putAssetStmt, err = tx.PrepareContext(ctx, fmt.Sprintf("INSERT OR REPLACE INTO %s (asset, address, amount, frozen) VALUES (?, ?, ?, ?)", assetHoldersTable))
+	if err == nil {
+		w.deleteAssetStmt, err = tx.PrepareContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE asset=? AND address=?", assetHoldersTable))
+	}
+	if err == nil {
+		w.putAppStmt, err = tx.PrepareContext(ctx, fmt.Sprintf("INSERT OR REPLACE INTO %s (app, address) VALUES (?, ?)", appOptInsTable))
+	}
+	if err == nil {
+		w.deleteAppStmt, err = tx.PrepareContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE app=? AND address=?", appOptInsTable))
+	}
+	if err != nil {
+		w.close()
+		return nil, err
+	}
+	return w, nil
+}
+
+// update applies the given change to the holdings index entries of the account.
+func (w *holdingsIndexWriter) update(ctx context.Context, addr basics.Address, u holdingsIndexUpdate) (err error) {
+	for aidx, holding := range u.putAssets {
+		_, err = w.putAssetStmt.ExecContext(ctx, aidx, addr[:], encodeHoldingAmount(holding.Amount), holding.Frozen)
+		if err != nil {
+			return err
+		}
+	}
+	for _, aidx := range u.deleteAssets {
+		_, err = w.deleteAssetStmt.ExecContext(ctx, aidx, addr[:])
+		if err != nil {
+			return err
+		}
+	}
+	for _, appIdx := range u.putApps {
+		_, err = w.putAppStmt.ExecContext(ctx, appIdx, addr[:])
+		if err != nil {
+			return err
+		}
+	}
+	for _, appIdx := range u.deleteApps {
+		_, err = w.deleteAppStmt.ExecContext(ctx, appIdx, addr[:])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *holdingsIndexWriter) close() {
+	for _, stmt := range []*sql.Stmt{w.putAssetStmt, w.deleteAssetStmt, w.putAppStmt, w.deleteAppStmt} {
+		if stmt != nil {
+			stmt.Close()
+		}
+	}
+}
+
 // accountsAddNormalizedBalance adds the normalizedonlinebalance column
 // to the accountbase table.
 func accountsAddNormalizedBalance(tx *sql.Tx, proto config.ConsensusParams) error {
@@ -729,7 +1039,7 @@ func accountsRound(tx *sql.Tx) (rnd basics.Round, hashrnd basics.Round, err erro
 
 func accountsDbInit(r db.Queryable, w db.Queryable) (*accountsDbQueries, error) {
 	var err error
-	qs := &accountsDbQueries{}
+	qs := &accountsDbQueries{r: r}
 
 	qs.listCreatablesStmt, err = r.Prepare("SELECT rnd, asset, creator FROM acctrounds LEFT JOIN assetcreators ON assetcreators.asset <= ? AND assetcreators.ctype = ? WHERE acctrounds.id='acctbase' ORDER BY assetcreators.asset desc LIMIT ?")
 	if err != nil {
@@ -978,6 +1288,85 @@ func (qs *accountsDbQueries) writeCatchpointStateString(ctx context.Context, sta
 	return cleared, err
 }
 
+// listAssetHolders returns up to maxResults holders of the given asset, ordered by their addresses, which follow the
+// after address and whose holding amounts are within the given range.
+func (qs *accountsDbQueries) listAssetHolders(aidx basics.AssetIndex, after *basics.Address, minAmount, maxAmount uint64, maxResults uint64) (results []AssetHolder, dbRound basics.Round, err error) {
+	afterbuf := []byte{}
+	if after != nil {
+		afterbuf = after[:]
+	}
+	err = db.Retry(func() error {
+		results = nil
+		rows, err := qs.r.Query("SELECT rnd, address, amount, frozen FROM acctrounds LEFT JOIN assetholders ON asset = ? AND address > ? AND amount >= ? AND amount <= ? WHERE id='acctbase' ORDER BY address LIMIT ?",
+			aidx, afterbuf, encodeHoldingAmount(minAmount), encodeHoldingAmount(maxAmount), maxResults)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		var addrbuf, amountbuf []byte
+		var frozen sql.NullBool
+		for rows.Next() {
+			err = rows.Scan(&dbRound, &addrbuf, &amountbuf, &frozen)
+			if err != nil {
+				return err
+			}
+			if addrbuf == nil {
+				// we received an entry without any holder. This would happen only on the first entry when the asset has no matching holders.
+				break
+			}
+			var holder AssetHolder
+			if len(addrbuf) != len(holder.Address) || len(amountbuf) != 8 {
+				return fmt.Errorf("asset holder record of asset %d is malformed", aidx)
+			}
+			copy(holder.Address[:], addrbuf)
+			holder.Holding.Amount = binary.BigEndian.Uint64(amountbuf)
+			holder.Holding.Frozen = frozen.Bool
+			results = append(results, holder)
+		}
+		return rows.Err()
+	})
+	return
+}
+
+// listAppOptIns returns up to maxResults addresses of the accounts opted into the given application, in increasing
+// order, following the after address.
+func (qs *accountsDbQueries) listAppOptIns(appIdx basics.AppIndex, after *basics.Address, maxResults uint64) (results []basics.Address, dbRound basics.Round, err error) {
+	afterbuf := []byte{}
+	if after != nil {
+		afterbuf = after[:]
+	}
+	err = db.Retry(func() error {
+		results = nil
+		rows, err := qs.r.Query("SELECT rnd, address FROM acctrounds LEFT JOIN appoptins ON app = ? AND address > ? WHERE id='acctbase' ORDER BY address LIMIT ?",
+			appIdx, afterbuf, maxResults)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		var addrbuf []byte
+		for rows.Next() {
+			err = rows.Scan(&dbRound, &addrbuf)
+			if err != nil {
+				return err
+			}
+			if addrbuf == nil {
+				// we received an entry without any address. This would happen only on the first entry when no account opted into the application.
+				break
+			}
+			var addr basics.Address
+			if len(addrbuf) != len(addr) {
+				return fmt.Errorf("application opt-in record of application %d is malformed", appIdx)
+			}
+			copy(addr[:], addrbuf)
+			results = append(results, addr)
+		}
+		return rows.Err()
+	})
+	return
+}
+
 func (qs *accountsDbQueries) close() {
 	preparedQueries := []**sql.Stmt{
 		&qs.listCreatablesStmt,
@@ -1153,6 +1542,11 @@ func accountsNewRound(tx *sql.Tx, updates compactAccountDeltas, creatables map[b
 		updatedAccountIdx++
 	}
 
+	err = updateHoldingsIndex(tx, updates)
+	if err != nil {
+		return
+	}
+
 	if len(creatables) > 0 {
 		insertCreatableIdxStmt, err = tx.Prepare("INSERT INTO assetcreators (asset, creator, ctype) VALUES (?, ?, ?)")
 		if err != nil {
@@ -1181,14 +1575,56 @@ func accountsNewRound(tx *sql.Tx, updates compactAccountDeltas, creatables map[b
 	return
 }
 
+// updateHoldingsIndex updates the asset holders and application opt-ins indexes with the holdings changes of the
+// updated accounts.
+func updateHoldingsIndex(tx *sql.Tx, updates compactAccountDeltas) error {
+	var holdingsWriter *holdingsIndexWriter
+	for i := 0; i < updates.len(); i++ {
+		addr, data := updates.getByIdx(i)
+		u := makeHoldingsIndexUpdate(data.old.accountData, data.new)
+		if len(u.putAssets) == 0 && len(u.deleteAssets) == 0 && len(u.putApps) == 0 && len(u.deleteApps) == 0 {
+			continue
+		}
+		if holdingsWriter == nil {
+			var err error
+			holdingsWriter, err = makeHoldingsIndexWriter(context.Background(), tx, "assetholders", "appoptins")
+			if err != nil {
+				return err
+			}
+			defer holdingsWriter.close()
+		}
+		err := holdingsWriter.update(context.Background(), addr, u)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // totalsNewRounds updates the accountsTotals by applying series of round changes
 func totalsNewRounds(tx *sql.Tx, updates []ledgercore.AccountDeltas, compactUpdates compactAccountDeltas, accountTotals []ledgercore.AccountTotals, proto config.ConsensusParams) (err error) {
-	var ot basics.OverflowTracker
 	totals, err := accountsTotals(tx, false)
 	if err != nil {
 		return
 	}
 
+	totals, err = applyTotalsNewRounds(totals, updates, compactUpdates, accountTotals, proto)
+	if err != nil {
+		return
+	}
+
+	err = accountsPutTotals(tx, totals, false)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// applyTotalsNewRounds applies series of round changes to the given totals, returning the updated totals.
+func applyTotalsNewRounds(totals ledgercore.AccountTotals, updates []ledgercore.AccountDeltas, compactUpdates compactAccountDeltas, accountTotals []ledgercore.AccountTotals, proto config.ConsensusParams) (ledgercore.AccountTotals, error) {
+	var ot basics.OverflowTracker
+
 	// copy the updates base account map, since we don't want to modify the input map.
 	accounts := make(map[basics.Address]basics.AccountData, compactUpdates.len())
 	for i := 0; i < compactUpdates.len(); i++ {
@@ -1205,8 +1641,7 @@ func totalsNewRounds(tx *sql.Tx, updates []ledgercore.AccountDeltas, compactUpda
 			if oldAccountData, has := accounts[addr]; has {
 				totals.DelAccount(proto, oldAccountData, &ot)
 			} else {
-				err = fmt.Errorf("missing old account data")
-				return
+				return totals, fmt.Errorf("missing old account data")
 			}
 
 			totals.AddAccount(proto, data, &ot)
@@ -1215,16 +1650,9 @@ func totalsNewRounds(tx *sql.Tx, updates []ledgercore.AccountDeltas, compactUpda
 	}
 
 	if ot.Overflowed {
-		err = fmt.Errorf("overflow computing totals")
-		return
+		return totals, fmt.Errorf("overflow computing totals")
 	}
-
-	err = accountsPutTotals(tx, totals, false)
-	if err != nil {
-		return
-	}
-
-	return
+	return totals, nil
 }
 
 // updates the round number associated with the current account data.
@@ -1415,15 +1843,22 @@ func (mc *MerkleCommitter) LoadPage(page uint64) (content []byte, err error) {
 }
 
 // encodedAccountsBatchIter allows us to iterate over the accounts data stored in the accountbase table.
+// When deltaAccounts is set, it iterates only over the accounts listed in the catchpointdeltaaccounts table,
+// returning an empty account data for the accounts which no longer exist.
 type encodedAccountsBatchIter struct {
-	rows *sql.Rows
+	rows          *sql.Rows
+	deltaAccounts bool
 }
 
 // Next returns an array containing the account data, in the same way it appear in the database
 // returning accountCount accounts data at a time.
 func (iterator *encodedAccountsBatchIter) Next(ctx context.Context, tx *sql.Tx, accountCount int) (bals []encodedBalanceRecord, err error) {
 	if iterator.rows == nil {
-		iterator.rows, err = tx.QueryContext(ctx, "SELECT address, data FROM accountbase ORDER BY address")
+		if iterator.deltaAccounts {
+			iterator.rows, err = tx.QueryContext(ctx, "SELECT catchpointdeltaaccounts.address, COALESCE(accountbase.data, ?) FROM catchpointdeltaaccounts LEFT JOIN accountbase ON catchpointdeltaaccounts.address = accountbase.address ORDER BY catchpointdeltaaccounts.address", protocol.Encode(&basics.AccountData{}))
+		} else {
+			iterator.rows, err = tx.QueryContext(ctx, "SELECT address, data FROM accountbase ORDER BY address")
+		}
 		if err != nil {
 			return
 		}
@@ -144,16 +144,21 @@ type accountUpdates struct {
 	// 0 means don't store any, -1 mean unlimited and positive number suggest the number of most recent catchpoint files.
 	catchpointFileHistoryLength int
 
+	// catchpointFullFileInterval is the number of catchpoints between two consecutive complete catchpoint files. The catchpoint
+	// files in between contain only the accounts modified since the preceding catchpoint file. 0 means that all the catchpoint
+	// files are complete.
+	catchpointFullFileInterval uint64
+
 	// vacuumOnStartup controls whether the accounts database would get vacuumed on startup.
 	vacuumOnStartup bool
 
 	// dynamic variables
 
-	// Connection to the database.
-	dbs db.Pair
+	// Connection to the tracker storage.
+	dbs trackerStore
 
-	// Prepared SQL statements for fast accounts DB lookups.
-	accountsq *accountsDbQueries
+	// Prepared queries for fast accounts DB lookups.
+	accountsq trackerQueries
 
 	// dbRound is always exactly accountsRound(),
 	// cached to avoid SQL queries.
@@ -326,6 +331,7 @@ func (au *accountUpdates) initialize(cfg config.Local, dbPathPrefix string, gene
 	if cfg.CatchpointFileHistoryLength < -1 {
 		au.catchpointFileHistoryLength = -1
 	}
+	au.catchpointFullFileInterval = cfg.CatchpointFullFileInterval
 	au.vacuumOnStartup = cfg.OptimizeAccountsDatabaseOnStartup
 	// initialize the commitSyncerClosed with a closed channel ( since the commitSyncer go-routine is not active )
 	au.commitSyncerClosed = make(chan struct{})
@@ -508,6 +514,159 @@ func (au *accountUpdates) listCreatables(maxCreatableIdx basics.CreatableIndex,
 	}
 }
 
+// ListAssetHolders lists the holders of the given asset by their addresses, following the after address, whose
+// holding amounts are within [minBalance, maxBalance], limiting to the first maxResults. It returns the round
+// the results are valid for.
+func (au *accountUpdates) ListAssetHolders(aidx basics.AssetIndex, after *basics.Address, minBalance, maxBalance uint64, maxResults uint64) ([]AssetHolder, basics.Round, error) {
+	au.accountsMu.RLock()
+	for {
+		currentDbRound := au.dbRound
+		currentDeltaLen := len(au.deltas)
+		// The accounts modified in memory supersede their database entries, so the ones whose database or
+		// in-memory data holds the asset are excluded from the database results, and their in-memory holdings
+		// are used instead. The accounts whose database data isn't cached are assumed to hold the asset.
+		excludedAccounts := make(map[basics.Address]bool)
+		var unsyncedHolders []AssetHolder
+		for addr, macct := range au.accounts {
+			if !addressAfter(addr, after) {
+				continue
+			}
+			holding, newHolds := heldAssetInRange(macct.data, aidx, minBalance, maxBalance)
+			if newHolds {
+				unsyncedHolders = append(unsyncedHolders, AssetHolder{Address: addr, Holding: holding})
+			}
+			base, cached := au.baseAccounts.read(addr)
+			_, oldHolds := heldAssetInRange(base.accountData, aidx, minBalance, maxBalance)
+			if newHolds || oldHolds || !cached {
+				excludedAccounts[addr] = true
+			}
+		}
+		au.accountsMu.RUnlock()
+
+		// Page through the database results until there are enough of them which aren't excluded.
+		res := unsyncedHolders
+		pageSize := maxResults
+		if pageSize == 0 {
+			// a page is still read for the round of the database.
+			pageSize = 1
+		}
+		var dbRound basics.Round
+		dbAfter := after
+		dbCount := uint64(0)
+		for {
+			var page []AssetHolder
+			var err error
+			page, dbRound, err = au.accountsq.listAssetHolders(aidx, dbAfter, minBalance, maxBalance, pageSize)
+			if err != nil {
+				return nil, 0, err
+			}
+			if dbRound != currentDbRound {
+				break
+			}
+			for _, holder := range page {
+				if !excludedAccounts[holder.Address] {
+					res = append(res, holder)
+					dbCount++
+				}
+			}
+			if uint64(len(page)) < pageSize || dbCount >= maxResults {
+				break
+			}
+			dbAfter = &page[len(page)-1].Address
+		}
+
+		if dbRound == currentDbRound {
+			sortAssetHolders(res)
+			if uint64(len(res)) > maxResults {
+				res = res[:maxResults]
+			}
+			return res, currentDbRound + basics.Round(currentDeltaLen), nil
+		}
+		if dbRound < currentDbRound {
+			au.log.Errorf("ListAssetHolders: database round %d is behind in-memory round %d", dbRound, currentDbRound)
+			return []AssetHolder{}, 0, &StaleDatabaseRoundError{databaseRound: dbRound, memoryRound: currentDbRound}
+		}
+		au.accountsMu.RLock()
+		for currentDbRound >= au.dbRound && currentDeltaLen == len(au.deltas) {
+			au.accountsReadCond.Wait()
+		}
+	}
+}
+
+// ListApplicationAccounts lists the addresses of the accounts opted into the given application, following the
+// after address, limiting to the first maxResults. It returns the round the results are valid for.
+func (au *accountUpdates) ListApplicationAccounts(appIdx basics.AppIndex, after *basics.Address, maxResults uint64) ([]basics.Address, basics.Round, error) {
+	au.accountsMu.RLock()
+	for {
+		currentDbRound := au.dbRound
+		currentDeltaLen := len(au.deltas)
+		// The accounts modified in memory supersede their database entries; see ListAssetHolders.
+		excludedAccounts := make(map[basics.Address]bool)
+		var unsyncedAccounts []basics.Address
+		for addr, macct := range au.accounts {
+			if !addressAfter(addr, after) {
+				continue
+			}
+			_, newOptedIn := macct.data.AppLocalStates[appIdx]
+			if newOptedIn {
+				unsyncedAccounts = append(unsyncedAccounts, addr)
+			}
+			base, cached := au.baseAccounts.read(addr)
+			_, oldOptedIn := base.accountData.AppLocalStates[appIdx]
+			if newOptedIn || oldOptedIn || !cached {
+				excludedAccounts[addr] = true
+			}
+		}
+		au.accountsMu.RUnlock()
+
+		res := unsyncedAccounts
+		pageSize := maxResults
+		if pageSize == 0 {
+			pageSize = 1
+		}
+		var dbRound basics.Round
+		dbAfter := after
+		dbCount := uint64(0)
+		for {
+			var page []basics.Address
+			var err error
+			page, dbRound, err = au.accountsq.listAppOptIns(appIdx, dbAfter, pageSize)
+			if err != nil {
+				return nil, 0, err
+			}
+			if dbRound != currentDbRound {
+				break
+			}
+			for _, addr := range page {
+				if !excludedAccounts[addr] {
+					res = append(res, addr)
+					dbCount++
+				}
+			}
+			if uint64(len(page)) < pageSize || dbCount >= maxResults {
+				break
+			}
+			dbAfter = &page[len(page)-1]
+		}
+
+		if dbRound == currentDbRound {
+			sortAddresses(res)
+			if uint64(len(res)) > maxResults {
+				res = res[:maxResults]
+			}
+			return res, currentDbRound + basics.Round(currentDeltaLen), nil
+		}
+		if dbRound < currentDbRound {
+			au.log.Errorf("ListApplicationAccounts: database round %d is behind in-memory round %d", dbRound, currentDbRound)
+			return []basics.Address{}, 0, &StaleDatabaseRoundError{databaseRound: dbRound, memoryRound: currentDbRound}
+		}
+		au.accountsMu.RLock()
+		for currentDbRound >= au.dbRound && currentDeltaLen == len(au.deltas) {
+			au.accountsReadCond.Wait()
+		}
+	}
+}
+
 // onlineTop returns the top n online accounts, sorted by their normalized
 // balance and address, whose voting keys are valid in voteRnd.  See the
 // normalization description in AccountData.NormalizedOnlineBalance().
@@ -566,12 +725,12 @@ func (au *accountUpdates) onlineTop(rnd basics.Round, voteRnd basics.Round, n ui
 			var accts map[basics.Address]*onlineAccount
 			start := time.Now()
 			ledgerAccountsonlinetopCount.Inc(nil)
-			err = au.dbs.Rdb.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
-				accts, err = accountsOnlineTop(tx, batchOffset, batchSize, proto)
+			err = au.dbs.snapshot(func(ctx context.Context, tx trackerStoreReader) (err error) {
+				accts, err = tx.accountsOnlineTop(batchOffset, batchSize, proto)
 				if err != nil {
 					return
 				}
-				dbRound, _, err = accountsRound(tx)
+				dbRound, _, err = tx.accountsRound()
 				return
 			})
 			ledgerAccountsonlinetopMicros.AddMicrosecondsSince(start, nil)
@@ -799,12 +958,15 @@ func (au *accountUpdates) Totals(rnd basics.Round) (totals ledgercore.AccountTot
 type ReadCloseSizer interface {
 	io.ReadCloser
 	Size() (int64, error)
+	// UncompressedSize returns the size of the uncompressed content of the stream, when known.
+	UncompressedSize() (int64, error)
 }
 
 // readCloseSizer is an instance of the ReadCloseSizer interface
 type readCloseSizer struct {
 	io.ReadCloser
-	size int64
+	size             int64
+	uncompressedSize int64
 }
 
 // Size returns the length of the associated stream.
@@ -815,14 +977,27 @@ func (r *readCloseSizer) Size() (int64, error) {
 	return r.size, nil
 }
 
+// UncompressedSize returns the length of the uncompressed content of the associated stream.
+func (r *readCloseSizer) UncompressedSize() (int64, error) {
+	if r.uncompressedSize <= 0 {
+		return 0, fmt.Errorf("unknown uncompressed stream size")
+	}
+	return r.uncompressedSize, nil
+}
+
 // GetCatchpointStream returns a ReadCloseSizer to the catchpoint file associated with the provided round
 func (au *accountUpdates) GetCatchpointStream(round basics.Round) (ReadCloseSizer, error) {
 	dbFileName := ""
 	fileSize := int64(0)
+	uncompressedSize := uint64(0)
 	start := time.Now()
 	ledgerGetcatchpointCount.Inc(nil)
-	err := au.dbs.Rdb.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
-		dbFileName, _, fileSize, err = getCatchpoint(tx, round)
+	err := au.dbs.snapshot(func(ctx context.Context, tx trackerStoreReader) (err error) {
+		dbFileName, _, fileSize, err = tx.getCatchpoint(round)
+		if err != nil {
+			return
+		}
+		uncompressedSize, _, err = tx.readCatchpointStateUint64(ctx, catchpointStateCatchpointUncompressedSize(round))
 		return
 	})
 	ledgerGetcatchpointMicros.AddMicrosecondsSince(start, nil)
@@ -834,13 +1009,13 @@ func (au *accountUpdates) GetCatchpointStream(round basics.Round) (ReadCloseSize
 		catchpointPath := filepath.Join(au.dbDirectory, dbFileName)
 		file, err := os.OpenFile(catchpointPath, os.O_RDONLY, 0666)
 		if err == nil && file != nil {
-			return &readCloseSizer{ReadCloser: file, size: fileSize}, nil
+			return &readCloseSizer{ReadCloser: file, size: fileSize, uncompressedSize: int64(uncompressedSize)}, nil
 		}
 		// else, see if this is a file-not-found error
 		if os.IsNotExist(err) {
 			// the database told us that we have this file.. but we couldn't find it.
 			// delete it from the database.
-			err := au.saveCatchpointFile(round, "", 0, "")
+			err := au.saveCatchpointFile(round, "", 0, 0, "")
 			if err != nil {
 				au.log.Warnf("accountUpdates: getCatchpointStream: unable to delete missing catchpoint entry: %v", err)
 				return nil, err
@@ -864,7 +1039,7 @@ func (au *accountUpdates) GetCatchpointStream(round basics.Round) (ReadCloseSize
 			return &readCloseSizer{ReadCloser: file, size: -1}, nil
 		}
 
-		err = au.saveCatchpointFile(round, fileName, fileInfo.Size(), "")
+		err = au.saveCatchpointFile(round, fileName, fileInfo.Size(), 0, "")
 		if err != nil {
 			au.log.Warnf("accountUpdates: getCatchpointStream: unable to save missing catchpoint entry: %v", err)
 		}
@@ -1011,7 +1186,7 @@ func (au *accountUpdates) initializeCaches(lastBalancesRound, lastestBlockRound,
 	defer func() {
 		if rollbackSynchronousMode {
 			// restore default synchronous mode
-			au.dbs.Wdb.SetSynchronousMode(context.Background(), au.synchronousMode, au.synchronousMode >= db.SynchronousModeFull)
+			au.dbs.setSynchronousMode(context.Background(), au.synchronousMode, au.synchronousMode >= db.SynchronousModeFull)
 		}
 	}()
 
@@ -1039,7 +1214,7 @@ func (au *accountUpdates) initializeCaches(lastBalancesRound, lastestBlockRound,
 
 			if !rollbackSynchronousMode {
 				// switch to rebuild synchronous mode to improve performance
-				au.dbs.Wdb.SetSynchronousMode(context.Background(), au.accountsRebuildSynchronousMode, au.accountsRebuildSynchronousMode >= db.SynchronousModeFull)
+				au.dbs.setSynchronousMode(context.Background(), au.accountsRebuildSynchronousMode, au.accountsRebuildSynchronousMode >= db.SynchronousModeFull)
 
 				// flip the switch to rollback the synchronous mode once we're done.
 				rollbackSynchronousMode = true
@@ -1117,7 +1292,7 @@ func (au *accountUpdates) initializeFromDisk(l ledgerForTracker) (lastBalancesRo
 	lastestBlockRound = l.Latest()
 	start := time.Now()
 	ledgerAccountsinitCount.Inc(nil)
-	err = au.dbs.Wdb.Atomic(func(ctx context.Context, tx *sql.Tx) error {
+	err = au.dbs.batch(func(ctx context.Context, tx trackerStoreWriter) error {
 		var err0 error
 		au.dbRound, err0 = au.accountsInitialize(ctx, tx)
 		if err0 != nil {
@@ -1126,7 +1301,7 @@ func (au *accountUpdates) initializeFromDisk(l ledgerForTracker) (lastBalancesRo
 		// Check for blocks DB and tracker DB un-sync
 		if au.dbRound > lastestBlockRound {
 			au.log.Warnf("accountUpdates.initializeFromDisk: resetting accounts DB (on round %v, but blocks DB's latest is %v)", au.dbRound, lastestBlockRound)
-			err0 = accountsReset(tx)
+			err0 = tx.accountsReset()
 			if err0 != nil {
 				return err0
 			}
@@ -1136,7 +1311,7 @@ func (au *accountUpdates) initializeFromDisk(l ledgerForTracker) (lastBalancesRo
 			}
 		}
 
-		totals, err0 := accountsTotals(tx, false)
+		totals, err0 := tx.accountsTotals(false)
 		if err0 != nil {
 			return err0
 		}
@@ -1156,12 +1331,26 @@ func (au *accountUpdates) initializeFromDisk(l ledgerForTracker) (lastBalancesRo
 		return
 	}
 
-	au.accountsq, err = accountsDbInit(au.dbs.Rdb.Handle, au.dbs.Wdb.Handle)
+	au.accountsq, err = au.dbs.queries()
+	if err != nil {
+		return
+	}
 	au.lastCatchpointLabel, _, err = au.accountsq.readCatchpointStateString(context.Background(), catchpointStateLastCatchpoint)
 	if err != nil {
 		return
 	}
 
+	if !au.catchpointDeltaTracking() {
+		// the modified accounts might not have been tracked since the last catchpoint file was written; make sure
+		// that the next catchpoint file would be a complete one.
+		err = au.dbs.batch(func(ctx context.Context, tx trackerStoreWriter) (err error) {
+			return au.resetCatchpointDeltaBase(ctx, tx, 0)
+		})
+		if err != nil {
+			return
+		}
+	}
+
 	hdr, err := l.BlockHdr(au.dbRound)
 	if err != nil {
 		return
@@ -1206,67 +1395,13 @@ func accountHashBuilder(addr basics.Address, accountData basics.AccountData, enc
 // accountsInitialize initializes the accounts DB if needed and return current account round.
 // as part of the initialization, it tests the current database schema version, and perform upgrade
 // procedures to bring it up to the database schema supported by the binary.
-func (au *accountUpdates) accountsInitialize(ctx context.Context, tx *sql.Tx) (basics.Round, error) {
-	// check current database version.
-	dbVersion, err := db.GetUserVersion(ctx, tx)
+func (au *accountUpdates) accountsInitialize(ctx context.Context, tx trackerStoreWriter) (basics.Round, error) {
+	err := tx.accountsUpgradeSchema(ctx, au)
 	if err != nil {
-		return 0, fmt.Errorf("accountsInitialize unable to read database schema version : %v", err)
-	}
-
-	// if database version is greater than supported by current binary, write a warning. This would keep the existing
-	// fallback behavior where we could use an older binary iff the schema happen to be backward compatible.
-	if dbVersion > accountDBVersion {
-		au.log.Warnf("accountsInitialize database schema version is %d, but algod supports only %d", dbVersion, accountDBVersion)
-	}
-
-	if dbVersion < accountDBVersion {
-		au.log.Infof("accountsInitialize upgrading database schema from version %d to version %d", dbVersion, accountDBVersion)
-		// newDatabase is determined during the tables creations. If we're filling the database with accounts,
-		// then we set this variable to true, allowing some of the upgrades to be skipped.
-		var newDatabase bool
-		for dbVersion < accountDBVersion {
-			au.log.Infof("accountsInitialize performing upgrade from version %d", dbVersion)
-			// perform the initialization/upgrade
-			switch dbVersion {
-			case 0:
-				dbVersion, newDatabase, err = au.upgradeDatabaseSchema0(ctx, tx)
-				if err != nil {
-					au.log.Warnf("accountsInitialize failed to upgrade accounts database (ledger.tracker.sqlite) from schema 0 : %v", err)
-					return 0, err
-				}
-			case 1:
-				dbVersion, err = au.upgradeDatabaseSchema1(ctx, tx, newDatabase)
-				if err != nil {
-					au.log.Warnf("accountsInitialize failed to upgrade accounts database (ledger.tracker.sqlite) from schema 1 : %v", err)
-					return 0, err
-				}
-			case 2:
-				dbVersion, err = au.upgradeDatabaseSchema2(ctx, tx, newDatabase)
-				if err != nil {
-					au.log.Warnf("accountsInitialize failed to upgrade accounts database (ledger.tracker.sqlite) from schema 2 : %v", err)
-					return 0, err
-				}
-			case 3:
-				dbVersion, err = au.upgradeDatabaseSchema3(ctx, tx, newDatabase)
-				if err != nil {
-					au.log.Warnf("accountsInitialize failed to upgrade accounts database (ledger.tracker.sqlite) from schema 3 : %v", err)
-					return 0, err
-				}
-			case 4:
-				dbVersion, err = au.upgradeDatabaseSchema4(ctx, tx, newDatabase)
-				if err != nil {
-					au.log.Warnf("accountsInitialize failed to upgrade accounts database (ledger.tracker.sqlite) from schema 4 : %v", err)
-					return 0, err
-				}
-			default:
-				return 0, fmt.Errorf("accountsInitialize unable to upgrade database from schema version %d", dbVersion)
-			}
-		}
-
-		au.log.Infof("accountsInitialize database schema upgrade complete")
+		return 0, err
 	}
 
-	rnd, hashRound, err := accountsRound(tx)
+	rnd, hashRound, err := tx.accountsRound()
 	if err != nil {
 		return 0, err
 	}
@@ -1274,7 +1409,7 @@ func (au *accountUpdates) accountsInitialize(ctx context.Context, tx *sql.Tx) (b
 	if hashRound != rnd {
 		// if the hashed round is different then the base round, something was modified, and the accounts aren't in sync
 		// with the hashes.
-		err = resetAccountHashes(tx)
+		err = tx.resetAccountHashes()
 		if err != nil {
 			return 0, err
 		}
@@ -1285,7 +1420,7 @@ func (au *accountUpdates) accountsInitialize(ctx context.Context, tx *sql.Tx) (b
 	}
 
 	// create the merkle trie for the balances
-	committer, err := MakeMerkleCommitter(tx, false)
+	committer, err := tx.merkleCommitter(false)
 	if err != nil {
 		return 0, fmt.Errorf("accountsInitialize was unable to makeMerkleCommitter: %v", err)
 	}
@@ -1304,7 +1439,7 @@ func (au *accountUpdates) accountsInitialize(ctx context.Context, tx *sql.Tx) (b
 
 	if rootHash.IsZero() {
 		au.log.Infof("accountsInitialize rebuilding merkle trie for round %d", rnd)
-		accountBuilderIt := makeOrderedAccountsIter(tx, trieRebuildAccountChunkSize)
+		accountBuilderIt := tx.makeOrderedAccountsIter(trieRebuildAccountChunkSize)
 		defer accountBuilderIt.Close(ctx)
 		startTrieBuildTime := time.Now()
 		accountsCount := 0
@@ -1367,7 +1502,7 @@ func (au *accountUpdates) accountsInitialize(ctx context.Context, tx *sql.Tx) (b
 		}
 
 		// we've just updated the merkle trie, update the hashRound to reflect that.
-		err = updateAccountsRound(tx, rnd, rnd)
+		err = tx.updateAccountsRound(rnd, rnd)
 		if err != nil {
 			return 0, fmt.Errorf("accountsInitialize was unable to update the account round to %d: %v", rnd, err)
 		}
@@ -1378,6 +1513,83 @@ func (au *accountUpdates) accountsInitialize(ctx context.Context, tx *sql.Tx) (b
 	return rnd, nil
 }
 
+// accountsUpgradeSQLiteSchema tests the current database schema version of the SQLite tracker database, and
+// performs the upgrade procedures to bring it up to the database schema supported by the binary.
+func (au *accountUpdates) accountsUpgradeSQLiteSchema(ctx context.Context, tx *sql.Tx) error {
+	// check current database version.
+	dbVersion, err := db.GetUserVersion(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("accountsInitialize unable to read database schema version : %v", err)
+	}
+
+	// if database version is greater than supported by current binary, write a warning. This would keep the existing
+	// fallback behavior where we could use an older binary iff the schema happen to be backward compatible.
+	if dbVersion > accountDBVersion {
+		au.log.Warnf("accountsInitialize database schema version is %d, but algod supports only %d", dbVersion, accountDBVersion)
+	}
+
+	if dbVersion < accountDBVersion {
+		au.log.Infof("accountsInitialize upgrading database schema from version %d to version %d", dbVersion, accountDBVersion)
+		// newDatabase is determined during the tables creations. If we're filling the database with accounts,
+		// then we set this variable to true, allowing some of the upgrades to be skipped.
+		var newDatabase bool
+		for dbVersion < accountDBVersion {
+			au.log.Infof("accountsInitialize performing upgrade from version %d", dbVersion)
+			// perform the initialization/upgrade
+			switch dbVersion {
+			case 0:
+				dbVersion, newDatabase, err = au.upgradeDatabaseSchema0(ctx, tx)
+				if err != nil {
+					au.log.Warnf("accountsInitialize failed to upgrade accounts database (ledger.tracker.sqlite) from schema 0 : %v", err)
+					return err
+				}
+			case 1:
+				dbVersion, err = au.upgradeDatabaseSchema1(ctx, tx, newDatabase)
+				if err != nil {
+					au.log.Warnf("accountsInitialize failed to upgrade accounts database (ledger.tracker.sqlite) from schema 1 : %v", err)
+					return err
+				}
+			case 2:
+				dbVersion, err = au.upgradeDatabaseSchema2(ctx, tx, newDatabase)
+				if err != nil {
+					au.log.Warnf("accountsInitialize failed to upgrade accounts database (ledger.tracker.sqlite) from schema 2 : %v", err)
+					return err
+				}
+			case 3:
+				dbVersion, err = au.upgradeDatabaseSchema3(ctx, tx, newDatabase)
+				if err != nil {
+					au.log.Warnf("accountsInitialize failed to upgrade accounts database (ledger.tracker.sqlite) from schema 3 : %v", err)
+					return err
+				}
+			case 4:
+				dbVersion, err = au.upgradeDatabaseSchema4(ctx, tx, newDatabase)
+				if err != nil {
+					au.log.Warnf("accountsInitialize failed to upgrade accounts database (ledger.tracker.sqlite) from schema 4 : %v", err)
+					return err
+				}
+			case 5:
+				dbVersion, err = au.upgradeDatabaseSchema5(ctx, tx, newDatabase)
+				if err != nil {
+					au.log.Warnf("accountsInitialize failed to upgrade accounts database (ledger.tracker.sqlite) from schema 5 : %v", err)
+					return err
+				}
+			case 6:
+				dbVersion, err = au.upgradeDatabaseSchema6(ctx, tx, newDatabase)
+				if err != nil {
+					au.log.Warnf("accountsInitialize failed to upgrade accounts database (ledger.tracker.sqlite) from schema 6 : %v", err)
+					return err
+				}
+			default:
+				return fmt.Errorf("accountsInitialize unable to upgrade database from schema version %d", dbVersion)
+			}
+		}
+
+		au.log.Infof("accountsInitialize database schema upgrade complete")
+	}
+
+	return nil
+}
+
 // upgradeDatabaseSchema0 upgrades the database schema from version 0 to version 1
 //
 // Schema of version 0 is expected to be aligned with the schema used on version 2.0.8 or before.
@@ -1508,7 +1720,7 @@ func (au *accountUpdates) upgradeDatabaseSchema2(ctx context.Context, tx *sql.Tx
 // upgradeDatabaseSchema3 upgrades the database schema from version 3 to version 4,
 // adding the normalizedonlinebalance column to the accountbase table.
 func (au *accountUpdates) upgradeDatabaseSchema3(ctx context.Context, tx *sql.Tx, newDatabase bool) (updatedDBVersion int32, err error) {
-	err = accountsAddNormalizedBalance(tx, au.ledger.GenesisProto())
+	err = accountsAddNormalizedBalance(tx, au.initProto)
 	if err != nil {
 		return 0, err
 	}
@@ -1584,9 +1796,42 @@ done:
 	return 5, nil
 }
 
+// upgradeDatabaseSchema5 upgrades the database schema from version 5 to version 6,
+// adding the catchpointdeltaaccounts table used for writing the catchpoint delta files.
+func (au *accountUpdates) upgradeDatabaseSchema5(ctx context.Context, tx *sql.Tx, newDatabase bool) (updatedDBVersion int32, err error) {
+	err = accountsCreateCatchpointDeltaAccounts(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	// update version
+	_, err = db.SetUserVersion(ctx, tx, 6)
+	if err != nil {
+		return 0, fmt.Errorf("accountsInitialize unable to update database schema version from 5 to 6: %v", err)
+	}
+	return 6, nil
+}
+
+// upgradeDatabaseSchema6 upgrades the database schema from version 6 to version 7,
+// adding the assetholders and appoptins tables, which index the asset holdings and the
+// application opt-ins of the accounts.
+func (au *accountUpdates) upgradeDatabaseSchema6(ctx context.Context, tx *sql.Tx, newDatabase bool) (updatedDBVersion int32, err error) {
+	err = accountsCreateHoldingsIndex(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	// update version
+	_, err = db.SetUserVersion(ctx, tx, 7)
+	if err != nil {
+		return 0, fmt.Errorf("accountsInitialize unable to update database schema version from 6 to 7: %v", err)
+	}
+	return 7, nil
+}
+
 // deleteStoredCatchpoints iterates over the storedcatchpoints table and deletes all the files stored on disk.
 // once all the files have been deleted, it would go ahead and remove the entries from the table.
-func (au *accountUpdates) deleteStoredCatchpoints(ctx context.Context, dbQueries *accountsDbQueries) (err error) {
+func (au *accountUpdates) deleteStoredCatchpoints(ctx context.Context, dbQueries trackerQueries) (err error) {
 	catchpointsFilesChunkSize := 50
 	for {
 		fileNames, err := dbQueries.getOldestCatchpointFiles(ctx, catchpointsFilesChunkSize, 0)
@@ -1612,6 +1857,10 @@ func (au *accountUpdates) deleteStoredCatchpoints(ctx context.Context, dbQueries
 			if err != nil {
 				return err
 			}
+			_, err = dbQueries.writeCatchpointStateUint64(ctx, catchpointStateCatchpointUncompressedSize(round), 0)
+			if err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -2159,10 +2408,10 @@ func (au *accountUpdates) commitRound(offset uint64, dbRound basics.Round, lookb
 	if updateStats {
 		stats.DatabaseCommitDuration = time.Duration(time.Now().UnixNano())
 	}
-	err := au.dbs.Wdb.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
+	err := au.dbs.batch(func(ctx context.Context, tx trackerStoreWriter) (err error) {
 		treeTargetRound := basics.Round(0)
 		if au.catchpointInterval > 0 {
-			mc, err0 := MakeMerkleCommitter(tx, false)
+			mc, err0 := tx.merkleCommitter(false)
 			if err0 != nil {
 				return err0
 			}
@@ -2179,13 +2428,13 @@ func (au *accountUpdates) commitRound(offset uint64, dbRound basics.Round, lookb
 			treeTargetRound = dbRound + basics.Round(offset)
 		}
 
-		db.ResetTransactionWarnDeadline(ctx, tx, time.Now().Add(accountsUpdatePerRoundHighWatermark*time.Duration(offset)))
+		tx.resetTransactionWarnDeadline(ctx, time.Now().Add(accountsUpdatePerRoundHighWatermark*time.Duration(offset)))
 
 		if updateStats {
 			stats.OldAccountPreloadDuration = time.Duration(time.Now().UnixNano())
 		}
 
-		err = compactDeltas.accountsLoadOld(tx)
+		err = tx.accountsLoadOld(&compactDeltas)
 		if err != nil {
 			return err
 		}
@@ -2194,7 +2443,7 @@ func (au *accountUpdates) commitRound(offset uint64, dbRound basics.Round, lookb
 			stats.OldAccountPreloadDuration = time.Duration(time.Now().UnixNano()) - stats.OldAccountPreloadDuration
 		}
 
-		err = totalsNewRounds(tx, deltas[:offset], compactDeltas, roundTotals[1:offset+1], config.Consensus[consensusVersion])
+		err = tx.totalsNewRounds(deltas[:offset], compactDeltas, roundTotals[1:offset+1], config.Consensus[consensusVersion])
 		if err != nil {
 			return err
 		}
@@ -2216,16 +2465,23 @@ func (au *accountUpdates) commitRound(offset uint64, dbRound basics.Round, lookb
 
 		// the updates of the actual account data is done last since the accountsNewRound would modify the compactDeltas old values
 		// so that we can update the base account back.
-		updatedPersistedAccounts, err = accountsNewRound(tx, compactDeltas, compactCreatableDeltas, genesisProto, dbRound+basics.Round(offset))
+		updatedPersistedAccounts, err = tx.accountsNewRound(compactDeltas, compactCreatableDeltas, genesisProto, dbRound+basics.Round(offset))
 		if err != nil {
 			return err
 		}
 
+		if au.catchpointDeltaTracking() {
+			err = tx.catchpointDeltaAddAccounts(ctx, compactDeltas.addresses)
+			if err != nil {
+				return err
+			}
+		}
+
 		if updateStats {
 			stats.AccountsWritingDuration = time.Duration(time.Now().UnixNano()) - stats.AccountsWritingDuration
 		}
 
-		err = updateAccountsRound(tx, dbRound+basics.Round(offset), treeTargetRound)
+		err = tx.updateAccountsRound(dbRound+basics.Round(offset), treeTargetRound)
 		if err != nil {
 			return err
 		}
@@ -2429,8 +2685,16 @@ func (au *accountUpdates) generateCatchpoint(committedRound basics.Round, label
 	var catchpointWriter *catchpointWriter
 	start := time.Now()
 	ledgerGeneratecatchpointCount.Inc(nil)
-	err = au.dbs.Rdb.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
-		catchpointWriter = makeCatchpointWriter(au.ctx, absCatchpointFileName, tx, committedRound, committedRoundDigest, label)
+	err = au.dbs.snapshot(func(ctx context.Context, tx trackerStoreReader) (err error) {
+		baseRound, baseLabel, err := au.catchpointDeltaBase(ctx, tx, committedRound)
+		if err != nil {
+			return err
+		}
+		if baseRound != 0 {
+			catchpointWriter = makeCatchpointDeltaWriter(au.ctx, absCatchpointFileName, tx, committedRound, committedRoundDigest, label, baseRound, baseLabel)
+		} else {
+			catchpointWriter = makeCatchpointWriter(au.ctx, absCatchpointFileName, tx, committedRound, committedRoundDigest, label)
+		}
 		for more {
 			stepCtx, stepCancelFunction := context.WithTimeout(au.ctx, chunkExecutionDuration)
 			writeStepStartTime := time.Now()
@@ -2442,7 +2706,7 @@ func (au *accountUpdates) generateCatchpoint(committedRound basics.Round, label
 				// we just wrote some data, but there is more to be written.
 				// go to sleep for while.
 				// before going to sleep, extend the transaction timeout so that we won't get warnings:
-				db.ResetTransactionWarnDeadline(ctx, tx, time.Now().Add(1*time.Second))
+				tx.resetTransactionWarnDeadline(ctx, time.Now().Add(1*time.Second))
 				select {
 				case <-time.After(100 * time.Millisecond):
 					// increase the time slot allocated for writing the catchpoint, but stop when we get to the longChunkExecutionDuration limit.
@@ -2484,11 +2748,20 @@ func (au *accountUpdates) generateCatchpoint(committedRound basics.Round, label
 		return
 	}
 
-	err = au.saveCatchpointFile(committedRound, relCatchpointFileName, catchpointWriter.GetSize(), catchpointWriter.GetCatchpoint())
+	err = au.saveCatchpointFile(committedRound, relCatchpointFileName, catchpointWriter.GetSize(), catchpointWriter.GetUncompressedSize(), catchpointWriter.GetCatchpoint())
 	if err != nil {
 		au.log.Warnf("accountUpdates: generateCatchpoint: unable to save catchpoint: %v", err)
 		return
 	}
+	if au.catchpointDeltaTracking() {
+		// the following catchpoint files would be written relative to this one.
+		err = au.dbs.batch(func(ctx context.Context, tx trackerStoreWriter) (err error) {
+			return au.resetCatchpointDeltaBase(ctx, tx, committedRound)
+		})
+		if err != nil {
+			au.log.Warnf("accountUpdates: generateCatchpoint: unable to reset the catchpoint delta accounts: %v", err)
+		}
+	}
 	catchpointGenerationStats.FileSize = uint64(catchpointWriter.GetSize())
 	catchpointGenerationStats.WritingDuration = uint64(time.Now().Sub(beforeGeneratingCatchpointTime).Nanoseconds())
 	catchpointGenerationStats.AccountsCount = catchpointWriter.GetTotalAccounts()
@@ -2500,9 +2773,48 @@ func (au *accountUpdates) generateCatchpoint(committedRound basics.Round, label
 		With("accountsCount", catchpointGenerationStats.AccountsCount).
 		With("fileSize", catchpointGenerationStats.FileSize).
 		With("catchpointLabel", catchpointGenerationStats.CatchpointLabel).
+		With("baseRound", catchpointWriter.GetBaseRound()).
 		Infof("Catchpoint file was generated")
 }
 
+// catchpointDeltaTracking returns true if the accounts modified between catchpoints are being tracked, so that
+// catchpoint delta files could be written.
+func (au *accountUpdates) catchpointDeltaTracking() bool {
+	return au.catchpointInterval > 0 && au.archivalLedger && au.catchpointFullFileInterval > 0
+}
+
+// catchpointDeltaBase returns the round and label of the catchpoint which the catchpoint file of the given round
+// would be written relative to. A zero round is returned when a complete catchpoint file needs to be written.
+func (au *accountUpdates) catchpointDeltaBase(ctx context.Context, tx trackerStoreReader, committedRound basics.Round) (baseRound basics.Round, baseLabel string, err error) {
+	if !au.catchpointDeltaTracking() || (uint64(committedRound)/au.catchpointInterval)%au.catchpointFullFileInterval == 0 {
+		return 0, "", nil
+	}
+	rnd, _, err := tx.readCatchpointStateUint64(ctx, catchpointStateDeltaBaseRound)
+	if err != nil || rnd == 0 || basics.Round(rnd) >= committedRound {
+		return 0, "", err
+	}
+	_, baseLabel, _, err = tx.getCatchpoint(basics.Round(rnd))
+	if err == sql.ErrNoRows {
+		// the base catchpoint file is no longer available.
+		return 0, "", nil
+	}
+	if err != nil {
+		return 0, "", err
+	}
+	return basics.Round(rnd), baseLabel, nil
+}
+
+// resetCatchpointDeltaBase clears the modified accounts, and sets the round of the catchpoint which they would be
+// tracked relative to. A zero round disables writing catchpoint delta files until the next complete catchpoint file.
+func (au *accountUpdates) resetCatchpointDeltaBase(ctx context.Context, tx trackerStoreWriter, baseRound basics.Round) error {
+	err := tx.catchpointDeltaReset(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.writeCatchpointStateUint64(ctx, catchpointStateDeltaBaseRound, uint64(baseRound))
+	return err
+}
+
 // catchpointRoundToPath calculate the catchpoint file path for a given round
 func catchpointRoundToPath(rnd basics.Round) string {
 	irnd := int64(rnd) / 256
@@ -2515,17 +2827,22 @@ func catchpointRoundToPath(rnd basics.Round) string {
 	return outStr
 }
 
-// saveCatchpointFile stores the provided fileName as the stored catchpoint for the given round.
-// after a successful insert operation to the database, it would delete up to 2 old entries, as needed.
+// saveCatchpointFile stores the provided fileName as the stored catchpoint for the given round, along with the size of its
+// uncompressed content, which is zero when unknown. after a successful insert operation to the database, it would delete up to 2 old entries, as needed.
 // deleting 2 entries while inserting single entry allow us to adjust the size of the backing storage and have the
 // database and storage realign.
-func (au *accountUpdates) saveCatchpointFile(round basics.Round, fileName string, fileSize int64, catchpoint string) (err error) {
+func (au *accountUpdates) saveCatchpointFile(round basics.Round, fileName string, fileSize int64, uncompressedSize int64, catchpoint string) (err error) {
 	if au.catchpointFileHistoryLength != 0 {
 		err = au.accountsq.storeCatchpoint(context.Background(), round, fileName, catchpoint, fileSize)
 		if err != nil {
 			au.log.Warnf("accountUpdates: saveCatchpoint: unable to save catchpoint: %v", err)
 			return
 		}
+		_, err = au.accountsq.writeCatchpointStateUint64(context.Background(), catchpointStateCatchpointUncompressedSize(round), uint64(uncompressedSize))
+		if err != nil {
+			au.log.Warnf("accountUpdates: saveCatchpoint: unable to save catchpoint uncompressed size: %v", err)
+			return
+		}
 	} else {
 		err = os.Remove(fileName)
 		if err != nil {
@@ -2555,6 +2872,10 @@ func (au *accountUpdates) saveCatchpointFile(round basics.Round, fileName string
 		if err != nil {
 			return fmt.Errorf("unable to delete old catchpoint entry '%s' : %v", fileToDelete, err)
 		}
+		_, err = au.accountsq.writeCatchpointStateUint64(context.Background(), catchpointStateCatchpointUncompressedSize(round), 0)
+		if err != nil {
+			return fmt.Errorf("unable to delete old catchpoint entry '%s' uncompressed size : %v", fileToDelete, err)
+		}
 	}
 	return
 }
@@ -2589,7 +2910,7 @@ func (au *accountUpdates) vacuumDatabase(ctx context.Context) (err error) {
 	}()
 
 	ledgerVacuumCount.Inc(nil)
-	vacuumStats, err := au.dbs.Wdb.Vacuum(ctx)
+	vacuumStats, err := au.dbs.vacuum(ctx)
 	close(vacuumExitCh)
 	vacuumLoggingAbort.Wait()
 
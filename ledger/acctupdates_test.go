@@ -17,12 +17,16 @@
 package ledger
 
 import (
+	"archive/tar"
 	"bytes"
+	"compress/gzip"
 	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"math"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -154,12 +158,12 @@ func (ml *mockLedgerForTracker) BlockHdr(rnd basics.Round) (bookkeeping.BlockHea
 	return ml.blocks[int(rnd)].block.BlockHeader, nil
 }
 
-func (ml *mockLedgerForTracker) trackerDB() db.Pair {
-	return ml.dbs
+func (ml *mockLedgerForTracker) trackerDB() trackerStore {
+	return makeSQLiteTrackerStore(ml.dbs)
 }
 
-func (ml *mockLedgerForTracker) blockDB() db.Pair {
-	return db.Pair{}
+func (ml *mockLedgerForTracker) blockDB() blockStore {
+	return makeSQLiteBlockStore(db.Pair{})
 }
 
 func (ml *mockLedgerForTracker) trackerLog() logging.Logger {
@@ -190,10 +194,27 @@ func (au *accountUpdates) allBalances(rnd basics.Round) (bals map[basics.Address
 		return
 	}
 
-	err = au.dbs.Rdb.Atomic(func(ctx context.Context, tx *sql.Tx) error {
-		var err0 error
-		bals, err0 = accountsAll(tx)
-		return err0
+	err = au.dbs.snapshot(func(ctx context.Context, tx trackerStoreReader) error {
+		bals = make(map[basics.Address]basics.AccountData)
+		it := tx.makeEncodedAccountsIter()
+		defer it.Close()
+		for {
+			records, err0 := it.Next(ctx, BalancesPerCatchpointFileChunk)
+			if err0 != nil {
+				return err0
+			}
+			for _, record := range records {
+				var data basics.AccountData
+				err0 = protocol.Decode(record.AccountData, &data)
+				if err0 != nil {
+					return err0
+				}
+				bals[record.Address] = data
+			}
+			if len(records) < BalancesPerCatchpointFileChunk {
+				return nil
+			}
+		}
 	})
 	if err != nil {
 		return
@@ -1153,6 +1174,14 @@ func TestGetCatchpointStream(t *testing.T) {
 	len, err := reader.Size()
 	require.NoError(t, err)
 	require.Equal(t, int64(3), len)
+	_, err = reader.UncompressedSize()
+	require.Error(t, err)
+	require.NoError(t, au.saveCatchpointFile(basics.Round(1), filepath.Join("catchpoints", "1.catchpoint"), 3, 30, ""))
+	reader, err = au.GetCatchpointStream(basics.Round(1))
+	require.NoError(t, err)
+	uncompressedSize, err := reader.UncompressedSize()
+	require.NoError(t, err)
+	require.Equal(t, int64(30), uncompressedSize)
 
 	// File deleted, but record in the database
 	err = os.Remove(filepath.Join(temporaryDirectroy, "catchpoints", "2.catchpoint"))
@@ -1546,6 +1575,190 @@ func TestReproducibleCatchpointLabels(t *testing.T) {
 	}
 }
 
+// stageCatchpointFile feeds the content of the given catchpoint file into the catchpoint catchup staging tables.
+func stageCatchpointFile(t *testing.T, accessor CatchpointCatchupAccessor, fileName string) error {
+	fileContent, err := ioutil.ReadFile(fileName)
+	require.NoError(t, err)
+	gzipReader, err := gzip.NewReader(bytes.NewBuffer(fileContent))
+	require.NoError(t, err)
+	defer gzipReader.Close()
+	tarReader := tar.NewReader(gzipReader)
+	var progress CatchpointCatchupAccessorProgress
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		require.NoError(t, err)
+		section := make([]byte, header.Size)
+		_, err = io.ReadFull(tarReader, section)
+		require.NoError(t, err)
+		err = accessor.ProgressStagingBalances(context.Background(), header.Name, section, &progress)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// TestCatchpointDeltaFiles tests that the catchpoint files in between the complete catchpoint files contain only the
+// modified accounts, and that applying them on top of their base catchpoint yields the balances of their catchpoint.
+func TestCatchpointDeltaFiles(t *testing.T) {
+	// create new protocol version, which has lower lookback
+	testProtocolVersion := protocol.ConsensusVersion("test-protocol-TestCatchpointDeltaFiles")
+	protoParams := config.Consensus[protocol.ConsensusCurrentVersion]
+	protoParams.MaxBalLookback = 32
+	protoParams.SeedLookback = 2
+	protoParams.SeedRefreshInterval = 8
+	config.Consensus[testProtocolVersion] = protoParams
+	temporaryDirectroy, err := ioutil.TempDir(os.TempDir(), "catchpoints")
+	require.NoError(t, err)
+	defer func() {
+		delete(config.Consensus, testProtocolVersion)
+		os.RemoveAll(temporaryDirectroy)
+	}()
+
+	ml := makeMockLedgerForTracker(t, false, 1, testProtocolVersion)
+	defer ml.Close()
+
+	accts := []map[basics.Address]basics.AccountData{randomAccounts(BalancesPerCatchpointFileChunk+20, true)}
+	pooldata := basics.AccountData{}
+	pooldata.MicroAlgos.Raw = 100 * 1000 * 1000 * 1000 * 1000
+	pooldata.Status = basics.NotParticipating
+	accts[0][testPoolAddr] = pooldata
+
+	sinkdata := basics.AccountData{}
+	sinkdata.MicroAlgos.Raw = 1000 * 1000 * 1000 * 1000
+	sinkdata.Status = basics.NotParticipating
+	accts[0][testSinkAddr] = sinkdata
+
+	au := &accountUpdates{}
+	cfg := config.GetDefaultLocal()
+	cfg.CatchpointInterval = 50
+	cfg.CatchpointTracking = 1
+	cfg.CatchpointFullFileInterval = 3
+	cfg.Archival = true
+	au.initialize(cfg, filepath.Join(temporaryDirectroy, "ledger"), protoParams, accts[0])
+	defer au.close()
+	require.NoError(t, au.loadFromDisk(ml))
+
+	rewardLevel := uint64(0)
+	lastCreatableID := crypto.RandUint64() % 512
+	knownCreatables := make(map[basics.CreatableIndex]bool)
+	for i := basics.Round(1); i <= basics.Round(4*cfg.CatchpointInterval); i++ {
+		rewardLevelDelta := crypto.RandUint64() % 5
+		rewardLevel += rewardLevelDelta
+		base := accts[i-1]
+		updates, totals, newLastCreatableID := randomDeltasBalancedFull(1, base, rewardLevel, lastCreatableID)
+		lastCreatableID = newLastCreatableID
+		prevTotals, err := au.Totals(basics.Round(i - 1))
+		require.NoError(t, err)
+
+		newPool := totals[testPoolAddr]
+		newPool.MicroAlgos.Raw -= prevTotals.RewardUnits() * rewardLevelDelta
+		updates.Upsert(testPoolAddr, newPool)
+		totals[testPoolAddr] = newPool
+
+		blk := bookkeeping.Block{
+			BlockHeader: bookkeeping.BlockHeader{
+				Round: basics.Round(i),
+			},
+		}
+		blk.RewardsLevel = rewardLevel
+		blk.CurrentProtocol = testProtocolVersion
+		delta := ledgercore.MakeStateDelta(&blk.BlockHeader, 0, updates.Len(), 0)
+		delta.Accts.MergeAccounts(updates)
+		delta.Creatables = creatablesFromUpdates(base, updates, knownCreatables)
+		au.newBlock(blk, delta)
+		au.committedUpTo(i)
+		ml.addMockBlock(blockEntry{block: blk}, delta)
+		accts = append(accts, totals)
+		if uint64(i)%cfg.CatchpointInterval == 0 {
+			au.waitAccountsWriting()
+		}
+	}
+
+	// every third catchpoint file is a complete one, and the ones in between are relative to their preceding catchpoint.
+	expectedBaseRounds := map[basics.Round]basics.Round{50: 0, 100: 50, 150: 0, 200: 150}
+	catchpointFiles := make(map[basics.Round]string)
+	catchpointLabels := make(map[basics.Round]string)
+	for rnd := basics.Round(50); rnd <= 200; rnd += 50 {
+		expectedBaseRound := expectedBaseRounds[rnd]
+		err := au.dbs.snapshot(func(ctx context.Context, tx trackerStoreReader) (err error) {
+			var fileName string
+			fileName, catchpointLabels[rnd], _, err = tx.getCatchpoint(rnd)
+			catchpointFiles[rnd] = filepath.Join(au.dbDirectory, fileName)
+			return
+		})
+		require.NoError(t, err)
+
+		fileContent, err := ioutil.ReadFile(catchpointFiles[rnd])
+		require.NoError(t, err)
+		gzipReader, err := gzip.NewReader(bytes.NewBuffer(fileContent))
+		require.NoError(t, err)
+		tarReader := tar.NewReader(gzipReader)
+		_, err = tarReader.Next()
+		require.NoError(t, err)
+		headerBytes, err := ioutil.ReadAll(tarReader)
+		require.NoError(t, err)
+		var fileHeader CatchpointFileHeader
+		require.NoError(t, protocol.Decode(headerBytes, &fileHeader))
+		gzipReader.Close()
+
+		require.Equal(t, expectedBaseRound, fileHeader.BaseRound)
+		require.Equal(t, catchpointLabels[rnd], fileHeader.Catchpoint)
+		accountsCount := uint64(0)
+		for _, acct := range accts[rnd-basics.Round(protoParams.MaxBalLookback)] {
+			if !acct.IsZero() {
+				accountsCount++
+			}
+		}
+		if expectedBaseRound == 0 {
+			require.Equal(t, catchpointFileVersion, fileHeader.Version)
+			require.Equal(t, accountsCount, fileHeader.TotalAccounts)
+		} else {
+			require.Equal(t, catchpointDeltaFileVersion, fileHeader.Version)
+			require.Equal(t, catchpointLabels[expectedBaseRound], fileHeader.BaseCatchpoint)
+			require.Less(t, fileHeader.TotalAccounts, accountsCount)
+		}
+	}
+
+	var initState InitState
+	initState.Block.CurrentProtocol = protocol.ConsensusCurrentVersion
+	l, err := OpenLedger(ml.log, t.Name(), true, initState, cfg)
+	require.NoError(t, err)
+	defer l.Close()
+	accessor := MakeCatchpointCatchupAccessor(l, l.log)
+
+	// a delta can't be applied on top of a catchpoint other than its base.
+	require.NoError(t, accessor.ResetStagingBalances(context.Background(), true))
+	require.NoError(t, stageCatchpointFile(t, accessor, catchpointFiles[50]))
+	require.NoError(t, accessor.BuildMerkleTrie(context.Background(), nil))
+	require.Error(t, stageCatchpointFile(t, accessor, catchpointFiles[200]))
+	require.NoError(t, stageCatchpointFile(t, accessor, catchpointFiles[100]))
+	require.Error(t, stageCatchpointFile(t, accessor, catchpointFiles[200]))
+
+	require.NoError(t, accessor.ResetStagingBalances(context.Background(), true))
+	require.NoError(t, stageCatchpointFile(t, accessor, catchpointFiles[150]))
+	require.NoError(t, accessor.BuildMerkleTrie(context.Background(), nil))
+	require.NoError(t, stageCatchpointFile(t, accessor, catchpointFiles[200]))
+	blockRound, err := accessor.GetCatchupBlockRound(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, basics.Round(200), blockRound)
+
+	err = l.trackerDBs.batch(func(ctx context.Context, tx trackerStoreWriter) error {
+		return tx.applyCatchpointStagingBalances(ctx, 0)
+	})
+	require.NoError(t, err)
+
+	// the staged balances should match the balances of the catchpoint round.
+	balancesRound := 200 - basics.Round(protoParams.MaxBalLookback)
+	for addr, acct := range accts[balancesRound] {
+		acctData, _, err := l.LookupWithoutRewards(0, addr)
+		require.NoError(t, err)
+		require.Equal(t, acct, acctData)
+	}
+}
+
 // TestCachesInitialization test the functionality of the initializeCaches cache.
 func TestCachesInitialization(t *testing.T) {
 	protocolVersion := protocol.ConsensusCurrentVersion
@@ -1920,3 +2133,94 @@ func TestConsecutiveVersion(t *testing.T) {
 		protocol.ConsensusV21,
 	}
 }
+
+func TestListAssetHolders(t *testing.T) {
+	dbs, _ := dbOpenTest(t, true)
+	setDbLogging(t, dbs)
+	defer dbs.Close()
+
+	tx, err := dbs.Wdb.Handle.Begin()
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	proto := config.Consensus[protocol.ConsensusCurrentVersion]
+
+	accts := randomHoldingsAccounts(20)
+	// accountsInit indexes the holdings of the initial accounts.
+	_, err = accountsInit(tx, accts, proto)
+	require.NoError(t, err)
+
+	au := &accountUpdates{}
+	au.accountsq, err = accountsDbInit(tx, tx)
+	require.NoError(t, err)
+	au.accounts = make(map[basics.Address]modifiedAccount)
+	au.baseAccounts.init(nil, 100, 80)
+
+	// modify some of the accounts in memory only, so that the results would merge the database and the in-memory holdings.
+	// the database data of half of them is cached, so that only the ones holding the asset are excluded from the database results.
+	i := 0
+	for addr, data := range accts {
+		if i%8 < 4 {
+			au.baseAccounts.write(persistedAccountData{addr: addr, accountData: data})
+		}
+		switch i % 4 {
+		case 0:
+			data.Assets = nil
+			data.AppLocalStates = nil
+		case 1:
+			data.Assets = map[basics.AssetIndex]basics.AssetHolding{1: {Amount: 12}}
+			data.AppLocalStates = map[basics.AppIndex]basics.AppLocalState{7: {}}
+		}
+		if i%4 < 2 {
+			au.accounts[addr] = modifiedAccount{data: data, ndeltas: 1}
+			accts[addr] = data
+		}
+		i++
+	}
+	for j := 0; j < 3; j++ {
+		data := basics.AccountData{
+			Assets:         map[basics.AssetIndex]basics.AssetHolding{1: {Amount: uint64(j)}},
+			AppLocalStates: map[basics.AppIndex]basics.AppLocalState{7: {}},
+		}
+		addr := randomAddress()
+		au.accounts[addr] = modifiedAccount{data: data, ndeltas: 1}
+		accts[addr] = data
+		if j == 0 {
+			// a new account has no database data.
+			au.baseAccounts.write(persistedAccountData{addr: addr})
+		}
+	}
+
+	for _, pageSize := range []uint64{1, 3, 100} {
+		var holders []AssetHolder
+		var after *basics.Address
+		for {
+			page, _, err := au.ListAssetHolders(1, after, 0, math.MaxUint64, pageSize)
+			require.NoError(t, err)
+			holders = append(holders, page...)
+			if uint64(len(page)) < pageSize {
+				break
+			}
+			after = &page[len(page)-1].Address
+		}
+		require.Equal(t, expectedAssetHolders(accts, 1, 0, math.MaxUint64), holders)
+
+		var optedIn []basics.Address
+		after = nil
+		for {
+			page, _, err := au.ListApplicationAccounts(7, after, pageSize)
+			require.NoError(t, err)
+			optedIn = append(optedIn, page...)
+			if uint64(len(page)) < pageSize {
+				break
+			}
+			after = &page[len(page)-1]
+		}
+		require.Equal(t, expectedAppAccounts(accts, 7), optedIn)
+	}
+
+	holders, rnd, err := au.ListAssetHolders(1, nil, 10, 30, 100)
+	require.NoError(t, err)
+	require.Equal(t, expectedAssetHolders(accts, 1, 10, 30), holders)
+	require.Equal(t, basics.Round(0), rnd)
+}
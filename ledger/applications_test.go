@@ -515,11 +515,12 @@ return`
 	var rowid int64
 	var dbRound basics.Round
 	var buf []byte
-	err = l.accts.accountsq.lookupStmt.QueryRow(creator[:]).Scan(&rowid, &dbRound, &buf)
+	accountsq := l.accts.accountsq.(*accountsDbQueries)
+	err = accountsq.lookupStmt.QueryRow(creator[:]).Scan(&rowid, &dbRound, &buf)
 	a.NoError(err)
 	a.Equal(expectedCreator, buf)
 
-	err = l.accts.accountsq.lookupStmt.QueryRow(userOptin[:]).Scan(&rowid, &dbRound, &buf)
+	err = accountsq.lookupStmt.QueryRow(userOptin[:]).Scan(&rowid, &dbRound, &buf)
 	a.NoError(err)
 	a.Equal(expectedUserOptIn, buf)
 	pad, err := l.accts.accountsq.lookup(userOptin)
@@ -527,7 +528,7 @@ return`
 	ad, err := l.Lookup(dbRound, userOptin)
 	a.Nil(ad.AppLocalStates[appIdx].KeyValue)
 
-	err = l.accts.accountsq.lookupStmt.QueryRow(userLocal[:]).Scan(&rowid, &dbRound, &buf)
+	err = accountsq.lookupStmt.QueryRow(userLocal[:]).Scan(&rowid, &dbRound, &buf)
 	a.NoError(err)
 	a.Equal(expectedUserLocal, buf)
 
@@ -19,7 +19,6 @@ package ledger
 import (
 	"context"
 	"crypto/rand"
-	"database/sql"
 	"fmt"
 	"io/ioutil"
 	mathrand "math/rand"
@@ -43,7 +42,6 @@ import (
 	"github.com/algorand/go-algorand/ledger/ledgercore"
 	"github.com/algorand/go-algorand/logging"
 	"github.com/algorand/go-algorand/protocol"
-	"github.com/algorand/go-algorand/util/db"
 )
 
 type wrappedLedger struct {
@@ -75,11 +73,11 @@ func (wl *wrappedLedger) Latest() basics.Round {
 	return wl.l.Latest()
 }
 
-func (wl *wrappedLedger) trackerDB() db.Pair {
+func (wl *wrappedLedger) trackerDB() trackerStore {
 	return wl.l.trackerDB()
 }
 
-func (wl *wrappedLedger) blockDB() db.Pair {
+func (wl *wrappedLedger) blockDB() blockStore {
 	return wl.l.blockDB()
 }
 
@@ -208,11 +206,11 @@ func TestArchivalRestart(t *testing.T) {
 	l.WaitForCommit(blk.Round())
 
 	var latest, earliest basics.Round
-	err = l.blockDBs.Rdb.Atomic(func(ctx context.Context, tx *sql.Tx) error {
-		latest, err = blockLatest(tx)
+	err = l.blockDBs.snapshot(func(ctx context.Context, tx blockStoreReader) error {
+		latest, err = tx.blockLatest()
 		require.NoError(t, err)
 
-		earliest, err = blockEarliest(tx)
+		earliest, err = tx.blockEarliest()
 		require.NoError(t, err)
 		return err
 	})
@@ -225,11 +223,11 @@ func TestArchivalRestart(t *testing.T) {
 	require.NoError(t, err)
 	defer l.Close()
 
-	err = l.blockDBs.Rdb.Atomic(func(ctx context.Context, tx *sql.Tx) error {
-		latest, err = blockLatest(tx)
+	err = l.blockDBs.snapshot(func(ctx context.Context, tx blockStoreReader) error {
+		latest, err = tx.blockLatest()
 		require.NoError(t, err)
 
-		earliest, err = blockEarliest(tx)
+		earliest, err = tx.blockEarliest()
 		require.NoError(t, err)
 		return err
 	})
@@ -739,11 +737,11 @@ func TestArchivalFromNonArchival(t *testing.T) {
 	l.WaitForCommit(blk.Round())
 
 	var latest, earliest basics.Round
-	err = l.blockDBs.Rdb.Atomic(func(ctx context.Context, tx *sql.Tx) error {
-		latest, err = blockLatest(tx)
+	err = l.blockDBs.snapshot(func(ctx context.Context, tx blockStoreReader) error {
+		latest, err = tx.blockLatest()
 		require.NoError(t, err)
 
-		earliest, err = blockEarliest(tx)
+		earliest, err = tx.blockEarliest()
 		require.NoError(t, err)
 		return err
 	})
@@ -759,11 +757,11 @@ func TestArchivalFromNonArchival(t *testing.T) {
 	require.NoError(t, err)
 	defer l.Close()
 
-	err = l.blockDBs.Rdb.Atomic(func(ctx context.Context, tx *sql.Tx) error {
-		latest, err = blockLatest(tx)
+	err = l.blockDBs.snapshot(func(ctx context.Context, tx blockStoreReader) error {
+		latest, err = tx.blockLatest()
 		require.NoError(t, err)
 
-		earliest, err = blockEarliest(tx)
+		earliest, err = tx.blockEarliest()
 		require.NoError(t, err)
 		return err
 	})
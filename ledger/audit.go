@@ -0,0 +1,521 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package ledger
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/algorand/go-algorand/agreement"
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/crypto/merkletrie"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/util/execpool"
+)
+
+// auditProgressInterval is the number of rounds between consecutive progress log messages of the ledger audit.
+const auditProgressInterval = 10000
+
+// AuditReport is the outcome of a ledger audit.
+type AuditReport struct {
+	// StartRound is the round the blocks replay started from; either the genesis round or the round of the catchpoint.
+	StartRound basics.Round
+	// ReplayedRound is the last round whose block was replayed.
+	ReplayedRound basics.Round
+	// AccountsRound is the round of the stored accounts database, at which the accounts were compared.
+	AccountsRound basics.Round
+	// ComparedAccounts is the number of accounts which were compared.
+	ComparedAccounts uint64
+	// DivergentAccounts is the number of accounts whose stored data differs from the replayed one.
+	DivergentAccounts uint64
+	// TrieRootCompared is true if the stored accounts merkle trie is up to date, and its root was compared.
+	TrieRootCompared bool
+
+	// Divergent is true if the stored ledger diverged from the replayed one.
+	Divergent bool
+	// DivergentRound is the round at which the divergence was detected: the round of the stored block which couldn't
+	// be replayed, or the AccountsRound when the stored accounts differ from the replayed ones. The stored ledger
+	// may have diverged at any earlier round since the previous check.
+	DivergentRound basics.Round
+	// DivergentAccount is the first, in address order, of the divergent accounts. It is valid only when DivergentAccounts is non-zero.
+	DivergentAccount basics.Address
+	// Reason describes the first divergence found.
+	Reason string
+}
+
+// diverged records the first divergence found by the audit.
+func (r *AuditReport) diverged(rnd basics.Round, reason string) {
+	if r.Divergent {
+		return
+	}
+	r.Divergent = true
+	r.DivergentRound = rnd
+	r.Reason = reason
+}
+
+// AuditLedger verifies the ledger stored at dbPathPrefix by the given storage backend, by re-evaluating its blocks on top of
+// a new ledger and comparing the resulting accounts, account totals and accounts merkle trie root against the stored tracker
+// database. The replay starts from the genesis, or, when a catchpoint file stream is provided, from the catchpoint, in which
+// case the stored blocks database needs to contain the blocks the catchpoint catchup requires. The databases of the audited
+// ledger are opened read-only, and must not be in use while the ledger is being audited.
+func AuditLedger(log logging.Logger, dbPathPrefix string, backend string, genesisInitState InitState, catchpoint io.Reader) (report AuditReport, err error) {
+	trackerPath, _, err := ledgerStoragePaths(dbPathPrefix, backend)
+	if err != nil {
+		return
+	}
+	if _, err = os.Stat(trackerPath); err != nil {
+		return report, fmt.Errorf("AuditLedger: unable to find ledger: %v", err)
+	}
+	storedTracker, storedBlocks, err := openLedgerDBReadOnly(dbPathPrefix, backend)
+	if err != nil {
+		return report, fmt.Errorf("AuditLedger: unable to open ledger: %v", err)
+	}
+	defer storedTracker.close()
+	defer storedBlocks.close()
+	storedTracker.setLogger(log)
+	storedBlocks.setLogger(log)
+
+	storedQueries, err := storedTracker.queries()
+	if err != nil {
+		return
+	}
+	defer storedQueries.close()
+	ctx := context.Background()
+	catchupState, _, err := storedQueries.readCatchpointStateUint64(ctx, catchpointStateCatchupState)
+	if err != nil {
+		return
+	}
+	if catchupState != CatchpointCatchupStateInactive {
+		return report, fmt.Errorf("AuditLedger: unable to audit a ledger while catchpoint catchup is in progress")
+	}
+	err = storedTracker.snapshot(func(ctx context.Context, tx trackerStoreReader) (err error) {
+		report.AccountsRound, _, err = tx.accountsRound()
+		return
+	})
+	if err != nil {
+		return
+	}
+	var earliest, latest basics.Round
+	err = storedBlocks.snapshot(func(ctx context.Context, tx blockStoreReader) (err error) {
+		earliest, err = tx.blockEarliest()
+		if err != nil {
+			return
+		}
+		latest, err = tx.blockLatest()
+		return
+	})
+	if err != nil {
+		return
+	}
+
+	auditDir, err := ioutil.TempDir("", "ledgeraudit")
+	if err != nil {
+		return
+	}
+	defer os.RemoveAll(auditDir)
+	cfg := config.GetDefaultLocal()
+	cfg.Archival = false
+	cfg.CatchpointTracking = -1
+	audit, err := OpenLedger(log, filepath.Join(auditDir, "audit"), false, genesisInitState, cfg)
+	if err != nil {
+		return report, fmt.Errorf("AuditLedger: unable to create the replay ledger: %v", err)
+	}
+	defer audit.Close()
+
+	if catchpoint != nil {
+		report.StartRound, err = auditLoadCatchpoint(ctx, log, audit, catchpoint, storedBlocks)
+		if err != nil {
+			return report, fmt.Errorf("AuditLedger: unable to load catchpoint: %v", err)
+		}
+	} else {
+		if earliest != genesisInitState.Block.Round() {
+			return report, fmt.Errorf("AuditLedger: the blocks database starts at round %d rather than at genesis; a catchpoint is required", earliest)
+		}
+		var genesisBlock bookkeeping.Block
+		genesisBlock, err = auditStoredBlock(storedBlocks, earliest)
+		if err != nil {
+			return
+		}
+		if genesisBlock.Hash() != genesisInitState.Block.Hash() {
+			return report, fmt.Errorf("AuditLedger: the genesis block of the blocks database doesn't match the genesis")
+		}
+		report.StartRound = earliest
+	}
+	if report.AccountsRound < report.StartRound || report.AccountsRound > latest {
+		return report, fmt.Errorf("AuditLedger: the accounts database round %d isn't within the replayed rounds %d-%d", report.AccountsRound, report.StartRound, latest)
+	}
+	log.Infof("AuditLedger: replaying rounds %d-%d, comparing the accounts at round %d", report.StartRound+1, latest, report.AccountsRound)
+
+	executionPool := execpool.MakePool(audit)
+	defer executionPool.Shutdown()
+	backlogPool := execpool.MakeBacklog(executionPool, 2*executionPool.GetParallelism(), execpool.LowPriority, audit)
+	defer backlogPool.Shutdown()
+
+	report.ReplayedRound = report.StartRound
+	if !auditReplayBlocks(ctx, log, audit, storedBlocks, backlogPool, report.AccountsRound, &report) {
+		return report, nil
+	}
+	err = auditCompareAccounts(ctx, audit, storedTracker, storedQueries, &report)
+	if err != nil {
+		return
+	}
+	// the blocks past the accounts round are replayed as well, to have the entire blocks database validated.
+	auditReplayBlocks(ctx, log, audit, storedBlocks, backlogPool, latest, &report)
+	return report, nil
+}
+
+// auditStoredBlock reads a block from the stored blocks database.
+func auditStoredBlock(storedBlocks blockStore, rnd basics.Round) (blk bookkeeping.Block, err error) {
+	err = storedBlocks.snapshot(func(ctx context.Context, tx blockStoreReader) (err error) {
+		blk, err = tx.blockGet(rnd)
+		return
+	})
+	return
+}
+
+// auditReplayBlocks validates and adds the stored blocks following the last replayed round, up to and including the given round.
+// It returns false if one of the blocks failed to be replayed.
+func auditReplayBlocks(ctx context.Context, log logging.Logger, audit *Ledger, storedBlocks blockStore, executionPool execpool.BacklogPool, upTo basics.Round, report *AuditReport) bool {
+	for rnd := report.ReplayedRound + 1; rnd <= upTo; rnd++ {
+		var blk bookkeeping.Block
+		var cert agreement.Certificate
+		err := storedBlocks.snapshot(func(ctx context.Context, tx blockStoreReader) (err error) {
+			blk, cert, err = tx.blockGetCert(rnd)
+			return
+		})
+		if err != nil {
+			report.diverged(rnd, fmt.Sprintf("unable to read the block of round %d: %v", rnd, err))
+			return false
+		}
+		vb, err := audit.Validate(ctx, blk, executionPool)
+		if err != nil {
+			report.diverged(rnd, fmt.Sprintf("the block of round %d failed validation: %v", rnd, err))
+			return false
+		}
+		err = audit.AddValidatedBlock(*vb, cert)
+		if err != nil {
+			report.diverged(rnd, fmt.Sprintf("unable to add the block of round %d: %v", rnd, err))
+			return false
+		}
+		report.ReplayedRound = rnd
+		if rnd%auditProgressInterval == 0 {
+			log.Infof("AuditLedger: replayed round %d", rnd)
+		}
+	}
+	return true
+}
+
+// auditLoadCatchpoint initializes the replay ledger from the given catchpoint file, using the stored blocks database for the
+// blocks the catchpoint catchup requires. It returns the round of the catchpoint.
+func auditLoadCatchpoint(ctx context.Context, log logging.Logger, audit *Ledger, catchpoint io.Reader, storedBlocks blockStore) (basics.Round, error) {
+	accessor := MakeCatchpointCatchupAccessor(audit, log)
+	err := accessor.ResetStagingBalances(ctx, true)
+	if err != nil {
+		return 0, err
+	}
+
+	var fileHeader CatchpointFileHeader
+	var progress CatchpointCatchupAccessorProgress
+	tarReader := tar.NewReader(catchpoint)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+		sectionBytes, err := ioutil.ReadAll(tarReader)
+		if err != nil {
+			return 0, err
+		}
+		if header.Name == "content.msgpack" {
+			err = protocol.Decode(sectionBytes, &fileHeader)
+			if err != nil {
+				return 0, err
+			}
+			if fileHeader.Version != catchpointFileVersion {
+				return 0, fmt.Errorf("catchpoint file version %d isn't a complete catchpoint file", fileHeader.Version)
+			}
+		}
+		err = accessor.ProgressStagingBalances(ctx, header.Name, sectionBytes, &progress)
+		if err != nil {
+			return 0, err
+		}
+	}
+	if !progress.SeenHeader {
+		return 0, fmt.Errorf("the catchpoint file has no content header")
+	}
+	err = accessor.BuildMerkleTrie(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	err = accessor.SetLabel(ctx, fileHeader.Catchpoint)
+	if err != nil {
+		return 0, err
+	}
+
+	blk, err := auditStoredBlock(storedBlocks, fileHeader.BlocksRound)
+	if err != nil {
+		return 0, fmt.Errorf("unable to read the catchpoint block of round %d: %v", fileHeader.BlocksRound, err)
+	}
+	err = accessor.VerifyCatchpoint(ctx, &blk)
+	if err != nil {
+		return 0, err
+	}
+	err = accessor.StoreBalancesRound(ctx, &blk)
+	if err != nil {
+		return 0, err
+	}
+	err = accessor.StoreFirstBlock(ctx, &blk)
+	if err != nil {
+		return 0, err
+	}
+	// the same lookback the catchpoint catchup service is using; unlike the catchup service, the stored blocks database
+	// has the genesis block as well, so a young ledger can be loaded all the way back to it.
+	lookback := config.Consensus[blk.CurrentProtocol].MaxTxnLife
+	if lookback < config.Consensus[blk.CurrentProtocol].MaxBalLookback {
+		lookback = config.Consensus[blk.CurrentProtocol].MaxBalLookback
+	}
+	if lookback > uint64(blk.Round()) {
+		lookback = uint64(blk.Round())
+	}
+	for i := uint64(1); i <= lookback; i++ {
+		rnd := blk.Round() - basics.Round(i)
+		prevBlk, err := auditStoredBlock(storedBlocks, rnd)
+		if err != nil {
+			return 0, fmt.Errorf("unable to read the block of round %d: %v", rnd, err)
+		}
+		err = accessor.StoreBlock(ctx, &prevBlk)
+		if err != nil {
+			return 0, err
+		}
+	}
+	err = accessor.CompleteCatchup(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return blk.Round(), nil
+}
+
+// auditAccountsStream reads the accounts of a tracker store in address order.
+type auditAccountsStream struct {
+	it   encodedAccountsIterator
+	buf  []encodedBalanceRecord
+	done bool
+}
+
+// peek returns the next account, without consuming it, or nil once all the accounts were read.
+func (s *auditAccountsStream) peek(ctx context.Context) (*encodedBalanceRecord, error) {
+	if len(s.buf) == 0 && !s.done {
+		bals, err := s.it.Next(ctx, BalancesPerCatchpointFileChunk)
+		if err != nil {
+			return nil, err
+		}
+		s.done = len(bals) < BalancesPerCatchpointFileChunk
+		s.buf = bals
+	}
+	if len(s.buf) == 0 {
+		return nil, nil
+	}
+	return &s.buf[0], nil
+}
+
+func (s *auditAccountsStream) pop() {
+	s.buf = s.buf[1:]
+}
+
+// auditCompareAccounts compares the accounts, account totals and accounts merkle trie of the stored tracker database against
+// the ones of the replay ledger, at the stored accounts round. The replay ledger is expected to have its latest round there.
+func auditCompareAccounts(ctx context.Context, audit *Ledger, storedTracker trackerStore, storedQueries trackerQueries, report *AuditReport) error {
+	rnd := report.AccountsRound
+	auditTotals, err := audit.Totals(rnd)
+	if err != nil {
+		return err
+	}
+
+	// the accounts modified by the replay ledger since its own accounts database round are looked up through the replay
+	// ledger; the rest of the accounts are the same as the ones in the replay accounts database.
+	au := &audit.accts
+	au.accountsMu.RLock()
+	modified := make([]basics.Address, 0, len(au.accounts))
+	for addr := range au.accounts {
+		modified = append(modified, addr)
+	}
+	au.accountsMu.RUnlock()
+	sort.Slice(modified, func(i, j int) bool {
+		return bytes.Compare(modified[i][:], modified[j][:]) < 0
+	})
+	modifiedSet := make(map[basics.Address]bool, len(modified))
+	for _, addr := range modified {
+		modifiedSet[addr] = true
+	}
+
+	trie, err := merkletrie.MakeTrie(&merkletrie.InMemoryCommitter{}, TrieMemoryConfig)
+	if err != nil {
+		return err
+	}
+	compare := func(addr basics.Address, stored []byte, expected basics.AccountData) error {
+		report.ComparedAccounts++
+		var storedData basics.AccountData
+		if len(stored) > 0 {
+			if err := protocol.Decode(stored, &storedData); err != nil {
+				return err
+			}
+		}
+		encodedExpected := protocol.Encode(&expected)
+		if !bytes.Equal(protocol.Encode(&storedData), encodedExpected) {
+			if report.DivergentAccounts == 0 || bytes.Compare(addr[:], report.DivergentAccount[:]) < 0 {
+				report.DivergentAccount = addr
+			}
+			report.DivergentAccounts++
+		}
+		if !expected.IsZero() {
+			if _, err := trie.Add(accountHashBuilder(addr, expected, encodedExpected)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var storedTotals ledgercore.AccountTotals
+	var storedTrieRoot crypto.Digest
+	err = storedTracker.snapshot(func(ctx context.Context, storedTx trackerStoreReader) error {
+		storedTotals, err = storedTx.accountsTotals(false)
+		if err != nil {
+			return err
+		}
+		_, hashRound, err := storedTx.accountsRound()
+		if err != nil {
+			return err
+		}
+		if hashRound == rnd {
+			mc, err := storedTx.merkleCommitter(false)
+			if err != nil {
+				return err
+			}
+			storedTrie, err := merkletrie.MakeTrie(mc, TrieMemoryConfig)
+			if err != nil {
+				return err
+			}
+			storedTrieRoot, err = storedTrie.RootHash()
+			if err != nil {
+				return err
+			}
+			report.TrieRootCompared = true
+		}
+
+		return audit.trackerDB().snapshot(func(ctx context.Context, auditTx trackerStoreReader) error {
+			storedStream := &auditAccountsStream{it: storedTx.makeEncodedAccountsIter()}
+			defer storedStream.it.Close()
+			auditStream := &auditAccountsStream{it: auditTx.makeEncodedAccountsIter()}
+			defer auditStream.it.Close()
+			for {
+				storedTx.resetTransactionWarnDeadline(ctx, time.Now().Add(time.Minute))
+				auditTx.resetTransactionWarnDeadline(ctx, time.Now().Add(time.Minute))
+				storedBal, err := storedStream.peek(ctx)
+				if err != nil {
+					return err
+				}
+				auditBal, err := auditStream.peek(ctx)
+				if err != nil {
+					return err
+				}
+				if storedBal == nil && auditBal == nil {
+					return nil
+				}
+				var addr basics.Address
+				var stored, expected []byte
+				switch {
+				case auditBal == nil || (storedBal != nil && bytes.Compare(storedBal.Address[:], auditBal.Address[:]) < 0):
+					addr, stored = storedBal.Address, storedBal.AccountData
+					storedStream.pop()
+				case storedBal == nil || bytes.Compare(auditBal.Address[:], storedBal.Address[:]) < 0:
+					addr, expected = auditBal.Address, auditBal.AccountData
+					auditStream.pop()
+				default:
+					addr, stored, expected = storedBal.Address, storedBal.AccountData, auditBal.AccountData
+					storedStream.pop()
+					auditStream.pop()
+				}
+				if modifiedSet[addr] {
+					continue
+				}
+				var expectedData basics.AccountData
+				if len(expected) > 0 {
+					if err := protocol.Decode(expected, &expectedData); err != nil {
+						return err
+					}
+				}
+				if err := compare(addr, stored, expectedData); err != nil {
+					return err
+				}
+			}
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, addr := range modified {
+		storedData, err := storedQueries.lookup(addr)
+		if err != nil {
+			return err
+		}
+		expected, _, err := audit.LookupWithoutRewards(rnd, addr)
+		if err != nil {
+			return err
+		}
+		var stored []byte
+		if !storedData.accountData.IsZero() {
+			stored = protocol.Encode(&storedData.accountData)
+		}
+		if err := compare(addr, stored, expected); err != nil {
+			return err
+		}
+	}
+
+	if report.DivergentAccounts > 0 {
+		report.diverged(rnd, fmt.Sprintf("%d of the stored accounts differ from the replayed ones, starting with account %v", report.DivergentAccounts, report.DivergentAccount))
+	}
+	if storedTotals != auditTotals {
+		report.diverged(rnd, fmt.Sprintf("the stored account totals %+v differ from the replayed ones %+v", storedTotals, auditTotals))
+	}
+	if report.TrieRootCompared {
+		expectedRoot, err := trie.RootHash()
+		if err != nil {
+			return err
+		}
+		if storedTrieRoot != expectedRoot {
+			report.diverged(rnd, fmt.Sprintf("the stored accounts merkle trie root %v differs from the replayed one %v", storedTrieRoot, expectedRoot))
+		}
+	}
+	return nil
+}
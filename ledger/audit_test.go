@@ -0,0 +1,207 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package ledger
+
+import (
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/agreement"
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// makeAuditTestLedger creates an on-disk ledger with the given number of blocks, each containing a single payment transaction.
+func makeAuditTestLedger(t *testing.T, dbPathPrefix string, genesisInitState InitState, initKeys map[basics.Address]*crypto.SignatureSecrets, cfg config.Local, blocks int) {
+	l, err := OpenLedger(logging.TestingLog(t), dbPathPrefix, false, genesisInitState, cfg)
+	require.NoError(t, err)
+	defer l.Close()
+
+	var addrs []basics.Address
+	for addr := range genesisInitState.Accounts {
+		if addr != testPoolAddr && addr != testSinkAddr {
+			addrs = append(addrs, addr)
+		}
+	}
+	proto := config.Consensus[genesisInitState.Block.CurrentProtocol]
+	for i := 0; i < blocks; i++ {
+		blk := makeNewEmptyBlock(t, l, t.Name(), genesisInitState.Accounts)
+		eval, err := l.StartEvaluator(blk.BlockHeader, 1)
+		require.NoError(t, err)
+		tx := transactions.Transaction{
+			Type: protocol.PaymentTx,
+			Header: transactions.Header{
+				Sender:      addrs[i%len(addrs)],
+				Fee:         basics.MicroAlgos{Raw: proto.MinTxnFee},
+				FirstValid:  blk.Round() - 1,
+				LastValid:   blk.Round() + 10,
+				GenesisID:   t.Name(),
+				GenesisHash: genesisInitState.GenesisHash,
+			},
+			PaymentTxnFields: transactions.PaymentTxnFields{
+				Receiver: addrs[(i+1)%len(addrs)],
+				Amount:   basics.MicroAlgos{Raw: uint64(1000 + i)},
+			},
+		}
+		require.NoError(t, eval.Transaction(sign(initKeys, tx), transactions.ApplyData{}))
+		vb, err := eval.GenerateBlock()
+		require.NoError(t, err)
+		require.NoError(t, l.AddValidatedBlock(*vb, agreement.Certificate{}))
+	}
+	l.WaitForCommit(l.Latest())
+	if cfg.CatchpointInterval > 0 {
+		catchpointRound := basics.Round(cfg.CatchpointInterval)
+		deadline := time.Now().Add(time.Minute)
+		for {
+			stream, err := l.GetCatchpointStream(catchpointRound)
+			if err == nil {
+				stream.Close()
+				break
+			}
+			require.True(t, time.Now().Before(deadline), "catchpoint file for round %d wasn't generated", catchpointRound)
+			time.Sleep(50 * time.Millisecond)
+		}
+	}
+}
+
+func TestAuditLedger(t *testing.T) {
+	genesisInitState, initKeys := testGenerateInitState(t, protocol.ConsensusCurrentVersion, 100)
+	dbDir, err := ioutil.TempDir("", "audit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dbDir)
+	dbPathPrefix := filepath.Join(dbDir, t.Name())
+
+	cfg := config.GetDefaultLocal()
+	cfg.Archival = true
+	cfg.CatchpointInterval = 0
+	blocks := 400
+	makeAuditTestLedger(t, dbPathPrefix, genesisInitState, initKeys, cfg, blocks)
+
+	log := logging.TestingLog(t)
+	report, err := AuditLedger(log, dbPathPrefix, StorageBackendSQLite, genesisInitState, nil)
+	require.NoError(t, err)
+	require.False(t, report.Divergent, report.Reason)
+	require.Equal(t, basics.Round(0), report.StartRound)
+	require.Equal(t, basics.Round(blocks), report.ReplayedRound)
+	require.NotZero(t, report.AccountsRound)
+	require.Equal(t, uint64(len(genesisInitState.Accounts)), report.ComparedAccounts)
+	require.Zero(t, report.DivergentAccounts)
+
+	// corrupt the balance of one of the stored accounts.
+	var corrupted basics.Address
+	for addr := range genesisInitState.Accounts {
+		if addr != testPoolAddr && addr != testSinkAddr {
+			corrupted = addr
+			break
+		}
+	}
+	trackerDB, blocksDB, err := openLedgerDB(dbPathPrefix, false, StorageBackendSQLite)
+	require.NoError(t, err)
+	err = trackerDB.(*sqliteTrackerStore).dbs.Wdb.Atomic(func(ctx context.Context, tx *sql.Tx) error {
+		var data []byte
+		err := tx.QueryRow("SELECT data FROM accountbase WHERE address=?", corrupted[:]).Scan(&data)
+		if err != nil {
+			return err
+		}
+		var ad basics.AccountData
+		err = protocol.Decode(data, &ad)
+		if err != nil {
+			return err
+		}
+		ad.MicroAlgos.Raw++
+		_, err = tx.Exec("UPDATE accountbase SET data=? WHERE address=?", protocol.Encode(&ad), corrupted[:])
+		return err
+	})
+	require.NoError(t, err)
+	trackerDB.close()
+	blocksDB.close()
+
+	report, err = AuditLedger(log, dbPathPrefix, StorageBackendSQLite, genesisInitState, nil)
+	require.NoError(t, err)
+	require.True(t, report.Divergent)
+	require.Equal(t, report.AccountsRound, report.DivergentRound)
+	require.Equal(t, uint64(1), report.DivergentAccounts)
+	require.Equal(t, corrupted, report.DivergentAccount)
+
+	// the audit doesn't create the databases which are missing.
+	trackerPath, blockPath, err := ledgerStoragePaths(dbPathPrefix, StorageBackendSQLite)
+	require.NoError(t, err)
+	require.NoError(t, os.Rename(blockPath, blockPath+".moved"))
+	_, err = AuditLedger(log, dbPathPrefix, StorageBackendSQLite, genesisInitState, nil)
+	require.Error(t, err)
+	require.NoFileExists(t, blockPath)
+	_, err = AuditLedger(log, filepath.Join(dbDir, "missing"), StorageBackendSQLite, genesisInitState, nil)
+	require.Error(t, err)
+	missingTracker, _, err := ledgerStoragePaths(filepath.Join(dbDir, "missing"), StorageBackendSQLite)
+	require.NoError(t, err)
+	require.NoFileExists(t, missingTracker)
+	require.FileExists(t, trackerPath)
+}
+
+func TestAuditLedgerFromCatchpoint(t *testing.T) {
+	genesisInitState, initKeys := testGenerateInitState(t, protocol.ConsensusCurrentVersion, 100)
+	dbDir, err := ioutil.TempDir("", "audit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dbDir)
+	dbPathPrefix := filepath.Join(dbDir, t.Name())
+
+	// the catchpoint round needs to be past the accounts lookback, and the accounts database round needs to be past the
+	// catchpoint round.
+	proto := config.Consensus[protocol.ConsensusCurrentVersion]
+	cfg := config.GetDefaultLocal()
+	cfg.Archival = true
+	cfg.CatchpointInterval = proto.MaxBalLookback + 80
+	blocks := int(cfg.CatchpointInterval + proto.MaxBalLookback + 20)
+	makeAuditTestLedger(t, dbPathPrefix, genesisInitState, initKeys, cfg, blocks)
+
+	l, err := OpenLedger(logging.TestingLog(t), dbPathPrefix, false, genesisInitState, cfg)
+	require.NoError(t, err)
+	stream, err := l.GetCatchpointStream(basics.Round(cfg.CatchpointInterval))
+	require.NoError(t, err)
+	catchpointFile, err := ioutil.ReadAll(stream)
+	stream.Close()
+	l.Close()
+	require.NoError(t, err)
+	catchpointPath := filepath.Join(dbDir, "catchpoint.tar.gz")
+	require.NoError(t, ioutil.WriteFile(catchpointPath, catchpointFile, 0600))
+
+	f, err := os.Open(catchpointPath)
+	require.NoError(t, err)
+	defer f.Close()
+	gzipReader, err := gzip.NewReader(f)
+	require.NoError(t, err)
+
+	report, err := AuditLedger(logging.TestingLog(t), dbPathPrefix, StorageBackendSQLite, genesisInitState, gzipReader)
+	require.NoError(t, err)
+	require.False(t, report.Divergent, report.Reason)
+	require.Equal(t, basics.Round(cfg.CatchpointInterval), report.StartRound)
+	require.Equal(t, basics.Round(blocks), report.ReplayedRound)
+	require.True(t, report.AccountsRound >= report.StartRound)
+	require.NotZero(t, report.ComparedAccounts)
+}
@@ -339,3 +339,27 @@ func blockEnsureSingleBlock(tx *sql.Tx) (blk bookkeeping.Block, err error) {
 
 	return blk, err
 }
+
+// blockImport inserts a block along with its encoded certificate, as read from another block database.
+// Unlike blockPut, the first imported block may be of any round, since the source database might have
+// already forgotten the earlier blocks.
+func blockImport(tx *sql.Tx, blk bookkeeping.Block, encodedCert []byte) error {
+	var max sql.NullInt64
+	err := tx.QueryRow("SELECT MAX(rnd) FROM blocks").Scan(&max)
+	if err != nil {
+		return err
+	}
+
+	if max.Valid && blk.Round() != basics.Round(max.Int64+1) {
+		return fmt.Errorf("inserting block %d but expected %d", blk.Round(), max.Int64+1)
+	}
+
+	_, err = tx.Exec("INSERT INTO blocks (rnd, proto, hdrdata, blkdata, certdata) VALUES (?, ?, ?, ?, ?)",
+		blk.Round(),
+		blk.CurrentProtocol,
+		protocol.Encode(&blk.BlockHeader),
+		protocol.Encode(&blk),
+		encodedCert,
+	)
+	return err
+}
@@ -18,7 +18,6 @@ package ledger
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"sync"
 	"time"
@@ -59,9 +58,9 @@ func bqInit(l *Ledger) (*blockQueue, error) {
 	bq.closed = make(chan struct{})
 	ledgerBlockqInitCount.Inc(nil)
 	start := time.Now()
-	err := bq.l.blockDBs.Rdb.Atomic(func(ctx context.Context, tx *sql.Tx) error {
+	err := bq.l.blockDBs.snapshot(func(ctx context.Context, tx blockStoreReader) error {
 		var err0 error
-		bq.lastCommitted, err0 = blockLatest(tx)
+		bq.lastCommitted, err0 = tx.blockLatest()
 		return err0
 	})
 	ledgerBlockqInitMicros.AddMicrosecondsSince(start, nil)
@@ -109,9 +108,9 @@ func (bq *blockQueue) syncer() {
 
 		start := time.Now()
 		ledgerSyncBlockputCount.Inc(nil)
-		err := bq.l.blockDBs.Wdb.Atomic(func(ctx context.Context, tx *sql.Tx) error {
+		err := bq.l.blockDBs.batch(func(ctx context.Context, tx blockStoreWriter) error {
 			for _, e := range workQ {
-				err0 := blockPut(tx, e.block, e.cert)
+				err0 := tx.blockPut(e.block, e.cert)
 				if err0 != nil {
 					return err0
 				}
@@ -145,8 +144,8 @@ func (bq *blockQueue) syncer() {
 			minToSave := bq.l.notifyCommit(committed)
 			bfstart := time.Now()
 			ledgerSyncBlockforgetCount.Inc(nil)
-			err = bq.l.blockDBs.Wdb.Atomic(func(ctx context.Context, tx *sql.Tx) error {
-				return blockForgetBefore(tx, minToSave)
+			err = bq.l.blockDBs.batch(func(ctx context.Context, tx blockStoreWriter) error {
+				return tx.blockForgetBefore(minToSave)
 			})
 			ledgerSyncBlockforgetMicros.AddMicrosecondsSince(bfstart, nil)
 			if err != nil {
@@ -259,9 +258,9 @@ func (bq *blockQueue) getBlock(r basics.Round) (blk bookkeeping.Block, err error
 
 	start := time.Now()
 	ledgerGetblockCount.Inc(nil)
-	err = bq.l.blockDBs.Rdb.Atomic(func(ctx context.Context, tx *sql.Tx) error {
+	err = bq.l.blockDBs.snapshot(func(ctx context.Context, tx blockStoreReader) error {
 		var err0 error
-		blk, err0 = blockGet(tx, r)
+		blk, err0 = tx.blockGet(r)
 		return err0
 	})
 	ledgerGetblockMicros.AddMicrosecondsSince(start, nil)
@@ -281,9 +280,9 @@ func (bq *blockQueue) getBlockHdr(r basics.Round) (hdr bookkeeping.BlockHeader,
 
 	start := time.Now()
 	ledgerGetblockhdrCount.Inc(nil)
-	err = bq.l.blockDBs.Rdb.Atomic(func(ctx context.Context, tx *sql.Tx) error {
+	err = bq.l.blockDBs.snapshot(func(ctx context.Context, tx blockStoreReader) error {
 		var err0 error
-		hdr, err0 = blockGetHdr(tx, r)
+		hdr, err0 = tx.blockGetHdr(r)
 		return err0
 	})
 	ledgerGetblockhdrMicros.AddMicrosecondsSince(start, nil)
@@ -307,9 +306,9 @@ func (bq *blockQueue) getEncodedBlockCert(r basics.Round) (blk []byte, cert []by
 
 	start := time.Now()
 	ledgerGeteblockcertCount.Inc(nil)
-	err = bq.l.blockDBs.Rdb.Atomic(func(ctx context.Context, tx *sql.Tx) error {
+	err = bq.l.blockDBs.snapshot(func(ctx context.Context, tx blockStoreReader) error {
 		var err0 error
-		blk, cert, err0 = blockGetEncodedCert(tx, r)
+		blk, cert, err0 = tx.blockGetEncodedCert(r)
 		return err0
 	})
 	ledgerGeteblockcertMicros.AddMicrosecondsSince(start, nil)
@@ -329,9 +328,9 @@ func (bq *blockQueue) getBlockCert(r basics.Round) (blk bookkeeping.Block, cert
 
 	start := time.Now()
 	ledgerGetblockcertCount.Inc(nil)
-	err = bq.l.blockDBs.Rdb.Atomic(func(ctx context.Context, tx *sql.Tx) error {
+	err = bq.l.blockDBs.snapshot(func(ctx context.Context, tx blockStoreReader) error {
 		var err0 error
-		blk, cert, err0 = blockGetCert(tx, r)
+		blk, cert, err0 = tx.blockGetCert(r)
 		return err0
 	})
 	ledgerGetblockcertMicros.AddMicrosecondsSince(start, nil)
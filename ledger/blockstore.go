@@ -0,0 +1,178 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package ledger
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/algorand/go-algorand/agreement"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/util/db"
+)
+
+// blockStore is the persistent storage of the ledger blocks and their certificates. In addition to the
+// blocks, it holds the staging blocks used during catchpoint catchup.
+type blockStore interface {
+	// snapshot executes fn over a consistent, read-only view of the storage.
+	snapshot(fn func(ctx context.Context, tx blockStoreReader) error) error
+
+	// batch executes fn within a write transaction. The changes made by fn are committed only if it returns
+	// no error.
+	batch(fn func(ctx context.Context, tx blockStoreWriter) error) error
+
+	// setSynchronousMode sets the durability guarantees of the writes made to the storage.
+	setSynchronousMode(ctx context.Context, mode db.SynchronousMode, fullfsync bool) error
+
+	setLogger(log logging.Logger)
+	close()
+}
+
+// blockStoreReader provides read access to the blocks storage within a snapshot or a batch.
+type blockStoreReader interface {
+	blockGet(rnd basics.Round) (blk bookkeeping.Block, err error)
+	blockGetHdr(rnd basics.Round) (hdr bookkeeping.BlockHeader, err error)
+	blockGetEncodedCert(rnd basics.Round) (blk []byte, cert []byte, err error)
+	blockGetCert(rnd basics.Round) (blk bookkeeping.Block, cert agreement.Certificate, err error)
+	blockLatest() (basics.Round, error)
+	blockEarliest() (basics.Round, error)
+}
+
+// blockStoreWriter provides read and write access to the blocks storage within a batch.
+type blockStoreWriter interface {
+	blockStoreReader
+
+	blockInit(initBlocks []bookkeeping.Block) error
+	blockResetDB() error
+	blockPut(blk bookkeeping.Block, cert agreement.Certificate) error
+	blockForgetBefore(rnd basics.Round) error
+
+	// blockImport adds a block whose certificate is already encoded. The first imported block may be of any round.
+	blockImport(blk bookkeeping.Block, encodedCert []byte) error
+
+	// catchpoint catchup staging
+	blockStartCatchupStaging(blk bookkeeping.Block) error
+	blockPutStaging(blk bookkeeping.Block) error
+	blockCompleteCatchup() error
+	blockAbortCatchup() error
+	blockEnsureSingleBlock() (blk bookkeeping.Block, err error)
+}
+
+// sqliteBlockStore is the blockStore implementation over the ledger.block.sqlite database.
+type sqliteBlockStore struct {
+	dbs db.Pair
+}
+
+// sqliteBlockTx implements the blockStoreReader and blockStoreWriter over a single SQL transaction.
+type sqliteBlockTx struct {
+	tx *sql.Tx
+}
+
+func makeSQLiteBlockStore(dbs db.Pair) *sqliteBlockStore {
+	return &sqliteBlockStore{dbs: dbs}
+}
+
+func (s *sqliteBlockStore) snapshot(fn func(ctx context.Context, tx blockStoreReader) error) error {
+	return s.dbs.Rdb.Atomic(func(ctx context.Context, tx *sql.Tx) error {
+		return fn(ctx, &sqliteBlockTx{tx: tx})
+	})
+}
+
+func (s *sqliteBlockStore) batch(fn func(ctx context.Context, tx blockStoreWriter) error) error {
+	return s.dbs.Wdb.Atomic(func(ctx context.Context, tx *sql.Tx) error {
+		return fn(ctx, &sqliteBlockTx{tx: tx})
+	})
+}
+
+func (s *sqliteBlockStore) setSynchronousMode(ctx context.Context, mode db.SynchronousMode, fullfsync bool) error {
+	return s.dbs.Wdb.SetSynchronousMode(ctx, mode, fullfsync)
+}
+
+func (s *sqliteBlockStore) setLogger(log logging.Logger) {
+	s.dbs.Rdb.SetLogger(log)
+	s.dbs.Wdb.SetLogger(log)
+}
+
+func (s *sqliteBlockStore) close() {
+	s.dbs.Close()
+}
+
+func (btx *sqliteBlockTx) blockGet(rnd basics.Round) (blk bookkeeping.Block, err error) {
+	return blockGet(btx.tx, rnd)
+}
+
+func (btx *sqliteBlockTx) blockGetHdr(rnd basics.Round) (hdr bookkeeping.BlockHeader, err error) {
+	return blockGetHdr(btx.tx, rnd)
+}
+
+func (btx *sqliteBlockTx) blockGetEncodedCert(rnd basics.Round) (blk []byte, cert []byte, err error) {
+	return blockGetEncodedCert(btx.tx, rnd)
+}
+
+func (btx *sqliteBlockTx) blockGetCert(rnd basics.Round) (blk bookkeeping.Block, cert agreement.Certificate, err error) {
+	return blockGetCert(btx.tx, rnd)
+}
+
+func (btx *sqliteBlockTx) blockLatest() (basics.Round, error) {
+	return blockLatest(btx.tx)
+}
+
+func (btx *sqliteBlockTx) blockEarliest() (basics.Round, error) {
+	return blockEarliest(btx.tx)
+}
+
+func (btx *sqliteBlockTx) blockInit(initBlocks []bookkeeping.Block) error {
+	return blockInit(btx.tx, initBlocks)
+}
+
+func (btx *sqliteBlockTx) blockResetDB() error {
+	return blockResetDB(btx.tx)
+}
+
+func (btx *sqliteBlockTx) blockPut(blk bookkeeping.Block, cert agreement.Certificate) error {
+	return blockPut(btx.tx, blk, cert)
+}
+
+func (btx *sqliteBlockTx) blockForgetBefore(rnd basics.Round) error {
+	return blockForgetBefore(btx.tx, rnd)
+}
+
+func (btx *sqliteBlockTx) blockImport(blk bookkeeping.Block, encodedCert []byte) error {
+	return blockImport(btx.tx, blk, encodedCert)
+}
+
+func (btx *sqliteBlockTx) blockStartCatchupStaging(blk bookkeeping.Block) error {
+	return blockStartCatchupStaging(btx.tx, blk)
+}
+
+func (btx *sqliteBlockTx) blockPutStaging(blk bookkeeping.Block) error {
+	return blockPutStaging(btx.tx, blk)
+}
+
+func (btx *sqliteBlockTx) blockCompleteCatchup() error {
+	return blockCompleteCatchup(btx.tx)
+}
+
+func (btx *sqliteBlockTx) blockAbortCatchup() error {
+	return blockAbortCatchup(btx.tx)
+}
+
+func (btx *sqliteBlockTx) blockEnsureSingleBlock() (blk bookkeeping.Block, err error) {
+	return blockEnsureSingleBlock(btx.tx)
+}
@@ -0,0 +1,334 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package ledger
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/algorand/go-algorand/agreement"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/util/db"
+)
+
+// kvBlockTables are the key prefixes of the blocks "tables". Each of these is followed by the block round.
+// The headers are stored separately from the blocks so that reading a header won't require decoding the
+// entire block. The certificates table is missing the entries of blocks that have no certificate.
+type kvBlockTables struct {
+	blocks  string
+	headers string
+	certs   string
+}
+
+var kvBaseBlockTables = kvBlockTables{
+	blocks:  "blk/",
+	headers: "hdr/",
+	certs:   "cert/",
+}
+
+var kvStagingBlockTables = kvBlockTables{
+	blocks:  "staging/blk/",
+	headers: "staging/hdr/",
+	certs:   "staging/cert/",
+}
+
+// kvBlockStore is the blockStore implementation over the key-value store.
+type kvBlockStore struct {
+	kv *kvStore
+}
+
+// kvBlockTx implements the blockStoreReader and blockStoreWriter over a key-value store snapshot
+// or transaction. w is nil when used over a snapshot.
+type kvBlockTx struct {
+	r kvReader
+	w kvWriter
+}
+
+func makeKVBlockStore(kv *kvStore) *kvBlockStore {
+	return &kvBlockStore{kv: kv}
+}
+
+func (s *kvBlockStore) snapshot(fn func(ctx context.Context, tx blockStoreReader) error) error {
+	return s.kv.snapshot(func(r kvReader) error {
+		return fn(context.Background(), &kvBlockTx{r: r})
+	})
+}
+
+func (s *kvBlockStore) batch(fn func(ctx context.Context, tx blockStoreWriter) error) error {
+	return s.kv.atomic(func(w kvWriter) error {
+		return fn(context.Background(), &kvBlockTx{r: w, w: w})
+	})
+}
+
+func (s *kvBlockStore) setSynchronousMode(ctx context.Context, mode db.SynchronousMode, fullfsync bool) error {
+	return s.kv.setSynchronousMode(mode)
+}
+
+func (s *kvBlockStore) setLogger(log logging.Logger) {
+	s.kv.setLogger(log)
+}
+
+func (s *kvBlockStore) close() {
+	s.kv.close()
+}
+
+// kvBlockBoundary returns the lowest or the highest round in the given tables.
+func kvBlockBoundary(r kvReader, tables kvBlockTables, latest bool) (rnd basics.Round, ok bool, err error) {
+	err = r.scan(kvPrefixRange(tables.headers), latest, func(key, value []byte) (bool, error) {
+		rnd = basics.Round(binary.BigEndian.Uint64(key[len(tables.headers):]))
+		ok = true
+		return false, nil
+	})
+	return
+}
+
+func kvBlockPut(w kvWriter, tables kvBlockTables, blk bookkeeping.Block, encodedCert []byte) error {
+	rnd := uint64(blk.Round())
+	err := w.put(kvUint64Key(tables.headers, rnd), protocol.Encode(&blk.BlockHeader))
+	if err != nil {
+		return err
+	}
+	err = w.put(kvUint64Key(tables.blocks, rnd), protocol.Encode(&blk))
+	if err != nil {
+		return err
+	}
+	if encodedCert != nil {
+		return w.put(kvUint64Key(tables.certs, rnd), encodedCert)
+	}
+	return nil
+}
+
+// kvBlockDeleteTables deletes all the blocks in the given tables.
+func kvBlockDeleteTables(w kvWriter, tables kvBlockTables) error {
+	for _, prefix := range []string{tables.blocks, tables.headers, tables.certs} {
+		err := kvDeleteRange(w, kvPrefixRange(prefix))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// kvBlockDeleteBefore deletes all the blocks in the given tables whose round is lower than rnd.
+func kvBlockDeleteBefore(w kvWriter, tables kvBlockTables, rnd basics.Round) error {
+	for _, prefix := range []string{tables.blocks, tables.headers, tables.certs} {
+		err := kvDeleteRange(w, &util.Range{Start: kvUint64Key(prefix, 0), Limit: kvUint64Key(prefix, uint64(rnd))})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (btx *kvBlockTx) blockGet(rnd basics.Round) (blk bookkeeping.Block, err error) {
+	buf, err := btx.r.get(kvUint64Key(kvBaseBlockTables.blocks, uint64(rnd)))
+	if err != nil {
+		if err == errKVNotFound {
+			err = ledgercore.ErrNoEntry{Round: rnd}
+		}
+		return
+	}
+	err = protocol.Decode(buf, &blk)
+	return
+}
+
+func (btx *kvBlockTx) blockGetHdr(rnd basics.Round) (hdr bookkeeping.BlockHeader, err error) {
+	buf, err := btx.r.get(kvUint64Key(kvBaseBlockTables.headers, uint64(rnd)))
+	if err != nil {
+		if err == errKVNotFound {
+			err = ledgercore.ErrNoEntry{Round: rnd}
+		}
+		return
+	}
+	err = protocol.Decode(buf, &hdr)
+	return
+}
+
+func (btx *kvBlockTx) blockGetEncodedCert(rnd basics.Round) (blk []byte, cert []byte, err error) {
+	blk, err = btx.r.get(kvUint64Key(kvBaseBlockTables.blocks, uint64(rnd)))
+	if err != nil {
+		if err == errKVNotFound {
+			err = ledgercore.ErrNoEntry{Round: rnd}
+		}
+		return
+	}
+	cert, err = btx.r.get(kvUint64Key(kvBaseBlockTables.certs, uint64(rnd)))
+	if err == errKVNotFound {
+		// the block was stored without a certificate.
+		return blk, nil, nil
+	}
+	return
+}
+
+func (btx *kvBlockTx) blockGetCert(rnd basics.Round) (blk bookkeeping.Block, cert agreement.Certificate, err error) {
+	blkbuf, certbuf, err := btx.blockGetEncodedCert(rnd)
+	if err != nil {
+		return
+	}
+	err = protocol.Decode(blkbuf, &blk)
+	if err != nil {
+		return
+	}
+
+	if certbuf != nil {
+		err = protocol.Decode(certbuf, &cert)
+	}
+	return
+}
+
+func (btx *kvBlockTx) blockLatest() (basics.Round, error) {
+	rnd, ok, err := kvBlockBoundary(btx.r, kvBaseBlockTables, true)
+	if err == nil && !ok {
+		err = fmt.Errorf("no blocks present")
+	}
+	return rnd, err
+}
+
+func (btx *kvBlockTx) blockEarliest() (basics.Round, error) {
+	rnd, ok, err := kvBlockBoundary(btx.r, kvBaseBlockTables, false)
+	if err == nil && !ok {
+		err = fmt.Errorf("no blocks present")
+	}
+	return rnd, err
+}
+
+func (btx *kvBlockTx) blockInit(initBlocks []bookkeeping.Block) error {
+	_, ok, err := kvBlockBoundary(btx.w, kvBaseBlockTables, true)
+	if err != nil || ok {
+		return err
+	}
+	for _, blk := range initBlocks {
+		err = btx.blockPut(blk, agreement.Certificate{})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (btx *kvBlockTx) blockResetDB() error {
+	return kvBlockDeleteTables(btx.w, kvBaseBlockTables)
+}
+
+func (btx *kvBlockTx) blockPut(blk bookkeeping.Block, cert agreement.Certificate) error {
+	latest, ok, err := kvBlockBoundary(btx.w, kvBaseBlockTables, true)
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		if blk.Round() != latest+1 {
+			return fmt.Errorf("inserting block %d but expected %d", blk.Round(), latest+1)
+		}
+	} else {
+		if blk.Round() != 0 {
+			return fmt.Errorf("inserting block %d but expected 0", blk.Round())
+		}
+	}
+	return kvBlockPut(btx.w, kvBaseBlockTables, blk, protocol.Encode(&cert))
+}
+
+func (btx *kvBlockTx) blockForgetBefore(rnd basics.Round) error {
+	latest, ok, err := kvBlockBoundary(btx.w, kvBaseBlockTables, true)
+	if err != nil {
+		return err
+	}
+	next := basics.Round(0)
+	if ok {
+		next = latest + 1
+	}
+
+	if rnd >= next {
+		return fmt.Errorf("forgetting too much: rnd %d >= next %d", rnd, next)
+	}
+	return kvBlockDeleteBefore(btx.w, kvBaseBlockTables, rnd)
+}
+
+func (btx *kvBlockTx) blockImport(blk bookkeeping.Block, encodedCert []byte) error {
+	latest, ok, err := kvBlockBoundary(btx.w, kvBaseBlockTables, true)
+	if err != nil {
+		return err
+	}
+	if ok && blk.Round() != latest+1 {
+		return fmt.Errorf("inserting block %d but expected %d", blk.Round(), latest+1)
+	}
+	return kvBlockPut(btx.w, kvBaseBlockTables, blk, encodedCert)
+}
+
+func (btx *kvBlockTx) blockStartCatchupStaging(blk bookkeeping.Block) error {
+	err := kvBlockDeleteTables(btx.w, kvStagingBlockTables)
+	if err != nil {
+		return err
+	}
+	return kvBlockPut(btx.w, kvStagingBlockTables, blk, nil)
+}
+
+func (btx *kvBlockTx) blockPutStaging(blk bookkeeping.Block) error {
+	return kvBlockPut(btx.w, kvStagingBlockTables, blk, nil)
+}
+
+func (btx *kvBlockTx) blockCompleteCatchup() error {
+	for _, move := range [][2]string{
+		{kvStagingBlockTables.blocks, kvBaseBlockTables.blocks},
+		{kvStagingBlockTables.headers, kvBaseBlockTables.headers},
+		{kvStagingBlockTables.certs, kvBaseBlockTables.certs},
+	} {
+		err := kvMovePrefix(btx.w, move[0], move[1])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (btx *kvBlockTx) blockAbortCatchup() error {
+	return kvBlockDeleteTables(btx.w, kvStagingBlockTables)
+}
+
+func (btx *kvBlockTx) blockEnsureSingleBlock() (blk bookkeeping.Block, err error) {
+	// delete all the blocks that aren't the latest one.
+	round, ok, err := kvBlockBoundary(btx.w, kvStagingBlockTables, true)
+	if err != nil {
+		return bookkeeping.Block{}, err
+	}
+	if !ok {
+		return bookkeeping.Block{}, ledgercore.ErrNoEntry{}
+	}
+
+	err = kvBlockDeleteBefore(btx.w, kvStagingBlockTables, round)
+	if err != nil {
+		return bookkeeping.Block{}, err
+	}
+
+	buf, err := btx.w.get(kvUint64Key(kvStagingBlockTables.blocks, uint64(round)))
+	if err != nil {
+		if err == errKVNotFound {
+			err = ledgercore.ErrNoEntry{Round: round}
+		}
+		return bookkeeping.Block{}, err
+	}
+
+	err = protocol.Decode(buf, &blk)
+	return blk, err
+}
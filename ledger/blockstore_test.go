@@ -0,0 +1,192 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package ledger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/agreement"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// openTestBlockStores opens an in-memory block store of each of the storage backends.
+func openTestBlockStores(t *testing.T) map[string]blockStore {
+	dbs, _ := dbOpenTest(t, true)
+	setDbLogging(t, dbs)
+	kv, err := openKVStore("", true)
+	require.NoError(t, err)
+
+	return map[string]blockStore{
+		StorageBackendSQLite:  makeSQLiteBlockStore(dbs),
+		StorageBackendLevelDB: makeKVBlockStore(kv),
+	}
+}
+
+// checkBlockStore verifies that the store holds exactly the given blocks.
+func checkBlockStore(t *testing.T, backend string, store blockStore, blocks []blockEntry) {
+	err := store.snapshot(func(ctx context.Context, tx blockStoreReader) error {
+		latest, err := tx.blockLatest()
+		require.NoError(t, err, backend)
+		require.Equal(t, blocks[len(blocks)-1].block.Round(), latest, backend)
+		earliest, err := tx.blockEarliest()
+		require.NoError(t, err, backend)
+		require.Equal(t, blocks[0].block.Round(), earliest, backend)
+
+		for _, entry := range blocks {
+			blk, err := tx.blockGet(entry.block.Round())
+			require.NoError(t, err, backend)
+			require.Equal(t, entry.block, blk, backend)
+			hdr, err := tx.blockGetHdr(entry.block.Round())
+			require.NoError(t, err, backend)
+			require.Equal(t, entry.block.BlockHeader, hdr, backend)
+			blk, cert, err := tx.blockGetCert(entry.block.Round())
+			require.NoError(t, err, backend)
+			require.Equal(t, entry.block, blk, backend)
+			require.Equal(t, entry.cert, cert, backend)
+		}
+
+		_, err = tx.blockGet(latest + 1)
+		require.Equal(t, ledgercore.ErrNoEntry{Round: latest + 1}, err, backend)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestBlockStoreBackends(t *testing.T) {
+	stores := openTestBlockStores(t)
+	blocks := randomInitChain(protocol.ConsensusCurrentVersion, 1)
+	for i := 1; i < 20; i++ {
+		blocks = append(blocks, randomBlock(basics.Round(i)))
+	}
+
+	for backend, store := range stores {
+		err := store.batch(func(ctx context.Context, tx blockStoreWriter) error {
+			err := tx.blockInit(nil)
+			require.NoError(t, err, backend)
+			_, err = tx.blockLatest()
+			require.Error(t, err, backend)
+
+			err = tx.blockInit(blockChainBlocks(blocks[:1]))
+			require.NoError(t, err, backend)
+			// initializing a non-empty store is a no-op.
+			err = tx.blockInit(blockChainBlocks(blocks[:1]))
+			require.NoError(t, err, backend)
+
+			for _, entry := range blocks[1:] {
+				err = tx.blockPut(entry.block, entry.cert)
+				require.NoError(t, err, backend)
+			}
+			require.Error(t, tx.blockPut(blocks[5].block, blocks[5].cert), backend)
+			return nil
+		})
+		require.NoError(t, err, backend)
+		checkBlockStore(t, backend, store, blocks)
+
+		err = store.batch(func(ctx context.Context, tx blockStoreWriter) error {
+			require.Error(t, tx.blockForgetBefore(basics.Round(len(blocks))), backend)
+			return tx.blockForgetBefore(10)
+		})
+		require.NoError(t, err, backend)
+		checkBlockStore(t, backend, store, blocks[10:])
+
+		// catchpoint catchup replaces all the blocks with the staged ones.
+		staged := []blockEntry{randomBlock(100), randomBlock(101), randomBlock(102)}
+		err = store.batch(func(ctx context.Context, tx blockStoreWriter) error {
+			err := tx.blockStartCatchupStaging(staged[0].block)
+			require.NoError(t, err, backend)
+			for _, entry := range staged[1:] {
+				err = tx.blockPutStaging(entry.block)
+				require.NoError(t, err, backend)
+			}
+			blk, err := tx.blockEnsureSingleBlock()
+			require.NoError(t, err, backend)
+			require.Equal(t, staged[2].block, blk, backend)
+
+			// the staging blocks aren't visible until the catchup is completed.
+			latest, err := tx.blockLatest()
+			require.NoError(t, err, backend)
+			require.Equal(t, basics.Round(len(blocks)-1), latest, backend)
+			return tx.blockCompleteCatchup()
+		})
+		require.NoError(t, err, backend)
+		staged[2].cert = agreement.Certificate{}
+		checkBlockStore(t, backend, store, staged[2:])
+
+		// imported blocks carry their encoded certificate.
+		imported := []blockEntry{randomBlock(200), randomBlock(201)}
+		err = store.batch(func(ctx context.Context, tx blockStoreWriter) error {
+			err := tx.blockResetDB()
+			require.NoError(t, err, backend)
+			err = tx.blockInit(nil)
+			require.NoError(t, err, backend)
+			for _, entry := range imported {
+				err = tx.blockImport(entry.block, protocol.Encode(&entry.cert))
+				require.NoError(t, err, backend)
+			}
+			return tx.blockImport(randomBlock(300).block, nil)
+		})
+		require.Error(t, err, backend)
+		err = store.batch(func(ctx context.Context, tx blockStoreWriter) error {
+			err := tx.blockResetDB()
+			require.NoError(t, err, backend)
+			err = tx.blockInit(nil)
+			require.NoError(t, err, backend)
+			for _, entry := range imported {
+				err = tx.blockImport(entry.block, protocol.Encode(&entry.cert))
+				require.NoError(t, err, backend)
+			}
+			return nil
+		})
+		require.NoError(t, err, backend)
+		checkBlockStore(t, backend, store, imported)
+
+		store.close()
+	}
+}
+
+func TestBlockStoreMissingCertificate(t *testing.T) {
+	stores := openTestBlockStores(t)
+	blk := bookkeeping.Block{}
+	blk.BlockHeader.Round = 7
+	for backend, store := range stores {
+		err := store.batch(func(ctx context.Context, tx blockStoreWriter) error {
+			err := tx.blockInit(nil)
+			require.NoError(t, err, backend)
+			return tx.blockImport(blk, nil)
+		})
+		require.NoError(t, err, backend)
+
+		err = store.snapshot(func(ctx context.Context, tx blockStoreReader) error {
+			blkbuf, certbuf, err := tx.blockGetEncodedCert(7)
+			require.NoError(t, err, backend)
+			require.Equal(t, protocol.Encode(&blk), blkbuf, backend)
+			require.Empty(t, certbuf, backend)
+			_, cert, err := tx.blockGetCert(7)
+			require.NoError(t, err, backend)
+			require.Equal(t, agreement.Certificate{}, cert, backend)
+			return nil
+		})
+		require.NoError(t, err, backend)
+		store.close()
+	}
+}
@@ -20,7 +20,6 @@ import (
 	"archive/tar"
 	"compress/gzip"
 	"context"
-	"database/sql"
 	"fmt"
 	"hash"
 	"io"
@@ -42,6 +41,10 @@ const (
 
 	// catchpointFileVersion is the catchpoint file version
 	catchpointFileVersion = uint64(0200)
+
+	// catchpointDeltaFileVersion is the version of the catchpoint delta files, which contain only the accounts modified since
+	// the base catchpoint. Deleted accounts are represented by an empty account data.
+	catchpointDeltaFileVersion = uint64(0201)
 )
 
 // catchpointWriter is the struct managing the persistence of accounts data into the catchpoint file.
@@ -52,7 +55,7 @@ type catchpointWriter struct {
 	ctx               context.Context
 	hasher            hash.Hash
 	innerWriter       io.WriteCloser
-	tx                *sql.Tx
+	tx                trackerStoreReader
 	filePath          string
 	file              *os.File
 	gzip              *gzip.Writer
@@ -63,10 +66,13 @@ type catchpointWriter struct {
 	fileHeader        *CatchpointFileHeader
 	balancesChunkNum  uint64
 	writtenBytes      int64
+	uncompressed      *countingWriter
 	blocksRound       basics.Round
 	blockHeaderDigest crypto.Digest
 	label             string
-	accountsIterator  encodedAccountsBatchIter
+	accountsIterator  encodedAccountsIterator
+	baseRound         basics.Round
+	baseLabel         string
 }
 
 type encodedBalanceRecord struct {
@@ -89,6 +95,9 @@ type CatchpointFileHeader struct {
 	TotalChunks       uint64                   `codec:"chunksCount"`
 	Catchpoint        string                   `codec:"catchpoint"`
 	BlockHeaderDigest crypto.Digest            `codec:"blockHeaderDigest"`
+	// BaseRound and BaseCatchpoint are set on catchpoint delta files, and identify the catchpoint the delta needs to be applied on.
+	BaseRound      basics.Round `codec:"baseRound"`
+	BaseCatchpoint string       `codec:"baseCatchpoint"`
 }
 
 type catchpointFileBalancesChunk struct {
@@ -96,17 +105,28 @@ type catchpointFileBalancesChunk struct {
 	Balances []encodedBalanceRecord `codec:"bl,allocbound=BalancesPerCatchpointFileChunk"`
 }
 
-func makeCatchpointWriter(ctx context.Context, filePath string, tx *sql.Tx, blocksRound basics.Round, blockHeaderDigest crypto.Digest, label string) *catchpointWriter {
+func makeCatchpointWriter(ctx context.Context, filePath string, tx trackerStoreReader, blocksRound basics.Round, blockHeaderDigest crypto.Digest, label string) *catchpointWriter {
 	return &catchpointWriter{
 		ctx:               ctx,
 		filePath:          filePath,
 		tx:                tx,
+		accountsIterator:  tx.makeEncodedAccountsIter(),
 		blocksRound:       blocksRound,
 		blockHeaderDigest: blockHeaderDigest,
 		label:             label,
 	}
 }
 
+// makeCatchpointDeltaWriter creates a catchpointWriter which writes only the accounts modified since the catchpoint
+// of the given base round.
+func makeCatchpointDeltaWriter(ctx context.Context, filePath string, tx trackerStoreReader, blocksRound basics.Round, blockHeaderDigest crypto.Digest, label string, baseRound basics.Round, baseLabel string) *catchpointWriter {
+	cw := makeCatchpointWriter(ctx, filePath, tx, blocksRound, blockHeaderDigest, label)
+	cw.accountsIterator = tx.makeCatchpointDeltaAccountsIter()
+	cw.baseRound = baseRound
+	cw.baseLabel = baseLabel
+	return cw
+}
+
 func (cw *catchpointWriter) Abort() error {
 	cw.accountsIterator.Close()
 	if cw.tar != nil {
@@ -133,7 +153,8 @@ func (cw *catchpointWriter) WriteStep(stepCtx context.Context) (more bool, err e
 			return
 		}
 		cw.gzip = gzip.NewWriter(cw.file)
-		cw.tar = tar.NewWriter(cw.gzip)
+		cw.uncompressed = &countingWriter{writer: cw.gzip}
+		cw.tar = tar.NewWriter(cw.uncompressed)
 	}
 
 	// have we timed-out / canceled by that point ?
@@ -170,6 +191,12 @@ func (cw *catchpointWriter) WriteStep(stepCtx context.Context) (more bool, err e
 		cw.headerWritten = true
 	}
 
+	if cw.fileHeader.TotalChunks == 0 {
+		// a catchpoint delta file might have no accounts at all.
+		cw.accountsIterator.Close()
+		return false, cw.closeFile()
+	}
+
 	writerRequest := make(chan catchpointFileBalancesChunk, 1)
 	writerResponse := make(chan error, 2)
 	go cw.asyncWriter(writerRequest, writerResponse, cw.balancesChunkNum)
@@ -268,48 +295,62 @@ func (cw *catchpointWriter) asyncWriter(balances chan catchpointFileBalancesChun
 		}
 
 		if len(bc.Balances) < BalancesPerCatchpointFileChunk || balancesChunkNum == cw.fileHeader.TotalChunks {
-			cw.tar.Close()
-			cw.gzip.Close()
-			cw.file.Close()
-			cw.file = nil
-			var fileInfo os.FileInfo
-			fileInfo, err = os.Stat(cw.filePath)
+			err = cw.closeFile()
 			if err != nil {
 				response <- err
-				break
 			}
-			cw.writtenBytes = fileInfo.Size()
 			break
 		}
 	}
 }
 
-func (cw *catchpointWriter) readDatabaseStep(ctx context.Context, tx *sql.Tx) (err error) {
-	cw.balancesChunk.Balances, err = cw.accountsIterator.Next(ctx, tx, BalancesPerCatchpointFileChunk)
+// closeFile completes the writing of the catchpoint file, and records its size.
+func (cw *catchpointWriter) closeFile() error {
+	cw.tar.Close()
+	cw.gzip.Close()
+	cw.file.Close()
+	cw.file = nil
+	fileInfo, err := os.Stat(cw.filePath)
+	if err != nil {
+		return err
+	}
+	cw.writtenBytes = fileInfo.Size()
+	return nil
+}
+
+func (cw *catchpointWriter) readDatabaseStep(ctx context.Context, tx trackerStoreReader) (err error) {
+	cw.balancesChunk.Balances, err = cw.accountsIterator.Next(ctx, BalancesPerCatchpointFileChunk)
 	if err == nil {
 		cw.balancesOffset += BalancesPerCatchpointFileChunk
 	}
 	return
 }
 
-func (cw *catchpointWriter) readHeaderFromDatabase(ctx context.Context, tx *sql.Tx) (err error) {
+func (cw *catchpointWriter) readHeaderFromDatabase(ctx context.Context, tx trackerStoreReader) (err error) {
 	var header CatchpointFileHeader
-	header.BalancesRound, _, err = accountsRound(tx)
+	header.BalancesRound, _, err = tx.accountsRound()
 	if err != nil {
 		return
 	}
-	header.Totals, err = accountsTotals(tx, false)
+	header.Totals, err = tx.accountsTotals(false)
 	if err != nil {
 		return
 	}
-	header.TotalAccounts, err = totalAccounts(context.Background(), tx)
+	header.Version = catchpointFileVersion
+	if cw.baseRound != 0 {
+		header.Version = catchpointDeltaFileVersion
+		header.BaseRound = cw.baseRound
+		header.BaseCatchpoint = cw.baseLabel
+		header.TotalAccounts, err = tx.catchpointDeltaAccountsCount(ctx)
+	} else {
+		header.TotalAccounts, err = tx.totalAccounts(ctx)
+	}
 	if err != nil {
 		return
 	}
 	header.TotalChunks = (header.TotalAccounts + BalancesPerCatchpointFileChunk - 1) / BalancesPerCatchpointFileChunk
 	header.BlocksRound = cw.blocksRound
 	header.Catchpoint = cw.label
-	header.Version = catchpointFileVersion
 	header.BlockHeaderDigest = cw.blockHeaderDigest
 	cw.fileHeader = &header
 	return
@@ -320,6 +361,14 @@ func (cw *catchpointWriter) GetSize() int64 {
 	return cw.writtenBytes
 }
 
+// GetUncompressedSize returns the size of the uncompressed content of the catchpoint file.
+func (cw *catchpointWriter) GetUncompressedSize() int64 {
+	if cw.uncompressed == nil {
+		return 0
+	}
+	return cw.uncompressed.count
+}
+
 // GetBalancesRound returns the round number of the balances to which this catchpoint is generated for.
 func (cw *catchpointWriter) GetBalancesRound() basics.Round {
 	if cw.fileHeader != nil {
@@ -336,6 +385,12 @@ func (cw *catchpointWriter) GetTotalAccounts() uint64 {
 	return 0
 }
 
+// GetBaseRound returns the round of the catchpoint this catchpoint delta file was written relative to, or zero
+// for a complete catchpoint file.
+func (cw *catchpointWriter) GetBaseRound() basics.Round {
+	return cw.baseRound
+}
+
 // GetCatchpoint returns the catchpoint string to which this catchpoint file was generated for.
 func (cw *catchpointWriter) GetCatchpoint() string {
 	if cw.fileHeader != nil {
@@ -362,3 +417,15 @@ func hasContextDeadlineExceeded(ctx context.Context) (contextExceeded bool, cont
 	}
 	return
 }
+
+// countingWriter is an io.Writer which counts the bytes written through it.
+type countingWriter struct {
+	writer io.Writer
+	count  int64
+}
+
+func (w *countingWriter) Write(p []byte) (n int, err error) {
+	n, err = w.writer.Write(p)
+	w.count += int64(n)
+	return
+}
@@ -21,7 +21,6 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
-	"database/sql"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -194,8 +193,8 @@ func TestBasicCatchpointWriter(t *testing.T) {
 	blockHeaderDigest := crypto.Hash([]byte{1, 2, 3})
 	catchpointLabel := fmt.Sprintf("%d#%v", blocksRound, blockHeaderDigest) // this is not a correct way to create a label, but it's good enough for this unit test
 
-	readDb := ml.trackerDB().Rdb
-	err = readDb.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
+	var uncompressedSize int64
+	err = ml.trackerDB().snapshot(func(ctx context.Context, tx trackerStoreReader) (err error) {
 		writer := makeCatchpointWriter(context.Background(), fileName, tx, blocksRound, blockHeaderDigest, catchpointLabel)
 		for {
 			more, err := writer.WriteStep(context.Background())
@@ -204,6 +203,7 @@ func TestBasicCatchpointWriter(t *testing.T) {
 				break
 			}
 		}
+		uncompressedSize = writer.GetUncompressedSize()
 		return
 	})
 	require.NoError(t, err)
@@ -213,6 +213,11 @@ func TestBasicCatchpointWriter(t *testing.T) {
 	require.NoError(t, err)
 	gzipReader, err := gzip.NewReader(bytes.NewBuffer(fileContent))
 	require.NoError(t, err)
+	uncompressedContent, err := ioutil.ReadAll(gzipReader)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(uncompressedContent)), uncompressedSize)
+	gzipReader, err = gzip.NewReader(bytes.NewBuffer(fileContent))
+	require.NoError(t, err)
 	tarReader := tar.NewReader(gzipReader)
 	defer gzipReader.Close()
 	for {
@@ -291,8 +296,7 @@ func TestFullCatchpointWriter(t *testing.T) {
 	blocksRound := basics.Round(12345)
 	blockHeaderDigest := crypto.Hash([]byte{1, 2, 3})
 	catchpointLabel := fmt.Sprintf("%d#%v", blocksRound, blockHeaderDigest) // this is not a correct way to create a label, but it's good enough for this unit test
-	readDb := ml.trackerDB().Rdb
-	err = readDb.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
+	err = ml.trackerDB().snapshot(func(ctx context.Context, tx trackerStoreReader) (err error) {
 		writer := makeCatchpointWriter(context.Background(), fileName, tx, blocksRound, blockHeaderDigest, catchpointLabel)
 		for {
 			more, err := writer.WriteStep(context.Background())
@@ -353,8 +357,8 @@ func TestFullCatchpointWriter(t *testing.T) {
 		require.NoError(t, err)
 	}
 
-	err = l.trackerDBs.Wdb.Atomic(func(ctx context.Context, tx *sql.Tx) error {
-		err := applyCatchpointStagingBalances(ctx, tx, 0)
+	err = l.trackerDBs.batch(func(ctx context.Context, tx trackerStoreWriter) error {
+		err := tx.applyCatchpointStagingBalances(ctx, 0)
 		return err
 	})
 	require.NoError(t, err)
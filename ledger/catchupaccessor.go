@@ -18,7 +18,6 @@ package ledger
 
 import (
 	"context"
-	"database/sql"
 	"encoding/hex"
 	"fmt"
 	"strings"
@@ -33,7 +32,6 @@ import (
 	"github.com/algorand/go-algorand/ledger/ledgercore"
 	"github.com/algorand/go-algorand/logging"
 	"github.com/algorand/go-algorand/protocol"
-	"github.com/algorand/go-algorand/util/db"
 	"github.com/algorand/go-algorand/util/metrics"
 )
 
@@ -57,6 +55,15 @@ type CatchpointCatchupAccessor interface {
 	// ProgressStagingBalances deserialize the given bytes as a temporary staging balances
 	ProgressStagingBalances(ctx context.Context, sectionName string, bytes []byte, progress *CatchpointCatchupAccessorProgress) (err error)
 
+	// GetDownloadProgress returns the persisted progress of the catchpoint file download
+	GetDownloadProgress(ctx context.Context) (progress CatchpointDownloadProgress, err error)
+
+	// SaveDownloadProgress persists the progress of the catchpoint file download, along with the hash of the last processed section
+	SaveDownloadProgress(ctx context.Context, progress CatchpointDownloadProgress) (err error)
+
+	// ResetDownloadProgress clears the persisted progress of the catchpoint file download
+	ResetDownloadProgress(ctx context.Context) (err error)
+
 	// BuildMerkleTrie inserts the account hashes into the merkle trie
 	BuildMerkleTrie(ctx context.Context, progressUpdates func(uint64)) (err error)
 
@@ -95,7 +102,7 @@ type CatchpointCatchupAccessorImpl struct {
 	log logging.Logger
 
 	// Prepared SQL statements for fast accounts DB lookups.
-	accountsq *accountsDbQueries
+	accountsq trackerQueries
 }
 
 // CatchpointCatchupState is the state of the current catchpoint catchup process
@@ -119,9 +126,7 @@ const (
 
 // MakeCatchpointCatchupAccessor creates a CatchpointCatchupAccessor given a ledger
 func MakeCatchpointCatchupAccessor(ledger *Ledger, log logging.Logger) CatchpointCatchupAccessor {
-	rdb := ledger.trackerDB().Rdb
-	wdb := ledger.trackerDB().Wdb
-	accountsq, err := accountsDbInit(rdb.Handle, wdb.Handle)
+	accountsq, err := ledger.trackerDB().queries()
 	if err != nil {
 		log.Warnf("unable to initialize account db in MakeCatchpointCatchupAccessor : %v", err)
 		return nil
@@ -181,38 +186,41 @@ func (c *CatchpointCatchupAccessorImpl) SetLabel(ctx context.Context, label stri
 
 // ResetStagingBalances resets the current staging balances, preparing for a new set of balances to be added
 func (c *CatchpointCatchupAccessorImpl) ResetStagingBalances(ctx context.Context, newCatchup bool) (err error) {
-	wdb := c.ledger.trackerDB().Wdb
+	trackerDBs := c.ledger.trackerDB()
 	if !newCatchup {
 		c.ledger.setSynchronousMode(ctx, c.ledger.synchronousMode)
 	}
 	start := time.Now()
 	ledgerResetstagingbalancesCount.Inc(nil)
-	err = wdb.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
-		err = resetCatchpointStagingBalances(ctx, tx, newCatchup)
+	err = trackerDBs.batch(func(ctx context.Context, tx trackerStoreWriter) (err error) {
+		err = tx.resetCatchpointStagingBalances(ctx, newCatchup)
 		if err != nil {
 			return fmt.Errorf("unable to reset catchpoint catchup balances : %v", err)
 		}
+		_, err = tx.writeCatchpointStateString(ctx, catchpointStateCatchupStagedLabel, "")
+		if err != nil {
+			return err
+		}
+		err = resetCatchpointDownloadProgress(ctx, tx)
+		if err != nil {
+			return err
+		}
 		if !newCatchup {
-			sq, err := accountsDbInit(tx, tx)
-			if err != nil {
-				return fmt.Errorf("unable to initialize accountsDbInit: %v", err)
-			}
-			defer sq.close()
-			_, err = sq.writeCatchpointStateUint64(ctx, catchpointStateCatchupBalancesRound, 0)
+			_, err = tx.writeCatchpointStateUint64(ctx, catchpointStateCatchupBalancesRound, 0)
 			if err != nil {
 				return err
 			}
 
-			_, err = sq.writeCatchpointStateUint64(ctx, catchpointStateCatchupBlockRound, 0)
+			_, err = tx.writeCatchpointStateUint64(ctx, catchpointStateCatchupBlockRound, 0)
 			if err != nil {
 				return err
 			}
 
-			_, err = sq.writeCatchpointStateString(ctx, catchpointStateCatchupLabel, "")
+			_, err = tx.writeCatchpointStateString(ctx, catchpointStateCatchupLabel, "")
 			if err != nil {
 				return err
 			}
-			_, err = sq.writeCatchpointStateUint64(ctx, catchpointStateCatchupState, 0)
+			_, err = tx.writeCatchpointStateUint64(ctx, catchpointStateCatchupState, 0)
 			if err != nil {
 				return fmt.Errorf("unable to write catchpoint catchup state '%s': %v", catchpointStateCatchupState, err)
 			}
@@ -235,6 +243,152 @@ type CatchpointCatchupAccessorProgress struct {
 	// While rebuilding the trie, we don't want to force and reload (some) of these nodes into the cache for each catchpoint file chunk.
 	cachedTrie     *merkletrie.Trie
 	evictFrequency uint64
+
+	// deltaHeader is the header of the catchpoint delta file being processed, or nil when processing a complete catchpoint file.
+	deltaHeader *CatchpointFileHeader
+
+	// DownloadProgress, when set, is persisted by ProgressStagingBalances in the same database transaction as the staged section,
+	// so that a resumed download would never stage the same section twice. Its accounts counters are updated to match this progress.
+	DownloadProgress *CatchpointDownloadProgress
+}
+
+// CatchpointDownloadProgress is the persisted progress of a catchpoint file download. It allows an interrupted download to be
+// resumed, either from the same peer or from another one, rather than starting over.
+type CatchpointDownloadProgress struct {
+	// Round is the round of the catchpoint file being downloaded. Zero indicates that there is no download in progress.
+	Round basics.Round
+	// ReceivedBytes is the number of bytes of the uncompressed catchpoint file stream which were received and processed.
+	ReceivedBytes uint64
+	// SectionOffset is the offset of the last processed section within the uncompressed catchpoint file stream.
+	SectionOffset uint64
+	// Sections is the number of catchpoint file sections which were processed.
+	Sections uint64
+	// SectionHash is the hash of the last processed section. It is used to verify that the catchpoint file the download is
+	// resumed from is identical to the one that was partially processed.
+	SectionHash crypto.Digest
+
+	TotalAccounts     uint64
+	TotalChunks       uint64
+	ProcessedAccounts uint64
+	ProcessedBytes    uint64
+}
+
+// StagingProgress returns the staging balances progress matching the persisted download progress.
+func (p CatchpointDownloadProgress) StagingProgress() CatchpointCatchupAccessorProgress {
+	return CatchpointCatchupAccessorProgress{
+		TotalAccounts:     p.TotalAccounts,
+		ProcessedAccounts: p.ProcessedAccounts,
+		ProcessedBytes:    p.ProcessedBytes,
+		TotalChunks:       p.TotalChunks,
+		SeenHeader:        p.Sections > 0,
+	}
+}
+
+// uint64Fields returns the catchpoint state variables the uint64 fields of the download progress are stored in.
+func (p *CatchpointDownloadProgress) uint64Fields() map[catchpointState]*uint64 {
+	return map[catchpointState]*uint64{
+		catchpointStateCatchupDownloadRound:             (*uint64)(&p.Round),
+		catchpointStateCatchupDownloadBytes:             &p.ReceivedBytes,
+		catchpointStateCatchupDownloadSectionOffset:     &p.SectionOffset,
+		catchpointStateCatchupDownloadSections:          &p.Sections,
+		catchpointStateCatchupDownloadTotalAccounts:     &p.TotalAccounts,
+		catchpointStateCatchupDownloadTotalChunks:       &p.TotalChunks,
+		catchpointStateCatchupDownloadProcessedAccounts: &p.ProcessedAccounts,
+		catchpointStateCatchupDownloadProcessedBytes:    &p.ProcessedBytes,
+	}
+}
+
+// GetDownloadProgress returns the persisted progress of the catchpoint file download
+func (c *CatchpointCatchupAccessorImpl) GetDownloadProgress(ctx context.Context) (progress CatchpointDownloadProgress, err error) {
+	for stateName, field := range progress.uint64Fields() {
+		*field, _, err = c.accountsq.readCatchpointStateUint64(ctx, stateName)
+		if err != nil {
+			return CatchpointDownloadProgress{}, fmt.Errorf("unable to read catchpoint catchup state '%s': %v", stateName, err)
+		}
+	}
+	if progress.Sections == 0 {
+		return
+	}
+	stateName := catchpointStateCatchupDownloadSectionHash(progress.Sections - 1)
+	sectionHash, _, err := c.accountsq.readCatchpointStateString(ctx, stateName)
+	if err != nil {
+		return CatchpointDownloadProgress{}, fmt.Errorf("unable to read catchpoint catchup state '%s': %v", stateName, err)
+	}
+	progress.SectionHash, err = crypto.DigestFromString(sectionHash)
+	if err != nil {
+		return CatchpointDownloadProgress{}, fmt.Errorf("unable to parse catchpoint catchup state '%s': %v", stateName, err)
+	}
+	return
+}
+
+// SaveDownloadProgress persists the progress of the catchpoint file download, along with the hash of the last processed section
+func (c *CatchpointCatchupAccessorImpl) SaveDownloadProgress(ctx context.Context, progress CatchpointDownloadProgress) (err error) {
+	trackerDBs := c.ledger.trackerDB()
+	return trackerDBs.batch(func(ctx context.Context, tx trackerStoreWriter) (err error) {
+		return writeCatchpointDownloadProgress(ctx, tx, progress)
+	})
+}
+
+// writeCatchpointDownloadProgress writes the catchpoint file download progress variables, along with the hash of the last processed section.
+func writeCatchpointDownloadProgress(ctx context.Context, tx trackerStoreWriter, progress CatchpointDownloadProgress) (err error) {
+	for stateName, field := range progress.uint64Fields() {
+		_, err = tx.writeCatchpointStateUint64(ctx, stateName, *field)
+		if err != nil {
+			return fmt.Errorf("unable to write catchpoint catchup state '%s': %v", stateName, err)
+		}
+	}
+	if progress.Sections == 0 {
+		return nil
+	}
+	stateName := catchpointStateCatchupDownloadSectionHash(progress.Sections - 1)
+	_, err = tx.writeCatchpointStateString(ctx, stateName, progress.SectionHash.String())
+	if err != nil {
+		return fmt.Errorf("unable to write catchpoint catchup state '%s': %v", stateName, err)
+	}
+	return nil
+}
+
+// writeStagedDownloadProgress writes the download progress attached to the given catchup progress, if any, with the accounts
+// counters the catchup progress would have once the section being staged is committed.
+func writeStagedDownloadProgress(ctx context.Context, tx trackerStoreWriter, progress *CatchpointCatchupAccessorProgress, totalAccounts, totalChunks, processedAccounts, processedBytes uint64) error {
+	if progress.DownloadProgress == nil {
+		return nil
+	}
+	progress.DownloadProgress.TotalAccounts = totalAccounts
+	progress.DownloadProgress.TotalChunks = totalChunks
+	progress.DownloadProgress.ProcessedAccounts = processedAccounts
+	progress.DownloadProgress.ProcessedBytes = processedBytes
+	return writeCatchpointDownloadProgress(ctx, tx, *progress.DownloadProgress)
+}
+
+// ResetDownloadProgress clears the persisted progress of the catchpoint file download
+func (c *CatchpointCatchupAccessorImpl) ResetDownloadProgress(ctx context.Context) (err error) {
+	trackerDBs := c.ledger.trackerDB()
+	return trackerDBs.batch(func(ctx context.Context, tx trackerStoreWriter) error {
+		return resetCatchpointDownloadProgress(ctx, tx)
+	})
+}
+
+// resetCatchpointDownloadProgress deletes the catchpoint file download progress variables, including the hashes of all the processed sections.
+func resetCatchpointDownloadProgress(ctx context.Context, tx trackerStoreWriter) error {
+	sections, _, err := tx.readCatchpointStateUint64(ctx, catchpointStateCatchupDownloadSections)
+	if err != nil {
+		return fmt.Errorf("unable to read catchpoint catchup state '%s': %v", catchpointStateCatchupDownloadSections, err)
+	}
+	for section := uint64(0); section < sections; section++ {
+		_, err = tx.writeCatchpointStateString(ctx, catchpointStateCatchupDownloadSectionHash(section), "")
+		if err != nil {
+			return err
+		}
+	}
+	var progress CatchpointDownloadProgress
+	for stateName := range progress.uint64Fields() {
+		_, err = tx.writeCatchpointStateUint64(ctx, stateName, 0)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // ProgressStagingBalances deserialize the given bytes as a temporary staging balances
@@ -260,28 +414,45 @@ func (c *CatchpointCatchupAccessorImpl) processStagingContent(ctx context.Contex
 	if err != nil {
 		return err
 	}
-	if fileHeader.Version != catchpointFileVersion {
+	switch fileHeader.Version {
+	case catchpointFileVersion:
+	case catchpointDeltaFileVersion:
+		// a catchpoint delta can only be applied on top of the catchpoint it was generated relative to.
+		var stagedLabel string
+		stagedLabel, _, err = c.accountsq.readCatchpointStateString(ctx, catchpointStateCatchupStagedLabel)
+		if err != nil {
+			return fmt.Errorf("CatchpointCatchupAccessorImpl::processStagingContent: unable to read catchpoint catchup state '%s': %v", catchpointStateCatchupStagedLabel, err)
+		}
+		if stagedLabel == "" || stagedLabel != fileHeader.BaseCatchpoint {
+			return fmt.Errorf("CatchpointCatchupAccessorImpl::processStagingContent: unable to apply catchpoint delta of '%s' on top of the staged catchpoint '%s'", fileHeader.BaseCatchpoint, stagedLabel)
+		}
+	default:
 		return fmt.Errorf("CatchpointCatchupAccessorImpl::processStagingContent: unable to process catchpoint - version %d is not supported", fileHeader.Version)
 	}
 
 	// the following fields are now going to be ignored. We could add these to the database and validate these
 	// later on:
 	// TotalAccounts, TotalAccounts, Catchpoint, BlockHeaderDigest, BalancesRound
-	wdb := c.ledger.trackerDB().Wdb
+	trackerDBs := c.ledger.trackerDB()
 	start := time.Now()
 	ledgerProcessstagingcontentCount.Inc(nil)
-	err = wdb.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
-		sq, err := accountsDbInit(tx, tx)
+	err = trackerDBs.batch(func(ctx context.Context, tx trackerStoreWriter) (err error) {
+		_, err = tx.writeCatchpointStateUint64(ctx, catchpointStateCatchupBlockRound, uint64(fileHeader.BlocksRound))
 		if err != nil {
-			return fmt.Errorf("CatchpointCatchupAccessorImpl::processStagingContent: unable to initialize accountsDbInit: %v", err)
+			return fmt.Errorf("CatchpointCatchupAccessorImpl::processStagingContent: unable to write catchpoint catchup state '%s': %v", catchpointStateCatchupBlockRound, err)
+		}
+		if fileHeader.Version == catchpointFileVersion {
+			// the label of a catchpoint delta is recorded only once the delta was applied and verified.
+			_, err = tx.writeCatchpointStateString(ctx, catchpointStateCatchupStagedLabel, fileHeader.Catchpoint)
+			if err != nil {
+				return fmt.Errorf("CatchpointCatchupAccessorImpl::processStagingContent: unable to write catchpoint catchup state '%s': %v", catchpointStateCatchupStagedLabel, err)
+			}
 		}
-		defer sq.close()
-		_, err = sq.writeCatchpointStateUint64(ctx, catchpointStateCatchupBlockRound, uint64(fileHeader.BlocksRound))
+		err = tx.accountsPutTotals(fileHeader.Totals, true)
 		if err != nil {
-			return fmt.Errorf("CatchpointCatchupAccessorImpl::processStagingContent: unable to write catchpoint catchup state '%s': %v", catchpointStateCatchupBlockRound, err)
+			return
 		}
-		err = accountsPutTotals(tx, fileHeader.Totals, true)
-		return
+		return writeStagedDownloadProgress(ctx, tx, progress, fileHeader.TotalAccounts, fileHeader.TotalChunks, 0, 0)
 	})
 	ledgerProcessstagingcontentMicros.AddMicrosecondsSince(start, nil)
 	if err == nil {
@@ -289,6 +460,12 @@ func (c *CatchpointCatchupAccessorImpl) processStagingContent(ctx context.Contex
 		progress.TotalAccounts = fileHeader.TotalAccounts
 		progress.TotalChunks = fileHeader.TotalChunks
 		c.ledger.setSynchronousMode(ctx, c.ledger.accountsRebuildSynchronousMode)
+		if fileHeader.Version == catchpointDeltaFileVersion {
+			progress.deltaHeader = &fileHeader
+			if progress.TotalAccounts == 0 {
+				err = c.finishStagingDelta(ctx, progress)
+			}
+		}
 	}
 	return err
 }
@@ -309,109 +486,237 @@ func (c *CatchpointCatchupAccessorImpl) processStagingBalances(ctx context.Conte
 		return fmt.Errorf("processStagingBalances received a chunk with no accounts")
 	}
 
-	wdb := c.ledger.trackerDB().Wdb
+	if progress.deltaHeader != nil {
+		return c.processStagingDeltaBalances(ctx, bytes, balances.Balances, progress)
+	}
+
+	trackerDBs := c.ledger.trackerDB()
 	start := time.Now()
 	ledgerProcessstagingbalancesCount.Inc(nil)
 
 	normalizedAccountBalances, err := prepareNormalizedBalances(balances.Balances, c.ledger.GenesisProto())
 
-	wg := sync.WaitGroup{}
-	errChan := make(chan error, 3)
+	hasCreatables := false
+	for _, accBal := range normalizedAccountBalances {
+		// the holdings are indexed alongside the creatables.
+		if len(accBal.accountData.AssetParams) > 0 || len(accBal.accountData.AppParams) > 0 ||
+			len(accBal.accountData.Assets) > 0 || len(accBal.accountData.AppLocalStates) > 0 {
+			hasCreatables = true
+			break
+		}
+	}
+	writeBalances := func(ctx context.Context, tx trackerStoreWriter) error {
+		return tx.writeCatchpointStagingBalances(ctx, normalizedAccountBalances)
+	}
+	writeCreatables := func(ctx context.Context, tx trackerStoreWriter) error {
+		if !hasCreatables {
+			return nil
+		}
+		return tx.writeCatchpointStagingCreatable(ctx, normalizedAccountBalances)
+	}
+	writeHashes := func(ctx context.Context, tx trackerStoreWriter) error {
+		return tx.writeCatchpointStagingHashes(ctx, normalizedAccountBalances)
+	}
 
-	// start the balances writer
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		err := wdb.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
-			err = writeCatchpointStagingBalances(ctx, tx, normalizedAccountBalances)
-			if err != nil {
-				return
+	if err == nil && progress.DownloadProgress != nil {
+		// the download progress has to be committed atomically with the staged section, so all the writers share a single transaction.
+		err = trackerDBs.batch(func(ctx context.Context, tx trackerStoreWriter) (err error) {
+			for _, writer := range []func(context.Context, trackerStoreWriter) error{writeBalances, writeCreatables, writeHashes} {
+				err = writer(ctx, tx)
+				if err != nil {
+					return err
+				}
 			}
-			return nil
+			return writeStagedDownloadProgress(ctx, tx, progress, progress.TotalAccounts, progress.TotalChunks,
+				progress.ProcessedAccounts+uint64(len(balances.Balances)), progress.ProcessedBytes+uint64(len(bytes)))
 		})
-		if err != nil {
-			errChan <- err
-		}
-	}()
+	} else if err == nil {
+		err = c.writeStagingBalancesConcurrently(writeBalances, writeCreatables, writeHashes)
+	}
 
-	// on a in-memory database, wait for the writer to finish before starting the new writer
-	if wdb.IsSharedCacheConnection() {
-		wg.Wait()
+	ledgerProcessstagingbalancesMicros.AddMicrosecondsSince(start, nil)
+	if err == nil {
+		progress.ProcessedAccounts += uint64(len(balances.Balances))
+		progress.ProcessedBytes += uint64(len(bytes))
 	}
 
-	// starts the creatables writer
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		hasCreatables := false
-		for _, accBal := range normalizedAccountBalances {
-			if len(accBal.accountData.AssetParams) > 0 || len(accBal.accountData.AppParams) > 0 {
-				hasCreatables = true
-				break
-			}
-		}
-		if hasCreatables {
-			err := wdb.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
-				err = writeCatchpointStagingCreatable(ctx, tx, normalizedAccountBalances)
-				return err
+	// not strictly required, but clean up the pointer in case of either a failure or when we're done.
+	if err != nil || progress.ProcessedAccounts == progress.TotalAccounts {
+		progress.cachedTrie = nil
+		// restore "normal" synchronous mode
+		c.ledger.setSynchronousMode(ctx, c.ledger.synchronousMode)
+	}
+	return err
+}
+
+// writeStagingBalancesConcurrently runs each of the given staging writers in its own transaction, concurrently unless the
+// database is an in-memory one.
+func (c *CatchpointCatchupAccessorImpl) writeStagingBalancesConcurrently(writers ...func(context.Context, trackerStoreWriter) error) error {
+	trackerDBs := c.ledger.trackerDB()
+	wg := sync.WaitGroup{}
+	errChan := make(chan error, len(writers))
+	for _, writer := range writers {
+		writer := writer
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := trackerDBs.batch(func(ctx context.Context, tx trackerStoreWriter) error {
+				return writer(ctx, tx)
 			})
 			if err != nil {
 				errChan <- err
 			}
+		}()
+		// on a in-memory database, wait for the writer to finish before starting the new writer
+		if trackerDBs.isSharedCacheConnection() {
+			wg.Wait()
 		}
-	}()
-
-	// on a in-memory database, wait for the writer to finish before starting the new writer
-	if wdb.IsSharedCacheConnection() {
-		wg.Wait()
 	}
-
-	// start the accounts pending hashes writer
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		err := wdb.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
-			err = writeCatchpointStagingHashes(ctx, tx, normalizedAccountBalances)
-			if err != nil {
-				return
-			}
-			return err
-		})
-		if err != nil {
-			errChan <- err
-		}
-	}()
-
 	wg.Wait()
 	select {
 	case err := <-errChan:
 		return err
 	default:
 	}
+	return nil
+}
 
-	ledgerProcessstagingbalancesMicros.AddMicrosecondsSince(start, nil)
-	if err == nil {
-		progress.ProcessedAccounts += uint64(len(balances.Balances))
-		progress.ProcessedBytes += uint64(len(bytes))
+// processStagingDeltaBalances applies the accounts of a catchpoint delta file chunk on top of the staging balances, updating
+// the staging merkle trie accordingly. Accounts with an empty account data are deleted.
+func (c *CatchpointCatchupAccessorImpl) processStagingDeltaBalances(ctx context.Context, bytes []byte, balances []encodedBalanceRecord, progress *CatchpointCatchupAccessorProgress) (err error) {
+	start := time.Now()
+	ledgerProcessstagingdeltaCount.Inc(nil)
+
+	proto := c.ledger.GenesisProto()
+	normalizedAccountBalances, err := prepareNormalizedBalances(balances, proto)
+	if err != nil {
+		return err
+	}
+	addresses := make([]basics.Address, len(normalizedAccountBalances))
+	updatedAccountBalances := make([]normalizedAccountBalance, 0, len(normalizedAccountBalances))
+	for i, balance := range normalizedAccountBalances {
+		addresses[i] = balance.address
+		if !balance.accountData.IsZero() {
+			updatedAccountBalances = append(updatedAccountBalances, balance)
+		}
 	}
 
-	// not strictly required, but clean up the pointer in case of either a failure or when we're done.
-	if err != nil || progress.ProcessedAccounts == progress.TotalAccounts {
+	trackerDBs := c.ledger.trackerDB()
+	err = trackerDBs.batch(func(ctx context.Context, tx trackerStoreWriter) (err error) {
+		stagedBalances, err := tx.readCatchpointStagingBalances(ctx, addresses)
+		if err != nil {
+			return err
+		}
+		previousAccountBalances, err := prepareNormalizedBalances(stagedBalances, proto)
+		if err != nil {
+			return err
+		}
+		err = tx.deleteCatchpointStagingBalances(ctx, previousAccountBalances)
+		if err != nil {
+			return err
+		}
+		err = tx.writeCatchpointStagingBalances(ctx, updatedAccountBalances)
+		if err != nil {
+			return err
+		}
+		err = tx.writeCatchpointStagingCreatable(ctx, updatedAccountBalances)
+		if err != nil {
+			return err
+		}
+
+		mc, err := tx.merkleCommitter(true)
+		if err != nil {
+			return err
+		}
+		if progress.cachedTrie == nil {
+			progress.cachedTrie, err = merkletrie.MakeTrie(mc, TrieMemoryConfig)
+			if err != nil {
+				return err
+			}
+		} else {
+			progress.cachedTrie.SetCommitter(mc)
+		}
+		for _, balance := range previousAccountBalances {
+			var deleted bool
+			deleted, err = progress.cachedTrie.Delete(balance.accountHash)
+			if err != nil {
+				return err
+			}
+			if !deleted {
+				return fmt.Errorf("CatchpointCatchupAccessorImpl::processStagingDeltaBalances: the hash of the staged account %v is missing from the merkle trie", balance.address)
+			}
+		}
+		for _, balance := range updatedAccountBalances {
+			var added bool
+			added, err = progress.cachedTrie.Add(balance.accountHash)
+			if err != nil {
+				return err
+			}
+			if !added {
+				return fmt.Errorf("CatchpointCatchupAccessorImpl::processStagingDeltaBalances: The provided catchpoint delta file contained the same account more than once. hash '%s'", hex.EncodeToString(balance.accountHash))
+			}
+		}
+		_, err = progress.cachedTrie.Evict(true)
+		if err != nil {
+			return err
+		}
+		return writeStagedDownloadProgress(ctx, tx, progress, progress.TotalAccounts, progress.TotalChunks,
+			progress.ProcessedAccounts+uint64(len(balances)), progress.ProcessedBytes+uint64(len(bytes)))
+	})
+	ledgerProcessstagingdeltaMicros.AddMicrosecondsSince(start, nil)
+	if err != nil {
+		// the trie cache might no longer match the staging trie.
 		progress.cachedTrie = nil
-		// restore "normal" synchronous mode
 		c.ledger.setSynchronousMode(ctx, c.ledger.synchronousMode)
+		return err
+	}
+
+	progress.ProcessedAccounts += uint64(len(balances))
+	progress.ProcessedBytes += uint64(len(bytes))
+	if progress.ProcessedAccounts == progress.TotalAccounts {
+		err = c.finishStagingDelta(ctx, progress)
 	}
 	return err
 }
 
+// finishStagingDelta verifies that the staging balances, after the catchpoint delta was applied, match the catchpoint label of the delta.
+// Once verified, the label is recorded as the staged catchpoint, so that subsequent deltas could be applied on top of it.
+func (c *CatchpointCatchupAccessorImpl) finishStagingDelta(ctx context.Context, progress *CatchpointCatchupAccessorProgress) (err error) {
+	header := progress.deltaHeader
+	progress.cachedTrie = nil
+	defer c.ledger.setSynchronousMode(ctx, c.ledger.synchronousMode)
+
+	trackerDBs := c.ledger.trackerDB()
+	return trackerDBs.batch(func(ctx context.Context, tx trackerStoreWriter) (err error) {
+		mc, err := tx.merkleCommitter(true)
+		if err != nil {
+			return fmt.Errorf("unable to make MerkleCommitter: %v", err)
+		}
+		trie, err := merkletrie.MakeTrie(mc, TrieMemoryConfig)
+		if err != nil {
+			return fmt.Errorf("unable to make trie: %v", err)
+		}
+		balancesHash, err := trie.RootHash()
+		if err != nil {
+			return fmt.Errorf("unable to get trie root hash: %v", err)
+		}
+
+		catchpointLabel := ledgercore.MakeCatchpointLabel(header.BlocksRound, header.BlockHeaderDigest, balancesHash, header.Totals).String()
+		if catchpointLabel != header.Catchpoint {
+			return fmt.Errorf("catchpoint delta hash mismatch; expected %s, calculated %s", header.Catchpoint, catchpointLabel)
+		}
+		_, err = tx.writeCatchpointStateString(ctx, catchpointStateCatchupStagedLabel, header.Catchpoint)
+		return err
+	})
+}
+
 // BuildMerkleTrie would process the catchpointpendinghashes and insert all the items in it into the merkle trie
 func (c *CatchpointCatchupAccessorImpl) BuildMerkleTrie(ctx context.Context, progressUpdates func(uint64)) (err error) {
-	wdb := c.ledger.trackerDB().Wdb
-	rdb := c.ledger.trackerDB().Rdb
-	err = wdb.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
+	trackerDBs := c.ledger.trackerDB()
+	err = trackerDBs.batch(func(ctx context.Context, tx trackerStoreWriter) (err error) {
 		// creating the index can take a while, so ensure we don't generate false alerts for no good reason.
-		db.ResetTransactionWarnDeadline(ctx, tx, time.Now().Add(120*time.Second))
-		return createCatchpointStagingHashesIndex(ctx, tx)
+		tx.resetTransactionWarnDeadline(ctx, time.Now().Add(120*time.Second))
+		return tx.createCatchpointStagingHashesIndex(ctx)
 	})
 	if err != nil {
 		return
@@ -430,8 +735,8 @@ func (c *CatchpointCatchupAccessorImpl) BuildMerkleTrie(ctx context.Context, pro
 		defer wg.Done()
 		defer close(writerQueue)
 
-		err := rdb.Atomic(func(transactionCtx context.Context, tx *sql.Tx) (err error) {
-			it := makeCatchpointPendingHashesIterator(trieRebuildAccountChunkSize, tx)
+		err := trackerDBs.snapshot(func(transactionCtx context.Context, tx trackerStoreReader) (err error) {
+			it := tx.makeCatchpointPendingHashesIter(trieRebuildAccountChunkSize)
 			var hashes [][]byte
 			for {
 				hashes, err = it.Next(transactionCtx)
@@ -451,7 +756,7 @@ func (c *CatchpointCatchupAccessorImpl) BuildMerkleTrie(ctx context.Context, pro
 			}
 			// disable the warning for over-long atomic operation execution. It's meaningless here since it's
 			// co-dependent on the other go-routine.
-			db.ResetTransactionWarnDeadline(transactionCtx, tx, time.Now().Add(5*time.Second))
+			tx.resetTransactionWarnDeadline(transactionCtx, time.Now().Add(5*time.Second))
 			return err
 		})
 		if err != nil {
@@ -466,14 +771,14 @@ func (c *CatchpointCatchupAccessorImpl) BuildMerkleTrie(ctx context.Context, pro
 		uncommitedHashesCount := 0
 		keepWriting := true
 		hashesWritten := uint64(0)
-		var mc *MerkleCommitter
+		var mc merkletrie.Committer
 		if progressUpdates != nil {
 			progressUpdates(hashesWritten)
 		}
 
-		err := wdb.Atomic(func(transactionCtx context.Context, tx *sql.Tx) (err error) {
+		err := trackerDBs.batch(func(transactionCtx context.Context, tx trackerStoreWriter) (err error) {
 			// create the merkle trie for the balances
-			mc, err = MakeMerkleCommitter(tx, true)
+			mc, err = tx.merkleCommitter(true)
 			if err != nil {
 				return
 			}
@@ -500,8 +805,8 @@ func (c *CatchpointCatchupAccessorImpl) BuildMerkleTrie(ctx context.Context, pro
 				continue
 			}
 
-			err = rdb.Atomic(func(transactionCtx context.Context, tx *sql.Tx) (err error) {
-				mc, err = MakeMerkleCommitter(tx, true)
+			err = trackerDBs.snapshot(func(transactionCtx context.Context, tx trackerStoreReader) (err error) {
+				mc, err = tx.merkleCommitter(true)
 				if err != nil {
 					return
 				}
@@ -525,10 +830,10 @@ func (c *CatchpointCatchupAccessorImpl) BuildMerkleTrie(ctx context.Context, pro
 			}
 
 			if uncommitedHashesCount >= trieRebuildCommitFrequency {
-				err = wdb.Atomic(func(transactionCtx context.Context, tx *sql.Tx) (err error) {
+				err = trackerDBs.batch(func(transactionCtx context.Context, tx trackerStoreWriter) (err error) {
 					// set a long 30-second window for the evict before warning is generated.
-					db.ResetTransactionWarnDeadline(transactionCtx, tx, time.Now().Add(30*time.Second))
-					mc, err = MakeMerkleCommitter(tx, true)
+					tx.resetTransactionWarnDeadline(transactionCtx, time.Now().Add(30*time.Second))
+					mc, err = tx.merkleCommitter(true)
 					if err != nil {
 						return
 					}
@@ -554,10 +859,10 @@ func (c *CatchpointCatchupAccessorImpl) BuildMerkleTrie(ctx context.Context, pro
 			return
 		}
 		if uncommitedHashesCount > 0 {
-			err = wdb.Atomic(func(transactionCtx context.Context, tx *sql.Tx) (err error) {
+			err = trackerDBs.batch(func(transactionCtx context.Context, tx trackerStoreWriter) (err error) {
 				// set a long 30-second window for the evict before warning is generated.
-				db.ResetTransactionWarnDeadline(transactionCtx, tx, time.Now().Add(30*time.Second))
-				mc, err = MakeMerkleCommitter(tx, true)
+				tx.resetTransactionWarnDeadline(transactionCtx, time.Now().Add(30*time.Second))
+				mc, err = tx.merkleCommitter(true)
 				if err != nil {
 					return
 				}
@@ -596,7 +901,7 @@ func (c *CatchpointCatchupAccessorImpl) GetCatchupBlockRound(ctx context.Context
 
 // VerifyCatchpoint verifies that the catchpoint is valid by reconstructing the label.
 func (c *CatchpointCatchupAccessorImpl) VerifyCatchpoint(ctx context.Context, blk *bookkeeping.Block) (err error) {
-	rdb := c.ledger.trackerDB().Rdb
+	trackerDBs := c.ledger.trackerDB()
 	var balancesHash crypto.Digest
 	var blockRound basics.Round
 	var totals ledgercore.AccountTotals
@@ -616,9 +921,9 @@ func (c *CatchpointCatchupAccessorImpl) VerifyCatchpoint(ctx context.Context, bl
 
 	start := time.Now()
 	ledgerVerifycatchpointCount.Inc(nil)
-	err = rdb.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
+	err = trackerDBs.snapshot(func(ctx context.Context, tx trackerStoreReader) (err error) {
 		// create the merkle trie for the balances
-		mc, err0 := MakeMerkleCommitter(tx, true)
+		mc, err0 := tx.merkleCommitter(true)
 		if err0 != nil {
 			return fmt.Errorf("unable to make MerkleCommitter: %v", err0)
 		}
@@ -633,7 +938,7 @@ func (c *CatchpointCatchupAccessorImpl) VerifyCatchpoint(ctx context.Context, bl
 			return fmt.Errorf("unable to get trie root hash: %v", err)
 		}
 
-		totals, err = accountsTotals(tx, true)
+		totals, err = tx.accountsTotals(true)
 		if err != nil {
 			return fmt.Errorf("unable to get accounts totals: %v", err)
 		}
@@ -661,16 +966,11 @@ func (c *CatchpointCatchupAccessorImpl) StoreBalancesRound(ctx context.Context,
 	// calculate the balances round and store it. It *should* be identical to the one in the catchpoint file header, but we don't want to
 	// trust the one in the catchpoint file header, so we'll calculate it ourselves.
 	balancesRound := blk.Round() - basics.Round(config.Consensus[blk.CurrentProtocol].MaxBalLookback)
-	wdb := c.ledger.trackerDB().Wdb
+	trackerDBs := c.ledger.trackerDB()
 	start := time.Now()
 	ledgerStorebalancesroundCount.Inc(nil)
-	err = wdb.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
-		sq, err := accountsDbInit(tx, tx)
-		if err != nil {
-			return fmt.Errorf("CatchpointCatchupAccessorImpl::StoreBalancesRound: unable to initialize accountsDbInit: %v", err)
-		}
-		defer sq.close()
-		_, err = sq.writeCatchpointStateUint64(ctx, catchpointStateCatchupBalancesRound, uint64(balancesRound))
+	err = trackerDBs.batch(func(ctx context.Context, tx trackerStoreWriter) (err error) {
+		_, err = tx.writeCatchpointStateUint64(ctx, catchpointStateCatchupBalancesRound, uint64(balancesRound))
 		if err != nil {
 			return fmt.Errorf("CatchpointCatchupAccessorImpl::StoreBalancesRound: unable to write catchpoint catchup state '%s': %v", catchpointStateCatchupBalancesRound, err)
 		}
@@ -685,8 +985,8 @@ func (c *CatchpointCatchupAccessorImpl) StoreFirstBlock(ctx context.Context, blk
 	blockDbs := c.ledger.blockDB()
 	start := time.Now()
 	ledgerStorefirstblockCount.Inc(nil)
-	err = blockDbs.Wdb.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
-		return blockStartCatchupStaging(tx, *blk)
+	err = blockDbs.batch(func(ctx context.Context, tx blockStoreWriter) (err error) {
+		return tx.blockStartCatchupStaging(*blk)
 	})
 	ledgerStorefirstblockMicros.AddMicrosecondsSince(start, nil)
 	if err != nil {
@@ -700,8 +1000,8 @@ func (c *CatchpointCatchupAccessorImpl) StoreBlock(ctx context.Context, blk *boo
 	blockDbs := c.ledger.blockDB()
 	start := time.Now()
 	ledgerCatchpointStoreblockCount.Inc(nil)
-	err = blockDbs.Wdb.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
-		return blockPutStaging(tx, *blk)
+	err = blockDbs.batch(func(ctx context.Context, tx blockStoreWriter) (err error) {
+		return tx.blockPutStaging(*blk)
 	})
 	ledgerCatchpointStoreblockMicros.AddMicrosecondsSince(start, nil)
 	if err != nil {
@@ -715,12 +1015,12 @@ func (c *CatchpointCatchupAccessorImpl) FinishBlocks(ctx context.Context, applyC
 	blockDbs := c.ledger.blockDB()
 	start := time.Now()
 	ledgerCatchpointFinishblocksCount.Inc(nil)
-	err = blockDbs.Wdb.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
+	err = blockDbs.batch(func(ctx context.Context, tx blockStoreWriter) (err error) {
 		if applyChanges {
-			return blockCompleteCatchup(tx)
+			return tx.blockCompleteCatchup()
 		}
 		// TODO: unused, either actually implement cleanup on catchpoint failure, or delete this
-		return blockAbortCatchup(tx)
+		return tx.blockAbortCatchup()
 	})
 	ledgerCatchpointFinishblocksMicros.AddMicrosecondsSince(start, nil)
 	if err != nil {
@@ -734,8 +1034,8 @@ func (c *CatchpointCatchupAccessorImpl) EnsureFirstBlock(ctx context.Context) (b
 	blockDbs := c.ledger.blockDB()
 	start := time.Now()
 	ledgerCatchpointEnsureblock1Count.Inc(nil)
-	err = blockDbs.Wdb.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
-		blk, err = blockEnsureSingleBlock(tx)
+	err = blockDbs.batch(func(ctx context.Context, tx blockStoreWriter) (err error) {
+		blk, err = tx.blockEnsureSingleBlock()
 		return
 	})
 	ledgerCatchpointEnsureblock1Micros.AddMicrosecondsSince(start, nil)
@@ -762,60 +1062,74 @@ func (c *CatchpointCatchupAccessorImpl) CompleteCatchup(ctx context.Context) (er
 
 // finishBalances concludes the catchup of the balances(tracker) database.
 func (c *CatchpointCatchupAccessorImpl) finishBalances(ctx context.Context) (err error) {
-	wdb := c.ledger.trackerDB().Wdb
+	trackerDBs := c.ledger.trackerDB()
 	start := time.Now()
 	ledgerCatchpointFinishBalsCount.Inc(nil)
-	err = wdb.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
+	err = trackerDBs.batch(func(ctx context.Context, tx trackerStoreWriter) (err error) {
 		var balancesRound uint64
 		var totals ledgercore.AccountTotals
 
-		sq, err := accountsDbInit(tx, tx)
+		balancesRound, _, err = tx.readCatchpointStateUint64(ctx, catchpointStateCatchupBalancesRound)
+		if err != nil {
+			return err
+		}
+
+		totals, err = tx.accountsTotals(true)
+		if err != nil {
+			return err
+		}
+
+		err = tx.applyCatchpointStagingBalances(ctx, basics.Round(balancesRound))
 		if err != nil {
-			return fmt.Errorf("unable to initialize accountsDbInit: %v", err)
+			return err
 		}
-		defer sq.close()
 
-		balancesRound, _, err = sq.readCatchpointStateUint64(ctx, catchpointStateCatchupBalancesRound)
+		err = tx.accountsPutTotals(totals, false)
 		if err != nil {
 			return err
 		}
 
-		totals, err = accountsTotals(tx, true)
+		err = tx.resetCatchpointStagingBalances(ctx, false)
 		if err != nil {
 			return err
 		}
 
-		err = applyCatchpointStagingBalances(ctx, tx, basics.Round(balancesRound))
+		_, err = tx.writeCatchpointStateUint64(ctx, catchpointStateCatchupBalancesRound, 0)
 		if err != nil {
 			return err
 		}
 
-		err = accountsPutTotals(tx, totals, false)
+		_, err = tx.writeCatchpointStateUint64(ctx, catchpointStateCatchupBlockRound, 0)
 		if err != nil {
 			return err
 		}
 
-		err = resetCatchpointStagingBalances(ctx, tx, false)
+		_, err = tx.writeCatchpointStateString(ctx, catchpointStateCatchupLabel, "")
 		if err != nil {
 			return err
 		}
 
-		_, err = sq.writeCatchpointStateUint64(ctx, catchpointStateCatchupBalancesRound, 0)
+		_, err = tx.writeCatchpointStateString(ctx, catchpointStateCatchupStagedLabel, "")
 		if err != nil {
 			return err
 		}
 
-		_, err = sq.writeCatchpointStateUint64(ctx, catchpointStateCatchupBlockRound, 0)
+		err = resetCatchpointDownloadProgress(ctx, tx)
 		if err != nil {
 			return err
 		}
 
-		_, err = sq.writeCatchpointStateString(ctx, catchpointStateCatchupLabel, "")
+		// the accounts were replaced altogether, so the next catchpoint file needs to be a complete one.
+		err = tx.catchpointDeltaReset(ctx)
+		if err != nil {
+			return err
+		}
+		_, err = tx.writeCatchpointStateUint64(ctx, catchpointStateDeltaBaseRound, 0)
 		if err != nil {
 			return err
 		}
 
-		_, err = sq.writeCatchpointStateUint64(ctx, catchpointStateCatchupState, 0)
+		_, err = tx.writeCatchpointStateUint64(ctx, catchpointStateCatchupState, 0)
 		if err != nil {
 			return fmt.Errorf("unable to write catchpoint catchup state '%s': %v", catchpointStateCatchupState, err)
 		}
@@ -832,6 +1146,8 @@ var ledgerProcessstagingcontentCount = metrics.NewCounter("ledger_catchup_proces
 var ledgerProcessstagingcontentMicros = metrics.NewCounter("ledger_catchup_processstagingcontent_micros", "µs spent")
 var ledgerProcessstagingbalancesCount = metrics.NewCounter("ledger_catchup_processstagingbalances_count", "calls")
 var ledgerProcessstagingbalancesMicros = metrics.NewCounter("ledger_catchup_processstagingbalances_micros", "µs spent")
+var ledgerProcessstagingdeltaCount = metrics.NewCounter("ledger_catchup_processstagingdelta_count", "calls")
+var ledgerProcessstagingdeltaMicros = metrics.NewCounter("ledger_catchup_processstagingdelta_micros", "µs spent")
 var ledgerVerifycatchpointCount = metrics.NewCounter("ledger_catchup_verifycatchpoint_count", "calls")
 var ledgerVerifycatchpointMicros = metrics.NewCounter("ledger_catchup_verifycatchpoint_micros", "µs spent")
 var ledgerStorebalancesroundCount = metrics.NewCounter("ledger_catchup_storebalancesround_count", "calls")
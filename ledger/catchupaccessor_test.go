@@ -191,6 +191,112 @@ func TestCatchupAcessorFoo(t *testing.T) {
 	require.NoError(t, err, "ResetStagingBalances")
 }
 
+func TestCatchupAccessorDownloadProgress(t *testing.T) {
+	log := logging.TestingLog(t)
+	genesisInitState, _ := testGenerateInitState(t, protocol.ConsensusCurrentVersion, 100)
+	for _, backend := range []string{StorageBackendSQLite, StorageBackendLevelDB} {
+		cfg := config.GetDefaultLocal()
+		cfg.LedgerStorageBackend = backend
+		l, err := OpenLedger(log, t.Name()+backend, true, genesisInitState, cfg)
+		require.NoError(t, err, backend)
+		catchpointAccessor := MakeCatchpointCatchupAccessor(l, log)
+		ctx := context.Background()
+
+		progress, err := catchpointAccessor.GetDownloadProgress(ctx)
+		require.NoError(t, err, backend)
+		require.Equal(t, CatchpointDownloadProgress{}, progress, backend)
+
+		progress = CatchpointDownloadProgress{Round: 1000, TotalAccounts: 30, TotalChunks: 3}
+		for section := uint64(0); section < 3; section++ {
+			progress.SectionOffset = progress.ReceivedBytes
+			progress.ReceivedBytes += 1024
+			progress.Sections++
+			progress.SectionHash = crypto.Hash([]byte(fmt.Sprintf("section %d", section)))
+			progress.ProcessedAccounts += 10
+			progress.ProcessedBytes += 1000
+			require.NoError(t, catchpointAccessor.SaveDownloadProgress(ctx, progress), backend)
+
+			persisted, err := catchpointAccessor.GetDownloadProgress(ctx)
+			require.NoError(t, err, backend)
+			require.Equal(t, progress, persisted, backend)
+		}
+		require.Equal(t, CatchpointCatchupAccessorProgress{TotalAccounts: 30, ProcessedAccounts: 30, ProcessedBytes: 3000, TotalChunks: 3, SeenHeader: true}, progress.StagingProgress(), backend)
+
+		// resetting the staging balances discards the download progress as well.
+		require.NoError(t, catchpointAccessor.ResetStagingBalances(ctx, true), backend)
+		progress, err = catchpointAccessor.GetDownloadProgress(ctx)
+		require.NoError(t, err, backend)
+		require.Equal(t, CatchpointDownloadProgress{}, progress, backend)
+		queries, err := l.trackerDB().queries()
+		require.NoError(t, err, backend)
+		for section := uint64(0); section < 3; section++ {
+			_, def, err := queries.readCatchpointStateString(ctx, catchpointStateCatchupDownloadSectionHash(section))
+			require.NoError(t, err, backend)
+			require.True(t, def, backend)
+		}
+
+		require.NoError(t, catchpointAccessor.SaveDownloadProgress(ctx, CatchpointDownloadProgress{Round: 1000, Sections: 1}), backend)
+		require.NoError(t, catchpointAccessor.ResetDownloadProgress(ctx), backend)
+		progress, err = catchpointAccessor.GetDownloadProgress(ctx)
+		require.NoError(t, err, backend)
+		require.Equal(t, CatchpointDownloadProgress{}, progress, backend)
+		l.Close()
+	}
+}
+
+func TestCatchupAccessorStagedDownloadProgress(t *testing.T) {
+	log := logging.TestingLog(t)
+	genesisInitState, _ := testGenerateInitState(t, protocol.ConsensusCurrentVersion, 100)
+	l, err := OpenLedger(log, t.Name(), true, genesisInitState, config.GetDefaultLocal())
+	require.NoError(t, err)
+	defer l.Close()
+	catchpointAccessor := MakeCatchpointCatchupAccessor(l, log)
+	ctx := context.Background()
+	require.NoError(t, catchpointAccessor.ResetStagingBalances(ctx, true))
+
+	accountsCount := uint64(BalancesPerCatchpointFileChunk * 2)
+	fileHeader := CatchpointFileHeader{
+		Version:       catchpointFileVersion,
+		TotalAccounts: accountsCount,
+		TotalChunks:   2,
+	}
+	var progress CatchpointCatchupAccessorProgress
+	sections := [][]byte{protocol.Encode(&fileHeader)}
+	encodedAccountChunks, _ := createTestingEncodedChunks(accountsCount)
+	sections = append(sections, encodedAccountChunks...)
+	download := CatchpointDownloadProgress{Round: 1000}
+	for i, section := range sections {
+		sectionName := "content.msgpack"
+		if i > 0 {
+			sectionName = fmt.Sprintf("balances.%d.msgpack", i)
+		}
+		sectionProgress := download
+		sectionProgress.Sections++
+		sectionProgress.SectionHash = crypto.Hash(section)
+		progress.DownloadProgress = &sectionProgress
+		require.NoError(t, catchpointAccessor.ProgressStagingBalances(ctx, sectionName, section, &progress))
+		download = sectionProgress
+
+		// the download progress is persisted along with the staged section, and matches the staging progress.
+		persisted, err := catchpointAccessor.GetDownloadProgress(ctx)
+		require.NoError(t, err)
+		require.Equal(t, download, persisted)
+		require.Equal(t, progress.ProcessedAccounts, persisted.ProcessedAccounts)
+		require.Equal(t, progress.ProcessedBytes, persisted.ProcessedBytes)
+		require.Equal(t, fileHeader.TotalAccounts, persisted.TotalAccounts)
+	}
+	require.Equal(t, accountsCount, progress.ProcessedAccounts)
+
+	// a section which fails to be staged leaves the persisted download progress untouched.
+	sectionProgress := download
+	sectionProgress.Sections++
+	progress.DownloadProgress = &sectionProgress
+	require.Error(t, catchpointAccessor.ProgressStagingBalances(ctx, "balances.1.msgpack", encodedAccountChunks[0], &progress))
+	persisted, err := catchpointAccessor.GetDownloadProgress(ctx)
+	require.NoError(t, err)
+	require.Equal(t, download, persisted)
+}
+
 func TestBuildMerkleTrie(t *testing.T) {
 	// setup boilerplate
 	log := logging.TestingLog(t)
@@ -76,6 +76,10 @@ type roundCowBase struct {
 	// are beyond the scope of this cache.
 	// The account data store here is always the account data without the rewards.
 	accounts map[basics.Address]basics.AccountData
+
+	// accountsMu protects the accounts cache, which could be accessed concurrently when the
+	// transaction groups are being evaluated in parallel.
+	accountsMu sync.RWMutex
 }
 
 func (x *roundCowBase) getCreator(cidx basics.CreatableIndex, ctype basics.CreatableType) (basics.Address, bool, error) {
@@ -86,17 +90,31 @@ func (x *roundCowBase) getCreator(cidx basics.CreatableIndex, ctype basics.Creat
 // first, and if it cannot find it there, it would defer to the underlaying implementation.
 // note that errors in accounts data retrivals are not cached as these typically cause the transaction evaluation to fail.
 func (x *roundCowBase) lookup(addr basics.Address) (basics.AccountData, error) {
-	if accountData, found := x.accounts[addr]; found {
+	x.accountsMu.RLock()
+	accountData, found := x.accounts[addr]
+	x.accountsMu.RUnlock()
+	if found {
 		return accountData, nil
 	}
 
 	accountData, _, err := x.l.LookupWithoutRewards(x.rnd, addr)
 	if err == nil {
+		x.accountsMu.Lock()
 		x.accounts[addr] = accountData
+		x.accountsMu.Unlock()
 	}
 	return accountData, err
 }
 
+// cacheAccounts adds the provided balance records to the per-round accounts cache.
+func (x *roundCowBase) cacheAccounts(balances []basics.BalanceRecord) {
+	x.accountsMu.Lock()
+	defer x.accountsMu.Unlock()
+	for _, br := range balances {
+		x.accounts[br.Addr] = br.AccountData
+	}
+}
+
 func (x *roundCowBase) checkDup(firstValid, lastValid basics.Round, txid transactions.Txid, txl ledgercore.Txlease) error {
 	return x.l.CheckDup(x.proto, x.rnd+1, firstValid, lastValid, txid, TxLease{txl})
 }
@@ -721,12 +739,27 @@ func (eval *BlockEvaluator) transactionGroup(txgroup []transactions.SignedTxnWit
 		return fmt.Errorf("group size %d exceeds maximum %d", len(txgroup), eval.proto.MaxTxGroupSize)
 	}
 
-	var txibs []transactions.SignedTxnInBlock
-	var group transactions.TxGroup
-	var groupTxBytes int
-
 	cow := eval.state.child(len(txgroup))
 
+	txibs, groupTxBytes, err := eval.evalTransactionGroup(txgroup, cow, eval.blockTxBytes)
+	if err != nil {
+		return err
+	}
+
+	eval.block.Payset = append(eval.block.Payset, txibs...)
+	eval.blockTxBytes += groupTxBytes
+	cow.commitToParent()
+
+	return nil
+}
+
+// evalTransactionGroup executes a group of transactions on the provided cow, without committing the
+// changes to the block evaluator. The blockTxBytes is the number of bytes used by the block prior to this
+// transaction group, and is being used to test whether the transaction group would fit in the block.
+// On success, it returns the encoded transactions as well as the number of bytes they would use in the block.
+func (eval *BlockEvaluator) evalTransactionGroup(txgroup []transactions.SignedTxnWithAD, cow *roundCowState, blockTxBytes int) (txibs []transactions.SignedTxnInBlock, groupTxBytes int, err error) {
+	var group transactions.TxGroup
+
 	// Prepare eval params for any ApplicationCall transactions in the group
 	evalParams := eval.prepareEvalParams(txgroup)
 
@@ -736,23 +769,23 @@ func (eval *BlockEvaluator) transactionGroup(txgroup []transactions.SignedTxnWit
 		var txib transactions.SignedTxnInBlock
 
 		cow.setGroupIdx(gi)
-		err := eval.transaction(txad.SignedTxn, evalParams[gi], txad.ApplyData, cow, &txib)
+		err = eval.transaction(txad.SignedTxn, evalParams[gi], txad.ApplyData, cow, &txib)
 		if err != nil {
-			return err
+			return nil, 0, err
 		}
 
 		txibs = append(txibs, txib)
 
 		if eval.validate {
 			groupTxBytes += txib.GetEncodedLength()
-			if eval.blockTxBytes+groupTxBytes > eval.proto.MaxTxnBytesPerBlock {
-				return ErrNoSpace
+			if blockTxBytes+groupTxBytes > eval.proto.MaxTxnBytesPerBlock {
+				return nil, 0, ErrNoSpace
 			}
 		}
 
 		// Make sure all transactions in group have the same group value
 		if txad.SignedTxn.Txn.Group != txgroup[0].SignedTxn.Txn.Group {
-			return fmt.Errorf("transactionGroup: inconsistent group values: %v != %v",
+			return nil, 0, fmt.Errorf("transactionGroup: inconsistent group values: %v != %v",
 				txad.SignedTxn.Txn.Group, txgroup[0].SignedTxn.Txn.Group)
 		}
 
@@ -762,23 +795,19 @@ func (eval *BlockEvaluator) transactionGroup(txgroup []transactions.SignedTxnWit
 
 			group.TxGroupHashes = append(group.TxGroupHashes, crypto.HashObj(txWithoutGroup))
 		} else if len(txgroup) > 1 {
-			return fmt.Errorf("transactionGroup: [%d] had zero Group but was submitted in a group of %d", gi, len(txgroup))
+			return nil, 0, fmt.Errorf("transactionGroup: [%d] had zero Group but was submitted in a group of %d", gi, len(txgroup))
 		}
 	}
 
 	// If we had a non-zero Group value, check that all group members are present.
 	if group.TxGroupHashes != nil {
 		if txgroup[0].SignedTxn.Txn.Group != crypto.HashObj(group) {
-			return fmt.Errorf("transactionGroup: incomplete group: %v != %v (%v)",
+			return nil, 0, fmt.Errorf("transactionGroup: incomplete group: %v != %v (%v)",
 				txgroup[0].SignedTxn.Txn.Group, crypto.HashObj(group), group)
 		}
 	}
 
-	eval.block.Payset = append(eval.block.Payset, txibs...)
-	eval.blockTxBytes += groupTxBytes
-	cow.commitToParent()
-
-	return nil
+	return txibs, groupTxBytes, nil
 }
 
 // Check the minimum balance requirement for the modified accounts in `cow`.
@@ -1162,10 +1191,13 @@ func (validator *evalTxValidator) run() {
 
 // used by Ledger.Validate() Ledger.AddBlock() Ledger.trackerEvalVerified()(accountUpdates.loadFromDisk())
 //
-// Validate: eval(ctx, l, blk, true, txcache, executionPool, true)
-// AddBlock: eval(context.Background(), l, blk, false, txcache, nil, true)
-// tracker:  eval(context.Background(), l, blk, false, txcache, nil, false)
-func eval(ctx context.Context, l ledgerForEvaluator, blk bookkeeping.Block, validate bool, txcache verify.VerifiedTransactionCache, executionPool execpool.BacklogPool) (ledgercore.StateDelta, error) {
+// Validate: eval(ctx, l, blk, true, txcache, executionPool, parallelism)
+// AddBlock: eval(context.Background(), l, blk, false, txcache, nil, parallelism)
+// tracker:  eval(context.Background(), l, blk, false, txcache, nil, 0)
+//
+// The parallelism argument controls the number of go-routines used to evaluate non-conflicting transaction
+// groups concurrently; a value of zero or one evaluates the transaction groups sequentially.
+func eval(ctx context.Context, l ledgerForEvaluator, blk bookkeeping.Block, validate bool, txcache verify.VerifiedTransactionCache, executionPool execpool.BacklogPool, parallelism int) (ledgercore.StateDelta, error) {
 	eval, err := startEvaluator(l, blk.BlockHeader, len(blk.Payset), validate, false)
 	if err != nil {
 		return ledgercore.StateDelta{}, err
@@ -1213,6 +1245,11 @@ func eval(ctx context.Context, l ledgerForEvaluator, blk bookkeeping.Block, vali
 
 	base := eval.state.lookupParent.(*roundCowBase)
 
+	var groupsEvaluator *parallelGroupEvaluator
+	if parallelism > 1 && !eval.state.compatibilityMode {
+		groupsEvaluator = makeParallelGroupEvaluator(eval, parallelism)
+	}
+
 transactionGroupLoop:
 	for {
 		select {
@@ -1223,10 +1260,12 @@ transactionGroupLoop:
 				return ledgercore.StateDelta{}, err
 			}
 
-			for _, br := range txgroup.balances {
-				base.accounts[br.Addr] = br.AccountData
+			base.cacheAccounts(txgroup.balances)
+			if groupsEvaluator != nil {
+				err = groupsEvaluator.add(txgroup.group)
+			} else {
+				err = eval.TransactionGroup(txgroup.group)
 			}
-			err = eval.TransactionGroup(txgroup.group)
 			if err != nil {
 				return ledgercore.StateDelta{}, err
 			}
@@ -1240,6 +1279,14 @@ transactionGroupLoop:
 		}
 	}
 
+	// Evaluate any transaction groups still pending in the parallel evaluator
+	if groupsEvaluator != nil {
+		err = groupsEvaluator.flush()
+		if err != nil {
+			return ledgercore.StateDelta{}, err
+		}
+	}
+
 	// Finally, proceeds any pending end-of-block state changes
 	err = eval.endOfBlock()
 	if err != nil {
@@ -1453,7 +1500,7 @@ func loadAccounts(ctx context.Context, l ledgerForEvaluator, rnd basics.Round, g
 // not a valid block (e.g., it has duplicate transactions, overspends some
 // account, etc).
 func (l *Ledger) Validate(ctx context.Context, blk bookkeeping.Block, executionPool execpool.BacklogPool) (*ValidatedBlock, error) {
-	delta, err := eval(ctx, l, blk, true, l.verifiedTxnCache, executionPool)
+	delta, err := eval(ctx, l, blk, true, l.verifiedTxnCache, executionPool, l.parallelEvalWorkers)
 	if err != nil {
 		return nil, err
 	}
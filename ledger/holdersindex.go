@@ -0,0 +1,97 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package ledger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+
+	"github.com/algorand/go-algorand/data/basics"
+)
+
+// AssetHolder is an account holding an asset, along with its holding.
+type AssetHolder struct {
+	Address basics.Address
+	Holding basics.AssetHolding
+}
+
+// holdingsIndexUpdate is the change to the asset holders and application opt-ins indexes of a single account.
+type holdingsIndexUpdate struct {
+	putAssets    map[basics.AssetIndex]basics.AssetHolding
+	deleteAssets []basics.AssetIndex
+	putApps      []basics.AppIndex
+	deleteApps   []basics.AppIndex
+}
+
+// makeHoldingsIndexUpdate returns the change to the holdings indexes of an account whose data changes from old to new.
+func makeHoldingsIndexUpdate(old, new basics.AccountData) (u holdingsIndexUpdate) {
+	for aidx, holding := range new.Assets {
+		if oldHolding, ok := old.Assets[aidx]; !ok || oldHolding != holding {
+			if u.putAssets == nil {
+				u.putAssets = make(map[basics.AssetIndex]basics.AssetHolding)
+			}
+			u.putAssets[aidx] = holding
+		}
+	}
+	for aidx := range old.Assets {
+		if _, ok := new.Assets[aidx]; !ok {
+			u.deleteAssets = append(u.deleteAssets, aidx)
+		}
+	}
+	for appIdx := range new.AppLocalStates {
+		if _, ok := old.AppLocalStates[appIdx]; !ok {
+			u.putApps = append(u.putApps, appIdx)
+		}
+	}
+	for appIdx := range old.AppLocalStates {
+		if _, ok := new.AppLocalStates[appIdx]; !ok {
+			u.deleteApps = append(u.deleteApps, appIdx)
+		}
+	}
+	return
+}
+
+// encodeHoldingAmount encodes an asset amount in big-endian order, so that the stored amounts would compare as the
+// amounts themselves. SQLite integers can't hold the full range of asset amounts.
+func encodeHoldingAmount(amount uint64) []byte {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], amount)
+	return buf[:]
+}
+
+// heldAssetInRange returns the holding of the asset by the account, and whether the account holds the asset with an
+// amount within [minAmount, maxAmount].
+func heldAssetInRange(data basics.AccountData, aidx basics.AssetIndex, minAmount, maxAmount uint64) (basics.AssetHolding, bool) {
+	holding, ok := data.Assets[aidx]
+	return holding, ok && holding.Amount >= minAmount && holding.Amount <= maxAmount
+}
+
+// addressAfter tests whether addr follows the given address, where a nil address precedes all addresses.
+func addressAfter(addr basics.Address, after *basics.Address) bool {
+	return after == nil || bytes.Compare(addr[:], after[:]) > 0
+}
+
+// sortAddresses sorts the given addresses in increasing order.
+func sortAddresses(addrs []basics.Address) {
+	sort.Slice(addrs, func(i, j int) bool { return bytes.Compare(addrs[i][:], addrs[j][:]) < 0 })
+}
+
+// sortAssetHolders sorts the given asset holders by their addresses.
+func sortAssetHolders(holders []AssetHolder) {
+	sort.Slice(holders, func(i, j int) bool { return bytes.Compare(holders[i].Address[:], holders[j].Address[:]) < 0 })
+}
@@ -0,0 +1,519 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package ledger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/algorand/go-deadlock"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/comparer"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/memdb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/util/db"
+)
+
+// kvTxPromotionSize is the size of the pending writes of a kvTx, beyond which the writes are no longer buffered in memory
+// but rather written into a leveldb transaction. Small transactions are committed as a single write batch, which is
+// much cheaper than a leveldb transaction, which always ends up creating a new table file.
+const kvTxPromotionSize = 32 * 1024 * 1024
+
+// kvDeleteChunkSize is the number of keys being deleted (or moved) at a time by deleteRange/moveRange.
+const kvDeleteChunkSize = 1024
+
+const (
+	kvPendingPut    = byte(1)
+	kvPendingDelete = byte(2)
+)
+
+// errKVNotFound is returned by kvReader.get when the requested key does not exist.
+var errKVNotFound = leveldb.ErrNotFound
+
+// kvStore is an embedded LSM based key-value store, used as an alternative to the SQLite database for
+// storing the ledger. Writes are serialized and made within kvTx transactions, while reads are made
+// over a consistent snapshot of the store.
+type kvStore struct {
+	db *leveldb.DB
+
+	// writeMu serializes the write transactions.
+	writeMu deadlock.Mutex
+
+	// syncWrites is set when the committed transactions need to be synced to disk before returning.
+	// It is synchronized by writeMu.
+	syncWrites bool
+
+	// txPromotionSize is the pending writes size beyond which a kvTx is promoted to a leveldb transaction.
+	txPromotionSize int
+
+	log logging.Logger
+}
+
+// kvReader provides read access to the key-value store.
+type kvReader interface {
+	// get returns the value associated with the given key, or errKVNotFound.
+	get(key []byte) ([]byte, error)
+
+	// scan calls fn with the key-value pairs in the given range, in either ascending or descending key order,
+	// until fn returns false or an error. The key and value slices are only valid during the fn call, and the
+	// store must not be modified by fn.
+	scan(rng *util.Range, reverse bool, fn func(key, value []byte) (bool, error)) error
+}
+
+// kvWriter provides read and write access to the key-value store.
+type kvWriter interface {
+	kvReader
+	put(key, value []byte) error
+	delete(key []byte) error
+}
+
+// openKVStore opens ( or creates ) the key-value store in the given directory. If inMemory is set, the
+// store is created in memory, and the path is ignored.
+func openKVStore(path string, inMemory bool) (*kvStore, error) {
+	options := &opt.Options{
+		// the ledger values are mostly msgpack encoded data, which compress well.
+		Compression: opt.SnappyCompression,
+	}
+	var ldb *leveldb.DB
+	var err error
+	if inMemory {
+		ldb, err = leveldb.Open(storage.NewMemStorage(), options)
+	} else {
+		ldb, err = leveldb.OpenFile(path, options)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &kvStore{db: ldb, txPromotionSize: kvTxPromotionSize, log: logging.Base()}, nil
+}
+
+// openKVStoreReadOnly opens the existing key-value store in the given directory for reading only.
+func openKVStoreReadOnly(path string) (*kvStore, error) {
+	ldb, err := leveldb.OpenFile(path, &opt.Options{ReadOnly: true, ErrorIfMissing: true})
+	if err != nil {
+		return nil, err
+	}
+	return &kvStore{db: ldb, txPromotionSize: kvTxPromotionSize, log: logging.Base()}, nil
+}
+
+func (s *kvStore) setLogger(log logging.Logger) {
+	s.log = log
+}
+
+// setSynchronousMode sets whether the committed transactions are synced to disk.
+func (s *kvStore) setSynchronousMode(mode db.SynchronousMode) error {
+	if mode < db.SynchronousModeOff || mode > db.SynchronousModeExtra {
+		return fmt.Errorf("invalid value(%d) was provided to mode", mode)
+	}
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	s.syncWrites = mode >= db.SynchronousModeFull
+	return nil
+}
+
+func (s *kvStore) close() {
+	err := s.db.Close()
+	if err != nil {
+		s.log.Warnf("kvStore.close: unable to close key-value store : %v", err)
+	}
+}
+
+// snapshot calls fn with a read-only, consistent view of the store.
+func (s *kvStore) snapshot(fn func(r kvReader) error) error {
+	snap, err := s.db.GetSnapshot()
+	if err != nil {
+		return err
+	}
+	defer snap.Release()
+	return fn(&kvSnapshot{snap: snap})
+}
+
+// atomic calls fn with a kvTx. The writes made by fn are committed only if it returns no error.
+func (s *kvStore) atomic(fn func(w kvWriter) error) (err error) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	snap, err := s.db.GetSnapshot()
+	if err != nil {
+		return err
+	}
+	tx := &kvTx{
+		store:   s,
+		snap:    snap,
+		pending: memdb.New(comparer.DefaultComparer, 0),
+	}
+	defer tx.release()
+
+	err = fn(tx)
+	if err != nil {
+		return err
+	}
+	return tx.commit()
+}
+
+// compact compacts the underlying storage, returning the number of table files and the size of the store
+// before and after the compaction.
+func (s *kvStore) compact() (stats db.VacuumStats, err error) {
+	stats.PagesBefore, stats.SizeBefore, err = s.storageStats()
+	if err != nil {
+		return
+	}
+	err = s.db.CompactRange(util.Range{})
+	if err != nil {
+		return
+	}
+	stats.PagesAfter, stats.SizeAfter, err = s.storageStats()
+	return
+}
+
+// storageStats returns the number of table files and their total size.
+func (s *kvStore) storageStats() (tables uint64, size uint64, err error) {
+	var stats leveldb.DBStats
+	err = s.db.Stats(&stats)
+	if err != nil {
+		return
+	}
+	for level := range stats.LevelTablesCounts {
+		tables += uint64(stats.LevelTablesCounts[level])
+		size += uint64(stats.LevelSizes[level])
+	}
+	return
+}
+
+// kvSnapshot is the kvReader implementation over a consistent snapshot of the store.
+type kvSnapshot struct {
+	snap *leveldb.Snapshot
+}
+
+func (r *kvSnapshot) get(key []byte) ([]byte, error) {
+	return r.snap.Get(key, nil)
+}
+
+func (r *kvSnapshot) scan(rng *util.Range, reverse bool, fn func(key, value []byte) (bool, error)) error {
+	it := r.snap.NewIterator(rng, nil)
+	defer it.Release()
+	return kvMergeScan(it, nil, reverse, fn)
+}
+
+// kvTx is a write transaction over the store. The writes are buffered in memory, on top of a snapshot of the
+// store, and are written as a single batch on commit. Once the buffered writes grow beyond kvTxPromotionSize,
+// the transaction is promoted into a leveldb transaction, which doesn't need to retain the writes in memory.
+type kvTx struct {
+	store   *kvStore
+	snap    *leveldb.Snapshot
+	pending *memdb.DB
+	tr      *leveldb.Transaction
+}
+
+func (tx *kvTx) get(key []byte) ([]byte, error) {
+	if tx.tr != nil {
+		return tx.tr.Get(key, nil)
+	}
+	value, err := tx.pending.Get(key)
+	if err == nil {
+		if value[0] == kvPendingDelete {
+			return nil, errKVNotFound
+		}
+		return append([]byte{}, value[1:]...), nil
+	}
+	return tx.snap.Get(key, nil)
+}
+
+func (tx *kvTx) scan(rng *util.Range, reverse bool, fn func(key, value []byte) (bool, error)) error {
+	if tx.tr != nil {
+		it := tx.tr.NewIterator(rng, nil)
+		defer it.Release()
+		return kvMergeScan(it, nil, reverse, fn)
+	}
+	it := tx.snap.NewIterator(rng, nil)
+	defer it.Release()
+	pendingIt := tx.pending.NewIterator(rng)
+	defer pendingIt.Release()
+	return kvMergeScan(it, pendingIt, reverse, fn)
+}
+
+func (tx *kvTx) put(key, value []byte) error {
+	if tx.tr != nil {
+		return tx.tr.Put(key, value, nil)
+	}
+	pendingValue := make([]byte, 1+len(value))
+	pendingValue[0] = kvPendingPut
+	copy(pendingValue[1:], value)
+	err := tx.pending.Put(key, pendingValue)
+	if err != nil {
+		return err
+	}
+	return tx.promoteIfNeeded()
+}
+
+func (tx *kvTx) delete(key []byte) error {
+	if tx.tr != nil {
+		return tx.tr.Delete(key, nil)
+	}
+	err := tx.pending.Put(key, []byte{kvPendingDelete})
+	if err != nil {
+		return err
+	}
+	return tx.promoteIfNeeded()
+}
+
+// promoteIfNeeded moves the pending writes into a leveldb transaction once these become too large to be retained in memory.
+func (tx *kvTx) promoteIfNeeded() (err error) {
+	if tx.pending.Size() < tx.store.txPromotionSize {
+		return nil
+	}
+	tx.tr, err = tx.store.db.OpenTransaction()
+	if err != nil {
+		return err
+	}
+	err = tx.tr.Write(tx.pendingBatch(), nil)
+	if err != nil {
+		return err
+	}
+	tx.pending.Reset()
+	tx.snap.Release()
+	tx.snap = nil
+	return nil
+}
+
+// pendingBatch returns the pending writes as a leveldb batch.
+func (tx *kvTx) pendingBatch() *leveldb.Batch {
+	batch := new(leveldb.Batch)
+	it := tx.pending.NewIterator(nil)
+	defer it.Release()
+	for it.Next() {
+		value := it.Value()
+		if value[0] == kvPendingDelete {
+			batch.Delete(it.Key())
+		} else {
+			batch.Put(it.Key(), value[1:])
+		}
+	}
+	return batch
+}
+
+func (tx *kvTx) commit() (err error) {
+	if tx.tr != nil {
+		err = tx.tr.Commit()
+		tx.tr = nil
+		return err
+	}
+	if tx.pending.Len() == 0 {
+		return nil
+	}
+	return tx.store.db.Write(tx.pendingBatch(), &opt.WriteOptions{Sync: tx.store.syncWrites})
+}
+
+// release discards any uncommitted writes, and releases the resources held by the transaction.
+func (tx *kvTx) release() {
+	if tx.tr != nil {
+		tx.tr.Discard()
+		tx.tr = nil
+	}
+	if tx.snap != nil {
+		tx.snap.Release()
+		tx.snap = nil
+	}
+}
+
+// kvMergeScan iterates over the base iterator entries, overridden by the entries of the pending iterator.
+// The pending iterator, if provided, is expected to be iterating over a memdb holding the kvTx pending writes.
+func kvMergeScan(base iterator.Iterator, pending iterator.Iterator, reverse bool, fn func(key, value []byte) (bool, error)) error {
+	advance := func(it iterator.Iterator) bool {
+		if reverse {
+			return it.Prev()
+		}
+		return it.Next()
+	}
+	var baseValid, pendingValid bool
+	if reverse {
+		baseValid = base.Last()
+		if pending != nil {
+			pendingValid = pending.Last()
+		}
+	} else {
+		baseValid = base.First()
+		if pending != nil {
+			pendingValid = pending.First()
+		}
+	}
+
+	for baseValid || pendingValid {
+		useBase, usePending := baseValid, pendingValid
+		if baseValid && pendingValid {
+			cmp := bytes.Compare(base.Key(), pending.Key())
+			if reverse {
+				cmp = -cmp
+			}
+			// on equal keys, the pending write overrides the base entry.
+			useBase, usePending = cmp < 0, cmp >= 0
+		}
+
+		var more = true
+		var err error
+		if usePending {
+			value := pending.Value()
+			if value[0] != kvPendingDelete {
+				more, err = fn(pending.Key(), value[1:])
+			}
+			if baseValid && bytes.Equal(base.Key(), pending.Key()) {
+				baseValid = advance(base)
+			}
+			pendingValid = advance(pending)
+		} else if useBase {
+			more, err = fn(base.Key(), base.Value())
+			baseValid = advance(base)
+		}
+		if err != nil || !more {
+			return err
+		}
+	}
+	if err := base.Error(); err != nil {
+		return err
+	}
+	if pending != nil {
+		return pending.Error()
+	}
+	return nil
+}
+
+// kvKey concatenates the given prefix and suffix into a new key.
+func kvKey(prefix string, suffix []byte) []byte {
+	key := make([]byte, len(prefix)+len(suffix))
+	copy(key, prefix)
+	copy(key[len(prefix):], suffix)
+	return key
+}
+
+// kvUint64Key returns the key made of the given prefix followed by the big-endian encoding of n, so that
+// the keys would be ordered by n.
+func kvUint64Key(prefix string, n uint64) []byte {
+	key := make([]byte, len(prefix)+8)
+	copy(key, prefix)
+	binary.BigEndian.PutUint64(key[len(prefix):], n)
+	return key
+}
+
+// kvPrefixRange returns the range of all the keys beginning with the given prefix.
+func kvPrefixRange(prefix string) *util.Range {
+	return util.BytesPrefix([]byte(prefix))
+}
+
+// kvRangeAfter returns the range of the keys in rng that are greater than the given key.
+func kvRangeAfter(rng *util.Range, key []byte) *util.Range {
+	if key == nil {
+		return rng
+	}
+	return &util.Range{Start: append(append([]byte{}, key...), 0), Limit: rng.Limit}
+}
+
+// kvCollect returns up to count keys and values from the given range, following the provided key.
+// The returned slices are copies, and can be retained by the caller.
+func kvCollect(r kvReader, rng *util.Range, after []byte, count int) (keys [][]byte, values [][]byte, err error) {
+	err = r.scan(kvRangeAfter(rng, after), false, func(key, value []byte) (bool, error) {
+		keys = append(keys, append([]byte{}, key...))
+		values = append(values, append([]byte{}, value...))
+		return len(keys) < count, nil
+	})
+	return
+}
+
+// kvCount returns the number of keys in the given range.
+func kvCount(r kvReader, rng *util.Range) (count uint64, err error) {
+	err = r.scan(rng, false, func(key, value []byte) (bool, error) {
+		count++
+		return true, nil
+	})
+	return
+}
+
+// kvDeleteRange deletes all the keys in the given range.
+func kvDeleteRange(w kvWriter, rng *util.Range) error {
+	var last []byte
+	for {
+		keys, _, err := kvCollect(w, rng, last, kvDeleteChunkSize)
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			err = w.delete(key)
+			if err != nil {
+				return err
+			}
+		}
+		if len(keys) < kvDeleteChunkSize {
+			return nil
+		}
+		last = keys[len(keys)-1]
+	}
+}
+
+// kvMovePrefix moves all the keys beginning with the from prefix into keys beginning with the to prefix.
+// Any existing keys beginning with the to prefix are deleted.
+func kvMovePrefix(w kvWriter, from, to string) error {
+	err := kvDeleteRange(w, kvPrefixRange(to))
+	if err != nil {
+		return err
+	}
+	rng := kvPrefixRange(from)
+	var last []byte
+	for {
+		keys, values, err := kvCollect(w, rng, last, kvDeleteChunkSize)
+		if err != nil {
+			return err
+		}
+		for i, key := range keys {
+			err = w.put(kvKey(to, key[len(from):]), values[i])
+			if err != nil {
+				return err
+			}
+			err = w.delete(key)
+			if err != nil {
+				return err
+			}
+		}
+		if len(keys) < kvDeleteChunkSize {
+			return nil
+		}
+		last = keys[len(keys)-1]
+	}
+}
+
+// kvGetUint64 returns the uint64 stored in the given key.
+func kvGetUint64(r kvReader, key []byte) (uint64, error) {
+	value, err := r.get(key)
+	if err != nil {
+		return 0, err
+	}
+	if len(value) != 8 {
+		return 0, fmt.Errorf("unexpected value length %d for key '%s'", len(value), key)
+	}
+	return binary.BigEndian.Uint64(value), nil
+}
+
+// kvPutUint64 stores the given uint64 in the given key.
+func kvPutUint64(w kvWriter, key []byte, n uint64) error {
+	var value [8]byte
+	binary.BigEndian.PutUint64(value[:], n)
+	return w.put(key, value[:])
+}
@@ -0,0 +1,151 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package ledger
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/util/db"
+)
+
+func kvStoreKeys(t *testing.T, r kvReader, prefix string, reverse bool) (keys []string) {
+	err := r.scan(kvPrefixRange(prefix), reverse, func(key, value []byte) (bool, error) {
+		keys = append(keys, string(key))
+		return true, nil
+	})
+	require.NoError(t, err)
+	return
+}
+
+func testKVStoreTransactions(t *testing.T, promotionSize int) {
+	kv, err := openKVStore("", true)
+	require.NoError(t, err)
+	defer kv.close()
+	kv.txPromotionSize = promotionSize
+
+	err = kv.atomic(func(w kvWriter) error {
+		for i := 0; i < 10; i++ {
+			err := w.put([]byte(fmt.Sprintf("k/%d", i)), []byte{byte(i)})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	err = kv.atomic(func(w kvWriter) error {
+		// the transaction sees its own pending writes, merged with the committed ones.
+		require.NoError(t, w.delete([]byte("k/3")))
+		require.NoError(t, w.put([]byte("k/5"), []byte{55}))
+		require.NoError(t, w.put([]byte("k/55"), []byte{56}))
+
+		value, err := w.get([]byte("k/5"))
+		require.NoError(t, err)
+		require.Equal(t, []byte{55}, value)
+		_, err = w.get([]byte("k/3"))
+		require.Equal(t, errKVNotFound, err)
+
+		require.Equal(t, []string{"k/0", "k/1", "k/2", "k/4", "k/5", "k/55", "k/6", "k/7", "k/8", "k/9"}, kvStoreKeys(t, w, "k/", false))
+		require.Equal(t, []string{"k/9", "k/8", "k/7", "k/6", "k/55", "k/5", "k/4", "k/2", "k/1", "k/0"}, kvStoreKeys(t, w, "k/", true))
+
+		// the changes aren't visible outside of the transaction.
+		err = kv.snapshot(func(r kvReader) error {
+			require.Equal(t, 10, len(kvStoreKeys(t, r, "k/", false)))
+			return nil
+		})
+		require.NoError(t, err)
+		return nil
+	})
+	require.NoError(t, err)
+
+	err = kv.snapshot(func(r kvReader) error {
+		require.Equal(t, []string{"k/0", "k/1", "k/2", "k/4", "k/5", "k/55", "k/6", "k/7", "k/8", "k/9"}, kvStoreKeys(t, r, "k/", false))
+		return nil
+	})
+	require.NoError(t, err)
+
+	// a failing transaction is rolled back.
+	err = kv.atomic(func(w kvWriter) error {
+		require.NoError(t, kvDeleteRange(w, kvPrefixRange("k/")))
+		require.Empty(t, kvStoreKeys(t, w, "k/", false))
+		return fmt.Errorf("rollback")
+	})
+	require.Error(t, err)
+
+	err = kv.atomic(func(w kvWriter) error {
+		require.NoError(t, kvMovePrefix(w, "k/", "m/"))
+		return nil
+	})
+	require.NoError(t, err)
+	err = kv.snapshot(func(r kvReader) error {
+		require.Empty(t, kvStoreKeys(t, r, "k/", false))
+		require.Equal(t, []string{"m/0", "m/1", "m/2", "m/4", "m/5", "m/55", "m/6", "m/7", "m/8", "m/9"}, kvStoreKeys(t, r, "m/", false))
+
+		keys, values, err := kvCollect(r, kvPrefixRange("m/"), []byte("m/5"), 2)
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{[]byte("m/55"), []byte("m/6")}, keys)
+		require.Equal(t, [][]byte{{56}, {6}}, values)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestKVStoreTransactions(t *testing.T) {
+	t.Run("batch", func(t *testing.T) {
+		testKVStoreTransactions(t, kvTxPromotionSize)
+	})
+	t.Run("promoted", func(t *testing.T) {
+		testKVStoreTransactions(t, 1)
+	})
+}
+
+func TestKVStorePersistence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kvstore")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "test.leveldb")
+	kv, err := openKVStore(path, false)
+	require.NoError(t, err)
+	require.NoError(t, kv.setSynchronousMode(db.SynchronousModeFull))
+	require.Error(t, kv.setSynchronousMode(db.SynchronousModeExtra+1))
+
+	err = kv.atomic(func(w kvWriter) error {
+		return kvPutUint64(w, []byte("counter"), 12345)
+	})
+	require.NoError(t, err)
+	_, err = kv.compact()
+	require.NoError(t, err)
+	kv.close()
+
+	kv, err = openKVStore(path, false)
+	require.NoError(t, err)
+	defer kv.close()
+	err = kv.snapshot(func(r kvReader) error {
+		counter, err := kvGetUint64(r, []byte("counter"))
+		require.NoError(t, err)
+		require.Equal(t, uint64(12345), counter)
+		return nil
+	})
+	require.NoError(t, err)
+}
@@ -18,7 +18,6 @@ package ledger
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"os"
 	"time"
@@ -40,11 +39,11 @@ import (
 
 // Ledger is a database storing the contents of the ledger.
 type Ledger struct {
-	// Database connections to the DBs storing blocks and tracker state.
+	// Storage of the blocks and tracker state.
 	// We use potentially different databases to avoid SQLite contention
 	// during catchup.
-	trackerDBs db.Pair
-	blockDBs   db.Pair
+	trackerDBs trackerStore
+	blockDBs   blockStore
 
 	// blockQ is the buffer of added blocks that will be flushed to
 	// persistent storage
@@ -84,6 +83,9 @@ type Ledger struct {
 
 	// verifiedTxnCache holds all the verified transactions state
 	verifiedTxnCache verify.VerifiedTransactionCache
+
+	// parallelEvalWorkers is the number of go-routines used for evaluating the transaction groups of a block
+	parallelEvalWorkers int
 }
 
 // InitState structure defines blockchain init params
@@ -116,6 +118,7 @@ func OpenLedger(
 		synchronousMode:                db.SynchronousMode(cfg.LedgerSynchronousMode),
 		accountsRebuildSynchronousMode: db.SynchronousMode(cfg.AccountsRebuildSynchronousMode),
 		verifiedTxnCache:               verify.MakeVerifiedTransactionCache(verifiedCacheSize),
+		parallelEvalWorkers:            cfg.ParallelBlockEvaluationWorkers,
 	}
 
 	l.headerCache.maxEntries = 10
@@ -126,21 +129,19 @@ func OpenLedger(
 		}
 	}()
 
-	l.trackerDBs, l.blockDBs, err = openLedgerDB(dbPathPrefix, dbMem)
+	l.trackerDBs, l.blockDBs, err = openLedgerDB(dbPathPrefix, dbMem, cfg.LedgerStorageBackend)
 	if err != nil {
 		err = fmt.Errorf("OpenLedger.openLedgerDB %v", err)
 		return nil, err
 	}
-	l.trackerDBs.Rdb.SetLogger(log)
-	l.trackerDBs.Wdb.SetLogger(log)
-	l.blockDBs.Rdb.SetLogger(log)
-	l.blockDBs.Wdb.SetLogger(log)
+	l.trackerDBs.setLogger(log)
+	l.blockDBs.setLogger(log)
 
 	l.setSynchronousMode(context.Background(), l.synchronousMode)
 
 	start := time.Now()
 	ledgerInitblocksdbCount.Inc(nil)
-	err = l.blockDBs.Wdb.Atomic(func(ctx context.Context, tx *sql.Tx) error {
+	err = l.blockDBs.batch(func(ctx context.Context, tx blockStoreWriter) error {
 		return initBlocksDB(tx, l, []bookkeeping.Block{genesisInitState.Block}, cfg.Archival)
 	})
 	ledgerInitblocksdbMicros.AddMicrosecondsSince(start, nil)
@@ -213,13 +214,13 @@ func (l *Ledger) verifyMatchingGenesisHash() (err error) {
 	// Check that the genesis hash, if present, matches.
 	start := time.Now()
 	ledgerVerifygenhashCount.Inc(nil)
-	err = l.blockDBs.Rdb.Atomic(func(ctx context.Context, tx *sql.Tx) error {
-		latest, err := blockLatest(tx)
+	err = l.blockDBs.snapshot(func(ctx context.Context, tx blockStoreReader) error {
+		latest, err := tx.blockLatest()
 		if err != nil {
 			return err
 		}
 
-		hdr, err := blockGetHdr(tx, latest)
+		hdr, err := tx.blockGetHdr(latest)
 		if err != nil {
 			return err
 		}
@@ -237,7 +238,24 @@ func (l *Ledger) verifyMatchingGenesisHash() (err error) {
 	return
 }
 
-func openLedgerDB(dbPathPrefix string, dbMem bool) (trackerDBs db.Pair, blockDBs db.Pair, err error) {
+// The supported values of the LedgerStorageBackend configuration option.
+const (
+	// StorageBackendSQLite stores the ledger in SQLite databases.
+	StorageBackendSQLite = "sqlite"
+	// StorageBackendLevelDB stores the ledger in embedded LSM key-value stores.
+	StorageBackendLevelDB = "leveldb"
+)
+
+func openLedgerDB(dbPathPrefix string, dbMem bool, backend string) (trackerDBs trackerStore, blockDBs blockStore, err error) {
+	switch backend {
+	case StorageBackendSQLite, "":
+	case StorageBackendLevelDB:
+		return openLedgerKVStores(dbPathPrefix, dbMem)
+	default:
+		err = fmt.Errorf("unsupported ledger storage backend '%s'", backend)
+		return
+	}
+
 	// Backwards compatibility: we used to store both blocks and tracker
 	// state in a single SQLite db file.
 	var trackerDBFilename string
@@ -255,28 +273,98 @@ func openLedgerDB(dbPathPrefix string, dbMem bool) (trackerDBs db.Pair, blockDBs
 		}
 	}
 
-	trackerDBFilename = dbPathPrefix + ".tracker.sqlite"
-	blockDBFilename = dbPathPrefix + ".block.sqlite"
+	trackerDBFilename, blockDBFilename, err = ledgerStoragePaths(dbPathPrefix, StorageBackendSQLite)
+	if err != nil {
+		return
+	}
 
+	var trackerPair, blockPair db.Pair
 	outErr := make(chan error, 2)
 	go func() {
 		var lerr error
-		trackerDBs, lerr = db.OpenPair(trackerDBFilename, dbMem)
+		trackerPair, lerr = db.OpenPair(trackerDBFilename, dbMem)
 		outErr <- lerr
 	}()
 
 	go func() {
 		var lerr error
-		blockDBs, lerr = db.OpenPair(blockDBFilename, dbMem)
+		blockPair, lerr = db.OpenPair(blockDBFilename, dbMem)
 		outErr <- lerr
 	}()
 
 	err = <-outErr
+	if err == nil {
+		err = <-outErr
+	} else {
+		<-outErr
+	}
 	if err != nil {
+		trackerPair.Close()
+		blockPair.Close()
 		return
 	}
-	err = <-outErr
-	return
+	return makeSQLiteTrackerStore(trackerPair), makeSQLiteBlockStore(blockPair), nil
+}
+
+// openLedgerKVStores opens the key-value stores of the tracker and blocks databases.
+func openLedgerKVStores(dbPathPrefix string, dbMem bool) (trackerDBs trackerStore, blockDBs blockStore, err error) {
+	trackerPath, blockPath, err := ledgerStoragePaths(dbPathPrefix, StorageBackendLevelDB)
+	if err != nil {
+		return
+	}
+	trackerKV, err := openKVStore(trackerPath, dbMem)
+	if err != nil {
+		return
+	}
+	blockKV, err := openKVStore(blockPath, dbMem)
+	if err != nil {
+		trackerKV.close()
+		return
+	}
+	return makeKVTrackerStore(trackerKV), makeKVBlockStore(blockKV), nil
+}
+
+// openLedgerDBReadOnly opens the existing tracker and blocks databases of a ledger for reading only. Unlike
+// openLedgerDB, it fails rather than creating the databases which don't exist.
+func openLedgerDBReadOnly(dbPathPrefix string, backend string) (trackerDBs trackerStore, blockDBs blockStore, err error) {
+	trackerPath, blockPath, err := ledgerStoragePaths(dbPathPrefix, backend)
+	if err != nil {
+		return
+	}
+	if backend == StorageBackendLevelDB {
+		trackerKV, err := openKVStoreReadOnly(trackerPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		blockKV, err := openKVStoreReadOnly(blockPath)
+		if err != nil {
+			trackerKV.close()
+			return nil, nil, err
+		}
+		return makeKVTrackerStore(trackerKV), makeKVBlockStore(blockKV), nil
+	}
+
+	openPair := func(filename string) (p db.Pair, err error) {
+		p.Rdb, err = db.MakeReadOnlyAccessor(filename)
+		if err != nil {
+			return
+		}
+		p.Wdb, err = db.MakeReadOnlyAccessor(filename)
+		if err != nil {
+			p.Rdb.Close()
+		}
+		return
+	}
+	trackerPair, err := openPair(trackerPath)
+	if err != nil {
+		return
+	}
+	blockPair, err := openPair(blockPath)
+	if err != nil {
+		trackerPair.Close()
+		return
+	}
+	return makeSQLiteTrackerStore(trackerPair), makeSQLiteBlockStore(blockPair), nil
 }
 
 // setSynchronousMode sets the writing database connections synchronous mode to the specified mode
@@ -286,13 +374,13 @@ func (l *Ledger) setSynchronousMode(ctx context.Context, synchronousMode db.Sync
 		return
 	}
 
-	err := l.blockDBs.Wdb.SetSynchronousMode(ctx, synchronousMode, synchronousMode >= db.SynchronousModeFull)
+	err := l.blockDBs.setSynchronousMode(ctx, synchronousMode, synchronousMode >= db.SynchronousModeFull)
 	if err != nil {
 		l.log.Warnf("ledger.setSynchronousMode unable to set synchronous mode on blocks db: %v", err)
 		return
 	}
 
-	err = l.trackerDBs.Wdb.SetSynchronousMode(ctx, synchronousMode, synchronousMode >= db.SynchronousModeFull)
+	err = l.trackerDBs.setSynchronousMode(ctx, synchronousMode, synchronousMode >= db.SynchronousModeFull)
 	if err != nil {
 		l.log.Warnf("ledger.setSynchronousMode unable to set synchronous mode on trackers db: %v", err)
 		return
@@ -302,8 +390,8 @@ func (l *Ledger) setSynchronousMode(ctx context.Context, synchronousMode db.Sync
 // initBlocksDB performs DB initialization:
 // - creates and populates it with genesis blocks
 // - ensures DB is in good shape for archival mode and resets it if not
-func initBlocksDB(tx *sql.Tx, l *Ledger, initBlocks []bookkeeping.Block, isArchival bool) (err error) {
-	err = blockInit(tx, initBlocks)
+func initBlocksDB(tx blockStoreWriter, l *Ledger, initBlocks []bookkeeping.Block, isArchival bool) (err error) {
+	err = tx.blockInit(initBlocks)
 	if err != nil {
 		err = fmt.Errorf("initBlocksDB.blockInit %v", err)
 		return err
@@ -311,7 +399,7 @@ func initBlocksDB(tx *sql.Tx, l *Ledger, initBlocks []bookkeeping.Block, isArchi
 
 	// in archival mode check if DB contains all blocks up to the latest
 	if isArchival {
-		earliest, err := blockEarliest(tx)
+		earliest, err := tx.blockEarliest()
 		if err != nil {
 			err = fmt.Errorf("initBlocksDB.blockEarliest %v", err)
 			return err
@@ -321,12 +409,12 @@ func initBlocksDB(tx *sql.Tx, l *Ledger, initBlocks []bookkeeping.Block, isArchi
 		// So reset the DB and init it again
 		if earliest != basics.Round(0) {
 			l.log.Warnf("resetting blocks DB (earliest block is %v)", earliest)
-			err := blockResetDB(tx)
+			err := tx.blockResetDB()
 			if err != nil {
 				err = fmt.Errorf("initBlocksDB.blockResetDB %v", err)
 				return err
 			}
-			err = blockInit(tx, initBlocks)
+			err = tx.blockInit(initBlocks)
 			if err != nil {
 				err = fmt.Errorf("initBlocksDB.blockInit 2 %v", err)
 				return err
@@ -355,8 +443,12 @@ func (l *Ledger) Close() {
 	l.trackers.close()
 
 	// last, we close the underlying database connections.
-	l.blockDBs.Close()
-	l.trackerDBs.Close()
+	if l.blockDBs != nil {
+		l.blockDBs.close()
+	}
+	if l.trackerDBs != nil {
+		l.trackerDBs.close()
+	}
 }
 
 // RegisterBlockListeners registers listeners that will be called when a
@@ -424,6 +516,24 @@ func (l *Ledger) ListAssets(maxAssetIdx basics.AssetIndex, maxResults uint64) (r
 	return l.accts.ListAssets(maxAssetIdx, maxResults)
 }
 
+// ListAssetHolders takes an asset index, an optional address to list after, a holding amounts range and a maximum
+// result length, and returns up to that many holders of the asset, ordered by their addresses, as of the latest round,
+// along with that round.
+func (l *Ledger) ListAssetHolders(aidx basics.AssetIndex, after *basics.Address, minBalance, maxBalance uint64, maxResults uint64) (results []AssetHolder, rnd basics.Round, err error) {
+	l.trackerMu.RLock()
+	defer l.trackerMu.RUnlock()
+	return l.accts.ListAssetHolders(aidx, after, minBalance, maxBalance, maxResults)
+}
+
+// ListApplicationAccounts takes an application index, an optional address to list after and a maximum result length,
+// and returns up to that many addresses of the accounts opted into the application, in increasing order, as of the
+// latest round, along with that round.
+func (l *Ledger) ListApplicationAccounts(appIdx basics.AppIndex, after *basics.Address, maxResults uint64) (results []basics.Address, rnd basics.Round, err error) {
+	l.trackerMu.RLock()
+	defer l.trackerMu.RUnlock()
+	return l.accts.ListApplicationAccounts(appIdx, after, maxResults)
+}
+
 // ListApplications takes a maximum app index and maximum result length, and
 // returns up to that many CreatableLocators from the database where app idx is
 // less than or equal to the maximum.
@@ -527,7 +637,7 @@ func (l *Ledger) BlockCert(rnd basics.Round) (blk bookkeeping.Block, cert agreem
 func (l *Ledger) AddBlock(blk bookkeeping.Block, cert agreement.Certificate) error {
 	// passing nil as the executionPool is ok since we've asking the evaluator to skip verification.
 
-	updates, err := eval(context.Background(), l, blk, false, l.verifiedTxnCache, nil)
+	updates, err := eval(context.Background(), l, blk, false, l.verifiedTxnCache, nil, l.parallelEvalWorkers)
 	if err != nil {
 		return err
 	}
@@ -612,12 +722,12 @@ func (l *Ledger) GetCatchpointStream(round basics.Round) (ReadCloseSizer, error)
 }
 
 // ledgerForTracker methods
-func (l *Ledger) trackerDB() db.Pair {
+func (l *Ledger) trackerDB() trackerStore {
 	return l.trackerDBs
 }
 
 // ledgerForTracker methods
-func (l *Ledger) blockDB() db.Pair {
+func (l *Ledger) blockDB() blockStore {
 	return l.blockDBs
 }
 
@@ -630,7 +740,7 @@ func (l *Ledger) trackerLog() logging.Logger {
 // evaluator to shortcut the "main" ledger ( i.e. this struct ) and avoid taking the trackers lock a second time.
 func (l *Ledger) trackerEvalVerified(blk bookkeeping.Block, accUpdatesLedger ledgerForEvaluator) (ledgercore.StateDelta, error) {
 	// passing nil as the executionPool is ok since we've asking the evaluator to skip verification.
-	return eval(context.Background(), accUpdatesLedger, blk, false, l.verifiedTxnCache, nil)
+	return eval(context.Background(), accUpdatesLedger, blk, false, l.verifiedTxnCache, nil, 0)
 }
 
 // IsWritingCatchpointFile returns true when a catchpoint file is being generated. The function is used by the catchup service
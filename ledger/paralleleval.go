@@ -0,0 +1,402 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package ledger
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/util/metrics"
+)
+
+// parallelEvalMaxBatchSize is the maximal number of transaction groups that would be
+// evaluated concurrently as a single batch.
+const parallelEvalMaxBatchSize = 1024
+
+var ledgerParallelEvalBatchesCount = metrics.NewCounter("ledger_parallel_eval_batches_count", "transaction group batches evaluated concurrently")
+var ledgerParallelEvalGroupsCount = metrics.NewCounter("ledger_parallel_eval_groups_count", "transaction groups evaluated concurrently")
+var ledgerParallelEvalSequentialCount = metrics.NewCounter("ledger_parallel_eval_sequential_count", "transaction groups evaluated sequentially by the parallel evaluator")
+
+// evalResourceType is the type of a ledger resource accessed by a transaction group.
+//msgp:ignore evalResourceType
+type evalResourceType int
+
+const (
+	// evalResourceAccount is the balance record of an account
+	evalResourceAccount evalResourceType = iota
+	// evalResourceCreatable is an asset or an application. Both share the same index space.
+	evalResourceCreatable
+	// evalResourceTxid is a transaction id, used to detect duplicate transactions across groups.
+	evalResourceTxid
+)
+
+// evalResource identifies a single ledger resource accessed by a transaction group.
+type evalResource struct {
+	rtype evalResourceType
+	// key is either the account address or the transaction id, depending on rtype.
+	key [32]byte
+	// cidx is the creatable index, for evalResourceCreatable resources.
+	cidx basics.CreatableIndex
+}
+
+// groupResources is the read/write set of a single transaction group.
+type groupResources struct {
+	reads  map[evalResource]bool
+	writes map[evalResource]bool
+	// barrier is set when the transaction group cannot be evaluated concurrently with any
+	// other transaction group, and needs to be evaluated on its own.
+	barrier bool
+}
+
+func makeGroupResources() groupResources {
+	return groupResources{
+		reads:  make(map[evalResource]bool),
+		writes: make(map[evalResource]bool),
+	}
+}
+
+func (gr *groupResources) readCreatable(cidx basics.CreatableIndex) {
+	gr.reads[evalResource{rtype: evalResourceCreatable, cidx: cidx}] = true
+}
+
+func (gr *groupResources) writeCreatable(cidx basics.CreatableIndex) {
+	gr.writes[evalResource{rtype: evalResourceCreatable, cidx: cidx}] = true
+}
+
+func (gr *groupResources) writeAccount(addr basics.Address) {
+	if addr.IsZero() {
+		return
+	}
+	gr.writes[evalResource{rtype: evalResourceAccount, key: addr}] = true
+}
+
+func (gr *groupResources) writeTxid(txid transactions.Txid) {
+	gr.writes[evalResource{rtype: evalResourceTxid, key: txid}] = true
+}
+
+// conflicts tests whether the two read/write sets intersect in a way that would
+// make the result of their concurrent evaluation depend on the order of execution.
+func (gr *groupResources) conflicts(other *groupResources) bool {
+	if gr.barrier || other.barrier {
+		return true
+	}
+	for res := range gr.writes {
+		if other.writes[res] || other.reads[res] {
+			return true
+		}
+	}
+	for res := range gr.reads {
+		if other.writes[res] {
+			return true
+		}
+	}
+	return false
+}
+
+// merge adds the other read/write set into this one.
+func (gr *groupResources) merge(other *groupResources) {
+	for res := range other.writes {
+		gr.writes[res] = true
+	}
+	for res := range other.reads {
+		gr.reads[res] = true
+	}
+	gr.barrier = gr.barrier || other.barrier
+}
+
+// counterOffsetParent wraps the parent of a transaction group cow, offsetting the
+// transaction counter by the number of transactions preceding the group in its batch.
+// This ensures that creatable indices allocated by concurrently evaluated transaction
+// groups are identical to those that would be allocated during a sequential evaluation.
+type counterOffsetParent struct {
+	roundCowParent
+	offset uint64
+}
+
+func (p *counterOffsetParent) txnCounter() uint64 {
+	return p.roundCowParent.txnCounter() + p.offset
+}
+
+// parallelGroupResult is the outcome of evaluating a single transaction group of a batch.
+type parallelGroupResult struct {
+	cow          *roundCowState
+	txibs        []transactions.SignedTxnInBlock
+	groupTxBytes int
+	err          error
+}
+
+// parallelGroupEvaluator accumulates consecutive non-conflicting transaction groups into
+// batches, evaluates each batch concurrently on separate roundCowState children, and merges
+// the results into the block evaluator in the original transaction groups order. Transaction
+// groups that conflict with the pending batch would cause the batch to be flushed, so that the
+// evaluation order of conflicting groups is identical to the sequential one.
+//
+// All the transaction groups pay their fees to the fee sink. The fee sink is therefore excluded
+// from the conflict detection, and the fees collected by each transaction group are added to the
+// fee sink balance when the group is merged. Transaction groups that access the fee sink in any
+// other way are evaluated sequentially.
+type parallelGroupEvaluator struct {
+	eval    *BlockEvaluator
+	workers int
+
+	// batch is the list of pending transaction groups, and batchTxns is the total number of transactions in these groups.
+	batch     [][]transactions.SignedTxnWithAD
+	batchTxns int
+	// batchResources is the union of the read/write sets of the pending transaction groups.
+	batchResources groupResources
+}
+
+func makeParallelGroupEvaluator(eval *BlockEvaluator, workers int) *parallelGroupEvaluator {
+	return &parallelGroupEvaluator{
+		eval:           eval,
+		workers:        workers,
+		batchResources: makeGroupResources(),
+	}
+}
+
+// add adds the given transaction group to the pending batch, evaluating the pending batch
+// first if the transaction group conflicts with any of the pending transaction groups.
+func (pe *parallelGroupEvaluator) add(txgroup []transactions.SignedTxnWithAD) error {
+	if len(txgroup) == 0 {
+		return nil
+	}
+	// the fees of the concurrently evaluated groups are merged on top of the fee sink balance in
+	// the parent state. Until the fee sink is modified by the block ( typically by the first transaction
+	// group ), we need to evaluate the transaction groups sequentially so that the rewards are applied to it once.
+	if _, feeSinkModified := pe.eval.state.mods.Accts.Get(pe.eval.block.FeeSink); !feeSinkModified {
+		return pe.sequential(txgroup)
+	}
+
+	resources := pe.groupResources(txgroup)
+	if len(pe.batch) > 0 && (len(pe.batch) >= parallelEvalMaxBatchSize || resources.conflicts(&pe.batchResources)) {
+		err := pe.flush()
+		if err != nil {
+			return err
+		}
+		// the resources might depend on the state changes applied by the flushed batch
+		resources = pe.groupResources(txgroup)
+	}
+	if resources.barrier {
+		return pe.sequential(txgroup)
+	}
+
+	pe.batch = append(pe.batch, txgroup)
+	pe.batchTxns += len(txgroup)
+	pe.batchResources.merge(&resources)
+	return nil
+}
+
+// sequential flushes the pending batch and evaluates the given transaction group on its own.
+func (pe *parallelGroupEvaluator) sequential(txgroup []transactions.SignedTxnWithAD) error {
+	err := pe.flush()
+	if err != nil {
+		return err
+	}
+	ledgerParallelEvalSequentialCount.Inc(nil)
+	return pe.eval.transactionGroup(txgroup)
+}
+
+// flush evaluates the pending batch and merges the results into the block evaluator state.
+func (pe *parallelGroupEvaluator) flush() error {
+	batch := pe.batch
+	pe.batch = nil
+	pe.batchTxns = 0
+	pe.batchResources = makeGroupResources()
+
+	switch len(batch) {
+	case 0:
+		return nil
+	case 1:
+		ledgerParallelEvalSequentialCount.Inc(nil)
+		return pe.eval.transactionGroup(batch[0])
+	}
+
+	eval := pe.eval
+	feeSink := eval.block.FeeSink
+	feeSinkData, err := eval.state.lookup(feeSink)
+	if err != nil {
+		return err
+	}
+
+	results := make([]parallelGroupResult, len(batch))
+	offsets := make([]uint64, len(batch))
+	var offset uint64
+	for i, txgroup := range batch {
+		if len(txgroup) > eval.proto.MaxTxGroupSize {
+			results[i].err = fmt.Errorf("group size %d exceeds maximum %d", len(txgroup), eval.proto.MaxTxGroupSize)
+		}
+		offsets[i] = offset
+		offset += uint64(len(txgroup))
+	}
+
+	// evaluate all the transaction groups of the batch concurrently. The parent state is not
+	// modified until all the transaction groups are evaluated.
+	var wg sync.WaitGroup
+	next := int64(-1)
+	workers := pe.workers
+	if workers > len(batch) {
+		workers = len(batch)
+	}
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(atomic.AddInt64(&next, 1))
+				if i >= len(batch) {
+					return
+				}
+				if results[i].err != nil {
+					continue
+				}
+				cow := eval.state.child(len(batch[i]))
+				cow.lookupParent = &counterOffsetParent{roundCowParent: eval.state, offset: offsets[i]}
+				results[i].cow = cow
+				results[i].txibs, results[i].groupTxBytes, results[i].err = eval.evalTransactionGroup(batch[i], cow, eval.blockTxBytes)
+			}
+		}()
+	}
+	wg.Wait()
+
+	ledgerParallelEvalBatchesCount.Inc(nil)
+	ledgerParallelEvalGroupsCount.AddUint64(uint64(len(batch)), nil)
+
+	// merge the results in the original order; the first failing group determines the returned error, exactly as
+	// it would during a sequential evaluation.
+	for _, result := range results {
+		if result.err != nil {
+			return result.err
+		}
+		if eval.validate && eval.blockTxBytes+result.groupTxBytes > eval.proto.MaxTxnBytesPerBlock {
+			return ErrNoSpace
+		}
+		err = pe.mergeFeeSink(result.cow, feeSink, feeSinkData.MicroAlgos)
+		if err != nil {
+			return err
+		}
+		eval.block.Payset = append(eval.block.Payset, result.txibs...)
+		eval.blockTxBytes += result.groupTxBytes
+		result.cow.commitToParent()
+	}
+	return nil
+}
+
+// mergeFeeSink updates the fee sink balance in the given transaction group cow so that it would
+// reflect the fees collected by all the previously merged transaction groups, in addition to the fees
+// collected by this transaction group. The batchFeeSinkBalance is the fee sink balance that was
+// visible to the transaction group during its evaluation.
+func (pe *parallelGroupEvaluator) mergeFeeSink(cow *roundCowState, feeSink basics.Address, batchFeeSinkBalance basics.MicroAlgos) error {
+	groupFeeSinkData, modified := cow.mods.Accts.Get(feeSink)
+	if !modified {
+		return nil
+	}
+	var ot basics.OverflowTracker
+	collected := ot.SubA(groupFeeSinkData.MicroAlgos, batchFeeSinkBalance)
+	if ot.Overflowed {
+		return fmt.Errorf("fee sink %v balance decreased from %d to %d during parallel evaluation", feeSink, batchFeeSinkBalance.Raw, groupFeeSinkData.MicroAlgos.Raw)
+	}
+	feeSinkData, err := pe.eval.state.lookup(feeSink)
+	if err != nil {
+		return err
+	}
+	feeSinkData.MicroAlgos = ot.AddA(feeSinkData.MicroAlgos, collected)
+	if ot.Overflowed {
+		return fmt.Errorf("balance overflow (account %v, data %+v, was going to receive %v)", feeSink, feeSinkData, collected)
+	}
+	cow.mods.Accts.Upsert(feeSink, feeSinkData)
+	return nil
+}
+
+// groupResources builds the read/write set of the given transaction group, assuming that it
+// would be appended to the pending batch. Transaction groups that cannot be analyzed are marked as barriers.
+func (pe *parallelGroupEvaluator) groupResources(txgroup []transactions.SignedTxnWithAD) (resources groupResources) {
+	resources = makeGroupResources()
+	eval := pe.eval
+	feeSink := eval.block.FeeSink
+	// the counter value that would be used by the first transaction of the group to allocate creatable indices.
+	txnCounter := eval.state.txnCounter() + uint64(pe.batchTxns)
+
+	writeCreator := func(cidx basics.CreatableIndex, ctype basics.CreatableType) {
+		creator, exists, err := eval.state.getCreator(cidx, ctype)
+		if err != nil {
+			resources.barrier = true
+			return
+		}
+		if exists {
+			resources.writeAccount(creator)
+		}
+	}
+
+	for i, stxn := range txgroup {
+		tx := &stxn.SignedTxn.Txn
+		resources.writeTxid(stxn.SignedTxn.ID())
+		resources.writeAccount(tx.Sender)
+		resources.writeAccount(tx.Receiver)
+		resources.writeAccount(tx.CloseRemainderTo)
+		resources.writeAccount(tx.AssetSender)
+		resources.writeAccount(tx.AssetReceiver)
+		resources.writeAccount(tx.AssetCloseTo)
+		resources.writeAccount(tx.FreezeAccount)
+		for _, addr := range tx.Accounts {
+			resources.writeAccount(addr)
+		}
+
+		ctr := txnCounter + uint64(i)
+		switch tx.Type {
+		case protocol.PaymentTx, protocol.KeyRegistrationTx:
+		case protocol.AssetConfigTx:
+			if tx.ConfigAsset == 0 {
+				resources.writeCreatable(basics.CreatableIndex(ctr + 1))
+			} else {
+				resources.writeCreatable(basics.CreatableIndex(tx.ConfigAsset))
+				// reconfiguring or destroying an asset modifies the asset creator account.
+				writeCreator(basics.CreatableIndex(tx.ConfigAsset), basics.AssetCreatable)
+			}
+		case protocol.AssetTransferTx:
+			resources.readCreatable(basics.CreatableIndex(tx.XferAsset))
+		case protocol.AssetFreezeTx:
+			resources.readCreatable(basics.CreatableIndex(tx.FreezeAsset))
+		case protocol.ApplicationCallTx:
+			if tx.ApplicationID == 0 {
+				resources.writeCreatable(basics.CreatableIndex(ctr + 1))
+			} else {
+				resources.writeCreatable(basics.CreatableIndex(tx.ApplicationID))
+				// the global state of the application is stored in the creator account.
+				writeCreator(basics.CreatableIndex(tx.ApplicationID), basics.AppCreatable)
+			}
+			for _, aidx := range tx.ForeignApps {
+				resources.readCreatable(basics.CreatableIndex(aidx))
+			}
+			for _, aidx := range tx.ForeignAssets {
+				resources.readCreatable(basics.CreatableIndex(aidx))
+			}
+		default:
+			// compact certificates ( and any unknown transaction type ) are evaluated on their own.
+			resources.barrier = true
+		}
+	}
+
+	// the fee sink is excluded from the conflict detection, as long as it's being used only for collecting fees.
+	if resources.writes[evalResource{rtype: evalResourceAccount, key: feeSink}] {
+		resources.barrier = true
+	}
+	return resources
+}
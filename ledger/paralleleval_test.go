@@ -0,0 +1,328 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/data/transactions/logic"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/util/execpool"
+	"github.com/algorand/go-algorand/util/metrics"
+)
+
+// parallelEvalTxnMix is the mix of the transactions of a parallelEvalTestBlock. The transactions which are
+// neither asset transfers nor application calls are payments.
+type parallelEvalTxnMix struct {
+	// assetTransfers and appCalls are the percentages of the asset transactions and of the application calls.
+	// The asset transactions are mostly transfers between the accounts holding the asset, along with the
+	// opt-ins to it and an occasional reconfiguration of the asset.
+	assetTransfers int
+	appCalls       int
+}
+
+// parallelEvalTestBlock generates a block using a pingpong-like load, where the senders pay random receivers,
+// along with the asset and application transactions of the given mix. It returns the genesis used by the
+// block and the validated block.
+func parallelEvalTestBlock(t testing.TB, numAccounts int, numTxns int, mix parallelEvalTxnMix) (InitState, *ValidatedBlock) {
+	genesisInitState, addrs, keys := genesis(numAccounts)
+	dbName := fmt.Sprintf("%s.%d", t.Name(), crypto.RandUint64())
+	proto := config.Consensus[genesisInitState.Block.CurrentProtocol]
+	proto.MaxTxnBytesPerBlock = 1000000000 // very big, no limit
+	config.Consensus[protocol.ConsensusVersion(dbName)] = proto
+	genesisInitState.Block.CurrentProtocol = protocol.ConsensusVersion(dbName)
+
+	cfg := config.GetDefaultLocal()
+	l, err := OpenLedger(logging.Base(), dbName, true, genesisInitState, cfg)
+	require.NoError(t, err)
+	defer testLedgerCleanup(l, dbName, true)
+
+	newBlock := bookkeeping.MakeBlock(genesisInitState.Block.BlockHeader)
+	bev, err := l.StartEvaluator(newBlock.BlockHeader, 0)
+	require.NoError(t, err)
+
+	header := func(sender int) transactions.Header {
+		return transactions.Header{
+			Sender:      addrs[sender],
+			Fee:         minFee,
+			FirstValid:  newBlock.Round(),
+			LastValid:   newBlock.Round(),
+			GenesisHash: genesisInitState.GenesisHash,
+			Note:        []byte(fmt.Sprintf("%d", crypto.RandUint64())),
+		}
+	}
+	addTxn := func(sender int, txn transactions.Transaction) {
+		err := bev.Transaction(txn.Sign(keys[sender]), transactions.ApplyData{})
+		require.NoError(t, err)
+	}
+
+	var assetID basics.AssetIndex
+	var appID basics.AppIndex
+	// assetBalances holds the asset balances of the accounts which opted in to the asset.
+	assetBalances := make(map[int]uint64)
+	if mix.assetTransfers > 0 || mix.appCalls > 0 {
+		assetID = basics.AssetIndex(bev.state.txnCounter() + 1)
+		addTxn(0, transactions.Transaction{
+			Type:   protocol.AssetConfigTx,
+			Header: header(0),
+			AssetConfigTxnFields: transactions.AssetConfigTxnFields{
+				AssetParams: basics.AssetParams{Total: 1000000, Manager: addrs[0]},
+			},
+		})
+		assetBalances[0] = 1000000
+
+		ops, err := logic.AssembleString("#pragma version 2\nbyte \"c\"\nbyte \"c\"\napp_global_get\nint 1\n+\napp_global_put\nint 1")
+		require.NoError(t, err)
+		approval := ops.Program
+		ops, err = logic.AssembleString("#pragma version 2\nint 1")
+		require.NoError(t, err)
+		appID = basics.AppIndex(bev.state.txnCounter() + 1)
+		addTxn(1, transactions.Transaction{
+			Type:   protocol.ApplicationCallTx,
+			Header: header(1),
+			ApplicationCallTxnFields: transactions.ApplicationCallTxnFields{
+				ApprovalProgram:   approval,
+				ClearStateProgram: ops.Program,
+				GlobalStateSchema: basics.StateSchema{NumUint: 1},
+			},
+		})
+	}
+	assetTransfer := func(sender, receiver int, amount uint64) {
+		addTxn(sender, transactions.Transaction{
+			Type:   protocol.AssetTransferTx,
+			Header: header(sender),
+			AssetTransferTxnFields: transactions.AssetTransferTxnFields{
+				XferAsset:     assetID,
+				AssetReceiver: addrs[receiver],
+				AssetAmount:   amount,
+			},
+		})
+		assetBalances[sender] -= amount
+		assetBalances[receiver] += amount
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < numTxns; i++ {
+		sender := rnd.Intn(len(addrs))
+		receiver := rnd.Intn(len(addrs))
+		kind := rnd.Intn(100)
+		_, senderOptedIn := assetBalances[sender]
+		_, receiverOptedIn := assetBalances[receiver]
+		switch {
+		case kind < mix.appCalls:
+			addTxn(sender, transactions.Transaction{
+				Type:   protocol.ApplicationCallTx,
+				Header: header(sender),
+				ApplicationCallTxnFields: transactions.ApplicationCallTxnFields{
+					ApplicationID: appID,
+				},
+			})
+		case kind >= mix.appCalls+mix.assetTransfers || (senderOptedIn && receiverOptedIn && assetBalances[sender] == 0 && assetBalances[0] == 0):
+			addTxn(sender, transactions.Transaction{
+				Type:   protocol.PaymentTx,
+				Header: header(sender),
+				PaymentTxnFields: transactions.PaymentTxnFields{
+					Receiver: addrs[receiver],
+					Amount:   basics.MicroAlgos{Raw: 100 + uint64(i)},
+				},
+			})
+		case i%50 == 0:
+			// reconfigure the asset, which modifies the creator account
+			addTxn(0, transactions.Transaction{
+				Type:   protocol.AssetConfigTx,
+				Header: header(0),
+				AssetConfigTxnFields: transactions.AssetConfigTxnFields{
+					ConfigAsset: assetID,
+					AssetParams: basics.AssetParams{Manager: addrs[0], Reserve: addrs[receiver]},
+				},
+			})
+		case !senderOptedIn:
+			assetTransfer(sender, sender, 0)
+		case !receiverOptedIn:
+			assetTransfer(receiver, receiver, 0)
+		case assetBalances[sender] == 0:
+			// the creator hands out some of the asset
+			amount := uint64(1000)
+			if amount > assetBalances[0] {
+				amount = assetBalances[0]
+			}
+			assetTransfer(0, sender, amount)
+		default:
+			assetTransfer(sender, receiver, 1+uint64(rnd.Intn(int(assetBalances[sender]))))
+		}
+	}
+
+	validatedBlock, err := bev.GenerateBlock()
+	require.NoError(t, err)
+	return genesisInitState, validatedBlock
+}
+
+func requireEqualStateDelta(t *testing.T, expected, actual ledgercore.StateDelta) {
+	require.Equal(t, expected.Accts.Len(), actual.Accts.Len())
+	for i := 0; i < expected.Accts.Len(); i++ {
+		addr, data := expected.Accts.GetByIdx(i)
+		actualData, ok := actual.Accts.Get(addr)
+		require.True(t, ok, "account %v is missing", addr)
+		require.Equal(t, data, actualData, "account %v", addr)
+	}
+	require.Equal(t, expected.Creatables, actual.Creatables)
+	require.Equal(t, expected.Txids, actual.Txids)
+	require.Equal(t, expected.Txleases, actual.Txleases)
+	require.Equal(t, expected.CompactCertNext, actual.CompactCertNext)
+}
+
+func TestParallelEvalMatchesSequential(t *testing.T) {
+	genesisInitState, validatedBlock := parallelEvalTestBlock(t, 200, 1000, parallelEvalTxnMix{assetTransfers: 6, appCalls: 2})
+
+	dbName := fmt.Sprintf("%s.%d", t.Name(), crypto.RandUint64())
+	l, err := OpenLedger(logging.Base(), dbName, true, genesisInitState, config.GetDefaultLocal())
+	require.NoError(t, err)
+	defer testLedgerCleanup(l, dbName, true)
+
+	backlogPool := execpool.MakeBacklog(nil, 0, execpool.LowPriority, nil)
+	defer backlogPool.Shutdown()
+
+	sequential, err := eval(context.Background(), l, validatedBlock.blk, true, l.VerifiedTransactionCache(), backlogPool, 0)
+	require.NoError(t, err)
+	requireEqualStateDelta(t, validatedBlock.delta, sequential)
+
+	for _, workers := range []int{2, 4, 16} {
+		parallel, err := eval(context.Background(), l, validatedBlock.blk, true, l.VerifiedTransactionCache(), backlogPool, workers)
+		require.NoError(t, err)
+		requireEqualStateDelta(t, sequential, parallel)
+	}
+}
+
+func TestParallelEvalDetectsInvalidBlock(t *testing.T) {
+	genesisInitState, validatedBlock := parallelEvalTestBlock(t, 100, 200, parallelEvalTxnMix{})
+
+	dbName := fmt.Sprintf("%s.%d", t.Name(), crypto.RandUint64())
+	l, err := OpenLedger(logging.Base(), dbName, true, genesisInitState, config.GetDefaultLocal())
+	require.NoError(t, err)
+	defer testLedgerCleanup(l, dbName, true)
+
+	// duplicate one of the transactions later on in the block; the duplicate doesn't conflict with
+	// any of the accounts of its neighbors, and needs to be detected by the txid conflict.
+	blk := validatedBlock.blk
+	blk.Payset = append(blk.Payset[:150:150], blk.Payset[100])
+	blk.Payset = append(blk.Payset, validatedBlock.blk.Payset[150:]...)
+
+	backlogPool := execpool.MakeBacklog(nil, 0, execpool.LowPriority, nil)
+	defer backlogPool.Shutdown()
+
+	_, errSequential := eval(context.Background(), l, blk, true, l.VerifiedTransactionCache(), backlogPool, 0)
+	require.Error(t, errSequential)
+	_, errParallel := eval(context.Background(), l, blk, true, l.VerifiedTransactionCache(), backlogPool, 8)
+	require.Error(t, errParallel)
+	require.Equal(t, errSequential.Error(), errParallel.Error())
+}
+
+func TestGroupResourcesConflicts(t *testing.T) {
+	var addr1, addr2 basics.Address
+	crypto.RandBytes(addr1[:])
+	crypto.RandBytes(addr2[:])
+
+	g1 := makeGroupResources()
+	g1.writeAccount(addr1)
+	g1.readCreatable(5)
+
+	g2 := makeGroupResources()
+	g2.writeAccount(addr2)
+	g2.readCreatable(5)
+	require.False(t, g1.conflicts(&g2))
+	require.False(t, g2.conflicts(&g1))
+
+	g3 := makeGroupResources()
+	g3.writeCreatable(5)
+	require.True(t, g1.conflicts(&g3))
+	require.True(t, g3.conflicts(&g1))
+
+	g4 := makeGroupResources()
+	g4.writeAccount(addr1)
+	require.True(t, g1.conflicts(&g4))
+
+	g5 := makeGroupResources()
+	g5.barrier = true
+	require.True(t, g5.conflicts(&g2))
+
+	g2.merge(&g3)
+	require.True(t, g1.conflicts(&g2))
+}
+
+// parallelEvalCounter returns the current value of one of the counters of the parallel evaluation.
+func parallelEvalCounter(counter *metrics.Counter) (value uint64) {
+	values := make(map[string]string)
+	counter.AddMetric(values)
+	for _, v := range values {
+		value, _ = strconv.ParseUint(v, 10, 64)
+	}
+	return
+}
+
+func benchmarkBlockEvaluatorParallel(b *testing.B, numAccounts int, mix parallelEvalTxnMix) {
+	genesisInitState, validatedBlock := parallelEvalTestBlock(b, numAccounts, 20000, mix)
+	for _, workers := range []int{0, 2, 4, 8, 16} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			dbName := fmt.Sprintf("%s.%d", b.Name(), crypto.RandUint64())
+			cfg := config.GetDefaultLocal()
+			cfg.ParallelBlockEvaluationWorkers = workers
+			l, err := OpenLedger(logging.Base(), dbName, true, genesisInitState, cfg)
+			require.NoError(b, err)
+			defer testLedgerCleanup(l, dbName, true)
+
+			sequentialGroups := parallelEvalCounter(ledgerParallelEvalSequentialCount)
+			batches := parallelEvalCounter(ledgerParallelEvalBatchesCount)
+			start := time.Now()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, err = eval(context.Background(), l, validatedBlock.blk, false, nil, nil, l.parallelEvalWorkers)
+				require.NoError(b, err)
+			}
+			b.StopTimer()
+			b.ReportMetric(float64(time.Since(start))/float64(len(validatedBlock.blk.Payset)*b.N), "ns/eval_tx")
+			// the groups which fell back to the sequential evaluation, and the batches the others were split into
+			// by the conflicts.
+			b.ReportMetric(float64(parallelEvalCounter(ledgerParallelEvalSequentialCount)-sequentialGroups)/float64(b.N), "sequential_groups/block")
+			b.ReportMetric(float64(parallelEvalCounter(ledgerParallelEvalBatchesCount)-batches)/float64(b.N), "batches/block")
+		})
+	}
+}
+
+func BenchmarkBlockEvaluatorParallelPayments(b *testing.B) {
+	benchmarkBlockEvaluatorParallel(b, 10000, parallelEvalTxnMix{})
+}
+
+// BenchmarkBlockEvaluatorParallelMixed measures a pingpong-like mix of payments, asset transfers and application
+// calls, among fewer accounts, so that the transaction groups often conflict: the application calls all write the
+// global state of the same application, and the asset transfers share the accounts holding the asset.
+func BenchmarkBlockEvaluatorParallelMixed(b *testing.B) {
+	benchmarkBlockEvaluatorParallel(b, 1000, parallelEvalTxnMix{assetTransfers: 30, appCalls: 20})
+}
@@ -0,0 +1,368 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/util/db"
+)
+
+// migrateBlocksChunkSize is the number of blocks copied within a single transaction during the storage migration.
+const migrateBlocksChunkSize = 256
+
+// ledgerStoragePaths returns the paths of the tracker and blocks databases of the given storage backend.
+func ledgerStoragePaths(dbPathPrefix string, backend string) (trackerPath string, blockPath string, err error) {
+	switch backend {
+	case StorageBackendSQLite, "":
+		return dbPathPrefix + ".tracker.sqlite", dbPathPrefix + ".block.sqlite", nil
+	case StorageBackendLevelDB:
+		return dbPathPrefix + ".tracker.leveldb", dbPathPrefix + ".block.leveldb", nil
+	default:
+		return "", "", fmt.Errorf("unsupported ledger storage backend '%s'", backend)
+	}
+}
+
+// removeLedgerStorage deletes the tracker and blocks databases of the given storage backend.
+func removeLedgerStorage(dbPathPrefix string, backend string) error {
+	trackerPath, blockPath, err := ledgerStoragePaths(dbPathPrefix, backend)
+	if err != nil {
+		return err
+	}
+	for _, path := range []string{trackerPath, blockPath} {
+		for _, suffix := range []string{"", "-shm", "-wal"} {
+			err = os.RemoveAll(path + suffix)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// MigrateLedgerStorage copies the ledger stored at dbPathPrefix by the fromBackend storage into a new ledger
+// stored by the toBackend storage. The source ledger is left intact, and the destination ledger must not exist.
+// The accounts merkle trie isn't copied; it would get rebuilt once the migrated ledger is first opened.
+// The ledger must not be in use while it's being migrated.
+func MigrateLedgerStorage(log logging.Logger, dbPathPrefix string, genesisProto config.ConsensusParams, fromBackend, toBackend string) (err error) {
+	if fromBackend == toBackend {
+		return fmt.Errorf("MigrateLedgerStorage: source and destination storage backends are both '%s'", fromBackend)
+	}
+	trackerPath, blockPath, err := ledgerStoragePaths(dbPathPrefix, toBackend)
+	if err != nil {
+		return err
+	}
+	for _, path := range []string{trackerPath, blockPath} {
+		if _, err = os.Stat(path); !os.IsNotExist(err) {
+			return fmt.Errorf("MigrateLedgerStorage: destination '%s' already exists", path)
+		}
+	}
+	srcPath, _, err := ledgerStoragePaths(dbPathPrefix, fromBackend)
+	if err != nil {
+		return err
+	}
+	if _, err = os.Stat(srcPath); err != nil {
+		return fmt.Errorf("MigrateLedgerStorage: unable to find source ledger: %v", err)
+	}
+
+	srcTracker, srcBlocks, err := openLedgerDB(dbPathPrefix, false, fromBackend)
+	if err != nil {
+		return fmt.Errorf("MigrateLedgerStorage: unable to open source ledger: %v", err)
+	}
+	defer srcTracker.close()
+	defer srcBlocks.close()
+
+	dstTracker, dstBlocks, err := openLedgerDB(dbPathPrefix, false, toBackend)
+	if err != nil {
+		return fmt.Errorf("MigrateLedgerStorage: unable to create destination ledger: %v", err)
+	}
+	defer func() {
+		dstTracker.close()
+		dstBlocks.close()
+		if err != nil {
+			// remove the partially migrated ledger, so that the migration could be retried.
+			if rmErr := removeLedgerStorage(dbPathPrefix, toBackend); rmErr != nil {
+				log.Warnf("MigrateLedgerStorage: unable to remove partially migrated ledger: %v", rmErr)
+			}
+		}
+	}()
+	srcTracker.setLogger(log)
+	srcBlocks.setLogger(log)
+	dstTracker.setLogger(log)
+	dstBlocks.setLogger(log)
+
+	ctx := context.Background()
+	// the destination is being populated from scratch; there is no point in syncing each of the transactions.
+	dstTracker.setSynchronousMode(ctx, db.SynchronousModeOff, false)
+	dstBlocks.setSynchronousMode(ctx, db.SynchronousModeOff, false)
+
+	err = migrateBlocks(log, srcBlocks, dstBlocks)
+	if err != nil {
+		return fmt.Errorf("MigrateLedgerStorage: unable to migrate blocks: %v", err)
+	}
+	err = migrateAccounts(log, srcTracker, dstTracker, genesisProto)
+	if err != nil {
+		return fmt.Errorf("MigrateLedgerStorage: unable to migrate accounts: %v", err)
+	}
+
+	dstTracker.setSynchronousMode(ctx, db.SynchronousModeFull, true)
+	dstBlocks.setSynchronousMode(ctx, db.SynchronousModeFull, true)
+	err = migrateCatchpoints(srcTracker, dstTracker)
+	if err != nil {
+		return fmt.Errorf("MigrateLedgerStorage: unable to migrate catchpoints: %v", err)
+	}
+	return nil
+}
+
+// migrateBlocks copies all the blocks and their certificates.
+func migrateBlocks(log logging.Logger, src blockStore, dst blockStore) error {
+	var earliest, latest basics.Round
+	err := src.snapshot(func(ctx context.Context, tx blockStoreReader) (err error) {
+		earliest, err = tx.blockEarliest()
+		if err != nil {
+			return err
+		}
+		latest, err = tx.blockLatest()
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	log.Infof("MigrateLedgerStorage: migrating blocks %d-%d", earliest, latest)
+	err = dst.batch(func(ctx context.Context, tx blockStoreWriter) error {
+		// create the blocks schema, without adding any blocks.
+		return tx.blockInit(nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	type encodedBlockCert struct {
+		blk  bookkeeping.Block
+		cert []byte
+	}
+	for rnd := earliest; rnd <= latest; rnd += migrateBlocksChunkSize {
+		var chunk []encodedBlockCert
+		err = src.snapshot(func(ctx context.Context, tx blockStoreReader) error {
+			for r := rnd; r <= latest && r < rnd+migrateBlocksChunkSize; r++ {
+				blkbuf, certbuf, err := tx.blockGetEncodedCert(r)
+				if err != nil {
+					return err
+				}
+				var entry encodedBlockCert
+				err = protocol.Decode(blkbuf, &entry.blk)
+				if err != nil {
+					return err
+				}
+				entry.cert = certbuf
+				chunk = append(chunk, entry)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		err = dst.batch(func(ctx context.Context, tx blockStoreWriter) error {
+			for _, entry := range chunk {
+				err := tx.blockImport(entry.blk, entry.cert)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateAccounts copies the accounts and the creatables using the catchpoint staging tables of the destination,
+// which are then applied on top of an empty ledger.
+func migrateAccounts(log logging.Logger, src trackerStore, dst trackerStore, genesisProto config.ConsensusParams) error {
+	srcQueries, err := src.queries()
+	if err != nil {
+		return err
+	}
+	defer srcQueries.close()
+	catchupState, _, err := srcQueries.readCatchpointStateUint64(context.Background(), catchpointStateCatchupState)
+	if err != nil {
+		return err
+	}
+	if catchupState != CatchpointCatchupStateInactive {
+		return fmt.Errorf("unable to migrate a ledger while catchpoint catchup is in progress")
+	}
+
+	au := &accountUpdates{
+		log:          log,
+		initAccounts: make(map[basics.Address]basics.AccountData),
+		initProto:    genesisProto,
+	}
+	err = dst.batch(func(ctx context.Context, tx trackerStoreWriter) error {
+		err := tx.accountsUpgradeSchema(ctx, au)
+		if err != nil {
+			return err
+		}
+		return tx.resetCatchpointStagingBalances(ctx, true)
+	})
+	if err != nil {
+		return err
+	}
+
+	return src.snapshot(func(ctx context.Context, srcTx trackerStoreReader) error {
+		rnd, _, err := srcTx.accountsRound()
+		if err != nil {
+			return err
+		}
+		totals, err := srcTx.accountsTotals(false)
+		if err != nil {
+			return err
+		}
+		log.Infof("MigrateLedgerStorage: migrating accounts of round %d", rnd)
+
+		it := srcTx.makeEncodedAccountsIter()
+		defer it.Close()
+		migrated := 0
+		for {
+			// the source snapshot is kept open throughout the accounts migration.
+			srcTx.resetTransactionWarnDeadline(ctx, time.Now().Add(time.Minute))
+			bals, err := it.Next(ctx, BalancesPerCatchpointFileChunk)
+			if err != nil {
+				return err
+			}
+			if len(bals) > 0 {
+				normalizedAccountBalances, err := prepareNormalizedBalances(bals, genesisProto)
+				if err != nil {
+					return err
+				}
+				err = dst.batch(func(ctx context.Context, tx trackerStoreWriter) error {
+					err := tx.writeCatchpointStagingBalances(ctx, normalizedAccountBalances)
+					if err != nil {
+						return err
+					}
+					return tx.writeCatchpointStagingCreatable(ctx, normalizedAccountBalances)
+				})
+				if err != nil {
+					return err
+				}
+				migrated += len(bals)
+			}
+			if len(bals) < BalancesPerCatchpointFileChunk {
+				break
+			}
+		}
+
+		log.Infof("MigrateLedgerStorage: migrated %d accounts", migrated)
+		return dst.batch(func(ctx context.Context, tx trackerStoreWriter) error {
+			err := tx.applyCatchpointStagingBalances(ctx, rnd)
+			if err != nil {
+				return err
+			}
+			err = tx.accountsPutTotals(totals, false)
+			if err != nil {
+				return err
+			}
+			return tx.resetCatchpointStagingBalances(ctx, false)
+		})
+	})
+}
+
+// migrateCatchpoints copies the catchpoint state and the stored catchpoint files records.
+func migrateCatchpoints(src trackerStore, dst trackerStore) error {
+	srcQueries, err := src.queries()
+	if err != nil {
+		return err
+	}
+	defer srcQueries.close()
+	ctx := context.Background()
+
+	lastCatchpoint, _, err := srcQueries.readCatchpointStateString(ctx, catchpointStateLastCatchpoint)
+	if err != nil {
+		return err
+	}
+	writingCatchpoint, _, err := srcQueries.readCatchpointStateUint64(ctx, catchpointStateWritingCatchpoint)
+	if err != nil {
+		return err
+	}
+	// list all the stored catchpoints; the file count is bounded only by the int size.
+	fileNames, err := srcQueries.getOldestCatchpointFiles(ctx, int(^uint(0)>>1), 0)
+	if err != nil {
+		return err
+	}
+
+	type storedCatchpoint struct {
+		round      basics.Round
+		fileName   string
+		catchpoint string
+		fileSize   int64
+		// uncompressedSize is kept in the catchpoint state, rather than along with the stored catchpoint.
+		uncompressedSize uint64
+	}
+	catchpoints := make([]storedCatchpoint, 0, len(fileNames))
+	err = src.snapshot(func(ctx context.Context, tx trackerStoreReader) error {
+		for round := range fileNames {
+			cp := storedCatchpoint{round: round}
+			var err error
+			cp.fileName, cp.catchpoint, cp.fileSize, err = tx.getCatchpoint(round)
+			if err != nil {
+				return err
+			}
+			cp.uncompressedSize, _, err = tx.readCatchpointStateUint64(ctx, catchpointStateCatchpointUncompressedSize(round))
+			if err != nil {
+				return err
+			}
+			catchpoints = append(catchpoints, cp)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	dstQueries, err := dst.queries()
+	if err != nil {
+		return err
+	}
+	defer dstQueries.close()
+	for _, cp := range catchpoints {
+		err = dstQueries.storeCatchpoint(ctx, cp.round, cp.fileName, cp.catchpoint, cp.fileSize)
+		if err != nil {
+			return err
+		}
+		_, err = dstQueries.writeCatchpointStateUint64(ctx, catchpointStateCatchpointUncompressedSize(cp.round), cp.uncompressedSize)
+		if err != nil {
+			return err
+		}
+	}
+	return dst.batch(func(ctx context.Context, tx trackerStoreWriter) error {
+		_, err := tx.writeCatchpointStateString(ctx, catchpointStateLastCatchpoint, lastCatchpoint)
+		if err != nil {
+			return err
+		}
+		_, err = tx.writeCatchpointStateUint64(ctx, catchpointStateWritingCatchpoint, writingCatchpoint)
+		return err
+	})
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package ledger
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/agreement"
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
+	"github.com/algorand/go-algorand/logging"
+)
+
+// ledgerStorageImage is the content of a ledger, as observed through its public interface.
+type ledgerStorageImage struct {
+	latest   basics.Round
+	blocks   []bookkeeping.Block
+	accounts map[basics.Address]basics.AccountData
+	totals   ledgercore.AccountTotals
+}
+
+func openLedgerStorageImage(t *testing.T, dbPrefix string, genesisInitState InitState, backend string) ledgerStorageImage {
+	cfg := config.GetDefaultLocal()
+	cfg.Archival = true
+	cfg.LedgerStorageBackend = backend
+	l, err := OpenLedger(logging.TestingLog(t), dbPrefix, false, genesisInitState, cfg)
+	require.NoError(t, err)
+	defer l.Close()
+
+	image := ledgerStorageImage{
+		latest:   l.Latest(),
+		accounts: make(map[basics.Address]basics.AccountData),
+	}
+	for rnd := basics.Round(0); rnd <= image.latest; rnd++ {
+		blk, err := l.Block(rnd)
+		require.NoError(t, err)
+		image.blocks = append(image.blocks, blk)
+	}
+	for addr := range genesisInitState.Accounts {
+		image.accounts[addr], _, err = l.LookupWithoutRewards(image.latest, addr)
+		require.NoError(t, err)
+	}
+	image.totals, err = l.Totals(image.latest)
+	require.NoError(t, err)
+	return image
+}
+
+func TestMigrateLedgerStorage(t *testing.T) {
+	dbTempDir, err := ioutil.TempDir("", "testdir"+t.Name())
+	require.NoError(t, err)
+	defer os.RemoveAll(dbTempDir)
+	dbPrefix := filepath.Join(dbTempDir, t.Name())
+
+	genesisInitState := getInitState()
+	for addr, data := range randomAccounts(20, false) {
+		genesisInitState.Accounts[addr] = data
+	}
+	genesisProto := config.Consensus[genesisInitState.Block.CurrentProtocol]
+	log := logging.TestingLog(t)
+
+	cfg := config.GetDefaultLocal()
+	cfg.Archival = true
+	l, err := OpenLedger(log, dbPrefix, false, genesisInitState, cfg)
+	require.NoError(t, err)
+	blk := genesisInitState.Block
+	for i := 0; i < 400; i++ {
+		blk.BlockHeader.Round++
+		blk.BlockHeader.TimeStamp += int64(crypto.RandUint64() % 100 * 1000)
+		require.NoError(t, l.AddBlock(blk, agreement.Certificate{}))
+	}
+	l.WaitForCommit(blk.Round())
+	l.Close()
+
+	expected := openLedgerStorageImage(t, dbPrefix, genesisInitState, StorageBackendSQLite)
+	require.Equal(t, blk.Round(), expected.latest)
+
+	require.Error(t, MigrateLedgerStorage(log, dbPrefix, genesisProto, StorageBackendSQLite, StorageBackendSQLite))
+	require.Error(t, MigrateLedgerStorage(log, dbPrefix, genesisProto, StorageBackendLevelDB, StorageBackendSQLite))
+	require.Error(t, MigrateLedgerStorage(log, dbPrefix, genesisProto, StorageBackendSQLite, "unknown"))
+
+	err = MigrateLedgerStorage(log, dbPrefix, genesisProto, StorageBackendSQLite, StorageBackendLevelDB)
+	require.NoError(t, err)
+	require.Equal(t, expected, openLedgerStorageImage(t, dbPrefix, genesisInitState, StorageBackendLevelDB))
+
+	// migrate back into a fresh sqlite ledger.
+	require.NoError(t, removeLedgerStorage(dbPrefix, StorageBackendSQLite))
+	err = MigrateLedgerStorage(log, dbPrefix, genesisProto, StorageBackendLevelDB, StorageBackendSQLite)
+	require.NoError(t, err)
+	require.Equal(t, expected, openLedgerStorageImage(t, dbPrefix, genesisInitState, StorageBackendSQLite))
+}
@@ -0,0 +1,155 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package ledger
+
+import (
+	"context"
+	"time"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/crypto/merkletrie"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/util/db"
+)
+
+// trackerStore is the persistent storage used by the accounts tracker. It holds the account balances, the creatables,
+// the account totals, the balances merkle trie and the catchpoint state. The storage is accessed either by the
+// point queries returned from queries(), or within a snapshot/batch, which provide a consistent view of the
+// storage content.
+type trackerStore interface {
+	// queries returns the point queries which are executed outside of any explicit transaction.
+	queries() (trackerQueries, error)
+
+	// snapshot executes fn over a consistent, read-only view of the storage.
+	snapshot(fn func(ctx context.Context, tx trackerStoreReader) error) error
+
+	// batch executes fn within a write transaction. The changes made by fn are committed only if it returns
+	// no error.
+	batch(fn func(ctx context.Context, tx trackerStoreWriter) error) error
+
+	// setSynchronousMode sets the durability guarantees of the writes made to the storage.
+	setSynchronousMode(ctx context.Context, mode db.SynchronousMode, fullfsync bool) error
+
+	// isSharedCacheConnection returns true if concurrent write transactions cannot be opened in parallel.
+	isSharedCacheConnection() bool
+
+	// vacuum reclaims the unused space in the storage.
+	vacuum(ctx context.Context) (db.VacuumStats, error)
+
+	setLogger(log logging.Logger)
+	close()
+}
+
+// trackerQueries is the set of the accounts tracker point queries. Each of these runs in its own transaction.
+type trackerQueries interface {
+	listCreatables(maxIdx basics.CreatableIndex, maxResults uint64, ctype basics.CreatableType) (results []basics.CreatableLocator, dbRound basics.Round, err error)
+	lookupCreator(cidx basics.CreatableIndex, ctype basics.CreatableType) (addr basics.Address, ok bool, dbRound basics.Round, err error)
+	listAssetHolders(aidx basics.AssetIndex, after *basics.Address, minAmount, maxAmount uint64, maxResults uint64) (results []AssetHolder, dbRound basics.Round, err error)
+	listAppOptIns(appIdx basics.AppIndex, after *basics.Address, maxResults uint64) (results []basics.Address, dbRound basics.Round, err error)
+	lookup(addr basics.Address) (data persistedAccountData, err error)
+	storeCatchpoint(ctx context.Context, round basics.Round, fileName string, catchpoint string, fileSize int64) (err error)
+	getOldestCatchpointFiles(ctx context.Context, fileCount int, filesToKeep int) (fileNames map[basics.Round]string, err error)
+	readCatchpointStateUint64(ctx context.Context, stateName catchpointState) (rnd uint64, def bool, err error)
+	writeCatchpointStateUint64(ctx context.Context, stateName catchpointState, setValue uint64) (cleared bool, err error)
+	readCatchpointStateString(ctx context.Context, stateName catchpointState) (str string, def bool, err error)
+	writeCatchpointStateString(ctx context.Context, stateName catchpointState, setValue string) (cleared bool, err error)
+	close()
+}
+
+// trackerStoreReader provides read access to the accounts tracker storage within a snapshot or a batch.
+type trackerStoreReader interface {
+	accountsRound() (rnd basics.Round, hashrnd basics.Round, err error)
+	accountsTotals(catchpointStaging bool) (totals ledgercore.AccountTotals, err error)
+	accountsOnlineTop(offset, n uint64, proto config.ConsensusParams) (map[basics.Address]*onlineAccount, error)
+	totalAccounts(ctx context.Context) (total uint64, err error)
+	getCatchpoint(round basics.Round) (fileName string, catchpoint string, fileSize int64, err error)
+	readCatchpointStateUint64(ctx context.Context, stateName catchpointState) (rnd uint64, def bool, err error)
+
+	// merkleCommitter returns a merkletrie.Committer storing the trie pages of either the balances trie or
+	// the catchpoint staging trie. Pages can be stored only via a committer obtained from a batch.
+	merkleCommitter(staging bool) (merkletrie.Committer, error)
+
+	makeEncodedAccountsIter() encodedAccountsIterator
+	makeCatchpointPendingHashesIter(hashCount int) catchpointPendingHashesIter
+
+	// makeCatchpointDeltaAccountsIter iterates over the accounts modified since the last catchpoint file was written.
+	// Accounts which no longer exist are returned with an empty account data.
+	makeCatchpointDeltaAccountsIter() encodedAccountsIterator
+	catchpointDeltaAccountsCount(ctx context.Context) (count uint64, err error)
+
+	// readCatchpointStagingBalances returns the staged accounts of the given addresses. Addresses which
+	// aren't staged are omitted.
+	readCatchpointStagingBalances(ctx context.Context, addrs []basics.Address) (bals []encodedBalanceRecord, err error)
+
+	// resetTransactionWarnDeadline extends the time the current transaction may take before a warning
+	// is being logged.
+	resetTransactionWarnDeadline(ctx context.Context, deadline time.Time)
+}
+
+// trackerStoreWriter provides read and write access to the accounts tracker storage within a batch.
+type trackerStoreWriter interface {
+	trackerStoreReader
+
+	// accountsUpgradeSchema creates the storage schema if needed, initializing it with the genesis accounts,
+	// and upgrades it to the latest schema version supported by this binary.
+	accountsUpgradeSchema(ctx context.Context, au *accountUpdates) error
+	accountsReset() error
+	resetAccountHashes() error
+	accountsPutTotals(totals ledgercore.AccountTotals, catchpointStaging bool) error
+	accountsLoadOld(deltas *compactAccountDeltas) error
+	accountsNewRound(updates compactAccountDeltas, creatables map[basics.CreatableIndex]ledgercore.ModifiedCreatable, proto config.ConsensusParams, lastUpdateRound basics.Round) (updatedAccounts []persistedAccountData, err error)
+	totalsNewRounds(updates []ledgercore.AccountDeltas, compactUpdates compactAccountDeltas, accountTotals []ledgercore.AccountTotals, proto config.ConsensusParams) error
+	updateAccountsRound(rnd basics.Round, hashRound basics.Round) error
+	makeOrderedAccountsIter(accountCount int) orderedAccountsIterator
+	writeCatchpointStateUint64(ctx context.Context, stateName catchpointState, setValue uint64) (cleared bool, err error)
+	writeCatchpointStateString(ctx context.Context, stateName catchpointState, setValue string) (cleared bool, err error)
+
+	// catchpoint delta files
+	catchpointDeltaAddAccounts(ctx context.Context, addrs []basics.Address) error
+	catchpointDeltaReset(ctx context.Context) error
+
+	// catchpoint catchup staging
+	resetCatchpointStagingBalances(ctx context.Context, newCatchup bool) error
+	writeCatchpointStagingBalances(ctx context.Context, bals []normalizedAccountBalance) error
+	writeCatchpointStagingCreatable(ctx context.Context, bals []normalizedAccountBalance) error
+	writeCatchpointStagingHashes(ctx context.Context, bals []normalizedAccountBalance) error
+	deleteCatchpointStagingBalances(ctx context.Context, bals []normalizedAccountBalance) error
+	createCatchpointStagingHashesIndex(ctx context.Context) error
+	applyCatchpointStagingBalances(ctx context.Context, balancesRound basics.Round) error
+}
+
+// encodedAccountsIterator iterates over the accounts in the storage, ordered by their address, returning
+// up to accountCount encoded accounts at a time.
+type encodedAccountsIterator interface {
+	Next(ctx context.Context, accountCount int) (bals []encodedBalanceRecord, err error)
+	Close()
+}
+
+// orderedAccountsIterator iterates over the accounts in the storage ordered by their hashes. See orderedAccountsIter
+// for the semantics of the Next method.
+type orderedAccountsIterator interface {
+	Next(ctx context.Context) (acct []accountAddressHash, processedRecords int, err error)
+	Close(ctx context.Context) (err error)
+}
+
+// catchpointPendingHashesIter iterates over the catchpoint staging pending hashes, in their order.
+type catchpointPendingHashesIter interface {
+	Next(ctx context.Context) (hashes [][]byte, err error)
+	Close()
+}
@@ -0,0 +1,1242 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package ledger
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/crypto/merkletrie"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/util/db"
+)
+
+// kvTrackerVersion is the version of the tracker key-value store layout. Version 2 added the holders and optins tables.
+const kvTrackerVersion = uint64(2)
+
+// The tracker key-value store layout. Integers are stored in big-endian order, so that the keys would be ordered
+// by these.
+const (
+	// kvVersionKey holds the version of the store layout. It's missing on a newly created store.
+	kvVersionKey = "version"
+	// kvAcctRoundKey and kvHashRoundKey are the equivalents of the acctbase and hashbase rows of the acctrounds table.
+	kvAcctRoundKey = "round/acctbase"
+	kvHashRoundKey = "round/hashbase"
+	// kvTotalsPrefix is followed by the totals id, and holds the msgpack encoded AccountTotals.
+	kvTotalsPrefix = "totals/"
+	// kvCatchpointStatePrefix is followed by the catchpoint state name, and holds either a uint64 or a string value,
+	// prefixed by kvStateUint64 or kvStateString.
+	kvCatchpointStatePrefix = "cpstate/"
+	// kvCatchpointPrefix is followed by the catchpoint round, and holds the encoded kvStoredCatchpoint.
+	kvCatchpointPrefix = "catchpoint/"
+	// kvStagingHashPrefix is followed by the account hash and address, and holds the catchpoint pending hashes.
+	kvStagingHashPrefix = "staging/hash/"
+	// kvOrderedPrefix is followed by the account hash and address, and is used for ordering the accounts by their hashes.
+	kvOrderedPrefix = "ordered/"
+	// kvCatchpointDeltaPrefix is followed by the address of an account modified since the last catchpoint file was written.
+	kvCatchpointDeltaPrefix = "cpdelta/"
+)
+
+const (
+	kvStateUint64 = byte('i')
+	kvStateString = byte('s')
+)
+
+// kvAccountsTables are the key prefixes of the balances "tables".
+// The accounts table is keyed by the address, and holds the normalized online balance followed by the msgpack
+// encoded account data.
+// The online table is keyed by the complemented normalized online balance followed by the complemented address, so
+// that iterating over it would return the online accounts ordered by decreasing balance and address.
+// The creatables table is keyed by the creatable type followed by the creatable index, and holds the creator.
+// The trie table is keyed by the page number, and holds the merkle trie pages.
+// The holders table is keyed by the asset index followed by the address, and holds the asset amount followed by the
+// frozen flag byte.
+// The optins table is keyed by the application index followed by the address of an account opted into it.
+type kvAccountsTables struct {
+	accounts   string
+	online     string
+	creatables string
+	trie       string
+	holders    string
+	optins     string
+}
+
+var kvBaseTables = kvAccountsTables{
+	accounts:   "acct/",
+	online:     "online/",
+	creatables: "creatable/",
+	trie:       "trie/",
+	holders:    "holder/",
+	optins:     "optin/",
+}
+
+var kvStagingTables = kvAccountsTables{
+	accounts:   "staging/acct/",
+	online:     "staging/online/",
+	creatables: "staging/creatable/",
+	trie:       "staging/trie/",
+	holders:    "staging/holder/",
+	optins:     "staging/optin/",
+}
+
+// kvStoredCatchpoint is the value stored for every catchpoint file.
+//msgp:ignore kvStoredCatchpoint
+type kvStoredCatchpoint struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	FileName   string `codec:"filename"`
+	Catchpoint string `codec:"catchpoint"`
+	FileSize   int64  `codec:"filesize"`
+}
+
+// kvTrackerStore is the trackerStore implementation over the key-value store.
+type kvTrackerStore struct {
+	kv *kvStore
+}
+
+// kvTrackerQueries implements the trackerQueries over the key-value store.
+type kvTrackerQueries struct {
+	kv *kvStore
+}
+
+// kvTrackerTx implements the trackerStoreReader and trackerStoreWriter over a key-value store snapshot
+// or transaction. w is nil when used over a snapshot.
+type kvTrackerTx struct {
+	r kvReader
+	w kvWriter
+}
+
+func makeKVTrackerStore(kv *kvStore) *kvTrackerStore {
+	return &kvTrackerStore{kv: kv}
+}
+
+func (s *kvTrackerStore) queries() (trackerQueries, error) {
+	return &kvTrackerQueries{kv: s.kv}, nil
+}
+
+func (s *kvTrackerStore) snapshot(fn func(ctx context.Context, tx trackerStoreReader) error) error {
+	return s.kv.snapshot(func(r kvReader) error {
+		return fn(context.Background(), &kvTrackerTx{r: r})
+	})
+}
+
+func (s *kvTrackerStore) batch(fn func(ctx context.Context, tx trackerStoreWriter) error) error {
+	return s.kv.atomic(func(w kvWriter) error {
+		return fn(context.Background(), &kvTrackerTx{r: w, w: w})
+	})
+}
+
+func (s *kvTrackerStore) setSynchronousMode(ctx context.Context, mode db.SynchronousMode, fullfsync bool) error {
+	return s.kv.setSynchronousMode(mode)
+}
+
+func (s *kvTrackerStore) isSharedCacheConnection() bool {
+	return false
+}
+
+func (s *kvTrackerStore) vacuum(ctx context.Context) (db.VacuumStats, error) {
+	return s.kv.compact()
+}
+
+func (s *kvTrackerStore) setLogger(log logging.Logger) {
+	s.kv.setLogger(log)
+}
+
+func (s *kvTrackerStore) close() {
+	s.kv.close()
+}
+
+func kvAccountKey(tables kvAccountsTables, addr basics.Address) []byte {
+	return kvKey(tables.accounts, addr[:])
+}
+
+func kvOnlineAccountKey(tables kvAccountsTables, normBalance uint64, addr basics.Address) []byte {
+	key := kvUint64Key(tables.online, ^normBalance)
+	for _, b := range addr {
+		key = append(key, ^b)
+	}
+	return key
+}
+
+func kvCreatableKey(tables kvAccountsTables, ctype basics.CreatableType, cidx basics.CreatableIndex) []byte {
+	return kvUint64Key(tables.creatables+string([]byte{byte(ctype)}), uint64(cidx))
+}
+
+func kvAssetHolderKey(tables kvAccountsTables, aidx basics.AssetIndex, addr basics.Address) []byte {
+	return append(kvUint64Key(tables.holders, uint64(aidx)), addr[:]...)
+}
+
+func kvAppOptInKey(tables kvAccountsTables, appIdx basics.AppIndex, addr basics.Address) []byte {
+	return append(kvUint64Key(tables.optins, uint64(appIdx)), addr[:]...)
+}
+
+// kvUpdateHoldingsIndex applies the given change to the holders and optins tables entries of the account.
+func kvUpdateHoldingsIndex(w kvWriter, tables kvAccountsTables, addr basics.Address, u holdingsIndexUpdate) error {
+	for aidx, holding := range u.putAssets {
+		value := append(encodeHoldingAmount(holding.Amount), 0)
+		if holding.Frozen {
+			value[8] = 1
+		}
+		err := w.put(kvAssetHolderKey(tables, aidx, addr), value)
+		if err != nil {
+			return err
+		}
+	}
+	for _, aidx := range u.deleteAssets {
+		err := w.delete(kvAssetHolderKey(tables, aidx, addr))
+		if err != nil {
+			return err
+		}
+	}
+	for _, appIdx := range u.putApps {
+		err := w.put(kvAppOptInKey(tables, appIdx, addr), nil)
+		if err != nil {
+			return err
+		}
+	}
+	for _, appIdx := range u.deleteApps {
+		err := w.delete(kvAppOptInKey(tables, appIdx, addr))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// kvBuildHoldingsIndex fills the holders and optins tables with the holdings of all the accounts.
+func kvBuildHoldingsIndex(w kvWriter) error {
+	iter := &kvEncodedAccountsIter{r: w}
+	defer iter.Close()
+	for {
+		bals, err := iter.Next(context.Background(), 1000)
+		if err != nil {
+			return err
+		}
+		if len(bals) == 0 {
+			return nil
+		}
+		for _, bal := range bals {
+			var data basics.AccountData
+			err = protocol.Decode(bal.AccountData, &data)
+			if err != nil {
+				return err
+			}
+			err = kvUpdateHoldingsIndex(w, kvBaseTables, bal.Address, makeHoldingsIndexUpdate(basics.AccountData{}, data))
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// kvDecodeAccount splits the stored account value into the normalized online balance and the encoded account data.
+func kvDecodeAccount(value []byte) (normBalance uint64, encodedAccountData []byte, err error) {
+	if len(value) < 8 {
+		return 0, nil, fmt.Errorf("account value is too short : %d", len(value))
+	}
+	return binary.BigEndian.Uint64(value), value[8:], nil
+}
+
+// kvPutAccount stores the encoded account data, and updates the online accounts table accordingly.
+func kvPutAccount(w kvWriter, tables kvAccountsTables, addr basics.Address, normBalance uint64, encodedAccountData []byte) (existed bool, err error) {
+	existed, err = kvDeleteOnlineAccount(w, tables, addr)
+	if err != nil {
+		return
+	}
+	value := make([]byte, 8+len(encodedAccountData))
+	binary.BigEndian.PutUint64(value, normBalance)
+	copy(value[8:], encodedAccountData)
+	err = w.put(kvAccountKey(tables, addr), value)
+	if err != nil {
+		return
+	}
+	if normBalance > 0 {
+		err = w.put(kvOnlineAccountKey(tables, normBalance, addr), nil)
+	}
+	return
+}
+
+// kvDeleteAccount deletes the account, and its online accounts table entry.
+func kvDeleteAccount(w kvWriter, tables kvAccountsTables, addr basics.Address) (existed bool, err error) {
+	existed, err = kvDeleteOnlineAccount(w, tables, addr)
+	if err != nil || !existed {
+		return
+	}
+	err = w.delete(kvAccountKey(tables, addr))
+	return
+}
+
+// kvDeleteOnlineAccount deletes the online accounts table entry of the given account, if it has any.
+func kvDeleteOnlineAccount(w kvWriter, tables kvAccountsTables, addr basics.Address) (existed bool, err error) {
+	value, err := w.get(kvAccountKey(tables, addr))
+	if err == errKVNotFound {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	normBalance, _, err := kvDecodeAccount(value)
+	if err != nil {
+		return true, err
+	}
+	if normBalance > 0 {
+		err = w.delete(kvOnlineAccountKey(tables, normBalance, addr))
+	}
+	return true, err
+}
+
+func kvTotalsKey(catchpointStaging bool) []byte {
+	if catchpointStaging {
+		return []byte(kvTotalsPrefix + "catchpointStaging")
+	}
+	return []byte(kvTotalsPrefix)
+}
+
+func kvReadAccountsRound(r kvReader) (basics.Round, error) {
+	rnd, err := kvGetUint64(r, []byte(kvAcctRoundKey))
+	if err == errKVNotFound {
+		err = sql.ErrNoRows
+	}
+	return basics.Round(rnd), err
+}
+
+func kvReadCatchpointStateUint64(r kvReader, stateName catchpointState) (rnd uint64, def bool, err error) {
+	value, err := r.get(kvKey(kvCatchpointStatePrefix, []byte(stateName)))
+	if err == errKVNotFound {
+		return 0, true, nil
+	} else if err != nil {
+		return 0, false, err
+	}
+	if len(value) != 9 || value[0] != kvStateUint64 {
+		// the state holds a string value.
+		return 0, true, nil
+	}
+	return binary.BigEndian.Uint64(value[1:]), false, nil
+}
+
+func kvReadCatchpointStateString(r kvReader, stateName catchpointState) (str string, def bool, err error) {
+	value, err := r.get(kvKey(kvCatchpointStatePrefix, []byte(stateName)))
+	if err == errKVNotFound {
+		return "", true, nil
+	} else if err != nil {
+		return "", false, err
+	}
+	if len(value) == 0 || value[0] != kvStateString {
+		// the state holds a uint64 value.
+		return "", true, nil
+	}
+	return string(value[1:]), false, nil
+}
+
+func kvWriteCatchpointStateUint64(w kvWriter, stateName catchpointState, setValue uint64) (cleared bool, err error) {
+	key := kvKey(kvCatchpointStatePrefix, []byte(stateName))
+	if setValue == 0 {
+		return true, w.delete(key)
+	}
+	value := make([]byte, 9)
+	value[0] = kvStateUint64
+	binary.BigEndian.PutUint64(value[1:], setValue)
+	return false, w.put(key, value)
+}
+
+func kvWriteCatchpointStateString(w kvWriter, stateName catchpointState, setValue string) (cleared bool, err error) {
+	key := kvKey(kvCatchpointStatePrefix, []byte(stateName))
+	if setValue == "" {
+		return true, w.delete(key)
+	}
+	return false, w.put(key, append([]byte{kvStateString}, setValue...))
+}
+
+func (qs *kvTrackerQueries) listCreatables(maxIdx basics.CreatableIndex, maxResults uint64, ctype basics.CreatableType) (results []basics.CreatableLocator, dbRound basics.Round, err error) {
+	err = qs.kv.snapshot(func(r kvReader) (err error) {
+		dbRound, err = kvReadAccountsRound(r)
+		if err != nil || maxResults == 0 {
+			return
+		}
+		rng := &util.Range{Start: kvCreatableKey(kvBaseTables, ctype, 0)}
+		if maxIdx == basics.CreatableIndex(^uint64(0)) {
+			rng.Limit = util.BytesPrefix([]byte(kvBaseTables.creatables + string([]byte{byte(ctype)}))).Limit
+		} else {
+			rng.Limit = kvCreatableKey(kvBaseTables, ctype, maxIdx+1)
+		}
+		return r.scan(rng, true, func(key, value []byte) (bool, error) {
+			cl := basics.CreatableLocator{
+				Type:  ctype,
+				Index: basics.CreatableIndex(binary.BigEndian.Uint64(key[len(key)-8:])),
+			}
+			copy(cl.Creator[:], value)
+			results = append(results, cl)
+			return uint64(len(results)) < maxResults, nil
+		})
+	})
+	return
+}
+
+func (qs *kvTrackerQueries) listAssetHolders(aidx basics.AssetIndex, after *basics.Address, minAmount, maxAmount uint64, maxResults uint64) (results []AssetHolder, dbRound basics.Round, err error) {
+	err = qs.kv.snapshot(func(r kvReader) (err error) {
+		dbRound, err = kvReadAccountsRound(r)
+		if err != nil || maxResults == 0 {
+			return
+		}
+		prefix := kvUint64Key(kvBaseTables.holders, uint64(aidx))
+		var afterKey []byte
+		if after != nil {
+			afterKey = kvAssetHolderKey(kvBaseTables, aidx, *after)
+		}
+		return r.scan(kvRangeAfter(util.BytesPrefix(prefix), afterKey), false, func(key, value []byte) (bool, error) {
+			if len(value) != 9 {
+				return false, fmt.Errorf("asset holder record of asset %d is malformed", aidx)
+			}
+			amount := binary.BigEndian.Uint64(value)
+			if amount < minAmount || amount > maxAmount {
+				return true, nil
+			}
+			var holder AssetHolder
+			copy(holder.Address[:], key[len(prefix):])
+			holder.Holding.Amount = amount
+			holder.Holding.Frozen = value[8] != 0
+			results = append(results, holder)
+			return uint64(len(results)) < maxResults, nil
+		})
+	})
+	return
+}
+
+func (qs *kvTrackerQueries) listAppOptIns(appIdx basics.AppIndex, after *basics.Address, maxResults uint64) (results []basics.Address, dbRound basics.Round, err error) {
+	err = qs.kv.snapshot(func(r kvReader) (err error) {
+		dbRound, err = kvReadAccountsRound(r)
+		if err != nil || maxResults == 0 {
+			return
+		}
+		prefix := kvUint64Key(kvBaseTables.optins, uint64(appIdx))
+		var afterKey []byte
+		if after != nil {
+			afterKey = kvAppOptInKey(kvBaseTables, appIdx, *after)
+		}
+		return r.scan(kvRangeAfter(util.BytesPrefix(prefix), afterKey), false, func(key, value []byte) (bool, error) {
+			var addr basics.Address
+			copy(addr[:], key[len(prefix):])
+			results = append(results, addr)
+			return uint64(len(results)) < maxResults, nil
+		})
+	})
+	return
+}
+
+func (qs *kvTrackerQueries) lookupCreator(cidx basics.CreatableIndex, ctype basics.CreatableType) (addr basics.Address, ok bool, dbRound basics.Round, err error) {
+	err = qs.kv.snapshot(func(r kvReader) (err error) {
+		dbRound, err = kvReadAccountsRound(r)
+		if err != nil {
+			return fmt.Errorf("lookupCreator was unable to retrieve round number")
+		}
+		value, err := r.get(kvCreatableKey(kvBaseTables, ctype, cidx))
+		if err == errKVNotFound {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		ok = true
+		copy(addr[:], value)
+		return nil
+	})
+	return
+}
+
+func (qs *kvTrackerQueries) lookup(addr basics.Address) (data persistedAccountData, err error) {
+	err = qs.kv.snapshot(func(r kvReader) (err error) {
+		data.round, err = kvReadAccountsRound(r)
+		if err != nil {
+			return fmt.Errorf("unable to query account data for address %v : %w", addr, err)
+		}
+		data.addr = addr
+		value, err := r.get(kvAccountKey(kvBaseTables, addr))
+		if err == errKVNotFound {
+			// we don't have that account, just return the database round.
+			return nil
+		} else if err != nil {
+			return err
+		}
+		_, encodedAccountData, err := kvDecodeAccount(value)
+		if err != nil {
+			return err
+		}
+		data.rowid = 1
+		return protocol.Decode(encodedAccountData, &data.accountData)
+	})
+	return
+}
+
+func (qs *kvTrackerQueries) storeCatchpoint(ctx context.Context, round basics.Round, fileName string, catchpoint string, fileSize int64) (err error) {
+	return qs.kv.atomic(func(w kvWriter) error {
+		key := kvUint64Key(kvCatchpointPrefix, uint64(round))
+		if fileName == "" && catchpoint == "" && fileSize == 0 {
+			return w.delete(key)
+		}
+		return w.put(key, protocol.EncodeReflect(&kvStoredCatchpoint{FileName: fileName, Catchpoint: catchpoint, FileSize: fileSize}))
+	})
+}
+
+// getOldestCatchpointFiles returns up to fileCount of the oldest catchpoint files, excluding the filesToKeep newest ones.
+func (qs *kvTrackerQueries) getOldestCatchpointFiles(ctx context.Context, fileCount int, filesToKeep int) (fileNames map[basics.Round]string, err error) {
+	err = qs.kv.snapshot(func(r kvReader) error {
+		var rounds []basics.Round
+		var names []string
+		err := r.scan(kvPrefixRange(kvCatchpointPrefix), false, func(key, value []byte) (bool, error) {
+			var cp kvStoredCatchpoint
+			err := protocol.DecodeReflect(value, &cp)
+			if err != nil {
+				return false, err
+			}
+			rounds = append(rounds, basics.Round(binary.BigEndian.Uint64(key[len(kvCatchpointPrefix):])))
+			names = append(names, cp.FileName)
+			return true, nil
+		})
+		if err != nil {
+			return err
+		}
+		fileNames = make(map[basics.Round]string)
+		if filesToKeep < 0 || filesToKeep >= len(rounds) {
+			return nil
+		}
+		for i := 0; i < len(rounds)-filesToKeep && i < fileCount; i++ {
+			fileNames[rounds[i]] = names[i]
+		}
+		return nil
+	})
+	return
+}
+
+func (qs *kvTrackerQueries) readCatchpointStateUint64(ctx context.Context, stateName catchpointState) (rnd uint64, def bool, err error) {
+	err = qs.kv.snapshot(func(r kvReader) (err error) {
+		rnd, def, err = kvReadCatchpointStateUint64(r, stateName)
+		return
+	})
+	return
+}
+
+func (qs *kvTrackerQueries) writeCatchpointStateUint64(ctx context.Context, stateName catchpointState, setValue uint64) (cleared bool, err error) {
+	err = qs.kv.atomic(func(w kvWriter) (err error) {
+		cleared, err = kvWriteCatchpointStateUint64(w, stateName, setValue)
+		return
+	})
+	return
+}
+
+func (qs *kvTrackerQueries) readCatchpointStateString(ctx context.Context, stateName catchpointState) (str string, def bool, err error) {
+	err = qs.kv.snapshot(func(r kvReader) (err error) {
+		str, def, err = kvReadCatchpointStateString(r, stateName)
+		return
+	})
+	return
+}
+
+func (qs *kvTrackerQueries) writeCatchpointStateString(ctx context.Context, stateName catchpointState, setValue string) (cleared bool, err error) {
+	err = qs.kv.atomic(func(w kvWriter) (err error) {
+		cleared, err = kvWriteCatchpointStateString(w, stateName, setValue)
+		return
+	})
+	return
+}
+
+func (qs *kvTrackerQueries) close() {
+}
+
+func (tx *kvTrackerTx) accountsRound() (rnd basics.Round, hashrnd basics.Round, err error) {
+	rnd, err = kvReadAccountsRound(tx.r)
+	if err != nil {
+		return
+	}
+	hashRound, err := kvGetUint64(tx.r, []byte(kvHashRoundKey))
+	if err == errKVNotFound {
+		return rnd, 0, nil
+	}
+	return rnd, basics.Round(hashRound), err
+}
+
+func (tx *kvTrackerTx) accountsTotals(catchpointStaging bool) (totals ledgercore.AccountTotals, err error) {
+	value, err := tx.r.get(kvTotalsKey(catchpointStaging))
+	if err == errKVNotFound {
+		err = sql.ErrNoRows
+	}
+	if err != nil {
+		return
+	}
+	err = protocol.Decode(value, &totals)
+	return
+}
+
+func (tx *kvTrackerTx) accountsOnlineTop(offset, n uint64, proto config.ConsensusParams) (map[basics.Address]*onlineAccount, error) {
+	res := make(map[basics.Address]*onlineAccount, n)
+	var addresses []basics.Address
+	err := tx.r.scan(kvPrefixRange(kvBaseTables.online), false, func(key, value []byte) (bool, error) {
+		if offset > 0 {
+			offset--
+			return true, nil
+		}
+		if uint64(len(addresses)) >= n {
+			return false, nil
+		}
+		var addr basics.Address
+		for i, b := range key[len(key)-len(addr):] {
+			addr[i] = ^b
+		}
+		addresses = append(addresses, addr)
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addresses {
+		value, err := tx.r.get(kvAccountKey(kvBaseTables, addr))
+		if err != nil {
+			return nil, err
+		}
+		_, encodedAccountData, err := kvDecodeAccount(value)
+		if err != nil {
+			return nil, err
+		}
+		var data basics.AccountData
+		err = protocol.Decode(encodedAccountData, &data)
+		if err != nil {
+			return nil, err
+		}
+		res[addr] = accountDataToOnline(addr, &data, proto)
+	}
+	return res, nil
+}
+
+func (tx *kvTrackerTx) totalAccounts(ctx context.Context) (total uint64, err error) {
+	return kvCount(tx.r, kvPrefixRange(kvBaseTables.accounts))
+}
+
+func (tx *kvTrackerTx) getCatchpoint(round basics.Round) (fileName string, catchpoint string, fileSize int64, err error) {
+	value, err := tx.r.get(kvUint64Key(kvCatchpointPrefix, uint64(round)))
+	if err == errKVNotFound {
+		err = sql.ErrNoRows
+	}
+	if err != nil {
+		return
+	}
+	var cp kvStoredCatchpoint
+	err = protocol.DecodeReflect(value, &cp)
+	return cp.FileName, cp.Catchpoint, cp.FileSize, err
+}
+
+func (tx *kvTrackerTx) readCatchpointStateUint64(ctx context.Context, stateName catchpointState) (rnd uint64, def bool, err error) {
+	return kvReadCatchpointStateUint64(tx.r, stateName)
+}
+
+func (tx *kvTrackerTx) merkleCommitter(staging bool) (merkletrie.Committer, error) {
+	mc := &kvMerkleCommitter{r: tx.r, w: tx.w, prefix: kvBaseTables.trie}
+	if staging {
+		mc.prefix = kvStagingTables.trie
+	}
+	return mc, nil
+}
+
+func (tx *kvTrackerTx) makeEncodedAccountsIter() encodedAccountsIterator {
+	return &kvEncodedAccountsIter{r: tx.r}
+}
+
+func (tx *kvTrackerTx) makeCatchpointPendingHashesIter(hashCount int) catchpointPendingHashesIter {
+	return &kvCatchpointPendingHashesIter{r: tx.r, hashCount: hashCount}
+}
+
+func (tx *kvTrackerTx) makeCatchpointDeltaAccountsIter() encodedAccountsIterator {
+	return &kvCatchpointDeltaAccountsIter{r: tx.r}
+}
+
+func (tx *kvTrackerTx) catchpointDeltaAccountsCount(ctx context.Context) (count uint64, err error) {
+	return kvCount(tx.r, kvPrefixRange(kvCatchpointDeltaPrefix))
+}
+
+func (tx *kvTrackerTx) readCatchpointStagingBalances(ctx context.Context, addrs []basics.Address) (bals []encodedBalanceRecord, err error) {
+	for _, addr := range addrs {
+		value, err := tx.r.get(kvAccountKey(kvStagingTables, addr))
+		if err == errKVNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		_, encodedAccountData, err := kvDecodeAccount(value)
+		if err != nil {
+			return nil, err
+		}
+		bals = append(bals, encodedBalanceRecord{Address: addr, AccountData: encodedAccountData})
+	}
+	return bals, nil
+}
+
+func (tx *kvTrackerTx) resetTransactionWarnDeadline(ctx context.Context, deadline time.Time) {
+	// the key-value store transactions have no execution deadline.
+}
+
+// accountsUpgradeSchema initializes a new store with the genesis accounts, or upgrades the layout of an existing one.
+func (tx *kvTrackerTx) accountsUpgradeSchema(ctx context.Context, au *accountUpdates) error {
+	version, err := kvGetUint64(tx.w, []byte(kvVersionKey))
+	if err == nil {
+		if version > kvTrackerVersion {
+			au.log.Warnf("accountsInitialize key-value store version is %d, but algod supports only %d", version, kvTrackerVersion)
+			return nil
+		}
+		if version < 2 {
+			au.log.Infof("accountsInitialize building the asset holders and application opt-ins index")
+			err = kvBuildHoldingsIndex(tx.w)
+			if err != nil {
+				return err
+			}
+		}
+		if version == kvTrackerVersion {
+			return nil
+		}
+		return kvPutUint64(tx.w, []byte(kvVersionKey), kvTrackerVersion)
+	} else if err != errKVNotFound {
+		return err
+	}
+
+	au.log.Infof("accountsInitialize initializing key-value store")
+	var ot basics.OverflowTracker
+	var totals ledgercore.AccountTotals
+	for addr, data := range au.initAccounts {
+		_, err = kvPutAccount(tx.w, kvBaseTables, addr, data.NormalizedOnlineBalance(au.initProto), protocol.Encode(&data))
+		if err != nil {
+			return err
+		}
+		err = kvUpdateHoldingsIndex(tx.w, kvBaseTables, addr, makeHoldingsIndexUpdate(basics.AccountData{}, data))
+		if err != nil {
+			return err
+		}
+		totals.AddAccount(au.initProto, data, &ot)
+	}
+	if ot.Overflowed {
+		return fmt.Errorf("overflow computing totals")
+	}
+	err = tx.accountsPutTotals(totals, false)
+	if err != nil {
+		return err
+	}
+	err = kvPutUint64(tx.w, []byte(kvAcctRoundKey), 0)
+	if err != nil {
+		return err
+	}
+	return kvPutUint64(tx.w, []byte(kvVersionKey), kvTrackerVersion)
+}
+
+// accountsReset deletes the entire content of the store.
+func (tx *kvTrackerTx) accountsReset() error {
+	return kvDeleteRange(tx.w, &util.Range{})
+}
+
+func (tx *kvTrackerTx) resetAccountHashes() error {
+	return kvDeleteRange(tx.w, kvPrefixRange(kvBaseTables.trie))
+}
+
+func (tx *kvTrackerTx) accountsPutTotals(totals ledgercore.AccountTotals, catchpointStaging bool) error {
+	return tx.w.put(kvTotalsKey(catchpointStaging), protocol.Encode(&totals))
+}
+
+func (tx *kvTrackerTx) accountsLoadOld(deltas *compactAccountDeltas) error {
+	defer func() {
+		deltas.misses = nil
+	}()
+	for _, idx := range deltas.misses {
+		addr := deltas.addresses[idx]
+		value, err := tx.r.get(kvAccountKey(kvBaseTables, addr))
+		if err == errKVNotFound {
+			// we don't have that account, just return an empty record.
+			deltas.updateOld(idx, persistedAccountData{addr: addr})
+			continue
+		} else if err != nil {
+			return err
+		}
+		_, encodedAccountData, err := kvDecodeAccount(value)
+		if err != nil {
+			return err
+		}
+		persistedAcctData := persistedAccountData{addr: addr, rowid: 1}
+		err = protocol.Decode(encodedAccountData, &persistedAcctData.accountData)
+		if err != nil {
+			return err
+		}
+		deltas.updateOld(idx, persistedAcctData)
+	}
+	return nil
+}
+
+func (tx *kvTrackerTx) accountsNewRound(updates compactAccountDeltas, creatables map[basics.CreatableIndex]ledgercore.ModifiedCreatable, proto config.ConsensusParams, lastUpdateRound basics.Round) (updatedAccounts []persistedAccountData, err error) {
+	updatedAccounts = make([]persistedAccountData, updates.len())
+	updatedAccountIdx := 0
+	for i := 0; i < updates.len(); i++ {
+		addr, data := updates.getByIdx(i)
+		var existed bool
+		if data.old.rowid == 0 {
+			// zero rowid means we don't have a previous value.
+			if data.new.IsZero() {
+				// if we didn't had it before, and we don't have anything now, just skip it.
+			} else {
+				// create a new entry.
+				existed, err = kvPutAccount(tx.w, kvBaseTables, addr, data.new.NormalizedOnlineBalance(proto), protocol.Encode(&data.new))
+				if err == nil && existed {
+					err = fmt.Errorf("failed to insert account %v, as it already exists", addr)
+				}
+				updatedAccounts[updatedAccountIdx].rowid = 1
+				updatedAccounts[updatedAccountIdx].accountData = data.new
+			}
+		} else {
+			// non-zero rowid means we had a previous value.
+			if data.new.IsZero() {
+				// new value is zero, which means we need to delete the current value.
+				existed, err = kvDeleteAccount(tx.w, kvBaseTables, addr)
+				if err == nil && !existed {
+					err = fmt.Errorf("failed to delete account %v, as it doesn't exist", addr)
+				}
+				updatedAccounts[updatedAccountIdx].rowid = 0
+				updatedAccounts[updatedAccountIdx].accountData = basics.AccountData{}
+			} else {
+				existed, err = kvPutAccount(tx.w, kvBaseTables, addr, data.new.NormalizedOnlineBalance(proto), protocol.Encode(&data.new))
+				if err == nil && !existed {
+					err = fmt.Errorf("failed to update account %v, as it doesn't exist", addr)
+				}
+				updatedAccounts[updatedAccountIdx].rowid = data.old.rowid
+				updatedAccounts[updatedAccountIdx].accountData = data.new
+			}
+		}
+
+		if err == nil {
+			err = kvUpdateHoldingsIndex(tx.w, kvBaseTables, addr, makeHoldingsIndexUpdate(data.old.accountData, data.new))
+		}
+		if err != nil {
+			return
+		}
+
+		// set the returned persisted account states so that we could store that as the baseAccounts in commitRound
+		updatedAccounts[updatedAccountIdx].round = lastUpdateRound
+		updatedAccounts[updatedAccountIdx].addr = addr
+		updatedAccountIdx++
+	}
+
+	for cidx, cdelta := range creatables {
+		key := kvCreatableKey(kvBaseTables, cdelta.Ctype, cidx)
+		if cdelta.Created {
+			err = tx.w.put(key, cdelta.Creator[:])
+		} else {
+			err = tx.w.delete(key)
+		}
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (tx *kvTrackerTx) totalsNewRounds(updates []ledgercore.AccountDeltas, compactUpdates compactAccountDeltas, accountTotals []ledgercore.AccountTotals, proto config.ConsensusParams) error {
+	totals, err := tx.accountsTotals(false)
+	if err != nil {
+		return err
+	}
+	totals, err = applyTotalsNewRounds(totals, updates, compactUpdates, accountTotals, proto)
+	if err != nil {
+		return err
+	}
+	return tx.accountsPutTotals(totals, false)
+}
+
+func (tx *kvTrackerTx) updateAccountsRound(rnd basics.Round, hashRound basics.Round) error {
+	base, err := kvReadAccountsRound(tx.r)
+	if err != nil {
+		return err
+	}
+	if base > rnd {
+		return fmt.Errorf("newRound %d is not after base %d", rnd, base)
+	}
+	err = kvPutUint64(tx.w, []byte(kvAcctRoundKey), uint64(rnd))
+	if err != nil {
+		return err
+	}
+	return kvPutUint64(tx.w, []byte(kvHashRoundKey), uint64(hashRound))
+}
+
+func (tx *kvTrackerTx) makeOrderedAccountsIter(accountCount int) orderedAccountsIterator {
+	return &kvOrderedAccountsIter{w: tx.w, accountCount: accountCount}
+}
+
+func (tx *kvTrackerTx) writeCatchpointStateUint64(ctx context.Context, stateName catchpointState, setValue uint64) (cleared bool, err error) {
+	return kvWriteCatchpointStateUint64(tx.w, stateName, setValue)
+}
+
+func (tx *kvTrackerTx) writeCatchpointStateString(ctx context.Context, stateName catchpointState, setValue string) (cleared bool, err error) {
+	return kvWriteCatchpointStateString(tx.w, stateName, setValue)
+}
+
+func (tx *kvTrackerTx) catchpointDeltaAddAccounts(ctx context.Context, addrs []basics.Address) error {
+	for _, addr := range addrs {
+		err := tx.w.put(kvKey(kvCatchpointDeltaPrefix, addr[:]), nil)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (tx *kvTrackerTx) catchpointDeltaReset(ctx context.Context) error {
+	return kvDeleteRange(tx.w, kvPrefixRange(kvCatchpointDeltaPrefix))
+}
+
+func (tx *kvTrackerTx) resetCatchpointStagingBalances(ctx context.Context, newCatchup bool) error {
+	for _, prefix := range []string{kvStagingTables.accounts, kvStagingTables.online, kvStagingTables.creatables, kvStagingTables.trie, kvStagingTables.holders, kvStagingTables.optins, kvStagingHashPrefix} {
+		err := kvDeleteRange(tx.w, kvPrefixRange(prefix))
+		if err != nil {
+			return err
+		}
+	}
+	return tx.w.delete(kvTotalsKey(true))
+}
+
+func (tx *kvTrackerTx) writeCatchpointStagingBalances(ctx context.Context, bals []normalizedAccountBalance) error {
+	for _, balance := range bals {
+		existed, err := kvPutAccount(tx.w, kvStagingTables, balance.address, balance.normalizedBalance, balance.encodedAccountData)
+		if err != nil {
+			return err
+		}
+		if existed {
+			return fmt.Errorf("account %v already exists in the catchpoint staging balances", balance.address)
+		}
+	}
+	return nil
+}
+
+func (tx *kvTrackerTx) writeCatchpointStagingCreatable(ctx context.Context, bals []normalizedAccountBalance) error {
+	for _, balance := range bals {
+		err := kvUpdateHoldingsIndex(tx.w, kvStagingTables, balance.address, makeHoldingsIndexUpdate(basics.AccountData{}, balance.accountData))
+		if err != nil {
+			return err
+		}
+		// if the account has any asset params, it means that it's the creator of an asset.
+		for aidx := range balance.accountData.AssetParams {
+			err := tx.w.put(kvCreatableKey(kvStagingTables, basics.AssetCreatable, basics.CreatableIndex(aidx)), balance.address[:])
+			if err != nil {
+				return err
+			}
+		}
+		for aidx := range balance.accountData.AppParams {
+			err := tx.w.put(kvCreatableKey(kvStagingTables, basics.AppCreatable, basics.CreatableIndex(aidx)), balance.address[:])
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (tx *kvTrackerTx) writeCatchpointStagingHashes(ctx context.Context, bals []normalizedAccountBalance) error {
+	for _, balance := range bals {
+		key := kvKey(kvStagingHashPrefix, balance.accountHash)
+		key = append(key, balance.address[:]...)
+		err := tx.w.put(key, nil)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (tx *kvTrackerTx) deleteCatchpointStagingBalances(ctx context.Context, bals []normalizedAccountBalance) error {
+	for _, balance := range bals {
+		_, err := kvDeleteAccount(tx.w, kvStagingTables, balance.address)
+		if err != nil {
+			return err
+		}
+		err = kvUpdateHoldingsIndex(tx.w, kvStagingTables, balance.address, makeHoldingsIndexUpdate(balance.accountData, basics.AccountData{}))
+		if err != nil {
+			return err
+		}
+		for aidx := range balance.accountData.AssetParams {
+			err = tx.w.delete(kvCreatableKey(kvStagingTables, basics.AssetCreatable, basics.CreatableIndex(aidx)))
+			if err != nil {
+				return err
+			}
+		}
+		for aidx := range balance.accountData.AppParams {
+			err = tx.w.delete(kvCreatableKey(kvStagingTables, basics.AppCreatable, basics.CreatableIndex(aidx)))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// createCatchpointStagingHashesIndex is a no-op, as the pending hashes are already ordered by their keys.
+func (tx *kvTrackerTx) createCatchpointStagingHashesIndex(ctx context.Context) error {
+	return nil
+}
+
+func (tx *kvTrackerTx) applyCatchpointStagingBalances(ctx context.Context, balancesRound basics.Round) error {
+	moves := [][2]string{
+		{kvStagingTables.accounts, kvBaseTables.accounts},
+		{kvStagingTables.online, kvBaseTables.online},
+		{kvStagingTables.creatables, kvBaseTables.creatables},
+		{kvStagingTables.trie, kvBaseTables.trie},
+		{kvStagingTables.holders, kvBaseTables.holders},
+		{kvStagingTables.optins, kvBaseTables.optins},
+	}
+	for _, move := range moves {
+		err := kvMovePrefix(tx.w, move[0], move[1])
+		if err != nil {
+			return err
+		}
+	}
+	err := kvPutUint64(tx.w, []byte(kvAcctRoundKey), uint64(balancesRound))
+	if err != nil {
+		return err
+	}
+	return kvPutUint64(tx.w, []byte(kvHashRoundKey), uint64(balancesRound))
+}
+
+// kvMerkleCommitter implements the merkletrie.Committer interface, storing the trie pages in the key-value store.
+type kvMerkleCommitter struct {
+	r      kvReader
+	w      kvWriter
+	prefix string
+}
+
+func (mc *kvMerkleCommitter) StorePage(page uint64, content []byte) error {
+	if mc.w == nil {
+		return fmt.Errorf("unable to store merkle trie page %d on a read-only snapshot", page)
+	}
+	if len(content) == 0 {
+		return mc.w.delete(kvUint64Key(mc.prefix, page))
+	}
+	return mc.w.put(kvUint64Key(mc.prefix, page), content)
+}
+
+func (mc *kvMerkleCommitter) LoadPage(page uint64) (content []byte, err error) {
+	content, err = mc.r.get(kvUint64Key(mc.prefix, page))
+	if err == errKVNotFound {
+		return nil, nil
+	}
+	return
+}
+
+// kvEncodedAccountsIter iterates over the accounts ordered by their addresses.
+type kvEncodedAccountsIter struct {
+	r    kvReader
+	last []byte
+	done bool
+}
+
+func (iterator *kvEncodedAccountsIter) Next(ctx context.Context, accountCount int) (bals []encodedBalanceRecord, err error) {
+	if iterator.done {
+		return nil, nil
+	}
+	keys, values, err := kvCollect(iterator.r, kvPrefixRange(kvBaseTables.accounts), iterator.last, accountCount)
+	if err != nil {
+		return nil, err
+	}
+	bals = make([]encodedBalanceRecord, len(keys))
+	for i, key := range keys {
+		copy(bals[i].Address[:], key[len(kvBaseTables.accounts):])
+		_, bals[i].AccountData, err = kvDecodeAccount(values[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(keys) < accountCount {
+		iterator.done = true
+	} else {
+		iterator.last = keys[len(keys)-1]
+	}
+	return bals, nil
+}
+
+func (iterator *kvEncodedAccountsIter) Close() {
+	iterator.done = true
+}
+
+// kvCatchpointDeltaAccountsIter iterates over the accounts modified since the last catchpoint file was written, ordered
+// by their addresses.
+type kvCatchpointDeltaAccountsIter struct {
+	r    kvReader
+	last []byte
+	done bool
+}
+
+func (iterator *kvCatchpointDeltaAccountsIter) Next(ctx context.Context, accountCount int) (bals []encodedBalanceRecord, err error) {
+	if iterator.done {
+		return nil, nil
+	}
+	keys, _, err := kvCollect(iterator.r, kvPrefixRange(kvCatchpointDeltaPrefix), iterator.last, accountCount)
+	if err != nil {
+		return nil, err
+	}
+	bals = make([]encodedBalanceRecord, len(keys))
+	for i, key := range keys {
+		copy(bals[i].Address[:], key[len(kvCatchpointDeltaPrefix):])
+		value, err := iterator.r.get(kvAccountKey(kvBaseTables, bals[i].Address))
+		if err == errKVNotFound {
+			// the account was deleted.
+			bals[i].AccountData = protocol.Encode(&basics.AccountData{})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		_, bals[i].AccountData, err = kvDecodeAccount(value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(keys) < accountCount {
+		iterator.done = true
+	} else {
+		iterator.last = keys[len(keys)-1]
+	}
+	return bals, nil
+}
+
+func (iterator *kvCatchpointDeltaAccountsIter) Close() {
+	iterator.done = true
+}
+
+// kvCatchpointPendingHashesIter iterates over the catchpoint pending hashes, in their order.
+type kvCatchpointPendingHashesIter struct {
+	r         kvReader
+	hashCount int
+	last      []byte
+	done      bool
+}
+
+func (iterator *kvCatchpointPendingHashesIter) Next(ctx context.Context) (hashes [][]byte, err error) {
+	if iterator.done {
+		return nil, nil
+	}
+	keys, _, err := kvCollect(iterator.r, kvPrefixRange(kvStagingHashPrefix), iterator.last, iterator.hashCount)
+	if err != nil {
+		return nil, err
+	}
+	hashes = make([][]byte, len(keys))
+	for i, key := range keys {
+		hashes[i] = key[len(kvStagingHashPrefix) : len(key)-len(basics.Address{})]
+	}
+	if len(keys) < iterator.hashCount {
+		iterator.done = true
+	} else {
+		iterator.last = keys[len(keys)-1]
+	}
+	return hashes, nil
+}
+
+func (iterator *kvCatchpointPendingHashesIter) Close() {
+	iterator.done = true
+}
+
+// kvOrderedAccountsIter iterates over the accounts addresses in the order of the account hashes. It first
+// stores the hash of every account under the kvOrderedPrefix, and then iterates over these.
+type kvOrderedAccountsIter struct {
+	w            kvWriter
+	accountCount int
+	ordering     bool
+	done         bool
+	last         []byte
+}
+
+func (iterator *kvOrderedAccountsIter) Next(ctx context.Context) (acct []accountAddressHash, processedRecords int, err error) {
+	if iterator.done {
+		return nil, 0, sql.ErrNoRows
+	}
+	if !iterator.ordering {
+		if iterator.last == nil {
+			// clean up any leftovers of a previous iteration.
+			err = kvDeleteRange(iterator.w, kvPrefixRange(kvOrderedPrefix))
+			if err != nil {
+				return
+			}
+		}
+		var keys, values [][]byte
+		keys, values, err = kvCollect(iterator.w, kvPrefixRange(kvBaseTables.accounts), iterator.last, iterator.accountCount)
+		if err != nil {
+			return
+		}
+		for i, key := range keys {
+			var addr basics.Address
+			copy(addr[:], key[len(kvBaseTables.accounts):])
+			var encodedAccountData []byte
+			_, encodedAccountData, err = kvDecodeAccount(values[i])
+			if err != nil {
+				return
+			}
+			var accountData basics.AccountData
+			err = protocol.Decode(encodedAccountData, &accountData)
+			if err != nil {
+				return
+			}
+			hash := accountHashBuilder(addr, accountData, encodedAccountData)
+			err = iterator.w.put(append(kvKey(kvOrderedPrefix, hash), addr[:]...), nil)
+			if err != nil {
+				return
+			}
+		}
+		processedRecords = len(keys)
+		if len(keys) < iterator.accountCount {
+			iterator.ordering = true
+			iterator.last = nil
+		} else {
+			iterator.last = keys[len(keys)-1]
+		}
+		return
+	}
+
+	keys, _, err := kvCollect(iterator.w, kvPrefixRange(kvOrderedPrefix), iterator.last, iterator.accountCount)
+	if err != nil {
+		return
+	}
+	acct = make([]accountAddressHash, len(keys))
+	for i, key := range keys {
+		addrOffset := len(key) - len(acct[i].address)
+		copy(acct[i].address[:], key[addrOffset:])
+		acct[i].digest = key[len(kvOrderedPrefix):addrOffset]
+	}
+	if len(keys) < iterator.accountCount {
+		err = iterator.Close(ctx)
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(acct) == 0 {
+			return nil, 0, sql.ErrNoRows
+		}
+	} else {
+		iterator.last = keys[len(keys)-1]
+	}
+	return
+}
+
+func (iterator *kvOrderedAccountsIter) Close(ctx context.Context) (err error) {
+	if iterator.done {
+		return nil
+	}
+	iterator.done = true
+	return kvDeleteRange(iterator.w, kvPrefixRange(kvOrderedPrefix))
+}
@@ -0,0 +1,265 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package ledger
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/crypto/merkletrie"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/util/db"
+)
+
+// sqliteTrackerStore is the trackerStore implementation over the ledger.tracker.sqlite database.
+type sqliteTrackerStore struct {
+	dbs db.Pair
+}
+
+// sqliteTrackerTx implements the trackerStoreReader and trackerStoreWriter over a single SQL transaction.
+type sqliteTrackerTx struct {
+	tx *sql.Tx
+	// qs are the catchpoint state queries prepared over tx. These are created on demand.
+	qs *accountsDbQueries
+}
+
+func makeSQLiteTrackerStore(dbs db.Pair) *sqliteTrackerStore {
+	return &sqliteTrackerStore{dbs: dbs}
+}
+
+func (s *sqliteTrackerStore) queries() (trackerQueries, error) {
+	qs, err := accountsDbInit(s.dbs.Rdb.Handle, s.dbs.Wdb.Handle)
+	if err != nil {
+		return nil, err
+	}
+	return qs, nil
+}
+
+func (s *sqliteTrackerStore) snapshot(fn func(ctx context.Context, tx trackerStoreReader) error) error {
+	return s.dbs.Rdb.Atomic(func(ctx context.Context, tx *sql.Tx) error {
+		stx := &sqliteTrackerTx{tx: tx}
+		defer stx.close()
+		return fn(ctx, stx)
+	})
+}
+
+func (s *sqliteTrackerStore) batch(fn func(ctx context.Context, tx trackerStoreWriter) error) error {
+	return s.dbs.Wdb.Atomic(func(ctx context.Context, tx *sql.Tx) error {
+		stx := &sqliteTrackerTx{tx: tx}
+		defer stx.close()
+		return fn(ctx, stx)
+	})
+}
+
+func (s *sqliteTrackerStore) setSynchronousMode(ctx context.Context, mode db.SynchronousMode, fullfsync bool) error {
+	return s.dbs.Wdb.SetSynchronousMode(ctx, mode, fullfsync)
+}
+
+func (s *sqliteTrackerStore) isSharedCacheConnection() bool {
+	return s.dbs.Wdb.IsSharedCacheConnection()
+}
+
+func (s *sqliteTrackerStore) vacuum(ctx context.Context) (db.VacuumStats, error) {
+	return s.dbs.Wdb.Vacuum(ctx)
+}
+
+func (s *sqliteTrackerStore) setLogger(log logging.Logger) {
+	s.dbs.Rdb.SetLogger(log)
+	s.dbs.Wdb.SetLogger(log)
+}
+
+func (s *sqliteTrackerStore) close() {
+	s.dbs.Close()
+}
+
+func (stx *sqliteTrackerTx) close() {
+	if stx.qs != nil {
+		stx.qs.close()
+		stx.qs = nil
+	}
+}
+
+func (stx *sqliteTrackerTx) queries() (*accountsDbQueries, error) {
+	if stx.qs == nil {
+		qs, err := accountsDbInit(stx.tx, stx.tx)
+		if err != nil {
+			return nil, err
+		}
+		stx.qs = qs
+	}
+	return stx.qs, nil
+}
+
+func (stx *sqliteTrackerTx) accountsRound() (rnd basics.Round, hashrnd basics.Round, err error) {
+	return accountsRound(stx.tx)
+}
+
+func (stx *sqliteTrackerTx) accountsTotals(catchpointStaging bool) (totals ledgercore.AccountTotals, err error) {
+	return accountsTotals(stx.tx, catchpointStaging)
+}
+
+func (stx *sqliteTrackerTx) accountsOnlineTop(offset, n uint64, proto config.ConsensusParams) (map[basics.Address]*onlineAccount, error) {
+	return accountsOnlineTop(stx.tx, offset, n, proto)
+}
+
+func (stx *sqliteTrackerTx) totalAccounts(ctx context.Context) (total uint64, err error) {
+	return totalAccounts(ctx, stx.tx)
+}
+
+func (stx *sqliteTrackerTx) getCatchpoint(round basics.Round) (fileName string, catchpoint string, fileSize int64, err error) {
+	return getCatchpoint(stx.tx, round)
+}
+
+func (stx *sqliteTrackerTx) readCatchpointStateUint64(ctx context.Context, stateName catchpointState) (rnd uint64, def bool, err error) {
+	qs, err := stx.queries()
+	if err != nil {
+		return 0, false, err
+	}
+	return qs.readCatchpointStateUint64(ctx, stateName)
+}
+
+func (stx *sqliteTrackerTx) merkleCommitter(staging bool) (merkletrie.Committer, error) {
+	return MakeMerkleCommitter(stx.tx, staging)
+}
+
+func (stx *sqliteTrackerTx) makeEncodedAccountsIter() encodedAccountsIterator {
+	return &sqliteEncodedAccountsIter{tx: stx.tx}
+}
+
+func (stx *sqliteTrackerTx) makeCatchpointPendingHashesIter(hashCount int) catchpointPendingHashesIter {
+	return makeCatchpointPendingHashesIterator(hashCount, stx.tx)
+}
+
+func (stx *sqliteTrackerTx) makeCatchpointDeltaAccountsIter() encodedAccountsIterator {
+	return &sqliteEncodedAccountsIter{encodedAccountsBatchIter: encodedAccountsBatchIter{deltaAccounts: true}, tx: stx.tx}
+}
+
+func (stx *sqliteTrackerTx) catchpointDeltaAccountsCount(ctx context.Context) (count uint64, err error) {
+	return catchpointDeltaAccountsCount(ctx, stx.tx)
+}
+
+func (stx *sqliteTrackerTx) readCatchpointStagingBalances(ctx context.Context, addrs []basics.Address) (bals []encodedBalanceRecord, err error) {
+	return readCatchpointStagingBalances(ctx, stx.tx, addrs)
+}
+
+func (stx *sqliteTrackerTx) resetTransactionWarnDeadline(ctx context.Context, deadline time.Time) {
+	// The return value from ResetTransactionWarnDeadline can be safely ignored here since it would only default to writing the warning
+	// message, which would let us know that it failed anyway.
+	db.ResetTransactionWarnDeadline(ctx, stx.tx, deadline)
+}
+
+func (stx *sqliteTrackerTx) accountsUpgradeSchema(ctx context.Context, au *accountUpdates) error {
+	return au.accountsUpgradeSQLiteSchema(ctx, stx.tx)
+}
+
+func (stx *sqliteTrackerTx) accountsReset() error {
+	return accountsReset(stx.tx)
+}
+
+func (stx *sqliteTrackerTx) resetAccountHashes() error {
+	return resetAccountHashes(stx.tx)
+}
+
+func (stx *sqliteTrackerTx) accountsPutTotals(totals ledgercore.AccountTotals, catchpointStaging bool) error {
+	return accountsPutTotals(stx.tx, totals, catchpointStaging)
+}
+
+func (stx *sqliteTrackerTx) accountsLoadOld(deltas *compactAccountDeltas) error {
+	return deltas.accountsLoadOld(stx.tx)
+}
+
+func (stx *sqliteTrackerTx) accountsNewRound(updates compactAccountDeltas, creatables map[basics.CreatableIndex]ledgercore.ModifiedCreatable, proto config.ConsensusParams, lastUpdateRound basics.Round) (updatedAccounts []persistedAccountData, err error) {
+	return accountsNewRound(stx.tx, updates, creatables, proto, lastUpdateRound)
+}
+
+func (stx *sqliteTrackerTx) totalsNewRounds(updates []ledgercore.AccountDeltas, compactUpdates compactAccountDeltas, accountTotals []ledgercore.AccountTotals, proto config.ConsensusParams) error {
+	return totalsNewRounds(stx.tx, updates, compactUpdates, accountTotals, proto)
+}
+
+func (stx *sqliteTrackerTx) updateAccountsRound(rnd basics.Round, hashRound basics.Round) error {
+	return updateAccountsRound(stx.tx, rnd, hashRound)
+}
+
+func (stx *sqliteTrackerTx) makeOrderedAccountsIter(accountCount int) orderedAccountsIterator {
+	return makeOrderedAccountsIter(stx.tx, accountCount)
+}
+
+func (stx *sqliteTrackerTx) writeCatchpointStateUint64(ctx context.Context, stateName catchpointState, setValue uint64) (cleared bool, err error) {
+	qs, err := stx.queries()
+	if err != nil {
+		return false, err
+	}
+	return qs.writeCatchpointStateUint64(ctx, stateName, setValue)
+}
+
+func (stx *sqliteTrackerTx) writeCatchpointStateString(ctx context.Context, stateName catchpointState, setValue string) (cleared bool, err error) {
+	qs, err := stx.queries()
+	if err != nil {
+		return false, err
+	}
+	return qs.writeCatchpointStateString(ctx, stateName, setValue)
+}
+
+func (stx *sqliteTrackerTx) catchpointDeltaAddAccounts(ctx context.Context, addrs []basics.Address) error {
+	return catchpointDeltaAddAccounts(ctx, stx.tx, addrs)
+}
+
+func (stx *sqliteTrackerTx) catchpointDeltaReset(ctx context.Context) error {
+	return catchpointDeltaReset(ctx, stx.tx)
+}
+
+func (stx *sqliteTrackerTx) resetCatchpointStagingBalances(ctx context.Context, newCatchup bool) error {
+	return resetCatchpointStagingBalances(ctx, stx.tx, newCatchup)
+}
+
+func (stx *sqliteTrackerTx) writeCatchpointStagingBalances(ctx context.Context, bals []normalizedAccountBalance) error {
+	return writeCatchpointStagingBalances(ctx, stx.tx, bals)
+}
+
+func (stx *sqliteTrackerTx) writeCatchpointStagingCreatable(ctx context.Context, bals []normalizedAccountBalance) error {
+	return writeCatchpointStagingCreatable(ctx, stx.tx, bals)
+}
+
+func (stx *sqliteTrackerTx) writeCatchpointStagingHashes(ctx context.Context, bals []normalizedAccountBalance) error {
+	return writeCatchpointStagingHashes(ctx, stx.tx, bals)
+}
+
+func (stx *sqliteTrackerTx) deleteCatchpointStagingBalances(ctx context.Context, bals []normalizedAccountBalance) error {
+	return deleteCatchpointStagingBalances(ctx, stx.tx, bals)
+}
+
+func (stx *sqliteTrackerTx) createCatchpointStagingHashesIndex(ctx context.Context) error {
+	return createCatchpointStagingHashesIndex(ctx, stx.tx)
+}
+
+func (stx *sqliteTrackerTx) applyCatchpointStagingBalances(ctx context.Context, balancesRound basics.Round) error {
+	return applyCatchpointStagingBalances(ctx, stx.tx, balancesRound)
+}
+
+// sqliteEncodedAccountsIter adapts the encodedAccountsBatchIter to the encodedAccountsIterator interface.
+type sqliteEncodedAccountsIter struct {
+	encodedAccountsBatchIter
+	tx *sql.Tx
+}
+
+func (iterator *sqliteEncodedAccountsIter) Next(ctx context.Context, accountCount int) (bals []encodedBalanceRecord, err error) {
+	return iterator.encodedAccountsBatchIter.Next(ctx, iterator.tx, accountCount)
+}
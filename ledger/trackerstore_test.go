@@ -0,0 +1,540 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package ledger
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// openTestTrackerStores opens an in-memory tracker store of each of the storage backends, initialized
+// with the given genesis accounts.
+func openTestTrackerStores(t *testing.T, accts map[basics.Address]basics.AccountData, proto config.ConsensusParams) map[string]trackerStore {
+	dbs, _ := dbOpenTest(t, true)
+	setDbLogging(t, dbs)
+	kv, err := openKVStore("", true)
+	require.NoError(t, err)
+
+	stores := map[string]trackerStore{
+		StorageBackendSQLite:  makeSQLiteTrackerStore(dbs),
+		StorageBackendLevelDB: makeKVTrackerStore(kv),
+	}
+	au := &accountUpdates{
+		log:          logging.TestingLog(t),
+		initAccounts: accts,
+		initProto:    proto,
+	}
+	for backend, store := range stores {
+		err := store.batch(func(ctx context.Context, tx trackerStoreWriter) error {
+			return tx.accountsUpgradeSchema(ctx, au)
+		})
+		require.NoError(t, err, backend)
+	}
+	return stores
+}
+
+func closeTestTrackerStores(stores map[string]trackerStore) {
+	for _, store := range stores {
+		store.close()
+	}
+}
+
+// checkTrackerStoreAccounts verifies that the store content matches the given accounts at the given round.
+func checkTrackerStoreAccounts(t *testing.T, backend string, store trackerStore, rnd basics.Round, accts map[basics.Address]basics.AccountData, proto config.ConsensusParams) {
+	err := store.snapshot(func(ctx context.Context, tx trackerStoreReader) error {
+		r, _, err := tx.accountsRound()
+		require.NoError(t, err, backend)
+		require.Equal(t, rnd, r, backend)
+
+		total, err := tx.totalAccounts(ctx)
+		require.NoError(t, err, backend)
+		nonEmpty := 0
+		for _, data := range accts {
+			if !data.IsZero() {
+				nonEmpty++
+			}
+		}
+		require.Equal(t, uint64(nonEmpty), total, backend)
+		return nil
+	})
+	require.NoError(t, err)
+
+	qs, err := store.queries()
+	require.NoError(t, err)
+	defer qs.close()
+	for addr, data := range accts {
+		pad, err := qs.lookup(addr)
+		require.NoError(t, err, backend)
+		require.Equal(t, rnd, pad.round, backend)
+		require.Equal(t, data, pad.accountData, backend)
+	}
+	pad, err := qs.lookup(randomAddress())
+	require.NoError(t, err, backend)
+	require.Equal(t, basics.AccountData{}, pad.accountData, backend)
+}
+
+// TestTrackerStoreRounds applies the same rounds to all the storage backends, and verifies they all end up
+// with the same content.
+func TestTrackerStoreRounds(t *testing.T) {
+	proto := config.Consensus[protocol.ConsensusCurrentVersion]
+
+	accts := randomAccounts(20, true)
+	stores := openTestTrackerStores(t, accts, proto)
+	defer closeTestTrackerStores(stores)
+	for backend, store := range stores {
+		checkTrackerStoreAccounts(t, backend, store, 0, accts, proto)
+	}
+
+	numElementsPerSegement := 10
+	lastCreatableID := crypto.RandUint64() % 512
+	ctbsList, randomCtbs := randomCreatables(numElementsPerSegement)
+	expectedDbImage := make(map[basics.CreatableIndex]ledgercore.ModifiedCreatable)
+	for i := 1; i < 10; i++ {
+		var updates ledgercore.AccountDeltas
+		updates, accts, _, lastCreatableID = randomDeltasFull(20, accts, 0, lastCreatableID)
+		ctbsWithDeletes := randomCreatableSampling(i, ctbsList, randomCtbs,
+			expectedDbImage, numElementsPerSegement)
+
+		for backend, store := range stores {
+			var baseAccounts lruAccounts
+			baseAccounts.init(nil, 100, 80)
+			updatesCnt := makeCompactAccountDeltas([]ledgercore.AccountDeltas{updates}, baseAccounts)
+			err := store.batch(func(ctx context.Context, tx trackerStoreWriter) error {
+				err := tx.accountsLoadOld(&updatesCnt)
+				if err != nil {
+					return err
+				}
+				err = tx.totalsNewRounds([]ledgercore.AccountDeltas{updates}, updatesCnt, []ledgercore.AccountTotals{{}}, proto)
+				if err != nil {
+					return err
+				}
+				_, err = tx.accountsNewRound(updatesCnt, ctbsWithDeletes, proto, basics.Round(i))
+				if err != nil {
+					return err
+				}
+				return tx.updateAccountsRound(basics.Round(i), 0)
+			})
+			require.NoError(t, err, backend)
+			checkTrackerStoreAccounts(t, backend, store, basics.Round(i), accts, proto)
+		}
+	}
+
+	// the backends should agree on the totals, the online accounts and the creatables.
+	var expectedTotals *ledgercore.AccountTotals
+	var expectedOnline map[basics.Address]*onlineAccount
+	for backend, store := range stores {
+		err := store.snapshot(func(ctx context.Context, tx trackerStoreReader) error {
+			totals, err := tx.accountsTotals(false)
+			require.NoError(t, err, backend)
+			online, err := tx.accountsOnlineTop(0, 10, proto)
+			require.NoError(t, err, backend)
+			if expectedTotals == nil {
+				expectedTotals, expectedOnline = &totals, online
+			} else {
+				require.Equal(t, *expectedTotals, totals, backend)
+				require.Equal(t, expectedOnline, online, backend)
+			}
+			return nil
+		})
+		require.NoError(t, err)
+
+		qs, err := store.queries()
+		require.NoError(t, err)
+		for _, ctype := range []basics.CreatableType{basics.AssetCreatable, basics.AppCreatable} {
+			results, dbRound, err := qs.listCreatables(basics.CreatableIndex(math.MaxInt64), uint64(len(ctbsList)), ctype)
+			require.NoError(t, err, backend)
+			require.Equal(t, basics.Round(9), dbRound, backend)
+			expectedCount := 0
+			for cidx, mc := range expectedDbImage {
+				if mc.Ctype == ctype {
+					expectedCount++
+				}
+				creator, ok, _, err := qs.lookupCreator(cidx, mc.Ctype)
+				require.NoError(t, err, backend)
+				require.True(t, ok, backend)
+				require.Equal(t, mc.Creator, creator, backend)
+			}
+			require.Equal(t, expectedCount, len(results), backend)
+			for i, cl := range results {
+				require.Equal(t, expectedDbImage[cl.Index].Creator, cl.Creator, backend)
+				if i > 0 {
+					// listCreatables returns the creatables by descending index.
+					require.Greater(t, uint64(results[i-1].Index), uint64(cl.Index), backend)
+				}
+			}
+		}
+		qs.close()
+	}
+}
+
+func TestTrackerStoreCatchpointState(t *testing.T) {
+	proto := config.Consensus[protocol.ConsensusCurrentVersion]
+	stores := openTestTrackerStores(t, randomAccounts(5, true), proto)
+	defer closeTestTrackerStores(stores)
+
+	for backend, store := range stores {
+		ctx := context.Background()
+		qs, err := store.queries()
+		require.NoError(t, err)
+
+		value, def, err := qs.readCatchpointStateUint64(ctx, catchpointStateCatchupBlockRound)
+		require.NoError(t, err, backend)
+		require.True(t, def, backend)
+		require.Zero(t, value, backend)
+
+		cleared, err := qs.writeCatchpointStateUint64(ctx, catchpointStateCatchupBlockRound, 1234)
+		require.NoError(t, err, backend)
+		require.False(t, cleared, backend)
+		value, def, err = qs.readCatchpointStateUint64(ctx, catchpointStateCatchupBlockRound)
+		require.NoError(t, err, backend)
+		require.False(t, def, backend)
+		require.Equal(t, uint64(1234), value, backend)
+
+		// writing the default value clears the state.
+		cleared, err = qs.writeCatchpointStateUint64(ctx, catchpointStateCatchupBlockRound, 0)
+		require.NoError(t, err, backend)
+		require.True(t, cleared, backend)
+		_, def, err = qs.readCatchpointStateUint64(ctx, catchpointStateCatchupBlockRound)
+		require.NoError(t, err, backend)
+		require.True(t, def, backend)
+
+		_, err = qs.writeCatchpointStateString(ctx, catchpointStateLastCatchpoint, "label")
+		require.NoError(t, err, backend)
+		str, def, err := qs.readCatchpointStateString(ctx, catchpointStateLastCatchpoint)
+		require.NoError(t, err, backend)
+		require.False(t, def, backend)
+		require.Equal(t, "label", str, backend)
+
+		for rnd := basics.Round(1); rnd <= 5; rnd++ {
+			err = qs.storeCatchpoint(ctx, rnd*10, "file", "catchpoint", int64(rnd))
+			require.NoError(t, err, backend)
+		}
+		fileNames, err := qs.getOldestCatchpointFiles(ctx, 2, 2)
+		require.NoError(t, err, backend)
+		require.Equal(t, map[basics.Round]string{10: "file", 20: "file"}, fileNames, backend)
+		fileNames, err = qs.getOldestCatchpointFiles(ctx, 10, 4)
+		require.NoError(t, err, backend)
+		require.Equal(t, map[basics.Round]string{10: "file"}, fileNames, backend)
+
+		err = store.snapshot(func(ctx context.Context, tx trackerStoreReader) error {
+			fileName, catchpoint, fileSize, err := tx.getCatchpoint(30)
+			require.NoError(t, err, backend)
+			require.Equal(t, "file", fileName, backend)
+			require.Equal(t, "catchpoint", catchpoint, backend)
+			require.Equal(t, int64(3), fileSize, backend)
+			return nil
+		})
+		require.NoError(t, err)
+
+		// storing an empty file name deletes the catchpoint record.
+		err = qs.storeCatchpoint(ctx, 10, "", "", 0)
+		require.NoError(t, err, backend)
+		fileNames, err = qs.getOldestCatchpointFiles(ctx, 10, 0)
+		require.NoError(t, err, backend)
+		require.Equal(t, 4, len(fileNames), backend)
+		qs.close()
+	}
+}
+
+func TestTrackerStoreCatchpointDeltaAccounts(t *testing.T) {
+	proto := config.Consensus[protocol.ConsensusCurrentVersion]
+	accts := randomAccounts(5, true)
+	stores := openTestTrackerStores(t, accts, proto)
+	defer closeTestTrackerStores(stores)
+
+	var addrs []basics.Address
+	for addr := range accts {
+		addrs = append(addrs, addr)
+	}
+	deleted := randomAddress()
+	addrs = append(addrs, deleted)
+
+	for backend, store := range stores {
+		err := store.batch(func(ctx context.Context, tx trackerStoreWriter) error {
+			require.NoError(t, tx.catchpointDeltaAddAccounts(ctx, addrs[:3]), backend)
+			// adding the same account more than once is a no-op.
+			return tx.catchpointDeltaAddAccounts(ctx, addrs[2:])
+		})
+		require.NoError(t, err, backend)
+
+		err = store.snapshot(func(ctx context.Context, tx trackerStoreReader) error {
+			count, err := tx.catchpointDeltaAccountsCount(ctx)
+			require.NoError(t, err, backend)
+			require.Equal(t, uint64(len(addrs)), count, backend)
+
+			it := tx.makeCatchpointDeltaAccountsIter()
+			defer it.Close()
+			bals, err := it.Next(ctx, len(addrs)+1)
+			require.NoError(t, err, backend)
+			require.Equal(t, len(addrs), len(bals), backend)
+			for i, bal := range bals {
+				if i > 0 {
+					// the accounts are ordered by their addresses.
+					require.Equal(t, -1, bytes.Compare(bals[i-1].Address[:], bal.Address[:]), backend)
+				}
+				var data basics.AccountData
+				require.NoError(t, protocol.Decode(bal.AccountData, &data), backend)
+				require.Equal(t, accts[bal.Address], data, backend)
+			}
+			return nil
+		})
+		require.NoError(t, err, backend)
+
+		err = store.batch(func(ctx context.Context, tx trackerStoreWriter) error {
+			return tx.catchpointDeltaReset(ctx)
+		})
+		require.NoError(t, err, backend)
+		err = store.snapshot(func(ctx context.Context, tx trackerStoreReader) error {
+			count, err := tx.catchpointDeltaAccountsCount(ctx)
+			require.NoError(t, err, backend)
+			require.Zero(t, count, backend)
+			return nil
+		})
+		require.NoError(t, err, backend)
+	}
+}
+
+func TestTrackerStoreCatchpointStagingDelete(t *testing.T) {
+	proto := config.Consensus[protocol.ConsensusCurrentVersion]
+	stores := openTestTrackerStores(t, randomAccounts(5, true), proto)
+	defer closeTestTrackerStores(stores)
+
+	var records []encodedBalanceRecord
+	lastCreatableID := uint64(1)
+	for i := 0; i < 10; i++ {
+		var data basics.AccountData
+		data, lastCreatableID = randomFullAccountData(0, lastCreatableID)
+		records = append(records, encodedBalanceRecord{Address: randomAddress(), AccountData: protocol.Encode(&data)})
+	}
+	bals, err := prepareNormalizedBalances(records, proto)
+	require.NoError(t, err)
+
+	for backend, store := range stores {
+		err := store.batch(func(ctx context.Context, tx trackerStoreWriter) error {
+			require.NoError(t, tx.resetCatchpointStagingBalances(ctx, true), backend)
+			require.NoError(t, tx.writeCatchpointStagingBalances(ctx, bals), backend)
+			require.NoError(t, tx.writeCatchpointStagingCreatable(ctx, bals), backend)
+
+			staged, err := tx.readCatchpointStagingBalances(ctx, []basics.Address{bals[0].address, randomAddress(), bals[1].address})
+			require.NoError(t, err, backend)
+			require.Equal(t, records[:2], staged, backend)
+
+			require.NoError(t, tx.deleteCatchpointStagingBalances(ctx, bals[:5]), backend)
+			staged, err = tx.readCatchpointStagingBalances(ctx, []basics.Address{bals[0].address, bals[5].address})
+			require.NoError(t, err, backend)
+			require.Equal(t, records[5:6], staged, backend)
+
+			// the deleted accounts can be staged again.
+			return tx.writeCatchpointStagingBalances(ctx, bals[:5])
+		})
+		require.NoError(t, err, backend)
+	}
+}
+
+// expectedAssetHolders returns the holders of the given asset among the accounts, ordered by their addresses.
+func expectedAssetHolders(accts map[basics.Address]basics.AccountData, aidx basics.AssetIndex, minAmount, maxAmount uint64) []AssetHolder {
+	var holders []AssetHolder
+	for addr, data := range accts {
+		if holding, ok := data.Assets[aidx]; ok && holding.Amount >= minAmount && holding.Amount <= maxAmount {
+			holders = append(holders, AssetHolder{Address: addr, Holding: holding})
+		}
+	}
+	sortAssetHolders(holders)
+	return holders
+}
+
+// expectedAppAccounts returns the addresses of the accounts opted into the given application, in increasing order.
+func expectedAppAccounts(accts map[basics.Address]basics.AccountData, appIdx basics.AppIndex) []basics.Address {
+	var addrs []basics.Address
+	for addr, data := range accts {
+		if _, ok := data.AppLocalStates[appIdx]; ok {
+			addrs = append(addrs, addr)
+		}
+	}
+	sortAddresses(addrs)
+	return addrs
+}
+
+// checkTrackerStoreHoldings verifies that the holdings index queries of the store match the given accounts.
+func checkTrackerStoreHoldings(t *testing.T, backend string, store trackerStore, accts map[basics.Address]basics.AccountData) {
+	qs, err := store.queries()
+	require.NoError(t, err)
+	defer qs.close()
+
+	for _, aidx := range []basics.AssetIndex{1, 2, 3} {
+		expected := expectedAssetHolders(accts, aidx, 0, math.MaxUint64)
+		holders, _, err := qs.listAssetHolders(aidx, nil, 0, math.MaxUint64, uint64(len(accts)+1))
+		require.NoError(t, err, backend)
+		require.Equal(t, expected, holders, backend)
+
+		// page through the holders.
+		var paged []AssetHolder
+		var after *basics.Address
+		for {
+			page, _, err := qs.listAssetHolders(aidx, after, 0, math.MaxUint64, 2)
+			require.NoError(t, err, backend)
+			paged = append(paged, page...)
+			if len(page) < 2 {
+				break
+			}
+			after = &page[len(page)-1].Address
+		}
+		require.Equal(t, expected, paged, backend)
+
+		holders, _, err = qs.listAssetHolders(aidx, nil, 10, 20, uint64(len(accts)+1))
+		require.NoError(t, err, backend)
+		require.Equal(t, expectedAssetHolders(accts, aidx, 10, 20), holders, backend)
+	}
+
+	for _, appIdx := range []basics.AppIndex{7, 8} {
+		expected := expectedAppAccounts(accts, appIdx)
+		addrs, _, err := qs.listAppOptIns(appIdx, nil, uint64(len(accts)+1))
+		require.NoError(t, err, backend)
+		require.Equal(t, expected, addrs, backend)
+		if len(expected) > 1 {
+			addrs, _, err = qs.listAppOptIns(appIdx, &expected[0], 1)
+			require.NoError(t, err, backend)
+			require.Equal(t, expected[1:2], addrs, backend)
+		}
+	}
+}
+
+// randomHoldingsAccounts returns accounts holding the assets 1 and 2, and opted into the application 7.
+func randomHoldingsAccounts(count int) map[basics.Address]basics.AccountData {
+	accts := make(map[basics.Address]basics.AccountData)
+	for i := 0; i < count; i++ {
+		data := basics.AccountData{
+			MicroAlgos: basics.MicroAlgos{Raw: 1000000},
+			Assets:     map[basics.AssetIndex]basics.AssetHolding{1: {Amount: uint64(i * 5)}},
+		}
+		if i%2 == 0 {
+			// amounts with the high bit set are compared correctly.
+			data.Assets[2] = basics.AssetHolding{Amount: math.MaxUint64 - uint64(i), Frozen: true}
+		} else {
+			data.AppLocalStates = map[basics.AppIndex]basics.AppLocalState{7: {}}
+		}
+		accts[randomAddress()] = data
+	}
+	return accts
+}
+
+func TestTrackerStoreHoldingsIndex(t *testing.T) {
+	proto := config.Consensus[protocol.ConsensusCurrentVersion]
+	accts := randomHoldingsAccounts(8)
+	stores := openTestTrackerStores(t, accts, proto)
+	defer closeTestTrackerStores(stores)
+
+	var addrs []basics.Address
+	for addr := range accts {
+		addrs = append(addrs, addr)
+	}
+	sortAddresses(addrs)
+
+	// change a holding, opt out an account, and add a new holder.
+	newAccts := make(map[basics.Address]basics.AccountData, len(accts)+1)
+	for addr, data := range accts {
+		newAccts[addr] = data
+	}
+	var updates ledgercore.AccountDeltas
+	changed := accts[addrs[0]]
+	changed.Assets = map[basics.AssetIndex]basics.AssetHolding{1: {Amount: 15, Frozen: true}, 3: {Amount: 12}}
+	changed.AppLocalStates = map[basics.AppIndex]basics.AppLocalState{8: {}}
+	optedOut := accts[addrs[1]]
+	optedOut.Assets = nil
+	optedOut.AppLocalStates = nil
+	added := basics.AccountData{
+		MicroAlgos: basics.MicroAlgos{Raw: 1000000},
+		Assets:     map[basics.AssetIndex]basics.AssetHolding{1: {Amount: 11}},
+	}
+	newAddr := randomAddress()
+	for addr, data := range map[basics.Address]basics.AccountData{addrs[0]: changed, addrs[1]: optedOut, addrs[2]: {}, newAddr: added} {
+		updates.Upsert(addr, data)
+		newAccts[addr] = data
+	}
+	delete(newAccts, addrs[2])
+
+	for backend, store := range stores {
+		checkTrackerStoreHoldings(t, backend, store, accts)
+
+		var baseAccounts lruAccounts
+		baseAccounts.init(nil, 100, 80)
+		updatesCnt := makeCompactAccountDeltas([]ledgercore.AccountDeltas{updates}, baseAccounts)
+		err := store.batch(func(ctx context.Context, tx trackerStoreWriter) error {
+			err := tx.accountsLoadOld(&updatesCnt)
+			if err != nil {
+				return err
+			}
+			_, err = tx.accountsNewRound(updatesCnt, nil, proto, basics.Round(1))
+			if err != nil {
+				return err
+			}
+			return tx.updateAccountsRound(basics.Round(1), 0)
+		})
+		require.NoError(t, err, backend)
+		checkTrackerStoreHoldings(t, backend, store, newAccts)
+
+		// rebuilding the index, as done when upgrading the storage, yields the same index.
+		err = store.batch(func(ctx context.Context, tx trackerStoreWriter) error {
+			switch tx := tx.(type) {
+			case *sqliteTrackerTx:
+				for _, stmt := range []string{"DROP TABLE assetholders", "DROP TABLE appoptins"} {
+					_, err := tx.tx.Exec(stmt)
+					require.NoError(t, err, backend)
+				}
+				return accountsCreateHoldingsIndex(ctx, tx.tx)
+			case *kvTrackerTx:
+				require.NoError(t, kvDeleteRange(tx.w, kvPrefixRange(kvBaseTables.holders)), backend)
+				require.NoError(t, kvDeleteRange(tx.w, kvPrefixRange(kvBaseTables.optins)), backend)
+				require.NoError(t, kvPutUint64(tx.w, []byte(kvVersionKey), 1), backend)
+				return tx.accountsUpgradeSchema(ctx, &accountUpdates{log: logging.TestingLog(t)})
+			}
+			return nil
+		})
+		require.NoError(t, err, backend)
+		checkTrackerStoreHoldings(t, backend, store, newAccts)
+
+		// the index of the catchpoint staged accounts replaces the current one.
+		stagedAccts := randomHoldingsAccounts(6)
+		var records []encodedBalanceRecord
+		for addr, data := range stagedAccts {
+			records = append(records, encodedBalanceRecord{Address: addr, AccountData: protocol.Encode(&data)})
+		}
+		bals, err := prepareNormalizedBalances(records, proto)
+		require.NoError(t, err)
+		err = store.batch(func(ctx context.Context, tx trackerStoreWriter) error {
+			require.NoError(t, tx.resetCatchpointStagingBalances(ctx, true), backend)
+			require.NoError(t, tx.writeCatchpointStagingBalances(ctx, bals), backend)
+			require.NoError(t, tx.writeCatchpointStagingCreatable(ctx, bals), backend)
+			require.NoError(t, tx.deleteCatchpointStagingBalances(ctx, bals[:1]), backend)
+			return tx.applyCatchpointStagingBalances(ctx, basics.Round(2))
+		})
+		require.NoError(t, err, backend)
+		delete(stagedAccts, bals[0].address)
+		checkTrackerStoreHoldings(t, backend, store, stagedAccts)
+	}
+}
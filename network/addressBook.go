@@ -0,0 +1,230 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/algorand/go-deadlock"
+
+	"github.com/algorand/go-algorand/util"
+)
+
+// addressBookEntryLifetime is for how long the history of a relay which wasn't seen nor attempted is kept.
+const addressBookEntryLifetime = 30 * 24 * time.Hour
+
+// addressBookSmoothing is the weight of a new measurement in the exponentially weighted averages of the entries.
+const addressBookSmoothing = 0.25
+
+// addressBookReferenceRTT is the ping round trip time at which the score of a relay is halved.
+const addressBookReferenceRTT = 250 * time.Millisecond
+
+// addressBookMinScore is the weight the relays are ranked with at the least, so that even the relays which
+// performed the worst get tried once in a while.
+const addressBookMinScore = 0.01
+
+// addressBookEntry is the connection quality history of a relay, as persisted in the address book file.
+type addressBookEntry struct {
+	Address string `json:"address"`
+
+	// ConnectionAttempts and ConnectionSuccesses count the outgoing connection attempts to the relay, and how many
+	// of these succeeded.
+	ConnectionAttempts  uint64 `json:"attempts"`
+	ConnectionSuccesses uint64 `json:"successes"`
+
+	// PingRTT is the average ping round trip time of the relay; zero if it was never measured.
+	PingRTT time.Duration `json:"rtt,omitempty"`
+
+	// FirstMessageRatio is the average portion of the messages the relay delivered before any other peer, as
+	// measured by the connection performance monitor.
+	FirstMessageRatio float64 `json:"first,omitempty"`
+	// MessageDelay is the average relative message delay of the relay, in nanoseconds.
+	MessageDelay int64 `json:"delay,omitempty"`
+	// LeastPerforming counts the disconnections of the relay for being the least performing outgoing peer.
+	LeastPerforming uint64 `json:"leastperforming,omitempty"`
+
+	// LastSeen is the last time the node was connected to the relay, and LastAttempt the last time it attempted to.
+	LastSeen    time.Time `json:"lastseen"`
+	LastAttempt time.Time `json:"lastattempt"`
+}
+
+// score rates the relay; the higher the better. A relay with no history scores 0.5, so that relays which proved to
+// be good are preferred over unknown ones, which are in turn preferred over the ones which proved to be bad.
+func (e *addressBookEntry) score() float64 {
+	successRate := float64(e.ConnectionSuccesses+1) / float64(e.ConnectionAttempts+2)
+	latency := 1 / (1 + float64(e.PingRTT)/float64(addressBookReferenceRTT))
+	return successRate * latency * (1 + e.FirstMessageRatio) / float64(1+e.LeastPerforming)
+}
+
+// addressBook keeps the connection quality history of the relays across restarts, so that the node reconnects to
+// the relays which historically performed well.
+type addressBook struct {
+	mu deadlock.Mutex
+
+	entries map[string]*addressBookEntry
+
+	// file is the file the address book is persisted to; the address book isn't persisted when empty.
+	file string
+}
+
+func makeAddressBook() *addressBook {
+	return &addressBook{
+		entries: make(map[string]*addressBookEntry),
+	}
+}
+
+// entry returns the entry of the address, creating it if needed. The lock must be held.
+func (ab *addressBook) entry(addr string) *addressBookEntry {
+	e := ab.entries[addr]
+	if e == nil {
+		e = &addressBookEntry{Address: addr}
+		ab.entries[addr] = e
+	}
+	return e
+}
+
+// recordConnectionAttempt records the outcome of an outgoing connection attempt to the relay.
+func (ab *addressBook) recordConnectionAttempt(addr string, success bool, now time.Time) {
+	ab.mu.Lock()
+	defer ab.mu.Unlock()
+	e := ab.entry(addr)
+	e.ConnectionAttempts++
+	e.LastAttempt = now
+	if success {
+		e.ConnectionSuccesses++
+		e.LastSeen = now
+	}
+}
+
+// recordConnected records that the node is connected to the relay, along with the last ping round trip time to
+// the relay, if there is one.
+func (ab *addressBook) recordConnected(addr string, rtt time.Duration, now time.Time) {
+	ab.mu.Lock()
+	defer ab.mu.Unlock()
+	e := ab.entry(addr)
+	e.LastSeen = now
+	if rtt <= 0 {
+		return
+	}
+	if e.PingRTT == 0 {
+		e.PingRTT = rtt
+	} else {
+		e.PingRTT += time.Duration(addressBookSmoothing * float64(rtt-e.PingRTT))
+	}
+}
+
+// recordPerformance records the statistics of the relay gathered by the connection performance monitor.
+func (ab *addressBook) recordPerformance(addr string, stat pmPeerStatistics) {
+	ab.mu.Lock()
+	defer ab.mu.Unlock()
+	e := ab.entry(addr)
+	e.FirstMessageRatio += addressBookSmoothing * (float64(stat.peerFirstMessage) - e.FirstMessageRatio)
+	e.MessageDelay += int64(addressBookSmoothing * float64(stat.peerDelay-e.MessageDelay))
+}
+
+// recordLeastPerforming records the disconnection of the relay for being the least performing outgoing peer.
+func (ab *addressBook) recordLeastPerforming(addr string) {
+	ab.mu.Lock()
+	defer ab.mu.Unlock()
+	ab.entry(addr).LeastPerforming++
+}
+
+// rank shuffles the given relay addresses at random, weighted by their scores: every relay comes before another
+// one with a probability proportional to its score, so that the relays which performed well are mostly tried
+// first, while the others, and the unknown ones, still get picked from time to time.
+func (ab *addressBook) rank(addrs []string) {
+	ab.mu.Lock()
+	keys := make(map[string]float64, len(addrs))
+	for _, addr := range addrs {
+		score := (&addressBookEntry{}).score()
+		if e := ab.entries[addr]; e != nil {
+			score = e.score()
+		}
+		// the weighted random sampling of Efraimidis and Spirakis: sorting by u^(1/w), for a uniform u in (0, 1],
+		// draws the elements in a weighted random order.
+		keys[addr] = math.Pow(1-rand.Float64(), 1/math.Max(score, addressBookMinScore))
+	}
+	ab.mu.Unlock()
+	sort.SliceStable(addrs, func(i, j int) bool {
+		return keys[addrs[i]] > keys[addrs[j]]
+	})
+}
+
+// prune drops the entries of the relays which weren't seen nor attempted for a long while.
+func (ab *addressBook) prune(now time.Time) {
+	for addr, e := range ab.entries {
+		lastActive := e.LastSeen
+		if e.LastAttempt.After(lastActive) {
+			lastActive = e.LastAttempt
+		}
+		if now.Sub(lastActive) > addressBookEntryLifetime {
+			delete(ab.entries, addr)
+		}
+	}
+}
+
+// load sets the file the address book is persisted to, and loads the entries it holds. A missing file isn't an
+// error.
+func (ab *addressBook) load(file string, now time.Time) error {
+	ab.mu.Lock()
+	defer ab.mu.Unlock()
+	ab.file = file
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var entries []addressBookEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	for i := range entries {
+		ab.entries[entries[i].Address] = &entries[i]
+	}
+	ab.prune(now)
+	return nil
+}
+
+// save writes the address book to its file, if one was set.
+func (ab *addressBook) save(now time.Time) error {
+	ab.mu.Lock()
+	defer ab.mu.Unlock()
+	if ab.file == "" {
+		return nil
+	}
+	ab.prune(now)
+
+	entries := make([]addressBookEntry, 0, len(ab.entries))
+	for _, e := range ab.entries {
+		entries = append(entries, *e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Address < entries[j].Address })
+	data, err := json.MarshalIndent(entries, "", "\t")
+	if err != nil {
+		return err
+	}
+	return util.WriteFileAtomically(ab.file, data, 0600)
+}
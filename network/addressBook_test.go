@@ -0,0 +1,147 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressBookRank(t *testing.T) {
+	ab := makeAddressBook()
+	now := time.Now()
+
+	// a reliable and close relay.
+	for i := 0; i < 10; i++ {
+		ab.recordConnectionAttempt("good:4160", true, now)
+	}
+	ab.recordConnected("good:4160", 20*time.Millisecond, now)
+	// a reliable but distant relay.
+	for i := 0; i < 10; i++ {
+		ab.recordConnectionAttempt("far:4160", true, now)
+	}
+	ab.recordConnected("far:4160", time.Second, now)
+	// an unreachable relay.
+	for i := 0; i < 10; i++ {
+		ab.recordConnectionAttempt("down:4160", false, now)
+	}
+	// a relay which keeps lagging behind the others.
+	ab.recordConnectionAttempt("slow:4160", true, now)
+	ab.recordPerformance("slow:4160", pmPeerStatistics{peerDelay: int64(time.Second)})
+	ab.recordLeastPerforming("slow:4160")
+	ab.recordLeastPerforming("slow:4160")
+
+	// the relays are ranked at random, weighted by their scores: the better relays mostly come first, while the
+	// others still do once in a while.
+	first := make(map[string]int)
+	last := make(map[string]int)
+	const rounds = 10000
+	for i := 0; i < rounds; i++ {
+		addrs := []string{"down:4160", "unknown1:4160", "slow:4160", "far:4160", "unknown2:4160", "good:4160"}
+		ab.rank(addrs)
+		require.Len(t, addrs, 6)
+		first[addrs[0]]++
+		last[addrs[len(addrs)-1]]++
+	}
+	require.Greater(t, first["good:4160"], first["unknown1:4160"])
+	require.Greater(t, first["unknown1:4160"], first["slow:4160"])
+	require.Greater(t, first["slow:4160"], first["far:4160"])
+	require.Greater(t, first["far:4160"], first["down:4160"])
+	require.Greater(t, first["down:4160"], 0)
+	require.Greater(t, last["down:4160"], last["good:4160"])
+	require.Greater(t, last["good:4160"], 0)
+}
+
+func TestAddressBookAverages(t *testing.T) {
+	ab := makeAddressBook()
+	now := time.Now()
+
+	ab.recordConnected("relay:4160", 0, now)
+	require.Zero(t, ab.entries["relay:4160"].PingRTT)
+	ab.recordConnected("relay:4160", 100*time.Millisecond, now)
+	require.Equal(t, 100*time.Millisecond, ab.entries["relay:4160"].PingRTT)
+	ab.recordConnected("relay:4160", 500*time.Millisecond, now)
+	require.Equal(t, 200*time.Millisecond, ab.entries["relay:4160"].PingRTT)
+
+	ab.recordPerformance("relay:4160", pmPeerStatistics{peerFirstMessage: 1})
+	require.Equal(t, 0.25, ab.entries["relay:4160"].FirstMessageRatio)
+}
+
+func TestAddressBookPersistence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "addressbook")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "addressbook.json")
+
+	now := time.Now()
+	ab := makeAddressBook()
+	// a missing file means there is no history yet.
+	require.NoError(t, ab.load(file, now))
+	ab.recordConnectionAttempt("relay:4160", true, now)
+	ab.recordConnected("relay:4160", 50*time.Millisecond, now)
+	ab.recordConnectionAttempt("stale:4160", true, now.Add(-addressBookEntryLifetime-time.Minute))
+	require.NoError(t, ab.save(now))
+
+	restored := makeAddressBook()
+	require.NoError(t, restored.load(file, now))
+	require.Len(t, restored.entries, 1)
+	entry := restored.entries["relay:4160"]
+	require.NotNil(t, entry)
+	require.Equal(t, uint64(1), entry.ConnectionSuccesses)
+	require.Equal(t, 50*time.Millisecond, entry.PingRTT)
+	require.True(t, entry.LastSeen.Equal(now))
+
+	require.NoError(t, ioutil.WriteFile(file, []byte("not json"), 0600))
+	require.Error(t, makeAddressBook().load(file, now))
+}
+
+func TestWebsocketNetworkAddressBook(t *testing.T) {
+	dir, err := ioutil.TempDir("", "addressbook")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "addressbook.json")
+
+	netA := makeTestWebsocketNode(t)
+	netA.config.GossipFanout = 1
+	netA.Start()
+	defer netA.Stop()
+	netB := makeTestWebsocketNode(t)
+	netB.config.GossipFanout = 1
+	netB.SetAddressBookFile(file)
+	addrA, postListen := netA.Address()
+	require.True(t, postListen)
+	netB.phonebook.ReplacePeerList([]string{addrA}, "default", PhoneBookEntryRelayRole)
+	netB.Start()
+
+	readyTimeout := time.NewTimer(2 * time.Second)
+	waitReady(t, netA, readyTimeout.C)
+	waitReady(t, netB, readyTimeout.C)
+	netB.Stop()
+
+	restored := makeAddressBook()
+	require.NoError(t, restored.load(file, time.Now()))
+	entry := restored.entries[addrA]
+	require.NotNil(t, entry)
+	require.Equal(t, uint64(1), entry.ConnectionAttempts)
+	require.Equal(t, uint64(1), entry.ConnectionSuccesses)
+	require.False(t, entry.LastSeen.IsZero())
+}
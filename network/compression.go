@@ -0,0 +1,232 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"bytes"
+	"compress/flate"
+	"errors"
+	"io"
+	"net/http"
+	"net/textproto"
+	"sync"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// CompressionHeader HTTP header by which a client advertises the message compression codecs it supports, and by which
+// the server reports the codec it selected. Old peers ignore it, and exchange uncompressed messages.
+const CompressionHeader = "X-Algorand-Compression"
+
+// msgpDeflateCodec is the name of the deflate codec using the msgpDictionary preset dictionary.
+// The codec name must be changed whenever the dictionary, or the compressedMessageTags, are.
+const msgpDeflateCodec = "deflate-msgp-1"
+
+// supportedCompressionCodecs contains the list of supported compression codecs by this node ( in order of preference ).
+var supportedCompressionCodecs = []string{msgpDeflateCodec}
+
+// minCompressedMessageLength is the size below which the messages are sent uncompressed, as the compression wouldn't
+// make up for its cost.
+const minCompressedMessageLength = 64
+
+// compressedMessageTags are the tags of the messages which are compressed once a codec was negotiated. Over such
+// connections, the payload of these messages is prefixed by one of the compression flags.
+var compressedMessageTags = map[protocol.Tag]bool{
+	protocol.ProposalPayloadTag: true,
+	protocol.TopicMsgRespTag:    true,
+	protocol.TxnTag:             true,
+	protocol.VoteBundleTag:      true,
+}
+
+// maxTxnMessageLength is the maximal size of a decompressed transaction message. A transaction group can't be larger
+// than the transactions of a block.
+var maxTxnMessageLength = func() int {
+	maxLength := 0
+	for _, params := range config.Consensus {
+		if params.MaxTxnBytesPerBlock > maxLength {
+			maxLength = params.MaxTxnBytesPerBlock
+		}
+	}
+	if maxLength == 0 || maxLength > maxMessageLength {
+		return maxMessageLength
+	}
+	return maxLength
+}()
+
+// maxDecompressedMessageLength returns the maximal size of a decompressed message of one of the compressedMessageTags.
+func maxDecompressedMessageLength(tag protocol.Tag) int {
+	if tag == protocol.TxnTag {
+		return maxTxnMessageLength
+	}
+	return maxMessageLength
+}
+
+const (
+	compressionFlagNone    byte = 0
+	compressionFlagDeflate byte = 1
+)
+
+var errUnknownCompressionFlag = errors.New("unknown message compression flag")
+var errCompressedMessageTooLarge = errors.New("decompressed message is too large")
+
+// msgpDictionary is the preset dictionary of the deflate codec. It holds the msgpack encoding of the field names
+// which are the most common in the transactions, blocks, proposals and votes, followed by the encoding headers of
+// the addresses, digests and signatures. Deflate favors the recent data, so the most common strings come last.
+var msgpDictionary = makeMsgpDictionary([]string{
+	// votes and bundles
+	"cred", "eqv", "per", "prop", "props", "step", "sigs", "vote", "oprop", "oper", "dig", "encdig", "pf", "ps", "p", "p1s", "p2", "p2s", "s",
+	// blocks and proposals
+	"earn", "fees", "frac", "rwcalr", "rwd", "rate", "proto", "nextproto", "nextbefore", "nextswitch", "nextyes", "upgradeprop",
+	"upgradedelay", "upgradeyes", "prev", "seed", "tc", "ts", "txn256", "txns", "sdpf", "hgh", "hgi",
+	// transactions
+	"keyreg", "votefst", "votekd", "votekey", "votelst", "selkey", "nonpart", "appl", "apaa", "apan", "apap", "apas", "apat",
+	"apfa", "apgs", "apid", "apls", "apsu", "acfg", "apar", "caid", "afrz", "fadd", "faid", "axfer", "aamt", "aclose", "arcv",
+	"asnd", "xaid", "lsig", "msig", "subsig", "thr", "pk", "close", "rekey", "grp", "lx", "note", "amt", "rcv", "pay", "type",
+	"gen", "gh", "fee", "fv", "lv", "snd", "sig", "txn", "rnd",
+})
+
+func makeMsgpDictionary(fieldNames []string) []byte {
+	var dict bytes.Buffer
+	for _, name := range fieldNames {
+		// fixstr encoding.
+		dict.WriteByte(0xa0 | byte(len(name)))
+		dict.WriteString(name)
+	}
+	// the bin 8 headers of the 32 bytes addresses and digests, and of the 64 bytes signatures.
+	dict.Write([]byte{0xc4, 0x40, 0xc4, 0x20})
+	return dict.Bytes()
+}
+
+// selectCompressionCodec returns the first of the codecs advertised in the headers which is supported, or an empty
+// string if there is none.
+func selectCompressionCodec(otherHeaders http.Header) string {
+	for _, otherCodec := range otherHeaders[textproto.CanonicalMIMEHeaderKey(CompressionHeader)] {
+		for _, supportedCodec := range supportedCompressionCodecs {
+			if supportedCodec == otherCodec {
+				return supportedCodec
+			}
+		}
+	}
+	return ""
+}
+
+// messageCompressor compresses and decompresses the messages of a peer using the negotiated codec. The compression
+// is done by the peer write loop, while the decompression is done by the peer read loop, so that each direction has
+// its own buffers and needs no synchronization.
+type messageCompressor struct {
+	writer   *flate.Writer
+	writeBuf bytes.Buffer
+
+	reader io.ReadCloser
+}
+
+func makeMessageCompressor() *messageCompressor {
+	// the dictionary is valid, and the level is within range; NewWriterDict can't fail.
+	writer, _ := flate.NewWriterDict(nil, flate.BestSpeed, msgpDictionary)
+	return &messageCompressor{
+		writer: writer,
+		reader: flate.NewReaderDict(bytes.NewReader(nil), msgpDictionary),
+	}
+}
+
+// broadcastCompressors are the compressors of the broadcast messages, which are compressed once for all the peers.
+var broadcastCompressors = sync.Pool{
+	New: func() interface{} {
+		return makeMessageCompressor()
+	},
+}
+
+// compressBroadcast returns the wire encodings of the broadcast tagged messages of the compressedMessageTags, so that
+// they are compressed once for all the peers which negotiated a compression codec rather than once by every peer.
+// The encodings of the other messages, and of the messages which failed to compress, are left nil; the write loops
+// of the peers compress the latter on their own.
+func compressBroadcast(tags []protocol.Tag, data [][]byte) [][]byte {
+	compressor := broadcastCompressors.Get().(*messageCompressor)
+	defer broadcastCompressors.Put(compressor)
+	compressed := make([][]byte, len(data))
+	for i, d := range data {
+		if !compressedMessageTags[tags[i]] {
+			continue
+		}
+		encoded, err := compressor.compress(d)
+		if err != nil {
+			continue
+		}
+		compressed[i] = append([]byte(nil), encoded...)
+	}
+	return compressed
+}
+
+// compress returns the wire encoding of a tagged message of one of the compressedMessageTags. The returned slice is
+// only valid until the next call.
+func (c *messageCompressor) compress(data []byte) ([]byte, error) {
+	c.writeBuf.Reset()
+	c.writeBuf.Write(data[:2])
+	payload := data[2:]
+	if len(payload) >= minCompressedMessageLength {
+		c.writeBuf.WriteByte(compressionFlagDeflate)
+		c.writer.Reset(&c.writeBuf)
+		if _, err := c.writer.Write(payload); err != nil {
+			return nil, err
+		}
+		if err := c.writer.Close(); err != nil {
+			return nil, err
+		}
+		if c.writeBuf.Len() < len(data)+1 {
+			return c.writeBuf.Bytes(), nil
+		}
+		// the message didn't compress; send it as is.
+		c.writeBuf.Truncate(2)
+	}
+	c.writeBuf.WriteByte(compressionFlagNone)
+	c.writeBuf.Write(payload)
+	return c.writeBuf.Bytes(), nil
+}
+
+// decompress returns the payload of a received message of one of the compressedMessageTags, given its tag and wire
+// payload. The decompressed payload may not exceed the maximal size of the messages of the tag.
+func (c *messageCompressor) decompress(tag protocol.Tag, data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, errUnknownCompressionFlag
+	}
+	switch data[0] {
+	case compressionFlagNone:
+		return data[1:], nil
+	case compressionFlagDeflate:
+	default:
+		return nil, errUnknownCompressionFlag
+	}
+
+	if err := c.reader.(flate.Resetter).Reset(bytes.NewReader(data[1:]), msgpDictionary); err != nil {
+		return nil, err
+	}
+	maxLength := maxDecompressedMessageLength(tag)
+	sizeHint := 4 * len(data)
+	if sizeHint > maxLength {
+		sizeHint = maxLength
+	}
+	out := bytes.NewBuffer(make([]byte, 0, sizeHint))
+	n, err := out.ReadFrom(io.LimitReader(c.reader, int64(maxLength)+1))
+	if err != nil {
+		return nil, err
+	}
+	if n > int64(maxLength) {
+		return nil, errCompressedMessageTooLarge
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,213 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+func makeCompressionTestTxn() []byte {
+	var stxn transactions.SignedTxn
+	stxn.Txn.Type = protocol.PaymentTx
+	stxn.Txn.Sender = basics.Address(crypto.Hash([]byte("sender")))
+	stxn.Txn.Receiver = basics.Address(crypto.Hash([]byte("receiver")))
+	stxn.Txn.Amount = basics.MicroAlgos{Raw: 1000000}
+	stxn.Txn.Fee = basics.MicroAlgos{Raw: 1000}
+	stxn.Txn.FirstValid = 1000
+	stxn.Txn.LastValid = 2000
+	stxn.Txn.GenesisID = "go-test-network-genesis"
+	stxn.Txn.GenesisHash = crypto.Hash([]byte("genesis"))
+	crypto.RandBytes(stxn.Sig[:])
+	return protocol.Encode(&stxn)
+}
+
+func TestMessageCompressor(t *testing.T) {
+	sender, receiver := makeMessageCompressor(), makeMessageCompressor()
+
+	// a transaction group compresses.
+	var txns []byte
+	for i := 0; i < 4; i++ {
+		txns = append(txns, makeCompressionTestTxn()...)
+	}
+	msg := append([]byte(protocol.TxnTag), txns...)
+	wire, err := sender.compress(msg)
+	require.NoError(t, err)
+	require.Equal(t, []byte(protocol.TxnTag), wire[:2])
+	require.Equal(t, compressionFlagDeflate, wire[2])
+	require.Less(t, len(wire), len(msg))
+	data, err := receiver.decompress(protocol.TxnTag, append([]byte(nil), wire[2:]...))
+	require.NoError(t, err)
+	require.Equal(t, txns, data)
+
+	// short and incompressible messages are sent as is.
+	random := make([]byte, 1000)
+	crypto.RandBytes(random)
+	for _, payload := range [][]byte{[]byte("short"), random} {
+		msg = append([]byte(protocol.ProposalPayloadTag), payload...)
+		wire, err = sender.compress(msg)
+		require.NoError(t, err)
+		require.Equal(t, compressionFlagNone, wire[2])
+		require.Equal(t, payload, wire[3:])
+		data, err = receiver.decompress(protocol.ProposalPayloadTag, append([]byte(nil), wire[2:]...))
+		require.NoError(t, err)
+		require.Equal(t, payload, data)
+	}
+
+	// the compressor is reusable across messages.
+	msg = append([]byte(protocol.TxnTag), txns...)
+	wire, err = sender.compress(msg)
+	require.NoError(t, err)
+	data, err = receiver.decompress(protocol.TxnTag, append([]byte(nil), wire[2:]...))
+	require.NoError(t, err)
+	require.Equal(t, txns, data)
+
+	_, err = receiver.decompress(protocol.TxnTag, nil)
+	require.Equal(t, errUnknownCompressionFlag, err)
+	_, err = receiver.decompress(protocol.TxnTag, []byte{2, 1, 2, 3})
+	require.Equal(t, errUnknownCompressionFlag, err)
+	_, err = receiver.decompress(protocol.TxnTag, []byte{compressionFlagDeflate, 0xff, 0xff, 0xff})
+	require.Error(t, err)
+
+	// messages decompressing beyond maxMessageLength are rejected.
+	bomb := makeCompressionTestBomb(t, maxMessageLength+1)
+	_, err = receiver.decompress(protocol.ProposalPayloadTag, bomb)
+	require.Equal(t, errCompressedMessageTooLarge, err)
+
+	// and so are the transaction messages decompressing beyond the size of the transactions of a block.
+	require.Less(t, maxTxnMessageLength, maxMessageLength)
+	bomb = makeCompressionTestBomb(t, maxTxnMessageLength+1)
+	_, err = receiver.decompress(protocol.TxnTag, bomb)
+	require.Equal(t, errCompressedMessageTooLarge, err)
+	data, err = receiver.decompress(protocol.ProposalPayloadTag, bomb)
+	require.NoError(t, err)
+	require.Len(t, data, maxTxnMessageLength+1)
+}
+
+// makeCompressionTestBomb returns the wire payload of a deflated message of the given size.
+func makeCompressionTestBomb(t *testing.T, size int) []byte {
+	var bomb bytes.Buffer
+	bomb.WriteByte(compressionFlagDeflate)
+	w, err := flate.NewWriterDict(&bomb, flate.BestCompression, msgpDictionary)
+	require.NoError(t, err)
+	_, err = w.Write(make([]byte, size))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return bomb.Bytes()
+}
+
+func TestCompressBroadcast(t *testing.T) {
+	var txns []byte
+	for i := 0; i < 4; i++ {
+		txns = append(txns, makeCompressionTestTxn()...)
+	}
+	tags := []protocol.Tag{protocol.TxnTag, protocol.AgreementVoteTag}
+	data := [][]byte{append([]byte(protocol.TxnTag), txns...), append([]byte(protocol.AgreementVoteTag), txns...)}
+
+	// the messages of the compressed tags are compressed as the peers would, and the other ones are left to the peers.
+	compressed := compressBroadcast(tags, data)
+	require.Len(t, compressed, 2)
+	expected, err := makeMessageCompressor().compress(data[0])
+	require.NoError(t, err)
+	require.Equal(t, expected, compressed[0])
+	require.Nil(t, compressed[1])
+
+	// the compressed messages are shared by the peers, and so aren't overwritten by the following broadcasts.
+	compressBroadcast(tags, [][]byte{append([]byte(protocol.TxnTag), makeCompressionTestTxn()...), data[1]})
+	require.Equal(t, expected, compressed[0])
+}
+
+func TestSelectCompressionCodec(t *testing.T) {
+	header := make(http.Header)
+	require.Equal(t, "", selectCompressionCodec(header))
+	header.Add(CompressionHeader, "unknown")
+	require.Equal(t, "", selectCompressionCodec(header))
+	header.Add(CompressionHeader, msgpDeflateCodec)
+	require.Equal(t, msgpDeflateCodec, selectCompressionCodec(header))
+}
+
+// testCompressionNegotiation connects a client to a server, and checks the codec they negotiated and that the
+// messages they exchange in both directions are received intact.
+func testCompressionNegotiation(t *testing.T, serverCompression, clientCompression bool, expectedCodec string) {
+	serverConfig := defaultConfig
+	serverConfig.GossipFanout = 1
+	serverConfig.EnableMessageCompression = serverCompression
+	netA := makeTestWebsocketNodeWithConfig(t, serverConfig)
+	netA.Start()
+	defer netA.Stop()
+	clientConfig := defaultConfig
+	clientConfig.GossipFanout = 1
+	clientConfig.EnableMessageCompression = clientCompression
+	netB := makeTestWebsocketNodeWithConfig(t, clientConfig)
+	addrA, postListen := netA.Address()
+	require.True(t, postListen)
+	netB.phonebook.ReplacePeerList([]string{addrA}, "default", PhoneBookEntryRelayRole)
+	netB.Start()
+	defer netB.Stop()
+
+	receivedA := make(chan []byte, 10)
+	receivedB := make(chan []byte, 10)
+	netA.RegisterHandlers([]TaggedMessageHandler{{Tag: protocol.TxnTag, MessageHandler: HandlerFunc(func(msg IncomingMessage) OutgoingMessage {
+		receivedA <- msg.Data
+		return OutgoingMessage{}
+	})}})
+	netB.RegisterHandlers([]TaggedMessageHandler{{Tag: protocol.TxnTag, MessageHandler: HandlerFunc(func(msg IncomingMessage) OutgoingMessage {
+		receivedB <- msg.Data
+		return OutgoingMessage{}
+	})}})
+
+	readyTimeout := time.NewTimer(2 * time.Second)
+	waitReady(t, netA, readyTimeout.C)
+	waitReady(t, netB, readyTimeout.C)
+
+	peersA, _ := netA.peerSnapshot(nil)
+	peersB, _ := netB.peerSnapshot(nil)
+	require.Len(t, peersA, 1)
+	require.Len(t, peersB, 1)
+	require.Equal(t, expectedCodec, peersA[0].compressionCodec)
+	require.Equal(t, expectedCodec, peersB[0].compressionCodec)
+
+	for _, payload := range [][]byte{[]byte("foo"), makeCompressionTestTxn()} {
+		require.NoError(t, netA.Broadcast(context.Background(), protocol.TxnTag, payload, true, nil))
+		require.NoError(t, netB.Broadcast(context.Background(), protocol.TxnTag, payload, true, nil))
+		for _, received := range []chan []byte{receivedA, receivedB} {
+			select {
+			case data := <-received:
+				require.Equal(t, payload, data)
+			case <-time.After(2 * time.Second):
+				require.Fail(t, "timeout waiting for the message")
+			}
+		}
+	}
+}
+
+func TestWebsocketNetworkCompression(t *testing.T) {
+	t.Run("both", func(t *testing.T) { testCompressionNegotiation(t, true, true, msgpDeflateCodec) })
+	t.Run("server only", func(t *testing.T) { testCompressionNegotiation(t, true, false, "") })
+	t.Run("client only", func(t *testing.T) { testCompressionNegotiation(t, false, true, "") })
+}
@@ -0,0 +1,119 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/util/metrics"
+)
+
+var networkInboundMessagesQueueDropped = metrics.MakeCounter(metrics.MetricName{Name: "algod_network_rx_queue_dropped_total", Description: "number of incoming messages dropped since their inbound queue was full, by tag"})
+
+// inboundPriority is the priority class of an incoming message. The messages of the lower classes are handled first.
+type inboundPriority int
+
+const (
+	inboundPriorityAgreement inboundPriority = iota
+	inboundPriorityProposal
+	inboundPriorityCompactCert
+	inboundPriorityOther
+	inboundPriorityTransaction
+
+	inboundPriorityCount
+)
+
+// tagInboundPriority returns the priority class of the messages with the given tag.
+func tagInboundPriority(tag protocol.Tag) inboundPriority {
+	switch tag {
+	case protocol.AgreementVoteTag, protocol.VoteBundleTag:
+		return inboundPriorityAgreement
+	case protocol.ProposalPayloadTag:
+		return inboundPriorityProposal
+	case protocol.CompactCertSigTag:
+		return inboundPriorityCompactCert
+	case protocol.TxnTag, protocol.TxnAnnounceTag:
+		return inboundPriorityTransaction
+	default:
+		return inboundPriorityOther
+	}
+}
+
+// inboundQueues holds the messages received from the peers until they're handled, in one queue per priority class,
+// so that a burst of transactions doesn't delay the agreement messages.
+type inboundQueues struct {
+	queues      [inboundPriorityCount]chan IncomingMessage
+	prioritized bool
+}
+
+// makeInboundQueues creates the inbound queues, each holding up to length messages. When prioritized isn't set, all
+// the messages share a single queue.
+func makeInboundQueues(length int, prioritized bool) *inboundQueues {
+	q := &inboundQueues{prioritized: prioritized}
+	for i := range q.queues {
+		q.queues[i] = make(chan IncomingMessage, length)
+	}
+	return q
+}
+
+// priority returns the priority class the messages with the given tag are queued with.
+func (q *inboundQueues) priority(tag protocol.Tag) inboundPriority {
+	if !q.prioritized {
+		return inboundPriorityTransaction
+	}
+	return tagInboundPriority(tag)
+}
+
+// droppable returns whether the messages of the given priority class are dropped when their queue is full, rather than
+// having the read loop of the peer wait for room. Transactions are droppable, so that a busy queue never holds back the
+// agreement messages received from the same peer; they would be gossiped again by the other peers. Like the other
+// messages, a peer only has up to msgsInReadBufferPerPeer droppable messages queued at a time, so that a flooding peer
+// can't take the queue from the others.
+func (q *inboundQueues) droppable(priority inboundPriority) bool {
+	return q.prioritized && priority == inboundPriorityTransaction
+}
+
+// tryQueue queues the given message without waiting, and returns false if the queue of its priority class is full.
+// The message holds one of the tokens of its peer, which bound the messages the peer has queued; the token is given
+// back right away when the message is dropped, and otherwise once the message handler gets to the message.
+func (q *inboundQueues) tryQueue(msg IncomingMessage, priority inboundPriority) bool {
+	select {
+	case q.queues[priority] <- msg:
+		return true
+	default:
+		if msg.processing != nil {
+			msg.processing <- struct{}{}
+		}
+		return false
+	}
+}
+
+// queue returns the queue of the messages with the given tag.
+func (q *inboundQueues) queue(tag protocol.Tag) chan IncomingMessage {
+	return q.queues[q.priority(tag)]
+}
+
+// dequeue returns the pending message of the highest priority, if any, without waiting.
+func (q *inboundQueues) dequeue() (IncomingMessage, bool) {
+	for _, queue := range q.queues {
+		select {
+		case msg := <-queue:
+			return msg, true
+		default:
+		}
+	}
+	return IncomingMessage{}, false
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/protocol"
+)
+
+func TestInboundQueuesPriority(t *testing.T) {
+	q := makeInboundQueues(10, true)
+	tags := []protocol.Tag{protocol.TxnTag, protocol.UniEnsBlockReqTag, protocol.CompactCertSigTag, protocol.ProposalPayloadTag, protocol.AgreementVoteTag, protocol.VoteBundleTag}
+	for _, tag := range tags {
+		q.queue(tag) <- IncomingMessage{Tag: tag}
+	}
+	// the agreement messages come first, in their order of arrival, and the transactions last.
+	for _, tag := range []protocol.Tag{protocol.AgreementVoteTag, protocol.VoteBundleTag, protocol.ProposalPayloadTag, protocol.CompactCertSigTag, protocol.UniEnsBlockReqTag, protocol.TxnTag} {
+		msg, ok := q.dequeue()
+		require.True(t, ok)
+		require.Equal(t, tag, msg.Tag)
+	}
+	_, ok := q.dequeue()
+	require.False(t, ok)
+
+	// without priorities, the messages are handled in their order of arrival.
+	q = makeInboundQueues(10, false)
+	for _, tag := range tags {
+		q.queue(tag) <- IncomingMessage{Tag: tag}
+	}
+	for _, tag := range tags {
+		msg, ok := q.dequeue()
+		require.True(t, ok)
+		require.Equal(t, tag, msg.Tag)
+	}
+}
+
+// queueFromPeer queues the message the way the read loop of a peer holding the given tokens does, and returns false
+// if the peer has no token left or if the queue is full.
+func queueFromPeer(q *inboundQueues, msg IncomingMessage, priority inboundPriority, peerTokens chan struct{}) bool {
+	select {
+	case <-peerTokens:
+	default:
+		return false
+	}
+	msg.processing = peerTokens
+	return q.tryQueue(msg, priority)
+}
+
+// makePeerTokens returns the message tokens of a peer, as wsPeer primes them.
+func makePeerTokens() chan struct{} {
+	tokens := make(chan struct{}, msgsInReadBufferPerPeer)
+	for i := 0; i < msgsInReadBufferPerPeer; i++ {
+		tokens <- struct{}{}
+	}
+	return tokens
+}
+
+func TestInboundQueuesDropTransactions(t *testing.T) {
+	q := makeInboundQueues(2*msgsInReadBufferPerPeer, true)
+	require.True(t, q.droppable(inboundPriorityTransaction))
+	require.False(t, q.droppable(inboundPriorityAgreement))
+	require.False(t, q.droppable(inboundPriorityOther))
+	require.False(t, makeInboundQueues(1, false).droppable(inboundPriorityTransaction))
+
+	// a peer only has its share of transactions queued at a time, whatever room is left in the queue.
+	flooder, honest, late := makePeerTokens(), makePeerTokens(), makePeerTokens()
+	for i := 0; i < msgsInReadBufferPerPeer; i++ {
+		require.True(t, queueFromPeer(q, IncomingMessage{Tag: protocol.TxnTag}, inboundPriorityTransaction, flooder))
+	}
+	require.False(t, queueFromPeer(q, IncomingMessage{Tag: protocol.TxnTag}, inboundPriorityTransaction, flooder))
+	for i := 0; i < msgsInReadBufferPerPeer; i++ {
+		require.True(t, queueFromPeer(q, IncomingMessage{Tag: protocol.TxnTag}, inboundPriorityTransaction, honest))
+	}
+
+	// the transactions are dropped once their queue is full, without affecting the other queues; the peer gets the
+	// token of a dropped message back.
+	require.False(t, queueFromPeer(q, IncomingMessage{Tag: protocol.TxnTag}, inboundPriorityTransaction, late))
+	require.Len(t, late, msgsInReadBufferPerPeer)
+	require.True(t, queueFromPeer(q, IncomingMessage{Tag: protocol.AgreementVoteTag}, inboundPriorityAgreement, makePeerTokens()))
+	msg, ok := q.dequeue()
+	require.True(t, ok)
+	require.Equal(t, protocol.AgreementVoteTag, msg.Tag)
+
+	// once a message of the flooder is handled, it gives the token back.
+	msg, ok = q.dequeue()
+	require.True(t, ok)
+	require.Equal(t, protocol.TxnTag, msg.Tag)
+	msg.processing <- struct{}{}
+	require.Len(t, flooder, 1)
+	require.True(t, queueFromPeer(q, IncomingMessage{Tag: protocol.TxnTag}, inboundPriorityTransaction, flooder))
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"time"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/util/metrics"
+)
+
+var networkInboundMessagesRateLimited = metrics.MakeCounter(metrics.MetricName{Name: "algod_network_rx_rate_limited_total", Description: "number of incoming messages dropped for exceeding the rate limit of their tag, by tag"})
+
+// inboundRateLimitLogInterval is the minimal interval between the logs of the messages a peer had dropped for exceeding the rate limits.
+const inboundRateLimitLogInterval = time.Minute
+
+// inboundRateLimit is the rate at which a peer may send the messages of a tag.
+type inboundRateLimit struct {
+	// rate is the number of messages per second.
+	rate float64
+	// burst is the number of messages a peer may send at once, after being idle.
+	burst float64
+}
+
+// makeInboundRateLimits returns the rate limits configured by InboundMessageRateLimits, by tag.
+func makeInboundRateLimits(cfg config.Local, log logging.Logger) map[protocol.Tag]inboundRateLimit {
+	if len(cfg.InboundMessageRateLimits) == 0 {
+		return nil
+	}
+	limits := make(map[protocol.Tag]inboundRateLimit, len(cfg.InboundMessageRateLimits))
+	for tag, rate := range cfg.InboundMessageRateLimits {
+		if len(tag) != 2 {
+			log.Warnf("ignoring the inbound rate limit of the invalid message tag %q", tag)
+			continue
+		}
+		burst := float64(rate * cfg.InboundMessageBurstSeconds)
+		if burst < 1 && rate > 0 {
+			burst = 1
+		}
+		limits[protocol.Tag(tag)] = inboundRateLimit{rate: float64(rate), burst: burst}
+	}
+	return limits
+}
+
+// tokenBucket allows an action at a steady rate, with bursts of up to the size of the bucket.
+type tokenBucket struct {
+	inboundRateLimit
+	tokens float64
+	last   time.Time
+}
+
+// take removes a token from the bucket, and returns false if there were none left.
+func (b *tokenBucket) take(now time.Time) bool {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// inboundRateLimiter limits the rate of the messages received from a peer, by tag. It's only used by the read loop of
+// the peer, and so isn't synchronized.
+type inboundRateLimiter struct {
+	buckets map[protocol.Tag]*tokenBucket
+	log     logging.Logger
+	// peer is the address of the peer in the logs.
+	peer string
+	// dropped is the number of messages dropped since the last log, which is written at lastLog.
+	dropped uint64
+	lastLog time.Time
+}
+
+// makeInboundRateLimiter returns the limiter of the messages of a peer, or nil if there are no limits.
+func makeInboundRateLimiter(limits map[protocol.Tag]inboundRateLimit, log logging.Logger, peer string, now time.Time) *inboundRateLimiter {
+	if len(limits) == 0 {
+		return nil
+	}
+	l := &inboundRateLimiter{buckets: make(map[protocol.Tag]*tokenBucket, len(limits)), log: log, peer: peer}
+	for tag, limit := range limits {
+		l.buckets[tag] = &tokenBucket{inboundRateLimit: limit, tokens: limit.burst, last: now}
+	}
+	return l
+}
+
+// allow returns whether a message with the given tag is within the limits, and counts the dropped ones otherwise. The
+// dropped messages are logged at most once every inboundRateLimitLogInterval.
+func (l *inboundRateLimiter) allow(tag protocol.Tag, now time.Time) bool {
+	if l == nil {
+		return true
+	}
+	bucket := l.buckets[tag]
+	if bucket == nil || bucket.take(now) {
+		return true
+	}
+	networkInboundMessagesRateLimited.Inc(map[string]string{"tag": string(tag)})
+	l.dropped++
+	if now.Sub(l.lastLog) >= inboundRateLimitLogInterval {
+		l.log.Infof("dropped %d incoming messages from peer %s for exceeding the rate limits, the latest with tag %s", l.dropped, l.peer, tag)
+		l.dropped = 0
+		l.lastLog = now
+	}
+	return false
+}
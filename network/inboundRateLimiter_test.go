@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+func TestInboundRateLimiter(t *testing.T) {
+	cfg := defaultConfig
+	cfg.InboundMessageRateLimits = map[string]uint64{"TX": 10, "PP": 0, "bad": 5}
+	cfg.InboundMessageBurstSeconds = 2
+	limits := makeInboundRateLimits(cfg, logging.TestingLog(t))
+	require.Len(t, limits, 2)
+
+	now := time.Now()
+	limiter := makeInboundRateLimiter(limits, logging.TestingLog(t), "peer", now)
+	// the bucket starts full.
+	for i := 0; i < 20; i++ {
+		require.True(t, limiter.allow(protocol.TxnTag, now))
+	}
+	require.False(t, limiter.allow(protocol.TxnTag, now))
+	// it refills at the limit rate.
+	now = now.Add(500 * time.Millisecond)
+	for i := 0; i < 5; i++ {
+		require.True(t, limiter.allow(protocol.TxnTag, now))
+	}
+	require.False(t, limiter.allow(protocol.TxnTag, now))
+	// and never over the burst size.
+	now = now.Add(time.Hour)
+	for i := 0; i < 20; i++ {
+		require.True(t, limiter.allow(protocol.TxnTag, now))
+	}
+	require.False(t, limiter.allow(protocol.TxnTag, now))
+
+	// a zero limit drops all the messages, and the tags which aren't listed aren't limited.
+	require.False(t, limiter.allow(protocol.ProposalPayloadTag, now))
+	for i := 0; i < 100; i++ {
+		require.True(t, limiter.allow(protocol.AgreementVoteTag, now))
+	}
+
+	// without limits, there's no limiter.
+	require.Nil(t, makeInboundRateLimiter(makeInboundRateLimits(defaultConfig, logging.TestingLog(t)), logging.TestingLog(t), "peer", now))
+	require.True(t, (*inboundRateLimiter)(nil).allow(protocol.TxnTag, now))
+}
+
+func TestWebsocketNetworkInboundRateLimit(t *testing.T) {
+	netA := makeTestWebsocketNode(t)
+	netA.Start()
+	defer netA.Stop()
+	conf := defaultConfig
+	conf.GossipFanout = 1
+	conf.InboundMessageRateLimits = map[string]uint64{"TX": 1}
+	conf.InboundMessageBurstSeconds = 3
+	netB := makeTestWebsocketNodeWithConfig(t, conf)
+	addrA, postListen := netA.Address()
+	require.True(t, postListen)
+	netB.phonebook.ReplacePeerList([]string{addrA}, "default", PhoneBookEntryRelayRole)
+	netB.Start()
+	defer netB.Stop()
+
+	readyTimeout := time.NewTimer(2 * time.Second)
+	waitReady(t, netA, readyTimeout.C)
+	waitReady(t, netB, readyTimeout.C)
+
+	received := make(chan protocol.Tag, 20)
+	handler := HandlerFunc(func(msg IncomingMessage) OutgoingMessage {
+		received <- msg.Tag
+		return OutgoingMessage{}
+	})
+	netB.RegisterHandlers([]TaggedMessageHandler{{Tag: protocol.TxnTag, MessageHandler: handler}, {Tag: protocol.ProposalPayloadTag, MessageHandler: handler}})
+	for i := 0; i < 10; i++ {
+		require.NoError(t, netA.Broadcast(context.Background(), protocol.TxnTag, []byte{byte(i)}, true, nil))
+	}
+	// the messages of the tags which aren't limited still get through.
+	require.NoError(t, netA.Broadcast(context.Background(), protocol.ProposalPayloadTag, []byte{0}, true, nil))
+
+	counts := make(map[protocol.Tag]int)
+	timeout := time.After(time.Second)
+	for done := false; !done; {
+		select {
+		case tag := <-received:
+			counts[tag]++
+		case <-timeout:
+			done = true
+		}
+	}
+	require.Equal(t, 1, counts[protocol.ProposalPayloadTag])
+	require.True(t, counts[protocol.TxnTag] >= 3 && counts[protocol.TxnTag] < 10, "%d transactions received", counts[protocol.TxnTag])
+}
@@ -0,0 +1,127 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/algorand/go-algorand/network/messagerecorder"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+var errReplayPeer = errors.New("replayed peers can't be sent requests")
+
+// replayExcludedTags are the tags of the messages handled by the network itself, which are bound to live connections
+// and so are never replayed.
+var replayExcludedTags = map[protocol.Tag]bool{
+	protocol.PingTag:            true,
+	protocol.PingReplyTag:       true,
+	protocol.NetPrioResponseTag: true,
+}
+
+// replayPeer stands for a recorded peer, as the sender of the messages it recorded. The messages sent to it are
+// discarded.
+type replayPeer struct {
+	name string
+}
+
+func (p *replayPeer) GetAddress() string {
+	return p.name
+}
+
+func (p *replayPeer) Unicast(ctx context.Context, data []byte, tag protocol.Tag) error {
+	return nil
+}
+
+func (p *replayPeer) Version() string {
+	return ProtocolVersion
+}
+
+func (p *replayPeer) Request(ctx context.Context, tag Tag, topics Topics) (resp *Response, e error) {
+	return nil, errReplayPeer
+}
+
+func (p *replayPeer) Respond(ctx context.Context, reqMsg IncomingMessage, topics Topics) (e error) {
+	return nil
+}
+
+// SetMessageRecordingDir sets the directory the incoming messages are recorded to. It only applies when the message
+// recording is enabled, and must be called before the network is started.
+func (wn *WebsocketNetwork) SetMessageRecordingDir(dir string) {
+	if !wn.config.EnableMessageRecording {
+		return
+	}
+	recorder, err := messagerecorder.MakeRecorder(dir, wn.config.MessageRecordingFileSize, int(wn.config.MessageRecordingFileCount), wn.log)
+	if err != nil {
+		wn.log.Warnf("unable to record the incoming messages to %s: %v", dir, err)
+		return
+	}
+	wn.recorder = recorder
+}
+
+// recordMessage records an incoming message, when the message recording is enabled.
+func (wn *WebsocketNetwork) recordMessage(msg IncomingMessage) {
+	if wn.recorder == nil {
+		return
+	}
+	var peerName string
+	switch peer := msg.Sender.(type) {
+	case *wsPeer:
+		if id, authenticated := peer.Identity(); authenticated {
+			peerName = id.String()
+		} else if peer.outgoing {
+			peerName = peer.GetAddress()
+		} else {
+			peerName = peer.OriginAddress()
+		}
+	case *replayPeer:
+		peerName = peer.name
+	}
+	wn.recorder.Record(messagerecorder.Message{Tag: msg.Tag, Peer: peerName, Received: msg.Received, Data: msg.Data})
+}
+
+// ReplayMessage hands a recorded message to the handlers, as if it was just received from the recorded peer; the
+// replies to the peer are discarded. Unlike the received messages, which are handled concurrently, the replayed
+// messages are handled one at a time, in the order they are replayed. The handlers still run alongside the timers
+// and the goroutines of the node, so a replay isn't guaranteed to take the same course as the recorded run.
+func (wn *WebsocketNetwork) ReplayMessage(msg messagerecorder.Message) {
+	if replayExcludedTags[msg.Tag] {
+		return
+	}
+	wn.replayPeersMu.Lock()
+	if wn.replayPeers == nil {
+		wn.replayPeers = make(map[string]*replayPeer)
+	}
+	peer := wn.replayPeers[msg.Peer]
+	if peer == nil {
+		peer = &replayPeer{name: msg.Peer}
+		wn.replayPeers[msg.Peer] = peer
+	}
+	wn.replayPeersMu.Unlock()
+
+	incoming := IncomingMessage{Sender: peer, Tag: msg.Tag, Data: msg.Data, Net: wn, Received: time.Now().UnixNano()}
+	wn.recordMessage(incoming)
+	outmsg := wn.handlers.Handle(incoming)
+	if outmsg.Action == Broadcast {
+		err := wn.Broadcast(wn.ctx, msg.Tag, msg.Data, false, peer)
+		if err != nil && err != errBcastQFull {
+			wn.log.Warnf("WebsocketNetwork.ReplayMessage: WebsocketNetwork.Broadcast returned unexpected error %v", err)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/network/messagerecorder"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+func TestWebsocketNetworkMessageRecordingAndReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "recordings")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	conf := defaultConfig
+	conf.EnableMessageRecording = true
+	conf.MessageRecordingFileSize = 1 << 20
+	conf.MessageRecordingFileCount = 2
+	netA := makeTestWebsocketNode(t)
+	netA.Start()
+	defer netA.Stop()
+	netB := makeTestWebsocketNodeWithConfig(t, conf)
+	netB.SetMessageRecordingDir(dir)
+	addrA, postListen := netA.Address()
+	require.True(t, postListen)
+	netB.phonebook.ReplacePeerList([]string{addrA}, "default", PhoneBookEntryRelayRole)
+	netB.Start()
+
+	readyTimeout := time.NewTimer(2 * time.Second)
+	waitReady(t, netA, readyTimeout.C)
+	waitReady(t, netB, readyTimeout.C)
+
+	received := make(chan []byte, 10)
+	netB.RegisterHandlers([]TaggedMessageHandler{{Tag: protocol.TxnTag, MessageHandler: HandlerFunc(func(msg IncomingMessage) OutgoingMessage {
+		received <- msg.Data
+		return OutgoingMessage{}
+	})}})
+	for _, data := range []string{"a", "b", "c"} {
+		require.NoError(t, netA.Broadcast(context.Background(), protocol.TxnTag, []byte(data), true, nil))
+		select {
+		case <-received:
+		case <-time.After(2 * time.Second):
+			require.Fail(t, "timeout waiting for the message")
+		}
+	}
+	// stopping the network writes the recording out.
+	netB.Stop()
+
+	reader, err := messagerecorder.OpenRecording(dir)
+	require.NoError(t, err)
+	defer reader.Close()
+	var recorded []messagerecorder.Message
+	for {
+		msg, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		if msg.Tag == protocol.TxnTag {
+			recorded = append(recorded, msg)
+		}
+	}
+	require.Len(t, recorded, 3)
+	for i, data := range []string{"a", "b", "c"} {
+		require.Equal(t, data, string(recorded[i].Data))
+		require.Equal(t, addrA, recorded[i].Peer)
+	}
+
+	// the recording replays into a network which isn't connected to any peer.
+	netC := makeTestWebsocketNode(t)
+	netC.Start()
+	defer netC.Stop()
+	var replayed []IncomingMessage
+	netC.RegisterHandlers([]TaggedMessageHandler{{Tag: protocol.TxnTag, MessageHandler: HandlerFunc(func(msg IncomingMessage) OutgoingMessage {
+		replayed = append(replayed, msg)
+		return OutgoingMessage{Action: Disconnect}
+	})}})
+	for _, msg := range recorded {
+		netC.ReplayMessage(msg)
+	}
+	netC.ReplayMessage(messagerecorder.Message{Tag: protocol.PingTag, Peer: addrA})
+	require.Len(t, replayed, 3)
+	for i, msg := range replayed {
+		require.Equal(t, recorded[i].Data, msg.Data)
+		require.Equal(t, replayed[0].Sender, msg.Sender)
+		require.Equal(t, addrA, msg.Sender.(UnicastPeer).GetAddress())
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package messagerecorder records the messages a node receives from its peers to rotating local files, and replays
+// the recordings, so that the behavior of a node can be investigated after the fact.
+package messagerecorder
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/util/metrics"
+)
+
+const (
+	// recordingFilePrefix and recordingFileSuffix surround the creation time of the recording files, so that the files
+	// sort in the order they were recorded.
+	recordingFilePrefix = "messages-"
+	recordingFileSuffix = ".rec"
+	recordingTimeFormat = "20060102T150405.000000000"
+
+	// recordHeaderSize is the size of the record length preceding every record.
+	recordHeaderSize = 4
+	// maxRecordSize is the maximal size of a record; larger records are considered corrupted.
+	maxRecordSize = 64 * 1024 * 1024
+
+	// recordQueueSize is the number of messages waiting to be written before the messages start being dropped. The
+	// dropped messages are counted in the recording.
+	recordQueueSize = 1024
+)
+
+var recordedMessagesDropped = metrics.MakeCounter(metrics.MetricName{Name: "algod_network_recorded_messages_dropped_total", Description: "incoming messages which couldn't be recorded because the recorder fell behind"})
+
+// Message is a recorded incoming message.
+type Message struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	// Tag is the tag of the message.
+	Tag protocol.Tag `codec:"t"`
+	// Peer identifies the peer the message was received from: its authenticated identity when it has one, or else
+	// its address.
+	Peer string `codec:"p"`
+	// Received is the time at which the message was received, as returned by time.Time.UnixNano().
+	Received int64 `codec:"r"`
+	// Data is the payload of the message.
+	Data []byte `codec:"d"`
+	// Dropped is the number of messages received right before this one which weren't recorded, as the recorder fell
+	// behind. A record with no Tag only reports the messages dropped at the end of a recording.
+	Dropped uint64 `codec:"x"`
+}
+
+// Recorder writes the recorded messages to files of a directory. Once a file reaches its maximal size, the recorder
+// moves on to a new file, and removes the oldest files in excess of the maximal number of files.
+type Recorder struct {
+	// dropped is the number of messages dropped since the last queued one. It's accessed atomically, and comes first
+	// to be 64-bit aligned.
+	dropped uint64
+
+	dir          string
+	maxFileSize  uint64
+	maxFileCount int
+	log          logging.Logger
+
+	queue chan Message
+	done  chan struct{}
+
+	// the current file, only accessed by the writing goroutine.
+	file     *os.File
+	writer   *bufio.Writer
+	fileSize uint64
+}
+
+// MakeRecorder creates a recorder writing to the given directory, creating the directory if needed.
+func MakeRecorder(dir string, maxFileSize uint64, maxFileCount int, log logging.Logger) (*Recorder, error) {
+	r, err := makeRecorder(dir, maxFileSize, maxFileCount, log)
+	if err != nil {
+		return nil, err
+	}
+	go r.writeThread()
+	return r, nil
+}
+
+// makeRecorder creates a recorder which doesn't write the queued messages yet.
+func makeRecorder(dir string, maxFileSize uint64, maxFileCount int, log logging.Logger) (*Recorder, error) {
+	if maxFileCount < 1 {
+		return nil, fmt.Errorf("MakeRecorder: invalid file count %d", maxFileCount)
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	r := &Recorder{
+		dir:          dir,
+		maxFileSize:  maxFileSize,
+		maxFileCount: maxFileCount,
+		log:          log,
+		queue:        make(chan Message, recordQueueSize),
+		done:         make(chan struct{}),
+	}
+	if err := r.rotate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Record queues a message to be recorded. It never blocks: when the recorder falls behind, the message is dropped,
+// and the count of the dropped messages is recorded along with the next queued message.
+func (r *Recorder) Record(msg Message) {
+	msg.Dropped = atomic.SwapUint64(&r.dropped, 0)
+	select {
+	case r.queue <- msg:
+	default:
+		atomic.AddUint64(&r.dropped, msg.Dropped+1)
+		recordedMessagesDropped.Inc(nil)
+	}
+}
+
+// Close writes the queued messages and closes the current file. No message may be recorded once it's called.
+func (r *Recorder) Close() {
+	close(r.queue)
+	<-r.done
+}
+
+func (r *Recorder) writeThread() {
+	defer close(r.done)
+	for msg := range r.queue {
+		r.write(msg)
+		// only flush once there is nothing left to write, so that bursts of messages are written at once.
+		if len(r.queue) == 0 {
+			r.flush()
+		}
+	}
+	if dropped := atomic.SwapUint64(&r.dropped, 0); dropped > 0 {
+		r.write(Message{Received: time.Now().UnixNano(), Dropped: dropped})
+	}
+	r.closeFile()
+}
+
+func (r *Recorder) write(msg Message) {
+	if r.writer == nil {
+		return
+	}
+	record := protocol.EncodeReflect(&msg)
+	var header [recordHeaderSize]byte
+	binary.BigEndian.PutUint32(header[:], uint32(len(record)))
+	r.writer.Write(header[:])
+	r.writer.Write(record)
+	r.fileSize += uint64(len(header) + len(record))
+	if r.fileSize >= r.maxFileSize {
+		if err := r.rotate(); err != nil {
+			r.log.Warnf("Recorder: unable to start a new recording file in %s: %v", r.dir, err)
+		}
+	}
+}
+
+func (r *Recorder) flush() {
+	if r.writer == nil {
+		return
+	}
+	if err := r.writer.Flush(); err != nil {
+		r.log.Warnf("Recorder: unable to write to %s: %v", r.file.Name(), err)
+	}
+}
+
+func (r *Recorder) closeFile() {
+	if r.file == nil {
+		return
+	}
+	r.flush()
+	r.file.Close()
+	r.file = nil
+	r.writer = nil
+}
+
+// rotate closes the current file, starts a new one, and removes the oldest files in excess.
+func (r *Recorder) rotate() error {
+	r.closeFile()
+	name := recordingFilePrefix + time.Now().UTC().Format(recordingTimeFormat) + recordingFileSuffix
+	file, err := os.OpenFile(filepath.Join(r.dir, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	r.file = file
+	r.writer = bufio.NewWriter(file)
+	r.fileSize = 0
+
+	files, err := RecordingFiles(r.dir)
+	if err != nil {
+		return err
+	}
+	for len(files) > r.maxFileCount {
+		if err := os.Remove(files[0]); err != nil {
+			return err
+		}
+		files = files[1:]
+	}
+	return nil
+}
+
+// RecordingFiles returns the recording files of the given directory, in the order they were recorded. Given a
+// recording file rather than a directory, it returns that file.
+func RecordingFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+	dir, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer dir.Close()
+	names, err := dir.Readdirnames(-1)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, name := range names {
+		if strings.HasPrefix(name, recordingFilePrefix) && strings.HasSuffix(name, recordingFileSuffix) {
+			files = append(files, filepath.Join(path, name))
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
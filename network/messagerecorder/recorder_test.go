@@ -0,0 +1,187 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package messagerecorder
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+func readAll(t *testing.T, path string) (msgs []Message) {
+	reader, err := OpenRecording(path)
+	require.NoError(t, err)
+	defer reader.Close()
+	for {
+		msg, err := reader.Next()
+		if err == io.EOF {
+			return
+		}
+		require.NoError(t, err)
+		msgs = append(msgs, msg)
+	}
+}
+
+func TestRecordAndRotate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "messagerecorder")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	recorder, err := MakeRecorder(dir, 1000, 3, logging.TestingLog(t))
+	require.NoError(t, err)
+	var recorded []Message
+	for i := 0; i < 100; i++ {
+		msg := Message{Tag: protocol.TxnTag, Peer: fmt.Sprintf("peer%d", i%3), Received: int64(i), Data: make([]byte, 100)}
+		msg.Data[0] = byte(i)
+		recorder.Record(msg)
+		recorded = append(recorded, msg)
+		// give the recorder the time to write, so that no message is dropped, and the files get distinct names.
+		time.Sleep(time.Millisecond)
+	}
+	recorder.Close()
+
+	// only the most recent files are kept.
+	files, err := RecordingFiles(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+	msgs := readAll(t, dir)
+	require.NotEmpty(t, msgs)
+	require.True(t, len(msgs) < len(recorded))
+	require.Equal(t, recorded[len(recorded)-len(msgs):], msgs)
+
+	// a single file can be read as well.
+	require.Equal(t, msgs[len(msgs)-len(readAll(t, files[2])):], readAll(t, files[2]))
+
+	// a truncated record at the end of a file is skipped.
+	info, err := os.Stat(files[0])
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(files[0], info.Size()-10))
+	truncated := readAll(t, dir)
+	require.Equal(t, len(msgs)-1, len(truncated))
+}
+
+func TestReplayTiming(t *testing.T) {
+	dir, err := ioutil.TempDir("", "messagerecorder")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	recorder, err := MakeRecorder(dir, 1<<20, 1, logging.TestingLog(t))
+	require.NoError(t, err)
+	base := time.Now().UnixNano()
+	for i := 0; i < 5; i++ {
+		recorder.Record(Message{Tag: protocol.AgreementVoteTag, Peer: "peer", Received: base + int64(i)*int64(100*time.Millisecond)})
+	}
+	recorder.Close()
+
+	replay := func(speed float64) (time.Duration, int) {
+		reader, err := OpenRecording(dir)
+		require.NoError(t, err)
+		defer reader.Close()
+		var delivered int
+		start := time.Now()
+		count, dropped, err := Replay(context.Background(), reader, speed, func(msg Message) {
+			require.Equal(t, base+int64(delivered)*int64(100*time.Millisecond), msg.Received)
+			delivered++
+		})
+		require.NoError(t, err)
+		require.Equal(t, delivered, count)
+		require.Zero(t, dropped)
+		return time.Since(start), count
+	}
+
+	elapsed, count := replay(1)
+	require.Equal(t, 5, count)
+	require.True(t, elapsed >= 400*time.Millisecond)
+	elapsed, _ = replay(10)
+	require.True(t, elapsed >= 40*time.Millisecond && elapsed < 400*time.Millisecond)
+	elapsed, _ = replay(0)
+	require.True(t, elapsed < 40*time.Millisecond)
+
+	// the replay stops once its context is done.
+	reader, err := OpenRecording(dir)
+	require.NoError(t, err)
+	defer reader.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	count, _, err = Replay(ctx, reader, 1, func(msg Message) {
+		cancel()
+	})
+	require.Equal(t, context.Canceled, err)
+	require.Equal(t, 1, count)
+}
+
+func TestRecordDrops(t *testing.T) {
+	// fillQueue records messages until the queue of the recorder, which isn't writing yet, is full, and then records
+	// the given number of dropped messages.
+	fillQueue := func(recorder *Recorder, dropped int) {
+		for i := 0; i < recordQueueSize+dropped; i++ {
+			recorder.Record(Message{Tag: protocol.TxnTag, Peer: "peer", Received: int64(i)})
+		}
+	}
+	replay := func(dir string) (msgs []Message, dropped uint64) {
+		reader, err := OpenRecording(dir)
+		require.NoError(t, err)
+		defer reader.Close()
+		count, dropped, err := Replay(context.Background(), reader, 0, func(msg Message) {
+			msgs = append(msgs, msg)
+		})
+		require.NoError(t, err)
+		require.Len(t, msgs, count)
+		return
+	}
+
+	// the dropped messages are counted along with the next recorded message.
+	dir, err := ioutil.TempDir("", "messagerecorder")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	recorder, err := makeRecorder(dir, 1<<20, 1, logging.TestingLog(t))
+	require.NoError(t, err)
+	fillQueue(recorder, 3)
+	go recorder.writeThread()
+	require.Eventually(t, func() bool { return len(recorder.queue) == 0 }, 5*time.Second, time.Millisecond)
+	recorder.Record(Message{Tag: protocol.AgreementVoteTag, Peer: "peer"})
+	recorder.Close()
+	msgs, dropped := replay(dir)
+	require.Len(t, msgs, recordQueueSize+1)
+	require.Zero(t, msgs[recordQueueSize-1].Dropped)
+	require.Equal(t, uint64(3), msgs[recordQueueSize].Dropped)
+	require.Equal(t, uint64(3), dropped)
+
+	// the messages dropped at the end of a recording are counted as well, by a record of their own.
+	dir, err = ioutil.TempDir("", "messagerecorder")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	recorder, err = makeRecorder(dir, 1<<20, 1, logging.TestingLog(t))
+	require.NoError(t, err)
+	fillQueue(recorder, 2)
+	go recorder.writeThread()
+	recorder.Close()
+	records := readAll(t, dir)
+	require.Len(t, records, recordQueueSize+1)
+	require.Equal(t, Message{Received: records[recordQueueSize].Received, Dropped: 2}, records[recordQueueSize])
+	msgs, dropped = replay(dir)
+	require.Len(t, msgs, recordQueueSize)
+	require.Equal(t, uint64(2), dropped)
+}
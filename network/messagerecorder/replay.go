@@ -0,0 +1,155 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package messagerecorder
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/algorand/go-algorand/protocol"
+)
+
+var errTruncatedRecord = errors.New("truncated record")
+
+// Reader reads the messages of a sequence of recording files.
+type Reader struct {
+	files  []string
+	file   *os.File
+	reader *bufio.Reader
+}
+
+// OpenRecording returns a reader of the messages recorded in the given recording file, or in all the recording files
+// of the given directory.
+func OpenRecording(path string) (*Reader, error) {
+	files, err := RecordingFiles(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Reader{files: files}, nil
+}
+
+// Next returns the next recorded message, or io.EOF once all the messages were read. A truncated record at the end
+// of a file, as left behind by a node which stopped abruptly, is skipped.
+func (r *Reader) Next() (msg Message, err error) {
+	for {
+		if r.reader == nil {
+			if len(r.files) == 0 {
+				return msg, io.EOF
+			}
+			r.file, err = os.Open(r.files[0])
+			if err != nil {
+				return
+			}
+			r.files = r.files[1:]
+			r.reader = bufio.NewReader(r.file)
+		}
+
+		msg, err = r.readRecord()
+		if err == nil {
+			return
+		}
+		if err != io.EOF && err != errTruncatedRecord {
+			return
+		}
+		r.closeFile()
+	}
+}
+
+func (r *Reader) readRecord() (msg Message, err error) {
+	var header [recordHeaderSize]byte
+	n, err := io.ReadFull(r.reader, header[:])
+	if err == io.ErrUnexpectedEOF || (err == io.EOF && n > 0) {
+		return msg, errTruncatedRecord
+	}
+	if err != nil {
+		return
+	}
+	size := binary.BigEndian.Uint32(header[:])
+	if size > maxRecordSize {
+		return msg, fmt.Errorf("record of %d bytes in %s", size, r.file.Name())
+	}
+	record := make([]byte, size)
+	if _, err = io.ReadFull(r.reader, record); err != nil {
+		return msg, errTruncatedRecord
+	}
+	err = protocol.DecodeReflect(record, &msg)
+	return
+}
+
+func (r *Reader) closeFile() {
+	if r.file != nil {
+		r.file.Close()
+	}
+	r.file = nil
+	r.reader = nil
+}
+
+// Close closes the reader.
+func (r *Reader) Close() {
+	r.closeFile()
+	r.files = nil
+}
+
+// Replay reads all the messages of the reader, and delivers them one at a time. With a positive speed, the messages
+// are delivered with the same spacing as they were received, divided by the speed; so a speed of 1 reproduces the
+// original timing, and a speed of 10 replays ten times faster. With a zero speed, the messages are delivered as fast
+// as possible. It returns the number of delivered messages, and the number of messages missing from the recording
+// as the recorder fell behind.
+func Replay(ctx context.Context, r *Reader, speed float64, deliver func(Message)) (count int, dropped uint64, err error) {
+	var firstReceived int64
+	var start time.Time
+	for {
+		var msg Message
+		msg, err = r.Next()
+		if err == io.EOF {
+			return count, dropped, nil
+		}
+		if err != nil {
+			return
+		}
+		dropped += msg.Dropped
+		if msg.Tag == "" {
+			continue
+		}
+
+		if count == 0 {
+			firstReceived = msg.Received
+			start = time.Now()
+		}
+		if speed > 0 {
+			offset := time.Duration(float64(msg.Received-firstReceived) / speed)
+			if wait := time.Until(start.Add(offset)); wait > 0 {
+				select {
+				case <-time.After(wait):
+				case <-ctx.Done():
+					return count, dropped, ctx.Err()
+				}
+			}
+		}
+		if ctx.Err() != nil {
+			return count, dropped, ctx.Err()
+		}
+		deliver(msg)
+		count++
+	}
+}
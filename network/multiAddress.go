@@ -0,0 +1,215 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/algorand/go-deadlock"
+)
+
+// A relay may listen on several addresses, e.g. IPv4 and IPv6 ones, or public and private ones. It advertises all
+// of its public addresses to its peers, so that the nodes which are connected to the relay through one of them don't
+// connect to it again through another.
+
+var errMultiListenerClosed = errors.New("listener closed")
+
+// unreachableFamilyFailures is the number of consecutive failed connection attempts to the addresses of an IP family
+// after which the family is considered unreachable.
+const unreachableFamilyFailures = 3
+
+// multiListener accepts the connections of several listeners, as a single listener.
+type multiListener struct {
+	listeners []net.Listener
+	accepted  chan acceptResult
+	closing   chan struct{}
+	closeOnce sync.Once
+}
+
+type acceptResult struct {
+	conn net.Conn
+	err  error
+}
+
+// makeMultiListener returns a listener accepting the connections of all the given listeners. Its address is the one
+// of the first listener.
+func makeMultiListener(listeners []net.Listener) net.Listener {
+	if len(listeners) == 1 {
+		return listeners[0]
+	}
+	ml := &multiListener{
+		listeners: listeners,
+		accepted:  make(chan acceptResult),
+		closing:   make(chan struct{}),
+	}
+	for _, listener := range listeners {
+		go ml.acceptLoop(listener)
+	}
+	return ml
+}
+
+func (ml *multiListener) acceptLoop(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		select {
+		case ml.accepted <- acceptResult{conn: conn, err: err}:
+		case <-ml.closing:
+			if conn != nil {
+				conn.Close()
+			}
+			return
+		}
+		if err != nil {
+			if netErr, ok := err.(net.Error); ok && netErr.Temporary() {
+				continue
+			}
+			return
+		}
+	}
+}
+
+// Accept waits for the next connection on any of the listeners.
+func (ml *multiListener) Accept() (net.Conn, error) {
+	select {
+	case result := <-ml.accepted:
+		return result.conn, result.err
+	case <-ml.closing:
+		return nil, errMultiListenerClosed
+	}
+}
+
+// Close closes all the listeners.
+func (ml *multiListener) Close() (err error) {
+	ml.closeOnce.Do(func() {
+		close(ml.closing)
+		for _, listener := range ml.listeners {
+			if closeErr := listener.Close(); closeErr != nil && err == nil {
+				err = closeErr
+			}
+		}
+	})
+	return
+}
+
+// Addr returns the address of the first listener.
+func (ml *multiListener) Addr() net.Addr {
+	return ml.listeners[0].Addr()
+}
+
+// sortedAddresses returns the addresses of an address set of the config, sorted.
+func sortedAddresses(addresses map[string]bool) []string {
+	sorted := make([]string, 0, len(addresses))
+	for address, enabled := range addresses {
+		if enabled && address != "" {
+			sorted = append(sorted, address)
+		}
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// addressKey returns the host and port of a peer address, which is either a "host:port" or a URL, so that the
+// different forms of an address compare equal.
+func addressKey(addr string) string {
+	parsedURL, err := ParseHostOrURL(addr)
+	if err != nil {
+		return addr
+	}
+	return strings.ToLower(parsedURL.Host)
+}
+
+// addressFamily returns the IP family, "ipv4" or "ipv6", of the host of a peer address, or "" when the host is a
+// name rather than an IP address.
+func addressFamily(addr string) string {
+	ip := net.ParseIP(strings.Trim(addrHost(addr), "[]"))
+	switch {
+	case ip == nil:
+		return ""
+	case ip.To4() != nil:
+		return "ipv4"
+	default:
+		return "ipv6"
+	}
+}
+
+// connectionLimitHost returns the host the connections of a remote host are counted under, given the
+// ConnectionsIPv6PrefixLength. The IPv4 hosts, including the IPv4-mapped IPv6 ones, are counted by address, and the
+// IPv6 hosts by the prefix of the given length. The host names are returned as they are.
+func connectionLimitHost(host string, ipv6PrefixLength uint64) string {
+	ip := net.ParseIP(strings.Trim(host, "[]"))
+	if ip == nil {
+		return host
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4.String()
+	}
+	if ipv6PrefixLength == 0 || ipv6PrefixLength >= 128 {
+		return ip.String()
+	}
+	return fmt.Sprintf("%s/%d", ip.Mask(net.CIDRMask(int(ipv6PrefixLength), 128)), ipv6PrefixLength)
+}
+
+// addressFamilyTracker tracks over which IP families the node manages to connect to its peers, so that the addresses
+// of the families which keep failing, e.g. IPv6 on a host without IPv6 connectivity, are tried last. The addresses
+// with a host name are left to the dialer, which tries all the families of the name.
+type addressFamilyTracker struct {
+	mu       deadlock.Mutex
+	failures map[string]int
+}
+
+func makeAddressFamilyTracker() *addressFamilyTracker {
+	return &addressFamilyTracker{failures: make(map[string]int)}
+}
+
+// recordConnectionAttempt records whether connecting to an address succeeded.
+func (t *addressFamilyTracker) recordConnectionAttempt(addr string, connected bool) {
+	family := addressFamily(addr)
+	if family == "" {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if connected {
+		delete(t.failures, family)
+	} else {
+		t.failures[family]++
+	}
+}
+
+// prefer reorders the addresses so that the ones of the unreachable families come last, keeping the order of the
+// others.
+func (t *addressFamilyTracker) prefer(addrs []string) {
+	t.mu.Lock()
+	unreachable := make(map[string]bool, len(addrs))
+	for _, addr := range addrs {
+		if family := addressFamily(addr); family != "" && t.failures[family] >= unreachableFamilyFailures {
+			unreachable[addr] = true
+		}
+	}
+	t.mu.Unlock()
+	if len(unreachable) == 0 {
+		return
+	}
+	sort.SliceStable(addrs, func(i, j int) bool {
+		return !unreachable[addrs[i]] && unreachable[addrs[j]]
+	})
+}
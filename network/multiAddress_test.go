@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectionLimitHost(t *testing.T) {
+	require.Equal(t, "10.0.0.1", connectionLimitHost("10.0.0.1", 128))
+	require.Equal(t, "10.0.0.1", connectionLimitHost("::ffff:10.0.0.1", 64))
+	require.Equal(t, "relay.example.com", connectionLimitHost("relay.example.com", 64))
+
+	// the IPv6 addresses are counted one by one by default.
+	require.Equal(t, "2001:db8:1:2::1", connectionLimitHost("[2001:db8:1:2:0:0:0:1]", 128))
+	require.NotEqual(t, connectionLimitHost("2001:db8:1:2::1", 128), connectionLimitHost("2001:db8:1:2::2", 128))
+	require.Equal(t, connectionLimitHost("2001:db8:1:2::1", 0), connectionLimitHost("2001:db8:1:2::1", 128))
+	require.Equal(t, connectionLimitHost("2001:db8:1:2::1", 200), connectionLimitHost("2001:db8:1:2::1", 128))
+
+	// or by prefix, when configured so.
+	require.Equal(t, "2001:db8:1:2::/64", connectionLimitHost("2001:db8:1:2::1", 64))
+	require.Equal(t, "2001:db8:1:2::/64", connectionLimitHost("[2001:db8:1:2:aaaa:bbbb:cccc:dddd]", 64))
+	require.NotEqual(t, connectionLimitHost("2001:db8:1:2::1", 64), connectionLimitHost("2001:db8:1:3::1", 64))
+	require.Equal(t, "2001:db8::/48", connectionLimitHost("2001:db8:0:3::1", 48))
+}
+
+func TestAddressFamilyTracker(t *testing.T) {
+	require.Equal(t, "ipv4", addressFamily("10.0.0.1:4160"))
+	require.Equal(t, "ipv6", addressFamily("[2001:db8::1]:4160"))
+	require.Equal(t, "ipv6", addressFamily("http://[2001:db8::1]:4160"))
+	require.Equal(t, "", addressFamily("relay.example.com:4160"))
+	require.Equal(t, "relay.example.com:4160", addressKey("http://Relay.example.com:4160"))
+
+	tracker := makeAddressFamilyTracker()
+	addrs := []string{"[2001:db8::1]:4160", "10.0.0.1:4160", "[2001:db8::2]:4160", "relay.example.com:4160"}
+	for i := 0; i < unreachableFamilyFailures; i++ {
+		tracker.recordConnectionAttempt("[2001:db8::3]:4160", false)
+	}
+	tracker.recordConnectionAttempt("10.0.0.2:4160", false)
+	tracker.prefer(addrs)
+	require.Equal(t, []string{"10.0.0.1:4160", "relay.example.com:4160", "[2001:db8::1]:4160", "[2001:db8::2]:4160"}, addrs)
+
+	// a single successful connection makes the family reachable again.
+	tracker.recordConnectionAttempt("[2001:db8::3]:4160", true)
+	addrs = []string{"[2001:db8::1]:4160", "10.0.0.1:4160"}
+	tracker.prefer(addrs)
+	require.Equal(t, []string{"[2001:db8::1]:4160", "10.0.0.1:4160"}, addrs)
+}
+
+func TestWebsocketNetworkMultipleListenAddresses(t *testing.T) {
+	relayConfig := defaultConfig
+	relayConfig.AdditionalNetAddresses = map[string]bool{"localhost:0": true}
+	netA := makeTestWebsocketNodeWithConfig(t, relayConfig)
+	netA.Start()
+	defer netA.Stop()
+	require.Len(t, netA.listenAddresses, 2)
+	require.Len(t, netA.PublicAddresses(), 2)
+	addr1, addr2 := netA.listenAddresses[0], netA.listenAddresses[1]
+
+	clientConfig := defaultConfig
+	clientConfig.GossipFanout = 2
+	netB := makeTestWebsocketNodeWithConfig(t, clientConfig)
+	netB.phonebook.ReplacePeerList([]string{addr1}, "default", PhoneBookEntryRelayRole)
+	netB.Start()
+	defer netB.Stop()
+	require.Eventually(t, func() bool { return netB.numOutgoingPeers() == 1 }, 2*time.Second, 10*time.Millisecond)
+
+	// the other address of the relay isn't connected to, since the relay advertised it.
+	netB.phonebook.ReplacePeerList([]string{addr1, addr2}, "default", PhoneBookEntryRelayRole)
+	netB.RequestConnectOutgoing(false, nil)
+	time.Sleep(500 * time.Millisecond)
+	require.Equal(t, 1, netB.numOutgoingPeers())
+
+	// and connecting to it anyway is detected as connecting to the same relay.
+	gossipAddr, ok := netB.tryConnectReserveAddr(addr2)
+	require.True(t, ok)
+	netB.wg.Add(1)
+	netB.tryConnect(addr2, gossipAddr)
+	require.Equal(t, 1, netB.numOutgoingPeers())
+
+	// the relay accepts connections on all of its addresses.
+	netC := makeTestWebsocketNode(t)
+	netC.config.GossipFanout = 1
+	netC.phonebook.ReplacePeerList([]string{addr2}, "default", PhoneBookEntryRelayRole)
+	netC.Start()
+	defer netC.Stop()
+	require.Eventually(t, func() bool { return netC.numOutgoingPeers() == 1 }, 2*time.Second, 10*time.Millisecond)
+	peersC := netC.GetPeers(PeersConnectedOut)
+	_, port2, _ := net.SplitHostPort(addr2)
+	_, port, _ := net.SplitHostPort(peersC[0].(*wsPeer).conn.RemoteAddr().String())
+	require.Equal(t, port2, port)
+	require.Equal(t, 2, netA.NumPeers())
+}
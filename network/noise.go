@@ -0,0 +1,341 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+)
+
+// The encrypted handshake of the gossip connections follows the XX pattern of the Noise protocol framework
+// ( https://noiseprotocol.org/noise.html ):
+//   -> e
+//   <- e, ee, s, es
+//   -> s, se
+// Both peers learn and authenticate the static key of the other, which is its long-term identity, and derive the keys
+// encrypting the messages of the connection in each direction.
+
+// noiseProtocolName is the full name of the Noise protocol in use; it's also the value of the EncryptionHeader.
+const noiseProtocolName = "Noise_XX_25519_ChaChaPoly_SHA256"
+
+const noiseKeyLength = 32
+
+var errNoiseMessageTooShort = errors.New("noise handshake message too short")
+var errNoiseNonceExhausted = errors.New("noise cipher nonce exhausted")
+
+// noiseKeyPair is a Curve25519 key pair.
+type noiseKeyPair struct {
+	private [noiseKeyLength]byte
+	public  PeerIdentity
+}
+
+func makeNoiseKeyPair(private [noiseKeyLength]byte) (kp noiseKeyPair, err error) {
+	public, err := curve25519.X25519(private[:], curve25519.Basepoint)
+	if err != nil {
+		return
+	}
+	kp.private = private
+	copy(kp.public[:], public)
+	return
+}
+
+func generateNoiseKeyPair() (noiseKeyPair, error) {
+	var private [noiseKeyLength]byte
+	if _, err := io.ReadFull(rand.Reader, private[:]); err != nil {
+		return noiseKeyPair{}, err
+	}
+	return makeNoiseKeyPair(private)
+}
+
+func (kp noiseKeyPair) dh(public []byte) ([]byte, error) {
+	return curve25519.X25519(kp.private[:], public)
+}
+
+// noiseCipherState encrypts or decrypts the messages in one direction, with a counter as nonce.
+type noiseCipherState struct {
+	aead  cipher.AEAD
+	nonce uint64
+}
+
+func (c *noiseCipherState) initializeKey(key []byte) {
+	// chacha20poly1305.New only fails on a key of the wrong length.
+	c.aead, _ = chacha20poly1305.New(key[:chacha20poly1305.KeySize])
+	c.nonce = 0
+}
+
+func (c *noiseCipherState) nonceBytes() []byte {
+	var nonce [chacha20poly1305.NonceSize]byte
+	binary.LittleEndian.PutUint64(nonce[4:], c.nonce)
+	return nonce[:]
+}
+
+// encrypt encrypts the plaintext, authenticating the additional data as well. Before a key is set, the plaintext is
+// returned as is.
+func (c *noiseCipherState) encrypt(ad, plaintext []byte) ([]byte, error) {
+	if c.aead == nil {
+		return append([]byte(nil), plaintext...), nil
+	}
+	if c.nonce == ^uint64(0) {
+		return nil, errNoiseNonceExhausted
+	}
+	ciphertext := c.aead.Seal(nil, c.nonceBytes(), plaintext, ad)
+	c.nonce++
+	return ciphertext, nil
+}
+
+// decrypt decrypts the ciphertext, verifying the additional data as well. Before a key is set, the ciphertext is
+// returned as is.
+func (c *noiseCipherState) decrypt(ad, ciphertext []byte) ([]byte, error) {
+	if c.aead == nil {
+		return append([]byte(nil), ciphertext...), nil
+	}
+	if c.nonce == ^uint64(0) {
+		return nil, errNoiseNonceExhausted
+	}
+	plaintext, err := c.aead.Open(nil, c.nonceBytes(), ciphertext, ad)
+	if err != nil {
+		return nil, err
+	}
+	c.nonce++
+	return plaintext, nil
+}
+
+// overhead is the number of bytes encryption adds to a message.
+func (c *noiseCipherState) overhead() int {
+	if c.aead == nil {
+		return 0
+	}
+	return c.aead.Overhead()
+}
+
+// noiseSymmetricState is the chaining key and the handshake hash of a handshake in progress.
+type noiseSymmetricState struct {
+	cipher noiseCipherState
+	ck     [sha256.Size]byte
+	h      [sha256.Size]byte
+}
+
+func makeNoiseSymmetricState(prologue []byte) *noiseSymmetricState {
+	s := &noiseSymmetricState{}
+	// the protocol name is exactly as long as the hash, and so is used as is.
+	copy(s.h[:], noiseProtocolName)
+	s.ck = s.h
+	s.mixHash(prologue)
+	return s
+}
+
+func (s *noiseSymmetricState) mixHash(data []byte) {
+	hash := sha256.New()
+	hash.Write(s.h[:])
+	hash.Write(data)
+	hash.Sum(s.h[:0])
+}
+
+// noiseHKDF derives two keys from the chaining key and the input key material.
+func noiseHKDF(ck []byte, ikm []byte) (out1, out2 [sha256.Size]byte) {
+	reader := hkdf.New(sha256.New, ikm, ck, nil)
+	// reading up to 255 hashes from the hkdf reader can't fail.
+	io.ReadFull(reader, out1[:])
+	io.ReadFull(reader, out2[:])
+	return
+}
+
+func (s *noiseSymmetricState) mixKey(ikm []byte) {
+	var key [sha256.Size]byte
+	s.ck, key = noiseHKDF(s.ck[:], ikm)
+	s.cipher.initializeKey(key[:])
+}
+
+func (s *noiseSymmetricState) encryptAndHash(plaintext []byte) ([]byte, error) {
+	ciphertext, err := s.cipher.encrypt(s.h[:], plaintext)
+	if err != nil {
+		return nil, err
+	}
+	s.mixHash(ciphertext)
+	return ciphertext, nil
+}
+
+func (s *noiseSymmetricState) decryptAndHash(ciphertext []byte) ([]byte, error) {
+	plaintext, err := s.cipher.decrypt(s.h[:], ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	s.mixHash(ciphertext)
+	return plaintext, nil
+}
+
+// split returns the cipher states of the messages sent by the initiator, and of the ones sent by the responder.
+func (s *noiseSymmetricState) split() (initiatorCipher, responderCipher *noiseCipherState) {
+	k1, k2 := noiseHKDF(s.ck[:], nil)
+	initiatorCipher = &noiseCipherState{}
+	initiatorCipher.initializeKey(k1[:])
+	responderCipher = &noiseCipherState{}
+	responderCipher.initializeKey(k2[:])
+	return
+}
+
+// noiseMessageTransport carries the handshake messages.
+type noiseMessageTransport interface {
+	sendHandshakeMessage([]byte) error
+	receiveHandshakeMessage() ([]byte, error)
+}
+
+// noiseHandshake runs the handshake with the peer over the transport, and returns the cipher states of the sent and
+// of the received messages, along with the authenticated static key of the peer. The prologue is data both peers
+// already agree on, which the handshake binds to.
+func noiseHandshake(transport noiseMessageTransport, static noiseKeyPair, prologue []byte, initiator bool) (send, receive *noiseCipherState, remote PeerIdentity, err error) {
+	s := makeNoiseSymmetricState(prologue)
+	ephemeral, err := generateNoiseKeyPair()
+	if err != nil {
+		return
+	}
+	var remoteEphemeral []byte
+
+	// writeMessage appends the encrypted payload, hashing it into the handshake state.
+	writeMessage := func(msg []byte) error {
+		payload, err := s.encryptAndHash(nil)
+		if err != nil {
+			return err
+		}
+		return transport.sendHandshakeMessage(append(msg, payload...))
+	}
+	readPayload := func(msg []byte) error {
+		_, err := s.decryptAndHash(msg)
+		return err
+	}
+	mixDH := func(kp noiseKeyPair, public []byte) error {
+		shared, err := kp.dh(public)
+		if err != nil {
+			return err
+		}
+		s.mixKey(shared)
+		return nil
+	}
+	encryptStatic := func() ([]byte, error) {
+		return s.encryptAndHash(static.public[:])
+	}
+	decryptStatic := func(msg []byte) ([]byte, error) {
+		length := noiseKeyLength + s.cipher.overhead()
+		if len(msg) < length {
+			return nil, errNoiseMessageTooShort
+		}
+		plaintext, err := s.decryptAndHash(msg[:length])
+		if err != nil {
+			return nil, err
+		}
+		copy(remote[:], plaintext)
+		return msg[length:], nil
+	}
+	readEphemeral := func(msg []byte) ([]byte, error) {
+		if len(msg) < noiseKeyLength {
+			return nil, errNoiseMessageTooShort
+		}
+		remoteEphemeral = msg[:noiseKeyLength]
+		s.mixHash(remoteEphemeral)
+		return msg[noiseKeyLength:], nil
+	}
+
+	var msg []byte
+	if initiator {
+		// -> e
+		s.mixHash(ephemeral.public[:])
+		if err = writeMessage(append([]byte(nil), ephemeral.public[:]...)); err != nil {
+			return
+		}
+		// <- e, ee, s, es
+		if msg, err = transport.receiveHandshakeMessage(); err != nil {
+			return
+		}
+		if msg, err = readEphemeral(msg); err != nil {
+			return
+		}
+		if err = mixDH(ephemeral, remoteEphemeral); err != nil {
+			return
+		}
+		if msg, err = decryptStatic(msg); err != nil {
+			return
+		}
+		if err = mixDH(ephemeral, remote[:]); err != nil {
+			return
+		}
+		if err = readPayload(msg); err != nil {
+			return
+		}
+		// -> s, se
+		var encryptedStatic []byte
+		if encryptedStatic, err = encryptStatic(); err != nil {
+			return
+		}
+		if err = mixDH(static, remoteEphemeral); err != nil {
+			return
+		}
+		if err = writeMessage(encryptedStatic); err != nil {
+			return
+		}
+		send, receive = s.split()
+		return
+	}
+
+	// -> e
+	if msg, err = transport.receiveHandshakeMessage(); err != nil {
+		return
+	}
+	if msg, err = readEphemeral(msg); err != nil {
+		return
+	}
+	if err = readPayload(msg); err != nil {
+		return
+	}
+	// <- e, ee, s, es
+	s.mixHash(ephemeral.public[:])
+	if err = mixDH(ephemeral, remoteEphemeral); err != nil {
+		return
+	}
+	var encryptedStatic []byte
+	if encryptedStatic, err = encryptStatic(); err != nil {
+		return
+	}
+	if err = mixDH(static, remoteEphemeral); err != nil {
+		return
+	}
+	if err = writeMessage(append(append([]byte(nil), ephemeral.public[:]...), encryptedStatic...)); err != nil {
+		return
+	}
+	// -> s, se
+	if msg, err = transport.receiveHandshakeMessage(); err != nil {
+		return
+	}
+	if msg, err = decryptStatic(msg); err != nil {
+		return
+	}
+	if err = mixDH(ephemeral, remote[:]); err != nil {
+		return
+	}
+	if err = readPayload(msg); err != nil {
+		return
+	}
+	receive, send = s.split()
+	return
+}
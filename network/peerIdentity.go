@@ -0,0 +1,299 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"bytes"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/textproto"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/algorand/go-deadlock"
+	"github.com/algorand/websocket"
+
+	"github.com/algorand/go-algorand/util"
+	"github.com/algorand/go-algorand/util/metrics"
+)
+
+// EncryptionHeader HTTP header by which a client advertises the encrypted handshake protocol it supports, and by which
+// the server accepts it. Old peers ignore it, and exchange plain messages.
+const EncryptionHeader = "X-Algorand-Encryption"
+
+// peerHandshakeTimeout is the maximal duration of the encrypted handshake.
+const peerHandshakeTimeout = 10 * time.Second
+
+// maxHandshakeMessageLength is the maximal length of the messages of the encrypted handshake.
+const maxHandshakeMessageLength = 256
+
+// maxEncryptedPeerAddresses is the maximal number of addresses remembered as having completed the encrypted handshake.
+const maxEncryptedPeerAddresses = 10000
+
+// handshakePrologueHeaders are the connection request and response headers the encrypted handshake authenticates, so
+// that the negotiation can't be tampered with, nor the peers impersonated by relaying their headers.
+var handshakePrologueHeaders = []string{TelemetryIDHeader, InstanceNameHeader, NodeRandomHeader, EncryptionHeader, CompressionHeader}
+
+var networkEncryptionDowngrades = metrics.MakeCounter(metrics.MetricName{Name: "algod_network_encryption_downgrades_total", Description: "number of plain connections with peers which completed the encrypted handshake before"})
+
+var errBadPeerIdentity = errors.New("peer identity isn't a valid identity")
+var errPeerIdentityNotAllowed = errors.New("peer identity isn't in the identity allowlist")
+
+var peerIdentityEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// PeerIdentity is the long-term identity of a node: the public key the node authenticates with during the encrypted
+// handshake of its gossip connections.
+type PeerIdentity [noiseKeyLength]byte
+
+// String returns the base32 encoding of the identity, as used by the PeerIdentityAllowlist and PriorityPeers configs.
+func (id PeerIdentity) String() string {
+	return peerIdentityEncoding.EncodeToString(id[:])
+}
+
+// ParsePeerIdentity parses the base32 encoding of an identity.
+func ParsePeerIdentity(s string) (id PeerIdentity, err error) {
+	decoded, err := peerIdentityEncoding.DecodeString(s)
+	if err != nil {
+		return
+	}
+	if len(decoded) != len(id) {
+		err = errBadPeerIdentity
+		return
+	}
+	copy(id[:], decoded)
+	return
+}
+
+// AuthenticatedPeer is a peer which may have authenticated its identity, as the peers returned by GetPeers.
+type AuthenticatedPeer interface {
+	// Identity returns the identity the peer authenticated with; only the peers connected over an encrypted
+	// connection have one.
+	Identity() (id PeerIdentity, authenticated bool)
+}
+
+// loadOrCreateIdentityKey loads the identity key held by the file, creating the file with a new key if it doesn't
+// exist yet.
+func loadOrCreateIdentityKey(file string) (noiseKeyPair, error) {
+	data, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		kp, err := generateNoiseKeyPair()
+		if err != nil {
+			return noiseKeyPair{}, err
+		}
+		encoded := base64.StdEncoding.EncodeToString(kp.private[:])
+		return kp, util.WriteFileAtomically(file, []byte(encoded+"\n"), 0600)
+	}
+	if err != nil {
+		return noiseKeyPair{}, err
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return noiseKeyPair{}, err
+	}
+	var private [noiseKeyLength]byte
+	if len(decoded) != len(private) {
+		return noiseKeyPair{}, fmt.Errorf("identity key file %s holds a key of %d bytes", file, len(decoded))
+	}
+	copy(private[:], decoded)
+	return makeNoiseKeyPair(private)
+}
+
+// SetIdentityFile sets the file holding the identity key of the node, and loads the key it holds, creating the file
+// with a new key if needed. It only applies when the encrypted handshake is enabled.
+func (wn *WebsocketNetwork) SetIdentityFile(file string) {
+	if !wn.config.EnablePeerEncryption {
+		return
+	}
+	kp, err := loadOrCreateIdentityKey(file)
+	if err != nil {
+		wn.log.Warnf("unable to load the identity key from %s, using the temporary identity %s instead : %v", file, wn.identityKey.public, err)
+		return
+	}
+	wn.identityKey = kp
+	wn.log.Infof("peer identity %s", kp.public)
+}
+
+// Identity returns the identity of the node; it's only meaningful when the encrypted handshake is enabled.
+func (wn *WebsocketNetwork) Identity() PeerIdentity {
+	return wn.identityKey.public
+}
+
+// encryptionAccepted tells whether the peer which sent or answered a connection request with the given headers
+// supports the encrypted handshake.
+func (wn *WebsocketNetwork) encryptionAccepted(header map[string][]string) bool {
+	if !wn.config.EnablePeerEncryption {
+		return false
+	}
+	for _, protocolName := range header[EncryptionHeader] {
+		if protocolName == noiseProtocolName {
+			return true
+		}
+	}
+	return false
+}
+
+// identityAllowed tells whether the connections with the peers which authenticated with the given identity are
+// allowed. Without an allowlist, or with the encrypted handshake disabled, all the peers are allowed, even the ones
+// which didn't authenticate.
+func (wn *WebsocketNetwork) identityAllowed(id PeerIdentity, authenticated bool) bool {
+	if !wn.config.EnablePeerEncryption || len(wn.config.PeerIdentityAllowlist) == 0 {
+		return true
+	}
+	return authenticated && wn.config.PeerIdentityAllowlist[id.String()]
+}
+
+// encryptedPeerAddresses remembers the addresses of the peers which completed the encrypted handshake, so that the
+// plain connections with them are detected as downgrades: the encryption is offered by the unauthenticated connection
+// headers, which an attacker could remove.
+type encryptedPeerAddresses struct {
+	mu    deadlock.Mutex
+	addrs map[string]bool
+}
+
+// add remembers that the peer at the address completed the encrypted handshake.
+func (e *encryptedPeerAddresses) add(addr string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.addrs == nil {
+		e.addrs = make(map[string]bool)
+	}
+	if !e.addrs[addr] && len(e.addrs) >= maxEncryptedPeerAddresses {
+		// forget an arbitrary address to make room.
+		for other := range e.addrs {
+			delete(e.addrs, other)
+			break
+		}
+	}
+	e.addrs[addr] = true
+}
+
+// contains tells whether the peer at the address completed the encrypted handshake before.
+func (e *encryptedPeerAddresses) contains(addr string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.addrs[addr]
+}
+
+// handshakePrologue returns the prologue of the encrypted handshake, which binds the handshake to the genesis and to
+// the handshakePrologueHeaders of the connection request and response, as each side sent or received them.
+func handshakePrologue(genesisID string, requestHeader, responseHeader http.Header) []byte {
+	var prologue bytes.Buffer
+	prologue.WriteString("algorand gossip")
+	writeField := func(field string) {
+		var length [2]byte
+		binary.BigEndian.PutUint16(length[:], uint16(len(field)))
+		prologue.Write(length[:])
+		prologue.WriteString(field)
+	}
+	writeField(genesisID)
+	for _, header := range []http.Header{requestHeader, responseHeader} {
+		for _, name := range handshakePrologueHeaders {
+			writeField(strings.Join(header[textproto.CanonicalMIMEHeaderKey(name)], ","))
+		}
+	}
+	return prologue.Bytes()
+}
+
+// encryptConnection runs the encrypted handshake over the websocket connection, and returns the connection encrypting
+// the messages, along with the authenticated identity of the peer. The handshake fails unless both peers saw the
+// same connection request and response headers.
+func (wn *WebsocketNetwork) encryptConnection(conn *websocket.Conn, initiator bool, requestHeader, responseHeader http.Header) (wsPeerWebsocketConn, PeerIdentity, error) {
+	conn.SetReadDeadline(time.Now().Add(peerHandshakeTimeout))
+	conn.SetWriteDeadline(time.Now().Add(peerHandshakeTimeout))
+	defer conn.SetWriteDeadline(time.Time{})
+	defer conn.SetReadDeadline(time.Time{})
+
+	prologue := handshakePrologue(wn.GenesisID, requestHeader, responseHeader)
+	send, receive, remote, err := noiseHandshake(websocketHandshakeTransport{conn}, wn.identityKey, prologue, initiator)
+	if err != nil {
+		return nil, remote, err
+	}
+	if !wn.identityAllowed(remote, true) {
+		return nil, remote, errPeerIdentityNotAllowed
+	}
+	return &encryptedConn{wsPeerWebsocketConn: conn, send: send, receive: receive}, remote, nil
+}
+
+// websocketHandshakeTransport carries the handshake messages over a websocket connection.
+type websocketHandshakeTransport struct {
+	conn *websocket.Conn
+}
+
+func (t websocketHandshakeTransport) sendHandshakeMessage(msg []byte) error {
+	return t.conn.WriteMessage(websocket.BinaryMessage, msg)
+}
+
+func (t websocketHandshakeTransport) receiveHandshakeMessage() ([]byte, error) {
+	_, reader, err := t.conn.NextReader()
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(io.LimitReader(reader, maxHandshakeMessageLength))
+}
+
+// encryptedConn encrypts the messages of a websocket connection once the encrypted handshake completed. The control
+// messages are left as is.
+type encryptedConn struct {
+	wsPeerWebsocketConn
+
+	sendMu deadlock.Mutex
+	send   *noiseCipherState
+
+	// receive is only used by the read loop of the peer.
+	receive *noiseCipherState
+}
+
+// WriteMessage encrypts and writes a message.
+func (c *encryptedConn) WriteMessage(messageType int, data []byte) error {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+	ciphertext, err := c.send.encrypt(nil, data)
+	if err != nil {
+		return err
+	}
+	return c.wsPeerWebsocketConn.WriteMessage(messageType, ciphertext)
+}
+
+// NextReader reads and decrypts the next message.
+func (c *encryptedConn) NextReader() (int, io.Reader, error) {
+	messageType, reader, err := c.wsPeerWebsocketConn.NextReader()
+	if err != nil {
+		return messageType, nil, err
+	}
+	// the read limit of the connection bounds the length of the message.
+	ciphertext, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return messageType, nil, err
+	}
+	plaintext, err := c.receive.decrypt(nil, ciphertext)
+	if err != nil {
+		return messageType, nil, err
+	}
+	return messageType, bytes.NewReader(plaintext), nil
+}
+
+// SetReadLimit sets the maximal length of the decrypted messages.
+func (c *encryptedConn) SetReadLimit(limit int64) {
+	c.wsPeerWebsocketConn.SetReadLimit(limit + int64(c.receive.overhead()))
+}
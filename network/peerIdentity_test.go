@@ -0,0 +1,307 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// chanHandshakeTransport carries the handshake messages over channels, optionally tampering with the sent messages.
+type chanHandshakeTransport struct {
+	in     chan []byte
+	out    chan []byte
+	tamper func([]byte)
+}
+
+func (t chanHandshakeTransport) sendHandshakeMessage(msg []byte) error {
+	msg = append([]byte(nil), msg...)
+	if t.tamper != nil {
+		t.tamper(msg)
+	}
+	t.out <- msg
+	return nil
+}
+
+func (t chanHandshakeTransport) receiveHandshakeMessage() ([]byte, error) {
+	select {
+	case msg := <-t.in:
+		return msg, nil
+	case <-time.After(time.Second):
+		return nil, context.DeadlineExceeded
+	}
+}
+
+type handshakeResult struct {
+	send, receive *noiseCipherState
+	remote        PeerIdentity
+	err           error
+}
+
+func runNoiseHandshake(t *testing.T, initiatorKey, responderKey noiseKeyPair, responderPrologue string, tamper func([]byte)) (initiator, responder handshakeResult) {
+	toResponder := make(chan []byte, 3)
+	toInitiator := make(chan []byte, 3)
+	done := make(chan handshakeResult, 1)
+	go func() {
+		var r handshakeResult
+		r.send, r.receive, r.remote, r.err = noiseHandshake(chanHandshakeTransport{in: toResponder, out: toInitiator}, responderKey, []byte(responderPrologue), false)
+		done <- r
+	}()
+	initiator.send, initiator.receive, initiator.remote, initiator.err = noiseHandshake(chanHandshakeTransport{in: toInitiator, out: toResponder, tamper: tamper}, initiatorKey, []byte("prologue"), true)
+	responder = <-done
+	return
+}
+
+func TestNoiseHandshake(t *testing.T) {
+	initiatorKey, err := generateNoiseKeyPair()
+	require.NoError(t, err)
+	responderKey, err := generateNoiseKeyPair()
+	require.NoError(t, err)
+
+	initiator, responder := runNoiseHandshake(t, initiatorKey, responderKey, "prologue", nil)
+	require.NoError(t, initiator.err)
+	require.NoError(t, responder.err)
+	require.Equal(t, responderKey.public, initiator.remote)
+	require.Equal(t, initiatorKey.public, responder.remote)
+
+	// the messages of each direction are encrypted with their own key.
+	for i := 0; i < 3; i++ {
+		ciphertext, err := initiator.send.encrypt(nil, []byte("hello"))
+		require.NoError(t, err)
+		require.NotContains(t, string(ciphertext), "hello")
+		plaintext, err := responder.receive.decrypt(nil, ciphertext)
+		require.NoError(t, err)
+		require.Equal(t, "hello", string(plaintext))
+
+		ciphertext, err = responder.send.encrypt(nil, []byte("world"))
+		require.NoError(t, err)
+		plaintext, err = initiator.receive.decrypt(nil, ciphertext)
+		require.NoError(t, err)
+		require.Equal(t, "world", string(plaintext))
+	}
+	ciphertext, err := initiator.send.encrypt(nil, []byte("hello"))
+	require.NoError(t, err)
+	ciphertext[0] ^= 1
+	_, err = responder.receive.decrypt(nil, ciphertext)
+	require.Error(t, err)
+
+	// a tampered message fails the handshake.
+	initiator, responder = runNoiseHandshake(t, initiatorKey, responderKey, "prologue", func(msg []byte) { msg[len(msg)-1] ^= 1 })
+	require.True(t, initiator.err != nil || responder.err != nil)
+
+	// so does a mismatching prologue.
+	initiator, responder = runNoiseHandshake(t, initiatorKey, responderKey, "another prologue", nil)
+	require.Error(t, initiator.err)
+}
+
+func TestPeerIdentityFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "peerIdentity")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "peer_identity.key")
+
+	kp, err := loadOrCreateIdentityKey(file)
+	require.NoError(t, err)
+	info, err := os.Stat(file)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	reloaded, err := loadOrCreateIdentityKey(file)
+	require.NoError(t, err)
+	require.Equal(t, kp, reloaded)
+
+	id, err := ParsePeerIdentity(kp.public.String())
+	require.NoError(t, err)
+	require.Equal(t, kp.public, id)
+	_, err = ParsePeerIdentity("AAAA")
+	require.Error(t, err)
+
+	require.NoError(t, ioutil.WriteFile(file, []byte("not a key"), 0600))
+	_, err = loadOrCreateIdentityKey(file)
+	require.Error(t, err)
+}
+
+func TestWebsocketNetworkEncryption(t *testing.T) {
+	dir, err := ioutil.TempDir("", "peerIdentity")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	conf := defaultConfig
+	conf.EnablePeerEncryption = true
+	netA := makeTestWebsocketNodeWithConfig(t, conf)
+	netA.SetIdentityFile(filepath.Join(dir, "a.key"))
+	netA.Start()
+	defer netA.Stop()
+	netB := makeTestWebsocketNodeWithConfig(t, conf)
+	netB.SetIdentityFile(filepath.Join(dir, "b.key"))
+	addrA, postListen := netA.Address()
+	require.True(t, postListen)
+	netB.phonebook.ReplacePeerList([]string{addrA}, "default", PhoneBookEntryRelayRole)
+	netB.Start()
+	defer netB.Stop()
+
+	readyTimeout := time.NewTimer(2 * time.Second)
+	waitReady(t, netA, readyTimeout.C)
+	waitReady(t, netB, readyTimeout.C)
+
+	received := make(chan []byte, 1)
+	netB.RegisterHandlers([]TaggedMessageHandler{{Tag: protocol.TxnTag, MessageHandler: HandlerFunc(func(msg IncomingMessage) OutgoingMessage {
+		received <- msg.Data
+		return OutgoingMessage{}
+	})}})
+	require.NoError(t, netA.Broadcast(context.Background(), protocol.TxnTag, []byte("txn"), true, nil))
+	select {
+	case data := <-received:
+		require.Equal(t, "txn", string(data))
+	case <-time.After(2 * time.Second):
+		require.Fail(t, "timeout waiting for the message")
+	}
+
+	// each node knows the authenticated identity of the other.
+	for _, pair := range []struct{ local, remote *WebsocketNetwork }{{netA, netB}, {netB, netA}} {
+		peers, _ := pair.local.peerSnapshot(nil)
+		require.Len(t, peers, 1)
+		id, authenticated := peers[0].Identity()
+		require.True(t, authenticated)
+		require.Equal(t, pair.remote.Identity(), id)
+		require.IsType(t, &encryptedConn{}, peers[0].conn)
+
+		// the priority peers may be listed by identity.
+		require.False(t, checkPrioPeers(pair.local, peers[0]))
+		pair.local.config.PriorityPeers = map[string]bool{id.String(): true}
+		require.True(t, checkPrioPeers(pair.local, peers[0]))
+	}
+	gossipPeers := netB.GetPeers(PeersConnectedOut)
+	require.Len(t, gossipPeers, 1)
+	id, authenticated := gossipPeers[0].(AuthenticatedPeer).Identity()
+	require.True(t, authenticated)
+	require.Equal(t, netA.Identity(), id)
+}
+
+func TestWebsocketNetworkIdentityAllowlist(t *testing.T) {
+	relayKey, err := generateNoiseKeyPair()
+	require.NoError(t, err)
+	allowedKey, err := generateNoiseKeyPair()
+	require.NoError(t, err)
+
+	relayConf := defaultConfig
+	relayConf.EnablePeerEncryption = true
+	relayConf.PeerIdentityAllowlist = map[string]bool{allowedKey.public.String(): true}
+	relay := makeTestWebsocketNodeWithConfig(t, relayConf)
+	relay.identityKey = relayKey
+	relay.Start()
+	defer relay.Stop()
+	addr, postListen := relay.Address()
+	require.True(t, postListen)
+
+	connect := func(conf config.Local, key *noiseKeyPair) *WebsocketNetwork {
+		wn := makeTestWebsocketNodeWithConfig(t, conf)
+		if key != nil {
+			wn.identityKey = *key
+		}
+		wn.phonebook.ReplacePeerList([]string{addr}, "default", PhoneBookEntryRelayRole)
+		wn.Start()
+		return wn
+	}
+
+	encryptedConf := defaultConfig
+	encryptedConf.EnablePeerEncryption = true
+	allowed := connect(encryptedConf, &allowedKey)
+	defer allowed.Stop()
+	require.Eventually(t, func() bool { return allowed.NumPeers() == 1 && relay.NumPeers() == 1 }, 2*time.Second, 10*time.Millisecond)
+
+	// neither a peer with an unknown identity, nor a peer without the encrypted handshake are connected.
+	unknown := connect(encryptedConf, nil)
+	defer unknown.Stop()
+	plain := connect(defaultConfig, nil)
+	defer plain.Stop()
+	time.Sleep(500 * time.Millisecond)
+	require.Equal(t, 0, unknown.NumPeers())
+	require.Equal(t, 0, plain.NumPeers())
+	require.Equal(t, 1, relay.NumPeers())
+}
+
+func TestHandshakePrologue(t *testing.T) {
+	requestHeader := make(http.Header)
+	requestHeader.Set(TelemetryIDHeader, "guid")
+	requestHeader.Set(InstanceNameHeader, "instance")
+	requestHeader.Set(NodeRandomHeader, "random")
+	requestHeader.Set(EncryptionHeader, noiseProtocolName)
+	responseHeader := make(http.Header)
+	responseHeader.Set(NodeRandomHeader, "other random")
+	responseHeader.Set(EncryptionHeader, noiseProtocolName)
+	prologue := handshakePrologue("genesis", requestHeader, responseHeader)
+	require.Equal(t, prologue, handshakePrologue("genesis", requestHeader.Clone(), responseHeader.Clone()))
+
+	// the prologue differs once any of the authenticated headers, or the genesis, differs.
+	require.NotEqual(t, prologue, handshakePrologue("other genesis", requestHeader, responseHeader))
+	for _, name := range handshakePrologueHeaders {
+		tamperedRequest := requestHeader.Clone()
+		tamperedRequest.Add(name, "tampered")
+		require.NotEqual(t, prologue, handshakePrologue("genesis", tamperedRequest, responseHeader))
+		tamperedResponse := responseHeader.Clone()
+		tamperedResponse.Add(name, "tampered")
+		require.NotEqual(t, prologue, handshakePrologue("genesis", requestHeader, tamperedResponse))
+	}
+
+	// the fields can't be shifted from one header to the next.
+	shifted := requestHeader.Clone()
+	shifted.Set(TelemetryIDHeader, "guidinstance")
+	shifted.Set(InstanceNameHeader, "")
+	require.NotEqual(t, prologue, handshakePrologue("genesis", shifted, responseHeader))
+}
+
+func TestWebsocketNetworkEncryptionDowngrade(t *testing.T) {
+	plainConf := defaultConfig
+	relay := makeTestWebsocketNodeWithConfig(t, plainConf)
+	relay.Start()
+	defer relay.Stop()
+	addr, postListen := relay.Address()
+	require.True(t, postListen)
+
+	conf := defaultConfig
+	conf.EnablePeerEncryption = true
+	connect := func(encryptedBefore bool) *WebsocketNetwork {
+		wn := makeTestWebsocketNodeWithConfig(t, conf)
+		if encryptedBefore {
+			wn.encryptedAddresses.add(addr)
+		}
+		wn.phonebook.ReplacePeerList([]string{addr}, "default", PhoneBookEntryRelayRole)
+		wn.Start()
+		return wn
+	}
+
+	// the relay doesn't support the encrypted handshake, which is fine unless it completed it before.
+	fresh := connect(false)
+	defer fresh.Stop()
+	require.Eventually(t, func() bool { return fresh.NumPeers() == 1 }, 2*time.Second, 10*time.Millisecond)
+
+	downgraded := connect(true)
+	defer downgraded.Stop()
+	time.Sleep(500 * time.Millisecond)
+	require.Equal(t, 0, downgraded.NumPeers())
+	require.Equal(t, 1, fresh.NumPeers())
+}
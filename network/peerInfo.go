@@ -0,0 +1,145 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"errors"
+	"time"
+
+	"github.com/algorand/go-deadlock"
+
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// adminPhonebookNetworkName is the network name of the phonebook entries added by the administrator of the node, so
+// that the entries aren't replaced by the ones of the DNS bootstrap.
+const adminPhonebookNetworkName = "admin"
+
+var errPeerHostUnknown = errors.New("the host of the peer is unknown")
+
+// PeerInfo describes a connected peer.
+type PeerInfo struct {
+	// Address is the gossip address of the peer: the address it was dialed on for the outgoing connections, or the
+	// public address it advertised for the incoming ones, if any.
+	Address string
+	// RemoteAddress is the host and port the connection is established with.
+	RemoteAddress string
+	// Outgoing is set for the connections the node established.
+	Outgoing bool
+	// Version is the protocol version negotiated with the peer.
+	Version       string
+	TelemetryGUID string
+	InstanceName  string
+	// Identity is the identity the peer authenticated with, if the connection with the peer is encrypted.
+	Identity string
+	// ConnectedSince is the time at which the connection was established.
+	ConnectedSince time.Time
+	// PingRoundTripTime is the round trip time of the last ping answered by the peer, if any.
+	PingRoundTripTime time.Duration
+	// MessagesReceived and MessagesSent count the messages exchanged with the peer, by tag.
+	MessagesReceived map[protocol.Tag]uint64
+	MessagesSent     map[protocol.Tag]uint64
+}
+
+// InspectablePeer is a peer which may be described, as the peers returned by GetPeers.
+type InspectablePeer interface {
+	// Info returns the description of the peer.
+	Info() PeerInfo
+}
+
+// peerMessageCounters counts the messages exchanged with a peer, by tag.
+type peerMessageCounters struct {
+	mu       deadlock.Mutex
+	received map[protocol.Tag]uint64
+	sent     map[protocol.Tag]uint64
+}
+
+func (c *peerMessageCounters) countReceived(tag protocol.Tag) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.received == nil {
+		c.received = make(map[protocol.Tag]uint64)
+	}
+	c.received[tag]++
+}
+
+func (c *peerMessageCounters) countSent(tag protocol.Tag) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.sent == nil {
+		c.sent = make(map[protocol.Tag]uint64)
+	}
+	c.sent[tag]++
+}
+
+// snapshot returns copies of the counters.
+func (c *peerMessageCounters) snapshot() (received, sent map[protocol.Tag]uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	received = make(map[protocol.Tag]uint64, len(c.received))
+	for tag, count := range c.received {
+		received[tag] = count
+	}
+	sent = make(map[protocol.Tag]uint64, len(c.sent))
+	for tag, count := range c.sent {
+		sent[tag] = count
+	}
+	return
+}
+
+// Info returns the description of the peer.
+// (Implements InspectablePeer)
+func (wp *wsPeer) Info() PeerInfo {
+	info := PeerInfo{
+		Address:        wp.GetAddress(),
+		Outgoing:       wp.outgoing,
+		Version:        wp.version,
+		TelemetryGUID:  wp.TelemetryGUID,
+		InstanceName:   wp.InstanceName,
+		ConnectedSince: wp.createTime,
+	}
+	if wp.conn != nil && wp.conn.RemoteAddr() != nil {
+		info.RemoteAddress = wp.conn.RemoteAddr().String()
+	}
+	if id, authenticated := wp.Identity(); authenticated {
+		info.Identity = id.String()
+	}
+	_, info.PingRoundTripTime = wp.pingTimes()
+	info.MessagesReceived, info.MessagesSent = wp.messageCounters.snapshot()
+	return info
+}
+
+// BanPeer bans the hosts of the peer for the given duration, and drops the connections with them. The peers may be
+// banned this way even though the PeerBanPenaltyThreshold is 0, which only disables the bans for misbehaving.
+func (wn *WebsocketNetwork) BanPeer(peer Peer, duration time.Duration) error {
+	hosts := peerHosts(peer)
+	if len(hosts) == 0 {
+		return errPeerHostUnknown
+	}
+	wn.peerScores.banFor(hosts, duration, time.Now())
+	wn.log.Infof("banning peer %v for %v", hosts, duration)
+	if err := wn.peerScores.saveBans(); err != nil {
+		wn.log.Warnf("unable to save the peer bans: %v", err)
+	}
+	wn.disconnectHosts(hosts)
+	return nil
+}
+
+// AddPhonebookEntry adds an address to the phonebook, which the node may then connect to.
+func (wn *WebsocketNetwork) AddPhonebookEntry(address string, role PhoneBookEntryRoles) {
+	wn.phonebook.ExtendPeerList([]string{address}, adminPhonebookNetworkName, role)
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/protocol"
+)
+
+func TestWebsocketNetworkPeerInfoAndBan(t *testing.T) {
+	serverConfig := defaultConfig
+	serverConfig.PeerBanPenaltyThreshold = 100
+	netA := makeTestWebsocketNodeWithConfig(t, serverConfig)
+	netA.Start()
+	defer netA.Stop()
+	clientConfig := defaultConfig
+	clientConfig.GossipFanout = 1
+	clientConfig.PeerBanPenaltyThreshold = 0
+	netB := makeTestWebsocketNodeWithConfig(t, clientConfig)
+	addrA, postListen := netA.Address()
+	require.True(t, postListen)
+	netB.phonebook.ReplacePeerList([]string{addrA}, "default", PhoneBookEntryRelayRole)
+	netB.Start()
+	defer netB.Stop()
+
+	readyTimeout := time.NewTimer(2 * time.Second)
+	waitReady(t, netA, readyTimeout.C)
+	waitReady(t, netB, readyTimeout.C)
+
+	received := make(chan struct{}, 10)
+	netA.RegisterHandlers([]TaggedMessageHandler{{Tag: protocol.TxnTag, MessageHandler: HandlerFunc(func(msg IncomingMessage) OutgoingMessage {
+		received <- struct{}{}
+		return OutgoingMessage{}
+	})}})
+	for i := 0; i < 2; i++ {
+		require.NoError(t, netB.Broadcast(context.Background(), protocol.TxnTag, []byte{byte(i)}, true, nil))
+		select {
+		case <-received:
+		case <-time.After(2 * time.Second):
+			require.Fail(t, "timeout waiting for the message")
+		}
+	}
+
+	peersB := netB.GetPeers(PeersConnectedOut)
+	require.Len(t, peersB, 1)
+	infoB := peersB[0].(InspectablePeer).Info()
+	require.True(t, infoB.Outgoing)
+	require.Equal(t, addrA, infoB.Address)
+	require.NotEmpty(t, infoB.Version)
+	require.Equal(t, uint64(2), infoB.MessagesSent[protocol.TxnTag])
+
+	peersA := netA.GetPeers(PeersConnectedIn)
+	require.Len(t, peersA, 1)
+	infoA := peersA[0].(InspectablePeer).Info()
+	require.False(t, infoA.Outgoing)
+	require.NotEmpty(t, infoA.RemoteAddress)
+	require.Equal(t, uint64(2), infoA.MessagesReceived[protocol.TxnTag])
+
+	require.NoError(t, netA.BanPeer(peersA[0], time.Hour))
+	host := peerHosts(peersA[0])[0]
+	require.True(t, netA.peerScores.isBanned(host, time.Now().Add(59*time.Minute)))
+	require.False(t, netA.peerScores.isBanned(host, time.Now().Add(61*time.Minute)))
+	require.Eventually(t, func() bool { return netA.NumPeers() == 0 }, 2*time.Second, 10*time.Millisecond)
+
+	// the node which doesn't ban peers for misbehaving still bans the peers it's asked to.
+	require.NoError(t, netB.BanPeer(peersB[0], time.Hour))
+	for _, host := range peerHosts(peersB[0]) {
+		require.True(t, netB.peerScores.isBanned(host, time.Now()))
+	}
+}
+
+func TestWebsocketNetworkAddPhonebookEntry(t *testing.T) {
+	netA := makeTestWebsocketNode(t)
+	netA.AddPhonebookEntry("relay.example.com:4160", PhoneBookEntryRelayRole)
+	netA.AddPhonebookEntry("archiver.example.com:4160", PhoneBookEntryArchiverRole)
+
+	// the entries survive the refresh of the phonebook from the DNS bootstrap.
+	netA.phonebook.ReplacePeerList([]string{"other.example.com:4160"}, "default", PhoneBookEntryRelayRole)
+	require.ElementsMatch(t, []string{"relay.example.com:4160", "other.example.com:4160"}, netA.phonebook.GetAddresses(10, PhoneBookEntryRelayRole))
+	require.Equal(t, []string{"archiver.example.com:4160"}, netA.phonebook.GetAddresses(10, PhoneBookEntryArchiverRole))
+}
@@ -0,0 +1,358 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"os"
+	"time"
+
+	"github.com/algorand/go-deadlock"
+
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/util"
+	"github.com/algorand/go-algorand/util/metrics"
+)
+
+// PeerOffense is a kind of misbehavior of a peer, reported to the network through ReportPeer.
+type PeerOffense int
+
+const (
+	// OffenseInvalidMessage is reported when a peer sent a message which couldn't be decoded or processed.
+	OffenseInvalidMessage PeerOffense = iota
+	// OffenseInvalidTransaction is reported when a peer relayed a transaction group which failed verification.
+	OffenseInvalidTransaction
+	// OffenseInvalidAgreementMessage is reported when a peer sent a vote, bundle or proposal which failed verification.
+	OffenseInvalidAgreementMessage
+	// OffenseDuplicateFlood is reported when a peer repeatedly sends the same messages.
+	OffenseDuplicateFlood
+	// OffenseUnansweredRequest is reported when a peer didn't answer a request in time.
+	OffenseUnansweredRequest
+)
+
+// peerOffensePenalties are the penalties of each of the offenses, compared against the PeerBanPenaltyThreshold.
+var peerOffensePenalties = map[PeerOffense]float64{
+	OffenseInvalidMessage:          25,
+	OffenseInvalidTransaction:      25,
+	OffenseInvalidAgreementMessage: 25,
+	OffenseDuplicateFlood:          10,
+	OffenseUnansweredRequest:       5,
+}
+
+var peerOffenseNames = map[PeerOffense]string{
+	OffenseInvalidMessage:          "invalid_message",
+	OffenseInvalidTransaction:      "invalid_transaction",
+	OffenseInvalidAgreementMessage: "invalid_agreement_message",
+	OffenseDuplicateFlood:          "duplicate_flood",
+	OffenseUnansweredRequest:       "unanswered_request",
+}
+
+// String returns the name of the offense, as reported in the metrics.
+func (o PeerOffense) String() string {
+	if name, has := peerOffenseNames[o]; has {
+		return name
+	}
+	return "unknown"
+}
+
+// disconnects returns whether the offense is severe enough to drop the connection to the peer, regardless of its score.
+// Unanswered requests and duplicates may be caused by a slow or congested peer, and only count toward a ban.
+func (o PeerOffense) disconnects() bool {
+	return o != OffenseDuplicateFlood && o != OffenseUnansweredRequest
+}
+
+var networkPeerOffensesTotal = metrics.MakeCounter(metrics.MetricName{Name: "algod_network_peer_offenses_total", Description: "Misbehavior reports against peers, by offense"})
+var networkPeerBansTotal = metrics.MakeCounter(metrics.MetricName{Name: "algod_network_peer_bans_total", Description: "Number of peers banned for misbehaving"})
+
+// peerScoreHalfLife is the period over which the accumulated penalties of a peer are halved.
+const peerScoreHalfLife = 10 * time.Minute
+
+// maxPeerBanDuration caps the duration of the bans of the repeat offenders.
+const maxPeerBanDuration = 7 * 24 * time.Hour
+
+// peerBanMemory is for how long an expired ban is remembered. A peer banned again within this period gets a longer
+// ban, while a peer which behaved for that long starts over with the base ban duration.
+const peerBanMemory = 24 * time.Hour
+
+// peerScore is the decaying penalty accumulated by a peer.
+type peerScore struct {
+	penalty     float64
+	lastUpdated time.Time
+}
+
+// peerBan is a ban of a peer host, as persisted in the peer bans file.
+type peerBan struct {
+	Host  string    `json:"host"`
+	Until time.Time `json:"until"`
+	// Count is the number of successive bans of the host, which determines the duration of the next one.
+	Count int `json:"count"`
+}
+
+// peerScoreboard keeps track of the penalties of the peers, and of the hosts banned for misbehaving. The peers are
+// identified by their host, so that a banned peer can't reconnect from another port.
+type peerScoreboard struct {
+	mu deadlock.Mutex
+
+	threshold   float64
+	banDuration time.Duration
+
+	scores map[string]*peerScore
+	bans   map[string]*peerBan
+
+	// bansFile is the file the bans are persisted to; the bans aren't persisted when empty.
+	bansFile string
+}
+
+func makePeerScoreboard(threshold uint64, banDuration time.Duration) *peerScoreboard {
+	return &peerScoreboard{
+		threshold:   float64(threshold),
+		banDuration: banDuration,
+		scores:      make(map[string]*peerScore),
+		bans:        make(map[string]*peerBan),
+	}
+}
+
+// enabled returns whether peers get banned for misbehaving. The bans set with banFor apply regardless.
+func (sb *peerScoreboard) enabled() bool {
+	return sb.threshold > 0
+}
+
+// penalize adds the penalty of the offense to the score of the peer identified by the given hosts, and bans these
+// hosts if the peer reached the threshold. It returns true if the peer got banned.
+func (sb *peerScoreboard) penalize(hosts []string, offense PeerOffense, now time.Time) (banned bool) {
+	networkPeerOffensesTotal.Inc(map[string]string{"offense": offense.String()})
+	if !sb.enabled() || len(hosts) == 0 {
+		return false
+	}
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	sb.prune(now)
+
+	score := sb.scores[hosts[0]]
+	if score == nil {
+		score = &peerScore{}
+		sb.scores[hosts[0]] = score
+	}
+	score.penalty = decayedPenalty(score.penalty, now.Sub(score.lastUpdated)) + peerOffensePenalties[offense]
+	score.lastUpdated = now
+	if score.penalty < sb.threshold {
+		return false
+	}
+
+	delete(sb.scores, hosts[0])
+	for _, host := range hosts {
+		sb.ban(host, now)
+	}
+	networkPeerBansTotal.Inc(nil)
+	return true
+}
+
+// ban bans the host, doubling the duration of its previous ban if it is still remembered.
+func (sb *peerScoreboard) ban(host string, now time.Time) {
+	ban := sb.bans[host]
+	if ban == nil {
+		ban = &peerBan{Host: host}
+		sb.bans[host] = ban
+	}
+	if ban.Until.After(now) {
+		// the host is already banned; a peer connected before the ban gets it extended.
+		ban.Until = now.Add(sb.banDurationFor(ban.Count))
+		return
+	}
+	ban.Count++
+	ban.Until = now.Add(sb.banDurationFor(ban.Count))
+}
+
+// banFor bans the hosts until at least the given duration passed, regardless of the previous bans.
+func (sb *peerScoreboard) banFor(hosts []string, duration time.Duration, now time.Time) {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	sb.prune(now)
+	for _, host := range hosts {
+		ban := sb.bans[host]
+		if ban == nil {
+			ban = &peerBan{Host: host}
+			sb.bans[host] = ban
+		}
+		if until := now.Add(duration); until.After(ban.Until) {
+			ban.Until = until
+		}
+	}
+}
+
+func (sb *peerScoreboard) banDurationFor(count int) time.Duration {
+	duration := sb.banDuration
+	for i := 1; i < count && duration < maxPeerBanDuration; i++ {
+		duration *= 2
+	}
+	if duration > maxPeerBanDuration {
+		duration = maxPeerBanDuration
+	}
+	return duration
+}
+
+// isBanned returns whether the host is currently banned.
+func (sb *peerScoreboard) isBanned(host string, now time.Time) bool {
+	if host == "" {
+		return false
+	}
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	ban := sb.bans[host]
+	return ban != nil && ban.Until.After(now)
+}
+
+// prune drops the scores which decayed to nothing, and the bans which were forgotten.
+func (sb *peerScoreboard) prune(now time.Time) {
+	for host, score := range sb.scores {
+		if decayedPenalty(score.penalty, now.Sub(score.lastUpdated)) < 1 {
+			delete(sb.scores, host)
+		}
+	}
+	for host, ban := range sb.bans {
+		if now.Sub(ban.Until) > peerBanMemory {
+			delete(sb.bans, host)
+		}
+	}
+}
+
+func decayedPenalty(penalty float64, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return penalty
+	}
+	return penalty * math.Pow(0.5, float64(elapsed)/float64(peerScoreHalfLife))
+}
+
+// loadBans sets the file the bans are persisted to, and loads the bans it holds. A missing file isn't an error.
+func (sb *peerScoreboard) loadBans(bansFile string, now time.Time) error {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	sb.bansFile = bansFile
+
+	data, err := ioutil.ReadFile(bansFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var bans []peerBan
+	if err := json.Unmarshal(data, &bans); err != nil {
+		return err
+	}
+	for i := range bans {
+		sb.bans[bans[i].Host] = &bans[i]
+	}
+	sb.prune(now)
+	return nil
+}
+
+// saveBans writes the bans to the bans file, if one was set.
+func (sb *peerScoreboard) saveBans() error {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	if sb.bansFile == "" {
+		return nil
+	}
+
+	bans := make([]peerBan, 0, len(sb.bans))
+	for _, ban := range sb.bans {
+		bans = append(bans, *ban)
+	}
+	data, err := json.MarshalIndent(bans, "", "\t")
+	if err != nil {
+		return err
+	}
+	return util.WriteFileAtomically(sb.bansFile, data, 0600)
+}
+
+// floodDetectorWindow is the period over which the repeated messages of a peer are counted.
+const floodDetectorWindow = time.Minute
+
+// floodDetectorThreshold is the number of repeated messages within a floodDetectorWindow which makes a flood.
+const floodDetectorThreshold = 64
+
+// duplicateFloodDetector detects a peer sending the same messages over and over again. Receiving a message already
+// received from another peer is the norm of the gossip network; receiving it again from the same peer isn't.
+type duplicateFloodDetector struct {
+	sent        *messageFilter
+	repeats     int
+	windowStart time.Time
+}
+
+func makeDuplicateFloodDetector() *duplicateFloodDetector {
+	return &duplicateFloodDetector{
+		sent: makeMessageFilter(2, 512),
+	}
+}
+
+// check records the message received from the peer, and returns true whenever the peer reached the flood threshold.
+// It is only called from the peer read loop.
+func (d *duplicateFloodDetector) check(tag protocol.Tag, data []byte, now time.Time) bool {
+	if !d.sent.CheckIncomingMessage(tag, data, true, false) {
+		return false
+	}
+	if now.Sub(d.windowStart) > floodDetectorWindow {
+		d.windowStart = now
+		d.repeats = 0
+	}
+	d.repeats++
+	if d.repeats < floodDetectorThreshold {
+		return false
+	}
+	d.repeats = 0
+	return true
+}
+
+// addrHost returns the host of a peer address, which is either a "host:port" or a URL.
+func addrHost(addr string) string {
+	parsedURL, err := ParseHostOrURL(addr)
+	if err != nil {
+		return justHost(addr)
+	}
+	return parsedURL.Hostname()
+}
+
+// peerHosts returns the hosts identifying the peer on the scoreboard. The first host is the remote host of the
+// connection; an outgoing connection is also identified by the host of its phonebook address, which is the host
+// checked before connecting.
+func peerHosts(peer Peer) []string {
+	switch p := peer.(type) {
+	case *wsPeer:
+		var hosts []string
+		remoteHost := p.OriginAddress()
+		if remoteHost == "" && p.conn != nil && p.conn.RemoteAddr() != nil {
+			remoteHost = justHost(p.conn.RemoteAddr().String())
+		}
+		if remoteHost != "" {
+			hosts = append(hosts, remoteHost)
+		}
+		if p.outgoing {
+			if host := addrHost(p.GetAddress()); host != "" && host != remoteHost {
+				hosts = append(hosts, host)
+			}
+		}
+		return hosts
+	case *wsPeerCore:
+		if host := addrHost(p.GetAddress()); host != "" {
+			return []string{host}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/protocol"
+)
+
+func TestPeerScoreboardBan(t *testing.T) {
+	sb := makePeerScoreboard(100, time.Hour)
+	now := time.Now()
+	hosts := []string{"10.0.0.1", "relay.example.com"}
+
+	for i := 0; i < 3; i++ {
+		require.False(t, sb.penalize(hosts, OffenseInvalidTransaction, now))
+	}
+	require.False(t, sb.isBanned("10.0.0.1", now))
+	require.True(t, sb.penalize(hosts, OffenseInvalidAgreementMessage, now))
+	require.True(t, sb.isBanned("10.0.0.1", now))
+	require.True(t, sb.isBanned("relay.example.com", now))
+	require.False(t, sb.isBanned("10.0.0.2", now))
+
+	// the ban expires after the ban duration.
+	now = now.Add(time.Hour + time.Second)
+	require.False(t, sb.isBanned("10.0.0.1", now))
+
+	// a repeat offender gets a longer ban.
+	for i := 0; i < 4; i++ {
+		sb.penalize(hosts, OffenseInvalidMessage, now)
+	}
+	require.True(t, sb.isBanned("10.0.0.1", now.Add(90*time.Minute)))
+	require.False(t, sb.isBanned("10.0.0.1", now.Add(2*time.Hour+time.Second)))
+
+	// a host which behaved for long enough gets forgotten, and starts over.
+	now = now.Add(2*time.Hour + peerBanMemory + time.Second)
+	for i := 0; i < 4; i++ {
+		sb.penalize(hosts, OffenseInvalidMessage, now)
+	}
+	require.True(t, sb.isBanned("10.0.0.1", now.Add(59*time.Minute)))
+	require.False(t, sb.isBanned("10.0.0.1", now.Add(time.Hour+time.Second)))
+}
+
+func TestPeerScoreboardDecay(t *testing.T) {
+	sb := makePeerScoreboard(100, time.Hour)
+	now := time.Now()
+	hosts := []string{"10.0.0.1"}
+
+	for i := 0; i < 3; i++ {
+		require.False(t, sb.penalize(hosts, OffenseInvalidTransaction, now))
+	}
+	// after a half life, the 75 points decayed to 37.5.
+	now = now.Add(peerScoreHalfLife)
+	require.False(t, sb.penalize(hosts, OffenseInvalidTransaction, now))
+	require.False(t, sb.penalize(hosts, OffenseInvalidTransaction, now))
+	require.True(t, sb.penalize(hosts, OffenseInvalidTransaction, now))
+
+	// the minor offenses take longer to get a peer banned.
+	hosts = []string{"10.0.0.2"}
+	for i := 0; i < 19; i++ {
+		require.False(t, sb.penalize(hosts, OffenseUnansweredRequest, now))
+	}
+	require.True(t, sb.penalize(hosts, OffenseUnansweredRequest, now))
+}
+
+func TestPeerScoreboardDisabled(t *testing.T) {
+	sb := makePeerScoreboard(0, time.Hour)
+	now := time.Now()
+	for i := 0; i < 100; i++ {
+		require.False(t, sb.penalize([]string{"10.0.0.1"}, OffenseInvalidMessage, now))
+	}
+	require.False(t, sb.isBanned("10.0.0.1", now))
+
+	// the bans set explicitly apply regardless.
+	sb.banFor([]string{"10.0.0.1"}, time.Hour, now)
+	require.True(t, sb.isBanned("10.0.0.1", now))
+	require.False(t, sb.isBanned("10.0.0.1", now.Add(time.Hour+time.Second)))
+}
+
+func TestPeerScoreboardPersistence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "peerbans")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	bansFile := filepath.Join(dir, "peerbans.json")
+
+	now := time.Now()
+	sb := makePeerScoreboard(25, time.Hour)
+	// a missing file means there are no bans yet.
+	require.NoError(t, sb.loadBans(bansFile, now))
+	require.True(t, sb.penalize([]string{"10.0.0.1"}, OffenseInvalidMessage, now))
+	require.True(t, sb.penalize([]string{"10.0.0.2"}, OffenseInvalidMessage, now.Add(-time.Hour-peerBanMemory-time.Second)))
+	require.NoError(t, sb.saveBans())
+
+	restored := makePeerScoreboard(25, time.Hour)
+	require.NoError(t, restored.loadBans(bansFile, now))
+	require.True(t, restored.isBanned("10.0.0.1", now))
+	require.Len(t, restored.bans, 1)
+	require.Equal(t, 1, restored.bans["10.0.0.1"].Count)
+
+	require.NoError(t, ioutil.WriteFile(bansFile, []byte("not json"), 0600))
+	require.Error(t, makePeerScoreboard(25, time.Hour).loadBans(bansFile, now))
+}
+
+func TestDuplicateFloodDetector(t *testing.T) {
+	detector := makeDuplicateFloodDetector()
+	now := time.Now()
+	require.False(t, detector.check(protocol.TxnTag, []byte("txn"), now))
+	for i := 1; i < floodDetectorThreshold; i++ {
+		require.False(t, detector.check(protocol.TxnTag, []byte("txn"), now))
+	}
+	require.True(t, detector.check(protocol.TxnTag, []byte("txn"), now))
+
+	// distinct messages aren't a flood.
+	for i := 0; i < 2*floodDetectorThreshold; i++ {
+		require.False(t, detector.check(protocol.TxnTag, []byte{byte(i), byte(i >> 8)}, now))
+	}
+
+	// repeats are only counted within the window.
+	for i := 0; i < floodDetectorThreshold; i++ {
+		require.False(t, detector.check(protocol.TxnTag, []byte("txn"), now.Add(time.Duration(i)*floodDetectorWindow/8)))
+	}
+}
+
+func TestWebsocketNetworkPeerBan(t *testing.T) {
+	serverConfig := defaultConfig
+	serverConfig.GossipFanout = 1
+	serverConfig.PeerBanPenaltyThreshold = 25
+	netA := makeTestWebsocketNodeWithConfig(t, serverConfig)
+	netA.Start()
+	defer netA.Stop()
+	netB := makeTestWebsocketNode(t)
+	netB.config.GossipFanout = 1
+	addrA, postListen := netA.Address()
+	require.True(t, postListen)
+	netB.phonebook.ReplacePeerList([]string{addrA}, "default", PhoneBookEntryRelayRole)
+	netB.Start()
+	defer netB.Stop()
+
+	readyTimeout := time.NewTimer(2 * time.Second)
+	waitReady(t, netA, readyTimeout.C)
+	waitReady(t, netB, readyTimeout.C)
+
+	peersA, _ := netA.peerSnapshot(nil)
+	require.Len(t, peersA, 1)
+	netA.ReportPeer(peersA[0], OffenseDuplicateFlood)
+	require.Equal(t, 1, netA.NumPeers())
+	netA.ReportPeer(peersA[0], OffenseInvalidTransaction)
+	require.True(t, netA.peerScores.isBanned(peerHosts(peersA[0])[0], time.Now()))
+	require.Eventually(t, func() bool { return netA.NumPeers() == 0 }, 2*time.Second, 10*time.Millisecond)
+
+	// the banned peer can't reconnect.
+	netB.RequestConnectOutgoing(false, nil)
+	time.Sleep(500 * time.Millisecond)
+	require.Equal(t, 0, netA.NumPeers())
+}
+
+func TestWebsocketNetworkRelaysNotBanned(t *testing.T) {
+	serverConfig := defaultConfig
+	serverConfig.PeerBanPenaltyThreshold = 25
+	netA := makeTestWebsocketNodeWithConfig(t, serverConfig)
+	netA.Start()
+	defer netA.Stop()
+	clientConfig := defaultConfig
+	clientConfig.GossipFanout = 1
+	clientConfig.PeerBanPenaltyThreshold = 25
+	netB := makeTestWebsocketNodeWithConfig(t, clientConfig)
+	addrA, postListen := netA.Address()
+	require.True(t, postListen)
+	netB.phonebook.ReplacePeerList([]string{addrA}, "default", PhoneBookEntryRelayRole)
+	netB.Start()
+	defer netB.Stop()
+
+	readyTimeout := time.NewTimer(2 * time.Second)
+	waitReady(t, netA, readyTimeout.C)
+	waitReady(t, netB, readyTimeout.C)
+
+	// the relay netB connected to gets disconnected for its offense, but isn't banned.
+	peersB, _ := netB.peerSnapshot(nil)
+	require.Len(t, peersB, 1)
+	netB.ReportPeer(peersB[0], OffenseInvalidTransaction)
+	for _, host := range peerHosts(peersB[0]) {
+		require.False(t, netB.peerScores.isBanned(host, time.Now()))
+	}
+	require.Eventually(t, func() bool { return netB.NumPeers() == 0 }, 2*time.Second, 10*time.Millisecond)
+
+	// neither is an incoming peer sharing its host with a relay of the phonebook.
+	require.Eventually(t, func() bool {
+		netB.RequestConnectOutgoing(false, nil)
+		return netA.NumPeers() == 1
+	}, 5*time.Second, 50*time.Millisecond)
+	peersA, _ := netA.peerSnapshot(nil)
+	require.Len(t, peersA, 1)
+	host := peerHosts(peersA[0])[0]
+	netA.phonebook.ReplacePeerList([]string{net.JoinHostPort(host, "4160")}, "default", PhoneBookEntryRelayRole)
+	netA.ReportPeer(peersA[0], OffenseInvalidTransaction)
+	require.False(t, netA.peerScores.isBanned(host, time.Now()))
+}
@@ -79,6 +79,11 @@ func checkPrioPeers(wn *WebsocketNetwork, wp *wsPeer) bool {
 		return false
 	}
 
+	// the peers are listed either by address, or by the identity they authenticated with.
+	if id, authenticated := wp.Identity(); authenticated && pp[id.String()] {
+		return true
+	}
+
 	addr := wp.OriginAddress()
 	if addr == "" {
 		return false
@@ -44,6 +44,9 @@ type Phonebook interface {
 	// GetAddresses(N) returns up to N addresses, but may return fewer
 	GetAddresses(n int, role PhoneBookEntryRoles) []string
 
+	// AllAddresses returns all the addresses of the given role, including the ones waiting to be retried
+	AllAddresses(role PhoneBookEntryRoles) []string
+
 	// UpdateRetryAfter updates the retry-after field for the entries matching the given address
 	UpdateRetryAfter(addr string, retryAfter time.Time)
 
@@ -314,6 +317,19 @@ func (e *phonebookImpl) GetAddresses(n int, role PhoneBookEntryRoles) []string {
 	return shuffleSelect(e.filterRetryTime(time.Now(), role), n)
 }
 
+// AllAddresses returns all the addresses of the given role, including the ones waiting to be retried
+func (e *phonebookImpl) AllAddresses(role PhoneBookEntryRoles) []string {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	o := make([]string, 0, len(e.data))
+	for addr, entry := range e.data {
+		if entry.role == role {
+			o = append(o, addr)
+		}
+	}
+	return o
+}
+
 // ExtendPeerList adds unique addresses to this set of addresses
 func (e *phonebookImpl) ExtendPeerList(more []string, networkName string, role PhoneBookEntryRoles) {
 	e.lock.Lock()
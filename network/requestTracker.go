@@ -148,7 +148,24 @@ func (ard *hostIncomingRequests) countConnections(rateLimitingWindowStartTime ti
 	return uint(len(ard.requests) - i + len(ard.additionalHostRequests))
 }
 
-type hostsIncomingMap map[string]*hostIncomingRequests
+// hostsIncomingMap maps the connectionLimitHost of the remote hosts to their requests, so that an IPv6 host can't
+// escape the limits by changing its address within its prefix.
+type hostsIncomingMap struct {
+	ipv6PrefixLength uint64
+	hosts            map[string]*hostIncomingRequests
+}
+
+func makeHostsIncomingMap(ipv6PrefixLength uint64) hostsIncomingMap {
+	return hostsIncomingMap{
+		ipv6PrefixLength: ipv6PrefixLength,
+		hosts:            make(map[string]*hostIncomingRequests),
+	}
+}
+
+// limitHost returns the host the requests of the given remote host are counted under.
+func (him *hostsIncomingMap) limitHost(remoteHost string) string {
+	return connectionLimitHost(remoteHost, him.ipv6PrefixLength)
+}
 
 // pruneRequests cleans stale items from the hostRequests maps
 func (him *hostsIncomingMap) pruneRequests(rateLimitingWindowStartTime time.Time) {
@@ -156,7 +173,7 @@ func (him *hostsIncomingMap) pruneRequests(rateLimitingWindowStartTime time.Time
 	// but rather to make a progressive cleanup.
 	var removeHost string
 
-	for host, requestData := range *him {
+	for host, requestData := range him.hosts {
 		i := requestData.findTimestampIndex(rateLimitingWindowStartTime)
 		if i == 0 {
 			continue
@@ -170,20 +187,21 @@ func (him *hostsIncomingMap) pruneRequests(rateLimitingWindowStartTime time.Time
 		break
 	}
 	if removeHost != "" {
-		delete(*him, removeHost)
+		delete(him.hosts, removeHost)
 	}
 }
 
 // addRequest adds an entry to the hostRequests map, or update the item within the map
 func (him *hostsIncomingMap) addRequest(trackerRequest *TrackerRequest) {
-	requestData, has := (*him)[trackerRequest.remoteHost]
+	host := him.limitHost(trackerRequest.remoteHost)
+	requestData, has := him.hosts[host]
 	if !has {
 		requestData = &hostIncomingRequests{
-			remoteHost:             trackerRequest.remoteHost,
+			remoteHost:             host,
 			requests:               make([]*TrackerRequest, 0, 1),
 			additionalHostRequests: make(map[*TrackerRequest]struct{}),
 		}
-		(*him)[trackerRequest.remoteHost] = requestData
+		him.hosts[host] = requestData
 	}
 
 	requestData.add(trackerRequest)
@@ -191,7 +209,7 @@ func (him *hostsIncomingMap) addRequest(trackerRequest *TrackerRequest) {
 
 // countOriginConnections counts the number of connection that were seen since rateLimitingWindowStartTime coming from the host rateLimitingWindowStartTime
 func (him *hostsIncomingMap) countOriginConnections(remoteHost string, rateLimitingWindowStartTime time.Time) uint {
-	if requestData, has := (*him)[remoteHost]; has {
+	if requestData, has := him.hosts[him.limitHost(remoteHost)]; has {
 		return requestData.countConnections(rateLimitingWindowStartTime)
 	}
 	return 0
@@ -199,7 +217,7 @@ func (him *hostsIncomingMap) countOriginConnections(remoteHost string, rateLimit
 
 // convertToAdditionalRequest converts the given trackerRequest into a "additional request".
 func (him *hostsIncomingMap) convertToAdditionalRequest(trackerRequest *TrackerRequest) {
-	requestData, has := (*him)[trackerRequest.remoteHost]
+	requestData, has := him.hosts[him.limitHost(trackerRequest.remoteHost)]
 	if !has {
 		return
 	}
@@ -208,7 +226,7 @@ func (him *hostsIncomingMap) convertToAdditionalRequest(trackerRequest *TrackerR
 
 // removeTrackedConnection removes a trackerRequest from the additional requests map
 func (him *hostsIncomingMap) removeTrackedConnection(trackerRequest *TrackerRequest) {
-	requestData, has := (*him)[trackerRequest.remoteHost]
+	requestData, has := him.hosts[him.limitHost(trackerRequest.remoteHost)]
 	if !has {
 		return
 	}
@@ -240,10 +258,10 @@ func makeRequestsTracker(downstreamHandler http.Handler, log logging.Logger, con
 		downstreamHandler:   downstreamHandler,
 		log:                 log,
 		config:              config,
-		hostRequests:        make(map[string]*hostIncomingRequests, 0),
+		hostRequests:        makeHostsIncomingMap(config.ConnectionsIPv6PrefixLength),
 		acceptedConnections: make(map[net.Addr]*TrackerRequest, 0),
 		httpConnections:     make(map[net.Addr]*TrackerRequest, 0),
-		httpHostRequests:    make(map[string]*hostIncomingRequests, 0),
+		httpHostRequests:    makeHostsIncomingMap(config.ConnectionsIPv6PrefixLength),
 	}
 }
 
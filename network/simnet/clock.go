@@ -0,0 +1,134 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package simnet
+
+import (
+	"sort"
+	"time"
+
+	"github.com/algorand/go-deadlock"
+)
+
+// Clock is the time source of a simulated network. The latency, the bandwidth and the losses of the links, as well as
+// the deadlines of the connections, are all measured against it.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// Schedule calls f, from another goroutine, once the clock reaches the given time. The returned function cancels
+	// the call, and tells whether it did so before f was called.
+	Schedule(at time.Time, f func()) (stop func() bool)
+}
+
+// SystemClock returns the wall clock, on which a simulated network runs in real time. It suits the simulations of
+// full nodes, whose own timers run in real time as well.
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) Schedule(at time.Time, f func()) func() bool {
+	return time.AfterFunc(time.Until(at), f).Stop
+}
+
+// ManualClock is a Clock which only moves forward when told to. A simulated network running on it is deterministic:
+// the data written to its connections is delivered at the same simulated times on every run, regardless of the
+// scheduling of the goroutines, as long as the goroutines woken up by advancing the clock are done with the network
+// before it's advanced again.
+type ManualClock struct {
+	mu     deadlock.Mutex
+	now    time.Time
+	timers []*manualTimer
+	// seq orders the timers firing at the same time by creation.
+	seq uint64
+}
+
+type manualTimer struct {
+	at  time.Time
+	seq uint64
+	f   func()
+}
+
+// MakeManualClock creates a ManualClock set at the given time.
+func MakeManualClock(start time.Time) *ManualClock {
+	return &ManualClock{now: start}
+}
+
+// Now returns the current time of the clock.
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Schedule calls f once the clock is advanced to the given time, or right away if it's already past it.
+func (c *ManualClock) Schedule(at time.Time, f func()) func() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !at.After(c.now) {
+		go f()
+		return func() bool { return false }
+	}
+	c.seq++
+	timer := &manualTimer{at: at, seq: c.seq, f: f}
+	c.timers = append(c.timers, timer)
+	return func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for i, t := range c.timers {
+			if t == timer {
+				c.timers = append(c.timers[:i], c.timers[i+1:]...)
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Advance moves the clock forward by the given duration. The timers due by then are called one at a time, in the
+// order of their times and then of their creation, with the clock set to their time; the timers they create are
+// called as well if they are due.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	end := c.now.Add(d)
+	for {
+		sort.Slice(c.timers, func(i, j int) bool {
+			if !c.timers[i].at.Equal(c.timers[j].at) {
+				return c.timers[i].at.Before(c.timers[j].at)
+			}
+			return c.timers[i].seq < c.timers[j].seq
+		})
+		if len(c.timers) == 0 || c.timers[0].at.After(end) {
+			break
+		}
+		timer := c.timers[0]
+		c.timers = c.timers[1:]
+		if timer.at.After(c.now) {
+			c.now = timer.at
+		}
+		c.mu.Unlock()
+		timer.f()
+		c.mu.Lock()
+	}
+	c.now = end
+	c.mu.Unlock()
+}
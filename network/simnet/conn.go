@@ -0,0 +1,319 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package simnet
+
+import (
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/algorand/go-deadlock"
+)
+
+var errClosed = errors.New("use of closed network connection")
+var errBrokenPipe = errors.New("broken pipe")
+
+// timeoutError is the error of the reads and writes whose deadline passed.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+// segment is the data of a write, along with the time it reaches the other end of the link.
+type segment struct {
+	data      []byte
+	deliverAt time.Time
+}
+
+// stream carries the data written to one end of a connection to the other end.
+type stream struct {
+	mu    deadlock.Mutex
+	cond  *sync.Cond
+	clock Clock
+
+	segments []segment
+	// lastDelivery is the delivery time of the last segment; the segments are delivered in order.
+	lastDelivery time.Time
+	// busyUntil is the time at which the link is done carrying the data written so far.
+	busyUntil time.Time
+
+	// writerClosed is set once the writing end is closed; the reads then get io.EOF once all the data is read.
+	writerClosed bool
+	// readerClosed is set once the reading end is closed; the writes then fail.
+	readerClosed bool
+	// err is set once the connection is reset.
+	err error
+
+	readDeadline  time.Time
+	writeDeadline time.Time
+}
+
+func makeStream(clock Clock) *stream {
+	s := &stream{clock: clock}
+	s.cond = sync.NewCond(&s.mu)
+	return s
+}
+
+// wait waits for the stream to change, or for the given time to come. The lock must be held.
+func (s *stream) wait(wake time.Time) {
+	if !wake.IsZero() {
+		stop := s.clock.Schedule(wake, func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.cond.Broadcast()
+		})
+		defer stop()
+	}
+	s.cond.Wait()
+}
+
+func (s *stream) read(b []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for {
+		if s.readerClosed {
+			return 0, errClosed
+		}
+		if s.err != nil {
+			return 0, s.err
+		}
+		now := s.clock.Now()
+		if len(s.segments) > 0 && !s.segments[0].deliverAt.After(now) {
+			n := copy(b, s.segments[0].data)
+			s.segments[0].data = s.segments[0].data[n:]
+			if len(s.segments[0].data) == 0 {
+				s.segments = s.segments[1:]
+			}
+			return n, nil
+		}
+		if len(s.segments) == 0 && s.writerClosed {
+			return 0, io.EOF
+		}
+		if !s.readDeadline.IsZero() && !now.Before(s.readDeadline) {
+			return 0, timeoutError{}
+		}
+
+		var wake time.Time
+		if len(s.segments) > 0 {
+			wake = s.segments[0].deliverAt
+		}
+		if !s.readDeadline.IsZero() && (wake.IsZero() || s.readDeadline.Before(wake)) {
+			wake = s.readDeadline
+		}
+		s.wait(wake)
+	}
+}
+
+// write queues the data on the link, and waits for the link to be done carrying it.
+func (s *stream) write(b []byte, config LinkConfig, lossDelay time.Duration) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.writerClosed {
+		return 0, errClosed
+	}
+	if s.err != nil {
+		return 0, s.err
+	}
+	if s.readerClosed {
+		return 0, errBrokenPipe
+	}
+	now := s.clock.Now()
+	if !s.writeDeadline.IsZero() && !now.Before(s.writeDeadline) {
+		return 0, timeoutError{}
+	}
+	if len(b) == 0 {
+		return 0, nil
+	}
+
+	sent := now
+	if s.busyUntil.After(sent) {
+		sent = s.busyUntil
+	}
+	if config.Bandwidth > 0 {
+		sent = sent.Add(time.Duration(len(b)) * time.Second / time.Duration(config.Bandwidth))
+	}
+	s.busyUntil = sent
+	deliverAt := sent.Add(config.Latency + lossDelay)
+	if deliverAt.Before(s.lastDelivery) {
+		deliverAt = s.lastDelivery
+	}
+	s.lastDelivery = deliverAt
+	s.segments = append(s.segments, segment{data: append([]byte(nil), b...), deliverAt: deliverAt})
+	s.cond.Broadcast()
+
+	for {
+		if s.writerClosed || s.err != nil {
+			// the data was already queued.
+			return len(b), nil
+		}
+		now = s.clock.Now()
+		if !now.Before(sent) {
+			return len(b), nil
+		}
+		if !s.writeDeadline.IsZero() && !now.Before(s.writeDeadline) {
+			return len(b), timeoutError{}
+		}
+		wake := sent
+		if !s.writeDeadline.IsZero() && s.writeDeadline.Before(wake) {
+			wake = s.writeDeadline
+		}
+		s.wait(wake)
+	}
+}
+
+func (s *stream) closeWriter() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.writerClosed = true
+	s.cond.Broadcast()
+}
+
+func (s *stream) closeReader() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.readerClosed = true
+	s.segments = nil
+	s.cond.Broadcast()
+}
+
+func (s *stream) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err == nil {
+		s.err = errConnectionReset
+	}
+	s.segments = nil
+	s.cond.Broadcast()
+}
+
+func (s *stream) setReadDeadline(t time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.readDeadline = t
+	s.cond.Broadcast()
+}
+
+func (s *stream) setWriteDeadline(t time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.writeDeadline = t
+	s.cond.Broadcast()
+}
+
+// endpoint is one end of a connection.
+type endpoint struct {
+	host *Host
+	addr *net.TCPAddr
+}
+
+// connPair holds both ends of a connection.
+type connPair struct {
+	client *conn
+	server *conn
+
+	mu         deadlock.Mutex
+	closedEnds int
+}
+
+func makeConnPair(n *Network, client, server endpoint) *connPair {
+	up := makeStream(n.clock)
+	down := makeStream(n.clock)
+	pair := &connPair{}
+	pair.client = &conn{net: n, pair: pair, local: client, remote: server, in: down, out: up}
+	pair.server = &conn{net: n, pair: pair, local: server, remote: client, in: up, out: down}
+	return pair
+}
+
+// reset resets both ends of the connection.
+func (p *connPair) reset() {
+	p.client.in.reset()
+	p.client.out.reset()
+}
+
+// closed records that one of the ends was closed, and tells whether both are.
+func (p *connPair) closed() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closedEnds++
+	return p.closedEnds == 2
+}
+
+// conn is one end of a connection between two hosts. It implements net.Conn.
+type conn struct {
+	net       *Network
+	pair      *connPair
+	local     endpoint
+	remote    endpoint
+	in        *stream
+	out       *stream
+	closeOnce sync.Once
+}
+
+// Read reads the data delivered to this end of the connection.
+func (c *conn) Read(b []byte) (int, error) {
+	return c.in.read(b)
+}
+
+// Write writes data to the other end of the connection, through the link between the hosts.
+func (c *conn) Write(b []byte) (int, error) {
+	config, lossDelay := c.net.transmission(c.local.host, c.remote.host)
+	return c.out.write(b, config, lossDelay)
+}
+
+// Close closes this end of the connection. The other end reads the data written so far, and then io.EOF.
+func (c *conn) Close() error {
+	c.closeOnce.Do(func() {
+		c.out.closeWriter()
+		c.in.closeReader()
+		if c.pair.closed() {
+			c.net.removeConnPair(c.pair)
+		}
+	})
+	return nil
+}
+
+// LocalAddr returns the address of this end of the connection.
+func (c *conn) LocalAddr() net.Addr {
+	return c.local.addr
+}
+
+// RemoteAddr returns the address of the other end of the connection.
+func (c *conn) RemoteAddr() net.Addr {
+	return c.remote.addr
+}
+
+// SetDeadline sets both the read and the write deadlines.
+func (c *conn) SetDeadline(t time.Time) error {
+	c.in.setReadDeadline(t)
+	c.out.setWriteDeadline(t)
+	return nil
+}
+
+// SetReadDeadline sets the time after which the reads fail with a timeout; a zero time means no deadline.
+func (c *conn) SetReadDeadline(t time.Time) error {
+	c.in.setReadDeadline(t)
+	return nil
+}
+
+// SetWriteDeadline sets the time after which the writes fail with a timeout; a zero time means no deadline.
+func (c *conn) SetWriteDeadline(t time.Time) error {
+	c.out.setWriteDeadline(t)
+	return nil
+}
@@ -0,0 +1,403 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package simnet implements an in-process simulated network, on which many gossip networks, and so many full nodes,
+// can run within a single process. Each host of the simulated network is a network.Transport, and the links between
+// the hosts have a configurable latency, bandwidth and loss, and can be partitioned.
+package simnet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/algorand/go-deadlock"
+)
+
+// firstPort is the first port allocated to the listeners on port 0 and to the outgoing connections.
+const firstPort = 30000
+
+// listenBacklog is the number of connections a listener holds before they are accepted; the connections attempted
+// once the backlog is full are refused.
+const listenBacklog = 128
+
+// minRetransmitTimeout is the minimal delay after which lost data is retransmitted.
+const minRetransmitTimeout = 200 * time.Millisecond
+
+// maxRetransmissions is the maximal number of times the same data is lost.
+const maxRetransmissions = 6
+
+var errConnectionRefused = errors.New("connection refused")
+var errHostUnreachable = errors.New("no route to host")
+var errAddressInUse = errors.New("address already in use")
+var errConnectionReset = errors.New("connection reset by peer")
+
+// LinkConfig describes the behavior of the link between two hosts.
+type LinkConfig struct {
+	// Latency is the one way delay of the link.
+	Latency time.Duration
+
+	// Bandwidth is the rate at which the link carries the data, in bytes per second; zero stands for an unlimited
+	// bandwidth. The writes to a connection block for as long as the link takes to carry their data.
+	Bandwidth int64
+
+	// Loss is the probability that the data of a write is lost. Like with TCP, lost data is retransmitted, and so
+	// is delivered late rather than never.
+	Loss float64
+}
+
+// retransmitTimeout returns the delay after which data lost on the link is retransmitted.
+func (l LinkConfig) retransmitTimeout() time.Duration {
+	if rto := 2 * l.Latency; rto > minRetransmitTimeout {
+		return rto
+	}
+	return minRetransmitTimeout
+}
+
+type link struct {
+	from string
+	to   string
+}
+
+// Network is an in-process simulated network. Its time is kept by its clock, and the data losses of each of its links
+// are drawn from a random source derived from the seed of the network and the hosts of the link, so that the losses
+// of a link don't depend on the traffic of the others. On a ManualClock, the simulations are reproducible.
+type Network struct {
+	mu deadlock.Mutex
+
+	clock Clock
+	seed  int64
+	rands map[link]*rand.Rand
+
+	hosts      map[string]*Host
+	hostsByIP  map[string]*Host
+	listeners  map[string]*listener
+	connPairs  map[*connPair]bool
+	partitions map[string]int
+
+	defaultLink LinkConfig
+	links       map[link]LinkConfig
+}
+
+// MakeNetwork creates an empty simulated network running on the given clock, whose links have no latency, no loss and
+// an unlimited bandwidth.
+func MakeNetwork(seed int64, clock Clock) *Network {
+	return &Network{
+		clock:      clock,
+		seed:       seed,
+		rands:      make(map[link]*rand.Rand),
+		hosts:      make(map[string]*Host),
+		hostsByIP:  make(map[string]*Host),
+		listeners:  make(map[string]*listener),
+		connPairs:  make(map[*connPair]bool),
+		partitions: make(map[string]int),
+		links:      make(map[link]LinkConfig),
+	}
+}
+
+// AddHost adds a host to the network. Its address is both its name and an IPv4 address allocated by the network.
+func (n *Network) AddHost(name string) *Host {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if h, has := n.hosts[name]; has {
+		return h
+	}
+	index := len(n.hosts) + 1
+	h := &Host{
+		net:      n,
+		name:     name,
+		ip:       net.IPv4(10, byte(index>>16), byte(index>>8), byte(index)),
+		nextPort: firstPort,
+	}
+	n.hosts[name] = h
+	n.hostsByIP[h.ip.String()] = h
+	return h
+}
+
+// SetDefaultLink sets the configuration of the links which weren't configured with SetLink.
+func (n *Network) SetDefaultLink(config LinkConfig) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.defaultLink = config
+}
+
+// SetLink sets the configuration of the link between the two given hosts, in both directions. It applies to the
+// data written from then on.
+func (n *Network) SetLink(host1, host2 string, config LinkConfig) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.links[link{from: host1, to: host2}] = config
+	n.links[link{from: host2, to: host1}] = config
+}
+
+// Partition splits the network: the hosts of each of the given groups can only reach the hosts of the same group,
+// and the hosts which aren't part of any group can only reach each other. The connections between hosts which can't
+// reach each other any more are reset.
+func (n *Network) Partition(groups ...[]string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.partitions = make(map[string]int)
+	for i, group := range groups {
+		for _, name := range group {
+			n.partitions[name] = i + 1
+		}
+	}
+	for pair := range n.connPairs {
+		if !n.reachable(pair.client.local.host, pair.client.remote.host) {
+			delete(n.connPairs, pair)
+			pair.reset()
+		}
+	}
+}
+
+// Heal removes the partitions of the network.
+func (n *Network) Heal() {
+	n.Partition()
+}
+
+// reachable tells whether the data of the first host can reach the second. The lock must be held.
+func (n *Network) reachable(from, to *Host) bool {
+	return n.partitions[from.name] == n.partitions[to.name]
+}
+
+// link returns the configuration of the link from a host to another. The lock must be held.
+func (n *Network) link(from, to *Host) LinkConfig {
+	if config, has := n.links[link{from: from.name, to: to.name}]; has {
+		return config
+	}
+	return n.defaultLink
+}
+
+// transmission returns the configuration of the link the data of a write is transmitted on, and for how long the
+// data is delayed by the losses.
+func (n *Network) transmission(from, to *Host) (config LinkConfig, lossDelay time.Duration) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	config = n.link(from, to)
+	if config.Loss <= 0 {
+		return
+	}
+	l := link{from: from.name, to: to.name}
+	r := n.rands[l]
+	if r == nil {
+		h := fnv.New64a()
+		h.Write([]byte(l.from))
+		h.Write([]byte{0})
+		h.Write([]byte(l.to))
+		r = rand.New(rand.NewSource(n.seed ^ int64(h.Sum64())))
+		n.rands[l] = r
+	}
+	rto := config.retransmitTimeout()
+	for i := 0; i < maxRetransmissions && r.Float64() < config.Loss; i++ {
+		lossDelay += rto
+		rto *= 2
+	}
+	return
+}
+
+// resolve returns the host with the given name or IP address.
+func (n *Network) resolve(host string) (*Host, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if h, has := n.hosts[host]; has {
+		return h, nil
+	}
+	if h, has := n.hostsByIP[host]; has {
+		return h, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+// Host is a host of a simulated network. It implements network.Transport.
+type Host struct {
+	net  *Network
+	name string
+	ip   net.IP
+
+	// nextPort is the next port to allocate; it's protected by the lock of the network.
+	nextPort int
+}
+
+// Name returns the name of the host.
+func (h *Host) Name() string {
+	return h.name
+}
+
+// IP returns the simulated IP address of the host.
+func (h *Host) IP() net.IP {
+	return h.ip
+}
+
+// allocatePort returns a port of the host which is not in use. The lock of the network must be held.
+func (h *Host) allocatePort() int {
+	for {
+		port := h.nextPort
+		h.nextPort++
+		if _, used := h.net.listeners[h.addr(port).String()]; !used {
+			return port
+		}
+	}
+}
+
+func (h *Host) addr(port int) *net.TCPAddr {
+	return &net.TCPAddr{IP: h.ip, Port: port}
+}
+
+// Listen listens for the incoming connections on the given port of the host; the host part of the address is
+// ignored, and a port 0 gets a port allocated.
+func (h *Host) Listen(address string) (net.Listener, error) {
+	_, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %s", portStr)
+	}
+
+	n := h.net
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if port == 0 {
+		port = h.allocatePort()
+	}
+	addr := h.addr(port)
+	if _, used := n.listeners[addr.String()]; used {
+		return nil, &net.OpError{Op: "listen", Net: "tcp", Addr: addr, Err: errAddressInUse}
+	}
+	l := &listener{
+		host:   h,
+		addr:   addr,
+		accept: make(chan *conn, listenBacklog),
+		closed: make(chan struct{}),
+	}
+	n.listeners[addr.String()] = l
+	return l, nil
+}
+
+// DialContext connects to the given address; the host part of the address is either the name or the IP address of
+// a host of the network. Setting the connection up takes a round trip on the link.
+func (h *Host) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	if !strings.HasPrefix(network, "tcp") {
+		return nil, &net.OpError{Op: "dial", Net: network, Err: net.UnknownNetworkError(network)}
+	}
+	hostName, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %s", portStr)
+	}
+	remote, err := h.net.resolve(hostName)
+	if err != nil {
+		return nil, &net.OpError{Op: "dial", Net: network, Err: err}
+	}
+	remoteAddr := remote.addr(port)
+
+	n := h.net
+	n.mu.Lock()
+	roundTrip := n.link(h, remote).Latency + n.link(remote, h).Latency
+	n.mu.Unlock()
+	if roundTrip > 0 {
+		established := make(chan struct{})
+		stop := n.clock.Schedule(n.clock.Now().Add(roundTrip), func() { close(established) })
+		select {
+		case <-established:
+		case <-ctx.Done():
+			stop()
+			return nil, ctx.Err()
+		}
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if !n.reachable(h, remote) {
+		return nil, &net.OpError{Op: "dial", Net: network, Addr: remoteAddr, Err: errHostUnreachable}
+	}
+	l := n.listeners[remoteAddr.String()]
+	if l == nil {
+		return nil, &net.OpError{Op: "dial", Net: network, Addr: remoteAddr, Err: errConnectionRefused}
+	}
+	pair := makeConnPair(n, endpoint{host: h, addr: h.addr(h.allocatePort())}, endpoint{host: remote, addr: remoteAddr})
+	select {
+	case l.accept <- pair.server:
+	default:
+		// the backlog of the listener is full.
+		return nil, &net.OpError{Op: "dial", Net: network, Addr: remoteAddr, Err: errConnectionRefused}
+	}
+	n.connPairs[pair] = true
+	return pair.client, nil
+}
+
+// removeConnPair forgets a connection whose ends were closed.
+func (n *Network) removeConnPair(pair *connPair) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.connPairs, pair)
+}
+
+// listener is a listener on a port of a host.
+type listener struct {
+	host      *Host
+	addr      *net.TCPAddr
+	accept    chan *conn
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// Accept waits for the next connection to the listener.
+func (l *listener) Accept() (net.Conn, error) {
+	select {
+	case c := <-l.accept:
+		return c, nil
+	case <-l.closed:
+		return nil, &net.OpError{Op: "accept", Net: "tcp", Addr: l.addr, Err: errClosed}
+	}
+}
+
+// Close stops listening, and resets the connections which weren't accepted yet.
+func (l *listener) Close() error {
+	l.closeOnce.Do(func() {
+		n := l.host.net
+		n.mu.Lock()
+		delete(n.listeners, l.addr.String())
+		n.mu.Unlock()
+		close(l.closed)
+		for {
+			select {
+			case c := <-l.accept:
+				n.removeConnPair(c.pair)
+				c.pair.reset()
+			default:
+				return
+			}
+		}
+	})
+	return nil
+}
+
+// Addr returns the address the listener listens on.
+func (l *listener) Addr() net.Addr {
+	return l.addr
+}
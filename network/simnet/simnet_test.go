@@ -0,0 +1,364 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package simnet
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// waitScheduled waits for the given number of calls to be scheduled on the clock, which tells that the goroutines
+// making them are waiting on the simulated network.
+func waitScheduled(t *testing.T, clock *ManualClock, count int) {
+	require.Eventually(t, func() bool {
+		clock.mu.Lock()
+		defer clock.mu.Unlock()
+		return len(clock.timers) == count
+	}, 5*time.Second, time.Millisecond)
+}
+
+// connect returns both ends of a new connection from a host to another, linked without latency.
+func connect(t *testing.T, from, to *Host) (client, server net.Conn) {
+	l, err := to.Listen(":0")
+	require.NoError(t, err)
+	defer l.Close()
+	client, err = from.DialContext(context.Background(), "tcp", l.Addr().String())
+	require.NoError(t, err)
+	server, err = l.Accept()
+	require.NoError(t, err)
+	require.Equal(t, client.LocalAddr().String(), server.RemoteAddr().String())
+	require.Equal(t, server.LocalAddr().String(), client.RemoteAddr().String())
+	return
+}
+
+// readAsync reads from the connection in another goroutine.
+func readAsync(conn net.Conn) <-chan string {
+	read := make(chan string, 1)
+	go func() {
+		buf := make([]byte, 100)
+		count, _ := conn.Read(buf)
+		read <- string(buf[:count])
+	}()
+	return read
+}
+
+func TestLatency(t *testing.T) {
+	clock := MakeManualClock(time.Unix(0, 0))
+	n := MakeNetwork(1, clock)
+	a := n.AddHost("a")
+	b := n.AddHost("b")
+	n.SetLink("a", "b", LinkConfig{Latency: 50 * time.Millisecond})
+	l, err := b.Listen(":4160")
+	require.NoError(t, err)
+	defer l.Close()
+
+	// setting the connection up takes a round trip.
+	dialed := make(chan net.Conn, 1)
+	go func() {
+		conn, err := a.DialContext(context.Background(), "tcp", "b:4160")
+		require.NoError(t, err)
+		dialed <- conn
+	}()
+	waitScheduled(t, clock, 1)
+	clock.Advance(99 * time.Millisecond)
+	require.Empty(t, dialed)
+	clock.Advance(time.Millisecond)
+	client := <-dialed
+	defer client.Close()
+	server, err := l.Accept()
+	require.NoError(t, err)
+	defer server.Close()
+
+	_, err = client.Write([]byte("hello"))
+	require.NoError(t, err)
+	read := readAsync(server)
+	waitScheduled(t, clock, 1)
+	clock.Advance(49 * time.Millisecond)
+	require.Empty(t, read)
+	clock.Advance(time.Millisecond)
+	require.Equal(t, "hello", <-read)
+}
+
+func TestBandwidth(t *testing.T) {
+	clock := MakeManualClock(time.Unix(0, 0))
+	n := MakeNetwork(1, clock)
+	a := n.AddHost("a")
+	b := n.AddHost("b")
+	n.SetLink("a", "b", LinkConfig{Bandwidth: 100000})
+	client, server := connect(t, a, b)
+	defer client.Close()
+	defer server.Close()
+
+	// the writes wait for the link to carry their data, 50ms each.
+	written := make(chan error, 1)
+	go func() {
+		for i := 0; i < 4; i++ {
+			if _, err := client.Write(make([]byte, 5000)); err != nil {
+				written <- err
+				return
+			}
+		}
+		written <- nil
+	}()
+	start := clock.Now()
+	for i := 0; i < 4; i++ {
+		waitScheduled(t, clock, 1)
+		clock.Advance(50 * time.Millisecond)
+	}
+	require.NoError(t, <-written)
+	require.Equal(t, 200*time.Millisecond, clock.Now().Sub(start))
+
+	data, err := ioutil.ReadAll(io.LimitReader(server, 20000))
+	require.NoError(t, err)
+	require.Len(t, data, 20000)
+}
+
+// deliveries writes numbered messages from a to b over a lossy link, and returns the simulated times at which b
+// receives them. The hosts a and c exchange messages all along, on a link of their own.
+func deliveries(t *testing.T, seed int64, otherTraffic int) []time.Duration {
+	clock := MakeManualClock(time.Unix(0, 0))
+	n := MakeNetwork(seed, clock)
+	a := n.AddHost("a")
+	b := n.AddHost("b")
+	c := n.AddHost("c")
+	client, server := connect(t, a, b)
+	defer client.Close()
+	defer server.Close()
+	otherClient, otherServer := connect(t, a, c)
+	defer otherClient.Close()
+	defer otherServer.Close()
+	n.SetDefaultLink(LinkConfig{Latency: 10 * time.Millisecond, Loss: 0.3})
+
+	start := clock.Now()
+	for i := 0; i < 20; i++ {
+		_, err := client.Write([]byte{byte(i)})
+		require.NoError(t, err)
+		for j := 0; j < otherTraffic; j++ {
+			_, err := otherClient.Write([]byte{byte(j)})
+			require.NoError(t, err)
+		}
+	}
+
+	// poll the connection at every simulated millisecond, a deadline in the past returning the data already
+	// delivered.
+	var times []time.Duration
+	buf := make([]byte, 1)
+	for len(times) < 20 {
+		clock.Advance(time.Millisecond)
+		require.NoError(t, server.SetReadDeadline(clock.Now()))
+		for {
+			count, err := server.Read(buf)
+			if err != nil {
+				break
+			}
+			require.Equal(t, 1, count)
+			require.Equal(t, byte(len(times)), buf[0])
+			times = append(times, clock.Now().Sub(start))
+		}
+	}
+	return times
+}
+
+func TestDeterministicDelivery(t *testing.T) {
+	times := deliveries(t, 7, 0)
+	require.Equal(t, times, deliveries(t, 7, 0))
+	// the traffic of the other links doesn't change the losses of a link.
+	require.Equal(t, times, deliveries(t, 7, 3))
+	require.NotEqual(t, times, deliveries(t, 8, 0))
+
+	var late int
+	for _, delivery := range times {
+		require.True(t, delivery >= 10*time.Millisecond)
+		if delivery >= 10*time.Millisecond+minRetransmitTimeout {
+			late++
+		}
+	}
+	require.True(t, late > 0)
+}
+
+func TestLossDeterminism(t *testing.T) {
+	lossDelays := func(seed int64) (delays []time.Duration) {
+		n := MakeNetwork(seed, MakeManualClock(time.Unix(0, 0)))
+		a := n.AddHost("a")
+		b := n.AddHost("b")
+		n.SetDefaultLink(LinkConfig{Latency: 10 * time.Millisecond, Loss: 0.5})
+		for i := 0; i < 100; i++ {
+			_, delay := n.transmission(a, b)
+			delays = append(delays, delay)
+		}
+		return
+	}
+
+	delays := lossDelays(7)
+	require.Equal(t, delays, lossDelays(7))
+	var lost int
+	for _, delay := range delays {
+		if delay > 0 {
+			lost++
+			require.True(t, delay >= minRetransmitTimeout)
+		}
+	}
+	require.True(t, lost > 25 && lost < 75)
+}
+
+func TestManualClock(t *testing.T) {
+	clock := MakeManualClock(time.Unix(0, 0))
+	var calls []int
+	clock.Schedule(time.Unix(2, 0), func() { calls = append(calls, 2) })
+	clock.Schedule(time.Unix(1, 0), func() {
+		calls = append(calls, 1)
+		require.Equal(t, time.Unix(1, 0), clock.Now())
+		clock.Schedule(time.Unix(1, 500), func() { calls = append(calls, 3) })
+	})
+	stop := clock.Schedule(time.Unix(1, 0), func() { calls = append(calls, 4) })
+	require.True(t, stop())
+	require.False(t, stop())
+	clock.Schedule(time.Unix(3, 0), func() { calls = append(calls, 5) })
+
+	clock.Advance(2 * time.Second)
+	require.Equal(t, []int{1, 3, 2}, calls)
+	require.Equal(t, time.Unix(2, 0), clock.Now())
+
+	// the calls scheduled in the past are made right away.
+	done := make(chan struct{})
+	clock.Schedule(time.Unix(1, 0), func() { close(done) })
+	<-done
+}
+
+func TestPartition(t *testing.T) {
+	n := MakeNetwork(1, MakeManualClock(time.Unix(0, 0)))
+	a := n.AddHost("a")
+	b := n.AddHost("b")
+	c := n.AddHost("c")
+	l, err := b.Listen(":4160")
+	require.NoError(t, err)
+	defer l.Close()
+	_, err = b.Listen(":4160")
+	require.Error(t, err)
+
+	client, server := connect(t, a, b)
+	defer client.Close()
+	defer server.Close()
+	clientC, serverC := connect(t, c, b)
+	defer clientC.Close()
+	defer serverC.Close()
+
+	n.Partition([]string{"a"})
+	// the connections across the partition are reset.
+	_, err = server.Read(make([]byte, 1))
+	require.Equal(t, errConnectionReset, err)
+	_, err = client.Write([]byte("hello"))
+	require.Equal(t, errConnectionReset, err)
+	_, err = a.DialContext(context.Background(), "tcp", "b:4160")
+	require.Error(t, err)
+	// the hosts on the same side of the partition still reach each other.
+	_, err = clientC.Write([]byte("hello"))
+	require.NoError(t, err)
+	conn, err := c.DialContext(context.Background(), "tcp", "b:4160")
+	require.NoError(t, err)
+	conn.Close()
+
+	n.Heal()
+	conn, err = a.DialContext(context.Background(), "tcp", b.IP().String()+":4160")
+	require.NoError(t, err)
+	conn.Close()
+}
+
+func TestCloseAndDeadlines(t *testing.T) {
+	clock := MakeManualClock(time.Unix(0, 0))
+	n := MakeNetwork(1, clock)
+	a := n.AddHost("a")
+	b := n.AddHost("b")
+	client, server := connect(t, a, b)
+
+	require.NoError(t, server.SetReadDeadline(clock.Now().Add(20*time.Millisecond)))
+	readErr := make(chan error, 1)
+	go func() {
+		_, err := server.Read(make([]byte, 1))
+		readErr <- err
+	}()
+	waitScheduled(t, clock, 1)
+	clock.Advance(20 * time.Millisecond)
+	err := <-readErr
+	netErr, ok := err.(net.Error)
+	require.True(t, ok)
+	require.True(t, netErr.Timeout())
+
+	// a deadline in the past interrupts a pending read.
+	require.NoError(t, server.SetReadDeadline(time.Time{}))
+	go func() {
+		_, err := server.Read(make([]byte, 1))
+		readErr <- err
+	}()
+	require.NoError(t, server.SetReadDeadline(time.Unix(0, 0)))
+	require.Equal(t, timeoutError{}, <-readErr)
+	require.NoError(t, server.SetReadDeadline(time.Time{}))
+
+	// the data written before closing is still delivered.
+	_, err = client.Write([]byte("bye"))
+	require.NoError(t, err)
+	require.NoError(t, client.Close())
+	data, err := ioutil.ReadAll(server)
+	require.NoError(t, err)
+	require.Equal(t, "bye", string(data))
+	_, err = server.Write([]byte("hello"))
+	require.Equal(t, errBrokenPipe, err)
+	require.NoError(t, server.Close())
+
+	l, err := b.Listen(":0")
+	require.NoError(t, err)
+	require.NoError(t, l.Close())
+	_, err = a.DialContext(context.Background(), "tcp", l.Addr().String())
+	require.Error(t, err)
+	_, err = a.DialContext(context.Background(), "tcp", "unknown:4160")
+	require.Error(t, err)
+}
+
+func TestHTTPOverSimulatedNetwork(t *testing.T) {
+	// the HTTP stack has timers of its own, which run in real time.
+	n := MakeNetwork(1, SystemClock())
+	n.SetDefaultLink(LinkConfig{Latency: 5 * time.Millisecond, Bandwidth: 1000000})
+	client := n.AddHost("client")
+	server := n.AddHost("server")
+
+	l, err := server.Listen(":8080")
+	require.NoError(t, err)
+	httpServer := http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.RemoteAddr))
+	})}
+	go httpServer.Serve(l)
+	defer httpServer.Close()
+
+	httpClient := http.Client{Transport: &http.Transport{DialContext: client.DialContext}}
+	for i := 0; i < 3; i++ {
+		resp, err := httpClient.Get("http://server:8080/")
+		require.NoError(t, err)
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		require.NoError(t, err)
+		host, _, err := net.SplitHostPort(string(body))
+		require.NoError(t, err)
+		require.Equal(t, client.IP().String(), host)
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"context"
+	"net"
+)
+
+// Transport provides the connections the gossip network runs on: the listener of the incoming connections, and the
+// outgoing connections of both the websocket peers and the HTTP requests. The network runs on TCP by default, and
+// runs on another transport, such as the in-process simulated network of the simnet package, once set with
+// SetTransport.
+type Transport interface {
+	// Listen listens for the incoming connections on the given local address.
+	Listen(address string) (net.Listener, error)
+
+	// DialContext connects to the given address on the named network.
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// tcpTransport is the default Transport, running on the TCP stack of the host.
+type tcpTransport struct {
+	netDialer
+}
+
+// Listen listens for the incoming TCP connections on the given local address.
+func (t tcpTransport) Listen(address string) (net.Listener, error) {
+	return net.Listen("tcp", address)
+}
@@ -0,0 +1,86 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/network/simnet"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+func TestWebsocketNetworkSimulatedTransport(t *testing.T) {
+	// the websocket network has timers of its own, which run in real time.
+	sim := simnet.MakeNetwork(1, simnet.SystemClock())
+	sim.SetDefaultLink(simnet.LinkConfig{Latency: 20 * time.Millisecond})
+
+	conf := defaultConfig
+	conf.GossipFanout = 1
+	netA := makeTestWebsocketNodeWithConfig(t, conf)
+	netA.SetTransport(sim.AddHost("a"))
+	netA.Start()
+	defer netA.Stop()
+	netB := makeTestWebsocketNodeWithConfig(t, conf)
+	netB.SetTransport(sim.AddHost("b"))
+	addrA, postListen := netA.Address()
+	require.True(t, postListen)
+	parsedA, err := url.Parse(addrA)
+	require.NoError(t, err)
+	hostA, _, err := net.SplitHostPort(parsedA.Host)
+	require.NoError(t, err)
+	require.Equal(t, sim.AddHost("a").IP().String(), hostA)
+	netB.phonebook.ReplacePeerList([]string{addrA}, "default", PhoneBookEntryRelayRole)
+	netB.Start()
+	defer netB.Stop()
+
+	readyTimeout := time.NewTimer(2 * time.Second)
+	waitReady(t, netA, readyTimeout.C)
+	waitReady(t, netB, readyTimeout.C)
+
+	received := make(chan time.Time, 1)
+	netB.RegisterHandlers([]TaggedMessageHandler{{Tag: protocol.TxnTag, MessageHandler: HandlerFunc(func(msg IncomingMessage) OutgoingMessage {
+		received <- time.Now()
+		return OutgoingMessage{}
+	})}})
+	sent := time.Now()
+	require.NoError(t, netA.Broadcast(context.Background(), protocol.TxnTag, []byte("txn"), true, nil))
+	select {
+	case at := <-received:
+		require.True(t, at.Sub(sent) >= 20*time.Millisecond)
+	case <-time.After(2 * time.Second):
+		require.Fail(t, "timeout waiting for the message")
+	}
+
+	// the partition disconnects the nodes, which reconnect once it heals.
+	sim.Partition([]string{"a"})
+	require.Eventually(t, func() bool { return netA.NumPeers() == 0 && netB.NumPeers() == 0 }, 2*time.Second, 10*time.Millisecond)
+	netB.RequestConnectOutgoing(false, nil)
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, 0, netB.NumPeers())
+
+	sim.Heal()
+	require.Eventually(t, func() bool {
+		netB.RequestConnectOutgoing(false, nil)
+		return netA.NumPeers() == 1 && netB.NumPeers() == 1
+	}, 5*time.Second, 100*time.Millisecond)
+}
@@ -0,0 +1,370 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"errors"
+	"time"
+
+	"github.com/algorand/go-deadlock"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// The transaction announcements replace the flooding of the transaction messages between peers which both support
+// them: instead of the transaction groups, the peers exchange batches of the digests of the groups ( TxnAnnounceTag ),
+// and request the groups they haven't seen yet ( TxnRequestTag ), which are then sent as regular TxnTag messages.
+
+// txnAnnouncementsVersion is the first protocol version supporting the transaction announcements.
+const txnAnnouncementsVersion = "2.2"
+
+// maxTxnAnnouncementDigests is the maximal number of digests in an announcement or a request message.
+const maxTxnAnnouncementDigests = 512
+
+// txnAnnouncementDelay is for how long the digests are accumulated before being announced, so that they are sent in
+// batches.
+const txnAnnouncementDelay = 20 * time.Millisecond
+
+// txnRequestRetryInterval is for how long a requested transaction group isn't requested again from other peers.
+const txnRequestRetryInterval = 2 * time.Second
+
+// TxnRequestRetryCheckInterval is how often the unanswered transaction group requests are to be retried, using
+// TxnRequestTracker.Retries .
+const TxnRequestRetryCheckInterval = txnRequestRetryInterval / 4
+
+// txnBodyCacheBucketSize is the number of transaction groups held by each of the two buckets of the cache serving
+// the requests.
+const txnBodyCacheBucketSize = 10000
+
+// txnRequestTrackerSize is the number of digests held by each of the two generations of a TxnRequestTracker.
+const txnRequestTrackerSize = 50000
+
+// txnRequestMaxAnnouncers is the maximal number of other announcing peers a requested transaction group is remembered
+// to be requested from.
+const txnRequestMaxAnnouncers = 4
+
+var errBadTxnDigestsLength = errors.New("transaction digests message length isn't a multiple of the digest size")
+var errTooManyTxnDigests = errors.New("transaction digests message has too many digests")
+
+// TxnDigest returns the digest identifying the transaction group message data in announcements and requests.
+func TxnDigest(data []byte) crypto.Digest {
+	return crypto.Hash(data)
+}
+
+// EncodeTxnDigests encodes the digests of an announcement or of a request message.
+func EncodeTxnDigests(digests []crypto.Digest) []byte {
+	data := make([]byte, 0, len(digests)*crypto.DigestSize)
+	for _, digest := range digests {
+		data = append(data, digest[:]...)
+	}
+	return data
+}
+
+// DecodeTxnDigests decodes the digests of an announcement or of a request message.
+func DecodeTxnDigests(data []byte) ([]crypto.Digest, error) {
+	if len(data)%crypto.DigestSize != 0 {
+		return nil, errBadTxnDigestsLength
+	}
+	if len(data)/crypto.DigestSize > maxTxnAnnouncementDigests {
+		return nil, errTooManyTxnDigests
+	}
+	digests := make([]crypto.Digest, len(data)/crypto.DigestSize)
+	for i := range digests {
+		copy(digests[i][:], data[i*crypto.DigestSize:])
+	}
+	return digests, nil
+}
+
+// TxnRequestTracker keeps track of the transaction groups a node has seen or requested, so that the announced groups
+// are requested once, from one of the peers announcing them. The other peers announcing a requested group are
+// remembered, and the group is requested from the next one of them if the request isn't answered in time.
+type TxnRequestTracker struct {
+	mu deadlock.Mutex
+	// current and previous hold the state of the seen and of the requested groups. Once current is full, it becomes
+	// the previous generation.
+	current  map[crypto.Digest]*txnRequestState
+	previous map[crypto.Digest]*txnRequestState
+	// requests holds the requests which were made, in the order they were made, which is also the order in which
+	// they expire.
+	requests []txnRequest
+}
+
+// txnRequestState is the state of a transaction group which was seen or requested.
+type txnRequestState struct {
+	seen bool
+	// requested is the time of the latest request of the group.
+	requested time.Time
+	// announcers are the peers which announced the group, and weren't requested for it yet.
+	announcers []UnicastPeer
+}
+
+// txnRequest is a request of a transaction group, made at the given time.
+type txnRequest struct {
+	digest    crypto.Digest
+	requested time.Time
+}
+
+// TxnRequests are the transaction groups to request from a peer.
+type TxnRequests struct {
+	Peer    UnicastPeer
+	Digests []crypto.Digest
+}
+
+// MakeTxnRequestTracker creates a new TxnRequestTracker.
+func MakeTxnRequestTracker() *TxnRequestTracker {
+	return &TxnRequestTracker{
+		current:  make(map[crypto.Digest]*txnRequestState),
+		previous: make(map[crypto.Digest]*txnRequestState),
+	}
+}
+
+func (t *TxnRequestTracker) get(digest crypto.Digest) *txnRequestState {
+	if state, found := t.current[digest]; found {
+		return state
+	}
+	if state, found := t.previous[digest]; found {
+		return state
+	}
+	if len(t.current) >= txnRequestTrackerSize {
+		t.previous = t.current
+		t.current = make(map[crypto.Digest]*txnRequestState, txnRequestTrackerSize)
+	}
+	state := &txnRequestState{}
+	t.current[digest] = state
+	return state
+}
+
+func (t *TxnRequestTracker) request(digest crypto.Digest, state *txnRequestState, now time.Time) {
+	state.requested = now
+	t.requests = append(t.requests, txnRequest{digest: digest, requested: now})
+}
+
+// MarkSeen records that the transaction group with the given digest was received.
+func (t *TxnRequestTracker) MarkSeen(digest crypto.Digest) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	state := t.get(digest)
+	state.seen = true
+	state.announcers = nil
+}
+
+// Missing returns the digests announced by the given peer which need to be requested from it, and records them as
+// requested. A digest needs to be requested if its group wasn't seen, and if it wasn't already requested recently;
+// otherwise, the peer is remembered as one to request the group from, should the pending request go unanswered.
+func (t *TxnRequestTracker) Missing(digests []crypto.Digest, announcer UnicastPeer, now time.Time) []crypto.Digest {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var missing []crypto.Digest
+	for _, digest := range digests {
+		state := t.get(digest)
+		if state.seen {
+			continue
+		}
+		if !state.requested.IsZero() && now.Sub(state.requested) < txnRequestRetryInterval {
+			if len(state.announcers) < txnRequestMaxAnnouncers {
+				state.announcers = append(state.announcers, announcer)
+			}
+			continue
+		}
+		t.request(digest, state, now)
+		missing = append(missing, digest)
+	}
+	return missing
+}
+
+// Retries returns the transaction groups whose requests went unanswered for txnRequestRetryInterval, grouped by the
+// next peers which announced them, and records them as requested from these peers.
+func (t *TxnRequestTracker) Retries(now time.Time) []TxnRequests {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var retries []TxnRequests
+	peerRetries := make(map[UnicastPeer]int)
+	for len(t.requests) > 0 && now.Sub(t.requests[0].requested) >= txnRequestRetryInterval {
+		expired := t.requests[0]
+		t.requests = t.requests[1:]
+		state, found := t.current[expired.digest]
+		if !found {
+			state, found = t.previous[expired.digest]
+		}
+		// the group is no longer tracked, was received, was requested again since, or has no one else to ask.
+		if !found || state.seen || !state.requested.Equal(expired.requested) || len(state.announcers) == 0 {
+			continue
+		}
+		peer := state.announcers[0]
+		state.announcers = state.announcers[1:]
+		t.request(expired.digest, state, now)
+		idx, ok := peerRetries[peer]
+		if !ok {
+			idx = len(retries)
+			peerRetries[peer] = idx
+			retries = append(retries, TxnRequests{Peer: peer})
+		}
+		retries[idx].Digests = append(retries[idx].Digests, expired.digest)
+	}
+	if len(t.requests) == 0 {
+		// release the memory of the popped requests.
+		t.requests = nil
+	}
+	return retries
+}
+
+// txnBodyCache holds the recently broadcasted transaction groups, so that the requests for them can be served.
+type txnBodyCache struct {
+	mu       deadlock.Mutex
+	current  map[crypto.Digest][]byte
+	previous map[crypto.Digest][]byte
+}
+
+func makeTxnBodyCache() *txnBodyCache {
+	return &txnBodyCache{
+		current:  make(map[crypto.Digest][]byte),
+		previous: make(map[crypto.Digest][]byte),
+	}
+}
+
+func (c *txnBodyCache) add(digest crypto.Digest, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.current) >= txnBodyCacheBucketSize {
+		c.previous = c.current
+		c.current = make(map[crypto.Digest][]byte, txnBodyCacheBucketSize)
+	}
+	c.current[digest] = data
+}
+
+func (c *txnBodyCache) get(digest crypto.Digest) (data []byte, found bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if data, found = c.current[digest]; found {
+		return
+	}
+	data, found = c.previous[digest]
+	return
+}
+
+// txnAnnouncer batches the announcements to a peer, and keeps track of the transaction groups the peer is known to
+// have, which aren't announced to it.
+type txnAnnouncer struct {
+	mu             deadlock.Mutex
+	known          *messageFilter
+	pending        []crypto.Digest
+	flushScheduled bool
+}
+
+func makeTxnAnnouncer() *txnAnnouncer {
+	return &txnAnnouncer{
+		known: makeMessageFilter(2, txnBodyCacheBucketSize),
+	}
+}
+
+// markKnown records that the peer has the transaction group with the given digest.
+func (a *txnAnnouncer) markKnown(digest crypto.Digest) {
+	a.known.CheckDigest(digest, true, false)
+}
+
+// announceTxns queues the announcement of the given transaction groups to the peer, except for the ones it's known
+// to have.
+func (wp *wsPeer) announceTxns(digests []crypto.Digest) {
+	a := wp.txnAnnouncer
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, digest := range digests {
+		if a.known.CheckDigest(digest, true, false) {
+			continue
+		}
+		a.pending = append(a.pending, digest)
+	}
+	if len(a.pending) >= maxTxnAnnouncementDigests {
+		wp.sendTxnAnnouncementLocked()
+		return
+	}
+	if len(a.pending) > 0 && !a.flushScheduled {
+		a.flushScheduled = true
+		time.AfterFunc(txnAnnouncementDelay, wp.flushTxnAnnouncements)
+	}
+}
+
+func (wp *wsPeer) flushTxnAnnouncements() {
+	a := wp.txnAnnouncer
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.flushScheduled = false
+	wp.sendTxnAnnouncementLocked()
+}
+
+// sendTxnAnnouncementLocked sends the pending announcements, in messages of up to maxTxnAnnouncementDigests digests.
+// The announcer lock must be held.
+func (wp *wsPeer) sendTxnAnnouncementLocked() {
+	a := wp.txnAnnouncer
+	for len(a.pending) > 0 {
+		batch := a.pending
+		if len(batch) > maxTxnAnnouncementDigests {
+			batch = batch[:maxTxnAnnouncementDigests]
+		}
+		a.pending = a.pending[len(batch):]
+		data := append([]byte(protocol.TxnAnnounceTag), EncodeTxnDigests(batch)...)
+		wp.writeNonBlock(wp.net.ctx, data, false, crypto.Digest{}, time.Now())
+	}
+	a.pending = nil
+}
+
+// handleTxnRequest sends the requested transaction groups which are still held by the cache.
+func (wp *wsPeer) handleTxnRequest(msg IncomingMessage) {
+	digests, err := DecodeTxnDigests(msg.Data)
+	if err != nil {
+		wp.net.log.Warnf("bad transaction request message from %s : %v", wp.conn.RemoteAddr().String(), err)
+		return
+	}
+	if wp.net.txnBodies == nil {
+		return
+	}
+	tbytes := []byte(protocol.TxnTag)
+	var msgs [][]byte
+	var msgDigests []crypto.Digest
+	for _, digest := range digests {
+		body, found := wp.net.txnBodies.get(digest)
+		if !found {
+			continue
+		}
+		mbytes := make([]byte, len(tbytes)+len(body))
+		copy(mbytes, tbytes)
+		copy(mbytes[len(tbytes):], body)
+		msgs = append(msgs, mbytes)
+		msgDigests = append(msgDigests, crypto.Digest{})
+	}
+	if len(msgs) > 0 {
+		wp.writeNonBlockMsgs(wp.net.ctx, msgs, false, msgDigests, nil, time.Now())
+	}
+}
+
+// recordKnownTxns records the transaction groups the peer sent or announced, so that they aren't announced back.
+func (wp *wsPeer) recordKnownTxns(msg IncomingMessage) {
+	switch msg.Tag {
+	case protocol.TxnTag:
+		wp.txnAnnouncer.markKnown(TxnDigest(msg.Data))
+	case protocol.TxnAnnounceTag:
+		digests, err := DecodeTxnDigests(msg.Data)
+		if err != nil {
+			// the handler of the announcements deals with the malformed ones.
+			return
+		}
+		for _, digest := range digests {
+			wp.txnAnnouncer.markKnown(digest)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+func TestTxnDigestsEncoding(t *testing.T) {
+	digests := []crypto.Digest{crypto.Hash([]byte("a")), crypto.Hash([]byte("b"))}
+	data := EncodeTxnDigests(digests)
+	require.Len(t, data, 2*crypto.DigestSize)
+	decoded, err := DecodeTxnDigests(data)
+	require.NoError(t, err)
+	require.Equal(t, digests, decoded)
+
+	decoded, err = DecodeTxnDigests(nil)
+	require.NoError(t, err)
+	require.Empty(t, decoded)
+
+	_, err = DecodeTxnDigests(data[1:])
+	require.Equal(t, errBadTxnDigestsLength, err)
+	_, err = DecodeTxnDigests(make([]byte, (maxTxnAnnouncementDigests+1)*crypto.DigestSize))
+	require.Equal(t, errTooManyTxnDigests, err)
+}
+
+func TestTxnRequestTracker(t *testing.T) {
+	tracker := MakeTxnRequestTracker()
+	now := time.Now()
+	seen := crypto.Hash([]byte("seen"))
+	announced := crypto.Hash([]byte("announced"))
+	first, second := &wsPeer{}, &wsPeer{}
+
+	tracker.MarkSeen(seen)
+	require.Equal(t, []crypto.Digest{announced}, tracker.Missing([]crypto.Digest{seen, announced}, first, now))
+	// the group was just requested from another peer.
+	require.Empty(t, tracker.Missing([]crypto.Digest{seen, announced}, second, now.Add(time.Second)))
+	require.Empty(t, tracker.Retries(now.Add(time.Second)))
+	// the request wasn't answered; the group is requested from the other peer which announced it.
+	require.Equal(t, []TxnRequests{{Peer: second, Digests: []crypto.Digest{announced}}}, tracker.Retries(now.Add(txnRequestRetryInterval)))
+	// no other peer announced it since.
+	require.Empty(t, tracker.Retries(now.Add(2*txnRequestRetryInterval)))
+	// a new announcement of it has it requested again right away.
+	require.Equal(t, []crypto.Digest{announced}, tracker.Missing([]crypto.Digest{announced}, first, now.Add(2*txnRequestRetryInterval)))
+	tracker.MarkSeen(announced)
+	require.Empty(t, tracker.Missing([]crypto.Digest{announced}, second, now.Add(4*txnRequestRetryInterval)))
+	require.Empty(t, tracker.Retries(now.Add(4*txnRequestRetryInterval)))
+}
+
+func TestTxnRequestTrackerRetries(t *testing.T) {
+	tracker := MakeTxnRequestTracker()
+	now := time.Now()
+	peers := make([]*wsPeer, txnRequestMaxAnnouncers+2)
+	for i := range peers {
+		peers[i] = &wsPeer{}
+	}
+	digests := []crypto.Digest{crypto.Hash([]byte("a")), crypto.Hash([]byte("b"))}
+
+	require.Equal(t, digests, tracker.Missing(digests, peers[0], now))
+	for _, peer := range peers[1:] {
+		require.Empty(t, tracker.Missing(digests, peer, now.Add(time.Millisecond)))
+	}
+	// the groups are requested from one announcer after the other, up to txnRequestMaxAnnouncers of them.
+	for i := 1; i <= txnRequestMaxAnnouncers; i++ {
+		retries := tracker.Retries(now.Add(time.Duration(i) * txnRequestRetryInterval))
+		require.Equal(t, []TxnRequests{{Peer: peers[i], Digests: digests}}, retries)
+	}
+	require.Empty(t, tracker.Retries(now.Add(time.Duration(txnRequestMaxAnnouncers+1)*txnRequestRetryInterval)))
+	require.Empty(t, tracker.requests)
+}
+
+func TestTxnBodyCache(t *testing.T) {
+	cache := makeTxnBodyCache()
+	first := crypto.Hash([]byte("first"))
+	cache.add(first, []byte("first"))
+	for i := 0; i < txnBodyCacheBucketSize; i++ {
+		cache.add(crypto.Hash([]byte(strconv.Itoa(i))), nil)
+	}
+	// the first group moved to the previous generation.
+	data, found := cache.get(first)
+	require.True(t, found)
+	require.Equal(t, []byte("first"), data)
+	for i := 0; i < txnBodyCacheBucketSize; i++ {
+		cache.add(crypto.Hash([]byte("more"+strconv.Itoa(i))), nil)
+	}
+	_, found = cache.get(first)
+	require.False(t, found)
+}
+
+// txnGossipSimNode is a relay of the transaction gossip simulation. Like the transaction handler, it relays the new
+// transaction groups, and requests the announced groups it hasn't seen.
+type txnGossipSimNode struct {
+	net      *WebsocketNetwork
+	requests *TxnRequestTracker
+
+	mu       sync.Mutex
+	received map[crypto.Digest]bool
+}
+
+func (n *txnGossipSimNode) handleTxn(msg IncomingMessage) OutgoingMessage {
+	digest := TxnDigest(msg.Data)
+	n.requests.MarkSeen(digest)
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.received[digest] {
+		return OutgoingMessage{Action: Ignore}
+	}
+	n.received[digest] = true
+	return OutgoingMessage{Action: Broadcast}
+}
+
+func (n *txnGossipSimNode) handleTxnAnnouncement(msg IncomingMessage) OutgoingMessage {
+	digests, err := DecodeTxnDigests(msg.Data)
+	if err != nil {
+		return OutgoingMessage{Action: Disconnect}
+	}
+	peer := msg.Sender.(UnicastPeer)
+	if missing := n.requests.Missing(digests, peer, time.Now()); len(missing) > 0 {
+		peer.Unicast(context.Background(), EncodeTxnDigests(missing), protocol.TxnRequestTag)
+	}
+	return OutgoingMessage{Action: Ignore}
+}
+
+func (n *txnGossipSimNode) numReceived() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return len(n.received)
+}
+
+// txnGossipBytesSent returns the number of bytes sent by all the networks of the process to gossip the transactions.
+func txnGossipBytesSent() (total uint64) {
+	values := make(map[string]string)
+	networkSentBytesByTag.AddMetric(values)
+	for _, tag := range []protocol.Tag{protocol.TxnTag, protocol.TxnAnnounceTag, protocol.TxnRequestTag} {
+		sent, _ := strconv.ParseUint(values["algod_network_sent_bytes_"+string(tag)], 10, 64)
+		total += sent
+	}
+	return
+}
+
+// simulateTxnGossip connects a full mesh of relays, broadcasts transaction groups from one of them, and returns the
+// number of bytes sent until all the relays received all the groups.
+func simulateTxnGossip(t *testing.T, numRelays, numTxns int, announcements bool) uint64 {
+	conf := defaultConfig
+	conf.BaseLoggerDebugLevel = uint32(logging.Info)
+	conf.GossipFanout = numRelays - 1
+	conf.EnableMessageCompression = false
+	conf.EnableTransactionAnnouncements = announcements
+	// the inbound transactions are queued losslessly, so that only the gossip strategy affects the bandwidth.
+	conf.EnableInboundPriorityQueues = false
+
+	nodes := make([]*txnGossipSimNode, numRelays)
+	var addrs []string
+	for i := range nodes {
+		node := &txnGossipSimNode{
+			net:      makeTestWebsocketNodeWithConfig(t, conf),
+			requests: MakeTxnRequestTracker(),
+			received: make(map[crypto.Digest]bool),
+		}
+		node.net.RegisterHandlers([]TaggedMessageHandler{
+			{Tag: protocol.TxnTag, MessageHandler: HandlerFunc(node.handleTxn)},
+			{Tag: protocol.TxnAnnounceTag, MessageHandler: HandlerFunc(node.handleTxnAnnouncement)},
+		})
+		// each relay connects to the relays started before it, so that there is a single connection between any two.
+		node.net.phonebook.ReplacePeerList(addrs, "default", PhoneBookEntryRelayRole)
+		node.net.Start()
+		defer node.net.Stop()
+		addr, postListen := node.net.Address()
+		require.True(t, postListen)
+		addrs = append(addrs, addr)
+		nodes[i] = node
+	}
+	require.Eventually(t, func() bool {
+		for _, node := range nodes {
+			if node.net.NumPeers() != numRelays-1 {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+
+	start := txnGossipBytesSent()
+	for i := 0; i < numTxns; i++ {
+		txn := make([]byte, 512)
+		crypto.RandBytes(txn)
+		nodes[0].handleTxn(IncomingMessage{Data: txn})
+		require.NoError(t, nodes[0].net.Broadcast(context.Background(), protocol.TxnTag, txn, true, nil))
+	}
+	require.Eventually(t, func() bool {
+		for _, node := range nodes {
+			if node.numReceived() != numTxns {
+				return false
+			}
+		}
+		return true
+	}, 10*time.Second, 10*time.Millisecond)
+	return txnGossipBytesSent() - start
+}
+
+func TestTxnGossipAnnouncementsBandwidth(t *testing.T) {
+	const numRelays = 5
+	const numTxns = 200
+	floodBytes := simulateTxnGossip(t, numRelays, numTxns, false)
+	announcementBytes := simulateTxnGossip(t, numRelays, numTxns, true)
+	t.Logf("transaction gossip among %d relays: %d bytes flooded, %d bytes announced", numRelays, floodBytes, announcementBytes)
+
+	// when flooding, every relay sends every group to every peer it didn't receive it from first, while with the
+	// announcements every relay receives every group once.
+	require.Less(t, announcementBytes, floodBytes/2)
+}
+
+func TestTxnAnnouncementsVersionNegotiation(t *testing.T) {
+	conf := defaultConfig
+	conf.GossipFanout = 1
+	conf.EnableTransactionAnnouncements = true
+	netA := makeTestWebsocketNodeWithConfig(t, conf)
+	netA.Start()
+	defer netA.Stop()
+
+	// the old nodes only support the previous version, and keep receiving the transactions in full.
+	// note - this test changes the SupportedProtocolVersions global variable and therefore cannot be parallelized.
+	originalSupportedProtocolVersions := SupportedProtocolVersions
+	defer func() {
+		SupportedProtocolVersions = originalSupportedProtocolVersions
+	}()
+	oldConf := conf
+	oldConf.NetworkProtocolVersion = "2.1"
+	netB := makeTestWebsocketNodeWithConfig(t, oldConf)
+	addrA, postListen := netA.Address()
+	require.True(t, postListen)
+	netB.phonebook.ReplacePeerList([]string{addrA}, "default", PhoneBookEntryRelayRole)
+	netB.Start()
+	defer netB.Stop()
+
+	readyTimeout := time.NewTimer(2 * time.Second)
+	waitReady(t, netA, readyTimeout.C)
+	waitReady(t, netB, readyTimeout.C)
+	peersA, _ := netA.peerSnapshot(nil)
+	require.Len(t, peersA, 1)
+	require.Equal(t, "2.1", peersA[0].version)
+	require.Nil(t, peersA[0].txnAnnouncer)
+
+	received := make(chan []byte, 1)
+	netB.RegisterHandlers([]TaggedMessageHandler{{Tag: protocol.TxnTag, MessageHandler: HandlerFunc(func(msg IncomingMessage) OutgoingMessage {
+		received <- msg.Data
+		return OutgoingMessage{}
+	})}})
+	require.NoError(t, netA.Broadcast(context.Background(), protocol.TxnTag, []byte("txn"), true, nil))
+	select {
+	case data := <-received:
+		require.Equal(t, []byte("txn"), data)
+	case <-time.After(2 * time.Second):
+		require.Fail(t, "timeout waiting for the transaction")
+	}
+}
@@ -47,6 +47,7 @@ import (
 	"github.com/algorand/go-algorand/crypto"
 	"github.com/algorand/go-algorand/logging"
 	"github.com/algorand/go-algorand/logging/telemetryspec"
+	"github.com/algorand/go-algorand/network/messagerecorder"
 	"github.com/algorand/go-algorand/protocol"
 	tools_network "github.com/algorand/go-algorand/tools/network"
 	"github.com/algorand/go-algorand/tools/network/dnssec"
@@ -156,6 +157,16 @@ type GossipNode interface {
 	RelayArray(ctx context.Context, tag []protocol.Tag, data [][]byte, wait bool, except Peer) error
 	Disconnect(badnode Peer)
 	DisconnectPeers()
+
+	// ReportPeer reports a misbehavior of the peer. The penalties of the offenses accumulate, and a peer whose
+	// penalty reaches the ban threshold gets disconnected and banned for a while.
+	ReportPeer(peer Peer, offense PeerOffense)
+
+	// BanPeer bans the hosts of the peer for the given duration, and drops the connections with them.
+	BanPeer(peer Peer, duration time.Duration) error
+
+	// AddPhonebookEntry adds an address to the phonebook, which the node may then connect to.
+	AddPhonebookEntry(address string, role PhoneBookEntryRoles)
 	Ready() chan struct{}
 
 	// RegisterHTTPHandler path accepts gorilla/mux path annotations
@@ -312,7 +323,11 @@ type WebsocketNetwork struct {
 
 	log logging.Logger
 
-	readBuffer chan IncomingMessage
+	// readBuffer holds the messages received from the peers until they're handled by the messageHandlerThread(s).
+	readBuffer *inboundQueues
+
+	// inboundRateLimits are the limits of the rate at which each peer may send the messages of a tag.
+	inboundRateLimits map[protocol.Tag]inboundRateLimit
 
 	wg sync.WaitGroup
 
@@ -348,6 +363,26 @@ type WebsocketNetwork struct {
 
 	incomingMsgFilter *messageFilter // message filter to remove duplicate incoming messages from different peers
 
+	peerScores *peerScoreboard // misbehavior penalties and bans of the peers
+
+	addressBook *addressBook // connection quality history of the relays
+
+	addressFamilies *addressFamilyTracker // reachability of the IP families of the relay addresses
+
+	// listenAddresses are the addresses the node listens on; the first one is the one of NetAddress.
+	listenAddresses []string
+
+	txnBodies *txnBodyCache // recently broadcasted transaction groups, for serving the transaction requests; nil when the announcements are disabled
+
+	identityKey noiseKeyPair // identity key the node authenticates with during the encrypted handshake
+
+	// encryptedAddresses are the addresses of the peers which completed the encrypted handshake
+	encryptedAddresses encryptedPeerAddresses
+
+	recorder      *messagerecorder.Recorder // recorder of the incoming messages; nil when the recording is disabled
+	replayPeersMu deadlock.Mutex
+	replayPeers   map[string]*replayPeer // the recorded peers, by name, of the replayed messages
+
 	eventualReadyDelay time.Duration
 
 	relayMessages bool // True if we should relay messages from other nodes (nominally true for relays, false otherwise)
@@ -386,6 +421,9 @@ type WebsocketNetwork struct {
 	transport rateLimitingTransport
 	dialer    Dialer
 
+	// netTransport provides the connections the dialer and the listener run on.
+	netTransport Transport
+
 	// messagesOfInterest specifies the message types that this node
 	// wants to receive.  nil means default.  non-nil causes this
 	// map to be sent to new peers as a MsgOfInterest message type.
@@ -445,6 +483,21 @@ func (wn *WebsocketNetwork) PublicAddress() string {
 	return localAddr
 }
 
+// PublicAddresses returns all the addresses we tell other nodes to connect to, starting with PublicAddress. They are
+// the configured public addresses if there are any, and the addresses the node listens on otherwise.
+func (wn *WebsocketNetwork) PublicAddresses() []string {
+	addresses := []string{wn.PublicAddress()}
+	if len(wn.config.PublicAddress) > 0 {
+		return append(addresses, sortedAddresses(wn.config.AdditionalPublicAddresses)...)
+	}
+	for i, listenAddress := range wn.listenAddresses {
+		if i > 0 {
+			addresses = append(addresses, (&url.URL{Scheme: wn.scheme, Host: listenAddress}).String())
+		}
+	}
+	return addresses
+}
+
 // Broadcast sends a message.
 // If except is not nil then we will not send it to that neighboring Peer.
 // if wait is true then the call blocks until the packet has actually been sent to all neighbors.
@@ -540,14 +593,107 @@ func (wn *WebsocketNetwork) Disconnect(node Peer) {
 
 // Disconnect from a peer, probably due to protocol errors.
 func (wn *WebsocketNetwork) disconnect(badnode Peer, reason disconnectReason) {
-	if badnode == nil {
+	// the replayed peers aren't connected.
+	peer, ok := badnode.(*wsPeer)
+	if !ok {
 		return
 	}
-	peer := badnode.(*wsPeer)
 	peer.CloseAndWait()
 	wn.removePeer(peer, reason)
 }
 
+// ReportPeer reports a misbehavior of the peer, disconnecting it if the offense is severe or if it got it banned.
+func (wn *WebsocketNetwork) ReportPeer(peer Peer, offense PeerOffense) {
+	banned := wn.penalizePeer(peer, offense)
+	if wsp, ok := peer.(*wsPeer); ok && !banned && offense.disconnects() {
+		wn.disconnect(wsp, disconnectBadData)
+	}
+}
+
+// penalizePeer adds the penalty of the offense to the peer score. If the peer got banned, all the connections with
+// its hosts are dropped. It returns true if the peer got banned.
+func (wn *WebsocketNetwork) penalizePeer(peer Peer, offense PeerOffense) (banned bool) {
+	hosts := peerHosts(peer)
+	if wn.isRelayPeer(peer, hosts) {
+		// the relays are exempt from the bans; their offenses are only counted.
+		hosts = nil
+	}
+	if !wn.peerScores.penalize(hosts, offense, time.Now()) {
+		return false
+	}
+	wn.log.Infof("banning peer %v after %v", hosts, offense)
+	if err := wn.peerScores.saveBans(); err != nil {
+		wn.log.Warnf("unable to save the peer bans: %v", err)
+	}
+	wn.disconnectHosts(hosts)
+	return true
+}
+
+// isRelayPeer returns whether the peer, identified on the scoreboard by the given hosts, is a relay the node connected
+// to, or shares its host with a relay of the phonebook.
+func (wn *WebsocketNetwork) isRelayPeer(peer Peer, hosts []string) bool {
+	if wsp, ok := peer.(*wsPeer); ok && wsp.outgoing {
+		return true
+	}
+	for _, addr := range wn.phonebook.AllAddresses(PhoneBookEntryRelayRole) {
+		relayHost := addrHost(addr)
+		for _, host := range hosts {
+			if host == relayHost {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// disconnectHosts drops all the connections with the given hosts.
+func (wn *WebsocketNetwork) disconnectHosts(hosts []string) {
+	bannedHosts := make(map[string]bool, len(hosts))
+	for _, host := range hosts {
+		bannedHosts[host] = true
+	}
+	peers, _ := wn.peerSnapshot(nil)
+	for _, connectedPeer := range peers {
+		for _, host := range peerHosts(connectedPeer) {
+			if bannedHosts[host] {
+				wn.wg.Add(1)
+				go wn.disconnectThread(connectedPeer, disconnectBanned)
+				break
+			}
+		}
+	}
+}
+
+// SetAddressBookFile sets the file the relays connection quality history is persisted to, and loads the history it
+// holds.
+func (wn *WebsocketNetwork) SetAddressBookFile(file string) {
+	if err := wn.addressBook.load(file, time.Now()); err != nil {
+		wn.log.Warnf("unable to load the address book from %s: %v", file, err)
+	}
+}
+
+// peerLog returns the logger of the events of the peer, which includes the identity the peer authenticated with.
+func (wn *WebsocketNetwork) peerLog(peer *wsPeer) logging.Logger {
+	if id, authenticated := peer.Identity(); authenticated {
+		return wn.log.With("identity", id.String())
+	}
+	return wn.log
+}
+
+// SetTransport sets the transport the network listens and dials through, in place of TCP. It must be called before
+// the network is started.
+func (wn *WebsocketNetwork) SetTransport(t Transport) {
+	wn.netTransport = t
+	wn.dialer.innerDialer = t
+}
+
+// SetPeerBansFile sets the file the peer bans are persisted to, and loads the bans it holds.
+func (wn *WebsocketNetwork) SetPeerBansFile(bansFile string) {
+	if err := wn.peerScores.loadBans(bansFile, time.Now()); err != nil {
+		wn.log.Warnf("unable to load the peer bans from %s: %v", bansFile, err)
+	}
+}
+
 func closeWaiter(wg *sync.WaitGroup, peer *wsPeer) {
 	defer wg.Done()
 	peer.CloseAndWait()
@@ -657,6 +803,7 @@ func (wn *WebsocketNetwork) setup() {
 	}
 	maxIdleConnsPerHost := int(wn.config.ConnectionsRateLimitingCount)
 	wn.dialer = makeRateLimitingDialer(wn.phonebook, preferredResolver)
+	wn.netTransport = tcpTransport{wn.dialer.innerDialer}
 	wn.transport = makeRateLimitingTransport(wn.phonebook, 10*time.Second, &wn.dialer, maxIdleConnsPerHost)
 
 	wn.upgrader.ReadBufferSize = 4096
@@ -709,7 +856,8 @@ func (wn *WebsocketNetwork) setup() {
 	if readBufferLen > 10000 {
 		readBufferLen = 10000
 	}
-	wn.readBuffer = make(chan IncomingMessage, readBufferLen)
+	wn.readBuffer = makeInboundQueues(readBufferLen, wn.config.EnableInboundPriorityQueues)
+	wn.inboundRateLimits = makeInboundRateLimits(wn.config, wn.log)
 
 	var rbytes [10]byte
 	crypto.RandBytes(rbytes[:])
@@ -718,6 +866,21 @@ func (wn *WebsocketNetwork) setup() {
 	if wn.config.EnableIncomingMessageFilter {
 		wn.incomingMsgFilter = makeMessageFilter(wn.config.IncomingMessageFilterBucketCount, wn.config.IncomingMessageFilterBucketSize)
 	}
+	wn.addressBook = makeAddressBook()
+	wn.addressFamilies = makeAddressFamilyTracker()
+	if wn.config.EnableTransactionAnnouncements {
+		wn.txnBodies = makeTxnBodyCache()
+	}
+	if wn.config.EnablePeerEncryption {
+		// the node uses a temporary identity, unless it's given an identity key file.
+		identityKey, err := generateNoiseKeyPair()
+		if err != nil {
+			wn.log.Errorf("unable to generate an identity key, disabling the encrypted handshake : %v", err)
+			wn.config.EnablePeerEncryption = false
+		}
+		wn.identityKey = identityKey
+	}
+	wn.peerScores = makePeerScoreboard(wn.config.PeerBanPenaltyThreshold, time.Duration(wn.config.PeerBanDurationSeconds)*time.Second)
 	wn.connPerfMonitor = makeConnectionPerformanceMonitor([]Tag{protocol.AgreementVoteTag, protocol.TxnTag})
 	wn.lastNetworkAdvance = time.Now().UTC()
 	wn.handlers.log = wn.log
@@ -755,16 +918,31 @@ func (wn *WebsocketNetwork) Start() {
 	}
 
 	if wn.config.NetAddress != "" {
-		listener, err := net.Listen("tcp", wn.config.NetAddress)
+		listener, err := wn.netTransport.Listen(wn.config.NetAddress)
 		if err != nil {
 			wn.log.Errorf("network could not listen %v: %s", wn.config.NetAddress, err)
 			return
 		}
-		// wrap the original listener with a limited connection listener
-		listener = netutil.LimitListener(listener, wn.config.IncomingConnectionsLimit)
+		listeners := []net.Listener{listener}
+		for _, address := range sortedAddresses(wn.config.AdditionalNetAddresses) {
+			// an unusable additional address, e.g. an IPv6 one on a host without IPv6, doesn't prevent the relay from
+			// serving on its other addresses.
+			additionalListener, err := wn.netTransport.Listen(address)
+			if err != nil {
+				wn.log.Errorf("network could not listen %v: %s", address, err)
+				continue
+			}
+			listeners = append(listeners, additionalListener)
+		}
+		wn.listenAddresses = make([]string, len(listeners))
+		for i, l := range listeners {
+			wn.listenAddresses[i] = l.Addr().String()
+		}
+		// wrap the original listeners with a limited connection listener
+		listener = netutil.LimitListener(makeMultiListener(listeners), wn.config.IncomingConnectionsLimit)
 		// wrap the limited connection listener with a requests tracker listener
 		wn.listener = wn.requestsTracker.Listener(listener)
-		wn.log.Debugf("listening on %s", wn.listener.Addr().String())
+		wn.log.Debugf("listening on %v", wn.listenAddresses)
 		wn.throttledOutgoingConnections = int32(wn.config.GossipFanout / 2)
 	} else {
 		// on non-relay, all the outgoing connections are throttled.
@@ -866,6 +1044,14 @@ func (wn *WebsocketNetwork) Stop() {
 	if wn.listener != nil {
 		wn.log.Debugf("closed %s", listenAddr)
 	}
+	if err := wn.peerScores.saveBans(); err != nil {
+		wn.log.Warnf("unable to save the peer bans: %v", err)
+	}
+	wn.updateAddressBook()
+	if wn.recorder != nil {
+		wn.recorder.Close()
+		wn.recorder = nil
+	}
 
 	// Wait for the requestsTracker to finish up to avoid potential race condition
 	<-wn.requestsTracker.getWaitUntilNoConnectionsChannel(5 * time.Millisecond)
@@ -893,7 +1079,14 @@ func (wn *WebsocketNetwork) setHeaders(header http.Header) {
 	localInstanceName := wn.log.GetInstanceName()
 	header.Set(TelemetryIDHeader, localTelemetryGUID)
 	header.Set(InstanceNameHeader, localInstanceName)
-	header.Set(AddressHeader, wn.PublicAddress())
+	for i, address := range wn.PublicAddresses() {
+		// the nodes which only know about a single address take the first one.
+		if i == 0 {
+			header.Set(AddressHeader, address)
+		} else {
+			header.Add(AddressHeader, address)
+		}
+	}
 	header.Set(NodeRandomHeader, wn.RandomID)
 }
 
@@ -936,8 +1129,33 @@ func getCommonHeaders(headers http.Header) (otherTelemetryGUID, otherInstanceNam
 	return
 }
 
+// getAdvertisedAddresses retrieves all the public addresses a peer advertised in the provided headers.
+func getAdvertisedAddresses(headers http.Header) []string {
+	var addresses []string
+	for _, address := range headers.Values(AddressHeader) {
+		if address = logging.SanitizeTelemetryString(address, 1); address != "" {
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses
+}
+
 // checkIncomingConnectionLimits perform the connection limits counting for the incoming connections.
 func (wn *WebsocketNetwork) checkIncomingConnectionLimits(response http.ResponseWriter, request *http.Request, remoteHost, otherTelemetryGUID, otherInstanceName string) int {
+	if wn.peerScores.isBanned(remoteHost, time.Now()) {
+		networkConnectionsDroppedTotal.Inc(map[string]string{"reason": "banned"})
+		wn.log.EventWithDetails(telemetryspec.Network, telemetryspec.ConnectPeerFailEvent,
+			telemetryspec.ConnectPeerFailEventDetails{
+				Address:      remoteHost,
+				HostName:     otherTelemetryGUID,
+				Incoming:     true,
+				InstanceName: otherInstanceName,
+				Reason:       "Banned",
+			})
+		response.WriteHeader(http.StatusForbidden)
+		return http.StatusForbidden
+	}
+
 	if wn.numIncomingPeers() >= wn.config.IncomingConnectionsLimit {
 		networkConnectionsDroppedTotal.Inc(map[string]string{"reason": "incoming_connection_limit"})
 		wn.log.EventWithDetails(telemetryspec.Network, telemetryspec.ConnectPeerFailEvent,
@@ -1085,6 +1303,19 @@ func (wn *WebsocketNetwork) ServeHTTP(response http.ResponseWriter, request *htt
 		return
 	}
 
+	encrypted := wn.encryptionAccepted(request.Header)
+	if !encrypted && !wn.identityAllowed(PeerIdentity{}, false) {
+		wn.log.Infof("new peer %s rejected: the identity allowlist requires the encrypted handshake", request.RemoteAddr)
+		networkConnectionsDroppedTotal.Inc(map[string]string{"reason": "unauthenticated"})
+		response.WriteHeader(http.StatusForbidden)
+		return
+	}
+	if !encrypted && wn.config.EnablePeerEncryption && wn.encryptedAddresses.contains(trackedRequest.remoteHost) {
+		// several nodes may share the host, and so the connection is only reported rather than refused.
+		wn.log.Warnf("new peer %s connects without the encrypted handshake, while a peer of the same host completed it before", request.RemoteAddr)
+		networkEncryptionDowngrades.Inc(nil)
+	}
+
 	// if UseXForwardedForAddressField is not empty, attempt to override the otherPublicAddr with the X Forwarded For origin
 	trackedRequest.otherPublicAddr = trackedRequest.remoteAddr
 
@@ -1092,6 +1323,16 @@ func (wn *WebsocketNetwork) ServeHTTP(response http.ResponseWriter, request *htt
 	wn.setHeaders(responseHeader)
 	responseHeader.Set(ProtocolVersionHeader, matchingVersion)
 	responseHeader.Set(GenesisHeader, wn.GenesisID)
+	var compressionCodec string
+	if wn.config.EnableMessageCompression {
+		compressionCodec = selectCompressionCodec(request.Header)
+		if compressionCodec != "" {
+			responseHeader.Set(CompressionHeader, compressionCodec)
+		}
+	}
+	if encrypted {
+		responseHeader.Set(EncryptionHeader, noiseProtocolName)
+	}
 	var challenge string
 	if wn.prioScheme != nil {
 		challenge = wn.prioScheme.NewPrioChallenge()
@@ -1104,6 +1345,19 @@ func (wn *WebsocketNetwork) ServeHTTP(response http.ResponseWriter, request *htt
 		return
 	}
 
+	var peerConn wsPeerWebsocketConn = conn
+	var identity PeerIdentity
+	if encrypted {
+		peerConn, identity, err = wn.encryptConnection(conn, false, request.Header, responseHeader)
+		if err != nil {
+			wn.log.Infof("new peer %s encrypted handshake fail : %v", trackedRequest.otherPublicAddr, err)
+			networkConnectionsDroppedTotal.Inc(map[string]string{"reason": "handshake fail"})
+			conn.Close()
+			return
+		}
+		wn.encryptedAddresses.add(trackedRequest.remoteHost)
+	}
+
 	// we want to tell the response object that the status was changed to 101 ( switching protocols ) so that it will be logged.
 	if wn.requestsLogger != nil {
 		wn.requestsLogger.SetStatusCode(response, http.StatusSwitchingProtocols)
@@ -1111,19 +1365,22 @@ func (wn *WebsocketNetwork) ServeHTTP(response http.ResponseWriter, request *htt
 
 	peer := &wsPeer{
 		wsPeerCore:        makePeerCore(wn, trackedRequest.otherPublicAddr, wn.GetRoundTripper(), trackedRequest.remoteHost),
-		conn:              conn,
+		conn:              peerConn,
 		outgoing:          false,
 		InstanceName:      trackedRequest.otherInstanceName,
 		incomingMsgFilter: wn.incomingMsgFilter,
 		prioChallenge:     challenge,
 		createTime:        trackedRequest.created,
 		version:           matchingVersion,
+		compressionCodec:  compressionCodec,
+		identity:          identity,
+		authenticated:     encrypted,
 	}
 	peer.TelemetryGUID = trackedRequest.otherTelemetryGUID
 	peer.init(wn.config, wn.outgoingMessagesBufferSize)
 	wn.addPeer(peer)
 	localAddr, _ := wn.Address()
-	wn.log.With("event", "ConnectedIn").With("remote", trackedRequest.otherPublicAddr).With("local", localAddr).Infof("Accepted incoming connection from peer %s", trackedRequest.otherPublicAddr)
+	wn.peerLog(peer).With("event", "ConnectedIn").With("remote", trackedRequest.otherPublicAddr).With("local", localAddr).Infof("Accepted incoming connection from peer %s", trackedRequest.otherPublicAddr)
 	wn.log.EventWithDetails(telemetryspec.Network, telemetryspec.ConnectPeerEvent,
 		telemetryspec.PeerEventDetails{
 			Address:      trackedRequest.remoteHost,
@@ -1147,52 +1404,81 @@ func (wn *WebsocketNetwork) ServeHTTP(response http.ResponseWriter, request *htt
 func (wn *WebsocketNetwork) messageHandlerThread(peersConnectivityCheckCh <-chan time.Time) {
 	defer wn.wg.Done()
 
+	queues := &wn.readBuffer.queues
 	for {
 		select {
 		case <-wn.ctx.Done():
 			return
-		case msg := <-wn.readBuffer:
-			if msg.processing != nil {
-				// The channel send should never block, but just in case..
-				select {
-				case msg.processing <- struct{}{}:
-				default:
-					wn.log.Warnf("could not send on msg.processing")
-				}
-			}
-			if wn.config.EnableOutgoingNetworkMessageFiltering && len(msg.Data) >= messageFilterSize {
-				wn.sendFilterMessage(msg)
-			}
-			//wn.log.Debugf("msg handling %#v [%d]byte", msg.Tag, len(msg.Data))
-			start := time.Now()
-
-			// now, send to global handlers
-			outmsg := wn.handlers.Handle(msg)
-			handled := time.Now()
-			bufferNanos := start.UnixNano() - msg.Received
-			networkIncomingBufferMicros.AddUint64(uint64(bufferNanos/1000), nil)
-			handleTime := handled.Sub(start)
-			networkHandleMicros.AddUint64(uint64(handleTime.Nanoseconds()/1000), nil)
-			switch outmsg.Action {
-			case Disconnect:
-				wn.wg.Add(1)
-				go wn.disconnectThread(msg.Sender, disconnectBadData)
-			case Broadcast:
-				err := wn.Broadcast(wn.ctx, msg.Tag, msg.Data, false, msg.Sender)
-				if err != nil && err != errBcastQFull {
-					wn.log.Warnf("WebsocketNetwork.messageHandlerThread: WebsocketNetwork.Broadcast returned unexpected error %v", err)
-				}
-			case Respond:
-				err := msg.Sender.(*wsPeer).Respond(wn.ctx, msg, outmsg.Topics)
-				if err != nil && err != wn.ctx.Err() {
-					wn.log.Warnf("WebsocketNetwork.messageHandlerThread: wsPeer.Respond returned unexpected error %v", err)
-				}
-			default:
+		default:
+		}
+		// the pending messages are handled by order of priority; when there are none, wait for the first one.
+		msg, ok := wn.readBuffer.dequeue()
+		if !ok {
+			select {
+			case <-wn.ctx.Done():
+				return
+			case msg = <-queues[inboundPriorityAgreement]:
+			case msg = <-queues[inboundPriorityProposal]:
+			case msg = <-queues[inboundPriorityCompactCert]:
+			case msg = <-queues[inboundPriorityOther]:
+			case msg = <-queues[inboundPriorityTransaction]:
+			case <-peersConnectivityCheckCh:
+				// go over the peers and ensure we have some type of communication going on.
+				wn.checkPeersConnectivity()
+				continue
 			}
-		case <-peersConnectivityCheckCh:
-			// go over the peers and ensure we have some type of communication going on.
-			wn.checkPeersConnectivity()
 		}
+		wn.handleIncomingMessage(msg)
+	}
+}
+
+// handleIncomingMessage passes a message received from a peer to the handlers of its tag, and carries out the action
+// they return.
+func (wn *WebsocketNetwork) handleIncomingMessage(msg IncomingMessage) {
+	if msg.processing != nil {
+		// The channel send should never block, but just in case..
+		select {
+		case msg.processing <- struct{}{}:
+		default:
+			wn.log.Warnf("could not send on msg.processing")
+		}
+	}
+	if wn.config.EnableOutgoingNetworkMessageFiltering && len(msg.Data) >= messageFilterSize {
+		wn.sendFilterMessage(msg)
+	}
+	wn.recordMessage(msg)
+	//wn.log.Debugf("msg handling %#v [%d]byte", msg.Tag, len(msg.Data))
+	start := time.Now()
+
+	// now, send to global handlers
+	outmsg := wn.handlers.Handle(msg)
+	handled := time.Now()
+	bufferNanos := start.UnixNano() - msg.Received
+	networkIncomingBufferMicros.AddUint64(uint64(bufferNanos/1000), nil)
+	handleTime := handled.Sub(start)
+	networkHandleMicros.AddUint64(uint64(handleTime.Nanoseconds()/1000), nil)
+	switch outmsg.Action {
+	case Disconnect:
+		// a banned peer is already being disconnected.
+		if !wn.penalizePeer(msg.Sender, OffenseInvalidMessage) {
+			wn.wg.Add(1)
+			go wn.disconnectThread(msg.Sender, disconnectBadData)
+		}
+	case Broadcast:
+		err := wn.Broadcast(wn.ctx, msg.Tag, msg.Data, false, msg.Sender)
+		if err != nil && err != errBcastQFull {
+			wn.log.Warnf("WebsocketNetwork.messageHandlerThread: WebsocketNetwork.Broadcast returned unexpected error %v", err)
+		}
+	case Respond:
+		peer, ok := msg.Sender.(*wsPeer)
+		if !ok {
+			break
+		}
+		err := peer.Respond(wn.ctx, msg, outmsg.Topics)
+		if err != nil && err != wn.ctx.Err() {
+			wn.log.Warnf("WebsocketNetwork.messageHandlerThread: wsPeer.Respond returned unexpected error %v", err)
+		}
+	default:
 	}
 }
 
@@ -1407,6 +1693,36 @@ func (wn *WebsocketNetwork) innerBroadcast(request broadcastRequest, prio bool,
 		}
 	}
 
+	// the messages are compressed once for all the peers which negotiated a compression codec.
+	var compressed [][]byte
+	for _, peer := range peers {
+		if peer.compressor != nil {
+			compressed = compressBroadcast(request.tags, data)
+			break
+		}
+	}
+
+	// the transaction groups are announced, rather than sent, to the peers supporting the announcements.
+	var txnDigests []crypto.Digest
+	var nonTxnData [][]byte
+	var nonTxnDigests []crypto.Digest
+	var nonTxnCompressed [][]byte
+	if wn.txnBodies != nil {
+		for i, d := range request.data {
+			if request.tags[i] != protocol.TxnTag {
+				nonTxnData = append(nonTxnData, data[i])
+				nonTxnDigests = append(nonTxnDigests, digests[i])
+				if compressed != nil {
+					nonTxnCompressed = append(nonTxnCompressed, compressed[i])
+				}
+				continue
+			}
+			txnDigest := TxnDigest(d)
+			wn.txnBodies.add(txnDigest, d)
+			txnDigests = append(txnDigests, txnDigest)
+		}
+	}
+
 	// first send to all the easy outbound peers who don't block, get them started.
 	sentMessageCount := 0
 	for _, peer := range peers {
@@ -1416,7 +1732,16 @@ func (wn *WebsocketNetwork) innerBroadcast(request broadcastRequest, prio bool,
 		if peer == request.except {
 			continue
 		}
-		ok := peer.writeNonBlockMsgs(request.ctx, data, prio, digests, request.enqueueTime)
+		peerData, peerDigests, peerCompressed := data, digests, compressed
+		if peer.txnAnnouncer != nil && len(txnDigests) > 0 {
+			peer.announceTxns(txnDigests)
+			if len(nonTxnData) == 0 {
+				sentMessageCount++
+				continue
+			}
+			peerData, peerDigests, peerCompressed = nonTxnData, nonTxnDigests, nonTxnCompressed
+		}
+		ok := peer.writeNonBlockMsgs(request.ctx, peerData, prio, peerDigests, peerCompressed, request.enqueueTime)
 		if ok {
 			sentMessageCount++
 			continue
@@ -1489,14 +1814,43 @@ func (wn *WebsocketNetwork) connectedForIP(host string) (totalConnections int) {
 	wn.peersLock.RLock()
 	defer wn.peersLock.RUnlock()
 	totalConnections = 0
+	host = connectionLimitHost(host, wn.config.ConnectionsIPv6PrefixLength)
 	for _, peer := range wn.peers {
-		if host == peer.OriginAddress() {
+		if host == connectionLimitHost(peer.OriginAddress(), wn.config.ConnectionsIPv6PrefixLength) {
 			totalConnections++
 		}
 	}
 	return
 }
 
+// connectedRelayAddress returns the address of the outgoing connection with the node of the given random id, if any.
+func (wn *WebsocketNetwork) connectedRelayAddress(randomID string) (string, bool) {
+	wn.peersLock.RLock()
+	defer wn.peersLock.RUnlock()
+	for _, peer := range wn.peers {
+		if peer.outgoing && peer.randomID == randomID {
+			return peer.GetAddress(), true
+		}
+	}
+	return "", false
+}
+
+// connectedRelayAddresses returns the addresses the relays we're connected to advertised, as addressKey keys.
+func (wn *WebsocketNetwork) connectedRelayAddresses() map[string]bool {
+	wn.peersLock.RLock()
+	defer wn.peersLock.RUnlock()
+	addresses := make(map[string]bool)
+	for _, peer := range wn.peers {
+		if !peer.outgoing {
+			continue
+		}
+		for _, address := range peer.advertisedAddresses {
+			addresses[addressKey(address)] = true
+		}
+	}
+	return addresses
+}
+
 const meshThreadInterval = time.Minute
 const cliqueResolveInterval = 5 * time.Minute
 
@@ -1568,6 +1922,25 @@ func (wn *WebsocketNetwork) meshThread() {
 		// telemetry server; that would allow the telemetry server
 		// to construct a cross-node map of all the nodes interconnections.
 		wn.sendPeerConnectionsTelemetryStatus()
+
+		wn.updateAddressBook()
+	}
+}
+
+// updateAddressBook records the currently connected relays, along with their ping round trip times, in the address
+// book, and saves it.
+func (wn *WebsocketNetwork) updateAddressBook() {
+	now := time.Now()
+	for _, peer := range wn.outgoingPeers() {
+		wsp := peer.(*wsPeer)
+		lastPingSent, lastPingRoundTripTime := wsp.pingTimes()
+		if now.Sub(lastPingSent) >= maxPingAge {
+			lastPingRoundTripTime = 0
+		}
+		wn.addressBook.recordConnected(wsp.GetAddress(), lastPingRoundTripTime, now)
+	}
+	if err := wn.addressBook.save(now); err != nil {
+		wn.log.Warnf("unable to save the address book: %v", err)
 	}
 }
 
@@ -1583,11 +1956,18 @@ func (wn *WebsocketNetwork) checkNewConnectionsNeeded() bool {
 	if need <= 0 {
 		return false
 	}
-	// get more than we need so that we can ignore duplicates
-	newAddrs := wn.phonebook.GetAddresses(desired+numOutgoingTotal, PhoneBookEntryRelayRole)
+	// get all the relays, and try the ones which historically performed the best first.
+	newAddrs := wn.phonebook.GetAddresses(getAllAddresses, PhoneBookEntryRelayRole)
+	wn.addressBook.rank(newAddrs)
+	wn.addressFamilies.prefer(newAddrs)
+	// filter out self-public addresses, so we won't try to connect to outselves, as well as the other addresses of
+	// the relays we're already connected to.
+	skipAddrs := wn.connectedRelayAddresses()
+	for _, address := range wn.PublicAddresses() {
+		skipAddrs[addressKey(address)] = true
+	}
 	for _, na := range newAddrs {
-		if na == wn.config.PublicAddress {
-			// filter out self-public address, so we won't try to connect to outselves.
+		if skipAddrs[addressKey(na)] {
 			continue
 		}
 		gossipAddr, ok := wn.tryConnectReserveAddr(na)
@@ -1632,6 +2012,7 @@ func (wn *WebsocketNetwork) checkExistingConnectionsNeedDisconnecting() bool {
 	for _, stat := range peerStat.peerStatistics {
 		wsPeer := stat.peer.(*wsPeer)
 		wsPeer.peerMessageDelay = stat.peerDelay
+		wn.addressBook.recordPerformance(wsPeer.GetAddress(), stat)
 		wn.log.Infof("network performance monitor - peer '%s' delay %d first message portion %d%%", wsPeer.GetAddress(), stat.peerDelay, int(stat.peerFirstMessage*100))
 		if wsPeer.throttledOutgoingConnection && leastPerformingPeer == nil {
 			leastPerformingPeer = wsPeer
@@ -1640,6 +2021,7 @@ func (wn *WebsocketNetwork) checkExistingConnectionsNeedDisconnecting() bool {
 	if leastPerformingPeer == nil {
 		return wn.checkNetworkAdvanceDisconnect()
 	}
+	wn.addressBook.recordLeastPerforming(leastPerformingPeer.GetAddress())
 	wn.disconnect(leastPerformingPeer, disconnectLeastPerformingPeer)
 	wn.connPerfMonitor.Reset([]Peer{})
 
@@ -1866,12 +2248,13 @@ const ProtocolVersionHeader = "X-Algorand-Version"
 const ProtocolAcceptVersionHeader = "X-Algorand-Accept-Version"
 
 // SupportedProtocolVersions contains the list of supported protocol versions by this node ( in order of preference ).
-var SupportedProtocolVersions = []string{"2.1"}
+var SupportedProtocolVersions = []string{"2.2", "2.1"}
 
 // ProtocolVersion is the current version attached to the ProtocolVersionHeader header
 /* Version history:
  *  1   Catchup service over websocket connections with unicast messages between peers
  *  2.1 Introduced topic key/data pairs and enabled services over the gossip connections
+ *  2.2 Introduced the transaction announcements
  */
 const ProtocolVersion = "2.1"
 
@@ -1973,6 +2356,9 @@ func (wn *WebsocketNetwork) tryConnectReserveAddr(addr string) (gossipAddr strin
 	if exists {
 		return "", false
 	}
+	if wn.peerScores.isBanned(addrHost(addr), time.Now()) {
+		return "", false
+	}
 	// WARNING: isConnectedTo takes wn.peersLock; to avoid deadlock, never try to take wn.peersLock outside an attempt to lock wn.tryConnectLock
 	if wn.isConnectedTo(addr) {
 		return "", false
@@ -2030,6 +2416,13 @@ func (wn *WebsocketNetwork) tryConnect(addr, gossipAddr string) {
 		}
 	}()
 	defer wn.wg.Done()
+	connected := false
+	defer func() {
+		// attempts aborted by the network shutdown say nothing about the relay.
+		if connected || wn.ctx.Err() == nil {
+			wn.addressBook.recordConnectionAttempt(addr, connected, time.Now())
+		}
+	}()
 	requestHeader := make(http.Header)
 	wn.setHeaders(requestHeader)
 	for _, supportedProtocolVersion := range SupportedProtocolVersions {
@@ -2040,6 +2433,14 @@ func (wn *WebsocketNetwork) tryConnect(addr, gossipAddr string) {
 	SetUserAgentHeader(requestHeader)
 	myInstanceName := wn.log.GetInstanceName()
 	requestHeader.Set(InstanceNameHeader, myInstanceName)
+	if wn.config.EnableMessageCompression {
+		for _, codec := range supportedCompressionCodecs {
+			requestHeader.Add(CompressionHeader, codec)
+		}
+	}
+	if wn.config.EnablePeerEncryption {
+		requestHeader.Set(EncryptionHeader, noiseProtocolName)
+	}
 	var websocketDialer = websocket.Dialer{
 		Proxy:             http.ProxyFromEnvironment,
 		HandshakeTimeout:  45 * time.Second,
@@ -2080,9 +2481,13 @@ func (wn *WebsocketNetwork) tryConnect(addr, gossipAddr string) {
 			}
 		} else {
 			wn.log.Warnf("ws connect(%s) fail: %s", gossipAddr, err)
+			if wn.ctx.Err() == nil {
+				wn.addressFamilies.recordConnectionAttempt(addr, false)
+			}
 		}
 		return
 	}
+	wn.addressFamilies.recordConnectionAttempt(addr, true)
 
 	// no need to test the response.StatusCode since we know it's going to be http.StatusSwitchingProtocols, as it's already being tested inside websocketDialer.DialContext.
 	// we need to examine the headers here to extract which protocol version we should be using.
@@ -2092,6 +2497,47 @@ func (wn *WebsocketNetwork) tryConnect(addr, gossipAddr string) {
 		return
 	}
 
+	// a relay listening on several addresses may be listed under more than one of them.
+	randomID := response.Header.Get(NodeRandomHeader)
+	if otherAddr, connected := wn.connectedRelayAddress(randomID); connected {
+		wn.log.Infof("ws connect(%s) aborted: already connected to the same relay through %s", gossipAddr, otherAddr)
+		conn.Close()
+		return
+	}
+
+	// the servers which don't support compression don't include the compression header in their response.
+	var compressionCodec string
+	if wn.config.EnableMessageCompression {
+		compressionCodec = selectCompressionCodec(response.Header)
+	}
+
+	// likewise, the servers which don't support the encrypted handshake don't include the encryption header.
+	encrypted := wn.encryptionAccepted(response.Header)
+	if !encrypted && !wn.identityAllowed(PeerIdentity{}, false) {
+		wn.log.Warnf("ws connect(%s) aborted: the identity allowlist requires the encrypted handshake, which the peer doesn't support", gossipAddr)
+		conn.Close()
+		return
+	}
+	if !encrypted && wn.config.EnablePeerEncryption && wn.encryptedAddresses.contains(addr) {
+		// the relay completed the encrypted handshake before, so the plain response is a downgrade.
+		wn.log.Warnf("ws connect(%s) aborted: the peer doesn't accept the encrypted handshake it completed before", gossipAddr)
+		networkEncryptionDowngrades.Inc(nil)
+		networkConnectionsDroppedTotal.Inc(map[string]string{"reason": "encryption downgrade"})
+		conn.Close()
+		return
+	}
+	var peerConn wsPeerWebsocketConn = conn
+	var identity PeerIdentity
+	if encrypted {
+		peerConn, identity, err = wn.encryptConnection(conn, true, requestHeader, response.Header)
+		if err != nil {
+			wn.log.Warnf("ws connect(%s) fail - encrypted handshake : %v", gossipAddr, err)
+			conn.Close()
+			return
+		}
+		wn.encryptedAddresses.add(addr)
+	}
+
 	throttledConnection := false
 	if atomic.AddInt32(&wn.throttledOutgoingConnections, int32(-1)) >= 0 {
 		throttledConnection = true
@@ -2101,19 +2547,25 @@ func (wn *WebsocketNetwork) tryConnect(addr, gossipAddr string) {
 
 	peer := &wsPeer{
 		wsPeerCore:                  makePeerCore(wn, addr, wn.GetRoundTripper(), "" /* origin */),
-		conn:                        conn,
+		conn:                        peerConn,
 		outgoing:                    true,
 		incomingMsgFilter:           wn.incomingMsgFilter,
 		createTime:                  time.Now(),
 		connMonitor:                 wn.connPerfMonitor,
 		throttledOutgoingConnection: throttledConnection,
 		version:                     matchingVersion,
+		compressionCodec:            compressionCodec,
+		identity:                    identity,
+		authenticated:               encrypted,
+		randomID:                    randomID,
+		advertisedAddresses:         getAdvertisedAddresses(response.Header),
 	}
 	peer.TelemetryGUID, peer.InstanceName, _ = getCommonHeaders(response.Header)
 	peer.init(wn.config, wn.outgoingMessagesBufferSize)
 	wn.addPeer(peer)
+	connected = true
 	localAddr, _ := wn.Address()
-	wn.log.With("event", "ConnectedOut").With("remote", addr).With("local", localAddr).Infof("Made outgoing connection to peer %v", addr)
+	wn.peerLog(peer).With("event", "ConnectedOut").With("remote", addr).With("local", localAddr).Infof("Made outgoing connection to peer %v", addr)
 	wn.log.EventWithDetails(telemetryspec.Network, telemetryspec.ConnectPeerEvent,
 		telemetryspec.PeerEventDetails{
 			Address:      justHost(conn.RemoteAddr().String()),
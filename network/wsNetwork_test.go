@@ -624,7 +624,7 @@ func TestSlowHandlers(t *testing.T) {
 	// start slow handler calls that will block all handler threads
 	for i := 0; i < incomingThreads; i++ {
 		data := []byte{byte(i)}
-		node.readBuffer <- IncomingMessage{Sender: &injectionPeers[ipi], Tag: slowTag, Data: data, Net: node}
+		node.readBuffer.queue(slowTag) <- IncomingMessage{Sender: &injectionPeers[ipi], Tag: slowTag, Data: data, Net: node}
 		ipi++
 	}
 	defer slowCounter.Broadcast()
@@ -632,7 +632,7 @@ func TestSlowHandlers(t *testing.T) {
 	// start fast handler calls that won't get to run
 	for i := 0; i < incomingThreads; i++ {
 		data := []byte{byte(i)}
-		node.readBuffer <- IncomingMessage{Sender: &injectionPeers[ipi], Tag: fastTag, Data: data, Net: node}
+		node.readBuffer.queue(fastTag) <- IncomingMessage{Sender: &injectionPeers[ipi], Tag: fastTag, Data: data, Net: node}
 		ipi++
 	}
 	ok := false
@@ -713,7 +713,7 @@ func TestFloodingPeer(t *testing.T) {
 				}
 
 				select {
-				case node.readBuffer <- IncomingMessage{Sender: &injectionPeers[myIpi], Tag: slowTag, Data: data, Net: node, processing: processed}:
+				case node.readBuffer.queue(slowTag) <- IncomingMessage{Sender: &injectionPeers[myIpi], Tag: slowTag, Data: data, Net: node, processing: processed}:
 				case <-ctx.Done():
 					return
 				}
@@ -735,7 +735,7 @@ func TestFloodingPeer(t *testing.T) {
 	fastCounterDone := fastCounter.done
 	for ipi < len(injectionPeers) {
 		data := []byte{byte(ipi)}
-		node.readBuffer <- IncomingMessage{Sender: &injectionPeers[ipi], Tag: fastTag, Data: data, Net: node}
+		node.readBuffer.queue(fastTag) <- IncomingMessage{Sender: &injectionPeers[ipi], Tag: fastTag, Data: data, Net: node}
 		numFast++
 		ipi++
 	}
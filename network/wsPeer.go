@@ -41,16 +41,19 @@ import (
 const maxMessageLength = 4 * 1024 * 1024 // Currently the biggest message is VB vote bundles. TODO: per message type size limit?
 const averageMessageLength = 2 * 1024    // Most of the messages are smaller than this size, which makes it into a good base allocation.
 
-// This parameter controls how many messages from a single peer can be
-// queued up in the global wsNetwork.readBuffer at a time.  Making this
-// too large will allow a small number of peers to flood the global read
-// buffer and starve messages from other peers.
+// This parameter controls how many messages of each priority class from a
+// single peer can be queued up in the global wsNetwork.readBuffer at a time.
+// The droppable transactions aren't limited per peer, but by their rate limits.
+// Making this too large will allow a small number of peers to flood the
+// global read buffer and starve messages from other peers.
 const msgsInReadBufferPerPeer = 10
 
 var networkSentBytesTotal = metrics.MakeCounter(metrics.NetworkSentBytesTotal)
 var networkSentBytesByTag = metrics.NewTagCounter("algod_network_sent_bytes_{TAG}", "Number of bytes that were sent over the network per message tag")
 var networkReceivedBytesTotal = metrics.MakeCounter(metrics.NetworkReceivedBytesTotal)
 var networkReceivedBytesByTag = metrics.NewTagCounter("algod_network_received_bytes_{TAG}", "Number of bytes that were received from the network per message tag")
+var networkSentUncompressedBytesByTag = metrics.NewTagCounter("algod_network_sent_uncompressed_bytes_{TAG}", "Number of bytes that were sent over the network per message tag, prior to their compression")
+var networkReceivedUncompressedBytesByTag = metrics.NewTagCounter("algod_network_received_uncompressed_bytes_{TAG}", "Number of bytes that were received from the network per message tag, after their decompression")
 
 var networkMessageReceivedTotal = metrics.MakeCounter(metrics.NetworkMessageReceivedTotal)
 var networkMessageReceivedByTag = metrics.NewTagCounter("algod_network_message_received_{TAG}", "Number of complete messages that were received from the network per message tag")
@@ -76,6 +79,8 @@ var defaultSendMessageTags = map[protocol.Tag]bool{
 	protocol.ProposalPayloadTag: true,
 	protocol.TopicMsgRespTag:    true,
 	protocol.MsgOfInterestTag:   true,
+	protocol.TxnAnnounceTag:     true,
+	protocol.TxnRequestTag:      true,
 	protocol.TxnTag:             true,
 	protocol.UniCatchupReqTag:   true,
 	protocol.UniEnsBlockReqTag:  true,
@@ -99,6 +104,9 @@ type sendMessage struct {
 	msgTags      map[protocol.Tag]bool // when msgTags is specified ( i.e. non-nil ), the send goroutine is to replace the message tag filter with this one. No data would be accompanied to this message.
 	hash         crypto.Digest
 	ctx          context.Context
+	// compressed is the wire encoding of data for the peers which negotiated a compression codec, when the message
+	// was compressed once for all the peers it's broadcast to.
+	compressed []byte
 }
 
 // wsPeerCore also works for non-connected peers we want to do HTTP GET from
@@ -122,6 +130,7 @@ const disconnectLeastPerformingPeer disconnectReason = "LeastPerformingPeer"
 const disconnectCliqueResolve disconnectReason = "CliqueResolving"
 const disconnectRequestReceived disconnectReason = "DisconnectRequest"
 const disconnectStaleWrite disconnectReason = "DisconnectStaleWrite"
+const disconnectBanned disconnectReason = "Banned"
 
 // Response is the structure holding the response from the server
 type Response struct {
@@ -170,7 +179,17 @@ type wsPeer struct {
 	incomingMsgFilter *messageFilter
 	outgoingMsgFilter *messageFilter
 
-	processed chan struct{}
+	// txnAnnouncer batches the transaction announcements to the peer; nil when the transaction groups are sent to the
+	// peer in full.
+	txnAnnouncer *txnAnnouncer
+
+	// floodDetector detects the peer sending the same messages over and over again; nil when peers aren't banned for misbehaving.
+	floodDetector *duplicateFloodDetector
+
+	processed [inboundPriorityCount]chan struct{}
+
+	// inboundLimiter drops the messages the peer sends over the rate limits of their tags; nil when there are no limits.
+	inboundLimiter *inboundRateLimiter
 
 	pingLock              deadlock.Mutex
 	pingSent              time.Time
@@ -194,6 +213,13 @@ type wsPeer struct {
 	// peer version ( this is one of the version supported by the current node and listed in SupportedProtocolVersions )
 	version string
 
+	// randomID is the random id of the node at the other end of an outgoing connection, which identifies the node
+	// whichever of its addresses it was connected through.
+	randomID string
+
+	// advertisedAddresses are the public addresses the node at the other end of an outgoing connection advertised.
+	advertisedAddresses []string
+
 	// responseChannels used by the client to wait on the response of the request
 	responseChannels map[uint64]chan *Response
 
@@ -222,6 +248,22 @@ type wsPeer struct {
 
 	// clientDataStoreMu synchronizes access to clientDataStore
 	clientDataStoreMu deadlock.Mutex
+
+	// messageCounters counts the messages exchanged with the peer, by tag.
+	messageCounters peerMessageCounters
+
+	// compressionCodec is the message compression codec negotiated with the peer, or an empty string if the messages
+	// are exchanged uncompressed.
+	compressionCodec string
+
+	// compressor compresses and decompresses the messages of the compressedMessageTags when a compression codec was
+	// negotiated with the peer; it is nil otherwise.
+	compressor *messageCompressor
+
+	// identity is the identity the peer authenticated with during the encrypted handshake; it's only set when
+	// authenticated is.
+	identity      PeerIdentity
+	authenticated bool
 }
 
 // HTTPPeer is what the opaque Peer might be.
@@ -270,6 +312,11 @@ func (wp *wsPeer) Version() string {
 	return wp.version
 }
 
+// Identity returns the identity the peer authenticated with, if the connection with the peer is encrypted.
+func (wp *wsPeer) Identity() (PeerIdentity, bool) {
+	return wp.identity, wp.authenticated
+}
+
 // 	Unicast sends the given bytes to this specific peer. Does not wait for message to be sent.
 // (Implements UnicastPeer)
 func (wp *wsPeer) Unicast(ctx context.Context, msg []byte, tag protocol.Tag) error {
@@ -338,19 +385,36 @@ func (wp *wsPeer) init(config config.Local, sendBufferLength int) {
 	wp.sendMessageTag = defaultSendMessageTags
 	wp.clientDataStore = make(map[string]interface{})
 
-	// processed is a channel that messageHandlerThread writes to
-	// when it's done with one of our messages, so that we can queue
-	// another one onto wp.net.readBuffer.  Prime it with dummy
+	// processed are channels, one per priority class, that messageHandlerThread
+	// writes to when it's done with one of our messages, so that we can queue
+	// another one onto wp.net.readBuffer.  Prime them with dummy
 	// values so that we can write to readBuffer initially.
-	wp.processed = make(chan struct{}, msgsInReadBufferPerPeer)
-	for i := 0; i < msgsInReadBufferPerPeer; i++ {
-		wp.processed <- struct{}{}
+	for i := range wp.processed {
+		wp.processed[i] = make(chan struct{}, msgsInReadBufferPerPeer)
+		for j := 0; j < msgsInReadBufferPerPeer; j++ {
+			wp.processed[i] <- struct{}{}
+		}
+	}
+	if wp.conn != nil {
+		wp.inboundLimiter = makeInboundRateLimiter(wp.net.inboundRateLimits, wp.net.log, wp.conn.RemoteAddr().String(), time.Now())
 	}
 
 	if config.EnableOutgoingNetworkMessageFiltering {
 		wp.outgoingMsgFilter = makeMessageFilter(config.OutgoingMessageFilterBucketCount, config.OutgoingMessageFilterBucketSize)
 	}
 
+	if wp.compressionCodec != "" {
+		wp.compressor = makeMessageCompressor()
+	}
+
+	if config.PeerBanPenaltyThreshold > 0 {
+		wp.floodDetector = makeDuplicateFloodDetector()
+	}
+
+	if config.EnableTransactionAnnouncements && wp.version == txnAnnouncementsVersion {
+		wp.txnAnnouncer = makeTxnAnnouncer()
+	}
+
 	wp.wg.Add(2)
 	go wp.readLoop()
 	go wp.writeLoop()
@@ -381,7 +445,12 @@ func (wp *wsPeer) readLoop() {
 	defer func() {
 		wp.readLoopCleanup(cleanupCloseError)
 	}()
-	wp.conn.SetReadLimit(maxMessageLength)
+	readLimit := int64(maxMessageLength)
+	if wp.compressor != nil {
+		// leave room for the compression flag of the uncompressed messages.
+		readLimit++
+	}
+	wp.conn.SetReadLimit(readLimit)
 	slurper := MakeLimitedReaderSlurper(averageMessageLength, maxMessageLength)
 	for {
 		msg := IncomingMessage{}
@@ -418,7 +487,6 @@ func (wp *wsPeer) readLoop() {
 			wp.reportReadErr(err)
 			return
 		}
-		msg.processing = wp.processed
 		msg.Received = time.Now().UnixNano()
 		msg.Data = slurper.Bytes()
 		msg.Net = wp.net
@@ -427,6 +495,20 @@ func (wp *wsPeer) readLoop() {
 		networkMessageReceivedTotal.AddUint64(1, nil)
 		networkReceivedBytesByTag.Add(string(tag[:]), uint64(len(msg.Data)+2))
 		networkMessageReceivedByTag.Add(string(tag[:]), 1)
+		wp.messageCounters.countReceived(msg.Tag)
+		if !wp.inboundLimiter.allow(msg.Tag, time.Unix(0, msg.Received)) {
+			continue
+		}
+		if wp.compressor != nil && compressedMessageTags[msg.Tag] {
+			msg.Data, err = wp.compressor.decompress(msg.Tag, msg.Data)
+			if err != nil {
+				wp.net.log.Warnf("wsPeer readLoop: could not decompress %s message from %s : %v", msg.Tag, wp.conn.RemoteAddr().String(), err)
+				networkConnectionsDroppedTotal.Inc(map[string]string{"reason": "decompression"})
+				cleanupCloseError = disconnectBadData
+				return
+			}
+		}
+		networkReceivedUncompressedBytesByTag.Add(string(tag[:]), uint64(len(msg.Data)+2))
 		msg.Sender = wp
 
 		// for outgoing connections, we want to notify the connection monitor that we've received
@@ -471,6 +553,18 @@ func (wp *wsPeer) readLoop() {
 			// network maintenance message handled immediately instead of handing off to general handlers
 			wp.handleFilterMessage(msg)
 			continue
+		case protocol.TxnRequestTag:
+			// the requested transaction groups are served by the network directly, from the recently broadcasted ones.
+			wp.handleTxnRequest(msg)
+			continue
+		}
+		if wp.txnAnnouncer != nil {
+			wp.recordKnownTxns(msg)
+		}
+		if len(msg.Data) > 0 && wp.floodDetector != nil && dedupSafeTag(msg.Tag) {
+			if wp.floodDetector.check(msg.Tag, msg.Data, time.Now()) {
+				wp.net.penalizePeer(wp, OffenseDuplicateFlood)
+			}
 		}
 		if len(msg.Data) > 0 && wp.incomingMsgFilter != nil && dedupSafeTag(msg.Tag) {
 			if wp.incomingMsgFilter.CheckIncomingMessage(msg.Tag, msg.Data, true, true) {
@@ -483,17 +577,29 @@ func (wp *wsPeer) readLoop() {
 		}
 		//wp.net.log.Debugf("got msg %d bytes from %s", len(msg.Data), wp.conn.RemoteAddr().String())
 
-		// Wait for a previous message from this peer to be processed,
-		// to achieve fairness in wp.net.readBuffer.
+		// Wait for a previous message of the same priority from this peer
+		// to be processed, to achieve fairness in wp.net.readBuffer.
+		priority := wp.net.readBuffer.priority(msg.Tag)
+		msg.processing = wp.processed[priority]
 		select {
-		case <-wp.processed:
+		case <-wp.processed[priority]:
 		case <-wp.closing:
 			wp.net.log.Debugf("peer closing %s", wp.conn.RemoteAddr().String())
 			return
 		}
 
+		if wp.net.readBuffer.droppable(priority) {
+			// never block the read loop on a full queue of low priority
+			// messages, since the agreement messages of this peer would be
+			// waiting behind it.
+			if !wp.net.readBuffer.tryQueue(msg, priority) {
+				networkInboundMessagesQueueDropped.Inc(map[string]string{"tag": string(msg.Tag)})
+			}
+			continue
+		}
+
 		select {
-		case wp.net.readBuffer <- msg:
+		case wp.net.readBuffer.queues[priority] <- msg:
 		case <-wp.closing:
 			wp.net.log.Debugf("peer closing %s", wp.conn.RemoteAddr().String())
 			return
@@ -591,7 +697,12 @@ func (wp *wsPeer) writeLoopSendMsg(msg sendMessage) disconnectReason {
 	}
 	// the tags are always 2 char long; note that this is safe since it's only being used for messages that we have generated locally.
 	tag := protocol.Tag(msg.data[:2])
-	if !wp.sendMessageTag[tag] {
+	// the announcements of transactions follow the peer interest in the transactions.
+	interestTag := tag
+	if tag == protocol.TxnAnnounceTag {
+		interestTag = protocol.TxnTag
+	}
+	if !wp.sendMessageTag[interestTag] {
 		// the peer isn't interested in this message.
 		return disconnectReasonNone
 	}
@@ -603,9 +714,21 @@ func (wp *wsPeer) writeLoopSendMsg(msg sendMessage) disconnectReason {
 		networkConnectionsDroppedTotal.Inc(map[string]string{"reason": "stale message"})
 		return disconnectStaleWrite
 	}
+	data := msg.data
+	if wp.compressor != nil && compressedMessageTags[tag] && msg.compressed != nil {
+		data = msg.compressed
+	} else if wp.compressor != nil && compressedMessageTags[tag] {
+		var err error
+		data, err = wp.compressor.compress(msg.data)
+		if err != nil {
+			wp.net.log.Errorf("failed to compress a message tag=%s : %v", tag, err)
+			// just drop it, don't break the connection
+			return disconnectReasonNone
+		}
+	}
 	atomic.StoreInt64(&wp.intermittentOutgoingMessageEnqueueTime, msg.enqueued.UnixNano())
 	defer atomic.StoreInt64(&wp.intermittentOutgoingMessageEnqueueTime, 0)
-	err := wp.conn.WriteMessage(websocket.BinaryMessage, msg.data)
+	err := wp.conn.WriteMessage(websocket.BinaryMessage, data)
 	if err != nil {
 		if atomic.LoadInt32(&wp.didInnerClose) == 0 {
 			wp.net.log.Warn("peer write error ", err)
@@ -614,10 +737,12 @@ func (wp *wsPeer) writeLoopSendMsg(msg sendMessage) disconnectReason {
 		return disconnectWriteError
 	}
 	atomic.StoreInt64(&wp.lastPacketTime, time.Now().UnixNano())
-	networkSentBytesTotal.AddUint64(uint64(len(msg.data)), nil)
-	networkSentBytesByTag.Add(string(tag), uint64(len(msg.data)))
+	networkSentBytesTotal.AddUint64(uint64(len(data)), nil)
+	networkSentBytesByTag.Add(string(tag), uint64(len(data)))
+	networkSentUncompressedBytesByTag.Add(string(tag), uint64(len(msg.data)))
 	networkMessageSentTotal.AddUint64(1, nil)
 	networkMessageSentByTag.Add(string(tag), 1)
+	wp.messageCounters.countSent(tag)
 	networkMessageQueueMicrosTotal.AddUint64(uint64(time.Now().Sub(msg.peerEnqueued).Nanoseconds()/1000), nil)
 	return disconnectReasonNone
 }
@@ -666,11 +791,13 @@ func (wp *wsPeer) writeNonBlock(ctx context.Context, data []byte, highPrio bool,
 	digests := make([]crypto.Digest, 1, 1)
 	msgs[0] = data
 	digests[0] = digest
-	return wp.writeNonBlockMsgs(ctx, msgs, highPrio, digests, msgEnqueueTime)
+	return wp.writeNonBlockMsgs(ctx, msgs, highPrio, digests, nil, msgEnqueueTime)
 }
 
 // return true if enqueued/sent
-func (wp *wsPeer) writeNonBlockMsgs(ctx context.Context, data [][]byte, highPrio bool, digest []crypto.Digest, msgEnqueueTime time.Time) bool {
+// writeNonBlockMsgs enqueues the tagged messages, unless the peer's send buffer is full. compressed, when non-nil,
+// holds the wire encodings of the messages which were already compressed for the peers negotiating a compression codec.
+func (wp *wsPeer) writeNonBlockMsgs(ctx context.Context, data [][]byte, highPrio bool, digest []crypto.Digest, compressed [][]byte, msgEnqueueTime time.Time) bool {
 	includeIndices := make([]int, 0, len(data))
 	for i := range data {
 		if wp.outgoingMsgFilter != nil && len(data[i]) > messageFilterSize && wp.outgoingMsgFilter.CheckDigest(digest[i], false, false) {
@@ -692,7 +819,11 @@ func (wp *wsPeer) writeNonBlockMsgs(ctx context.Context, data [][]byte, highPrio
 	msgs := make([]sendMessage, 0, len(includeIndices))
 	enqueueTime := time.Now()
 	for _, index := range includeIndices {
-		msgs = append(msgs, sendMessage{data: data[index], enqueued: msgEnqueueTime, peerEnqueued: enqueueTime, hash: digest[index], ctx: ctx})
+		msg := sendMessage{data: data[index], enqueued: msgEnqueueTime, peerEnqueued: enqueueTime, hash: digest[index], ctx: ctx}
+		if compressed != nil {
+			msg.compressed = compressed[index]
+		}
+		msgs = append(msgs, msg)
 	}
 
 	if highPrio {
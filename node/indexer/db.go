@@ -21,7 +21,10 @@ import (
 	"database/sql"
 	"fmt"
 
+	"github.com/algorand/go-algorand/data/basics"
 	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/protocol"
 	"github.com/algorand/go-algorand/util/db"
 )
 
@@ -30,30 +33,57 @@ const (
 	maxRows = 100
 )
 
+// schemaVersion is the version of the indexer database schema. Databases created with an older schema are indexed again
+// from scratch, as the information the newer schema tracks can't be derived from the older one.
+const schemaVersion = 3
+
+var paramsSchema = `
+	CREATE TABLE IF NOT EXISTS params(
+		k CHAR(15) PRIMARY KEY DEFAULT NULL,
+		v INTEGER DEFAULT NULL,
+		UNIQUE (k)
+	);
+
+	INSERT OR IGNORE INTO params (k, v) VALUES ('maxRound', 1);
+`
+
 var schema = `
 	CREATE TABLE IF NOT EXISTS transactions(
 		txid CHAR(52) PRIMARY KEY NOT NULL,
 		from_addr CHAR(58) DEFAULT NULL,
 		to_addr CHAR(58) DEFAULT NULL,
 		round INTEGER DEFAULT NULL,
-		created_at INTEGER
+		created_at INTEGER,
+		intra INTEGER NOT NULL,
+		tx_type CHAR(6) NOT NULL,
+		asset_id INTEGER NOT NULL DEFAULT 0,
+		app_id INTEGER NOT NULL DEFAULT 0,
+		note_prefix BLOB
 	);
 
-	CREATE TABLE IF NOT EXISTS params(
-		k CHAR(15) PRIMARY KEY DEFAULT NULL,
-		v INTEGER DEFAULT NULL,
-		UNIQUE (k)
+	CREATE TABLE IF NOT EXISTS txn_addresses(
+		addr CHAR(58) NOT NULL,
+		round INTEGER NOT NULL,
+		intra INTEGER NOT NULL,
+		PRIMARY KEY (addr, round, intra)
 	);
 
-	INSERT OR IGNORE INTO params (k, v) VALUES ('maxRound', 1);
-
 	CREATE INDEX IF NOT EXISTS idx ON transactions (
 		created_at	DESC,
 		from_addr,
 		to_addr
 	);
+
+	CREATE UNIQUE INDEX IF NOT EXISTS txn_location_idx ON transactions (
+		round,
+		intra
+	);
 `
 
+// MaxNotePrefixLength is the number of leading note bytes stored for each transaction, and the longest note prefix
+// which transactions can be filtered by.
+const MaxNotePrefixLength = 32
+
 // Transaction represents a transaction in the system
 type Transaction struct {
 	TXID      string
@@ -61,6 +91,37 @@ type Transaction struct {
 	To        string `db:"to_addr_r"`
 	Round     uint32
 	CreatedAt uint32 `db:"created_at"`
+
+	// Intra is the offset of the transaction within its block's payset.
+	Intra      uint64
+	Type       protocol.TxType
+	AssetID    basics.AssetIndex
+	AppID      basics.AppIndex
+	NotePrefix []byte
+}
+
+// TransactionLocation is the position of a transaction in the blockchain.
+type TransactionLocation struct {
+	Round basics.Round
+	Intra uint64
+}
+
+// TransactionFilter selects the transactions of an account returned by GetTransactionsByAddr. Zero valued fields
+// don't filter the transactions.
+type TransactionFilter struct {
+	MinRound   basics.Round
+	MaxRound   basics.Round
+	Type       protocol.TxType
+	AssetID    basics.AssetIndex
+	AppID      basics.AppIndex
+	NotePrefix []byte
+	// AfterTime and BeforeTime bound the block timestamps of the transactions, as seconds from epoch, exclusively.
+	AfterTime  int64
+	BeforeTime int64
+	// Next resumes a previous query, returning the transactions preceding the given one.
+	Next *TransactionLocation
+	// Limit is the maximum number of transactions to return. It defaults to 100.
+	Limit uint64
 }
 
 // DB is a the db access layer for Indexer
@@ -91,7 +152,7 @@ func MakeIndexerDB(dbPath string, inMemory bool) (*DB, error) {
 	}
 	idb.dbw = dbw
 
-	_, err = dbw.Handle.Exec(schema)
+	err = dbw.Atomic(initSchema)
 	if err != nil {
 		return &DB{}, err
 	}
@@ -99,6 +160,37 @@ func MakeIndexerDB(dbPath string, inMemory bool) (*DB, error) {
 	return idb, nil
 }
 
+// initSchema creates the indexer tables, dropping the transactions indexed using an older schema so that they're
+// indexed again.
+func initSchema(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(paramsSchema)
+	if err != nil {
+		return err
+	}
+
+	var version int
+	err = tx.QueryRow("SELECT v FROM params WHERE k = 'schemaVersion'").Scan(&version)
+	if err == sql.ErrNoRows {
+		version = 1
+	} else if err != nil {
+		return err
+	}
+
+	if version < schemaVersion {
+		_, err = tx.Exec("DROP TABLE IF EXISTS transactions; DROP TABLE IF EXISTS txn_addresses; UPDATE params SET v = 1 WHERE k = 'maxRound';")
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(schema)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec("INSERT OR REPLACE INTO params (k, v) VALUES ('schemaVersion', $1);", schemaVersion)
+	return err
+}
+
 // AddBlock takes an Algorand block and stores its transactions in the DB.
 func (idb *DB) AddBlock(b bookkeeping.Block) error {
 	err := idb.dbw.Atomic(func(ctx context.Context, tx *sql.Tx) error {
@@ -113,22 +205,40 @@ func (idb *DB) AddBlock(b bookkeeping.Block) error {
 			return fmt.Errorf("tryign to add a future block %d, where the last one is %d", b.Round(), rnd)
 		}
 
-		stmt, err := tx.Prepare("INSERT INTO transactions (txid, from_addr, to_addr, round, created_at) VALUES($1,  $2, $3, $4, $5);")
+		stmt, err := tx.Prepare("INSERT INTO transactions (txid, from_addr, to_addr, round, created_at, intra, tx_type, asset_id, app_id, note_prefix) VALUES($1,  $2, $3, $4, $5, $6, $7, $8, $9, $10);")
 		if err != nil {
 			return err
 		}
 		defer stmt.Close()
 
+		addrStmt, err := tx.Prepare("INSERT OR IGNORE INTO txn_addresses (addr, round, intra) VALUES($1, $2, $3);")
+		if err != nil {
+			return err
+		}
+		defer addrStmt.Close()
+
 		payset, err := b.DecodePaysetFlat()
 		if err != nil {
 			return err
 		}
-		for _, txad := range payset {
+		for intra, txad := range payset {
 			txn := txad.SignedTxn
-			_, err = stmt.Exec(txn.ID().String(), txn.Txn.Sender.String(), txn.Txn.GetReceiverAddress().String(), b.Round(), b.TimeStamp)
+			notePrefix := txn.Txn.Note
+			if len(notePrefix) > MaxNotePrefixLength {
+				notePrefix = notePrefix[:MaxNotePrefixLength]
+			}
+			assetID, appID := transactions.PaysetCreatables(payset, b.TxnCounter, intra)
+			_, err = stmt.Exec(txn.ID().String(), txn.Txn.Sender.String(), txn.Txn.GetReceiverAddress().String(), b.Round(), b.TimeStamp,
+				intra, string(txn.Txn.Type), assetID, appID, notePrefix)
 			if err != nil {
 				return err
 			}
+			for _, addr := range txn.Txn.InvolvedAddrs() {
+				_, err = addrStmt.Exec(addr.String(), b.Round(), intra)
+				if err != nil {
+					return err
+				}
+			}
 		}
 
 		stmt2, err := tx.Prepare("UPDATE params SET v = $1 WHERE k = 'maxRound';")
@@ -263,6 +373,103 @@ func (idb *DB) GetTransactionsRoundsByAddrAndDate(addr string, top uint64, from,
 	return rounds, nil
 }
 
+// GetTransactionsByAddr returns the transactions the given address is part of, which match the filter, starting from
+// the latest one.
+func (idb *DB) GetTransactionsByAddr(addr string, filter TransactionFilter) ([]Transaction, error) {
+	query := `
+		SELECT
+			t.txid,
+			t.from_addr,
+			t.to_addr,
+			t.round,
+			t.created_at,
+			t.intra,
+			t.tx_type,
+			t.asset_id,
+			t.app_id,
+			t.note_prefix
+		FROM
+			txn_addresses a
+		JOIN
+			transactions t ON t.round = a.round AND t.intra = a.intra
+		WHERE
+		a.addr = ?`
+	args := []interface{}{addr}
+
+	if filter.MinRound != 0 {
+		query += " AND a.round >= ?"
+		args = append(args, filter.MinRound)
+	}
+	if filter.MaxRound != 0 {
+		query += " AND a.round <= ?"
+		args = append(args, filter.MaxRound)
+	}
+	if filter.Next != nil {
+		query += " AND (a.round < ? OR (a.round = ? AND a.intra < ?))"
+		args = append(args, filter.Next.Round, filter.Next.Round, filter.Next.Intra)
+	}
+	if filter.Type != "" {
+		query += " AND t.tx_type = ?"
+		args = append(args, string(filter.Type))
+	}
+	if filter.AssetID != 0 {
+		query += " AND t.asset_id = ?"
+		args = append(args, filter.AssetID)
+	}
+	if filter.AppID != 0 {
+		query += " AND t.app_id = ?"
+		args = append(args, filter.AppID)
+	}
+	if len(filter.NotePrefix) > 0 {
+		if len(filter.NotePrefix) > MaxNotePrefixLength {
+			return nil, fmt.Errorf("note prefix is longer than %d bytes", MaxNotePrefixLength)
+		}
+		query += " AND substr(t.note_prefix, 1, ?) = ?"
+		args = append(args, len(filter.NotePrefix), filter.NotePrefix)
+	}
+	if filter.AfterTime != 0 {
+		query += " AND t.created_at > ?"
+		args = append(args, filter.AfterTime)
+	}
+	if filter.BeforeTime != 0 {
+		query += " AND t.created_at < ?"
+		args = append(args, filter.BeforeTime)
+	}
+
+	// limit
+	top := filter.Limit
+	if top == 0 {
+		top = maxRows
+	}
+	query += " ORDER BY a.round DESC, a.intra DESC LIMIT ?;"
+	args = append(args, top)
+
+	rows, err := idb.dbr.Handle.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var txns []Transaction
+	for rows.Next() {
+		var txn Transaction
+		var txType string
+		err := rows.Scan(&txn.TXID, &txn.From, &txn.To, &txn.Round, &txn.CreatedAt, &txn.Intra, &txType, &txn.AssetID, &txn.AppID, &txn.NotePrefix)
+		if err != nil {
+			return nil, err
+		}
+		txn.Type = protocol.TxType(txType)
+		txns = append(txns, txn)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return txns, nil
+}
+
 // MaxRound returns the latest block in the DB
 func (idb *DB) MaxRound() (uint64, error) {
 	var rnd uint64
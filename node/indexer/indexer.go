@@ -89,6 +89,16 @@ func (idx *Indexer) GetRoundsByAddress(addr string, top uint64) ([]uint64, error
 	return rounds, nil
 }
 
+// GetTransactionsByAddress takes an address and a filter, and returns the transactions where the address was the
+// sender, a receiver or otherwise affected, which match the filter, starting from the latest one.
+func (idx *Indexer) GetTransactionsByAddress(addr string, filter TransactionFilter) ([]Transaction, error) {
+	txns, err := idx.IDB.GetTransactionsByAddr(addr, filter)
+	if err != nil {
+		return nil, err
+	}
+	return txns, nil
+}
+
 // NewBlock takes a block and updates the DB
 // If the block exists, return nil.the block must be the next block
 func (idx *Indexer) NewBlock(b bookkeeping.Block) error {
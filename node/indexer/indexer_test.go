@@ -17,8 +17,10 @@
 package indexer
 
 import (
+	"io/ioutil"
 	"math/rand"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -31,6 +33,7 @@ import (
 	"github.com/algorand/go-algorand/data/bookkeeping"
 	"github.com/algorand/go-algorand/data/transactions"
 	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/util/db"
 )
 
 type IndexSuite struct {
@@ -149,6 +152,147 @@ func (s *IndexSuite) TestIndexer_Asset() {
 
 }
 
+func (s *IndexSuite) TestIndexer_GetTransactionsByAddress() {
+	addr := s.addrs[0]
+	expected := make(map[string]bool)
+	for _, txn := range s.txns {
+		if txn.Txn.Sender == addr || txn.Txn.Receiver == addr || txn.Txn.AssetReceiver == addr {
+			expected[txn.ID().String()] = true
+		}
+	}
+
+	// page through all of the transactions of the address, starting from the latest one.
+	var found []Transaction
+	filter := TransactionFilter{Limit: 100}
+	for {
+		txns, err := s.idx.GetTransactionsByAddress(addr.String(), filter)
+		require.NoError(s.T(), err)
+		found = append(found, txns...)
+		if len(txns) < int(filter.Limit) {
+			break
+		}
+		last := txns[len(txns)-1]
+		filter.Next = &TransactionLocation{Round: basics.Round(last.Round), Intra: last.Intra}
+	}
+	require.Equal(s.T(), len(expected), len(found))
+	for i, txn := range found {
+		require.True(s.T(), expected[txn.TXID])
+		if i > 0 {
+			prev := found[i-1]
+			require.True(s.T(), txn.Round < prev.Round || (txn.Round == prev.Round && txn.Intra < prev.Intra))
+		}
+	}
+
+	// filter by round range and type.
+	txns, err := s.idx.GetTransactionsByAddress(addr.String(), TransactionFilter{MinRound: 3, MaxRound: 4, Type: protocol.AssetTransferTx, Limit: 5000})
+	require.NoError(s.T(), err)
+	require.NotEmpty(s.T(), txns)
+	for _, txn := range txns {
+		require.True(s.T(), txn.Round >= 3 && txn.Round <= 4)
+		require.Equal(s.T(), protocol.AssetTransferTx, txn.Type)
+	}
+
+	// filter by asset.
+	assetID := txns[0].AssetID
+	txns, err = s.idx.GetTransactionsByAddress(addr.String(), TransactionFilter{AssetID: assetID, Limit: 5000})
+	require.NoError(s.T(), err)
+	require.NotEmpty(s.T(), txns)
+	for _, txn := range txns {
+		require.Equal(s.T(), assetID, txn.AssetID)
+	}
+}
+
+func TestIndexerFilters(t *testing.T) {
+	dir, err := ioutil.TempDir("", "indexer")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	idx, err := MakeIndexer(dir, &TestLedger{}, false)
+	require.NoError(t, err)
+	defer idx.Shutdown()
+
+	_, txns, secrets, addrs := generateTestObjects(4, 2)
+	appCall := transactions.Transaction{
+		Type:   protocol.ApplicationCallTx,
+		Header: transactions.Header{Sender: addrs[0], Note: []byte("app creation note, which is longer than the stored prefix")},
+	}
+	referenced := basics.Address(keypair().SignatureVerifier)
+	appCall.Accounts = []basics.Address{referenced}
+	txns = append(txns, appCall.Sign(secrets[0]))
+
+	var payset []transactions.SignedTxnInBlock
+	b := bookkeeping.Block{
+		BlockHeader: bookkeeping.BlockHeader{
+			Round:      2,
+			TimeStamp:  1000,
+			TxnCounter: 100,
+		},
+	}
+	for _, txn := range txns {
+		txib, err := b.EncodeSignedTxn(txn, transactions.ApplyData{})
+		require.NoError(t, err)
+		payset = append(payset, txib)
+	}
+	b.Payset = payset
+	require.NoError(t, idx.NewBlock(b))
+
+	// the created application is identified by the transaction counter.
+	found, err := idx.GetTransactionsByAddress(addrs[0].String(), TransactionFilter{AppID: 100})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, txns[4].ID().String(), found[0].TXID)
+	require.Equal(t, uint64(4), found[0].Intra)
+
+	// the accounts referenced by an application call are part of its history.
+	found, err = idx.GetTransactionsByAddress(referenced.String(), TransactionFilter{})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, txns[4].ID().String(), found[0].TXID)
+
+	found, err = idx.GetTransactionsByAddress(addrs[0].String(), TransactionFilter{NotePrefix: []byte("app creation")})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	found, err = idx.GetTransactionsByAddress(addrs[0].String(), TransactionFilter{NotePrefix: []byte("app deletion")})
+	require.NoError(t, err)
+	require.Empty(t, found)
+	_, err = idx.GetTransactionsByAddress(addrs[0].String(), TransactionFilter{NotePrefix: make([]byte, MaxNotePrefixLength+1)})
+	require.Error(t, err)
+
+	found, err = idx.GetTransactionsByAddress(addrs[0].String(), TransactionFilter{AfterTime: 999, BeforeTime: 1001})
+	require.NoError(t, err)
+	require.NotEmpty(t, found)
+	found, err = idx.GetTransactionsByAddress(addrs[0].String(), TransactionFilter{AfterTime: 1000})
+	require.NoError(t, err)
+	require.Empty(t, found)
+}
+
+func TestIndexerSchemaUpgrade(t *testing.T) {
+	dir, err := ioutil.TempDir("", "indexer")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// create a database using the original schema, which didn't track the schema version.
+	dbw, err := db.MakeAccessor(filepath.Join(dir, dbName), false, false)
+	require.NoError(t, err)
+	_, err = dbw.Handle.Exec(`
+		CREATE TABLE transactions(txid CHAR(52) PRIMARY KEY NOT NULL, from_addr CHAR(58) DEFAULT NULL, to_addr CHAR(58) DEFAULT NULL, round INTEGER DEFAULT NULL, created_at INTEGER);
+		CREATE TABLE params(k CHAR(15) PRIMARY KEY DEFAULT NULL, v INTEGER DEFAULT NULL, UNIQUE (k));
+		INSERT INTO params (k, v) VALUES ('maxRound', 10);
+		INSERT INTO transactions (txid, from_addr, to_addr, round, created_at) VALUES ('txid', 'from', 'to', 10, 1000);
+	`)
+	require.NoError(t, err)
+	dbw.Close()
+
+	// the transactions are indexed again from scratch.
+	idx, err := MakeIndexer(dir, &TestLedger{}, false)
+	require.NoError(t, err)
+	defer idx.Shutdown()
+	rnd, err := idx.LastBlock()
+	require.NoError(t, err)
+	require.Equal(t, basics.Round(1), rnd)
+	_, err = idx.GetRoundByTXID("txid")
+	require.Error(t, err)
+}
+
 func TestExampleTestSuite(t *testing.T) {
 	suite.Run(t, new(IndexSuite))
 }
@@ -19,6 +19,7 @@ package node
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -44,8 +45,10 @@ import (
 	"github.com/algorand/go-algorand/ledger/ledgercore"
 	"github.com/algorand/go-algorand/logging"
 	"github.com/algorand/go-algorand/network"
+	"github.com/algorand/go-algorand/network/messagerecorder"
 	"github.com/algorand/go-algorand/network/messagetracer"
 	"github.com/algorand/go-algorand/node/indexer"
+	"github.com/algorand/go-algorand/node/webhooks"
 	"github.com/algorand/go-algorand/protocol"
 	"github.com/algorand/go-algorand/rpcs"
 	"github.com/algorand/go-algorand/util/db"
@@ -101,6 +104,11 @@ type AlgorandFullNode struct {
 	txHandler       *data.TxHandler
 	accountManager  *data.AccountManager
 
+	// participationKeysMu serializes the loading, installation and removal of participation key files.
+	participationKeysMu deadlock.Mutex
+	// participationKeyFiles maps the loaded participation keys to the names of the files they were loaded from.
+	participationKeyFiles map[data.ParticipationKeyIdentity]string
+
 	agreementService         *agreement.Service
 	catchupService           *catchup.Service
 	catchpointCatchupService *catchup.CatchpointCatchupService
@@ -108,7 +116,8 @@ type AlgorandFullNode struct {
 	ledgerService            *rpcs.LedgerService
 	txPoolSyncerService      *rpcs.TxSyncer
 
-	indexer *indexer.Indexer
+	indexer  *indexer.Indexer
+	webhooks *webhooks.Service
 
 	rootDir     string
 	genesisID   string
@@ -158,6 +167,12 @@ type TxnWithStatus struct {
 // MakeFull sets up an Algorand full node
 // (i.e., it returns a node that participates in consensus)
 func MakeFull(log logging.Logger, rootDir string, cfg config.Local, phonebookAddresses []string, genesis bookkeeping.Genesis) (*AlgorandFullNode, error) {
+	return MakeFullWithTransport(log, rootDir, cfg, phonebookAddresses, genesis, nil)
+}
+
+// MakeFullWithTransport sets up an Algorand full node whose gossip network runs on the given transport, such as a
+// host of an in-process simulated network; a nil transport stands for TCP.
+func MakeFullWithTransport(log logging.Logger, rootDir string, cfg config.Local, phonebookAddresses []string, genesis bookkeeping.Genesis, transport network.Transport) (*AlgorandFullNode, error) {
 
 	node := new(AlgorandFullNode)
 	node.rootDir = rootDir
@@ -177,9 +192,17 @@ func MakeFull(log logging.Logger, rootDir string, cfg config.Local, phonebookAdd
 		log.Errorf("could not create websocket node: %v", err)
 		return nil, err
 	}
+	if transport != nil {
+		p2pNode.SetTransport(transport)
+	}
 	p2pNode.SetPrioScheme(node)
+	p2pNode.SetPeerBansFile(filepath.Join(rootDir, config.PeerBansFilename))
+	p2pNode.SetAddressBookFile(filepath.Join(rootDir, config.AddressBookFilename))
+	p2pNode.SetIdentityFile(filepath.Join(rootDir, config.PeerIdentityFilename))
+	p2pNode.SetMessageRecordingDir(filepath.Join(rootDir, config.MessageRecordingsDirname))
 	node.net = p2pNode
 	node.accountManager = data.MakeAccountManager(log)
+	node.participationKeyFiles = make(map[data.ParticipationKeyIdentity]string)
 
 	accountListener := makeTopAccountListener(log)
 
@@ -210,6 +233,12 @@ func MakeFull(log logging.Logger, rootDir string, cfg config.Local, phonebookAdd
 	}
 
 	node.transactionPool = pools.MakeTransactionPool(node.ledger.Ledger, cfg, node.log)
+	if cfg.EnableTxPoolJournal {
+		err = node.transactionPool.OpenJournal(filepath.Join(genesisDir, config.TxPoolJournalFilename))
+		if err != nil {
+			log.Warnf("Cannot open the transaction pool journal: %v", err)
+		}
+	}
 
 	blockListeners := []ledger.BlockListener{
 		node.transactionPool,
@@ -219,6 +248,15 @@ func MakeFull(log logging.Logger, rootDir string, cfg config.Local, phonebookAdd
 	if node.config.EnableTopAccountsReporting {
 		blockListeners = append(blockListeners, &accountListener)
 	}
+
+	if cfg.EnableWebhooks {
+		node.webhooks, err = webhooks.MakeService(filepath.Join(genesisDir, config.WebhooksFilename), false, node.ledger, cfg.WebhookMaxDeliveryAttempts, node.log)
+		if err != nil {
+			log.Errorf("Cannot open the webhooks database: %v", err)
+			return nil, err
+		}
+		blockListeners = append(blockListeners, node.webhooks)
+	}
 	node.ledger.RegisterBlockListeners(blockListeners)
 	node.txHandler = data.MakeTxHandler(node.transactionPool, node.ledger, node.net, node.genesisID, node.genesisHash, node.lowPriorityCryptoVerificationPool)
 
@@ -305,37 +343,12 @@ func MakeFull(log logging.Logger, rootDir string, cfg config.Local, phonebookAdd
 }
 
 func bootstrapData(genesis bookkeeping.Genesis, log logging.Logger) (data.GenesisBalances, error) {
-	genalloc := make(map[basics.Address]basics.AccountData)
-	for _, entry := range genesis.Allocation {
-		addr, err := basics.UnmarshalChecksumAddress(entry.Address)
-		if err != nil {
-			log.Errorf("Cannot parse genesis addr %s: %v", entry.Address, err)
-			return data.GenesisBalances{}, err
-		}
-
-		_, present := genalloc[addr]
-		if present {
-			err = fmt.Errorf("repeated allocation to %s", entry.Address)
-			log.Error(err)
-			return data.GenesisBalances{}, err
-		}
-
-		genalloc[addr] = entry.State
-	}
-
-	feeSink, err := basics.UnmarshalChecksumAddress(genesis.FeeSink)
+	genalloc, err := data.MakeGenesisBalancesFromGenesis(genesis)
 	if err != nil {
-		log.Errorf("Cannot parse fee sink addr %s: %v", genesis.FeeSink, err)
+		log.Error(err)
 		return data.GenesisBalances{}, err
 	}
-
-	rewardsPool, err := basics.UnmarshalChecksumAddress(genesis.RewardsPool)
-	if err != nil {
-		log.Errorf("Cannot parse rewards pool addr %s: %v", genesis.RewardsPool, err)
-		return data.GenesisBalances{}, err
-	}
-
-	return data.MakeTimestampedGenesisBalances(genalloc, feeSink, rewardsPool, genesis.Timestamp), nil
+	return genalloc, nil
 }
 
 // Config returns a copy of the node's Local configuration
@@ -362,6 +375,10 @@ func (node *AlgorandFullNode) Start() {
 		}
 	}
 
+	if node.webhooks != nil {
+		node.webhooks.Start()
+	}
+
 	if node.catchpointCatchupService != nil {
 		startNetwork()
 		node.catchpointCatchupService.Start(node.ctx)
@@ -421,6 +438,17 @@ func (node *AlgorandFullNode) ListeningAddress() (string, bool) {
 	return node.net.Address()
 }
 
+// ReplayMessage hands a recorded network message to the handlers of the node, as if it was just received from the
+// recorded peer. The node must be started.
+func (node *AlgorandFullNode) ReplayMessage(msg messagerecorder.Message) error {
+	wn, ok := node.net.(*network.WebsocketNetwork)
+	if !ok {
+		return fmt.Errorf("ReplayMessage: the network of the node doesn't support replaying messages")
+	}
+	wn.ReplayMessage(msg)
+	return nil
+}
+
 // Stop stops running the node. Once a node is closed, it can never start again.
 func (node *AlgorandFullNode) Stop() {
 	node.mu.Lock()
@@ -451,10 +479,14 @@ func (node *AlgorandFullNode) Stop() {
 	node.highPriorityCryptoVerificationPool.Shutdown()
 	node.lowPriorityCryptoVerificationPool.Shutdown()
 	node.cryptoPool.Shutdown()
+	node.transactionPool.CloseJournal()
 	node.cancelCtx()
 	if node.indexer != nil {
 		node.indexer.Shutdown()
 	}
+	if node.webhooks != nil {
+		node.webhooks.Shutdown()
+	}
 }
 
 // note: unlike the other two functions, this accepts a whole filename
@@ -738,6 +770,12 @@ func (node *AlgorandFullNode) SuggestedFee() basics.MicroAlgos {
 	return basics.MicroAlgos{Raw: node.transactionPool.FeePerByte()}
 }
 
+// FeeEstimate returns the fee per byte recommended to get a new transaction committed within the given number of rounds,
+// based on the fees paid by the transactions of the recent blocks and on the congestion of the transaction pool.
+func (node *AlgorandFullNode) FeeEstimate(targetRounds uint64) pools.FeeEstimate {
+	return node.transactionPool.EstimateFee(targetRounds)
+}
+
 // GetPendingTxnsFromPool returns a snapshot of every pending transactions from the node's transaction pool in a slice.
 // Transactions are sorted in decreasing order. If no transactions, returns an empty slice.
 func (node *AlgorandFullNode) GetPendingTxnsFromPool() ([]transactions.SignedTxn, error) {
@@ -763,6 +801,9 @@ func (node *AlgorandFullNode) checkForParticipationKeys() {
 }
 
 func (node *AlgorandFullNode) loadParticipationKeys() error {
+	node.participationKeysMu.Lock()
+	defer node.participationKeysMu.Unlock()
+
 	// Generate a list of all potential participation key files
 	genesisDir := filepath.Join(node.rootDir, node.genesisID)
 	files, err := ioutil.ReadDir(genesisDir)
@@ -811,6 +852,7 @@ func (node *AlgorandFullNode) loadParticipationKeys() error {
 			// Tell the AccountManager about the Participation (dupes don't matter)
 			added := node.accountManager.AddParticipation(part)
 			if added {
+				node.participationKeyFiles[data.MakeParticipationKeyIdentity(part.Participation)] = filename
 				node.log.Infof("Loaded participation keys from storage: %s %s", part.Address(), info.Name())
 			} else {
 				part.Close()
@@ -923,12 +965,102 @@ func (node *AlgorandFullNode) Uint64() uint64 {
 	return crypto.RandUint64()
 }
 
+// ErrIndexerNotActive is returned when the indexer is required but isn't running.
+var ErrIndexerNotActive = errors.New("indexer is not active")
+
 // Indexer returns a pointer to nodes indexer
 func (node *AlgorandFullNode) Indexer() (*indexer.Indexer, error) {
 	if node.indexer != nil && node.config.IsIndexerActive {
 		return node.indexer, nil
 	}
-	return nil, fmt.Errorf("indexer is not active")
+	return nil, ErrIndexerNotActive
+}
+
+// ErrWebhooksNotActive is returned when the webhook notifications are required but aren't enabled.
+var ErrWebhooksNotActive = errors.New("webhooks are not enabled")
+
+// ListWebhooks returns the registered webhook watchers.
+// this function is intended to be called externally via the REST api interface.
+func (node *AlgorandFullNode) ListWebhooks() ([]webhooks.Watcher, error) {
+	if node.webhooks == nil {
+		return nil, ErrWebhooksNotActive
+	}
+	return node.webhooks.Watchers(), nil
+}
+
+// AddWebhook registers a webhook watcher, and returns it along with its assigned ID.
+// this function is intended to be called externally via the REST api interface.
+func (node *AlgorandFullNode) AddWebhook(w webhooks.Watcher) (webhooks.Watcher, error) {
+	if node.webhooks == nil {
+		return webhooks.Watcher{}, ErrWebhooksNotActive
+	}
+	return node.webhooks.AddWatcher(w)
+}
+
+// RemoveWebhook unregisters the webhook watcher with the given ID.
+// this function is intended to be called externally via the REST api interface.
+func (node *AlgorandFullNode) RemoveWebhook(id uint64) error {
+	if node.webhooks == nil {
+		return ErrWebhooksNotActive
+	}
+	return node.webhooks.RemoveWatcher(id)
+}
+
+// IndexedTransaction is a confirmed transaction, as found by the indexer.
+type IndexedTransaction struct {
+	Txn transactions.SignedTxnWithAD
+
+	Round basics.Round
+	// Intra is the offset of the transaction within its block's payset.
+	Intra uint64
+	// RoundTime is the timestamp of the block, as seconds from epoch.
+	RoundTime int64
+}
+
+// ListAccountTransactions returns the confirmed transactions of the given account which match the filter, starting
+// from the latest one, along with the last round indexed before they were looked up. It requires the indexer.
+// this function is intended to be called externally via the REST api interface.
+func (node *AlgorandFullNode) ListAccountTransactions(addr basics.Address, filter indexer.TransactionFilter) ([]IndexedTransaction, basics.Round, error) {
+	idx, err := node.Indexer()
+	if err != nil {
+		return nil, 0, err
+	}
+	indexedRound, err := idx.LastBlock()
+	if err != nil {
+		return nil, 0, err
+	}
+	found, err := idx.GetTransactionsByAddress(addr.String(), filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	txns := make([]IndexedTransaction, 0, len(found))
+	var payset []transactions.SignedTxnWithAD
+	var paysetRound basics.Round
+	for _, t := range found {
+		rnd := basics.Round(t.Round)
+		if payset == nil || paysetRound != rnd {
+			blk, err := node.ledger.Block(rnd)
+			if err != nil {
+				return nil, 0, err
+			}
+			payset, err = blk.DecodePaysetFlat()
+			if err != nil {
+				return nil, 0, err
+			}
+			paysetRound = rnd
+		}
+		if t.Intra >= uint64(len(payset)) {
+			return nil, 0, fmt.Errorf("indexed transaction %s is not in block %d", t.TXID, rnd)
+		}
+		txns = append(txns, IndexedTransaction{
+			Txn:       payset[t.Intra],
+			Round:     rnd,
+			Intra:     t.Intra,
+			RoundTime: int64(t.CreatedAt),
+		})
+	}
+	return txns, indexedRound, nil
 }
 
 // GetTransactionByID gets transaction by ID
@@ -0,0 +1,232 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/data"
+	"github.com/algorand/go-algorand/data/account"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/util/db"
+)
+
+// The voting statuses of an installed participation key.
+const (
+	// ParticipationKeyActive indicates the key is registered on chain and its account is online.
+	ParticipationKeyActive = "active"
+	// ParticipationKeyNotRegistered indicates the account isn't online with this key.
+	ParticipationKeyNotRegistered = "not-registered"
+	// ParticipationKeyExpired indicates the last round the key is valid for has passed.
+	ParticipationKeyExpired = "expired"
+)
+
+// ErrParticipationKeyNotFound is returned when the requested participation key isn't installed.
+var ErrParticipationKeyNotFound = errors.New("participation key not found")
+
+// ErrParticipationKeyExists is returned when installing a participation key whose file name is already taken.
+var ErrParticipationKeyExists = errors.New("a participation key for the same account and validity range is already installed")
+
+// ErrInvalidParticipationKey is returned when installing a participation key file which cannot be loaded.
+var ErrInvalidParticipationKey = errors.New("invalid participation key file")
+
+// ParticipationKeyInfo describes an installed participation key.
+type ParticipationKeyInfo struct {
+	ID           crypto.Digest
+	Address      basics.Address
+	FirstValid   basics.Round
+	LastValid    basics.Round
+	KeyDilution  uint64
+	VoteID       crypto.OneTimeSignatureVerifier
+	SelectionID  crypto.VrfPubkey
+	VotingStatus string
+}
+
+// ListParticipationKeys returns the participation keys installed on the node, along with their voting status as of
+// the latest round.
+func (node *AlgorandFullNode) ListParticipationKeys() ([]ParticipationKeyInfo, error) {
+	latest := node.ledger.Latest()
+	parts := node.accountManager.Participations()
+	infos := make([]ParticipationKeyInfo, 0, len(parts))
+	for _, part := range parts {
+		id := data.MakeParticipationKeyIdentity(part)
+		info := ParticipationKeyInfo{
+			ID:           id.ID(),
+			Address:      id.Address,
+			FirstValid:   id.FirstValid,
+			LastValid:    id.LastValid,
+			KeyDilution:  part.KeyDilution,
+			VoteID:       id.VoteID,
+			SelectionID:  id.SelectionID,
+			VotingStatus: ParticipationKeyNotRegistered,
+		}
+		if latest > id.LastValid {
+			info.VotingStatus = ParticipationKeyExpired
+		} else {
+			acctData, _, err := node.ledger.LookupWithoutRewards(latest, id.Address)
+			if err != nil {
+				return nil, err
+			}
+			if acctData.Status == basics.Online && acctData.VoteID == id.VoteID && acctData.SelectionID == id.SelectionID {
+				info.VotingStatus = ParticipationKeyActive
+			}
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].Address != infos[j].Address {
+			return bytes.Compare(infos[i].Address[:], infos[j].Address[:]) < 0
+		}
+		if infos[i].FirstValid != infos[j].FirstValid {
+			return infos[i].FirstValid < infos[j].FirstValid
+		}
+		return bytes.Compare(infos[i].ID[:], infos[j].ID[:]) < 0
+	})
+	return infos, nil
+}
+
+// InstallParticipationKey installs the given participation key database file, as generated by
+// `goal account addpartkey`, and starts using it. It returns the id of the installed key.
+func (node *AlgorandFullNode) InstallParticipationKey(partKeyBinary []byte) (crypto.Digest, error) {
+	genesisDir := filepath.Join(node.rootDir, node.genesisID)
+	id, err := node.installParticipationKeyFile(genesisDir, partKeyBinary)
+	if err != nil {
+		return crypto.Digest{}, err
+	}
+	err = node.loadParticipationKeys()
+	if err != nil {
+		return crypto.Digest{}, err
+	}
+	return id, nil
+}
+
+func (node *AlgorandFullNode) installParticipationKeyFile(genesisDir string, partKeyBinary []byte) (crypto.Digest, error) {
+	node.participationKeysMu.Lock()
+	defer node.participationKeysMu.Unlock()
+
+	// the uploaded file is stored under a name which loadParticipationKeys ignores until it's validated.
+	tmpFile, err := ioutil.TempFile(genesisDir, "upload-*.partkey.tmp")
+	if err != nil {
+		return crypto.Digest{}, err
+	}
+	tmpPath := tmpFile.Name()
+	defer os.Remove(tmpPath)
+	_, err = tmpFile.Write(partKeyBinary)
+	if err == nil {
+		err = tmpFile.Close()
+	} else {
+		tmpFile.Close()
+	}
+	if err != nil {
+		return crypto.Digest{}, err
+	}
+
+	handle, err := db.MakeErasableAccessor(tmpPath)
+	if err != nil {
+		return crypto.Digest{}, fmt.Errorf("%w: %v", ErrInvalidParticipationKey, err)
+	}
+	part, err := account.RestoreParticipation(handle)
+	if err != nil {
+		handle.Close()
+		return crypto.Digest{}, fmt.Errorf("%w: %v", ErrInvalidParticipationKey, err)
+	}
+	part.Close()
+	if part.Parent.IsZero() {
+		return crypto.Digest{}, fmt.Errorf("%w: missing (zero) parent address", ErrInvalidParticipationKey)
+	}
+
+	partKeyPath := filepath.Join(genesisDir, config.PartKeyFilename(part.Parent.String(), uint64(part.FirstValid), uint64(part.LastValid)))
+	if _, err = os.Stat(partKeyPath); err == nil {
+		return crypto.Digest{}, ErrParticipationKeyExists
+	}
+	err = os.Rename(tmpPath, partKeyPath)
+	if err != nil {
+		return crypto.Digest{}, err
+	}
+	return data.MakeParticipationKeyIdentity(part.Participation).ID(), nil
+}
+
+// findParticipationKey returns the installed participation key with the given id.
+func (node *AlgorandFullNode) findParticipationKey(id crypto.Digest) (account.Participation, data.ParticipationKeyIdentity, error) {
+	for _, part := range node.accountManager.Participations() {
+		identity := data.MakeParticipationKeyIdentity(part)
+		if identity.ID() == id {
+			return part, identity, nil
+		}
+	}
+	return account.Participation{}, data.ParticipationKeyIdentity{}, ErrParticipationKeyNotFound
+}
+
+// ParticipationKeyRegistration returns the unsigned key registration transaction which would register the participation
+// key with the given id on chain, bringing its account online.
+func (node *AlgorandFullNode) ParticipationKeyRegistration(id crypto.Digest, fee basics.MicroAlgos, firstValid, lastValid basics.Round) (transactions.Transaction, error) {
+	part, _, err := node.findParticipationKey(id)
+	if err != nil {
+		return transactions.Transaction{}, err
+	}
+	latestHdr, err := node.ledger.BlockHdr(node.ledger.Latest())
+	if err != nil {
+		return transactions.Transaction{}, err
+	}
+	var lease [32]byte
+	tx := part.GenerateRegistrationTransaction(fee, firstValid, lastValid, lease)
+	if config.Consensus[latestHdr.CurrentProtocol].SupportGenesisHash {
+		tx.GenesisHash = node.genesisHash
+	}
+	tx.GenesisID = node.genesisID
+	return tx, nil
+}
+
+// RemoveParticipationKey stops using the participation key with the given id, erases its secrets and deletes its file.
+func (node *AlgorandFullNode) RemoveParticipationKey(id crypto.Digest) error {
+	node.participationKeysMu.Lock()
+	defer node.participationKeysMu.Unlock()
+
+	_, identity, err := node.findParticipationKey(id)
+	if err != nil {
+		return err
+	}
+	part, ok := node.accountManager.RemoveParticipation(identity)
+	if !ok {
+		return ErrParticipationKeyNotFound
+	}
+	filename := node.participationKeyFiles[identity]
+	delete(node.participationKeyFiles, identity)
+
+	// erase the key material before deleting the file, so that it couldn't be recovered from the disk blocks. The
+	// consensus protocol version is irrelevant for the maxuint64 round number passed in.
+	err = <-part.DeleteOldKeys(basics.Round(math.MaxUint64), config.Consensus[protocol.ConsensusCurrentVersion])
+	part.Close()
+	if err != nil {
+		node.log.Warnf("RemoveParticipationKey: unable to erase the participation key of %v: %v", identity.Address, err)
+	}
+	if filename == "" {
+		return nil
+	}
+	return os.Remove(filepath.Join(node.rootDir, node.genesisID, filename))
+}
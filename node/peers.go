@@ -0,0 +1,92 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/algorand/go-algorand/network"
+)
+
+// ErrPeerNotFound is returned when no connected peer has the given address.
+var ErrPeerNotFound = errors.New("no connected peer has the given address")
+
+// ErrInvalidPeerAddress is returned when adding a phonebook entry whose address isn't a host:port address.
+var ErrInvalidPeerAddress = errors.New("invalid peer address")
+
+// ListPeers returns the description of the peers the node is connected to.
+func (node *AlgorandFullNode) ListPeers() []network.PeerInfo {
+	peers := node.net.GetPeers(network.PeersConnectedIn, network.PeersConnectedOut)
+	infos := make([]network.PeerInfo, 0, len(peers))
+	for _, peer := range peers {
+		if inspectable, ok := peer.(network.InspectablePeer); ok {
+			infos = append(infos, inspectable.Info())
+		}
+	}
+	return infos
+}
+
+// findPeer returns the connected peer with the given remote or gossip address.
+func (node *AlgorandFullNode) findPeer(address string) (network.Peer, error) {
+	for _, peer := range node.net.GetPeers(network.PeersConnectedIn, network.PeersConnectedOut) {
+		inspectable, ok := peer.(network.InspectablePeer)
+		if !ok {
+			continue
+		}
+		info := inspectable.Info()
+		if info.RemoteAddress == address || (info.Address != "" && info.Address == address) {
+			return peer, nil
+		}
+	}
+	return nil, ErrPeerNotFound
+}
+
+// DisconnectPeer drops the connection with the peer with the given remote or gossip address.
+func (node *AlgorandFullNode) DisconnectPeer(address string) error {
+	peer, err := node.findPeer(address)
+	if err != nil {
+		return err
+	}
+	node.net.Disconnect(peer)
+	return nil
+}
+
+// BanPeer bans the peer with the given remote or gossip address for the given duration, and drops the connections
+// with it.
+func (node *AlgorandFullNode) BanPeer(address string, duration time.Duration) error {
+	peer, err := node.findPeer(address)
+	if err != nil {
+		return err
+	}
+	return node.net.BanPeer(peer, duration)
+}
+
+// AddPhonebookEntry adds the host:port address of a relay, or of an archiver, to the phonebook of the node.
+func (node *AlgorandFullNode) AddPhonebookEntry(address string, archival bool) error {
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		return fmt.Errorf("%w %s: %v", ErrInvalidPeerAddress, address, err)
+	}
+	role := network.PhoneBookEntryRoles(network.PhoneBookEntryRelayRole)
+	if archival {
+		role = network.PhoneBookEntryArchiverRole
+	}
+	node.net.AddPhonebookEntry(address, role)
+	return nil
+}
@@ -0,0 +1,168 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package webhooks
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/algorand/go-algorand/data/basics"
+)
+
+var schema = []string{
+	// watchers holds the registered watchers. Exactly one of address, asset and app is set for every watcher.
+	`CREATE TABLE IF NOT EXISTS watchers (
+		id integer primary key autoincrement,
+		address blob,
+		asset integer,
+		app integer,
+		url text)`,
+
+	// deliveries is the queue of the notifications yet to be delivered. The notifications of a watcher are attempted
+	// in the order they were queued, once their next attempt time (in unix nanoseconds) is reached.
+	`CREATE TABLE IF NOT EXISTS deliveries (
+		id integer primary key autoincrement,
+		watcher integer,
+		url text,
+		payload blob,
+		attempts integer,
+		nextattempt integer)`,
+
+	`CREATE INDEX IF NOT EXISTS deliveries_watcher ON deliveries (watcher, nextattempt)`,
+
+	// state holds the last round whose transactions were matched against the watchers.
+	`CREATE TABLE IF NOT EXISTS state (
+		id text primary key,
+		rnd integer)`,
+}
+
+// delivery is a queued notification.
+type delivery struct {
+	id        int64
+	watcherID uint64
+	url       string
+	payload   []byte
+	attempts  uint64
+}
+
+func initDB(tx *sql.Tx) error {
+	for i, tableCreate := range schema {
+		_, err := tx.Exec(tableCreate)
+		if err != nil {
+			return fmt.Errorf("could not create webhooks table %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// getLastRound returns the last processed round, and whether there is one.
+func getLastRound(tx *sql.Tx) (rnd basics.Round, ok bool, err error) {
+	err = tx.QueryRow("SELECT rnd FROM state WHERE id='lastround'").Scan(&rnd)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	return rnd, err == nil, err
+}
+
+func setLastRound(tx *sql.Tx, rnd basics.Round) error {
+	_, err := tx.Exec("INSERT OR REPLACE INTO state (id, rnd) VALUES ('lastround', ?)", rnd)
+	return err
+}
+
+func addWatcher(tx *sql.Tx, w Watcher) (uint64, error) {
+	var address []byte
+	if !w.Address.IsZero() {
+		address = w.Address[:]
+	}
+	res, err := tx.Exec("INSERT INTO watchers (address, asset, app, url) VALUES (?, ?, ?, ?)", address, w.AssetID, w.AppID, w.URL)
+	if err != nil {
+		return 0, err
+	}
+	id, err := res.LastInsertId()
+	return uint64(id), err
+}
+
+// removeWatcher removes the watcher along with its queued notifications.
+func removeWatcher(tx *sql.Tx, id uint64) (bool, error) {
+	res, err := tx.Exec("DELETE FROM watchers WHERE id=?", id)
+	if err != nil {
+		return false, err
+	}
+	count, err := res.RowsAffected()
+	if err != nil || count == 0 {
+		return false, err
+	}
+	_, err = tx.Exec("DELETE FROM deliveries WHERE watcher=?", id)
+	return true, err
+}
+
+func getWatchers(tx *sql.Tx) ([]Watcher, error) {
+	rows, err := tx.Query("SELECT id, address, asset, app, url FROM watchers ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var watchers []Watcher
+	for rows.Next() {
+		var w Watcher
+		var address []byte
+		err = rows.Scan(&w.ID, &address, &w.AssetID, &w.AppID, &w.URL)
+		if err != nil {
+			return nil, err
+		}
+		copy(w.Address[:], address)
+		watchers = append(watchers, w)
+	}
+	return watchers, rows.Err()
+}
+
+func addDelivery(tx *sql.Tx, watcherID uint64, url string, payload []byte, nextAttempt int64) error {
+	_, err := tx.Exec("INSERT INTO deliveries (watcher, url, payload, attempts, nextattempt) VALUES (?, ?, ?, 0, ?)", watcherID, url, payload, nextAttempt)
+	return err
+}
+
+// getNextDelivery returns the earliest queued notification of the watcher due at the given time, if any. Otherwise,
+// it returns the time the next notification of the watcher is due, or zero when it has none queued.
+func getNextDelivery(tx *sql.Tx, watcherID uint64, now int64) (d *delivery, nextAttempt int64, err error) {
+	var dl delivery
+	err = tx.QueryRow("SELECT id, watcher, url, payload, attempts FROM deliveries WHERE watcher=? AND nextattempt<=? ORDER BY id LIMIT 1", watcherID, now).Scan(&dl.id, &dl.watcherID, &dl.url, &dl.payload, &dl.attempts)
+	if err == nil {
+		return &dl, 0, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, 0, err
+	}
+	var next sql.NullInt64
+	err = tx.QueryRow("SELECT MIN(nextattempt) FROM deliveries WHERE watcher=?", watcherID).Scan(&next)
+	return nil, next.Int64, err
+}
+
+func deleteDelivery(tx *sql.Tx, id int64) error {
+	_, err := tx.Exec("DELETE FROM deliveries WHERE id=?", id)
+	return err
+}
+
+func rescheduleDelivery(tx *sql.Tx, id int64, attempts uint64, nextAttempt int64) error {
+	_, err := tx.Exec("UPDATE deliveries SET attempts=?, nextattempt=? WHERE id=?", attempts, nextAttempt, id)
+	return err
+}
+
+func countDeliveries(tx *sql.Tx) (count uint64, err error) {
+	err = tx.QueryRow("SELECT COUNT(1) FROM deliveries").Scan(&count)
+	return
+}
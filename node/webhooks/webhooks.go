@@ -0,0 +1,511 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package webhooks notifies registered callback URLs of the committed transactions involving watched accounts,
+// assets and applications.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/algorand/go-deadlock"
+
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/util/db"
+)
+
+// deliveryTimeout is the time a callback is given to respond to a notification.
+const deliveryTimeout = 10 * time.Second
+
+// initialBackoff and maxBackoff bound the delay before retrying a failed delivery. The delay doubles on every failed
+// attempt.
+var initialBackoff = time.Second
+var maxBackoff = 10 * time.Minute
+
+// ErrWatcherNotFound is returned when the requested watcher isn't registered.
+var ErrWatcherNotFound = errors.New("webhook watcher not found")
+
+// ErrInvalidWatcher is returned when registering a malformed watcher.
+var ErrInvalidWatcher = errors.New("invalid webhook watcher")
+
+// Ledger is the ledger functionality used for fetching the committed blocks.
+type Ledger interface {
+	Block(rnd basics.Round) (blk bookkeeping.Block, err error)
+	Latest() basics.Round
+}
+
+// Watcher is a registration for the notifications of the transactions involving an address, an asset or an
+// application. Exactly one of Address, AssetID and AppID is set.
+type Watcher struct {
+	ID      uint64
+	Address basics.Address
+	AssetID basics.AssetIndex
+	AppID   basics.AppIndex
+	// URL is the callback the notifications are POSTed to.
+	URL string
+}
+
+// Notification is the JSON body POSTed to the callback of a watcher, listing the transactions of a committed block
+// which match the watcher. Notifications are delivered at least once, and might be delivered out of order once
+// a delivery is retried.
+type Notification struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	WatcherID    uint64                `codec:"watcher-id"`
+	Round        basics.Round          `codec:"round"`
+	RoundTime    int64                 `codec:"round-time"`
+	Transactions []NotifiedTransaction `codec:"transactions"`
+}
+
+// NotifiedTransaction is a transaction listed in a Notification.
+type NotifiedTransaction struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	IntraRoundOffset uint64                       `codec:"intra-round-offset"`
+	Txn              transactions.SignedTxnWithAD `codec:"txn"`
+}
+
+// Service matches the transactions of every committed block against the registered watchers, and delivers the
+// resulting notifications. The queued notifications, as well as the last processed round, are persisted so that
+// no notification is lost when the node restarts. The notifications of every watcher are delivered by a goroutine
+// of its own, so that a slow or unreachable callback only delays the notifications of its watcher.
+type Service struct {
+	// mu serializes the block processing and the changes to the watchers.
+	mu       deadlock.Mutex
+	watchers []Watcher
+	// lastRound is the last round whose transactions were matched against the watchers.
+	lastRound basics.Round
+	// deliverers holds the delivery goroutines of the watchers, by watcher ID, once the service is started.
+	deliverers map[uint64]*deliverer
+
+	db          db.Accessor
+	ledger      Ledger
+	log         logging.Logger
+	client      *http.Client
+	maxAttempts uint64
+
+	ctx      context.Context
+	shutdown context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+// MakeService opens the webhooks database at the given path, and creates a Service processing the blocks of the
+// given ledger. Deliveries which fail maxAttempts times are dropped.
+func MakeService(dbPath string, inMemory bool, ledger Ledger, maxAttempts uint64, log logging.Logger) (*Service, error) {
+	accessor, err := db.MakeAccessor(dbPath, false, inMemory)
+	if err != nil {
+		return nil, err
+	}
+	s := &Service{
+		db:          accessor,
+		ledger:      ledger,
+		log:         log,
+		client:      &http.Client{Timeout: deliveryTimeout},
+		maxAttempts: maxAttempts,
+	}
+	err = accessor.Atomic(func(ctx context.Context, tx *sql.Tx) error {
+		err := initDB(tx)
+		if err != nil {
+			return err
+		}
+		s.watchers, err = getWatchers(tx)
+		if err != nil {
+			return err
+		}
+		var ok bool
+		s.lastRound, ok, err = getLastRound(tx)
+		if err != nil || ok {
+			return err
+		}
+		// a new database only notifies of the blocks committed from now on.
+		s.lastRound = ledger.Latest()
+		return setLastRound(tx, s.lastRound)
+	})
+	if err != nil {
+		accessor.Close()
+		return nil, err
+	}
+	s.ctx, s.shutdown = context.WithCancel(context.Background())
+	return s, nil
+}
+
+// Start processes the blocks committed while the service wasn't running, and starts delivering the queued
+// notifications.
+func (s *Service) Start() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.ctx.Err() != nil {
+			return
+		}
+		s.processThrough(s.ledger.Latest(), nil)
+		s.deliverers = make(map[uint64]*deliverer)
+		for _, w := range s.watchers {
+			s.startDeliverer(w.ID)
+		}
+	}()
+}
+
+// Shutdown stops delivering the notifications, and closes the database.
+func (s *Service) Shutdown() {
+	// no delivery goroutine is started once the service is shut down.
+	s.mu.Lock()
+	s.shutdown()
+	s.mu.Unlock()
+	s.wg.Wait()
+	s.db.Close()
+}
+
+// OnNewBlock implements the ledger.BlockListener interface, queueing the notifications of the block transactions.
+func (s *Service) OnNewBlock(block bookkeeping.Block, delta ledgercore.StateDelta) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ctx.Err() != nil {
+		return
+	}
+	s.processThrough(block.Round(), &block)
+}
+
+// AddWatcher registers the given watcher, and returns it along with its assigned ID. The watcher is notified of
+// the blocks committed from now on.
+func (s *Service) AddWatcher(w Watcher) (Watcher, error) {
+	err := validateWatcher(w)
+	if err != nil {
+		return Watcher{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err = s.db.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
+		w.ID, err = addWatcher(tx, w)
+		return
+	})
+	if err != nil {
+		return Watcher{}, err
+	}
+	s.watchers = append(s.watchers, w)
+	if s.deliverers != nil && s.ctx.Err() == nil {
+		s.startDeliverer(w.ID)
+	}
+	return w, nil
+}
+
+// RemoveWatcher unregisters the watcher with the given ID, dropping its queued notifications.
+func (s *Service) RemoveWatcher(id uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var found bool
+	err := s.db.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
+		found, err = removeWatcher(tx, id)
+		return
+	})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrWatcherNotFound
+	}
+	if d, ok := s.deliverers[id]; ok {
+		// this aborts a delivery in progress, the notification being removed from the queue already.
+		d.cancel()
+		delete(s.deliverers, id)
+	}
+	for i, w := range s.watchers {
+		if w.ID == id {
+			s.watchers = append(s.watchers[:i], s.watchers[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// Watchers returns the registered watchers, ordered by their IDs.
+func (s *Service) Watchers() []Watcher {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Watcher(nil), s.watchers...)
+}
+
+func validateWatcher(w Watcher) error {
+	set := 0
+	if !w.Address.IsZero() {
+		set++
+	}
+	if w.AssetID != 0 {
+		set++
+	}
+	if w.AppID != 0 {
+		set++
+	}
+	if set != 1 {
+		return fmt.Errorf("%w: exactly one of an address, an asset or an application must be watched", ErrInvalidWatcher)
+	}
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: the callback must be an absolute http or https URL", ErrInvalidWatcher)
+	}
+	return nil
+}
+
+// processThrough processes the blocks following the last processed round, through the given round. The block of
+// that round may be provided by the caller; the others are fetched from the ledger. s.mu must be held.
+func (s *Service) processThrough(rnd basics.Round, blk *bookkeeping.Block) {
+	for s.lastRound < rnd {
+		next := s.lastRound + 1
+		var b bookkeeping.Block
+		if blk != nil && next == blk.Round() {
+			b = *blk
+		} else {
+			var err error
+			b, err = s.ledger.Block(next)
+			if err != nil {
+				if blk == nil {
+					s.log.Warnf("webhooks: unable to fetch block %d: %v", next, err)
+					return
+				}
+				// the ledger no longer has the missed blocks, as happens after a catchpoint catchup.
+				s.log.Warnf("webhooks: skipping rounds %d to %d, as their blocks are unavailable: %v", next, blk.Round()-1, err)
+				b = *blk
+			}
+		}
+		err := s.processBlock(b)
+		if err != nil {
+			// the block would be processed again along with the next one.
+			s.log.Warnf("webhooks: unable to process block %d: %v", b.Round(), err)
+			return
+		}
+		s.lastRound = b.Round()
+	}
+}
+
+// processBlock queues the notifications of the given block, and records it as the last processed one.
+func (s *Service) processBlock(b bookkeeping.Block) error {
+	var notifications []Notification
+	if len(s.watchers) > 0 {
+		payset, err := b.DecodePaysetFlat()
+		if err != nil {
+			return err
+		}
+		for _, w := range s.watchers {
+			n := Notification{WatcherID: w.ID, Round: b.Round(), RoundTime: b.TimeStamp}
+			for intra := range payset {
+				if matches(w, b, payset, intra) {
+					n.Transactions = append(n.Transactions, NotifiedTransaction{IntraRoundOffset: uint64(intra), Txn: payset[intra]})
+				}
+			}
+			if len(n.Transactions) > 0 {
+				notifications = append(notifications, n)
+			}
+		}
+	}
+
+	now := time.Now().UnixNano()
+	err := s.db.Atomic(func(ctx context.Context, tx *sql.Tx) error {
+		for i := range notifications {
+			err := addDelivery(tx, notifications[i].WatcherID, s.watcherURL(notifications[i].WatcherID), protocol.EncodeJSON(&notifications[i]), now)
+			if err != nil {
+				return err
+			}
+		}
+		return setLastRound(tx, b.Round())
+	})
+	if err == nil {
+		for i := range notifications {
+			if d, ok := s.deliverers[notifications[i].WatcherID]; ok {
+				d.signal()
+			}
+		}
+	}
+	return err
+}
+
+func (s *Service) watcherURL(id uint64) string {
+	for _, w := range s.watchers {
+		if w.ID == id {
+			return w.URL
+		}
+	}
+	return ""
+}
+
+// matches tests whether the transaction at the given offset of the block involves the watched entity.
+func matches(w Watcher, b bookkeeping.Block, payset []transactions.SignedTxnWithAD, intra int) bool {
+	txn := payset[intra].Txn
+	switch {
+	case !w.Address.IsZero():
+		for _, addr := range txn.InvolvedAddrs() {
+			if addr == w.Address {
+				return true
+			}
+		}
+		return false
+	case w.AssetID != 0:
+		aidx, _ := transactions.PaysetCreatables(payset, b.TxnCounter, intra)
+		return aidx == w.AssetID
+	case w.AppID != 0:
+		_, appIdx := transactions.PaysetCreatables(payset, b.TxnCounter, intra)
+		return appIdx == w.AppID
+	}
+	return false
+}
+
+// deliverer is the delivery goroutine of a watcher.
+type deliverer struct {
+	watcherID uint64
+	// wake is signaled once a notification of the watcher is queued.
+	wake chan struct{}
+	// ctx is canceled once the watcher is removed or the service is shut down.
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func (d *deliverer) signal() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// startDeliverer starts delivering the notifications of the given watcher. s.mu must be held.
+func (s *Service) startDeliverer(watcherID uint64) {
+	d := &deliverer{watcherID: watcherID, wake: make(chan struct{}, 1)}
+	d.ctx, d.cancel = context.WithCancel(s.ctx)
+	s.deliverers[watcherID] = d
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer d.cancel()
+		s.deliverAll(d)
+	}()
+}
+
+// deliverAll delivers the queued notifications of the watcher, in their queueing order, until the watcher is removed
+// or the service is shut down.
+func (s *Service) deliverAll(d *deliverer) {
+	for d.ctx.Err() == nil {
+		var dl *delivery
+		var nextAttempt int64
+		err := s.db.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
+			dl, nextAttempt, err = getNextDelivery(tx, d.watcherID, time.Now().UnixNano())
+			return
+		})
+		if err != nil {
+			s.log.Warnf("webhooks: unable to read the delivery queue of watcher %d: %v", d.watcherID, err)
+			nextAttempt = time.Now().Add(initialBackoff).UnixNano()
+		}
+		if dl != nil {
+			s.deliver(d.ctx, dl)
+			continue
+		}
+
+		var timer <-chan time.Time
+		if nextAttempt != 0 {
+			timer = time.After(time.Until(time.Unix(0, nextAttempt)))
+		}
+		select {
+		case <-d.wake:
+		case <-timer:
+		case <-d.ctx.Done():
+		}
+	}
+}
+
+// deliver POSTs the notification to its callback, and either removes it from the queue or schedules its retry.
+func (s *Service) deliver(ctx context.Context, d *delivery) {
+	err := s.post(ctx, d)
+	if err == nil {
+		err = s.db.Atomic(func(ctx context.Context, tx *sql.Tx) error {
+			return deleteDelivery(tx, d.id)
+		})
+		if err != nil {
+			s.log.Warnf("webhooks: unable to dequeue delivery %d: %v", d.id, err)
+		}
+		return
+	}
+	if ctx.Err() != nil {
+		// the delivery was aborted by the removal of the watcher, or by the shutdown; in the latter case, it would be
+		// attempted again once the node restarts.
+		return
+	}
+
+	attempts := d.attempts + 1
+	if attempts >= s.maxAttempts {
+		s.log.Warnf("webhooks: dropping the notification of watcher %d after %d failed attempts to deliver it to %s: %v", d.watcherID, attempts, d.url, err)
+		err = s.db.Atomic(func(ctx context.Context, tx *sql.Tx) error {
+			return deleteDelivery(tx, d.id)
+		})
+	} else {
+		s.log.Infof("webhooks: failed delivering the notification of watcher %d to %s (attempt %d): %v", d.watcherID, d.url, attempts, err)
+		err = s.db.Atomic(func(ctx context.Context, tx *sql.Tx) error {
+			return rescheduleDelivery(tx, d.id, attempts, time.Now().Add(backoff(attempts)).UnixNano())
+		})
+	}
+	if err != nil {
+		s.log.Warnf("webhooks: unable to update delivery %d: %v", d.id, err)
+	}
+}
+
+func (s *Service) post(ctx context.Context, d *delivery) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, d.url, bytes.NewReader(d.payload))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := s.client.Do(request)
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("callback responded with status %d", response.StatusCode)
+	}
+	return nil
+}
+
+// backoff returns the delay before the next attempt of a delivery which failed the given number of times.
+func backoff(attempts uint64) time.Duration {
+	delay := initialBackoff
+	for i := uint64(1); i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+	return delay
+}
+
+// QueuedDeliveries returns the number of notifications yet to be delivered.
+func (s *Service) QueuedDeliveries() (count uint64, err error) {
+	err = s.db.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
+		count, err = countDeliveries(tx)
+		return
+	})
+	return
+}
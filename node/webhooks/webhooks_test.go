@@ -0,0 +1,344 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package webhooks
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+type testLedger struct {
+	mu     sync.Mutex
+	blocks map[basics.Round]bookkeeping.Block
+	latest basics.Round
+}
+
+func (l *testLedger) Block(rnd basics.Round) (bookkeeping.Block, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b, ok := l.blocks[rnd]
+	if !ok {
+		return bookkeeping.Block{}, fmt.Errorf("no block %d", rnd)
+	}
+	return b, nil
+}
+
+func (l *testLedger) Latest() basics.Round {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.latest
+}
+
+// addBlock commits a block holding the given transactions, which are preceded by txnCounter transactions.
+func (l *testLedger) addBlock(t *testing.T, txnCounter uint64, txns ...transactions.Transaction) bookkeeping.Block {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b := bookkeeping.Block{
+		BlockHeader: bookkeeping.BlockHeader{
+			Round:      l.latest + 1,
+			TimeStamp:  int64(1000 + l.latest),
+			TxnCounter: txnCounter + uint64(len(txns)),
+		},
+	}
+	for _, txn := range txns {
+		txib, err := b.EncodeSignedTxn(transactions.SignedTxn{Txn: txn}, transactions.ApplyData{})
+		require.NoError(t, err)
+		b.Payset = append(b.Payset, txib)
+	}
+	l.blocks[b.Round()] = b
+	l.latest = b.Round()
+	return b
+}
+
+// testCallback records the notifications it receives, failing the first failures requests.
+type testCallback struct {
+	mu            sync.Mutex
+	failures      int
+	requests      int
+	notifications []Notification
+}
+
+func (c *testCallback) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests++
+	if c.failures > 0 {
+		c.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil || r.Header.Get("Content-Type") != "application/json" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	var n Notification
+	if protocol.DecodeJSON(body, &n) != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	c.notifications = append(c.notifications, n)
+}
+
+func (c *testCallback) received() []Notification {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Notification(nil), c.notifications...)
+}
+
+func (c *testCallback) requestCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.requests
+}
+
+func setShortBackoff(t *testing.T) {
+	savedInitial, savedMax := initialBackoff, maxBackoff
+	initialBackoff, maxBackoff = 10*time.Millisecond, 40*time.Millisecond
+	t.Cleanup(func() {
+		initialBackoff, maxBackoff = savedInitial, savedMax
+	})
+}
+
+func makeTestService(t *testing.T, dir string, l *testLedger, maxAttempts uint64) *Service {
+	s, err := MakeService(filepath.Join(dir, "webhooks.sqlite"), false, l, maxAttempts, logging.TestingLog(t))
+	require.NoError(t, err)
+	return s
+}
+
+func makeTestDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "webhooks")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+func TestWatcherValidation(t *testing.T) {
+	s := makeTestService(t, makeTestDir(t), &testLedger{blocks: map[basics.Round]bookkeeping.Block{}}, 3)
+	defer s.Shutdown()
+
+	addr := basics.Address{1}
+	for _, w := range []Watcher{
+		{URL: "http://localhost/hook"},
+		{Address: addr, AssetID: 1, URL: "http://localhost/hook"},
+		{Address: addr, URL: "localhost/hook"},
+		{Address: addr, URL: "ftp://localhost/hook"},
+	} {
+		_, err := s.AddWatcher(w)
+		require.True(t, errors.Is(err, ErrInvalidWatcher), "%v", w)
+	}
+
+	w, err := s.AddWatcher(Watcher{AppID: 7, URL: "https://localhost/hook"})
+	require.NoError(t, err)
+	require.NotZero(t, w.ID)
+	require.Equal(t, []Watcher{w}, s.Watchers())
+
+	require.Equal(t, ErrWatcherNotFound, s.RemoveWatcher(w.ID+1))
+	require.NoError(t, s.RemoveWatcher(w.ID))
+	require.Empty(t, s.Watchers())
+}
+
+func TestNotifications(t *testing.T) {
+	setShortBackoff(t)
+	callback := &testCallback{}
+	server := httptest.NewServer(callback)
+	defer server.Close()
+
+	l := &testLedger{blocks: map[basics.Round]bookkeeping.Block{}}
+	s := makeTestService(t, makeTestDir(t), l, 3)
+	defer s.Shutdown()
+	s.Start()
+
+	watched, other := basics.Address{1}, basics.Address{2}
+	addrWatcher, err := s.AddWatcher(Watcher{Address: watched, URL: server.URL})
+	require.NoError(t, err)
+	assetWatcher, err := s.AddWatcher(Watcher{AssetID: 11, URL: server.URL})
+	require.NoError(t, err)
+	appWatcher, err := s.AddWatcher(Watcher{AppID: 12, URL: server.URL})
+	require.NoError(t, err)
+
+	pay := transactions.Transaction{Type: protocol.PaymentTx, Header: transactions.Header{Sender: other}}
+	pay.Receiver = watched
+	unrelated := transactions.Transaction{Type: protocol.PaymentTx, Header: transactions.Header{Sender: other}}
+	// the asset and the application are created by the transactions at offsets 1 and 2, being the 11th and
+	// 12th transactions of the ledger.
+	assetCreate := transactions.Transaction{Type: protocol.AssetConfigTx, Header: transactions.Header{Sender: other}}
+	appCreate := transactions.Transaction{Type: protocol.ApplicationCallTx, Header: transactions.Header{Sender: other}}
+	b := l.addBlock(t, 9, pay, assetCreate, appCreate, unrelated)
+	s.OnNewBlock(b, ledgercore.StateDelta{})
+
+	xfer := transactions.Transaction{Type: protocol.AssetTransferTx, Header: transactions.Header{Sender: other}}
+	xfer.XferAsset = 11
+	xfer.AssetReceiver = watched
+	b = l.addBlock(t, 13, unrelated, xfer)
+	s.OnNewBlock(b, ledgercore.StateDelta{})
+
+	require.Eventually(t, func() bool { return len(callback.received()) == 5 }, 5*time.Second, 10*time.Millisecond)
+	count, err := s.QueuedDeliveries()
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	got := make(map[uint64][]Notification)
+	for _, n := range callback.received() {
+		got[n.WatcherID] = append(got[n.WatcherID], n)
+	}
+	require.Len(t, got[addrWatcher.ID], 2)
+	require.Equal(t, basics.Round(1), got[addrWatcher.ID][0].Round)
+	require.Equal(t, int64(1000), got[addrWatcher.ID][0].RoundTime)
+	require.Len(t, got[addrWatcher.ID][0].Transactions, 1)
+	require.Equal(t, uint64(0), got[addrWatcher.ID][0].Transactions[0].IntraRoundOffset)
+	require.Equal(t, pay, got[addrWatcher.ID][0].Transactions[0].Txn.Txn)
+	require.Equal(t, uint64(1), got[addrWatcher.ID][1].Transactions[0].IntraRoundOffset)
+
+	require.Len(t, got[assetWatcher.ID], 2)
+	require.Equal(t, assetCreate, got[assetWatcher.ID][0].Transactions[0].Txn.Txn)
+	require.Equal(t, xfer, got[assetWatcher.ID][1].Transactions[0].Txn.Txn)
+
+	require.Len(t, got[appWatcher.ID], 1)
+	require.Equal(t, uint64(2), got[appWatcher.ID][0].Transactions[0].IntraRoundOffset)
+}
+
+func TestDeliveryRetries(t *testing.T) {
+	setShortBackoff(t)
+	callback := &testCallback{failures: 2}
+	server := httptest.NewServer(callback)
+	defer server.Close()
+
+	l := &testLedger{blocks: map[basics.Round]bookkeeping.Block{}}
+	s := makeTestService(t, makeTestDir(t), l, 3)
+	defer s.Shutdown()
+	s.Start()
+
+	watched := basics.Address{1}
+	_, err := s.AddWatcher(Watcher{Address: watched, URL: server.URL})
+	require.NoError(t, err)
+	pay := transactions.Transaction{Type: protocol.PaymentTx, Header: transactions.Header{Sender: watched}}
+
+	// the third attempt succeeds.
+	s.OnNewBlock(l.addBlock(t, 0, pay), ledgercore.StateDelta{})
+	require.Eventually(t, func() bool { return len(callback.received()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, 3, callback.requestCount())
+
+	// a notification failing every attempt is dropped once maxAttempts is reached.
+	callback.mu.Lock()
+	callback.failures = 3
+	callback.mu.Unlock()
+	s.OnNewBlock(l.addBlock(t, 1, pay), ledgercore.StateDelta{})
+	require.Eventually(t, func() bool {
+		count, err := s.QueuedDeliveries()
+		return err == nil && count == 0 && callback.requestCount() == 6
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Len(t, callback.received(), 1)
+}
+
+func TestSlowCallback(t *testing.T) {
+	// the slow callback holds the requests until they're aborted; the server notices it once the body is read.
+	pending := make(chan struct{}, 10)
+	aborted := make(chan struct{}, 10)
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		pending <- struct{}{}
+		<-r.Context().Done()
+		aborted <- struct{}{}
+	}))
+	defer slow.Close()
+	callback := &testCallback{}
+	server := httptest.NewServer(callback)
+	defer server.Close()
+
+	l := &testLedger{blocks: map[basics.Round]bookkeeping.Block{}}
+	s := makeTestService(t, makeTestDir(t), l, 3)
+	defer s.Shutdown()
+	s.Start()
+
+	watched := basics.Address{1}
+	slowWatcher, err := s.AddWatcher(Watcher{Address: watched, URL: slow.URL})
+	require.NoError(t, err)
+	_, err = s.AddWatcher(Watcher{Address: watched, URL: server.URL})
+	require.NoError(t, err)
+	pay := transactions.Transaction{Type: protocol.PaymentTx, Header: transactions.Header{Sender: watched}}
+
+	// the notifications of the other watcher don't wait for the slow callback.
+	s.OnNewBlock(l.addBlock(t, 0, pay), ledgercore.StateDelta{})
+	s.OnNewBlock(l.addBlock(t, 1, pay), ledgercore.StateDelta{})
+	<-pending
+	require.Eventually(t, func() bool { return len(callback.received()) == 2 }, 5*time.Second, 10*time.Millisecond)
+
+	// removing the slow watcher aborts its delivery in progress, along with its queued notifications.
+	require.NoError(t, s.RemoveWatcher(slowWatcher.ID))
+	select {
+	case <-aborted:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the delivery in progress wasn't aborted")
+	}
+	require.Eventually(t, func() bool {
+		count, err := s.QueuedDeliveries()
+		return err == nil && count == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Empty(t, pending)
+}
+
+func TestDurableQueue(t *testing.T) {
+	setShortBackoff(t)
+	callback := &testCallback{}
+	server := httptest.NewServer(callback)
+	defer server.Close()
+
+	dir := makeTestDir(t)
+	l := &testLedger{blocks: map[basics.Round]bookkeeping.Block{}}
+	l.addBlock(t, 0)
+
+	// the notifications queued while not delivering are kept across restarts.
+	s := makeTestService(t, dir, l, 3)
+	watched := basics.Address{1}
+	w, err := s.AddWatcher(Watcher{Address: watched, URL: server.URL})
+	require.NoError(t, err)
+	pay := transactions.Transaction{Type: protocol.PaymentTx, Header: transactions.Header{Sender: watched}}
+	s.OnNewBlock(l.addBlock(t, 0, pay), ledgercore.StateDelta{})
+	s.Shutdown()
+
+	// the blocks committed while the node was down are processed once restarted.
+	l.addBlock(t, 1, pay)
+	l.addBlock(t, 2)
+
+	s = makeTestService(t, dir, l, 3)
+	defer s.Shutdown()
+	require.Equal(t, []Watcher{w}, s.Watchers())
+	s.Start()
+
+	require.Eventually(t, func() bool { return len(callback.received()) == 2 }, 5*time.Second, 10*time.Millisecond)
+	received := callback.received()
+	require.Equal(t, basics.Round(2), received[0].Round)
+	require.Equal(t, basics.Round(3), received[1].Round)
+}
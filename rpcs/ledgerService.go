@@ -20,6 +20,7 @@ import (
 	"compress/gzip"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"strconv"
 	"strings"
@@ -32,6 +33,7 @@ import (
 	"github.com/algorand/go-algorand/config"
 	"github.com/algorand/go-algorand/data"
 	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/ledger"
 	"github.com/algorand/go-algorand/ledger/ledgercore"
 	"github.com/algorand/go-algorand/logging"
 	"github.com/algorand/go-algorand/network"
@@ -206,6 +208,13 @@ func (ls *LedgerService) ServeHTTP(response http.ResponseWriter, request *http.R
 	}
 
 	response.Header().Set("Content-Type", LedgerResponseContentType)
+	response.Header().Set("Accept-Ranges", "bytes")
+	if rangeHeader := request.Header.Get("Range"); rangeHeader != "" {
+		if ls.serveCatchpointRange(response, basics.Round(round), cs, rangeHeader) {
+			return
+		}
+		// the range isn't a single byte range; ignore it, and serve the entire file.
+	}
 	requestedCompressedResponse := strings.Contains(request.Header.Get("Accept-Encoding"), "gzip")
 	if requestedCompressedResponse {
 		response.Header().Set("Content-Encoding", "gzip")
@@ -228,3 +237,97 @@ func (ls *LedgerService) ServeHTTP(response http.ResponseWriter, request *http.R
 		logging.Base().Infof("LedgerService.ServeHTTP : unable to write decompressed catchpoint file for round %d, written bytes %d : %v", round, written, err)
 	}
 }
+
+// serveCatchpointRange serves a single byte range of the uncompressed catchpoint file, allowing an interrupted download to be resumed.
+// Since the catchpoint files are stored compressed, the range is located by decompressing the file, and its size is the one recorded
+// when the file was written. It returns false if the range header doesn't specify a single byte range, or if the uncompressed size of
+// the file isn't known, in which case the range header is to be ignored.
+func (ls *LedgerService) serveCatchpointRange(response http.ResponseWriter, round basics.Round, cs ledger.ReadCloseSizer, rangeHeader string) bool {
+	first, last, ok := parseByteRange(rangeHeader)
+	if !ok {
+		return false
+	}
+	size, err := cs.UncompressedSize()
+	if err != nil {
+		// catchpoint files written before the uncompressed size was recorded are served entirely.
+		return false
+	}
+
+	if first < 0 {
+		// a suffix range, of the last -first bytes.
+		first = size + first
+		if first < 0 {
+			first = 0
+		}
+		last = size - 1
+	} else if last < 0 || last >= size {
+		last = size - 1
+	}
+	if first >= size || first > last {
+		response.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", size))
+		response.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+		return true
+	}
+
+	decompressedGzip, err := gzip.NewReader(cs)
+	if err != nil {
+		logging.Base().Warnf("LedgerService.ServeHTTP : failed to decompress catchpoint %d %v", round, err)
+		response.WriteHeader(http.StatusInternalServerError)
+		response.Write([]byte(fmt.Sprintf("catchpoint file for round %d could not be decompressed due to internal error : %v", round, err)))
+		return true
+	}
+	defer decompressedGzip.Close()
+	_, err = io.CopyN(ioutil.Discard, decompressedGzip, first)
+	if err != nil {
+		logging.Base().Warnf("LedgerService.ServeHTTP : failed to decompress catchpoint %d %v", round, err)
+		response.WriteHeader(http.StatusInternalServerError)
+		response.Write([]byte(fmt.Sprintf("catchpoint file for round %d could not be decompressed due to internal error : %v", round, err)))
+		return true
+	}
+	response.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", first, last, size))
+	response.Header().Set("Content-Length", strconv.FormatInt(last-first+1, 10))
+	response.WriteHeader(http.StatusPartialContent)
+	written, err := io.CopyN(response, decompressedGzip, last-first+1)
+	if err != nil {
+		logging.Base().Infof("LedgerService.ServeHTTP : unable to write catchpoint file range for round %d, written bytes %d : %v", round, written, err)
+	}
+	return true
+}
+
+// parseByteRange parses an http range header consisting of a single byte range. An open ended range is returned with a negative last,
+// and a suffix range is returned with a negative first, denoting the suffix length. ok is false if the header isn't a valid single byte range.
+func parseByteRange(rangeHeader string) (first, last int64, ok bool) {
+	const unitPrefix = "bytes="
+	if !strings.HasPrefix(rangeHeader, unitPrefix) {
+		return 0, 0, false
+	}
+	byteRange := strings.TrimSpace(rangeHeader[len(unitPrefix):])
+	if strings.Contains(byteRange, ",") {
+		// multiple ranges aren't supported.
+		return 0, 0, false
+	}
+	separator := strings.Index(byteRange, "-")
+	if separator < 0 {
+		return 0, 0, false
+	}
+	firstStr, lastStr := strings.TrimSpace(byteRange[:separator]), strings.TrimSpace(byteRange[separator+1:])
+	if firstStr == "" {
+		suffixLength, err := strconv.ParseInt(lastStr, 10, 64)
+		if err != nil || suffixLength <= 0 {
+			return 0, 0, false
+		}
+		return -suffixLength, -1, true
+	}
+	first, err := strconv.ParseInt(firstStr, 10, 64)
+	if err != nil || first < 0 {
+		return 0, 0, false
+	}
+	if lastStr == "" {
+		return first, -1, true
+	}
+	last, err = strconv.ParseInt(lastStr, 10, 64)
+	if err != nil || last < first {
+		return 0, 0, false
+	}
+	return first, last, true
+}
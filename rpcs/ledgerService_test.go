@@ -0,0 +1,123 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package rpcs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/crypto"
+)
+
+func TestParseByteRange(t *testing.T) {
+	testCases := []struct {
+		header string
+		first  int64
+		last   int64
+		ok     bool
+	}{
+		{"bytes=0-", 0, -1, true},
+		{"bytes=1024-", 1024, -1, true},
+		{"bytes=10-20", 10, 20, true},
+		{"bytes= 10 - 20 ", 10, 20, true},
+		{"bytes=-500", -500, -1, true},
+		{"bytes=20-10", 0, 0, false},
+		{"bytes=-0", 0, 0, false},
+		{"bytes=0-10,20-30", 0, 0, false},
+		{"bytes=x-", 0, 0, false},
+		{"bytes=10", 0, 0, false},
+		{"items=0-10", 0, 0, false},
+		{"", 0, 0, false},
+	}
+	for _, testCase := range testCases {
+		first, last, ok := parseByteRange(testCase.header)
+		require.Equal(t, testCase.ok, ok, testCase.header)
+		if ok {
+			require.Equal(t, testCase.first, first, testCase.header)
+			require.Equal(t, testCase.last, last, testCase.header)
+		}
+	}
+}
+
+// testCatchpointStream is a catchpoint stream of an in-memory compressed catchpoint file.
+type testCatchpointStream struct {
+	*bytes.Reader
+	uncompressedSize int64
+}
+
+func (s *testCatchpointStream) Close() error {
+	return nil
+}
+
+func (s *testCatchpointStream) Size() (int64, error) {
+	return s.Reader.Size(), nil
+}
+
+func (s *testCatchpointStream) UncompressedSize() (int64, error) {
+	if s.uncompressedSize <= 0 {
+		return 0, fmt.Errorf("unknown uncompressed stream size")
+	}
+	return s.uncompressedSize, nil
+}
+
+func TestServeCatchpointRange(t *testing.T) {
+	content := make([]byte, 100000)
+	crypto.RandBytes(content[:50000])
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	_, err := gzipWriter.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, gzipWriter.Close())
+
+	ls := &LedgerService{}
+	serve := func(rangeHeader string, uncompressedSize int64) (*httptest.ResponseRecorder, bool) {
+		response := httptest.NewRecorder()
+		cs := &testCatchpointStream{Reader: bytes.NewReader(compressed.Bytes()), uncompressedSize: uncompressedSize}
+		served := ls.serveCatchpointRange(response, 100, cs, rangeHeader)
+		return response, served
+	}
+
+	response, served := serve("bytes=60000-", int64(len(content)))
+	require.True(t, served)
+	require.Equal(t, http.StatusPartialContent, response.Code)
+	require.Equal(t, fmt.Sprintf("bytes 60000-%d/%d", len(content)-1, len(content)), response.Header().Get("Content-Range"))
+	body, err := ioutil.ReadAll(response.Body)
+	require.NoError(t, err)
+	require.Equal(t, content[60000:], body)
+
+	response, served = serve("bytes=-100", int64(len(content)))
+	require.True(t, served)
+	require.Equal(t, http.StatusPartialContent, response.Code)
+	require.Equal(t, content[len(content)-100:], response.Body.Bytes())
+
+	response, served = serve(fmt.Sprintf("bytes=%d-", len(content)), int64(len(content)))
+	require.True(t, served)
+	require.Equal(t, http.StatusRequestedRangeNotSatisfiable, response.Code)
+
+	// without a recorded uncompressed size, the range is ignored rather than found by decompressing the file.
+	_, served = serve("bytes=60000-", 0)
+	require.False(t, served)
+	_, served = serve("bytes=1-2,4-5", int64(len(content)))
+	require.False(t, served)
+}
@@ -19,6 +19,8 @@ package network
 import (
 	"context"
 	"fmt"
+	"net"
+	"strconv"
 
 	"github.com/algorand/go-algorand/logging"
 )
@@ -71,7 +73,9 @@ func ReadFromSRV(service string, protocol string, name string, fallbackDNSResolv
 		if srv.Target[len(srv.Target)-1:] == "." {
 			srv.Target = srv.Target[:len(srv.Target)-1]
 		}
-		addrs = append(addrs, fmt.Sprintf("%s:%d", srv.Target, srv.Port))
+		// a relay listening on several addresses, e.g. IPv4 and IPv6 ones, may be listed under several targets, which
+		// the clients recognize once connected; joining the host and port keeps any IPv6 literal valid.
+		addrs = append(addrs, net.JoinHostPort(srv.Target, strconv.Itoa(int(srv.Port))))
 	}
 	return
 }
@@ -24,6 +24,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"os"
 	"reflect"
 	"runtime"
 	"strings"
@@ -83,6 +84,16 @@ func MakeAccessor(dbfilename string, readOnly bool, inMemory bool) (Accessor, er
 	return makeAccessorImpl(dbfilename, readOnly, inMemory, []string{"_journal_mode=wal"})
 }
 
+// MakeReadOnlyAccessor opens an existing database for reading only. Unlike an Accessor made by MakeAccessor with
+// readOnly set, which only runs read-only transactions, it fails if the database doesn't exist rather than creating
+// it, and its connections refuse to make any change to the database.
+func MakeReadOnlyAccessor(dbfilename string) (Accessor, error) {
+	if _, err := os.Stat(dbfilename); err != nil {
+		return Accessor{}, err
+	}
+	return makeAccessorImpl(dbfilename, true, false, []string{"_journal_mode=wal", "_query_only=true"})
+}
+
 // MakeErasableAccessor creates a new Accessor with the secure_delete pragma set;
 // see https://www.sqlite.org/pragma.html#pragma_secure_delete
 // It is not read-only and not in-memory (otherwise, erasability doesn't matter)
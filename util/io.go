@@ -52,6 +52,31 @@ func CopyFile(src, dst string) (int64, error) {
 	return nBytes, err
 }
 
+// WriteFileAtomically writes the data to a temporary file next to the given file, syncs it, and moves it over the
+// given file, so that a crash leaves either the previous or the new content of the file behind, but never a truncated
+// one.
+func WriteFileAtomically(filename string, data []byte, perm os.FileMode) error {
+	tmpFile := filename + ".tmp"
+	file, err := os.OpenFile(tmpFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpFile, filename)
+	}
+	if err != nil {
+		os.Remove(tmpFile)
+	}
+	return err
+}
+
 // FileExists checks to see if the specified file (or directory) exists
 func FileExists(filePath string) bool {
 	_, err := os.Stat(filePath)
@@ -89,4 +89,13 @@ var (
 	TransactionMessagesDroppedFromBacklog = MetricName{Name: "algod_transaction_messages_dropped_backlog", Description: "Number of transaction messages dropped from backlog"}
 	// TransactionMessagesDroppedFromPool "Number of transaction messages dropped from pool"
 	TransactionMessagesDroppedFromPool = MetricName{Name: "algod_transaction_messages_dropped_pool", Description: "Number of transaction messages dropped from pool"}
+	// TransactionPoolEvicted "Number of transactions evicted from the full transaction pool by higher paying transactions"
+	TransactionPoolEvicted = MetricName{Name: "algod_transaction_pool_evicted_total", Description: "Number of transactions evicted from the full transaction pool by higher paying transactions"}
+	// TransactionPoolReplaced "Number of pending transactions replaced by higher paying transactions sharing their lease"
+	TransactionPoolReplaced = MetricName{Name: "algod_transaction_pool_replaced_total", Description: "Number of pending transactions replaced by higher paying transactions sharing their lease"}
+
+	// RestAPITokenRequests "Number of REST API requests authorized by a scoped API token"
+	RestAPITokenRequests = MetricName{Name: "algod_rest_api_token_requests_total", Description: "Number of REST API requests authorized by a scoped API token"}
+	// RestAPITokenRejected "Number of REST API requests made with a scoped API token which were rejected"
+	RestAPITokenRejected = MetricName{Name: "algod_rest_api_token_rejected_total", Description: "Number of REST API requests made with a scoped API token which were rejected"}
 )
@@ -33,6 +33,10 @@ const (
 	AlgodTokenFilename      = "algod.token"
 	AlgodAdminTokenFilename = "algod.admin.token"
 	KmdTokenFilename        = "kmd.token"
+
+	// AlgodScopedTokensFilename is the optional file defining additional algod API tokens, each restricted to a set of
+	// endpoint groups and to its own request rate and concurrency limits.
+	AlgodScopedTokensFilename = "algod.tokens.json"
 )
 
 func tokenFilepath(dataDir, tokenFilename string) string {